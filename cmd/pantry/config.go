@@ -0,0 +1,346 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/api"
+	"github.com/mwhite7112/woodpantry-pantry/internal/chaos"
+	"github.com/mwhite7112/woodpantry-pantry/internal/events"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+	"github.com/mwhite7112/woodpantry-pantry/internal/slo"
+	"github.com/mwhite7112/woodpantry-pantry/internal/units"
+)
+
+// config is the environment the service starts with. Variables read where
+// they are used, such as HTTP_CLIENT_* and JWT_*, are not in it.
+type config struct {
+	port             string
+	dbURL            string
+	dictURL          string
+	llmProvider      string
+	openaiKey        string
+	anthropicKey     string
+	extractModel     string
+	fallbackModel    string
+	openaiBaseURL    string
+	anthropicBaseURL string
+	localBaseURL     string
+	localKey         string
+	azureEndpoint    string
+	azureKey         string
+	azureAPIVersion  string
+	rabbitMQURL      string
+	eventBroker      string
+	kafka            events.KafkaConfig
+
+	loc                 *time.Location
+	processedMessageTTL time.Duration
+	taxonomyTTL         time.Duration
+	publishDebounce     time.Duration
+	eventRetryBuffer    int
+	reconcileInterval   time.Duration
+	stagedJobTTL        time.Duration
+	llmMonthlyTokens    int64
+	llmRetries          int
+	llmRetryBackoff     time.Duration
+	maxStagedItems      int
+	injector            *chaos.Injector
+
+	ingestWorkers       int
+	ingestQueueSize     int
+	llmFailureThreshold int
+	ingestJobAttempts   int
+	ingestRetryDelay    time.Duration
+	ingestPollInterval  time.Duration
+
+	expiryLeadDays int
+	shelfLives     map[string]int
+	sloTracker     *slo.Tracker
+	server         *http.Server
+	maxBodyBytes   int
+	displayUnits   units.System
+}
+
+// loadConfig reads and validates the environment, failing on the first
+// missing or malformed variable.
+func loadConfig() (*config, error) {
+	cfg := &config{
+		port:             envOrDefault("PORT", "8080"),
+		dbURL:            os.Getenv("DB_URL"),
+		dictURL:          os.Getenv("DICTIONARY_URL"),
+		openaiKey:        os.Getenv("OPENAI_API_KEY"),
+		anthropicKey:     os.Getenv("ANTHROPIC_API_KEY"),
+		openaiBaseURL:    envOrDefault("OPENAI_BASE_URL", service.DefaultOpenAIBaseURL),
+		anthropicBaseURL: envOrDefault("ANTHROPIC_BASE_URL", service.DefaultAnthropicBaseURL),
+		localBaseURL:     envOrDefault("LOCAL_LLM_BASE_URL", service.DefaultLocalBaseURL),
+		localKey:         os.Getenv("LOCAL_LLM_API_KEY"),
+		azureEndpoint:    os.Getenv("AZURE_OPENAI_ENDPOINT"),
+		azureKey:         os.Getenv("AZURE_OPENAI_API_KEY"),
+		azureAPIVersion:  envOrDefault("AZURE_OPENAI_API_VERSION", service.DefaultAzureAPIVersion),
+		rabbitMQURL:      os.Getenv("RABBITMQ_URL"),
+	}
+	if cfg.dbURL == "" {
+		return nil, errors.New("DB_URL is required")
+	}
+	if cfg.dictURL == "" {
+		return nil, errors.New("DICTIONARY_URL is required")
+	}
+
+	// Without an OpenAI key, an Anthropic key or Azure endpoint is enough to
+	// pick the provider.
+	defaultProvider := llmProviderOpenAI
+	switch {
+	case cfg.openaiKey != "":
+	case cfg.anthropicKey != "":
+		defaultProvider = llmProviderAnthropic
+	case cfg.azureEndpoint != "":
+		defaultProvider = llmProviderAzure
+	}
+	cfg.llmProvider = envOrDefault("LLM_PROVIDER", defaultProvider)
+	switch cfg.llmProvider {
+	case llmProviderOpenAI:
+		if cfg.openaiKey == "" {
+			return nil, errors.New("OPENAI_API_KEY is required")
+		}
+		cfg.extractModel = envOrDefault("EXTRACT_MODEL", "gpt-5-mini")
+	case llmProviderAnthropic:
+		if cfg.anthropicKey == "" {
+			return nil, errors.New("ANTHROPIC_API_KEY is required with LLM_PROVIDER=anthropic")
+		}
+		cfg.extractModel = envOrDefault("EXTRACT_MODEL", "claude-haiku-4-5")
+	case llmProviderLocal:
+		// Local servers have whatever models were pulled, so there is no
+		// default to fall back on.
+		cfg.extractModel = os.Getenv("EXTRACT_MODEL")
+		if cfg.extractModel == "" {
+			return nil, errors.New("EXTRACT_MODEL is required with LLM_PROVIDER=local")
+		}
+	case llmProviderAzure:
+		// Azure names models by deployment.
+		cfg.extractModel = os.Getenv("AZURE_OPENAI_DEPLOYMENT")
+		switch {
+		case cfg.azureEndpoint == "":
+			return nil, errors.New("AZURE_OPENAI_ENDPOINT is required with LLM_PROVIDER=azure")
+		case cfg.azureKey == "":
+			return nil, errors.New("AZURE_OPENAI_API_KEY is required with LLM_PROVIDER=azure")
+		case cfg.extractModel == "":
+			return nil, errors.New("AZURE_OPENAI_DEPLOYMENT is required with LLM_PROVIDER=azure")
+		}
+	default:
+		return nil, fmt.Errorf("LLM_PROVIDER must be openai, anthropic, azure or local, got %q", cfg.llmProvider)
+	}
+
+	cfg.eventBroker = envOrDefault("EVENT_BROKER", events.BrokerRabbitMQ)
+	switch cfg.eventBroker {
+	case events.BrokerRabbitMQ:
+	case events.BrokerKafka:
+		for broker := range strings.SplitSeq(os.Getenv("KAFKA_BROKERS"), ",") {
+			if broker = strings.TrimSpace(broker); broker != "" {
+				cfg.kafka.Brokers = append(cfg.kafka.Brokers, broker)
+			}
+		}
+		if len(cfg.kafka.Brokers) == 0 {
+			return nil, errors.New("KAFKA_BROKERS is required with EVENT_BROKER=kafka")
+		}
+		cfg.kafka.Topic = envOrDefault("KAFKA_TOPIC", events.DefaultKafkaTopic)
+		cfg.kafka.HouseholdID = os.Getenv("KAFKA_HOUSEHOLD_ID")
+	default:
+		return nil, fmt.Errorf("EVENT_BROKER must be rabbitmq or kafka, got %q", cfg.eventBroker)
+	}
+
+	cfg.loc = time.UTC
+	if v := os.Getenv("PANTRY_TIMEZONE"); v != "" {
+		l, err := time.LoadLocation(v)
+		if err != nil {
+			return nil, fmt.Errorf("PANTRY_TIMEZONE: %w", err)
+		}
+		cfg.loc = l
+	}
+
+	cfg.processedMessageTTL = service.DefaultProcessedMessageTTL
+	if v := os.Getenv("PROCESSED_MESSAGE_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("PROCESSED_MESSAGE_TTL must be a positive duration, got %q", v)
+		}
+		cfg.processedMessageTTL = ttl
+	}
+
+	cfg.taxonomyTTL = service.DefaultTaxonomyTTL
+	if v := os.Getenv("TAXONOMY_CACHE_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("TAXONOMY_CACHE_TTL must be a positive duration, got %q", v)
+		}
+		cfg.taxonomyTTL = ttl
+	}
+
+	cfg.publishDebounce = service.DefaultPublishDebounce
+	if v := os.Getenv("PANTRY_UPDATED_DEBOUNCE"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("PANTRY_UPDATED_DEBOUNCE must be a non-negative duration, got %q", v)
+		}
+		cfg.publishDebounce = d
+	}
+
+	cfg.eventRetryBuffer = events.DefaultRetryBufferSize
+	if v := os.Getenv("EVENT_RETRY_BUFFER_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("EVENT_RETRY_BUFFER_SIZE must be a non-negative integer, got %q", v)
+		}
+		cfg.eventRetryBuffer = n
+	}
+
+	cfg.reconcileInterval = service.DefaultReconcileInterval
+	if v := os.Getenv("RECONCILE_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("RECONCILE_INTERVAL must be a non-negative duration, got %q", v)
+		}
+		cfg.reconcileInterval = d
+	}
+
+	cfg.stagedJobTTL = service.DefaultStagedJobTTL
+	if v := os.Getenv("STAGED_JOB_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("STAGED_JOB_TTL must be a non-negative duration, got %q", v)
+		}
+		cfg.stagedJobTTL = d
+	}
+
+	if v := os.Getenv("LLM_MONTHLY_TOKEN_BUDGET"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("LLM_MONTHLY_TOKEN_BUDGET must be a non-negative integer, got %q", v)
+		}
+		cfg.llmMonthlyTokens = n
+	}
+
+	cfg.llmRetries = service.DefaultLLMRetries
+	if v := os.Getenv("LLM_RETRIES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("LLM_RETRIES must be a non-negative integer, got %q", v)
+		}
+		cfg.llmRetries = n
+	}
+	cfg.llmRetryBackoff = service.DefaultLLMRetryBackoff
+	if v := os.Getenv("LLM_RETRY_BACKOFF"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("LLM_RETRY_BACKOFF must be a positive duration, got %q", v)
+		}
+		cfg.llmRetryBackoff = d
+	}
+	cfg.fallbackModel = os.Getenv("EXTRACT_FALLBACK_MODEL")
+
+	var err error
+	cfg.maxStagedItems, err = positiveIntEnv("MAX_STAGED_ITEMS", service.DefaultMaxStagedItems)
+	if err != nil {
+		return nil, err
+	}
+
+	if os.Getenv("CHAOS_ENABLED") == "true" {
+		faults, err := chaos.Parse(os.Getenv("CHAOS"))
+		if err != nil {
+			return nil, fmt.Errorf("CHAOS: %w", err)
+		}
+		cfg.injector = chaos.New(faults)
+		slog.Warn("fault injection enabled; never run this in production", "faults", os.Getenv("CHAOS"))
+	}
+
+	cfg.ingestWorkers, err = positiveIntEnv("INGEST_WORKERS", service.DefaultIngestWorkers)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ingestQueueSize, err = positiveIntEnv("INGEST_QUEUE_SIZE", service.DefaultIngestQueueSize)
+	if err != nil {
+		return nil, err
+	}
+	cfg.llmFailureThreshold, err = positiveIntEnv("LLM_FAILURE_THRESHOLD", service.DefaultProviderFailureThreshold)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ingestJobAttempts, err = positiveIntEnv("INGEST_JOB_ATTEMPTS", events.DefaultJobAttempts)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ingestRetryDelay = events.DefaultJobRetryDelay
+	if v := os.Getenv("INGEST_RETRY_DELAY"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("INGEST_RETRY_DELAY must be a non-negative duration, got %q", v)
+		}
+		cfg.ingestRetryDelay = d
+	}
+	cfg.ingestPollInterval = service.DefaultDBQueuePollInterval
+	if v := os.Getenv("INGEST_POLL_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("INGEST_POLL_INTERVAL must be a positive duration, got %q", v)
+		}
+		cfg.ingestPollInterval = d
+	}
+
+	cfg.expiryLeadDays = service.DefaultExpiryLeadDays
+	if v := os.Getenv("EXPIRY_DEFAULT_LEAD_DAYS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("EXPIRY_DEFAULT_LEAD_DAYS must be a non-negative integer, got %q", v)
+		}
+		cfg.expiryLeadDays = n
+	}
+
+	cfg.shelfLives, err = service.ParseShelfLives(os.Getenv("STALE_SHELF_LIFE_DAYS"))
+	if err != nil {
+		return nil, fmt.Errorf("STALE_SHELF_LIFE_DAYS: %w", err)
+	}
+
+	cfg.sloTracker, err = sloFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.server, err = serverFromEnv(fmt.Sprintf(":%s", cfg.port))
+	if err != nil {
+		return nil, err
+	}
+	cfg.maxBodyBytes, err = positiveIntEnv("HTTP_MAX_BODY_BYTES", api.DefaultMaxBodyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if v := os.Getenv("DISPLAY_UNITS"); v != "" {
+		system, ok := units.ParseSystem(v)
+		if !ok {
+			return nil, fmt.Errorf("DISPLAY_UNITS must be metric or imperial, got %q", v)
+		}
+		cfg.displayUnits = system
+	}
+	return cfg, nil
+}
+
+// brokerConfig is the event broker to publish to, or false when events are
+// not published: the RabbitMQ broker without RABBITMQ_URL.
+func (c *config) brokerConfig() (events.BrokerConfig, bool) {
+	if c.eventBroker == events.BrokerRabbitMQ && c.rabbitMQURL == "" {
+		return events.BrokerConfig{}, false
+	}
+	return events.BrokerConfig{Broker: c.eventBroker, RabbitMQURL: c.rabbitMQURL, Kafka: c.kafka}, true
+}
+
+// rabbitMQ reports whether events, and so ingest jobs, can go through
+// RabbitMQ.
+func (c *config) rabbitMQ() bool {
+	return c.eventBroker == events.BrokerRabbitMQ && c.rabbitMQURL != ""
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/events"
+	"github.com/mwhite7112/woodpantry-pantry/internal/httpx"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// doctorCheckTimeout bounds each dependency check.
+const doctorCheckTimeout = 15 * time.Second
+
+// Doctor results. A warning does not fail the report.
+const (
+	doctorPass = "PASS"
+	doctorWarn = "WARN"
+	doctorFail = "FAIL"
+	doctorSkip = "SKIP"
+)
+
+// doctorReport prints one line per check and counts the failures.
+type doctorReport struct {
+	w      io.Writer
+	failed int
+}
+
+func (r *doctorReport) add(check, status, detail string) {
+	if status == doctorFail {
+		r.failed++
+	}
+	fmt.Fprintf(r.w, "%-4s  %-10s  %s\n", status, check, detail)
+}
+
+// check runs fn with a timeout and records a pass with detail, or a failure
+// with the error.
+func (r *doctorReport) check(check, detail string, fn func(context.Context) error) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), doctorCheckTimeout)
+	defer cancel()
+	if err := fn(ctx); err != nil {
+		r.add(check, doctorFail, err.Error())
+		return false
+	}
+	r.add(check, doctorPass, detail)
+	return true
+}
+
+// runDoctor validates the configuration and checks every dependency the
+// service needs to start, without changing anything: migrations are
+// compared, not applied. It returns an error when any check fails.
+func runDoctor(w io.Writer) error {
+	report := &doctorReport{w: w}
+	defer func() {
+		fmt.Fprintf(w, "\n%d check(s) failed\n", report.failed)
+	}()
+
+	cfg, httpClients, err := doctorConfig()
+	if err != nil {
+		report.add("config", doctorFail, err.Error())
+		for _, check := range []string{"postgres", "migrations", "broker", "dictionary", "llm"} {
+			report.add(check, doctorSkip, "needs a valid configuration")
+		}
+		return errors.New("pantry doctor found problems")
+	}
+	report.add("config", doctorPass, "environment is valid")
+
+	sqlDB, err := sql.Open("postgres", cfg.dbURL)
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	defer sqlDB.Close()
+	if report.check("postgres", "connected", sqlDB.PingContext) {
+		doctorMigrations(report, sqlDB)
+	} else {
+		report.add("migrations", doctorSkip, "needs postgres")
+	}
+
+	if broker, ok := cfg.brokerConfig(); !ok {
+		report.add(cfg.eventBroker, doctorSkip, "RABBITMQ_URL not set; events are not published")
+	} else {
+		report.check(cfg.eventBroker, "connected", func(ctx context.Context) error {
+			pub, err := events.NewPublisher(broker)
+			if err != nil {
+				return err
+			}
+			defer pub.Close()
+			return pub.Ping(ctx)
+		})
+	}
+
+	dict := clients.NewDictionaryClient(cfg.dictURL, httpClients.Client(httpx.Dictionary))
+	report.check("dictionary", "reachable", dict.Ping)
+
+	var opts []service.ExtractorOption
+	if model := os.Getenv("VISION_EXTRACT_MODEL"); model != "" {
+		opts = append(opts, service.WithVisionModel(model))
+	}
+	extractor := newLLMExtractor(cfg, httpClients, cfg.extractModel, opts...)
+	report.check("llm", cfg.llmProvider+" completion succeeded", extractor.DryRun)
+
+	if report.failed > 0 {
+		return errors.New("pantry doctor found problems")
+	}
+	return nil
+}
+
+// doctorConfig loads the configuration, including the variables run reads
+// later on, such as INGEST_QUEUE and HTTP_CLIENT_*.
+func doctorConfig() (*config, *httpx.Factory, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := ingestQueueFromEnv(modeAll, cfg.rabbitMQ()); err != nil {
+		return nil, nil, err
+	}
+	httpClients, err := newHTTPClients(cfg.injector)
+	if err != nil {
+		return nil, nil, err
+	}
+	if path := os.Getenv("SHADOW_EXTRACT_PROMPT_FILE"); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return nil, nil, fmt.Errorf("SHADOW_EXTRACT_PROMPT_FILE: %w", err)
+		}
+	}
+	if path := os.Getenv("HOOKS_CONFIG"); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return nil, nil, fmt.Errorf("HOOKS_CONFIG: %w", err)
+		}
+	}
+	return cfg, httpClients, nil
+}
+
+// doctorMigrations compares the applied migrations with this build. Pending
+// migrations only warn, since startup applies them.
+func doctorMigrations(report *doctorReport, sqlDB *sql.DB) {
+	ctx, cancel := context.WithTimeout(context.Background(), doctorCheckTimeout)
+	defer cancel()
+
+	migrationsDir, err := fs.Sub(db.MigrationsFS, "migrations")
+	if err != nil {
+		report.add("migrations", doctorFail, err.Error())
+		return
+	}
+	files, err := db.EmbeddedMigrations(migrationsDir)
+	if err != nil {
+		report.add("migrations", doctorFail, err.Error())
+		return
+	}
+	latest := files[len(files)-1].Version
+
+	version, dirty, err := db.AppliedMigration(ctx, sqlDB)
+	switch {
+	case errors.Is(err, db.ErrNoMigrations):
+		report.add("migrations", doctorWarn, fmt.Sprintf("empty database; startup applies all %d migrations", len(files)))
+		return
+	case err != nil:
+		report.add("migrations", doctorFail, err.Error())
+		return
+	case dirty:
+		report.add("migrations", doctorFail, fmt.Sprintf("migration %d failed partway; fix the schema and force it", version))
+		return
+	case version > latest:
+		report.add("migrations", doctorFail,
+			fmt.Sprintf("database is at %d, newer than this build's %d", version, latest))
+		return
+	}
+	if err := db.VerifyMigrations(ctx, db.New(sqlDB), files); err != nil {
+		status := doctorFail
+		if os.Getenv("SKIP_MIGRATION_INTEGRITY_CHECK") == "true" {
+			status = doctorWarn
+		}
+		report.add("migrations", status, err.Error())
+		return
+	}
+	if version < latest {
+		report.add("migrations", doctorWarn, fmt.Sprintf("at %d; startup applies up to %d", version, latest))
+		return
+	}
+	report.add("migrations", doctorPass, fmt.Sprintf("at %d, up to date", version))
+}
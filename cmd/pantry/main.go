@@ -5,9 +5,15 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io/fs"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/golang-migrate/migrate/v4"
@@ -17,11 +23,19 @@ import (
 	_ "github.com/lib/pq"
 
 	"github.com/mwhite7112/woodpantry-pantry/internal/api"
+	"github.com/mwhite7112/woodpantry-pantry/internal/auth"
+	"github.com/mwhite7112/woodpantry-pantry/internal/chaos"
 	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
 	"github.com/mwhite7112/woodpantry-pantry/internal/db"
 	"github.com/mwhite7112/woodpantry-pantry/internal/events"
+	"github.com/mwhite7112/woodpantry-pantry/internal/hooks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/httpx"
 	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+	"github.com/mwhite7112/woodpantry-pantry/internal/notify"
 	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+	"github.com/mwhite7112/woodpantry-pantry/internal/slo"
+	"github.com/mwhite7112/woodpantry-pantry/internal/tracing"
+	"github.com/mwhite7112/woodpantry-pantry/internal/webhook"
 )
 
 func main() {
@@ -34,27 +48,30 @@ func main() {
 }
 
 func run() error {
-	port := envOrDefault("PORT", "8080")
-
-	dbURL := os.Getenv("DB_URL")
-	if dbURL == "" {
-		return errors.New("DB_URL is required")
+	mode, err := runModeFromArgs(os.Args[1:])
+	if err != nil {
+		return err
 	}
-
-	dictURL := os.Getenv("DICTIONARY_URL")
-	if dictURL == "" {
-		return errors.New("DICTIONARY_URL is required")
+	if mode == modeDoctor {
+		return runDoctor(os.Stdout)
 	}
-
-	openaiKey := os.Getenv("OPENAI_API_KEY")
-	if openaiKey == "" {
-		return errors.New("OPENAI_API_KEY is required")
+	// Background subsystems run everywhere but in API-only replicas.
+	background := mode != modeAPI
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
 	}
 
-	extractModel := envOrDefault("EXTRACT_MODEL", "gpt-5-mini")
-	rabbitMQURL := os.Getenv("RABBITMQ_URL")
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing(context.Background()) //nolint:errcheck // best effort on exit
+	if tracing.Enabled(os.Getenv) {
+		slog.Info("OpenTelemetry tracing enabled")
+	}
 
-	sqlDB, err := sql.Open("postgres", dbURL)
+	sqlDB, err := sql.Open("postgres", cfg.dbURL)
 	if err != nil {
 		return fmt.Errorf("open database: %w", err)
 	}
@@ -64,55 +81,711 @@ func run() error {
 		return fmt.Errorf("connect to database: %w", err)
 	}
 
-	if err := runMigrations(sqlDB); err != nil {
+	if err := runMigrations(sqlDB, os.Getenv("SKIP_MIGRATION_INTEGRITY_CHECK") != "true"); err != nil {
 		return fmt.Errorf("migrations: %w", err)
 	}
 
-	const httpClientTimeout = 30 * time.Second
-
-	queries := db.New(sqlDB)
-	httpClient := &http.Client{Timeout: httpClientTimeout}
+	httpClients, err := newHTTPClients(cfg.injector)
+	if err != nil {
+		return err
+	}
+	var dbtx db.DBTX = sqlDB
+	if cfg.injector != nil {
+		dbtx = cfg.injector.DBTX(sqlDB)
+	}
+	queries := db.New(dbtx)
 
-	pantryPublisher := setupPantryUpdatedPublisher(rabbitMQURL)
+	outbox := service.NewEventOutbox(queries)
+	publisherOpts := []events.PublisherOption{events.WithOutbox(outbox), events.WithRetryBuffer(cfg.eventRetryBuffer)}
+	if os.Getenv("EVENT_SCHEMA_VALIDATION") == "true" {
+		publisherOpts = append(publisherOpts, events.WithSchemaValidation())
+	}
+	pantryPublisher := setupPublisher(cfg, publisherOpts...)
 	defer pantryPublisher.Close()
+	if deliverer, ok := pantryPublisher.(service.EventDeliverer); ok && background {
+		const outboxDrainInterval = 15 * time.Second
+		go outbox.RunDrain(context.Background(), deliverer, outboxDrainInterval)
+	}
+
+	// Notification, hook and subscription webhooks share one client so every
+	// attempt lands in the same audit.
+	const webhookPruneInterval = time.Hour
+	webhookAudit := service.NewWebhookAudit(queries)
+	webhookClient := webhook.NewClient(httpClients.Client(httpx.Webhooks), webhookAudit)
+	if background {
+		go webhookAudit.RunPrune(context.Background(), webhookPruneInterval)
+	}
+
+	webhooks := service.NewWebhookService(queries, webhookClient)
+	if background {
+		go webhooks.RunDispatch(context.Background(), service.DefaultWebhookDispatchInterval)
+	}
 
-	pantry := service.NewPantryService(queries, pantryPublisher)
-	dict := clients.NewDictionaryClient(dictURL, httpClient)
-	extractor := service.NewOpenAIExtractor(openaiKey, extractModel)
+	// Only the service's pantry.updated path is coalesced; outbox redelivery,
+	// hooks and expiry events go to the publisher directly. Snapshots are
+	// read after the window closes, so they show its final state.
+	var updates service.UpdatePublisher = pantryPublisher
+	if pub, ok := pantryPublisher.(events.Publisher); ok && os.Getenv("EVENT_ITEM_SNAPSHOTS") == "true" {
+		updates = service.NewSnapshotPublisher(pantryPublisher, queries, itemChanges{pub})
+		slog.Info("pantry.updated.v2 item snapshots enabled")
+	}
+	feed := service.NewPantryFeed()
+	updates = feed.Wrap(webhooks.Wrap(updates))
+	debounced := service.NewDebouncedPublisher(updates, cfg.publishDebounce)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := debounced.Flush(ctx); err != nil {
+			slog.Warn("failed to flush pending pantry.updated on shutdown", "error", err)
+		}
+	}()
+
+	pantry := service.NewPantryService(queries, debounced)
+	pantry.SetLotTracking(os.Getenv("LOT_TRACKING") == "true")
+	pantry.SetTimeZone(cfg.loc)
+	dict := clients.NewDictionaryClient(cfg.dictURL, httpClients.Client(httpx.Dictionary))
+	activity := service.NewActivityLog(queries, dict)
+	pantry.SetActivityLog(activity)
+	if os.Getenv("VALIDATE_INGREDIENT_IDS") == "true" {
+		pantry.SetIngredientValidator(service.NewIngredientValidator(dict, service.DefaultIngredientCacheTTL))
+	}
+	var primaryOpts []service.ExtractorOption
+	if model := os.Getenv("VISION_EXTRACT_MODEL"); model != "" {
+		primaryOpts = append(primaryOpts, service.WithVisionModel(model))
+	}
+	if cfg.fallbackModel != "" {
+		primaryOpts = append(primaryOpts, service.WithFallbackModel(cfg.fallbackModel))
+	}
+	extractor := newLLMExtractor(cfg, httpClients, cfg.extractModel, primaryOpts...)
 	ingest := service.NewIngestService(queries, dict, extractor)
+	ingest.SetMaxStagedItems(cfg.maxStagedItems)
+	ingest.SetJobStatusHook(webhooks)
+	unitDefaults := service.NewUnitDefaults(queries)
+	ingest.SetUnitDefaults(unitDefaults)
+	reviewRules := service.NewReviewRules(queries)
+	ingest.SetReviewRules(reviewRules)
+	if os.Getenv("REDACT_INGEST_INPUT") == "true" {
+		var detector service.PIIDetector
+		if model := os.Getenv("REDACT_PII_MODEL"); model != "" {
+			detector = newLLMExtractor(cfg, httpClients, model)
+		}
+		ingest.SetRedactor(service.NewRedactor(detector))
+	}
+	if background {
+		ingest.StartWorkers(context.Background(), cfg.ingestWorkers, cfg.ingestQueueSize)
+	}
+	if pub, ok := pantryPublisher.(events.Publisher); ok {
+		ingest.SetConfirmSummary(confirmSummaries{pub}, dict)
+	}
+	rabbitJobQueue, _ := pantryPublisher.(service.IngestJobQueue)
+	ingestQueue, err := ingestQueueFromEnv(mode, rabbitJobQueue != nil)
+	if err != nil {
+		return err
+	}
+	switch ingestQueue {
+	case ingestQueueRabbitMQ:
+		ingest.SetJobQueue(rabbitJobQueue)
+		if background {
+			consumer := events.NewIngestJobConsumer(cfg.rabbitMQURL, ingestJobHandler(ingest), cfg.ingestWorkers,
+				events.WithMaxAttempts(cfg.ingestJobAttempts), events.WithRetryDelay(cfg.ingestRetryDelay))
+			go consumer.Run(context.Background())
+		}
+		slog.Info("ingest jobs queued through RabbitMQ", "queue", events.IngestQueue)
+	case ingestQueueDB:
+		ingest.SetJobQueue(service.NewDBJobQueue(queries))
+		if background {
+			go ingest.RunDBQueue(context.Background(), service.DBQueueOptions{
+				Workers:      cfg.ingestWorkers,
+				MaxAttempts:  cfg.ingestJobAttempts,
+				RetryDelay:   cfg.ingestRetryDelay,
+				PollInterval: cfg.ingestPollInterval,
+			})
+		}
+		slog.Info("ingest jobs queued in Postgres")
+	}
+	llmHealth := service.NewProviderHealth(extractor, cfg.llmFailureThreshold)
+	ingest.SetProviderHealth(llmHealth)
+	go llmHealth.RunProbe(context.Background(), service.DefaultProviderProbeInterval)
+	if model := os.Getenv("SHADOW_EXTRACT_MODEL"); model != "" {
+		var shadowOpts []service.ExtractorOption
+		candidate := model
+		if path := os.Getenv("SHADOW_EXTRACT_PROMPT_FILE"); path != "" {
+			prompt, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("SHADOW_EXTRACT_PROMPT_FILE: %w", err)
+			}
+			shadowOpts = append(shadowOpts, service.WithSystemPrompt(string(prompt)))
+			candidate += "+" + filepath.Base(path)
+		}
+		ingest.SetShadow(newLLMExtractor(cfg, httpClients, model, shadowOpts...), candidate)
+		slog.Info("shadow extraction enabled", "candidate", candidate)
+	}
+
+	senders := map[string]service.NotificationSender{"webhook": notify.NewWebhookSender(webhookClient)}
+	if addr := os.Getenv("SMTP_ADDR"); addr != "" {
+		email, err := notify.NewEmailSender(addr, os.Getenv("SMTP_FROM"),
+			os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"))
+		if err != nil {
+			return fmt.Errorf("SMTP_ADDR: %w", err)
+		}
+		senders["email"] = email
+	}
+	notifications := service.NewNotificationService(queries, dict, senders)
+	watchlist := service.NewWatchlistService(queries)
+	expiry := service.NewExpiryService(queries, dict, cfg.expiryLeadDays)
+	expiry.SetNotifier(notifications)
+	if background {
+		go notifications.RunDispatch(context.Background(), service.DefaultNotificationDispatchInterval)
+		go notifications.RunLowStockScan(context.Background(), watchlist, service.DefaultLowStockScanInterval)
+		expiringPublisher, _ := pantryPublisher.(service.ExpiringPublisher)
+		go expiry.RunScan(context.Background(), expiringPublisher, service.DefaultExpiryScanInterval)
+	}
+
+	reconciler := service.NewReconciler(queries, pantry)
+	if cfg.reconcileInterval > 0 && background {
+		go reconciler.RunScheduled(context.Background(), cfg.reconcileInterval, os.Getenv("RECONCILE_REPAIR") == "true")
+	}
+
+	janitor := service.NewJobJanitor(queries, cfg.stagedJobTTL)
+	janitor.SetJobStatusHook(webhooks)
+	if cfg.stagedJobTTL > 0 && background {
+		go janitor.RunExpire(context.Background(), service.DefaultJobJanitorInterval)
+	}
+
+	readOnly := service.NewReadOnlyMode(os.Getenv("READ_ONLY_MODE") == "true", os.Getenv("READ_ONLY_MESSAGE"))
+	if readOnly.State().Enabled {
+		slog.Warn("starting in read-only mode; writes are rejected until PUT /admin/read-only turns it off")
+	}
+
+	routerOpts := []api.Option{
+		api.WithMaintenance(service.NewMaintenanceService(queries, dict)),
+		api.WithReconciler(reconciler),
+		api.WithJobJanitor(janitor),
+		api.WithWatchlist(watchlist),
+		api.WithExpiry(expiry),
+		api.WithNotifications(notifications),
+		api.WithActivity(activity),
+		api.WithUnitDefaults(unitDefaults),
+		api.WithDisplayNames(service.NewDisplayOverrides(queries)),
+		api.WithReviewRules(reviewRules),
+		api.WithValuation(service.NewValuationService(queries, dict)),
+		api.WithDisplayUnits(cfg.displayUnits),
+		api.WithSLO(cfg.sloTracker),
+		api.WithReadOnly(readOnly),
+		api.WithWebhookAudit(webhookAudit),
+		api.WithWebhookSubscriptions(webhooks),
+		api.WithPantryFeed(feed),
+		api.WithTaxonomy(service.NewTaxonomy(dict, cfg.taxonomyTTL)),
+		api.WithMaxBodyBytes(int64(cfg.maxBodyBytes)),
+	}
+	routerOpts = append(routerOpts, api.WithReadiness(readinessChecks(sqlDB, pantryPublisher, cfg.eventBroker, dict)...))
+	if jwksURL := os.Getenv("JWT_JWKS_URL"); jwksURL != "" {
+		verifier := auth.NewVerifier(jwksURL, httpClients.Client(httpx.IdentityProvider),
+			auth.WithIssuer(os.Getenv("JWT_ISSUER")), auth.WithAudience(os.Getenv("JWT_AUDIENCE")))
+		routerOpts = append(routerOpts, api.WithAuth(verifier))
+		slog.Info("bearer JWT auth enabled", "jwks_url", jwksURL)
+	}
+	if os.Getenv("PROCESS_SYNC") == "true" {
+		routerOpts = append(routerOpts, api.WithSyncProcessing())
+		slog.Info("ingest jobs are processed synchronously within the request")
+	}
+	if cfg.llmMonthlyTokens > 0 {
+		budget := service.NewLLMBudget(queries, cfg.llmMonthlyTokens)
+		ingest.SetBudget(budget, service.NewHeuristicExtractor())
+		routerOpts = append(routerOpts, api.WithLLMBudget(budget))
+	}
+	if len(cfg.shelfLives) > 0 {
+		stale := service.NewStaleService(queries, dict, cfg.shelfLives)
+		if background {
+			go stale.RunScan(context.Background(), service.DefaultStaleScanInterval)
+		}
+		routerOpts = append(routerOpts, api.WithStale(stale))
+	}
 
-	handler := api.NewRouter(pantry, ingest, dict)
+	if path := os.Getenv("HOOKS_CONFIG"); path != "" {
+		runner, err := setupConfirmHooks(path, webhookClient, pantryPublisher)
+		if err != nil {
+			return err
+		}
+		ingest.SetConfirmHook(runner)
+	}
 
-	addr := fmt.Sprintf(":%s", port)
-	slog.Info("pantry service listening", "addr", addr)
-	if err := http.ListenAndServe(addr, handler); err != nil {
+	const processedMessageCleanupInterval = time.Hour
+	dedup := service.NewMessageDeduper(queries, cfg.processedMessageTTL)
+	if background {
+		go dedup.RunCleanup(context.Background(), processedMessageCleanupInterval)
+	}
+	if os.Getenv("CONSUME_RECIPE_COOKED") == "true" {
+		if !cfg.rabbitMQ() {
+			return errors.New("CONSUME_RECIPE_COOKED requires EVENT_BROKER=rabbitmq and RABBITMQ_URL")
+		}
+		if background {
+			consumer := events.NewRecipeCookedConsumer(cfg.rabbitMQURL, recipeCookedHandler(pantry, dedup))
+			go consumer.Run(context.Background())
+		}
+	}
+	if os.Getenv("CONSUME_DICTIONARY_MERGED") == "true" {
+		if !cfg.rabbitMQ() {
+			return errors.New("CONSUME_DICTIONARY_MERGED requires EVENT_BROKER=rabbitmq and RABBITMQ_URL")
+		}
+		if background {
+			consumer := events.NewDictionaryMergedConsumer(cfg.rabbitMQURL, dictionaryMergedHandler(pantry, dedup))
+			go consumer.Run(context.Background())
+		}
+	}
+
+	// Every mode returns on SIGTERM, so the deferred flush of pending
+	// pantry.updated events runs.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if mode == modeWorker {
+		slog.Info("pantry worker running", "ingest_queue", ingestQueue)
+		<-ctx.Done()
+		slog.Info("pantry worker shutting down")
+		return nil
+	}
+
+	handler := api.NewRouter(pantry, ingest, dict, routerOpts...)
+	if cfg.injector != nil {
+		handler = cfg.injector.Middleware(handler)
+	}
+
+	cfg.server.Handler = handler
+	slog.Info("pantry service listening", "addr", cfg.server.Addr)
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- cfg.server.ListenAndServe() }()
+	select {
+	case err := <-serveErr:
 		return fmt.Errorf("server error: %w", err)
+	case <-ctx.Done():
 	}
 
+	// In-flight requests finish before the deferred flush publishes what
+	// they changed.
+	slog.Info("pantry service shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	if err := cfg.server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shut down server: %w", err)
+	}
 	return nil
 }
 
+// httpShutdownTimeout bounds waiting for in-flight requests on SIGTERM. With
+// the 5 second flush after it, shutdown fits in Kubernetes' default 30
+// second grace period.
+const httpShutdownTimeout = 20 * time.Second
+
+// Run modes, chosen by the first argument. Split deployments run api
+// replicas behind the load balancer and scale worker replicas for LLM
+// processing separately; both share the database and job queue.
+const (
+	modeAll    = "all"    // HTTP API and background subsystems
+	modeAPI    = "api"    // HTTP API only
+	modeWorker = "worker" // background subsystems only
+	modeDoctor = "doctor" // check configuration and dependencies, then exit
+)
+
+// runModeFromArgs reads the run mode, defaulting to modeAll.
+func runModeFromArgs(args []string) (string, error) {
+	if len(args) == 0 {
+		return modeAll, nil
+	}
+	switch args[0] {
+	case modeAll, modeAPI, modeWorker, modeDoctor:
+		return args[0], nil
+	}
+	return "", fmt.Errorf("unknown run mode %q; want all, api, worker or doctor", args[0])
+}
+
+// Ingest job queues, chosen by INGEST_QUEUE.
+const (
+	ingestQueueMemory   = "memory"
+	ingestQueueRabbitMQ = "rabbitmq"
+	ingestQueueDB       = "db"
+)
+
+// ingestQueueFromEnv reads INGEST_QUEUE. Unset, it is RabbitMQ when
+// connected, otherwise in-memory for a single process and Postgres for split
+// modes. An in-memory queue cannot cross processes, so split modes reject it.
+func ingestQueueFromEnv(mode string, rabbitMQ bool) (string, error) {
+	queue := os.Getenv("INGEST_QUEUE")
+	switch {
+	case queue == "" && rabbitMQ:
+		return ingestQueueRabbitMQ, nil
+	case queue == "" && mode == modeAll:
+		return ingestQueueMemory, nil
+	case queue == "":
+		return ingestQueueDB, nil
+	case queue == ingestQueueRabbitMQ && !rabbitMQ:
+		return "", errors.New("INGEST_QUEUE=rabbitmq requires RABBITMQ_URL and EVENT_BROKER=rabbitmq")
+	case queue == ingestQueueMemory && mode != modeAll:
+		return "", fmt.Errorf("INGEST_QUEUE=memory cannot be used in %s mode", mode)
+	case queue == ingestQueueMemory, queue == ingestQueueRabbitMQ, queue == ingestQueueDB:
+		return queue, nil
+	}
+	return "", fmt.Errorf("INGEST_QUEUE must be memory, rabbitmq or db, got %q", queue)
+}
+
+// LLM providers, chosen by LLM_PROVIDER.
+const (
+	llmProviderOpenAI    = "openai"
+	llmProviderAnthropic = "anthropic"
+	llmProviderAzure     = "azure"
+	llmProviderLocal     = "local"
+)
+
+// llmExtractor is what the service uses of an LLM provider's extractor.
+type llmExtractor interface {
+	service.LLMExtractor
+	service.ImageExtractor
+	service.PIIDetector
+	service.ProviderPinger
+	DryRun(ctx context.Context) error
+}
+
+// newLLMExtractor returns an extractor calling model at the configured
+// provider through its shared HTTP client, retrying as LLM_RETRIES says.
+// opts are applied after the provider's base URL, client and retries.
+func newLLMExtractor(
+	cfg *config, httpClients *httpx.Factory, model string, opts ...service.ExtractorOption,
+) llmExtractor {
+	opts = append([]service.ExtractorOption{service.WithRetries(cfg.llmRetries, cfg.llmRetryBackoff)}, opts...)
+	switch cfg.llmProvider {
+	case llmProviderAnthropic:
+		opts = append([]service.ExtractorOption{
+			service.WithBaseURL(cfg.anthropicBaseURL),
+			service.WithHTTPClient(httpClients.Client(httpx.Anthropic)),
+		}, opts...)
+		return service.NewAnthropicExtractor(cfg.anthropicKey, model, opts...)
+	case llmProviderLocal:
+		opts = append([]service.ExtractorOption{
+			service.WithBaseURL(cfg.localBaseURL),
+			service.WithHTTPClient(httpClients.Client(httpx.LocalLLM)),
+		}, opts...)
+		return service.NewLocalExtractor(cfg.localKey, model, opts...)
+	case llmProviderAzure:
+		opts = append([]service.ExtractorOption{service.WithHTTPClient(httpClients.Client(httpx.OpenAI))}, opts...)
+		return service.NewAzureOpenAIExtractor(cfg.azureEndpoint, cfg.azureAPIVersion, cfg.azureKey, model, opts...)
+	}
+	opts = append([]service.ExtractorOption{
+		service.WithBaseURL(cfg.openaiBaseURL),
+		service.WithHTTPClient(httpClients.Client(httpx.OpenAI)),
+	}, opts...)
+	return service.NewOpenAIExtractor(cfg.openaiKey, model, opts...)
+}
+
+// Server timeout defaults. The write timeout outlasts an ingest processed
+// within the request (PROCESS_SYNC).
+const (
+	defaultReadHeaderTimeout = 10 * time.Second
+	defaultReadTimeout       = time.Minute
+	defaultWriteTimeout      = 2 * time.Minute
+	defaultIdleTimeout       = 2 * time.Minute
+	defaultMaxHeaderBytes    = 64 << 10
+)
+
+// serverFromEnv builds the HTTP server from the HTTP_* timeout and limit
+// variables, so a slow client cannot hold a connection open indefinitely.
+func serverFromEnv(addr string) (*http.Server, error) {
+	srv := &http.Server{
+		Addr:     addr,
+		ErrorLog: slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
+	}
+	for _, t := range []struct {
+		key string
+		def time.Duration
+		set *time.Duration
+	}{
+		{"HTTP_READ_HEADER_TIMEOUT", defaultReadHeaderTimeout, &srv.ReadHeaderTimeout},
+		{"HTTP_READ_TIMEOUT", defaultReadTimeout, &srv.ReadTimeout},
+		{"HTTP_WRITE_TIMEOUT", defaultWriteTimeout, &srv.WriteTimeout},
+		{"HTTP_IDLE_TIMEOUT", defaultIdleTimeout, &srv.IdleTimeout},
+	} {
+		*t.set = t.def
+		if v := os.Getenv(t.key); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("%s must be a positive duration, got %q", t.key, v)
+			}
+			*t.set = d
+		}
+	}
+	maxHeaderBytes, err := positiveIntEnv("HTTP_MAX_HEADER_BYTES", defaultMaxHeaderBytes)
+	if err != nil {
+		return nil, err
+	}
+	srv.MaxHeaderBytes = maxHeaderBytes
+	return srv, nil
+}
+
+// defaultHistogramEndpoints keep latency histograms with trace exemplars
+// unless SLO_HISTOGRAM_ENDPOINTS says otherwise.
+const defaultHistogramEndpoints = "POST /pantry/ingest,POST /pantry/ingest/{job_id}/confirm"
+
+// sloFromEnv builds the SLO tracker from SLO_SUCCESS_TARGET, SLO_LATENCY_TARGET,
+// SLO_WINDOW, SLO_ENDPOINT_TARGETS, SLO_HISTOGRAM_ENDPOINTS and SLO_SHED_BELOW.
+func sloFromEnv() (*slo.Tracker, error) {
+	def := slo.Objective{Success: slo.DefaultSuccess, Latency: slo.DefaultLatency}
+	if v := os.Getenv("SLO_SUCCESS_TARGET"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f <= 0 || f >= 1 {
+			return nil, fmt.Errorf("SLO_SUCCESS_TARGET must be between 0 and 1 exclusive, got %q", v)
+		}
+		def.Success = f
+	}
+	if v := os.Getenv("SLO_LATENCY_TARGET"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("SLO_LATENCY_TARGET must be a positive duration, got %q", v)
+		}
+		def.Latency = d
+	}
+	window := slo.DefaultWindow
+	if v := os.Getenv("SLO_WINDOW"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < time.Minute {
+			return nil, fmt.Errorf("SLO_WINDOW must be a duration of at least 1m, got %q", v)
+		}
+		window = d
+	}
+	overrides, err := slo.ParseObjectives(os.Getenv("SLO_ENDPOINT_TARGETS"), def)
+	if err != nil {
+		return nil, fmt.Errorf("SLO_ENDPOINT_TARGETS: %w", err)
+	}
+
+	tracker := slo.New(def, overrides, window)
+	histograms := defaultHistogramEndpoints
+	if v, ok := os.LookupEnv("SLO_HISTOGRAM_ENDPOINTS"); ok {
+		histograms = v
+	}
+	var endpoints []string
+	for _, name := range strings.Split(histograms, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			endpoints = append(endpoints, name)
+		}
+	}
+	tracker.SetHistograms(endpoints...)
+	if v := os.Getenv("SLO_SHED_BELOW"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 || f > 1 {
+			return nil, fmt.Errorf("SLO_SHED_BELOW must be between 0 and 1, got %q", v)
+		}
+		tracker.SetShedBelow(f)
+	}
+	return tracker, nil
+}
+
+// newHTTPClients builds the outbound client factory from HTTP_CLIENT_TIMEOUTS
+// and HTTP_CLIENT_PROXIES. With fault injection on, Dictionary and LLM
+// provider calls go through the injector.
+func newHTTPClients(injector *chaos.Injector) (*httpx.Factory, error) {
+	timeouts, err := httpx.ParseTimeouts(os.Getenv("HTTP_CLIENT_TIMEOUTS"))
+	if err != nil {
+		return nil, fmt.Errorf("HTTP_CLIENT_TIMEOUTS: %w", err)
+	}
+	proxies, err := httpx.ParseProxies(os.Getenv("HTTP_CLIENT_PROXIES"))
+	if err != nil {
+		return nil, fmt.Errorf("HTTP_CLIENT_PROXIES: %w", err)
+	}
+	opts := append(timeouts, proxies...)
+	// Only the Dictionary is ours; third parties do not get our trace IDs.
+	opts = append(opts, httpx.WithMiddleware(func(dep httpx.Dependency, rt http.RoundTripper) http.RoundTripper {
+		if dep == httpx.Dictionary {
+			return tracing.Transport(rt)
+		}
+		return rt
+	}))
+	if injector != nil {
+		targets := map[httpx.Dependency]chaos.Target{
+			httpx.Dictionary: chaos.Dictionary, httpx.OpenAI: chaos.LLM, httpx.Anthropic: chaos.LLM,
+			httpx.LocalLLM: chaos.LLM,
+		}
+		opts = append(opts, httpx.WithMiddleware(func(dep httpx.Dependency, rt http.RoundTripper) http.RoundTripper {
+			if target, ok := targets[dep]; ok {
+				return injector.Transport(rt, target)
+			}
+			return rt
+		}))
+	}
+	return httpx.NewFactory(opts...), nil
+}
+
+// positiveIntEnv reads a positive integer from key, returning def when unset.
+func positiveIntEnv(key string, def int) (int, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer, got %q", key, v)
+	}
+	return n, nil
+}
+
 type pantryPublisher interface {
 	service.UpdatePublisher
 	Close() error
 }
 
-func setupPantryUpdatedPublisher(rabbitMQURL string) pantryPublisher {
-	if rabbitMQURL == "" {
+// setupPublisher returns a no-op publisher when no broker is configured.
+// Otherwise it publishes to EVENT_BROKER and connects lazily, so events that
+// cannot reach the broker, including while it is down at startup, are kept
+// by the outbox given in opts and drained once it is reachable.
+func setupPublisher(cfg *config, opts ...events.PublisherOption) pantryPublisher {
+	broker, ok := cfg.brokerConfig()
+	if !ok {
 		slog.Info("RABBITMQ_URL not set; pantry.updated publishing disabled")
 		return nopCloserPublisher{}
 	}
 
-	pub, err := events.NewPantryUpdatedPublisher(rabbitMQURL)
+	pub, err := events.NewPublisher(broker, append(opts, events.WithLazyConnect())...)
 	if err != nil {
-		slog.Warn("failed to initialize RabbitMQ publisher; pantry.updated publishing disabled", "error", err)
+		slog.Warn("failed to initialize event publisher; pantry.updated publishing disabled",
+			"broker", broker.Broker, "error", err)
 		return nopCloserPublisher{}
 	}
+	if rabbit, ok := pub.(*events.PantryUpdatedPublisher); ok && !rabbit.Connected() {
+		slog.Warn("RabbitMQ unreachable; events will be queued in the outbox until it returns")
+	}
 
-	slog.Info("RabbitMQ pantry.updated publisher enabled")
+	slog.Info("event publisher enabled", "broker", broker.Broker)
 	return pub
 }
 
+// readinessChecks probes Postgres always, the event broker when publishing
+// is enabled, and the Dictionary only when READYZ_CHECK_DICTIONARY is true:
+// ingest and adds need it, but reads and consumes do not.
+func readinessChecks(
+	sqlDB *sql.DB, publisher pantryPublisher, broker string, dict *clients.DictionaryClient,
+) []api.ReadinessCheck {
+	checks := []api.ReadinessCheck{{Name: "postgres", Check: sqlDB.PingContext}}
+	if pub, ok := publisher.(events.Publisher); ok {
+		checks = append(checks, api.ReadinessCheck{Name: broker, Check: pub.Ping})
+	}
+	if os.Getenv("READYZ_CHECK_DICTIONARY") == "true" {
+		checks = append(checks, api.ReadinessCheck{Name: "dictionary", Check: dict.Ping})
+	}
+	return checks
+}
+
+// ingestJobHandler runs queued jobs on ingest, telling the consumer to hold
+// jobs back while the LLM provider is down.
+func ingestJobHandler(ingest *service.IngestService) events.JobHandler {
+	return func(ctx context.Context, jobID uuid.UUID, final bool) error {
+		err := ingest.ProcessQueuedJob(ctx, jobID, final)
+		if errors.Is(err, service.ErrIngestDeferred) {
+			return events.ErrJobDeferred
+		}
+		return err
+	}
+}
+
+// Consumer names in processed_messages.
+const (
+	recipeCookedConsumer     = "recipe.cooked"
+	dictionaryMergedConsumer = "dictionary.merged"
+)
+
+// recipeCookedHandler subtracts a cooked recipe's ingredients from the
+// pantry, once per message.
+func recipeCookedHandler(pantry *service.PantryService, dedup *service.MessageDeduper) func(
+	context.Context, string, events.RecipeCooked,
+) error {
+	return func(ctx context.Context, messageID string, event events.RecipeCooked) error {
+		_, err := dedup.ProcessOnce(ctx, recipeCookedConsumer, messageID, func(ctx context.Context) error {
+			ingredients := make([]service.CookedIngredient, len(event.Ingredients))
+			for i, in := range event.Ingredients {
+				ingredients[i] = service.CookedIngredient(in)
+			}
+			consumed, err := pantry.ConsumeCooked(ctx, ingredients)
+			if err != nil && len(consumed) > 0 {
+				// A retry would subtract the applied amounts a second time.
+				slog.WarnContext(ctx, "recipe cooked partially applied", "recipe_id", event.RecipeID, "error", err)
+				err = nil
+			}
+			slog.InfoContext(ctx, "recipe cooked", "recipe_id", event.RecipeID,
+				"ingredients", len(ingredients), "consumed", len(consumed))
+			return err
+		})
+		return err
+	}
+}
+
+// dictionaryMergedHandler moves the pantry off a merged ingredient ID, once
+// per message.
+func dictionaryMergedHandler(pantry *service.PantryService, dedup *service.MessageDeduper) func(
+	context.Context, string, events.DictionaryMerged,
+) error {
+	return func(ctx context.Context, messageID string, event events.DictionaryMerged) error {
+		_, err := dedup.ProcessOnce(ctx, dictionaryMergedConsumer, messageID, func(ctx context.Context) error {
+			merge, err := pantry.MergeIngredient(ctx, event.MergedID, event.SurvivingID)
+			if err != nil {
+				return err
+			}
+			slog.InfoContext(ctx, "ingredient merged", "merged_id", event.MergedID,
+				"surviving_id", event.SurvivingID, "pantry_item", merge.PantryItem, "staged_items", merge.StagedItems)
+			return nil
+		})
+		return err
+	}
+}
+
+// confirmSummaries publishes service confirm summaries as
+// pantry.ingest.confirm_summary events.
+type confirmSummaries struct {
+	pub events.Publisher
+}
+
+func (c confirmSummaries) PublishConfirmSummary(ctx context.Context, s service.ConfirmSummary) error {
+	categories := make([]events.CategoryQuantity, len(s.Categories))
+	for i, q := range s.Categories {
+		categories[i] = events.CategoryQuantity(q)
+	}
+	return c.pub.PublishConfirmSummary(ctx, events.ConfirmSummary{
+		JobID:         s.JobID,
+		Source:        s.Source,
+		ItemCount:     s.ItemCount,
+		SkippedCount:  s.SkippedCount,
+		OverrideCount: s.OverrideCount,
+		Categories:    categories,
+	}, s.ConfirmedAt)
+}
+
+// itemChanges publishes service item snapshots as pantry.updated.v2 events.
+type itemChanges struct {
+	pub events.Publisher
+}
+
+func (c itemChanges) PublishItemChanges(ctx context.Context, changes []service.ItemChange, reset bool) error {
+	out := make([]events.ItemChange, len(changes))
+	for i, change := range changes {
+		out[i] = events.ItemChange(change)
+	}
+	return c.pub.PublishItemChanges(ctx, out, reset)
+}
+
+func setupConfirmHooks(path string, client *webhook.Client, publisher pantryPublisher) (*hooks.Runner, error) {
+	sinks, err := hooks.Load(path, map[string]hooks.Factory{
+		"webhook": func(cfg hooks.SinkConfig) (hooks.Sink, error) {
+			return hooks.NewWebhookSink(cfg.URL, cfg.Secret, cfg.Headers, client)
+		},
+		"event": func(cfg hooks.SinkConfig) (hooks.Sink, error) {
+			raw, ok := publisher.(hooks.Publisher)
+			if !ok {
+				return nil, errors.New("event hooks require an event broker")
+			}
+			return hooks.NewEventSink(cfg.RoutingKey, raw)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("post-confirm hooks enabled", "count", len(sinks))
+	return hooks.NewRunner(sinks...), nil
+}
+
 type nopCloserPublisher struct{}
 
 func (nopCloserPublisher) PublishPantryUpdated(_ context.Context, _ []uuid.UUID) error {
@@ -123,7 +796,28 @@ func (nopCloserPublisher) Close() error {
 	return nil
 }
 
-func runMigrations(sqlDB *sql.DB) error {
+// runMigrations applies pending migrations. With verify set it first refuses
+// to start if a migration already applied was changed since, and afterwards
+// records the checksums of everything applied.
+func runMigrations(sqlDB *sql.DB, verify bool) error {
+	ctx := context.Background()
+	migrationsDir, err := fs.Sub(db.MigrationsFS, "migrations")
+	if err != nil {
+		return fmt.Errorf("open embedded migrations: %w", err)
+	}
+	files, err := db.EmbeddedMigrations(migrationsDir)
+	if err != nil {
+		return err
+	}
+	queries := db.New(sqlDB)
+	if verify {
+		if err := db.VerifyMigrations(ctx, queries, files); err != nil {
+			return err
+		}
+	} else {
+		slog.Warn("migration integrity check skipped; edited migrations will not be detected")
+	}
+
 	srcDriver, err := iofs.New(db.MigrationsFS, "migrations")
 	if err != nil {
 		return fmt.Errorf("create migration source: %w", err)
@@ -139,7 +833,12 @@ func runMigrations(sqlDB *sql.DB) error {
 	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
 		return fmt.Errorf("run migrations: %w", err)
 	}
-	return nil
+
+	version, _, err := m.Version()
+	if err != nil {
+		return fmt.Errorf("read migration version: %w", err)
+	}
+	return db.RecordMigrations(ctx, queries, files, int64(version)) //nolint:gosec // migration versions are small
 }
 
 func envOrDefault(key, def string) string {
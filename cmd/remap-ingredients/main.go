@@ -0,0 +1,127 @@
+// Command remap-ingredients moves the pantry's stored ingredient IDs to a new
+// Dictionary instance. It names every stored ID through the old Dictionary,
+// resolves the names against the new one, and rewrites pantry_items,
+// watchlist, staged_items, pantry_item_tombstones, pantry_activity and
+// ingredient_display_overrides in a single serializable transaction.
+//
+// Without -apply it is a dry run: the plan is written and the transaction
+// rolled back. Apply refuses plans with unresolved or conflicting entries.
+// Stop the pantry service (or its writers) while applying.
+//
+//	DB_URL=postgres://... remap-ingredients -from http://dict-staging -to http://dict-prod [-apply] [-out plan.json]
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	_ "github.com/lib/pq"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/httpx"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// report is the JSON document written to -out.
+type report struct {
+	DryRun bool                 `json:"dry_run"`
+	Plan   service.RemapPlan    `json:"plan"`
+	Counts *service.RemapCounts `json:"rewritten,omitempty"`
+}
+
+func main() {
+	logging.Setup()
+
+	if err := run(); err != nil {
+		slog.Error("fatal", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	from := flag.String("from", "", "base URL of the Dictionary the stored IDs come from")
+	to := flag.String("to", "", "base URL of the Dictionary to move the IDs to")
+	apply := flag.Bool("apply", false, "rewrite the IDs; without it the plan is only reported")
+	out := flag.String("out", "-", "file to write the plan report to, - for stdout")
+	flag.Parse()
+
+	if *from == "" || *to == "" {
+		return errors.New("-from and -to are required")
+	}
+	dbURL := os.Getenv("DB_URL")
+	if dbURL == "" {
+		return errors.New("DB_URL is required")
+	}
+
+	sqlDB, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	defer sqlDB.Close()
+
+	ctx := context.Background()
+	tx, err := sqlDB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+
+	httpClient := httpx.NewFactory().Client(httpx.Dictionary)
+	remapper := service.NewIngredientRemapper(
+		db.New(sqlDB).WithTx(tx),
+		clients.NewDictionaryClient(*from, httpClient),
+		clients.NewDictionaryClient(*to, httpClient),
+	)
+
+	plan, err := remapper.Plan(ctx)
+	if err != nil {
+		return err
+	}
+	rep := report{DryRun: !*apply, Plan: plan}
+	slog.Info("remap plan built",
+		"ingredients", len(plan.Entries),
+		"unresolved", plan.Unresolved,
+		"conflicts", plan.Conflicts,
+		"low_confidence", plan.LowConfidence,
+	)
+
+	if *apply {
+		counts, err := remapper.Apply(ctx, plan)
+		if err != nil {
+			if werr := writeReport(*out, rep); werr != nil {
+				slog.Error("failed to write plan report", "error", werr)
+			}
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("commit: %w", err)
+		}
+		rep.Counts = &counts
+		slog.Info("ingredient ids remapped", "pantry_items", counts.PantryItems, "watchlist", counts.Watchlist)
+	}
+	return writeReport(*out, rep)
+}
+
+func writeReport(path string, rep report) error {
+	var w io.Writer = os.Stdout
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("create report: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rep)
+}
@@ -0,0 +1,348 @@
+//go:build e2e
+
+// Package e2e drives the real pantry binary against containerized Postgres and
+// RabbitMQ plus in-process stubs for the Dictionary and OpenAI, asserting on
+// HTTP responses and emitted AMQP messages.
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/testutil/llmserver"
+	"github.com/mwhite7112/woodpantry-pantry/pkg/model"
+)
+
+// dictionaryNamespace seeds deterministic ingredient IDs so runs are replayable.
+var dictionaryNamespace = uuid.MustParse("6f1c2a8e-3b0e-4c55-9a57-2f7c1e0d9b11")
+
+func ingredientID(name string) uuid.UUID {
+	return uuid.NewSHA1(dictionaryNamespace, []byte(name))
+}
+
+type stack struct {
+	baseURL    string
+	deliveries <-chan amqp.Delivery
+}
+
+func TestMain(m *testing.M) {
+	// Disable Ryuk reaper — it doesn't work with rootless Podman.
+	// Cleanup is handled by t.Cleanup instead.
+	os.Setenv("TESTCONTAINERS_RYUK_DISABLED", "true")
+	os.Exit(m.Run())
+}
+
+func TestIngestReviewConfirm(t *testing.T) {
+	s := startStack(t, map[string]string{
+		"2 lbs chicken breast, 1 head garlic": `{"items":[
+			{"raw_text":"2 lbs chicken breast","name":"chicken breast","quantity":2,"unit":"lb","confidence":0.97},
+			{"raw_text":"1 head garlic","name":"garlic","quantity":1,"unit":"head","confidence":0.95}
+		]}`,
+	})
+
+	var created struct {
+		JobID  uuid.UUID `json:"job_id"`
+		Status string    `json:"status"`
+	}
+	doJSON(t, http.MethodPost, s.baseURL+"/pantry/ingest",
+		`{"type":"text_blob","content":"2 lbs chicken breast, 1 head garlic"}`,
+		http.StatusAccepted, &created)
+	assert.Equal(t, "pending", created.Status)
+	requested := nextEvent(t, s.deliveries)
+	assert.Equal(t, "pantry.ingest.requested", requested.RoutingKey)
+	assert.Contains(t, string(requested.Body), created.JobID.String())
+
+	job := waitForStatus(t, s.baseURL, created.JobID, "staged")
+	require.Len(t, job.Items, 2)
+
+	doJSON(t, http.MethodPost, s.baseURL+"/pantry/ingest/"+created.JobID.String()+"/confirm", "",
+		http.StatusOK, nil)
+
+	var pantry struct {
+		Items []model.PantryItem `json:"items"`
+	}
+	doJSON(t, http.MethodGet, s.baseURL+"/pantry", "", http.StatusOK, &pantry)
+	require.Len(t, pantry.Items, 2)
+
+	gotIngredients := map[uuid.UUID]bool{}
+	itemIDs := map[string]bool{}
+	for _, item := range pantry.Items {
+		gotIngredients[item.IngredientID] = true
+		itemIDs[item.ID.String()] = true
+	}
+	assert.True(t, gotIngredients[ingredientID("chicken breast")])
+	assert.True(t, gotIngredients[ingredientID("garlic")])
+
+	event := nextEvent(t, s.deliveries)
+	assert.Equal(t, "pantry.updated", event.RoutingKey)
+	var payload struct {
+		ChangedItemIDs []string `json:"changed_item_ids"`
+	}
+	require.NoError(t, json.Unmarshal(event.Body, &payload))
+	require.Len(t, payload.ChangedItemIDs, 2)
+	for _, id := range payload.ChangedItemIDs {
+		assert.True(t, itemIDs[id], "event references unknown item %s", id)
+	}
+}
+
+func TestIngestExtractionFailure(t *testing.T) {
+	// Retried once through the queue before it is marked failed.
+	s := startStack(t, map[string]string{"INGEST_JOB_ATTEMPTS": "2", "INGEST_RETRY_DELAY": "100ms"})
+
+	var created struct {
+		JobID uuid.UUID `json:"job_id"`
+	}
+	doJSON(t, http.MethodPost, s.baseURL+"/pantry/ingest", `{"content":"unparseable"}`, http.StatusAccepted, &created)
+
+	waitForStatus(t, s.baseURL, created.JobID, "failed")
+}
+
+func TestManualAddAndDeletePublishes(t *testing.T) {
+	s := startStack(t, map[string]string{})
+
+	var item model.PantryItem
+	doJSON(t, http.MethodPost, s.baseURL+"/pantry/items",
+		`{"name":"flour","quantity":2,"unit":"cup"}`, http.StatusCreated, &item)
+	assert.Contains(t, string(nextEvent(t, s.deliveries).Body), item.ID.String())
+
+	doJSON(t, http.MethodDelete, s.baseURL+"/pantry/items/"+item.ID.String(), "", http.StatusNoContent, nil)
+	assert.Contains(t, string(nextEvent(t, s.deliveries).Body), item.ID.String())
+}
+
+// startStack boots Postgres, RabbitMQ, the stubs, and the pantry binary.
+// fixtures maps exact ingest input text to the JSON content the llmserver
+// mock returns; unknown inputs get a 500.
+func startStack(t *testing.T, fixtures map[string]string) stack {
+	t.Helper()
+	ctx := context.Background()
+
+	dbURL := startPostgres(ctx, t)
+	amqpURL := startRabbitMQ(ctx, t)
+	deliveries := bindEventQueue(t, amqpURL)
+
+	dictionary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Name string `json:"name"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"ingredient":{"id":%q,"name":%q},"confidence":1,"created":false}`,
+			ingredientID(req.Name), req.Name)
+	}))
+	t.Cleanup(dictionary.Close)
+
+	opts := make([]llmserver.Option, 0, len(fixtures))
+	for input, content := range fixtures {
+		opts = append(opts, llmserver.WithInput(input, llmserver.Scenario{Content: content}))
+	}
+	openai := llmserver.New(t, opts...)
+
+	port := freePort(t)
+	cmd := exec.Command(buildBinary(t))
+	cmd.Env = append(os.Environ(),
+		"PORT="+port,
+		"DB_URL="+dbURL,
+		"DICTIONARY_URL="+dictionary.URL,
+		"OPENAI_API_KEY=sk-e2e",
+		"OPENAI_BASE_URL="+openai.URL,
+		"RABBITMQ_URL="+amqpURL,
+		"LOG_LEVEL=debug",
+	)
+	var logs bytes.Buffer
+	cmd.Stdout = &logs
+	cmd.Stderr = &logs
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		if t.Failed() {
+			t.Logf("pantry logs:\n%s", logs.String())
+		}
+	})
+
+	baseURL := "http://127.0.0.1:" + port
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(baseURL + "/healthz") //nolint:noctx
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 30*time.Second, 200*time.Millisecond, "pantry binary did not become healthy")
+
+	return stack{baseURL: baseURL, deliveries: deliveries}
+}
+
+var (
+	buildOnce sync.Once
+	binPath   string
+	buildErr  error
+)
+
+func buildBinary(t *testing.T) string {
+	t.Helper()
+
+	buildOnce.Do(func() {
+		_, filename, _, _ := runtime.Caller(0)
+		root := filepath.Join(filepath.Dir(filename), "..")
+		binPath = filepath.Join(os.TempDir(), "pantry-e2e-"+uuid.NewString())
+		cmd := exec.Command("go", "build", "-o", binPath, "./cmd/pantry")
+		cmd.Dir = root
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			buildErr = fmt.Errorf("go build: %w\n%s", err, out)
+		}
+	})
+	require.NoError(t, buildErr)
+	return binPath
+}
+
+func startPostgres(ctx context.Context, t *testing.T) string {
+	t.Helper()
+
+	pg, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("pantry_db"),
+		postgres.WithUsername("test"),
+		postgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(30*time.Second),
+		),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = pg.Terminate(ctx) })
+
+	connStr, err := pg.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+	return connStr
+}
+
+func startRabbitMQ(ctx context.Context, t *testing.T) string {
+	t.Helper()
+
+	rmq, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "rabbitmq:3.13-alpine",
+			ExposedPorts: []string{"5672/tcp"},
+			WaitingFor:   wait.ForLog("Server startup complete").WithStartupTimeout(60 * time.Second),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = rmq.Terminate(ctx) })
+
+	host, err := rmq.Host(ctx)
+	require.NoError(t, err)
+	port, err := rmq.MappedPort(ctx, "5672/tcp")
+	require.NoError(t, err)
+	return fmt.Sprintf("amqp://guest:guest@%s:%s/", host, port.Port())
+}
+
+// bindEventQueue declares the shared exchange and an exclusive queue bound to
+// pantry.# so every event the binary emits is captured.
+func bindEventQueue(t *testing.T, amqpURL string) <-chan amqp.Delivery {
+	t.Helper()
+
+	conn, err := amqp.Dial(amqpURL)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ch, err := conn.Channel()
+	require.NoError(t, err)
+	require.NoError(t, ch.ExchangeDeclare("woodpantry.topic", "topic", true, false, false, false, nil))
+
+	q, err := ch.QueueDeclare("", false, true, true, false, nil)
+	require.NoError(t, err)
+	require.NoError(t, ch.QueueBind(q.Name, "pantry.#", "woodpantry.topic", false, nil))
+
+	deliveries, err := ch.Consume(q.Name, "", true, true, false, false, nil)
+	require.NoError(t, err)
+	return deliveries
+}
+
+func nextEvent(t *testing.T, deliveries <-chan amqp.Delivery) amqp.Delivery {
+	t.Helper()
+
+	select {
+	case d := <-deliveries:
+		return d
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for AMQP event")
+		return amqp.Delivery{}
+	}
+}
+
+type jobResponse struct {
+	Status string            `json:"status"`
+	Items  []json.RawMessage `json:"items"`
+}
+
+func waitForStatus(t *testing.T, baseURL string, jobID uuid.UUID, want string) jobResponse {
+	t.Helper()
+
+	var job jobResponse
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(baseURL + "/pantry/ingest/" + jobID.String()) //nolint:noctx
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		return json.NewDecoder(resp.Body).Decode(&job) == nil && job.Status == want
+	}, 30*time.Second, 250*time.Millisecond, "job %s never reached %q", jobID, want)
+	return job
+}
+
+func doJSON(t *testing.T, method, url, body string, wantStatus int, out any) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, wantStatus, resp.StatusCode, "%s %s", method, url)
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+}
+
+func freePort(t *testing.T) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	_, port, err := net.SplitHostPort(l.Addr().String())
+	require.NoError(t, err)
+	return port
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// --- GET /pantry/activity ---
+
+func handleListActivity(a *service.ActivityLog) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		limit := service.DefaultActivityLimit
+		if v := q.Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 || n > service.MaxActivityLimit {
+				jsonError(r.Context(), w,
+					fmt.Sprintf("limit must be between 1 and %d", service.MaxActivityLimit), http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+		var before int64
+		if v := q.Get("before"); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n <= 0 {
+				jsonError(r.Context(), w, "before must be a positive integer", http.StatusBadRequest)
+				return
+			}
+			before = n
+		}
+
+		page, err := a.Feed(r.Context(), before, limit)
+		if err != nil {
+			jsonError(r.Context(), w, "failed to list activity", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, page)
+	}
+}
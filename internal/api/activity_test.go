@@ -0,0 +1,58 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+func withActivity(q *mocks.MockQuerier, dict *clients.DictionaryClient) Option {
+	return WithActivity(service.NewActivityLog(q, dict))
+}
+
+func TestGetActivity(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withActivity)
+	mockQ.EXPECT().ListPantryActivity(mock.Anything, db.ListPantryActivityParams{
+		Before: sql.NullInt64{Int64: 10, Valid: true},
+		Limit:  1,
+	}).Return([]db.PantryActivity{{
+		ID:        9,
+		Kind:      service.ActivityIngestConfirmed,
+		Source:    sql.NullString{String: "email", Valid: true},
+		ItemCount: 3,
+	}}, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry/activity?before=10&limit=1", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var page service.ActivityPage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	require.Len(t, page.Entries, 1)
+	assert.Equal(t, "Added 3 items from a grocery list via email", page.Entries[0].Description)
+	assert.Equal(t, int64(9), page.NextCursor)
+}
+
+func TestGetActivity_BadParams(t *testing.T) {
+	t.Parallel()
+
+	_, router := setupRouter(t, withActivity)
+	for _, query := range []string{"limit=0", "limit=101", "before=abc", "before=-1"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry/activity?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/events"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// --- GET /admin/event-schemas ---
+
+func handleListEventSchemas(w http.ResponseWriter, r *http.Request) {
+	jsonOK(w, map[string]any{"schemas": events.Schemas()})
+}
+
+// --- GET /admin/workers ---
+
+func handleListWorkers(ingest *service.IngestService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jsonOK(w, ingest.WorkerStatus())
+	}
+}
+
+// --- GET /admin/llm-health ---
+
+func handleLLMHealth(ingest *service.IngestService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status, ok := ingest.ProviderHealth()
+		if !ok {
+			jsonError(r.Context(), w, "llm health tracking is not enabled", http.StatusNotFound)
+			return
+		}
+		jsonOK(w, status)
+	}
+}
+
+// --- POST /admin/ingest/{job_id}/transition ---
+
+type transitionRequest struct {
+	To     string `json:"to"`
+	Reason string `json:"reason"` // required; recorded in the audit log
+}
+
+func handleTransitionJob(ingest *service.IngestService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID, err := uuid.Parse(chi.URLParam(r, "job_id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid job_id", http.StatusBadRequest)
+			return
+		}
+
+		var req transitionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.To == "" {
+			jsonError(r.Context(), w, "to is required", http.StatusBadRequest)
+			return
+		}
+		if strings.TrimSpace(req.Reason) == "" {
+			jsonError(r.Context(), w, "reason is required", http.StatusBadRequest)
+			return
+		}
+
+		job, err := ingest.ForceTransition(r.Context(), jobID, req.To, req.Reason)
+		switch {
+		case err == nil:
+			jsonOK(w, map[string]any{"job_id": job.ID, "status": job.Status})
+		case errors.Is(err, sql.ErrNoRows):
+			jsonError(r.Context(), w, "job not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrJobConfirmed), errors.Is(err, service.ErrTransitionNotAllowed):
+			jsonError(r.Context(), w, err.Error(), http.StatusConflict)
+		case errors.Is(err, service.ErrIngestQueueFull):
+			w.Header().Set("Retry-After", ingestRetryAfter)
+			jsonError(r.Context(), w, "ingest queue is full; retry later", http.StatusServiceUnavailable)
+		default:
+			jsonError(r.Context(), w, "failed to transition job", http.StatusInternalServerError, err)
+		}
+	}
+}
+
+// --- GET /admin/shadow-extractions/report ---
+
+func handleShadowReport(ingest *service.IngestService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := service.DefaultShadowReportLimit
+		if v := r.URL.Query().Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 || n > service.MaxShadowReportLimit {
+				jsonError(r.Context(), w,
+					fmt.Sprintf("limit must be between 1 and %d", service.MaxShadowReportLimit), http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+
+		report, err := ingest.ShadowReport(r.Context(), limit)
+		if err != nil {
+			jsonError(r.Context(), w, "failed to build shadow report", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, report)
+	}
+}
+
+// --- GET /admin/maintenance/vacuum-hints ---
+
+func handleVacuumHints(m *service.MaintenanceService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, err := m.VacuumHints(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to read table stats", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, report)
+	}
+}
+
+// --- POST /admin/maintenance/analyze ---
+
+func handleAnalyze(m *service.MaintenanceService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, err := m.Analyze(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to analyze tables", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, report)
+	}
+}
+
+// --- POST /admin/maintenance/reindex ---
+
+func handleReindex(m *service.MaintenanceService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, err := m.Reindex(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to reindex tables", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, report)
+	}
+}
+
+// --- POST /admin/maintenance/cleanup-orphans ---
+
+func handleCleanupOrphans(m *service.MaintenanceService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, err := m.CleanupOrphans(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to clean up orphaned rows", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, report)
+	}
+}
+
+// --- POST /admin/maintenance/integrity-check ---
+
+func handleIntegrityCheck(m *service.MaintenanceService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, err := m.CheckIntegrity(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to check integrity", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, report)
+	}
+}
+
+// --- GET /admin/llm-budget ---
+
+func handleGetLLMBudget(b *service.LLMBudget) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status, err := b.Status(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to read llm budget", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, status)
+	}
+}
+
+// --- POST /admin/llm-budget/reset ---
+
+func handleResetLLMBudget(b *service.LLMBudget) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status, err := b.Reset(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to reset llm budget", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, status)
+	}
+}
@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+func withMaintenance(q *mocks.MockQuerier, dict *clients.DictionaryClient) Option {
+	return WithMaintenance(service.NewMaintenanceService(q, dict))
+}
+
+func TestPostCleanupOrphans(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withMaintenance)
+	mockQ.EXPECT().DeleteOrphanedStagedItems(mock.Anything).Return(2, nil)
+	mockQ.EXPECT().DeletePantryTombstonesBefore(mock.Anything, mock.Anything).Return(5, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/maintenance/cleanup-orphans", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]int64
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, int64(2), body["deleted_staged_items"])
+	assert.Equal(t, int64(5), body["deleted_tombstones"])
+}
+
+func TestMaintenanceRoutes_NotMountedByDefault(t *testing.T) {
+	t.Parallel()
+
+	_, router := setupRouter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/maintenance/analyze", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestLLMBudgetRoutes(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	budget := service.NewLLMBudget(mockQ, 1000)
+	ingestSvc := service.NewIngestService(mockQ, &stubResolver{}, &stubExtractor{})
+	router := NewRouter(service.NewPantryService(mockQ), ingestSvc, nil, WithLLMBudget(budget))
+
+	mockQ.EXPECT().GetLLMUsage(mock.Anything, mock.Anything).Return(db.LlmUsage{TokensUsed: 1200}, nil).Once()
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/llm-budget", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var status service.BudgetStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.True(t, status.Exceeded)
+	assert.Equal(t, int64(1200), status.TokensUsed)
+
+	mockQ.EXPECT().ResetLLMUsage(mock.Anything, mock.Anything).Return(nil)
+	mockQ.EXPECT().GetLLMUsage(mock.Anything, mock.Anything).Return(db.LlmUsage{}, nil).Once()
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/llm-budget/reset", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.False(t, status.Exceeded)
+	assert.Equal(t, int64(1000), status.Remaining)
+}
+
+func TestGetWorkers(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	ingestSvc := service.NewIngestService(mockQ, &stubResolver{}, &stubExtractor{})
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	ingestSvc.StartWorkers(ctx, 2, 10)
+	router := NewRouter(service.NewPantryService(mockQ), ingestSvc, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/workers", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var status service.WorkerPoolStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, 2, status.PoolSize)
+	assert.Equal(t, 10, status.QueueCapacity)
+	assert.Len(t, status.Workers, 2)
+}
+
+func TestPostTransitionJob(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	ingestSvc := service.NewIngestService(mockQ, &stubResolver{}, &stubExtractor{})
+	router := NewRouter(service.NewPantryService(mockQ), ingestSvc, nil)
+
+	post := func(jobID uuid.UUID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/admin/ingest/"+jobID.String()+"/transition",
+			strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := post(uuid.New(), `{"to":"failed"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "reason is required")
+
+	confirmed := uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, confirmed).
+		Return(db.IngestionJob{ID: confirmed, Status: "confirmed"}, nil)
+	rec = post(confirmed, `{"to":"failed","reason":"cleanup"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	stuck := uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, stuck).
+		Return(db.IngestionJob{ID: stuck, Status: "processing"}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, db.TransitionIngestionJobStatusParams{
+		ToStatus:   "failed",
+		ID:         stuck,
+		FromStatus: "processing",
+	}).Return(db.IngestionJob{ID: stuck, Status: "failed"}, nil)
+	rec = post(stuck, `{"to":"failed","reason":"worker crashed"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"failed"`)
+}
+
+func TestGetShadowReport(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	ingestSvc := service.NewIngestService(mockQ, &stubResolver{}, &stubExtractor{})
+	router := NewRouter(service.NewPantryService(mockQ), ingestSvc, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/shadow-extractions/report?limit=0", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	mockQ.EXPECT().ListShadowExtractions(mock.Anything, int32(service.DefaultShadowReportLimit)).Return(nil, nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/shadow-extractions/report", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"active_candidate":"","sample":0,"candidates":[],"divergences":[]}`, rec.Body.String())
+}
+
+func TestGetEventSchemas(t *testing.T) {
+	t.Parallel()
+
+	_, router := setupRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/event-schemas", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Schemas []struct {
+			Name    string          `json:"name"`
+			Version int             `json:"version"`
+			Schema  json.RawMessage `json:"schema"`
+		} `json:"schemas"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	names := make([]string, len(body.Schemas))
+	for i, s := range body.Schemas {
+		names[i] = s.Name
+		assert.NotEmpty(t, s.Schema)
+	}
+	assert.Equal(t, []string{
+		"pantry.expiring", "pantry.ingest.confirm_summary", "pantry.ingest.requested", "pantry.updated",
+		"pantry.updated.v2",
+	}, names)
+}
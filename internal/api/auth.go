@@ -0,0 +1,113 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/auth"
+)
+
+// Scopes a bearer token may grant. Each route needs exactly one, chosen by
+// routeScope.
+const (
+	ScopePantryRead  = "pantry:read"
+	ScopePantryWrite = "pantry:write"
+	ScopeIngestWrite = "ingest:write"
+	ScopeAdmin       = "admin"
+)
+
+// publicPaths are served without a token: probes and scrapers carry none.
+var publicPaths = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// readScopedPosts are the POSTs that only read, so pantry:read is enough.
+var readScopedPosts = map[string]bool{
+	"/pantry/items/lookup":  true,
+	"/pantry/items/preview": true,
+}
+
+// queryTokenPaths also take the token as ?access_token=, since browsers
+// cannot set headers on a WebSocket handshake.
+var queryTokenPaths = map[string]bool{
+	"/pantry/ws": true,
+}
+
+// WithAuth requires a bearer JWT that v accepts on every route but the probes
+// and /metrics, and the scope routeScope assigns to the route.
+func WithAuth(v *auth.Verifier) Option {
+	return func(o *routerOptions) { o.auth = v }
+}
+
+// routeScope returns the scope a request needs, or "" for a public route.
+// Everything under /admin and /webhooks needs admin, since subscriptions
+// hold signing secrets and receive the pantry's events. Elsewhere reads need
+// pantry:read, writes under /pantry/ingest need ingest:write, and other
+// writes need pantry:write.
+func routeScope(method, path string) string {
+	switch {
+	case publicPaths[path] || method == http.MethodOptions:
+		return ""
+	case strings.HasPrefix(path, "/admin/"), path == "/webhooks", strings.HasPrefix(path, "/webhooks/"):
+		return ScopeAdmin
+	case method == http.MethodGet || method == http.MethodHead:
+		return ScopePantryRead
+	case method == http.MethodPost && readScopedPosts[path]:
+		return ScopePantryRead
+	case path == "/pantry/ingest" || strings.HasPrefix(path, "/pantry/ingest/"):
+		return ScopeIngestWrite
+	default:
+		return ScopePantryWrite
+	}
+}
+
+// requireScope answers 401 for a missing or invalid token, 403 for a token
+// without the route's scope, and 503 when the signing keys cannot be
+// fetched.
+func requireScope(v *auth.Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := routeScope(r.Method, r.URL.Path)
+			if scope == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			token := bearerToken(r)
+			if token == "" {
+				w.Header().Set("WWW-Authenticate", `Bearer`)
+				jsonError(r.Context(), w, "bearer token required", http.StatusUnauthorized)
+				return
+			}
+			claims, err := v.Verify(r.Context(), token)
+			if errors.Is(err, auth.ErrKeysUnavailable) {
+				jsonError(r.Context(), w, "cannot verify tokens right now", http.StatusServiceUnavailable, err)
+				return
+			}
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				jsonError(r.Context(), w, "invalid bearer token", http.StatusUnauthorized)
+				return
+			}
+			if !claims.HasScope(scope) {
+				w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+				jsonError(r.Context(), w, "token lacks scope "+scope, http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// bearerToken returns the request's bearer token, or "" when it has none.
+func bearerToken(r *http.Request) string {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	if queryTokenPaths[r.URL.Path] {
+		return r.URL.Query().Get("access_token")
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/auth"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+	"github.com/mwhite7112/woodpantry-pantry/internal/testutil/jwksserver"
+)
+
+func TestRouteScope(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		method, path, want string
+	}{
+		{http.MethodGet, "/healthz", ""},
+		{http.MethodGet, "/metrics", ""},
+		{http.MethodGet, "/pantry", ScopePantryRead},
+		{http.MethodGet, "/pantry/ingest/abc", ScopePantryRead},
+		{http.MethodPost, "/pantry/items/lookup", ScopePantryRead},
+		{http.MethodPost, "/pantry/items", ScopePantryWrite},
+		{http.MethodDelete, "/pantry/items/abc", ScopePantryWrite},
+		{http.MethodPost, "/pantry/ingest", ScopeIngestWrite},
+		{http.MethodPost, "/pantry/ingest/abc/confirm", ScopeIngestWrite},
+		{http.MethodGet, "/admin/workers", ScopeAdmin},
+		{http.MethodPut, "/admin/read-only", ScopeAdmin},
+		{http.MethodGet, "/webhooks", ScopeAdmin},
+		{http.MethodDelete, "/webhooks/abc", ScopeAdmin},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, routeScope(tc.method, tc.path), tc.method+" "+tc.path)
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	t.Parallel()
+
+	idp := jwksserver.New(t)
+	mockQ := mocks.NewMockQuerier(t)
+	router := NewRouter(service.NewPantryService(mockQ), service.NewIngestService(mockQ, nil, nil), nil,
+		WithAuth(auth.NewVerifier(idp.JWKSURL(), idp.Client())))
+	mockQ.EXPECT().ListPantryItems(mock.Anything).Return([]db.PantryItem{}, nil)
+
+	token := func(scope string) string {
+		return idp.SignRS256(t, map[string]any{"exp": time.Now().Add(time.Hour).Unix(), "scope": scope})
+	}
+	tests := []struct {
+		name, method, path, token string
+		want                      int
+	}{
+		{name: "public", method: http.MethodGet, path: "/healthz", want: http.StatusOK},
+		{name: "no token", method: http.MethodGet, path: "/pantry", want: http.StatusUnauthorized},
+		{name: "bad token", method: http.MethodGet, path: "/pantry", token: "nope", want: http.StatusUnauthorized},
+		{
+			name: "missing scope", method: http.MethodDelete, path: "/pantry/reset?confirm=true",
+			token: token(ScopePantryRead), want: http.StatusForbidden,
+		},
+		{
+			name: "read scope", method: http.MethodGet, path: "/pantry",
+			token: token(ScopePantryRead + " " + ScopeIngestWrite), want: http.StatusOK,
+		},
+	}
+	for _, tc := range tests {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		if tc.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, tc.want, rec.Code, tc.name)
+		if tc.want == http.StatusForbidden {
+			assert.Contains(t, rec.Header().Get("WWW-Authenticate"), `scope="pantry:write"`)
+		}
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name, target, header, want string
+	}{
+		{name: "header", target: "/pantry", header: "Bearer abc", want: "abc"},
+		{name: "header wins", target: "/pantry/ws?access_token=q", header: "Bearer abc", want: "abc"},
+		{name: "query on feed", target: "/pantry/ws?access_token=q", want: "q"},
+		{name: "query elsewhere ignored", target: "/pantry?access_token=q", want: ""},
+		{name: "other scheme", target: "/pantry", header: "Basic abc", want: ""},
+	}
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+		if tc.header != "" {
+			req.Header.Set("Authorization", tc.header)
+		}
+		assert.Equal(t, tc.want, bearerToken(req), tc.name)
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// maxBatchEntries bounds how many entries one batch request may carry.
+const maxBatchEntries = 100
+
+// batchResult is the outcome of one entry of a batch request. Index is the
+// entry's position in the request; Ref echoes the caller's identifier for it
+// when the entry has one (e.g. staged_item_id); ID is the resulting resource.
+type batchResult struct {
+	Index  int        `json:"index"`
+	Status int        `json:"status"`
+	Ref    *uuid.UUID `json:"ref,omitempty"`
+	ID     *uuid.UUID `json:"id,omitempty"`
+	Error  string     `json:"error,omitempty"`
+}
+
+func (b batchResult) ok() bool {
+	return b.Status >= 200 && b.Status < 300
+}
+
+// entryError is a per-entry failure: the status and message that entry
+// reports, plus the underlying error to log for server-side failures.
+type entryError struct {
+	status int
+	msg    string
+	err    error
+}
+
+// result turns the error into the batch result for entry index.
+func (e *entryError) result(ctx context.Context, index int) batchResult {
+	if e.status >= http.StatusInternalServerError && e.err != nil {
+		slog.Default().ErrorContext(ctx, e.msg, "status", e.status, "index", index, "error", e.err)
+	}
+	return batchResult{Index: index, Status: e.status, Error: e.msg}
+}
+
+// writeBatch writes the shared batch response: 200 when every entry
+// succeeded, otherwise 207 Multi-Status so clients inspect each result.
+func writeBatch(w http.ResponseWriter, results []batchResult) {
+	succeeded := 0
+	for _, r := range results {
+		if r.ok() {
+			succeeded++
+		}
+	}
+	status := http.StatusOK
+	if succeeded < len(results) {
+		status = http.StatusMultiStatus
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+		"results":   results,
+	})
+}
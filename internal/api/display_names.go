@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// WithDisplayNames mounts the /pantry/display-names endpoints and merges the
+// household's names and emojis into pantry item lists.
+func WithDisplayNames(d *service.DisplayOverrides) Option {
+	return func(o *routerOptions) { o.displayNames = d }
+}
+
+// --- GET /pantry/display-names ---
+
+func handleListDisplayNames(overrides *service.DisplayOverrides) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		names, err := overrides.List(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to list display names", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{"display_names": names})
+	}
+}
+
+// --- PUT /pantry/display-names/:ingredient_id ---
+
+type displayNameRequest struct {
+	DisplayName string `json:"display_name"`
+	Emoji       string `json:"emoji"`
+}
+
+func handleSetDisplayName(overrides *service.DisplayOverrides) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ingredientID, err := uuid.Parse(chi.URLParam(r, "ingredient_id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid ingredient_id", http.StatusBadRequest)
+			return
+		}
+		var req displayNameRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		name, err := overrides.Set(r.Context(), ingredientID, req.DisplayName, req.Emoji)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidDisplayOverride) {
+				jsonError(r.Context(), w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			jsonError(r.Context(), w, "failed to save display name", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, name)
+	}
+}
+
+// --- DELETE /pantry/display-names/:ingredient_id ---
+
+func handleDeleteDisplayName(overrides *service.DisplayOverrides) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ingredientID, err := uuid.Parse(chi.URLParam(r, "ingredient_id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid ingredient_id", http.StatusBadRequest)
+			return
+		}
+		if err := overrides.Delete(r.Context(), ingredientID); err != nil {
+			if errors.Is(err, service.ErrDisplayOverrideNotFound) {
+				jsonError(r.Context(), w, err.Error(), http.StatusNotFound)
+				return
+			}
+			jsonError(r.Context(), w, "failed to delete display name", http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+func withDisplayNames(q *mocks.MockQuerier, _ *clients.DictionaryClient) Option {
+	return WithDisplayNames(service.NewDisplayOverrides(q))
+}
+
+func TestListPantry_MergesDisplayNames(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withDisplayNames)
+	named, plain := uuid.New(), uuid.New()
+	mockQ.EXPECT().ListPantryItems(mock.Anything).Return([]db.PantryItem{
+		{ID: uuid.New(), IngredientID: named, Quantity: 1, Unit: "bottle"},
+		{ID: uuid.New(), IngredientID: plain, Quantity: 2, Unit: "l"},
+	}, nil)
+	mockQ.EXPECT().ListIngredientDisplayOverrides(mock.Anything).Return([]db.IngredientDisplayOverride{{
+		IngredientID: named,
+		DisplayName:  sql.NullString{String: "Dad's hot sauce", Valid: true},
+		Emoji:        sql.NullString{String: "🌶️", Valid: true},
+	}}, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Items []map[string]any `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Items, 2)
+	assert.Equal(t, named.String(), resp.Items[0]["ingredient_id"], "canonical ID is unchanged")
+	assert.Equal(t, "Dad's hot sauce", resp.Items[0]["display_name"])
+	assert.Equal(t, "🌶️", resp.Items[0]["emoji"])
+	assert.NotContains(t, resp.Items[1], "display_name")
+}
+
+func TestSetDisplayName_Errors(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withDisplayNames)
+	id := uuid.New()
+	mockQ.EXPECT().DeleteIngredientDisplayOverride(mock.Anything, id).Return(0, nil)
+
+	for _, tc := range []struct {
+		method, target, body string
+		want                 int
+	}{
+		{http.MethodPut, "/pantry/display-names/not-a-uuid", `{"display_name":"x"}`, http.StatusBadRequest},
+		{http.MethodPut, "/pantry/display-names/" + id.String(), `{}`, http.StatusBadRequest},
+		{http.MethodPut, "/pantry/display-names/" + id.String(), `{"emoji":"hot"}`, http.StatusBadRequest},
+		{http.MethodDelete, "/pantry/display-names/" + id.String(), "", http.StatusNotFound},
+	} {
+		req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, tc.want, rec.Code, "%s %s %s", tc.method, tc.target, tc.body)
+	}
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+)
+
+// notModified sets the ETag header and, if the request's If-None-Match
+// already names etag, answers 304 and reports true. Comparison is weak, as
+// RFC 9110 requires for If-None-Match. Clients must revalidate every time.
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	return notModifiedCached(w, r, etag, "no-cache")
+}
+
+// notModifiedCached is notModified with the given Cache-Control, for
+// responses clients may reuse without asking.
+func notModifiedCached(w http.ResponseWriter, r *http.Request, etag, cacheControl string) bool {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", cacheControl)
+
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == want {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// --- GET /pantry/expiring ---
+
+type expiringItemResponse struct {
+	ID           uuid.UUID `json:"id"`
+	IngredientID uuid.UUID `json:"ingredient_id"`
+	DisplayName  string    `json:"display_name,omitempty"`
+	Emoji        string    `json:"emoji,omitempty"`
+	Quantity     float64   `json:"quantity"`
+	Unit         string    `json:"unit"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	Category     string    `json:"category"`
+	LeadDays     int       `json:"lead_days"`
+	Expired      bool      `json:"expired"`
+}
+
+func handleListExpiring(expiry *service.ExpiryService, overrides *service.DisplayOverrides) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, err := expiry.Expiring(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to list expiring items", http.StatusInternalServerError, err)
+			return
+		}
+		names := overrides.Names(r.Context())
+		resp := make([]expiringItemResponse, len(items))
+		for i, it := range items {
+			name := names[it.Item.IngredientID]
+			resp[i] = expiringItemResponse{
+				ID:           it.Item.ID,
+				IngredientID: it.Item.IngredientID,
+				DisplayName:  name.DisplayName,
+				Emoji:        name.Emoji,
+				Quantity:     it.Item.Quantity,
+				Unit:         it.Item.Unit,
+				ExpiresAt:    it.Item.ExpiresAt.Time,
+				Category:     it.Category,
+				LeadDays:     it.LeadDays,
+				Expired:      it.Expired,
+			}
+		}
+		jsonOK(w, map[string]any{"items": resp})
+	}
+}
+
+// --- GET /admin/expiry-lead-times ---
+
+func handleListLeadTimes(expiry *service.ExpiryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		leads, err := expiry.ListLeadTimes(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to list lead times", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{
+			"default_lead_days": expiry.DefaultLeadDays(),
+			"lead_times":        leads,
+		})
+	}
+}
+
+// --- PUT /admin/expiry-lead-times/:category ---
+
+type leadTimeRequest struct {
+	LeadDays *int `json:"lead_days"`
+}
+
+func handleSetLeadTime(expiry *service.ExpiryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req leadTimeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.LeadDays == nil {
+			jsonError(r.Context(), w, "lead_days is required", http.StatusBadRequest)
+			return
+		}
+
+		lead, err := expiry.SetLeadTime(r.Context(), chi.URLParam(r, "category"), *req.LeadDays)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidLeadTime) {
+				jsonError(r.Context(), w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			jsonError(r.Context(), w, "failed to save lead time", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, lead)
+	}
+}
+
+// --- DELETE /admin/expiry-lead-times/:category ---
+
+func handleDeleteLeadTime(expiry *service.ExpiryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := expiry.DeleteLeadTime(r.Context(), chi.URLParam(r, "category")); err != nil {
+			if errors.Is(err, service.ErrLeadTimeNotFound) {
+				jsonError(r.Context(), w, err.Error(), http.StatusNotFound)
+				return
+			}
+			jsonError(r.Context(), w, "failed to delete lead time", http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
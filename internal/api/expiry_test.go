@@ -0,0 +1,95 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// withExpiry mounts the expiry endpoints, backed by a Dictionary that
+// answers with dictHandler.
+func withExpiry(t *testing.T, dictHandler http.HandlerFunc) routerOption {
+	t.Helper()
+
+	dictServer := httptest.NewServer(dictHandler)
+	t.Cleanup(dictServer.Close)
+	dict := clients.NewDictionaryClient(dictServer.URL, dictServer.Client())
+	return func(q *mocks.MockQuerier, _ *clients.DictionaryClient) Option {
+		return WithExpiry(service.NewExpiryService(q, dict, service.DefaultExpiryLeadDays))
+	}
+}
+
+func TestGetExpiring(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withExpiry(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"` + uuid.NewString() + `","name":"milk","category":"dairy"}`)) //nolint:errcheck
+	}))
+
+	item := db.PantryItem{
+		ID:           uuid.New(),
+		IngredientID: uuid.New(),
+		Quantity:     1,
+		Unit:         "l",
+		ExpiresAt:    sql.NullTime{Time: time.Now().Add(24 * time.Hour), Valid: true},
+	}
+	mockQ.EXPECT().ListExpiryLeadTimes(mock.Anything).Return([]db.ExpiryLeadTime{{Category: "dairy", LeadDays: 3}}, nil)
+	mockQ.EXPECT().ListPantryItemsExpiringBefore(mock.Anything, mock.Anything).Return([]db.PantryItem{item}, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry/expiring", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Items []map[string]any `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, item.ID.String(), resp.Items[0]["id"])
+	assert.Equal(t, "dairy", resp.Items[0]["category"])
+	assert.InDelta(t, 3, resp.Items[0]["lead_days"], 0)
+	assert.Equal(t, false, resp.Items[0]["expired"])
+}
+
+func TestLeadTimeRoutes(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withExpiry(t, func(w http.ResponseWriter, r *http.Request) {}))
+
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/admin/expiry-lead-times/produce", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusBadRequest, put(`{}`).Code)
+	assert.Equal(t, http.StatusBadRequest, put(`{"lead_days":-2}`).Code)
+
+	mockQ.EXPECT().UpsertExpiryLeadTime(mock.Anything, db.UpsertExpiryLeadTimeParams{Category: "produce", LeadDays: 1}).
+		Return(db.ExpiryLeadTime{Category: "produce", LeadDays: 1}, nil)
+	rec := put(`{"lead_days":1}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"lead_days":1`)
+
+	mockQ.EXPECT().DeleteExpiryLeadTime(mock.Anything, "rice").Return(0, nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/expiry-lead-times/rice", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,871 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/notify"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+	"github.com/mwhite7112/woodpantry-pantry/internal/slo"
+	"github.com/mwhite7112/woodpantry-pantry/internal/testutil/golden"
+)
+
+// Fixtures shared by the golden cases. IDs and times are normalized in the
+// snapshots, so only their relationships matter.
+var (
+	goldenTime       = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	goldenItemID     = uuid.MustParse("00000000-0000-4000-8000-000000000001")
+	goldenIngredient = uuid.MustParse("00000000-0000-4000-8000-000000000002")
+	goldenJobID      = uuid.MustParse("00000000-0000-4000-8000-000000000003")
+	goldenStagedID   = uuid.MustParse("00000000-0000-4000-8000-000000000004")
+	goldenLotID      = uuid.MustParse("00000000-0000-4000-8000-000000000005")
+
+	goldenItem = db.PantryItem{
+		ID:           goldenItemID,
+		IngredientID: goldenIngredient,
+		Quantity:     1.5,
+		Unit:         "kg",
+		ExpiresAt:    sql.NullTime{Time: goldenTime.AddDate(0, 0, 2), Valid: true},
+		AddedAt:      goldenTime,
+		UpdatedAt:    goldenTime,
+	}
+	goldenJob = db.IngestionJob{
+		ID:        goldenJobID,
+		Type:      "text_blob",
+		RawInput:  "1.5 kg flour",
+		Status:    "staged",
+		Source:    "api",
+		Priority:  "interactive",
+		CreatedAt: goldenTime,
+	}
+	goldenStaged = db.StagedItem{
+		ID:           goldenStagedID,
+		JobID:        goldenJobID,
+		IngredientID: uuid.NullUUID{UUID: goldenIngredient, Valid: true},
+		RawText:      "1.5 kg flour",
+		Quantity:     1.5,
+		Unit:         "kg",
+		Confidence:   0.95,
+	}
+	goldenDisplayName = db.IngredientDisplayOverride{
+		IngredientID: goldenIngredient,
+		DisplayName:  sql.NullString{String: "Grandma's flour", Valid: true},
+		Emoji:        sql.NullString{String: "🌾", Valid: true},
+		UpdatedAt:    goldenTime,
+	}
+	goldenReviewRule = db.ReviewRule{
+		ID:          uuid.MustParse("00000000-0000-4000-8000-000000000006"),
+		Action:      "review",
+		Category:    sql.NullString{String: "baking", Valid: true},
+		Description: "check baking goods",
+		CreatedAt:   goldenTime,
+	}
+	goldenSubscription = db.WebhookSubscription{
+		ID:         uuid.MustParse("00000000-0000-4000-8000-000000000007"),
+		Url:        "https://example.com/pantry",
+		EventTypes: []string{"pantry.updated"},
+		Secret:     "s3cret",
+		Active:     true,
+		CreatedAt:  goldenTime,
+		UpdatedAt:  goldenTime,
+	}
+)
+
+// goldenExtractor extracts the same single item from any input.
+type goldenExtractor struct{}
+
+func (goldenExtractor) Extract(_ context.Context, _ string) (*service.ExtractionResponse, error) {
+	return &service.ExtractionResponse{Items: []service.ExtractedItem{
+		{RawText: "1.5 kg flour", Name: "flour", Quantity: 1.5, Unit: "kg", Confidence: 0.95},
+	}}, nil
+}
+
+// setupGoldenRouter mounts every optional route group against one mock
+// querier and a Dictionary that knows a single ingredient.
+func setupGoldenRouter(t *testing.T) (*mocks.MockQuerier, http.Handler) {
+	t.Helper()
+
+	mockQ := mocks.NewMockQuerier(t)
+	ingredient := clients.Ingredient{ID: goldenIngredient, Name: "flour", Category: "baking"}
+	dictServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/ingredients/resolve" {
+			json.NewEncoder(w).Encode(clients.ResolveResult{Ingredient: ingredient, Confidence: 1}) //nolint:errcheck
+			return
+		}
+		if r.URL.Path == "/ingredients" {
+			json.NewEncoder(w).Encode([]clients.Ingredient{ingredient}) //nolint:errcheck
+			return
+		}
+		json.NewEncoder(w).Encode(ingredient) //nolint:errcheck
+	}))
+	t.Cleanup(dictServer.Close)
+	dict := clients.NewDictionaryClient(dictServer.URL, dictServer.Client())
+
+	notifications := service.NewNotificationService(mockQ, dict, map[string]service.NotificationSender{
+		"webhook": notify.NewWebhookSender(nil),
+	})
+	pantry := service.NewPantryService(mockQ)
+	router := NewRouter(
+		pantry,
+		service.NewIngestService(mockQ, &stubResolver{}, goldenExtractor{}),
+		dict,
+		WithMaintenance(service.NewMaintenanceService(mockQ, dict)),
+		WithWatchlist(service.NewWatchlistService(mockQ)),
+		WithExpiry(service.NewExpiryService(mockQ, dict, service.DefaultExpiryLeadDays)),
+		WithNotifications(notifications),
+		WithActivity(service.NewActivityLog(mockQ, dict)),
+		WithUnitDefaults(service.NewUnitDefaults(mockQ)),
+		WithDisplayNames(service.NewDisplayOverrides(mockQ)),
+		WithReviewRules(service.NewReviewRules(mockQ)),
+		WithValuation(service.NewValuationService(mockQ, dict)),
+		WithLLMBudget(service.NewLLMBudget(mockQ, 1000)),
+		WithReadOnly(service.NewReadOnlyMode(false, "")),
+		WithWebhookAudit(service.NewWebhookAudit(mockQ)),
+		WithWebhookSubscriptions(service.NewWebhookService(mockQ, nil)),
+		WithReconciler(service.NewReconciler(mockQ, pantry)),
+		WithTaxonomy(service.NewTaxonomy(dict, time.Hour)),
+		WithStale(service.NewStaleService(mockQ, dict, map[string]int{"baking": 90})),
+		WithReadiness(ReadinessCheck{Name: "postgres", Check: func(context.Context) error { return nil }}),
+		WithSLO(slo.New(slo.Objective{Success: 0.99, Latency: time.Minute}, nil, time.Hour)),
+	)
+	return mockQ, router
+}
+
+// TestGolden snapshots the success response of every route. When a response
+// shape changes on purpose, run `go test ./internal/api -run TestGolden -update`
+// and review the diff under testdata/golden.
+func TestGolden(t *testing.T) {
+	t.Parallel()
+
+	item := "/pantry/items/" + goldenItemID.String()
+	job := "/pantry/ingest/" + goldenJobID.String()
+	subscription := "/webhooks/" + goldenSubscription.ID.String()
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		accept string
+		scrub  []string // response fields measured at run time
+		setup  func(q *mocks.MockQuerier)
+	}{
+		{name: "healthz", method: http.MethodGet, target: "/healthz"},
+		{name: "readyz", method: http.MethodGet, target: "/readyz"},
+		{name: "metrics", method: http.MethodGet, target: "/metrics"},
+		{name: "get taxonomy", method: http.MethodGet, target: "/pantry/taxonomy"},
+		{
+			name: "metrics openmetrics", method: http.MethodGet, target: "/metrics",
+			accept: "application/openmetrics-text;version=1.0.0,text/plain;version=0.0.4;q=0.5",
+		},
+		{
+			name: "list pantry", method: http.MethodGet, target: "/pantry",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListPantryItems(mock.Anything).Return([]db.PantryItem{goldenItem}, nil)
+				q.EXPECT().ListIngredientDisplayOverrides(mock.Anything).
+					Return([]db.IngredientDisplayOverride{goldenDisplayName}, nil)
+			},
+		},
+		{
+			name: "list pantry page", method: http.MethodGet, target: "/pantry?limit=1",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListPantryItemsPage(mock.Anything, mock.Anything).
+					Return([]db.PantryItem{goldenItem, goldenItem}, nil)
+				q.EXPECT().CountPantryItems(mock.Anything).Return(int64(7), nil)
+				q.EXPECT().ListIngredientDisplayOverrides(mock.Anything).Return(nil, nil)
+			},
+		},
+		{
+			name: "list pantry csv", method: http.MethodGet, target: "/pantry", accept: "text/csv",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListPantryItems(mock.Anything).Return([]db.PantryItem{goldenItem}, nil)
+			},
+		},
+		{
+			name: "list pantry imperial", method: http.MethodGet, target: "/pantry?units=imperial",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListPantryItems(mock.Anything).Return([]db.PantryItem{goldenItem}, nil)
+				q.EXPECT().ListIngredientDisplayOverrides(mock.Anything).Return(nil, nil)
+			},
+		},
+		{
+			name: "list pantry delta", method: http.MethodGet, target: "/pantry?updated_since=2026-02-01T00:00:00Z",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().PantryChangesCutoff(mock.Anything).Return(goldenTime, nil)
+				q.EXPECT().ListPantryItemsUpdatedSince(mock.Anything, mock.Anything).
+					Return([]db.PantryItem{goldenItem}, nil)
+				q.EXPECT().ListPantryTombstonesSince(mock.Anything, mock.Anything).
+					Return([]db.PantryItemTombstone{{
+						ItemID: uuid.New(), IngredientID: uuid.New(), DeletedAt: goldenTime,
+					}}, nil)
+				q.EXPECT().ListIngredientDisplayOverrides(mock.Anything).Return(nil, nil)
+			},
+		},
+		{
+			name: "get item by ingredient", method: http.MethodGet,
+			target: "/pantry/items?ingredient_id=" + goldenIngredient.String(),
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().GetPantryItemByIngredient(mock.Anything, goldenIngredient).Return(goldenItem, nil)
+			},
+		},
+		{
+			name: "add item", method: http.MethodPost, target: "/pantry/items",
+			body: `{"ingredient_id":"` + goldenIngredient.String() + `","quantity":1.5,"unit":"kg"}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().UpsertPantryItem(mock.Anything, mock.Anything).Return(goldenItem, nil)
+			},
+		},
+		{
+			name: "add items batch", method: http.MethodPost, target: "/pantry/items/batch",
+			body: `{"items":[{"ingredient_id":"` + goldenIngredient.String() + `","quantity":1.5,"unit":"kg"},` +
+				`{"name":"flour","quantity":0,"unit":"kg"}]}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().UpsertPantryItem(mock.Anything, mock.Anything).Return(goldenItem, nil)
+			},
+		},
+		{
+			name: "lookup items", method: http.MethodPost, target: "/pantry/items/lookup",
+			body: `{"ingredient_ids":["` + goldenIngredient.String() + `","` + goldenLotID.String() + `"]}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListPantryItemsByIngredients(mock.Anything, mock.Anything).
+					Return([]db.PantryItem{goldenItem}, nil)
+				q.EXPECT().ListIngredientDisplayOverrides(mock.Anything).Return(nil, nil)
+			},
+		},
+		{
+			name: "preview item", method: http.MethodPost, target: "/pantry/items/preview",
+			body: `{"ingredient_id":"` + goldenIngredient.String() + `","quantity":500,"unit":"g","on_conflict":"add"}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().GetPantryItemByIngredient(mock.Anything, goldenIngredient).Return(goldenItem, nil)
+			},
+		},
+		{
+			name: "update item", method: http.MethodPatch, target: item, body: `{"quantity":2}`,
+			setup: func(q *mocks.MockQuerier) {
+				updated := goldenItem
+				updated.Quantity = 2
+				q.EXPECT().UpdatePantryItem(mock.Anything, mock.Anything).Return(updated, nil)
+			},
+		},
+		{
+			name: "consume item", method: http.MethodPost, target: item + "/consume", body: `{"quantity":0.5}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ConsumePantryItem(mock.Anything, mock.Anything).Return(db.ConsumePantryItemRow{
+					ID:               goldenItem.ID,
+					IngredientID:     goldenItem.IngredientID,
+					Quantity:         goldenItem.Quantity - 0.5,
+					Unit:             goldenItem.Unit,
+					ExpiresAt:        goldenItem.ExpiresAt,
+					AddedAt:          goldenItem.AddedAt,
+					UpdatedAt:        goldenItem.UpdatedAt,
+					PreviousQuantity: goldenItem.Quantity,
+				}, nil)
+			},
+		},
+		{
+			name: "delete item", method: http.MethodDelete, target: item,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().DeletePantryItem(mock.Anything, goldenItemID).Return(nil)
+			},
+		},
+		{
+			name: "list lots", method: http.MethodGet, target: item + "/lots",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().GetPantryItem(mock.Anything, goldenItemID).Return(goldenItem, nil)
+				q.EXPECT().ListPantryLotsByItem(mock.Anything, goldenItemID).Return([]db.PantryLot{{
+					ID: goldenLotID, PantryItemID: goldenItemID, Quantity: 1.5, Unit: "kg",
+					SourceJobID: uuid.NullUUID{UUID: goldenJobID, Valid: true}, AddedAt: goldenTime,
+				}}, nil)
+			},
+		},
+		{
+			name: "list lot history", method: http.MethodGet, target: item + "/lots/history",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().GetPantryItem(mock.Anything, goldenItemID).Return(goldenItem, nil)
+				q.EXPECT().ListPantryLotEventsByItem(mock.Anything, goldenItemID).Return([]db.PantryLotEvent{{
+					ID: uuid.New(), PantryItemID: goldenItemID, LotID: goldenLotID, Kind: "added",
+					Quantity: 1.5, Unit: "kg", OccurredAt: goldenTime,
+				}}, nil)
+			},
+		},
+		{
+			name: "create ingest job", method: http.MethodPost, target: "/pantry/ingest",
+			body: `{"content":"1.5 kg flour"}`,
+			setup: func(q *mocks.MockQuerier) {
+				pending := goldenJob
+				pending.Status = "pending"
+				q.EXPECT().CreateIngestionJob(mock.Anything, mock.Anything).Return(pending, nil)
+				q.On("TransitionIngestionJobStatus", mock.Anything, mock.Anything).
+					Return(db.IngestionJob{}, nil).Maybe()
+				q.On("CreateStagedItem", mock.Anything, mock.Anything).Return(db.StagedItem{}, nil).Maybe()
+				q.On("UpsertIngestionJobTimings", mock.Anything, mock.Anything).Return(nil).Maybe()
+			},
+		},
+		{
+			name: "list ingest jobs", method: http.MethodGet, target: "/pantry/ingest",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListIngestionJobs(mock.Anything, mock.Anything).Return([]db.IngestionJob{goldenJob}, nil)
+			},
+		},
+		{
+			name: "ingest stats", method: http.MethodGet, target: "/pantry/ingest/stats",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().IngestSourceStats(mock.Anything, mock.Anything).Return([]db.IngestSourceStatsRow{{
+					Source: "api", Jobs: 4, ConfirmedJobs: 3, FailedJobs: 1, StagedItems: 10, NeedsReviewItems: 2,
+				}}, nil)
+			},
+		},
+		{
+			name: "get ingest job", method: http.MethodGet, target: job,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().GetIngestionJob(mock.Anything, goldenJobID).Return(goldenJob, nil)
+				q.EXPECT().StagedItemsFingerprint(mock.Anything, goldenJobID).
+					Return(db.StagedItemsFingerprintRow{ItemCount: 1, Checksum: "0123456789abcdef0123"}, nil)
+				q.EXPECT().ListStagedItemsByJob(mock.Anything, goldenJobID).Return([]db.StagedItem{goldenStaged}, nil)
+				q.EXPECT().GetIngestionJobTimings(mock.Anything, goldenJobID).Return(db.IngestionJobTiming{
+					JobID: goldenJobID, Attempts: 1, ExtractionMs: 800, ResolutionMs: 40, ResolutionMaxMs: 40,
+					ResolvedItems: 1, TotalMs: 860, RecordedAt: goldenTime,
+				}, nil)
+			},
+		},
+		{
+			name: "reextract item", method: http.MethodPost,
+			target: job + "/items/" + goldenStagedID.String() + "/reextract",
+			body:   `{"hint":"baking"}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().GetIngestionJob(mock.Anything, goldenJobID).Return(goldenJob, nil)
+				q.EXPECT().GetStagedItem(mock.Anything, goldenStagedID).Return(goldenStaged, nil)
+				q.EXPECT().UpdateStagedItemExtraction(mock.Anything, mock.Anything).Return(goldenStaged, nil)
+			},
+		},
+		{
+			name: "edit staged item", method: http.MethodPatch,
+			target: job + "/items/" + goldenStagedID.String(),
+			body:   `{"quantity":2,"unit":"kilograms"}`,
+			setup: func(q *mocks.MockQuerier) {
+				edited := goldenStaged
+				edited.Quantity = 2
+				q.EXPECT().GetIngestionJob(mock.Anything, goldenJobID).Return(goldenJob, nil)
+				q.EXPECT().GetStagedItem(mock.Anything, goldenStagedID).Return(goldenStaged, nil)
+				q.EXPECT().UpdateStagedItem(mock.Anything, db.UpdateStagedItemParams{
+					ID: goldenStagedID, IngredientID: goldenStaged.IngredientID, Quantity: 2, Unit: "kg",
+				}).Return(edited, nil)
+			},
+		},
+		{
+			name: "delete staged item", method: http.MethodDelete,
+			target: job + "/items/" + goldenStagedID.String(),
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().GetIngestionJob(mock.Anything, goldenJobID).Return(goldenJob, nil)
+				q.EXPECT().GetStagedItem(mock.Anything, goldenStagedID).Return(goldenStaged, nil)
+				q.EXPECT().DeleteStagedItem(mock.Anything, db.DeleteStagedItemParams{
+					ID: goldenStagedID, JobID: goldenJobID,
+				}).Return(1, nil)
+			},
+		},
+		{
+			name: "confirm job", method: http.MethodPost, target: job + "/confirm",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().GetIngestionJob(mock.Anything, goldenJobID).Return(goldenJob, nil)
+				q.EXPECT().ListStagedItemsByJob(mock.Anything, goldenJobID).Return([]db.StagedItem{goldenStaged}, nil)
+				q.EXPECT().ListPantryItemsByIngredients(mock.Anything, mock.Anything).
+					Return([]db.PantryItem{goldenItem}, nil)
+				q.EXPECT().UpsertPantryItems(mock.Anything, mock.Anything).Return([]db.PantryItem{goldenItem}, nil)
+				q.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(goldenJob, nil)
+			},
+		},
+		{
+			name: "cancel job", method: http.MethodPost, target: job + "/cancel",
+			setup: func(q *mocks.MockQuerier) {
+				cancelled := goldenJob
+				cancelled.Status = "cancelled"
+				q.EXPECT().CancelIngestionJob(mock.Anything, goldenJobID).Return(cancelled, nil)
+			},
+		},
+		{
+			name: "retry job", method: http.MethodPost, target: job + "/retry",
+			setup: func(q *mocks.MockQuerier) {
+				pending := goldenJob
+				pending.Status = "pending"
+				q.EXPECT().TransitionIngestionJobStatus(mock.Anything, db.TransitionIngestionJobStatusParams{
+					ToStatus: "pending", ID: goldenJobID, FromStatus: "failed",
+				}).Return(pending, nil)
+				q.EXPECT().DeleteStagedItemsByJob(mock.Anything, goldenJobID).Return(nil)
+				q.On("TransitionIngestionJobStatus", mock.Anything, mock.Anything).
+					Return(db.IngestionJob{}, nil).Maybe()
+				q.On("CreateStagedItem", mock.Anything, mock.Anything).Return(db.StagedItem{}, nil).Maybe()
+				q.On("UpsertIngestionJobTimings", mock.Anything, mock.Anything).Return(nil).Maybe()
+			},
+		},
+		{
+			name: "reject job", method: http.MethodPost, target: job + "/reject",
+			setup: func(q *mocks.MockQuerier) {
+				rejected := goldenJob
+				rejected.Status = "rejected"
+				q.EXPECT().TransitionIngestionJobStatus(mock.Anything, db.TransitionIngestionJobStatusParams{
+					ToStatus: "rejected", ID: goldenJobID, FromStatus: "staged",
+				}).Return(rejected, nil)
+			},
+		},
+		{
+			name: "reset pantry", method: http.MethodDelete, target: "/pantry/reset?confirm=true",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().DeleteAllPantryItems(mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "list watchlist", method: http.MethodGet, target: "/pantry/watchlist",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListWatchlist(mock.Anything).Return([]db.Watchlist{{
+					IngredientID: goldenIngredient,
+					MinQuantity:  sql.NullFloat64{Float64: 2, Valid: true},
+					Unit:         sql.NullString{String: "kg", Valid: true},
+					CreatedAt:    goldenTime,
+					UpdatedAt:    goldenTime,
+				}}, nil)
+			},
+		},
+		{
+			name: "watch ingredient", method: http.MethodPost, target: "/pantry/watchlist",
+			body: `{"ingredient_id":"` + goldenIngredient.String() + `","min_quantity":2,"unit":"kg"}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().UpsertWatchlistEntry(mock.Anything, mock.Anything).Return(db.Watchlist{
+					IngredientID: goldenIngredient,
+					MinQuantity:  sql.NullFloat64{Float64: 2, Valid: true},
+					Unit:         sql.NullString{String: "kg", Valid: true},
+					CreatedAt:    goldenTime,
+					UpdatedAt:    goldenTime,
+				}, nil)
+			},
+		},
+		{
+			name: "list missing watched", method: http.MethodGet, target: "/pantry/watchlist/missing",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListMissingWatchedIngredients(mock.Anything).Return([]db.ListMissingWatchedIngredientsRow{{
+					IngredientID:    goldenIngredient,
+					MinQuantity:     sql.NullFloat64{Float64: 2, Valid: true},
+					Unit:            sql.NullString{String: "kg", Valid: true},
+					PantryItemID:    uuid.NullUUID{UUID: goldenItemID, Valid: true},
+					CurrentQuantity: sql.NullFloat64{Float64: 1.5, Valid: true},
+					CurrentUnit:     sql.NullString{String: "kg", Valid: true},
+				}}, nil)
+			},
+		},
+		{
+			name: "unwatch ingredient", method: http.MethodDelete,
+			target: "/pantry/watchlist/" + goldenIngredient.String(),
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().DeleteWatchlistEntry(mock.Anything, goldenIngredient).Return(1, nil)
+			},
+		},
+		{
+			name: "list stale", method: http.MethodGet, target: "/pantry/stale",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListStaleItems(mock.Anything).Return([]db.ListStaleItemsRow{{
+					ID:            goldenItem.ID,
+					IngredientID:  goldenItem.IngredientID,
+					Quantity:      goldenItem.Quantity,
+					Unit:          goldenItem.Unit,
+					ExpiresAt:     goldenItem.ExpiresAt,
+					UpdatedAt:     goldenItem.UpdatedAt,
+					Category:      "baking",
+					ShelfLifeDays: 90,
+					FlaggedAt:     goldenTime.AddDate(0, 3, 0),
+				}}, nil)
+			},
+		},
+		{
+			name: "list expiring", method: http.MethodGet, target: "/pantry/expiring",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListExpiryLeadTimes(mock.Anything).Return([]db.ExpiryLeadTime{
+					{Category: "baking", LeadDays: 7, UpdatedAt: goldenTime},
+				}, nil)
+				q.EXPECT().ListPantryItemsExpiringBefore(mock.Anything, mock.Anything).
+					Return([]db.PantryItem{goldenItem}, nil)
+				q.EXPECT().ListIngredientDisplayOverrides(mock.Anything).
+					Return([]db.IngredientDisplayOverride{goldenDisplayName}, nil)
+			},
+		},
+		{
+			name: "list lead times", method: http.MethodGet, target: "/admin/expiry-lead-times",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListExpiryLeadTimes(mock.Anything).Return([]db.ExpiryLeadTime{
+					{Category: "baking", LeadDays: 7, UpdatedAt: goldenTime},
+				}, nil)
+			},
+		},
+		{
+			name: "set lead time", method: http.MethodPut, target: "/admin/expiry-lead-times/baking",
+			body: `{"lead_days":7}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().UpsertExpiryLeadTime(mock.Anything, mock.Anything).
+					Return(db.ExpiryLeadTime{Category: "baking", LeadDays: 7, UpdatedAt: goldenTime}, nil)
+			},
+		},
+		{
+			name: "delete lead time", method: http.MethodDelete, target: "/admin/expiry-lead-times/baking",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().DeleteExpiryLeadTime(mock.Anything, "baking").Return(1, nil)
+			},
+		},
+		{
+			name: "list notification preferences", method: http.MethodGet, target: "/pantry/notification-preferences",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListNotificationPreferences(mock.Anything).Return([]db.NotificationPreference{{
+					Kind: "expiring", Channel: "webhook", Target: "https://example.com/hook", UpdatedAt: goldenTime,
+				}}, nil)
+			},
+		},
+		{
+			name: "set notification preference", method: http.MethodPut,
+			target: "/pantry/notification-preferences/expiring",
+			body:   `{"channel":"webhook","target":"https://example.com/hook","secret":"s3cret"}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().UpsertNotificationPreference(mock.Anything, mock.Anything).Return(db.NotificationPreference{
+					Kind: "expiring", Channel: "webhook", Target: "https://example.com/hook", Secret: "s3cret",
+					UpdatedAt: goldenTime,
+				}, nil)
+			},
+		},
+		{
+			name: "list notifications", method: http.MethodGet, target: "/admin/notifications",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListRecentNotifications(mock.Anything, mock.Anything).Return([]db.Notification{{
+					ID: 1, Kind: "expiring", Channel: "webhook", Target: "https://example.com/hook",
+					Subject: "1 pantry item is expiring soon", Body: "- flour (1.5 kg): expires 2026-03-03\n",
+					Attempts: 1, SentAt: sql.NullTime{Time: goldenTime, Valid: true}, CreatedAt: goldenTime,
+				}}, nil)
+			},
+		},
+		{
+			name: "list display names", method: http.MethodGet, target: "/pantry/display-names",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListIngredientDisplayOverrides(mock.Anything).
+					Return([]db.IngredientDisplayOverride{goldenDisplayName}, nil)
+			},
+		},
+		{
+			name: "set display name", method: http.MethodPut,
+			target: "/pantry/display-names/" + goldenIngredient.String(),
+			body:   `{"display_name":"Grandma's flour","emoji":"🌾"}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().UpsertIngredientDisplayOverride(mock.Anything, mock.Anything).Return(goldenDisplayName, nil)
+			},
+		},
+		{
+			name: "delete display name", method: http.MethodDelete,
+			target: "/pantry/display-names/" + goldenIngredient.String(),
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().DeleteIngredientDisplayOverride(mock.Anything, goldenIngredient).Return(1, nil)
+			},
+		},
+		{
+			name: "list category units", method: http.MethodGet, target: "/admin/category-units",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListCategoryDefaultUnits(mock.Anything).Return([]db.CategoryDefaultUnit{
+					{Category: "baking", Unit: "g", UpdatedAt: goldenTime},
+				}, nil)
+			},
+		},
+		{
+			name: "set category unit", method: http.MethodPut, target: "/admin/category-units/baking",
+			body: `{"unit":"g"}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().UpsertCategoryDefaultUnit(mock.Anything, mock.Anything).
+					Return(db.CategoryDefaultUnit{Category: "baking", Unit: "g", UpdatedAt: goldenTime}, nil)
+			},
+		},
+		{
+			name: "delete category unit", method: http.MethodDelete, target: "/admin/category-units/baking",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().DeleteCategoryDefaultUnit(mock.Anything, "baking").Return(1, nil)
+			},
+		},
+		{
+			name: "list review rules", method: http.MethodGet, target: "/admin/review-rules",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListReviewRules(mock.Anything).Return([]db.ReviewRule{goldenReviewRule}, nil)
+			},
+		},
+		{
+			name: "create review rule", method: http.MethodPost, target: "/admin/review-rules",
+			body: `{"action":"review","category":"baking","description":"check baking goods"}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().CreateReviewRule(mock.Anything, mock.Anything).Return(goldenReviewRule, nil)
+			},
+		},
+		{
+			name: "delete review rule", method: http.MethodDelete,
+			target: "/admin/review-rules/" + goldenReviewRule.ID.String(),
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().DeleteReviewRule(mock.Anything, goldenReviewRule.ID).Return(1, nil)
+			},
+		},
+		{
+			name: "pantry value", method: http.MethodGet, target: "/pantry/value",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListPantryItems(mock.Anything).Return([]db.PantryItem{goldenItem}, nil)
+				q.EXPECT().ListIngredientPrices(mock.Anything).Return([]db.IngredientPrice{}, nil)
+				q.EXPECT().ListCategoryPrices(mock.Anything).Return([]db.CategoryPrice{
+					{Category: "baking", Price: 1.5, Unit: "kg", UpdatedAt: goldenTime},
+				}, nil)
+			},
+		},
+		{
+			name: "list prices", method: http.MethodGet, target: "/admin/prices",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListIngredientPrices(mock.Anything).Return([]db.IngredientPrice{
+					{IngredientID: goldenIngredient, Price: 2, Unit: "kg", UpdatedAt: goldenTime},
+				}, nil)
+				q.EXPECT().ListCategoryPrices(mock.Anything).Return([]db.CategoryPrice{
+					{Category: "baking", Price: 1.5, Unit: "kg", UpdatedAt: goldenTime},
+				}, nil)
+			},
+		},
+		{
+			name: "set ingredient price", method: http.MethodPut,
+			target: "/admin/prices/ingredients/" + goldenIngredient.String(), body: `{"price":2,"unit":"kg"}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().UpsertIngredientPrice(mock.Anything, mock.Anything).Return(db.IngredientPrice{
+					IngredientID: goldenIngredient, Price: 2, Unit: "kg", UpdatedAt: goldenTime,
+				}, nil)
+			},
+		},
+		{
+			name: "delete ingredient price", method: http.MethodDelete,
+			target: "/admin/prices/ingredients/" + goldenIngredient.String(),
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().DeleteIngredientPrice(mock.Anything, goldenIngredient).Return(1, nil)
+			},
+		},
+		{
+			name: "set category price", method: http.MethodPut, target: "/admin/prices/categories/baking",
+			body: `{"price":1.5,"unit":"kg"}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().UpsertCategoryPrice(mock.Anything, mock.Anything).Return(db.CategoryPrice{
+					Category: "baking", Price: 1.5, Unit: "kg", UpdatedAt: goldenTime,
+				}, nil)
+			},
+		},
+		{
+			name: "delete category price", method: http.MethodDelete, target: "/admin/prices/categories/baking",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().DeleteCategoryPrice(mock.Anything, "baking").Return(1, nil)
+			},
+		},
+		{
+			name: "list activity", method: http.MethodGet, target: "/pantry/activity",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListPantryActivity(mock.Anything, mock.Anything).Return([]db.PantryActivity{{
+					ID: 7, Kind: "added", IngredientID: uuid.NullUUID{UUID: goldenIngredient, Valid: true},
+					Quantity: sql.NullFloat64{Float64: 1.5, Valid: true},
+					Unit:     sql.NullString{String: "kg", Valid: true},
+					Source:   sql.NullString{String: "manual", Valid: true}, ItemCount: 1, OccurredAt: goldenTime,
+				}}, nil)
+			},
+		},
+		{name: "get slo", method: http.MethodGet, target: "/admin/slo"},
+		{name: "list event schemas", method: http.MethodGet, target: "/admin/event-schemas"},
+		{name: "list workers", method: http.MethodGet, target: "/admin/workers"},
+		{name: "llm health", method: http.MethodGet, target: "/admin/llm-health"},
+		{
+			name: "transition job", method: http.MethodPost,
+			target: "/admin/ingest/" + goldenJobID.String() + "/transition",
+			body:   `{"to":"failed","reason":"worker crashed"}`,
+			setup: func(q *mocks.MockQuerier) {
+				processing := goldenJob
+				processing.Status = "processing"
+				failed := goldenJob
+				failed.Status = "failed"
+				q.EXPECT().GetIngestionJob(mock.Anything, goldenJobID).Return(processing, nil)
+				q.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(failed, nil)
+			},
+		},
+		{
+			name: "shadow report", method: http.MethodGet, target: "/admin/shadow-extractions/report",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListShadowExtractions(mock.Anything, mock.Anything).Return([]db.ShadowExtraction{{
+					JobID:        goldenJobID,
+					Candidate:    "candidate-model",
+					PrimaryItems: json.RawMessage(`[{"name":"flour","quantity":1.5,"unit":"kg"}]`),
+					ShadowItems:  json.RawMessage(`[{"name":"flour","quantity":1.5,"unit":"kg"}]`),
+					TokensUsed:   120,
+					DurationMs:   900,
+					CreatedAt:    goldenTime,
+				}}, nil)
+			},
+		},
+		{
+			name: "vacuum hints", method: http.MethodGet, target: "/admin/maintenance/vacuum-hints",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListTableStats(mock.Anything).Return([]db.ListTableStatsRow{{
+					TableName: "pantry_items", NLiveTup: 100, NDeadTup: 40,
+				}}, nil)
+			},
+		},
+		{
+			name: "analyze", method: http.MethodPost, target: "/admin/maintenance/analyze",
+			scrub: []string{"duration_ms"},
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().AnalyzeTables(mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "reindex", method: http.MethodPost, target: "/admin/maintenance/reindex",
+			scrub: []string{"duration_ms"},
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ReindexPantryItems(mock.Anything).Return(nil)
+				q.EXPECT().ReindexIngestionJobs(mock.Anything).Return(nil)
+				q.EXPECT().ReindexStagedItems(mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "cleanup orphans", method: http.MethodPost, target: "/admin/maintenance/cleanup-orphans",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().DeleteOrphanedStagedItems(mock.Anything).Return(2, nil)
+				q.EXPECT().DeletePantryTombstonesBefore(mock.Anything, mock.Anything).Return(1, nil)
+			},
+		},
+		{
+			name: "integrity check", method: http.MethodPost, target: "/admin/maintenance/integrity-check",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListPantryIngredientIDs(mock.Anything).Return([]uuid.UUID{goldenIngredient}, nil)
+			},
+		},
+		{
+			name: "get llm budget", method: http.MethodGet, target: "/admin/llm-budget",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().GetLLMUsage(mock.Anything, mock.Anything).
+					Return(db.LlmUsage{Month: goldenTime, TokensUsed: 250, UpdatedAt: goldenTime}, nil)
+			},
+		},
+		{
+			name: "reset llm budget", method: http.MethodPost, target: "/admin/llm-budget/reset",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ResetLLMUsage(mock.Anything, mock.Anything).Return(nil)
+				q.EXPECT().GetLLMUsage(mock.Anything, mock.Anything).
+					Return(db.LlmUsage{Month: goldenTime, UpdatedAt: goldenTime}, nil)
+			},
+		},
+		{name: "get read-only", method: http.MethodGet, target: "/admin/read-only"},
+		{
+			name: "set read-only", method: http.MethodPut, target: "/admin/read-only",
+			body: `{"enabled":true,"message":"restoring from backup"}`, scrub: []string{"since"},
+		},
+		{
+			name: "list webhook deliveries", method: http.MethodGet, target: "/admin/webhooks?source=hook",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListWebhookDeliveries(mock.Anything, mock.Anything).Return([]db.WebhookDelivery{
+					{
+						ID: 2, Source: "hook", Endpoint: "https://example.com/hook", Signed: true,
+						StatusCode: sql.NullInt32{Int32: 502, Valid: true}, LatencyMs: 84,
+						Error: sql.NullString{String: "webhook status 502", Valid: true}, AttemptedAt: goldenTime,
+					},
+					{
+						ID: 1, Source: "hook", Endpoint: "https://example.com/hook", Signed: true,
+						StatusCode: sql.NullInt32{Int32: 204, Valid: true}, LatencyMs: 41, AttemptedAt: goldenTime,
+					},
+				}, nil)
+			},
+		},
+		{
+			name: "list webhook subscriptions", method: http.MethodGet, target: "/webhooks",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListWebhookSubscriptions(mock.Anything).
+					Return([]db.WebhookSubscription{goldenSubscription}, nil)
+			},
+		},
+		{
+			name: "create webhook subscription", method: http.MethodPost, target: "/webhooks",
+			body: `{"url":"https://example.com/pantry","event_types":["pantry.updated"],"secret":"s3cret"}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().CreateWebhookSubscription(mock.Anything, mock.Anything).Return(goldenSubscription, nil)
+			},
+		},
+		{
+			name: "get webhook subscription", method: http.MethodGet, target: subscription,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().GetWebhookSubscription(mock.Anything, goldenSubscription.ID).Return(goldenSubscription, nil)
+			},
+		},
+		{
+			name: "update webhook subscription", method: http.MethodPut, target: subscription,
+			body: `{"url":"https://example.com/pantry","event_types":["pantry.updated"],"active":false}`,
+			setup: func(q *mocks.MockQuerier) {
+				updated := goldenSubscription
+				updated.Active = false
+				q.EXPECT().UpdateWebhookSubscription(mock.Anything, mock.Anything).Return(updated, nil)
+			},
+		},
+		{
+			name: "delete webhook subscription", method: http.MethodDelete, target: subscription,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().DeleteWebhookSubscription(mock.Anything, goldenSubscription.ID).Return(1, nil)
+			},
+		},
+		{name: "get reconciliation before first run", method: http.MethodGet, target: "/admin/reconciliation"},
+		{
+			name: "reconcile", method: http.MethodPost, target: "/admin/reconciliation",
+			scrub: []string{"duration_ms"},
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListPantryItems(mock.Anything).Return([]db.PantryItem{goldenItem}, nil)
+				q.EXPECT().ListAllPantryLots(mock.Anything).Return([]db.PantryLot{
+					{ID: goldenLotID, PantryItemID: goldenItemID, Quantity: 1.5, Unit: "kg", AddedAt: goldenTime},
+				}, nil)
+				q.EXPECT().ListLotHistoryTotals(mock.Anything).Return([]db.ListLotHistoryTotalsRow{
+					{PantryItemID: goldenItemID, LotID: goldenLotID, Unit: "kg", Quantity: 1},
+				}, nil)
+			},
+		},
+		{
+			name: "repair reconciliation", method: http.MethodPost, target: "/admin/reconciliation/repair",
+			scrub: []string{"duration_ms"},
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().ListPantryItems(mock.Anything).Return([]db.PantryItem{goldenItem}, nil)
+				q.EXPECT().ListAllPantryLots(mock.Anything).Return([]db.PantryLot{
+					{ID: goldenLotID, PantryItemID: goldenItemID, Quantity: 1.5, Unit: "kg", AddedAt: goldenTime},
+				}, nil)
+				q.EXPECT().ListLotHistoryTotals(mock.Anything).Return(nil, nil)
+				q.EXPECT().InsertPantryLotEvent(mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{name: "not found", method: http.MethodGet, target: "/nope"},
+		{name: "method not allowed", method: http.MethodPut, target: item},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ, router := setupGoldenRouter(t)
+			if tc.setup != nil {
+				tc.setup(mockQ)
+			}
+
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			golden.AssertResponse(t, rec, tc.scrub...)
+		})
+	}
+}
@@ -3,40 +3,309 @@ package api
 import (
 	"context"
 	"database/sql"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
 
+	"github.com/mwhite7112/woodpantry-pantry/internal/auth"
 	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
 	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
 	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+	"github.com/mwhite7112/woodpantry-pantry/internal/slo"
+	"github.com/mwhite7112/woodpantry-pantry/internal/units"
+	"github.com/mwhite7112/woodpantry-pantry/pkg/model"
 )
 
+// Option enables optional route groups on the router.
+type Option func(*routerOptions)
+
+type routerOptions struct {
+	maintenance   *service.MaintenanceService
+	watchlist     *service.WatchlistService
+	expiry        *service.ExpiryService
+	notify        *service.NotificationService
+	activity      *service.ActivityLog
+	unitDefaults  *service.UnitDefaults
+	displayNames  *service.DisplayOverrides
+	reviewRules   *service.ReviewRules
+	valuation     *service.ValuationService
+	llmBudget     *service.LLMBudget
+	readOnly      *service.ReadOnlyMode
+	webhooks      *service.WebhookAudit
+	subscriptions *service.WebhookService
+	feed          *service.PantryFeed
+	reconciler    *service.Reconciler
+	janitor       *service.JobJanitor
+	taxonomy      *service.Taxonomy
+	stale         *service.StaleService
+	readiness     []ReadinessCheck
+	maxBodyBytes  int64
+	slo           *slo.Tracker
+	displayUnits  units.System
+	syncIngest    bool
+	auth          *auth.Verifier
+}
+
+// WithMaintenance mounts the /admin/maintenance endpoints.
+func WithMaintenance(m *service.MaintenanceService) Option {
+	return func(o *routerOptions) { o.maintenance = m }
+}
+
+// WithWatchlist mounts the /pantry/watchlist endpoints.
+func WithWatchlist(wl *service.WatchlistService) Option {
+	return func(o *routerOptions) { o.watchlist = wl }
+}
+
+// WithExpiry mounts GET /pantry/expiring and the /admin/expiry-lead-times
+// endpoints.
+func WithExpiry(e *service.ExpiryService) Option {
+	return func(o *routerOptions) { o.expiry = e }
+}
+
+// WithNotifications mounts the /pantry/notification-preferences endpoints
+// and GET /admin/notifications.
+func WithNotifications(n *service.NotificationService) Option {
+	return func(o *routerOptions) { o.notify = n }
+}
+
+// WithActivity mounts GET /pantry/activity.
+func WithActivity(a *service.ActivityLog) Option {
+	return func(o *routerOptions) { o.activity = a }
+}
+
+// WithUnitDefaults mounts the /admin/category-units endpoints.
+func WithUnitDefaults(u *service.UnitDefaults) Option {
+	return func(o *routerOptions) { o.unitDefaults = u }
+}
+
+// WithReviewRules mounts the /admin/review-rules endpoints.
+func WithReviewRules(rr *service.ReviewRules) Option {
+	return func(o *routerOptions) { o.reviewRules = rr }
+}
+
+// WithValuation mounts GET /pantry/value and the /admin/prices endpoints.
+func WithValuation(v *service.ValuationService) Option {
+	return func(o *routerOptions) { o.valuation = v }
+}
+
+// WithLLMBudget mounts the /admin/llm-budget endpoints.
+func WithLLMBudget(b *service.LLMBudget) Option {
+	return func(o *routerOptions) { o.llmBudget = b }
+}
+
+// WithDisplayUnits sets the deployment-wide measurement system used to render
+// quantities when a request expresses no preference of its own.
+func WithDisplayUnits(system units.System) Option {
+	return func(o *routerOptions) { o.displayUnits = system }
+}
+
+// WithJobJanitor adds the job janitor's last run to /metrics.
+func WithJobJanitor(j *service.JobJanitor) Option {
+	return func(o *routerOptions) { o.janitor = j }
+}
+
+// WithSyncProcessing makes POST /pantry/ingest extract and stage each job
+// before responding, for tests, local development and serverless hosts that
+// cannot keep background work running.
+func WithSyncProcessing() Option {
+	return func(o *routerOptions) { o.syncIngest = true }
+}
+
 // NewRouter wires all routes.
 func NewRouter(
 	pantry *service.PantryService,
 	ingest *service.IngestService,
 	dict *clients.DictionaryClient,
+	opts ...Option,
 ) http.Handler {
+	var o routerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	r := chi.NewRouter()
 	r.Use(logging.Middleware)
+	r.Use(traceRequests)
+	if o.slo != nil {
+		r.Use(trackSLO(o.slo))
+	}
 	r.Use(middleware.Recoverer)
+	if o.auth != nil {
+		r.Use(requireScope(o.auth))
+	}
+	if o.maxBodyBytes <= 0 {
+		o.maxBodyBytes = DefaultMaxBodyBytes
+	}
+	r.Use(limitBody(o.maxBodyBytes))
+	r.Use(requireJSONBody)
+	if o.readOnly != nil {
+		r.Use(rejectWritesWhenReadOnly(o.readOnly))
+	}
+	r.NotFound(notFound)
+	r.MethodNotAllowed(methodNotAllowed(r))
 
 	r.Get("/healthz", handleHealth)
+	if o.readiness != nil {
+		r.Get("/readyz", handleReady(o.readiness))
+	}
+	if o.slo != nil {
+		var gauges []func(io.Writer) error
+		if o.reconciler != nil {
+			gauges = append(gauges, o.reconciler.WriteMetrics)
+		}
+		if o.janitor != nil {
+			gauges = append(gauges, o.janitor.WriteMetrics)
+		}
+		r.Get("/metrics", handleMetrics(o.slo, gauges...))
+	}
+
+	r.With(produces(mediaJSON, mediaCSV), shedLowPriority(o.slo, wantsCSV)).
+		Get("/pantry", handleListPantry(pantry, o.displayUnits, o.displayNames))
+	if o.feed != nil {
+		r.Get("/pantry/ws", handlePantryFeed(o.feed))
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Use(produces(mediaJSON))
+
+		r.Get("/pantry/items", handleGetItemByIngredient(pantry))
+		r.Post("/pantry/items", handleAddItem(pantry, dict))
+		r.Post("/pantry/items/batch", handleAddItems(pantry, dict))
+		r.Post("/pantry/items/lookup", handleLookupItems(pantry, o.displayNames))
+		r.Post("/pantry/items/preview", handlePreviewItem(pantry, dict))
+		r.Patch("/pantry/items/{id}", handleUpdateItem(pantry))
+		r.Post("/pantry/items/{id}/consume", handleConsumeItem(pantry))
+		r.Delete("/pantry/items/{id}", handleDeleteItem(pantry))
+		r.Get("/pantry/items/{id}/lots", handleListLots(pantry))
+		r.Get("/pantry/items/{id}/lots/history", handleListLotHistory(pantry))
+		r.Post("/pantry/ingest", handleIngest(ingest, o.syncIngest))
+		r.Get("/pantry/ingest", handleListJobs(ingest))
+		r.With(shedLowPriority(o.slo, nil)).Get("/pantry/ingest/stats", handleIngestStats(ingest))
+		r.Get("/pantry/ingest/{job_id}", handleGetJob(ingest))
+		r.Patch("/pantry/ingest/{job_id}/items/{item_id}", handleUpdateStagedItem(pantry, ingest))
+		r.Delete("/pantry/ingest/{job_id}/items/{item_id}", handleDeleteStagedItem(ingest))
+		r.Post("/pantry/ingest/{job_id}/items/{item_id}/reextract", handleReextractItem(ingest))
+		r.Post("/pantry/ingest/{job_id}/confirm", handleConfirmJob(pantry, ingest))
+		r.Post("/pantry/ingest/{job_id}/cancel", handleCancelJob(ingest))
+		r.Post("/pantry/ingest/{job_id}/retry", handleRetryJob(ingest))
+		r.Post("/pantry/ingest/{job_id}/reject", handleRejectJob(ingest))
+		r.Delete("/pantry/reset", handleReset(pantry))
+
+		if o.watchlist != nil {
+			r.Get("/pantry/watchlist", handleListWatchlist(o.watchlist))
+			r.Post("/pantry/watchlist", handleWatchIngredient(o.watchlist, dict))
+			r.Get("/pantry/watchlist/missing", handleListMissingWatched(o.watchlist))
+			r.Delete("/pantry/watchlist/{ingredient_id}", handleUnwatchIngredient(o.watchlist))
+		}
+
+		if o.displayNames != nil {
+			r.Get("/pantry/display-names", handleListDisplayNames(o.displayNames))
+			r.Put("/pantry/display-names/{ingredient_id}", handleSetDisplayName(o.displayNames))
+			r.Delete("/pantry/display-names/{ingredient_id}", handleDeleteDisplayName(o.displayNames))
+		}
+		if o.expiry != nil {
+			r.Get("/pantry/expiring", handleListExpiring(o.expiry, o.displayNames))
+			r.Get("/admin/expiry-lead-times", handleListLeadTimes(o.expiry))
+			r.Put("/admin/expiry-lead-times/{category}", handleSetLeadTime(o.expiry))
+			r.Delete("/admin/expiry-lead-times/{category}", handleDeleteLeadTime(o.expiry))
+		}
+
+		if o.notify != nil {
+			r.Get("/pantry/notification-preferences", handleListNotificationPreferences(o.notify))
+			r.Put("/pantry/notification-preferences/{kind}", handleSetNotificationPreference(o.notify))
+			r.Get("/admin/notifications", handleListNotifications(o.notify))
+		}
+
+		if o.unitDefaults != nil {
+			r.Get("/admin/category-units", handleListCategoryUnits(o.unitDefaults))
+			r.Put("/admin/category-units/{category}", handleSetCategoryUnit(o.unitDefaults))
+			r.Delete("/admin/category-units/{category}", handleDeleteCategoryUnit(o.unitDefaults))
+		}
+
+		if o.reviewRules != nil {
+			r.Get("/admin/review-rules", handleListReviewRules(o.reviewRules))
+			r.Post("/admin/review-rules", handleCreateReviewRule(o.reviewRules))
+			r.Delete("/admin/review-rules/{id}", handleDeleteReviewRule(o.reviewRules))
+		}
+
+		if o.valuation != nil {
+			r.Get("/pantry/value", handleGetValue(o.valuation))
+			r.Get("/admin/prices", handleListPrices(o.valuation))
+			r.Put("/admin/prices/ingredients/{ingredient_id}", handleSetIngredientPrice(o.valuation))
+			r.Delete("/admin/prices/ingredients/{ingredient_id}", handleDeleteIngredientPrice(o.valuation))
+			r.Put("/admin/prices/categories/{category}", handleSetCategoryPrice(o.valuation))
+			r.Delete("/admin/prices/categories/{category}", handleDeleteCategoryPrice(o.valuation))
+		}
+
+		if o.activity != nil {
+			r.Get("/pantry/activity", handleListActivity(o.activity))
+		}
+
+		if o.taxonomy != nil {
+			r.Get("/pantry/taxonomy", handleGetTaxonomy(o.taxonomy))
+		}
+
+		if o.stale != nil {
+			r.Get("/pantry/stale", handleListStale(o.stale))
+		}
+
+		if o.slo != nil {
+			r.Get("/admin/slo", handleGetSLO(o.slo))
+		}
+
+		r.Get("/admin/event-schemas", handleListEventSchemas)
+		r.Get("/admin/workers", handleListWorkers(ingest))
+		r.Get("/admin/llm-health", handleLLMHealth(ingest))
+		r.Post("/admin/ingest/{job_id}/transition", handleTransitionJob(ingest))
+		r.Get("/admin/shadow-extractions/report", handleShadowReport(ingest))
+
+		if o.maintenance != nil {
+			r.Get("/admin/maintenance/vacuum-hints", handleVacuumHints(o.maintenance))
+			r.Post("/admin/maintenance/analyze", handleAnalyze(o.maintenance))
+			r.Post("/admin/maintenance/reindex", handleReindex(o.maintenance))
+			r.Post("/admin/maintenance/cleanup-orphans", handleCleanupOrphans(o.maintenance))
+			r.Post("/admin/maintenance/integrity-check", handleIntegrityCheck(o.maintenance))
+		}
+
+		if o.llmBudget != nil {
+			r.Get("/admin/llm-budget", handleGetLLMBudget(o.llmBudget))
+			r.Post("/admin/llm-budget/reset", handleResetLLMBudget(o.llmBudget))
+		}
+
+		if o.readOnly != nil {
+			r.Get("/admin/read-only", handleGetReadOnly(o.readOnly))
+			r.Put("/admin/read-only", handleSetReadOnly(o.readOnly))
+		}
 
-	r.Get("/pantry", handleListPantry(pantry))
-	r.Post("/pantry/items", handleAddItem(pantry, dict))
-	r.Delete("/pantry/items/{id}", handleDeleteItem(pantry))
-	r.Post("/pantry/ingest", handleIngest(ingest))
-	r.Get("/pantry/ingest/{job_id}", handleGetJob(ingest))
-	r.Post("/pantry/ingest/{job_id}/confirm", handleConfirmJob(pantry, ingest))
-	r.Delete("/pantry/reset", handleReset(pantry))
+		if o.webhooks != nil {
+			r.Get("/admin/webhooks", handleListWebhookDeliveries(o.webhooks))
+		}
+
+		if o.subscriptions != nil {
+			r.Get("/webhooks", handleListWebhookSubscriptions(o.subscriptions))
+			r.Post("/webhooks", handleCreateWebhookSubscription(o.subscriptions))
+			r.Get("/webhooks/{id}", handleGetWebhookSubscription(o.subscriptions))
+			r.Put("/webhooks/{id}", handleUpdateWebhookSubscription(o.subscriptions))
+			r.Delete("/webhooks/{id}", handleDeleteWebhookSubscription(o.subscriptions))
+		}
+
+		if o.reconciler != nil {
+			r.Get("/admin/reconciliation", handleGetReconciliation(o.reconciler))
+			r.Post("/admin/reconciliation", handleReconcile(o.reconciler, false))
+			r.Post("/admin/reconciliation/repair", handleReconcile(o.reconciler, true))
+		}
+	})
 
 	return r
 }
@@ -47,25 +316,258 @@ func handleHealth(w http.ResponseWriter, r *http.Request) {
 
 // --- GET /pantry ---
 
-func handleListPantry(pantry *service.PantryService) http.HandlerFunc {
+// displayQuantity is a display-only rendering of a stored quantity in the
+// caller's preferred measurement system.
+type displayQuantity struct {
+	Quantity float64 `json:"quantity"`
+	Unit     string  `json:"unit"`
+}
+
+type pantryItemResponse struct {
+	model.PantryItem
+
+	DisplayName string           `json:"display_name,omitempty"`
+	Emoji       string           `json:"emoji,omitempty"`
+	Display     *displayQuantity `json:"display,omitempty"`
+}
+
+func handleListPantry(
+	pantry *service.PantryService, defaultUnits units.System, overrides *service.DisplayOverrides,
+) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		system, ok := preferredUnits(r, defaultUnits)
+		if !ok && r.URL.Query().Has("units") {
+			jsonError(r.Context(), w, "units must be metric or imperial", http.StatusBadRequest)
+			return
+		}
+
+		asCSV := wantsCSV(r)
+		paged := r.URL.Query().Has("limit") || r.URL.Query().Has("cursor")
+
+		if r.URL.Query().Has("updated_since") {
+			if paged {
+				jsonError(r.Context(), w,
+					"updated_since cannot be combined with limit or cursor", http.StatusBadRequest)
+				return
+			}
+			if asCSV {
+				jsonError(r.Context(), w, "updated_since is only available as JSON", http.StatusNotAcceptable)
+				return
+			}
+			since, err := time.Parse(time.RFC3339, r.URL.Query().Get("updated_since"))
+			if err != nil {
+				jsonError(r.Context(), w, "updated_since must be RFC3339", http.StatusBadRequest)
+				return
+			}
+			delta, err := pantry.ListChangesSince(r.Context(), since)
+			if err != nil {
+				jsonError(r.Context(), w, "failed to list pantry changes", http.StatusInternalServerError, err)
+				return
+			}
+			jsonOK(w, map[string]any{
+				"items":   presentItems(delta.Items, system, ok, overrides.Names(r.Context())),
+				"deleted": model.FromTombstones(delta.Deleted),
+				"as_of":   delta.AsOf,
+			})
+			return
+		}
+
+		if paged {
+			if asCSV {
+				jsonError(r.Context(), w, "limit and cursor are only available as JSON", http.StatusNotAcceptable)
+				return
+			}
+			listPantryPage(w, r, pantry, system, ok, overrides)
+			return
+		}
+
 		items, err := pantry.ListItems(r.Context())
 		if err != nil {
 			jsonError(r.Context(), w, "failed to list pantry items", http.StatusInternalServerError, err)
 			return
 		}
-		jsonOK(w, map[string]any{"items": items})
+		if asCSV {
+			writePantryCSV(w, items)
+			return
+		}
+		jsonOK(w, map[string]any{"items": presentItems(items, system, ok, overrides.Names(r.Context()))})
+	}
+}
+
+// listPantryPage answers GET /pantry?limit=&cursor= with one page of items,
+// the pantry's total size and the cursor for the next page.
+func listPantryPage(
+	w http.ResponseWriter,
+	r *http.Request,
+	pantry *service.PantryService,
+	system units.System,
+	localize bool,
+	overrides *service.DisplayOverrides,
+) {
+	limit := service.DefaultPantryPageLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > service.MaxPantryPageLimit {
+			jsonError(r.Context(), w,
+				fmt.Sprintf("limit must be between 1 and %d", service.MaxPantryPageLimit), http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	page, err := pantry.ListItemsPage(r.Context(), r.URL.Query().Get("cursor"), limit)
+	if errors.Is(err, service.ErrInvalidCursor) {
+		jsonError(r.Context(), w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		jsonError(r.Context(), w, "failed to list pantry items", http.StatusInternalServerError, err)
+		return
+	}
+	resp := map[string]any{
+		"items": presentItems(page.Items, system, localize, overrides.Names(r.Context())),
+		"total": page.Total,
+	}
+	if page.NextCursor != "" {
+		resp["next_cursor"] = page.NextCursor
+	}
+	jsonOK(w, resp)
+}
+
+// writePantryCSV renders stored quantities; display conversion is JSON-only.
+func writePantryCSV(w http.ResponseWriter, items []db.PantryItem) {
+	w.Header().Set("Content-Type", mediaCSV+"; charset=utf-8")
+	cw := csv.NewWriter(w)
+	header := []string{"id", "ingredient_id", "quantity", "unit", "expires_at", "added_at", "updated_at", "quantity_unknown"}
+	cw.Write(header) //nolint:errcheck
+	for _, item := range items {
+		expiresAt := ""
+		if item.ExpiresAt.Valid {
+			expiresAt = item.ExpiresAt.Time.Format(time.RFC3339)
+		}
+		cw.Write([]string{ //nolint:errcheck
+			item.ID.String(),
+			item.IngredientID.String(),
+			strconv.FormatFloat(item.Quantity, 'f', -1, 64),
+			item.Unit,
+			expiresAt,
+			item.AddedAt.Format(time.RFC3339),
+			item.UpdatedAt.Format(time.RFC3339),
+			strconv.FormatBool(item.QuantityUnknown),
+		})
+	}
+	cw.Flush()
+}
+
+// presentItems adds display quantities when a measurement system applies,
+// and the household's display name and emoji for each ingredient that has
+// one. Without either, items are returned as plain model.PantryItem.
+func presentItems(
+	items []db.PantryItem, system units.System, localize bool, names map[uuid.UUID]service.DisplayOverride,
+) any {
+	if !localize && len(names) == 0 {
+		return model.FromPantryItems(items)
+	}
+	resp := make([]pantryItemResponse, len(items))
+	for i, item := range items {
+		name := names[item.IngredientID]
+		resp[i] = pantryItemResponse{PantryItem: model.FromPantryItem(item), DisplayName: name.DisplayName, Emoji: name.Emoji}
+		if !localize || !service.QuantityCounts(item) {
+			continue
+		}
+		if qty, unit, converted := units.Localize(item.Quantity, item.Unit, system); converted {
+			resp[i].Display = &displayQuantity{Quantity: qty, Unit: unit}
+		}
+	}
+	return resp
+}
+
+// preferredUnits resolves the display measurement system for a request:
+// ?units= wins, then Accept-Language, then the deployment default.
+func preferredUnits(r *http.Request, defaultUnits units.System) (units.System, bool) {
+	if r.URL.Query().Has("units") {
+		return units.ParseSystem(r.URL.Query().Get("units"))
+	}
+	if system, ok := units.SystemForLanguage(r.Header.Get("Accept-Language")); ok {
+		return system, true
+	}
+	return defaultUnits, defaultUnits != ""
+}
+
+// --- GET /pantry/items?ingredient_id= ---
+
+func handleGetItemByIngredient(pantry *service.PantryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := r.URL.Query().Get("ingredient_id")
+		if v == "" {
+			jsonError(r.Context(), w, "ingredient_id is required; use GET /pantry to list items", http.StatusBadRequest)
+			return
+		}
+		ingredientID, err := uuid.Parse(v)
+		if err != nil {
+			jsonError(r.Context(), w, "invalid ingredient_id", http.StatusBadRequest)
+			return
+		}
+
+		item, err := pantry.GetItemByIngredient(r.Context(), ingredientID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(r.Context(), w, "no pantry item for ingredient", http.StatusNotFound)
+				return
+			}
+			jsonError(r.Context(), w, "failed to get pantry item", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, model.FromPantryItem(item))
+	}
+}
+
+// --- POST /pantry/items/lookup ---
+
+type lookupItemsRequest struct {
+	IngredientIDs []uuid.UUID `json:"ingredient_ids"`
+}
+
+func handleLookupItems(pantry *service.PantryService, overrides *service.DisplayOverrides) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req lookupItemsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if len(req.IngredientIDs) == 0 {
+			jsonError(r.Context(), w, "ingredient_ids is required", http.StatusBadRequest)
+			return
+		}
+		if len(req.IngredientIDs) > service.MaxIngredientLookup {
+			jsonError(r.Context(), w,
+				fmt.Sprintf("at most %d ingredient_ids per request", service.MaxIngredientLookup),
+				http.StatusBadRequest)
+			return
+		}
+
+		items, missing, err := pantry.LookupItems(r.Context(), req.IngredientIDs)
+		if err != nil {
+			jsonError(r.Context(), w, "failed to look up pantry items", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{
+			"items":   presentItems(items, "", false, overrides.Names(r.Context())),
+			"missing": missing,
+		})
 	}
 }
 
 // --- POST /pantry/items ---
 
 type addItemRequest struct {
-	Name         string  `json:"name"`          // raw text → resolved via Dictionary
-	IngredientID string  `json:"ingredient_id"` // direct canonical ID (takes precedence)
-	Quantity     float64 `json:"quantity"`
-	Unit         string  `json:"unit"`
-	ExpiresAt    *string `json:"expires_at"` // ISO 8601 or null
+	Name            string   `json:"name,omitempty"`   // raw text → resolved via Dictionary
+	IngredientID    string   `json:"ingredient_id"`    // direct canonical ID (takes precedence)
+	Quantity        *float64 `json:"quantity"`         // omitted or null: in stock, amount unknown
+	QuantityUnknown bool     `json:"quantity_unknown"` // quantity, if given, is an estimate
+	Unit            string   `json:"unit"`
+	ExpiresAt       *string  `json:"expires_at"`  // RFC3339, YYYY-MM-DD, or null
+	OnConflict      string   `json:"on_conflict"` // replace (default), add, or max
 }
 
 func handleAddItem(pantry *service.PantryService, dict *clients.DictionaryClient) http.HandlerFunc {
@@ -75,54 +577,333 @@ func handleAddItem(pantry *service.PantryService, dict *clients.DictionaryClient
 			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
 			return
 		}
-		if req.Quantity <= 0 {
-			jsonError(r.Context(), w, "quantity must be positive", http.StatusBadRequest)
+		in, e := parseAddItem(r.Context(), pantry, dict, req)
+		if e != nil {
+			jsonError(r.Context(), w, e.msg, e.status, e.err)
 			return
 		}
-		if req.Unit == "" {
-			jsonError(r.Context(), w, "unit is required", http.StatusBadRequest)
+
+		item, err := pantry.UpsertItemInput(r.Context(), in)
+		if err != nil {
+			jsonError(r.Context(), w, "failed to save pantry item", http.StatusInternalServerError, err)
 			return
 		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(model.FromPantryItem(item)) //nolint:errcheck
+	}
+}
 
-		var ingredientID uuid.UUID
-		switch {
-		case req.IngredientID != "":
-			id, err := uuid.Parse(req.IngredientID)
-			if err != nil {
-				jsonError(r.Context(), w, "invalid ingredient_id", http.StatusBadRequest)
-				return
+// parseAddItem validates one add-item request and resolves its ingredient.
+// It is shared by the single and batch add endpoints.
+func parseAddItem(
+	ctx context.Context,
+	pantry *service.PantryService,
+	dict *clients.DictionaryClient,
+	req addItemRequest,
+) (service.ItemInput, *entryError) {
+	quantity, unknown := 0.0, req.Quantity == nil || req.QuantityUnknown
+	if req.Quantity != nil {
+		quantity = *req.Quantity
+		if quantity <= 0 {
+			return service.ItemInput{}, &entryError{status: http.StatusBadRequest, msg: "quantity must be positive"}
+		}
+		if quantity > service.MaxQuantity {
+			return service.ItemInput{}, &entryError{status: http.StatusBadRequest, msg: "quantity is too large"}
+		}
+	}
+	unit := req.Unit
+	if unit == "" {
+		if !unknown {
+			return service.ItemInput{}, &entryError{status: http.StatusBadRequest, msg: "unit is required"}
+		}
+		unit = units.DefaultCountUnit
+	}
+	strategy, err := service.ParseConflictStrategy(req.OnConflict)
+	if err != nil {
+		return service.ItemInput{}, &entryError{
+			status: http.StatusBadRequest,
+			msg:    "on_conflict must be one of replace, add, max",
+		}
+	}
+
+	ingredientID, e := lookupIngredientID(ctx, dict, req.IngredientID, req.Name)
+	if e != nil {
+		return service.ItemInput{}, e
+	}
+	if req.IngredientID != "" {
+		if err := pantry.ValidateIngredient(ctx, ingredientID); err != nil {
+			return service.ItemInput{}, &entryError{
+				status: http.StatusUnprocessableEntity,
+				msg:    "unknown ingredient_id",
 			}
-			ingredientID = id
-		case req.Name != "":
-			result, err := dict.Resolve(r.Context(), req.Name)
-			if err != nil {
-				jsonError(r.Context(), w, "failed to resolve ingredient: "+err.Error(), http.StatusBadGateway)
+		}
+	}
+
+	var expiresAt sql.NullTime
+	if req.ExpiresAt != nil {
+		t, err := pantry.ParseExpiresAt(*req.ExpiresAt)
+		if err != nil {
+			return service.ItemInput{}, &entryError{
+				status: http.StatusBadRequest,
+				msg:    "expires_at must be RFC3339 or YYYY-MM-DD",
+			}
+		}
+		expiresAt = sql.NullTime{Time: t, Valid: true}
+	}
+
+	return service.ItemInput{
+		IngredientID:    ingredientID,
+		Quantity:        quantity,
+		QuantityUnknown: unknown,
+		Unit:            unit,
+		ExpiresAt:       expiresAt,
+		Strategy:        strategy,
+	}, nil
+}
+
+// --- POST /pantry/items/preview ---
+
+type previewItemResponse struct {
+	Action       string            `json:"action"` // "create" or "update"
+	Item         addItemRequest    `json:"item"`   // normalized; POST it to /pantry/items to save Result
+	Existing     *model.PantryItem `json:"existing"`
+	Result       model.PantryItem  `json:"result"`
+	UnitReplaced bool              `json:"unit_replaced"`
+}
+
+func handlePreviewItem(pantry *service.PantryService, dict *clients.DictionaryClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req addItemRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		in, e := parseAddItem(r.Context(), pantry, dict, req)
+		if e != nil {
+			jsonError(r.Context(), w, e.msg, e.status, e.err)
+			return
+		}
+
+		preview, err := pantry.PreviewItem(r.Context(), in)
+		if err != nil {
+			jsonError(r.Context(), w, "failed to preview pantry item", http.StatusInternalServerError, err)
+			return
+		}
+		resp := previewItemResponse{
+			Action:       "create",
+			Item:         previewRequest(preview.Input),
+			Result:       model.FromPantryItem(preview.Result),
+			UnitReplaced: preview.UnitReplaced,
+		}
+		if preview.Existing != nil {
+			existing := model.FromPantryItem(*preview.Existing)
+			resp.Existing = &existing
+			resp.Action = "update"
+		}
+		jsonOK(w, resp)
+	}
+}
+
+// previewRequest turns a normalized input back into the add request that
+// saves it, naming the resolved ingredient directly.
+func previewRequest(in service.ItemInput) addItemRequest {
+	req := addItemRequest{
+		IngredientID:    in.IngredientID.String(),
+		QuantityUnknown: in.QuantityUnknown,
+		Unit:            in.Unit,
+		OnConflict:      string(in.Strategy),
+	}
+	if !in.QuantityUnknown || in.Quantity > 0 {
+		req.Quantity = &in.Quantity
+	}
+	if in.ExpiresAt.Valid {
+		expires := in.ExpiresAt.Time.Format(time.RFC3339)
+		req.ExpiresAt = &expires
+	}
+	return req
+}
+
+// --- POST /pantry/items/batch ---
+
+type addItemsRequest struct {
+	Items []addItemRequest `json:"items"`
+}
+
+func handleAddItems(pantry *service.PantryService, dict *clients.DictionaryClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req addItemsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if len(req.Items) == 0 {
+			jsonError(r.Context(), w, "items is required", http.StatusBadRequest)
+			return
+		}
+		if len(req.Items) > maxBatchEntries {
+			jsonError(r.Context(), w, fmt.Sprintf("at most %d items per request", maxBatchEntries),
+				http.StatusBadRequest)
+			return
+		}
+
+		results := make([]batchResult, len(req.Items))
+		inputs := make([]service.ItemInput, 0, len(req.Items))
+		indexes := make([]int, 0, len(req.Items)) // request index of each input
+		for i, item := range req.Items {
+			in, e := parseAddItem(r.Context(), pantry, dict, item)
+			if e != nil {
+				results[i] = e.result(r.Context(), i)
+				continue
+			}
+			inputs = append(inputs, in)
+			indexes = append(indexes, i)
+		}
+
+		items, errs := pantry.UpsertItems(r.Context(), inputs)
+		for j, i := range indexes {
+			if errs[j] != nil {
+				e := &entryError{
+					status: http.StatusInternalServerError,
+					msg:    "failed to save pantry item",
+					err:    errs[j],
+				}
+				results[i] = e.result(r.Context(), i)
+				continue
+			}
+			results[i] = batchResult{Index: i, Status: http.StatusCreated, ID: &items[j].ID}
+		}
+		writeBatch(w, results)
+	}
+}
+
+// --- PATCH /pantry/items/:id ---
+
+// updateItemRequest holds only the fields to change. expires_at is kept raw
+// so an explicit null, which clears the expiry, differs from an omitted key.
+type updateItemRequest struct {
+	Quantity  *float64        `json:"quantity"`
+	Unit      *string         `json:"unit"`
+	ExpiresAt json.RawMessage `json:"expires_at"` // RFC3339, YYYY-MM-DD, or null
+}
+
+func handleUpdateItem(pantry *service.PantryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		var req updateItemRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		patch, msg := parseItemPatch(pantry, req)
+		if msg != "" {
+			jsonError(r.Context(), w, msg, http.StatusBadRequest)
+			return
+		}
+
+		item, err := pantry.UpdateItem(r.Context(), id, patch)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(r.Context(), w, "item not found", http.StatusNotFound)
 				return
 			}
-			ingredientID = result.Ingredient.ID
-		default:
-			jsonError(r.Context(), w, "name or ingredient_id is required", http.StatusBadRequest)
+			jsonError(r.Context(), w, "failed to update pantry item", http.StatusInternalServerError, err)
 			return
 		}
+		jsonOK(w, model.FromPantryItem(item))
+	}
+}
 
+// parseItemPatch validates a PATCH body with the same rules as adding an
+// item, returning a message for the client when it is invalid.
+func parseItemPatch(pantry *service.PantryService, req updateItemRequest) (service.ItemPatch, string) {
+	var patch service.ItemPatch
+	if req.Quantity == nil && req.Unit == nil && req.ExpiresAt == nil {
+		return patch, "at least one of quantity, unit, expires_at is required"
+	}
+	if req.Quantity != nil {
+		if *req.Quantity <= 0 {
+			return patch, "quantity must be positive"
+		}
+		if *req.Quantity > service.MaxQuantity {
+			return patch, "quantity is too large"
+		}
+		patch.Quantity = req.Quantity
+	}
+	if req.Unit != nil {
+		if *req.Unit == "" {
+			return patch, "unit must not be empty"
+		}
+		patch.Unit = req.Unit
+	}
+	if req.ExpiresAt != nil {
 		var expiresAt sql.NullTime
-		if req.ExpiresAt != nil {
-			t, err := time.Parse(time.RFC3339, *req.ExpiresAt)
+		if string(req.ExpiresAt) != "null" {
+			var v string
+			if err := json.Unmarshal(req.ExpiresAt, &v); err != nil {
+				return patch, "expires_at must be RFC3339 or YYYY-MM-DD"
+			}
+			t, err := pantry.ParseExpiresAt(v)
 			if err != nil {
-				jsonError(r.Context(), w, "expires_at must be RFC3339", http.StatusBadRequest)
-				return
+				return patch, "expires_at must be RFC3339 or YYYY-MM-DD"
 			}
 			expiresAt = sql.NullTime{Time: t, Valid: true}
 		}
+		patch.ExpiresAt = &expiresAt
+	}
+	return patch, ""
+}
+
+// --- POST /pantry/items/:id/consume ---
 
-		item, err := pantry.UpsertItem(r.Context(), ingredientID, req.Quantity, req.Unit, expiresAt)
+type consumeItemRequest struct {
+	Quantity float64 `json:"quantity"`
+	Unit     string  `json:"unit"` // optional; defaults to the item's unit
+}
+
+type consumeItemResponse struct {
+	Item     model.PantryItem `json:"item"`
+	Consumed float64          `json:"consumed"`
+}
+
+func handleConsumeItem(pantry *service.PantryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
 		if err != nil {
-			jsonError(r.Context(), w, "failed to save pantry item", http.StatusInternalServerError, err)
+			jsonError(r.Context(), w, "invalid id", http.StatusBadRequest)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusCreated)
-		json.NewEncoder(w).Encode(item) //nolint:errcheck,musttag // musttag: sqlc-generated struct lacks json tags
+		var req consumeItemRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Quantity <= 0 {
+			jsonError(r.Context(), w, "quantity must be positive", http.StatusBadRequest)
+			return
+		}
+		if req.Quantity > service.MaxQuantity {
+			jsonError(r.Context(), w, "quantity is too large", http.StatusBadRequest)
+			return
+		}
+
+		consumed, err := pantry.ConsumeItem(r.Context(), id, req.Quantity, req.Unit)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			jsonError(r.Context(), w, "item not found", http.StatusNotFound)
+			return
+		case errors.Is(err, service.ErrQuantityUnknown), errors.Is(err, service.ErrIncompatibleUnit):
+			jsonError(r.Context(), w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case err != nil:
+			jsonError(r.Context(), w, "failed to consume pantry item", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, consumeItemResponse{Item: model.FromPantryItem(consumed.Item), Consumed: consumed.Consumed})
 	}
 }
 
@@ -147,6 +928,50 @@ func handleDeleteItem(pantry *service.PantryService) http.HandlerFunc {
 	}
 }
 
+// --- GET /pantry/items/:id/lots ---
+
+func handleListLots(pantry *service.PantryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		lots, err := pantry.ListLots(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(r.Context(), w, "item not found", http.StatusNotFound)
+				return
+			}
+			jsonError(r.Context(), w, "failed to list lots", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{"lot_tracking": pantry.LotTracking(), "lots": model.FromPantryLots(lots)})
+	}
+}
+
+// --- GET /pantry/items/:id/lots/history ---
+
+func handleListLotHistory(pantry *service.PantryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		events, err := pantry.ListLotHistory(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				jsonError(r.Context(), w, "item not found", http.StatusNotFound)
+				return
+			}
+			jsonError(r.Context(), w, "failed to list lot history", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{"events": model.FromPantryLotEvents(events)})
+	}
+}
+
 // --- DELETE /pantry/reset?confirm=true ---
 
 func handleReset(pantry *service.PantryService) http.HandlerFunc {
@@ -165,6 +990,51 @@ func handleReset(pantry *service.PantryService) http.HandlerFunc {
 
 // --- helpers ---
 
+// resolveIngredientID returns the canonical ingredient ID for a request that
+// carries either an explicit ingredient_id (which wins) or a raw name to
+// resolve via the Dictionary. On failure it writes the error response.
+func resolveIngredientID(
+	w http.ResponseWriter,
+	r *http.Request,
+	dict *clients.DictionaryClient,
+	ingredientID, name string,
+) (uuid.UUID, bool) {
+	id, e := lookupIngredientID(r.Context(), dict, ingredientID, name)
+	if e != nil {
+		jsonError(r.Context(), w, e.msg, e.status, e.err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// lookupIngredientID is resolveIngredientID without the response writing,
+// for batch entries that report errors individually.
+func lookupIngredientID(
+	ctx context.Context,
+	dict *clients.DictionaryClient,
+	ingredientID, name string,
+) (uuid.UUID, *entryError) {
+	switch {
+	case ingredientID != "":
+		id, err := uuid.Parse(ingredientID)
+		if err != nil {
+			return uuid.Nil, &entryError{status: http.StatusBadRequest, msg: "invalid ingredient_id"}
+		}
+		return id, nil
+	case name != "":
+		result, err := dict.Resolve(ctx, name)
+		if err != nil {
+			return uuid.Nil, &entryError{
+				status: http.StatusBadGateway,
+				msg:    "failed to resolve ingredient: " + err.Error(),
+			}
+		}
+		return result.Ingredient.ID, nil
+	default:
+		return uuid.Nil, &entryError{status: http.StatusBadRequest, msg: "name or ingredient_id is required"}
+	}
+}
+
 func jsonOK(w http.ResponseWriter, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(v) //nolint:errcheck
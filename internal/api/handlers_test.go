@@ -3,6 +3,7 @@ package api
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -18,9 +19,15 @@ import (
 	"github.com/mwhite7112/woodpantry-pantry/internal/db"
 	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
 	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+	"github.com/mwhite7112/woodpantry-pantry/internal/units"
 )
 
-func setupRouter(t *testing.T) (*mocks.MockQuerier, http.Handler) {
+// routerOption builds a router Option from the querier and Dictionary
+// client setupRouter creates, so a feature's service shares the mock the
+// test sets expectations on.
+type routerOption func(q *mocks.MockQuerier, dict *clients.DictionaryClient) Option
+
+func setupRouter(t *testing.T, opts ...routerOption) (*mocks.MockQuerier, http.Handler) {
 	t.Helper()
 
 	mockQ := mocks.NewMockQuerier(t)
@@ -33,7 +40,11 @@ func setupRouter(t *testing.T) (*mocks.MockQuerier, http.Handler) {
 	t.Cleanup(dictServer.Close)
 
 	dictClient := clients.NewDictionaryClient(dictServer.URL, dictServer.Client())
-	router := NewRouter(pantrySvc, ingestSvc, dictClient)
+	options := make([]Option, len(opts))
+	for i, opt := range opts {
+		options[i] = opt(mockQ, dictClient)
+	}
+	router := NewRouter(pantrySvc, ingestSvc, dictClient, options...)
 
 	return mockQ, router
 }
@@ -102,6 +113,28 @@ func TestPostPantryItems_WithIngredientID(t *testing.T) {
 	assert.Equal(t, http.StatusCreated, rec.Code)
 }
 
+func TestPostPantryItems_OnConflictAdd(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+
+	ingredientID := uuid.New()
+	mockQ.EXPECT().UpsertPantryItemAdd(mock.Anything, db.UpsertPantryItemAddParams{
+		IngredientID: ingredientID,
+		Quantity:     2,
+		Unit:         "lb",
+		ExpiresAt:    sql.NullTime{},
+	}).Return(db.PantryItem{ID: uuid.New(), IngredientID: ingredientID, Quantity: 3.5, Unit: "lb"}, nil)
+
+	body := `{"ingredient_id":"` + ingredientID.String() + `","quantity":2,"unit":"lb","on_conflict":"add"}`
+	req := httptest.NewRequest(http.MethodPost, "/pantry/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
 func TestPostPantryItems_WithName(t *testing.T) {
 	t.Parallel()
 
@@ -116,10 +149,7 @@ func TestPostPantryItems_WithName(t *testing.T) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(clients.ResolveResult{
-			Ingredient: struct {
-				ID   uuid.UUID `json:"id"`
-				Name string    `json:"name"`
-			}{ID: ingredientID, Name: "garlic"},
+			Ingredient: clients.Ingredient{ID: ingredientID, Name: "garlic"},
 			Confidence: 0.95,
 			Created:    false,
 		})
@@ -155,6 +185,54 @@ func TestPostPantryItems_WithName(t *testing.T) {
 	assert.Equal(t, http.StatusCreated, rec.Code)
 }
 
+func TestPostPantryItems_UnknownIngredientID(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	pantrySvc := service.NewPantryService(mockQ)
+	ingestSvc := service.NewIngestService(mockQ, &stubResolver{}, &stubExtractor{})
+
+	dictServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer dictServer.Close()
+
+	dictClient := clients.NewDictionaryClient(dictServer.URL, dictServer.Client())
+	pantrySvc.SetIngredientValidator(service.NewIngredientValidator(dictClient, 0))
+	router := NewRouter(pantrySvc, ingestSvc, dictClient)
+
+	body := `{"ingredient_id":"` + uuid.NewString() + `","quantity":1,"unit":"piece"}`
+	req := httptest.NewRequest(http.MethodPost, "/pantry/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "unknown ingredient_id")
+}
+
+func TestPostPantryItems_UnknownQuantity(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+	ingredientID := uuid.New()
+
+	mockQ.EXPECT().UpsertPantryItem(mock.Anything, db.UpsertPantryItemParams{
+		IngredientID:    ingredientID,
+		Unit:            units.DefaultCountUnit,
+		QuantityUnknown: true,
+	}).Return(db.PantryItem{IngredientID: ingredientID, Unit: units.DefaultCountUnit, QuantityUnknown: true}, nil)
+
+	body := `{"ingredient_id":"` + ingredientID.String() + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/pantry/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"quantity_unknown":true`)
+}
+
 func TestPostPantryItems_MissingFields(t *testing.T) {
 	t.Parallel()
 
@@ -165,11 +243,26 @@ func TestPostPantryItems_MissingFields(t *testing.T) {
 	}{
 		{"missing name and ingredient_id", `{"quantity":1,"unit":"cup"}`, "name or ingredient_id is required"},
 		{
-			"missing quantity",
+			"zero quantity",
 			`{"ingredient_id":"` + uuid.New().String() + `","quantity":0,"unit":"cup"}`,
 			"quantity must be positive",
 		},
+		{
+			"quantity too large",
+			`{"ingredient_id":"` + uuid.New().String() + `","quantity":1e12,"unit":"cup"}`,
+			"quantity is too large",
+		},
 		{"missing unit", `{"ingredient_id":"` + uuid.New().String() + `","quantity":1,"unit":""}`, "unit is required"},
+		{
+			"unknown on_conflict",
+			`{"ingredient_id":"` + uuid.New().String() + `","quantity":1,"unit":"cup","on_conflict":"sum"}`,
+			"on_conflict must be one of",
+		},
+		{
+			"bad expires_at",
+			`{"ingredient_id":"` + uuid.New().String() + `","quantity":1,"unit":"cup","expires_at":"03/12/2026"}`,
+			"expires_at must be RFC3339 or YYYY-MM-DD",
+		},
 	}
 
 	for _, tc := range tests {
@@ -205,6 +298,174 @@ func TestDeletePantryItem(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, rec.Code)
 }
 
+func TestPatchPantryItem(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+	tests := []struct {
+		name   string
+		body   string
+		params db.UpdatePantryItemParams
+	}{
+		{
+			name:   "quantity only",
+			body:   `{"quantity":3}`,
+			params: db.UpdatePantryItemParams{ID: id, Quantity: sql.NullFloat64{Float64: 3, Valid: true}},
+		},
+		{
+			name: "unit and expiry",
+			body: `{"unit":"kg","expires_at":"2026-04-01T00:00:00Z"}`,
+			params: db.UpdatePantryItemParams{
+				ID:           id,
+				Unit:         sql.NullString{String: "kg", Valid: true},
+				SetExpiresAt: true,
+				ExpiresAt:    sql.NullTime{Time: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+			},
+		},
+		{
+			name:   "clear expiry",
+			body:   `{"expires_at":null}`,
+			params: db.UpdatePantryItemParams{ID: id, SetExpiresAt: true},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ, router := setupRouter(t)
+			mockQ.EXPECT().UpdatePantryItem(mock.Anything, tc.params).Return(db.PantryItem{ID: id}, nil)
+
+			req := httptest.NewRequest(http.MethodPatch, "/pantry/items/"+id.String(), strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
+
+func TestPatchPantryItem_Errors(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+	missing := uuid.New()
+	mockQ.EXPECT().UpdatePantryItem(mock.Anything, mock.MatchedBy(func(p db.UpdatePantryItemParams) bool {
+		return p.ID == missing
+	})).Return(db.PantryItem{}, sql.ErrNoRows)
+
+	for body, want := range map[string]int{
+		`{}`:                      http.StatusBadRequest,
+		`{"quantity":0}`:          http.StatusBadRequest,
+		`{"unit":""}`:             http.StatusBadRequest,
+		`{"expires_at":"soon"}`:   http.StatusBadRequest,
+		`{"expires_at":20260401}`: http.StatusBadRequest,
+		`{"quantity":2}`:          http.StatusNotFound,
+	} {
+		req := httptest.NewRequest(http.MethodPatch, "/pantry/items/"+missing.String(), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, want, rec.Code, body)
+	}
+}
+
+func TestConsumePantryItem(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+	id := uuid.New()
+	mockQ.EXPECT().ConsumePantryItem(mock.Anything, db.ConsumePantryItemParams{Quantity: 0.5, ID: id}).
+		Return(db.ConsumePantryItemRow{ID: id, Quantity: 1.5, Unit: "cup", PreviousQuantity: 2}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/pantry/items/"+id.String()+"/consume",
+		strings.NewReader(`{"quantity":0.5}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp struct {
+		Item     db.PantryItem `json:"item"`
+		Consumed float64       `json:"consumed"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, 1.5, resp.Item.Quantity)
+	assert.Equal(t, 0.5, resp.Consumed)
+}
+
+func TestConsumePantryItem_Errors(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+	missing, unknown := uuid.New(), uuid.New()
+	mockQ.EXPECT().ConsumePantryItem(mock.Anything, mock.Anything).Return(db.ConsumePantryItemRow{}, sql.ErrNoRows)
+	mockQ.EXPECT().GetPantryItem(mock.Anything, missing).Return(db.PantryItem{}, sql.ErrNoRows)
+	mockQ.EXPECT().GetPantryItem(mock.Anything, unknown).
+		Return(db.PantryItem{ID: unknown, Unit: "bottle", QuantityUnknown: true}, nil)
+
+	tests := []struct {
+		id   uuid.UUID
+		body string
+		want int
+	}{
+		{id: missing, body: `{"quantity":0}`, want: http.StatusBadRequest},
+		{id: missing, body: `{"quantity":-1}`, want: http.StatusBadRequest},
+		{id: missing, body: `not json`, want: http.StatusBadRequest},
+		{id: missing, body: `{"quantity":1}`, want: http.StatusNotFound},
+		{id: unknown, body: `{"quantity":1}`, want: http.StatusUnprocessableEntity},
+	}
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/pantry/items/"+tc.id.String()+"/consume",
+			strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, tc.want, rec.Code, tc.body)
+	}
+}
+
+func TestPreviewPantryItem(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+	ingredientID := uuid.New()
+	mockQ.EXPECT().GetPantryItemByIngredient(mock.Anything, ingredientID).
+		Return(db.PantryItem{ID: uuid.New(), IngredientID: ingredientID, Quantity: 2, Unit: "lb"}, nil)
+
+	body := `{"ingredient_id":"` + ingredientID.String() + `","quantity":1,"unit":"lbs","on_conflict":"add"}`
+	req := httptest.NewRequest(http.MethodPost, "/pantry/items/preview", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp struct {
+		Action string `json:"action"`
+		Item   struct {
+			Unit string `json:"unit"`
+		} `json:"item"`
+		Result db.PantryItem `json:"result"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "update", resp.Action)
+	assert.Equal(t, "lb", resp.Item.Unit)
+	assert.Equal(t, 3.0, resp.Result.Quantity)
+}
+
+func TestPreviewPantryItem_BadRequest(t *testing.T) {
+	t.Parallel()
+
+	_, router := setupRouter(t)
+
+	body := `{"ingredient_id":"` + uuid.NewString() + `","quantity":-1,"unit":"lb"}`
+	req := httptest.NewRequest(http.MethodPost, "/pantry/items/preview", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 func TestDeletePantryReset_WithConfirm(t *testing.T) {
 	t.Parallel()
 
@@ -230,3 +491,356 @@ func TestDeletePantryReset_WithoutConfirm(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
+
+func TestGetPantry_DisplayUnits(t *testing.T) {
+	t.Parallel()
+
+	items := []db.PantryItem{
+		{ID: uuid.New(), IngredientID: uuid.New(), Quantity: 1000, Unit: "g"},
+		{ID: uuid.New(), IngredientID: uuid.New(), Quantity: 3, Unit: "clove"},
+	}
+
+	tests := []struct {
+		name           string
+		target         string
+		acceptLanguage string
+		wantDisplay    bool
+	}{
+		{"no preference", "/pantry", "", false},
+		{"query param", "/pantry?units=imperial", "", true},
+		{"accept-language", "/pantry", "en-US,en;q=0.9", true},
+		{"query param overrides header", "/pantry?units=metric", "en-US", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ, router := setupRouter(t)
+			mockQ.EXPECT().ListPantryItems(mock.Anything).Return(items, nil)
+
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			if tc.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tc.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var body struct {
+				Items []struct {
+					Unit    string
+					Display *struct {
+						Quantity float64 `json:"quantity"`
+						Unit     string  `json:"unit"`
+					} `json:"display"`
+				} `json:"items"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			require.Len(t, body.Items, 2)
+			assert.Equal(t, "g", body.Items[0].Unit, "stored unit is never rewritten")
+			assert.Nil(t, body.Items[1].Display, "count units are not converted")
+			if !tc.wantDisplay {
+				assert.Nil(t, body.Items[0].Display)
+				return
+			}
+			require.NotNil(t, body.Items[0].Display)
+			assert.Equal(t, "lb", body.Items[0].Display.Unit)
+			assert.InDelta(t, 2.2, body.Items[0].Display.Quantity, 0.01)
+		})
+	}
+}
+
+func TestGetPantry_InvalidUnits(t *testing.T) {
+	t.Parallel()
+
+	_, router := setupRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/pantry?units=cubits", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestPostPantryItemsBatch(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+
+	good, failing := uuid.New(), uuid.New()
+	saved := db.PantryItem{ID: uuid.New(), IngredientID: good}
+	mockQ.EXPECT().UpsertPantryItem(mock.Anything, mock.MatchedBy(func(p db.UpsertPantryItemParams) bool {
+		return p.IngredientID == good
+	})).Return(saved, nil)
+	mockQ.EXPECT().UpsertPantryItemAdd(mock.Anything, mock.MatchedBy(func(p db.UpsertPantryItemAddParams) bool {
+		return p.IngredientID == failing
+	})).Return(db.PantryItem{}, errors.New("db down"))
+
+	body := `{"items":[
+		{"ingredient_id":"` + good.String() + `","quantity":2,"unit":"l"},
+		{"ingredient_id":"` + good.String() + `","quantity":-1,"unit":"l"},
+		{"ingredient_id":"` + failing.String() + `","quantity":1,"unit":"kg","on_conflict":"add"}
+	]}`
+	req := httptest.NewRequest(http.MethodPost, "/pantry/items/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusMultiStatus, rec.Code)
+	var resp struct {
+		Succeeded int `json:"succeeded"`
+		Failed    int `json:"failed"`
+		Results   []struct {
+			Index  int        `json:"index"`
+			Status int        `json:"status"`
+			ID     *uuid.UUID `json:"id"`
+			Error  string     `json:"error"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.Succeeded)
+	assert.Equal(t, 2, resp.Failed)
+	require.Len(t, resp.Results, 3)
+	assert.Equal(t, http.StatusCreated, resp.Results[0].Status)
+	assert.Equal(t, saved.ID, *resp.Results[0].ID)
+	assert.Equal(t, http.StatusBadRequest, resp.Results[1].Status)
+	assert.Equal(t, "quantity must be positive", resp.Results[1].Error)
+	assert.Equal(t, 2, resp.Results[2].Index)
+	assert.Equal(t, http.StatusInternalServerError, resp.Results[2].Status)
+}
+
+func TestPostPantryItemsBatch_AllSucceeded(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+	mockQ.EXPECT().UpsertPantryItem(mock.Anything, mock.Anything).Return(db.PantryItem{ID: uuid.New()}, nil)
+
+	body := `{"items":[{"ingredient_id":"` + uuid.NewString() + `","quantity":1,"unit":"g"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/pantry/items/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/pantry/items/batch", strings.NewReader(`{"items":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetPantryItemByIngredient(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+
+	ingredientID, missingID := uuid.New(), uuid.New()
+	item := db.PantryItem{ID: uuid.New(), IngredientID: ingredientID, Quantity: 2, Unit: "l"}
+	mockQ.EXPECT().GetPantryItemByIngredient(mock.Anything, ingredientID).Return(item, nil)
+	mockQ.EXPECT().GetPantryItemByIngredient(mock.Anything, missingID).Return(db.PantryItem{}, sql.ErrNoRows)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry/items"+query, nil))
+		return rec
+	}
+
+	rec := get("?ingredient_id=" + ingredientID.String())
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), item.ID.String())
+	assert.Equal(t, http.StatusNotFound, get("?ingredient_id="+missingID.String()).Code)
+	assert.Equal(t, http.StatusBadRequest, get("?ingredient_id=garlic").Code)
+	assert.Equal(t, http.StatusBadRequest, get("").Code)
+}
+
+func TestPostPantryItemsLookup(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+
+	have, missing := uuid.New(), uuid.New()
+	item := db.PantryItem{ID: uuid.New(), IngredientID: have, Quantity: 1, Unit: "kg"}
+	mockQ.EXPECT().ListPantryItemsByIngredients(mock.Anything, []uuid.UUID{have, missing}).
+		Return([]db.PantryItem{item}, nil)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/pantry/items/lookup", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := post(`{"ingredient_ids":["` + have.String() + `","` + missing.String() + `"]}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp struct {
+		Items   []map[string]any `json:"items"`
+		Missing []uuid.UUID      `json:"missing"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, []uuid.UUID{missing}, resp.Missing)
+
+	assert.Equal(t, http.StatusBadRequest, post(`{"ingredient_ids":[]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post(`{"ingredient_ids":["garlic"]}`).Code)
+}
+
+func TestGetPantryItemLots(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+
+	itemID := uuid.New()
+	lot := db.PantryLot{ID: uuid.New(), PantryItemID: itemID, Quantity: 1, Unit: "l"}
+	mockQ.EXPECT().GetPantryItem(mock.Anything, itemID).Return(db.PantryItem{ID: itemID}, nil)
+	mockQ.EXPECT().ListPantryLotsByItem(mock.Anything, itemID).Return([]db.PantryLot{lot}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/pantry/items/"+itemID.String()+"/lots", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), lot.ID.String())
+}
+
+func TestGetPantryItemLots_NotFound(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+
+	itemID := uuid.New()
+	mockQ.EXPECT().GetPantryItem(mock.Anything, itemID).Return(db.PantryItem{}, sql.ErrNoRows)
+
+	req := httptest.NewRequest(http.MethodGet, "/pantry/items/"+itemID.String()+"/lots", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGetPantryItemLotHistory(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+
+	itemID := uuid.New()
+	event := db.PantryLotEvent{ID: uuid.New(), PantryItemID: itemID, Kind: service.LotEventConsumed}
+	mockQ.EXPECT().GetPantryItem(mock.Anything, itemID).Return(db.PantryItem{ID: itemID}, nil)
+	mockQ.EXPECT().ListPantryLotEventsByItem(mock.Anything, itemID).Return([]db.PantryLotEvent{event}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/pantry/items/"+itemID.String()+"/lots/history", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), event.ID.String())
+}
+
+func TestPostPantryItems_DateOnlyExpiry(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+
+	ingredientID := uuid.New()
+	mockQ.EXPECT().UpsertPantryItem(mock.Anything, db.UpsertPantryItemParams{
+		IngredientID: ingredientID,
+		Quantity:     1,
+		Unit:         "l",
+		ExpiresAt:    sql.NullTime{Time: time.Date(2026, 3, 12, 23, 59, 59, 0, time.UTC), Valid: true},
+	}).Return(db.PantryItem{ID: uuid.New()}, nil)
+
+	body := `{"ingredient_id":"` + ingredientID.String() + `","quantity":1,"unit":"l","expires_at":"2026-03-12"}`
+	req := httptest.NewRequest(http.MethodPost, "/pantry/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestGetPantry_UpdatedSince(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+
+	since := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	asOf := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	changed := db.PantryItem{ID: uuid.New(), Quantity: 1, Unit: "cup"}
+	deleted := db.PantryItemTombstone{ItemID: uuid.New(), IngredientID: uuid.New(), DeletedAt: asOf}
+
+	mockQ.EXPECT().PantryChangesCutoff(mock.Anything).Return(asOf, nil)
+	mockQ.EXPECT().ListPantryItemsUpdatedSince(mock.Anything, since).Return([]db.PantryItem{changed}, nil)
+	mockQ.EXPECT().ListPantryTombstonesSince(mock.Anything, since).Return([]db.PantryItemTombstone{deleted}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/pantry?updated_since=2026-02-01T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Contains(t, string(body["items"]), changed.ID.String())
+	assert.Contains(t, string(body["deleted"]), deleted.ItemID.String())
+	assert.JSONEq(t, `"2026-02-02T00:00:00Z"`, string(body["as_of"]))
+}
+
+func TestGetPantry_InvalidUpdatedSince(t *testing.T) {
+	t.Parallel()
+
+	_, router := setupRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/pantry?updated_since=yesterday", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetPantry_Paged(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusBadRequest, get("/pantry?limit=0").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/pantry?cursor=not-a-cursor").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/pantry?limit=5&updated_since=2026-02-01T00:00:00Z").Code)
+
+	added := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	first := db.PantryItem{ID: uuid.New(), AddedAt: added, Quantity: 1, Unit: "cup"}
+	second := db.PantryItem{ID: uuid.New(), AddedAt: added, Quantity: 2, Unit: "cup"}
+	mockQ.EXPECT().ListPantryItemsPage(mock.Anything, db.ListPantryItemsPageParams{Limit: 2}).
+		Return([]db.PantryItem{first, second}, nil)
+	mockQ.EXPECT().CountPantryItems(mock.Anything).Return(int64(2), nil)
+
+	rec := get("/pantry?limit=1")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var page struct {
+		Items      []db.PantryItem `json:"items"`
+		Total      int64           `json:"total"`
+		NextCursor string          `json:"next_cursor"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, first.ID, page.Items[0].ID)
+	assert.Equal(t, int64(2), page.Total)
+	require.NotEmpty(t, page.NextCursor)
+
+	// The cursor resumes after the last item returned.
+	mockQ.EXPECT().ListPantryItemsPage(mock.Anything, db.ListPantryItemsPageParams{
+		AfterAddedAt: sql.NullTime{Time: added, Valid: true},
+		AfterID:      uuid.NullUUID{UUID: first.ID, Valid: true},
+		Limit:        2,
+	}).Return([]db.PantryItem{second}, nil)
+	mockQ.EXPECT().CountPantryItems(mock.Anything).Return(int64(2), nil)
+
+	rec = get("/pantry?limit=1&cursor=" + page.NextCursor)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), second.ID.String())
+	assert.NotContains(t, rec.Body.String(), "next_cursor")
+}
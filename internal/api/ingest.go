@@ -2,53 +2,263 @@ package api
 
 import (
 	"database/sql"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
 	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+	"github.com/mwhite7112/woodpantry-pantry/pkg/model"
 )
 
 // --- POST /pantry/ingest ---
 
+// ingestRetryAfter is the Retry-After hint, in seconds, when the ingest
+// queue is full.
+const ingestRetryAfter = "5"
+
+// ingestSourceHeader lets a gateway or client SDK tag the channel when the
+// body does not carry a source.
+const ingestSourceHeader = "X-Ingest-Source"
+
 type ingestRequest struct {
-	Type    string `json:"type"`    // text_blob
-	Content string `json:"content"` // raw grocery list text
+	Type     string `json:"type"`     // text_blob|receipt_image|fridge_photo
+	Content  string `json:"content"`  // raw grocery list text, or a base64 image for a photo type
+	Source   string `json:"source"`   // web|mobile|email|voice|chatbot|api; falls back to X-Ingest-Source
+	Priority string `json:"priority"` // interactive (default, someone is waiting)|background
 }
 
-func handleIngest(ingest *service.IngestService) http.HandlerFunc {
+// receiptFormField is the multipart field holding a receipt or fridge photo.
+const receiptFormField = "image"
+
+// handleIngest creates a job and queues it, or with sync set processes it
+// before answering, so the response already carries "staged" or "failed".
+func handleIngest(ingest *service.IngestService, sync bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var req ingestRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
-			return
-		}
-		if req.Content == "" {
-			jsonError(r.Context(), w, "content is required", http.StatusBadRequest)
+		req, ok := decodeIngestRequest(w, r)
+		if !ok {
 			return
 		}
 		jobType := req.Type
 		if jobType == "" {
 			jobType = "text_blob"
 		}
+		if service.IsImageJob(jobType) {
+			input, err := encodePhoto(jobType, req.Content)
+			if err != nil {
+				jsonError(r.Context(), w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			req.Content = input
+		}
+		if req.Source == "" {
+			req.Source = r.Header.Get(ingestSourceHeader)
+		}
+		source, err := service.ParseIngestSource(req.Source)
+		if err != nil {
+			jsonError(r.Context(), w, "source must be one of "+strings.Join(service.IngestSources, ", "),
+				http.StatusBadRequest)
+			return
+		}
+		priority, err := service.ParseIngestPriority(req.Priority)
+		if err != nil {
+			jsonError(r.Context(), w, "priority must be one of "+strings.Join(service.IngestPriorities, ", "),
+				http.StatusBadRequest)
+			return
+		}
 
-		job, err := ingest.CreateJob(r.Context(), jobType, req.Content)
+		job, err := ingest.CreateJob(r.Context(), jobType, source, priority, req.Content)
 		if err != nil {
 			jsonError(r.Context(), w, "failed to create ingest job", http.StatusInternalServerError, err)
 			return
 		}
 
-		ingest.ProcessJobAsync(job.ID, req.Content)
+		resp := map[string]any{
+			"job_id":   job.ID,
+			"status":   job.Status,
+			"source":   job.Source,
+			"priority": job.Priority,
+		}
+		if sync {
+			err := ingest.ProcessJobSync(r.Context(), job.ID, job.RawInput)
+			switch {
+			case err == nil:
+				resp["status"] = "staged"
+			case errors.Is(err, service.ErrJobCancelled):
+				resp["status"] = "cancelled"
+			default:
+				resp["status"] = "failed"
+				resp["failure_reason"] = service.FailureReason(err)
+			}
+		} else {
+			err = ingest.ProcessJobAsync(r.Context(), job.ID, job.RawInput, job.Priority)
+			switch {
+			case errors.Is(err, service.ErrIngestDeferred):
+				resp["delayed"] = true
+				resp["message"] = "LLM provider is unavailable; processing will start when it recovers"
+			case err != nil:
+				// The job was never queued, so it would otherwise sit pending.
+				ingest.MarkJobFailed(r.Context(), job.ID, err)
+				if errors.Is(err, service.ErrIngestQueueFull) {
+					w.Header().Set("Retry-After", ingestRetryAfter)
+					jsonError(r.Context(), w, "ingest queue is full; retry later", http.StatusServiceUnavailable)
+				} else {
+					jsonError(r.Context(), w, "failed to queue ingest job", http.StatusInternalServerError, err)
+				}
+				return
+			}
+		}
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusAccepted)
-		json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck
-			"job_id": job.ID,
-			"status": job.Status,
-		})
+		json.NewEncoder(w).Encode(resp) //nolint:errcheck
+	}
+}
+
+// decodeIngestRequest reads a JSON ingest request, or a multipart upload
+// whose image field is a receipt photo, or a fridge photo when the type
+// field says so.
+func decodeIngestRequest(w http.ResponseWriter, r *http.Request) (ingestRequest, bool) {
+	var req ingestRequest
+	if isMultipart(r) {
+		r.Body = http.MaxBytesReader(w, r.Body, service.MaxReceiptImageBytes+multipartOverhead)
+		file, _, err := r.FormFile(receiptFormField)
+		if err != nil {
+			jsonError(r.Context(), w, "multipart uploads need an image field no larger than 8 MB",
+				http.StatusBadRequest)
+			return req, false
+		}
+		defer file.Close()
+		image, err := io.ReadAll(file)
+		if err != nil {
+			jsonError(r.Context(), w, "failed to read image", http.StatusBadRequest)
+			return req, false
+		}
+		req.Type = service.JobTypeReceiptImage
+		if r.FormValue("type") == service.JobTypeFridgePhoto {
+			req.Type = service.JobTypeFridgePhoto
+		}
+		req.Source = r.FormValue("source")
+		req.Priority = r.FormValue("priority")
+		req.Content = base64.StdEncoding.EncodeToString(image)
+		return req, true
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+		return req, false
+	}
+	if req.Content == "" {
+		jsonError(r.Context(), w, "content is required", http.StatusBadRequest)
+		return req, false
+	}
+	return req, true
+}
+
+// encodePhoto decodes a base64 photo, bare or as a data URL.
+func encodePhoto(jobType, content string) (string, error) {
+	if _, data, ok := strings.Cut(content, ";base64,"); ok && strings.HasPrefix(content, "data:") {
+		content = data
+	}
+	image, err := base64.StdEncoding.DecodeString(strings.TrimSpace(content))
+	if err != nil {
+		return "", fmt.Errorf("%w: content must be base64", service.ErrInvalidImage)
+	}
+	return service.EncodeImageInput(jobType, image)
+}
+
+// --- GET /pantry/ingest ---
+
+type jobSummary struct {
+	JobID          uuid.UUID `json:"job_id"`
+	Type           string    `json:"type"`
+	Source         string    `json:"source"`
+	Priority       string    `json:"priority"`
+	Status         string    `json:"status"`
+	BudgetExceeded bool      `json:"budget_exceeded"`
+	TruncatedItems int32     `json:"truncated_items"`
+	FailureReason  string    `json:"failure_reason"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func handleListJobs(ingest *service.IngestService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		filter := service.JobFilter{Status: q.Get("status"), Limit: service.DefaultJobListLimit}
+		if v := q.Get("source"); v != "" {
+			if v != "unknown" {
+				if _, err := service.ParseIngestSource(v); err != nil {
+					jsonError(r.Context(), w, err.Error(), http.StatusBadRequest)
+					return
+				}
+			}
+			filter.Source = v
+		}
+		if v := q.Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 || n > service.MaxJobListLimit {
+				jsonError(r.Context(), w,
+					fmt.Sprintf("limit must be between 1 and %d", service.MaxJobListLimit), http.StatusBadRequest)
+				return
+			}
+			filter.Limit = n
+		}
+
+		jobs, err := ingest.ListJobs(r.Context(), filter)
+		if err != nil {
+			jsonError(r.Context(), w, "failed to list jobs", http.StatusInternalServerError, err)
+			return
+		}
+		resp := make([]jobSummary, len(jobs))
+		for i, j := range jobs {
+			resp[i] = jobSummary{
+				JobID:          j.ID,
+				Type:           j.Type,
+				Source:         j.Source,
+				Priority:       j.Priority,
+				Status:         j.Status,
+				BudgetExceeded: j.BudgetExceeded,
+				TruncatedItems: j.TruncatedItems,
+				FailureReason:  j.FailureReason,
+				CreatedAt:      j.CreatedAt,
+			}
+		}
+		jsonOK(w, map[string]any{"jobs": resp})
+	}
+}
+
+// --- GET /pantry/ingest/stats ---
+
+// defaultStatsWindowDays is the look-back for ingest stats without ?days=.
+const defaultStatsWindowDays = 30
+
+func handleIngestStats(ingest *service.IngestService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		days := defaultStatsWindowDays
+		if v := r.URL.Query().Get("days"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				jsonError(r.Context(), w, "days must be a positive integer", http.StatusBadRequest)
+				return
+			}
+			days = n
+		}
+
+		since := time.Now().AddDate(0, 0, -days)
+		stats, err := ingest.SourceStats(r.Context(), since)
+		if err != nil {
+			jsonError(r.Context(), w, "failed to compute ingest stats", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{"since": since, "sources": stats})
 	}
 }
 
@@ -72,20 +282,263 @@ func handleGetJob(ingest *service.IngestService) http.HandlerFunc {
 			return
 		}
 
+		// Clients poll this endpoint while a job is processing; an unchanged
+		// job costs two small queries and no body.
+		etag, err := ingest.JobETag(r.Context(), job)
+		if err != nil {
+			jsonError(r.Context(), w, "failed to get job", http.StatusInternalServerError, err)
+			return
+		}
+		if notModified(w, r, etag) {
+			return
+		}
+
 		items, err := ingest.ListStagedItems(r.Context(), jobID)
 		if err != nil {
 			jsonError(r.Context(), w, "failed to get staged items", http.StatusInternalServerError, err)
 			return
 		}
+		timings, err := ingest.JobTimings(r.Context(), jobID)
+		if err != nil {
+			jsonError(r.Context(), w, "failed to get job timings", http.StatusInternalServerError, err)
+			return
+		}
+
+		warnings := []string{}
+		if job.TruncatedItems > 0 {
+			warnings = append(warnings, fmt.Sprintf(
+				"extraction produced %d items; only the first %d were staged",
+				len(items)+int(job.TruncatedItems), len(items)))
+		}
 
 		jsonOK(w, map[string]any{
-			"job_id": job.ID,
-			"status": job.Status,
-			"items":  items,
+			"job_id":          job.ID,
+			"source":          job.Source,
+			"priority":        job.Priority,
+			"status":          job.Status,
+			"budget_exceeded": job.BudgetExceeded,
+			"truncated_items": job.TruncatedItems,
+			"failure_reason":  job.FailureReason,
+			"warnings":        warnings,
+			"timings":         timings,
+			"items":           model.FromStagedItems(items),
 		})
 	}
 }
 
+// --- POST /pantry/ingest/:job_id/items/:item_id/reextract ---
+
+type reextractRequest struct {
+	Hint string `json:"hint"`
+}
+
+func handleReextractItem(ingest *service.IngestService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID, itemID, ok := stagedItemIDs(w, r)
+		if !ok {
+			return
+		}
+
+		var req reextractRequest
+		// body is optional — decode only if present
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+				return
+			}
+		}
+
+		item, err := ingest.ReextractItem(r.Context(), jobID, itemID, req.Hint)
+		switch {
+		case err == nil:
+			jsonOK(w, model.FromStagedItem(item))
+		case errors.Is(err, sql.ErrNoRows):
+			jsonError(r.Context(), w, "staged item not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrJobNotStaged):
+			jsonError(r.Context(), w, err.Error(), http.StatusConflict)
+		case errors.Is(err, service.ErrNothingExtracted):
+			jsonError(r.Context(), w, err.Error(), http.StatusUnprocessableEntity)
+		default:
+			jsonError(r.Context(), w, "failed to re-extract item", http.StatusBadGateway, err)
+		}
+	}
+}
+
+// --- PATCH /pantry/ingest/:job_id/items/:item_id ---
+
+type updateStagedItemRequest struct {
+	IngredientID *uuid.UUID `json:"ingredient_id"`
+	Quantity     *float64   `json:"quantity"`
+	Unit         *string    `json:"unit"`
+}
+
+func handleUpdateStagedItem(pantry *service.PantryService, ingest *service.IngestService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID, itemID, ok := stagedItemIDs(w, r)
+		if !ok {
+			return
+		}
+		var req updateStagedItemRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if msg := validateStagedItemPatch(req); msg != "" {
+			jsonError(r.Context(), w, msg, http.StatusBadRequest)
+			return
+		}
+
+		item, err := ingest.EditStagedItem(r.Context(), jobID, itemID, pantry, service.StagedItemPatch(req))
+		switch {
+		case err == nil:
+			jsonOK(w, model.FromStagedItem(item))
+		case errors.Is(err, sql.ErrNoRows):
+			jsonError(r.Context(), w, "staged item not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrJobNotStaged):
+			jsonError(r.Context(), w, err.Error(), http.StatusConflict)
+		case errors.Is(err, service.ErrUnknownIngredient):
+			jsonError(r.Context(), w, "unknown ingredient_id", http.StatusUnprocessableEntity)
+		default:
+			jsonError(r.Context(), w, "failed to update staged item", http.StatusInternalServerError, err)
+		}
+	}
+}
+
+// validateStagedItemPatch applies the pantry PATCH rules to a staged item
+// edit, returning a message for the client when it is invalid.
+func validateStagedItemPatch(req updateStagedItemRequest) string {
+	if req.IngredientID == nil && req.Quantity == nil && req.Unit == nil {
+		return "at least one of ingredient_id, quantity, unit is required"
+	}
+	if req.Quantity != nil {
+		if *req.Quantity <= 0 {
+			return "quantity must be positive"
+		}
+		if *req.Quantity > service.MaxQuantity {
+			return "quantity is too large"
+		}
+	}
+	if req.Unit != nil && strings.TrimSpace(*req.Unit) == "" {
+		return "unit must not be empty"
+	}
+	return ""
+}
+
+// --- DELETE /pantry/ingest/:job_id/items/:item_id ---
+
+func handleDeleteStagedItem(ingest *service.IngestService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID, itemID, ok := stagedItemIDs(w, r)
+		if !ok {
+			return
+		}
+		err := ingest.DeleteStagedItem(r.Context(), jobID, itemID)
+		switch {
+		case err == nil:
+			w.WriteHeader(http.StatusNoContent)
+		case errors.Is(err, sql.ErrNoRows):
+			jsonError(r.Context(), w, "staged item not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrJobNotStaged):
+			jsonError(r.Context(), w, err.Error(), http.StatusConflict)
+		default:
+			jsonError(r.Context(), w, "failed to delete staged item", http.StatusInternalServerError, err)
+		}
+	}
+}
+
+// stagedItemIDs parses the job_id and item_id URL parameters, answering 400
+// when either is malformed.
+func stagedItemIDs(w http.ResponseWriter, r *http.Request) (jobID, itemID uuid.UUID, ok bool) {
+	jobID, err := uuid.Parse(chi.URLParam(r, "job_id"))
+	if err != nil {
+		jsonError(r.Context(), w, "invalid job_id", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+	itemID, err = uuid.Parse(chi.URLParam(r, "item_id"))
+	if err != nil {
+		jsonError(r.Context(), w, "invalid item_id", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return jobID, itemID, true
+}
+
+// --- POST /pantry/ingest/:job_id/cancel ---
+
+func handleCancelJob(ingest *service.IngestService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID, err := uuid.Parse(chi.URLParam(r, "job_id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid job_id", http.StatusBadRequest)
+			return
+		}
+
+		job, err := ingest.CancelJob(r.Context(), jobID)
+		switch {
+		case err == nil:
+			jsonOK(w, map[string]any{"job_id": job.ID, "status": job.Status})
+		case errors.Is(err, sql.ErrNoRows):
+			jsonError(r.Context(), w, "job not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrJobNotCancellable):
+			jsonError(r.Context(), w, err.Error(), http.StatusConflict)
+		default:
+			jsonError(r.Context(), w, "failed to cancel job", http.StatusInternalServerError, err)
+		}
+	}
+}
+
+// --- POST /pantry/ingest/:job_id/retry ---
+
+func handleRetryJob(ingest *service.IngestService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID, err := uuid.Parse(chi.URLParam(r, "job_id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid job_id", http.StatusBadRequest)
+			return
+		}
+
+		job, err := ingest.RetryJob(r.Context(), jobID)
+		switch {
+		case err == nil:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(map[string]any{"job_id": job.ID, "status": job.Status}) //nolint:errcheck
+		case errors.Is(err, sql.ErrNoRows):
+			jsonError(r.Context(), w, "job not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrJobNotFailed):
+			jsonError(r.Context(), w, err.Error(), http.StatusConflict)
+		case errors.Is(err, service.ErrIngestQueueFull):
+			w.Header().Set("Retry-After", ingestRetryAfter)
+			jsonError(r.Context(), w, "ingest queue is full; retry later", http.StatusServiceUnavailable)
+		default:
+			jsonError(r.Context(), w, "failed to retry job", http.StatusInternalServerError, err)
+		}
+	}
+}
+
+// --- POST /pantry/ingest/:job_id/reject ---
+
+func handleRejectJob(ingest *service.IngestService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID, err := uuid.Parse(chi.URLParam(r, "job_id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid job_id", http.StatusBadRequest)
+			return
+		}
+
+		job, err := ingest.RejectJob(r.Context(), jobID)
+		switch {
+		case err == nil:
+			jsonOK(w, map[string]any{"job_id": job.ID, "status": job.Status})
+		case errors.Is(err, sql.ErrNoRows):
+			jsonError(r.Context(), w, "job not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrJobNotRejectable):
+			jsonError(r.Context(), w, err.Error(), http.StatusConflict)
+		default:
+			jsonError(r.Context(), w, "failed to reject job", http.StatusInternalServerError, err)
+		}
+	}
+}
+
 // --- POST /pantry/ingest/:job_id/confirm ---
 
 type confirmRequest struct {
@@ -109,15 +562,40 @@ func handleConfirmJob(pantry *service.PantryService, ingest *service.IngestServi
 			}
 		}
 
-		if err := ingest.ConfirmJob(r.Context(), jobID, pantry, req.Overrides); err != nil {
+		confirmed, err := ingest.ConfirmJob(r.Context(), jobID, pantry, req.Overrides)
+		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
 				jsonError(r.Context(), w, "job not found", http.StatusNotFound)
 				return
 			}
+			if errors.Is(err, service.ErrInvalidOverride) {
+				jsonError(r.Context(), w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			var conflict *service.UnitConflictError
+			if errors.As(err, &conflict) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck
+					"error":          conflict.Error(),
+					"unit_conflicts": conflict.Conflicts,
+				})
+				return
+			}
 			jsonError(r.Context(), w, err.Error(), http.StatusUnprocessableEntity)
 			return
 		}
 
-		w.WriteHeader(http.StatusNoContent)
+		results := make([]batchResult, len(confirmed))
+		for i, c := range confirmed {
+			results[i] = batchResult{Index: i, Status: http.StatusOK, Ref: &c.StagedItemID}
+			if c.PantryItemID.Valid {
+				results[i].ID = &c.PantryItemID.UUID
+			} else {
+				results[i].Status = http.StatusUnprocessableEntity
+				results[i].Error = c.Skipped
+			}
+		}
+		writeBatch(w, results)
 	}
 }
@@ -1,9 +1,14 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -65,6 +70,8 @@ func TestPostIngest_Success(t *testing.T) {
 	mockQ.EXPECT().CreateIngestionJob(mock.Anything, db.CreateIngestionJobParams{
 		Type:     "text_blob",
 		RawInput: "2 cups flour",
+		Source:   "api",
+		Priority: "interactive",
 	}).Return(db.IngestionJob{
 		ID:        jobID,
 		Type:      "text_blob",
@@ -74,7 +81,9 @@ func TestPostIngest_Success(t *testing.T) {
 	}, nil)
 
 	// ProcessJobAsync runs in a goroutine — set up optional expectations
-	mockQ.On("UpdateIngestionJobStatus", mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil).Maybe()
+	mockQ.On("TransitionIngestionJobStatus", mock.Anything, mock.Anything).
+		Return(db.IngestionJob{}, nil).Maybe()
+	mockQ.On("UpsertIngestionJobTimings", mock.Anything, mock.Anything).Return(nil).Maybe()
 
 	body := `{"content":"2 cups flour"}`
 	req := httptest.NewRequest(http.MethodPost, "/pantry/ingest", strings.NewReader(body))
@@ -91,6 +100,263 @@ func TestPostIngest_Success(t *testing.T) {
 	assert.Equal(t, "pending", result["status"])
 }
 
+func TestPostIngest_Sync(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	ingestSvc := service.NewIngestService(mockQ, &stubResolver{}, &stubExtractor{})
+	router := NewRouter(service.NewPantryService(mockQ), ingestSvc, nil, WithSyncProcessing())
+
+	jobID := uuid.New()
+	mockQ.EXPECT().CreateIngestionJob(mock.Anything, mock.Anything).
+		Return(db.IngestionJob{ID: jobID, Status: "pending", RawInput: "2 cups flour"}, nil)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, db.TransitionIngestionJobStatusParams{
+		ToStatus:   "processing",
+		ID:         jobID,
+		FromStatus: "pending",
+	}).Return(db.IngestionJob{ID: jobID, Status: "processing"}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, db.TransitionIngestionJobStatusParams{
+		ToStatus:   "staged",
+		ID:         jobID,
+		FromStatus: "processing",
+	}).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/pantry/ingest", strings.NewReader(`{"content":"2 cups flour"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	var result map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, "staged", result["status"])
+}
+
+func TestPostIngest_QueueFull(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	ingestSvc := service.NewIngestService(mockQ, &stubResolver{}, &stubExtractor{})
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	ingestSvc.StartWorkers(ctx, 0, 0)
+	router := NewRouter(service.NewPantryService(mockQ), ingestSvc, nil)
+
+	jobID := uuid.New()
+	mockQ.EXPECT().CreateIngestionJob(mock.Anything, mock.Anything).
+		Return(db.IngestionJob{ID: jobID, Status: "pending"}, nil)
+	mockQ.EXPECT().FailIngestionJob(mock.Anything, db.FailIngestionJobParams{
+		ID:            jobID,
+		FailureReason: "ingest queue is full",
+	}).Return(1, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/pantry/ingest", strings.NewReader(`{"content":"2 cups flour"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "5", rec.Header().Get("Retry-After"))
+}
+
+type failingJobQueue struct{ err error }
+
+func (q failingJobQueue) PublishIngestRequested(context.Context, uuid.UUID) error { return q.err }
+
+func TestPostIngest_EnqueueFailure(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	ingestSvc := service.NewIngestService(mockQ, &stubResolver{}, &stubExtractor{})
+	ingestSvc.SetJobQueue(failingJobQueue{err: errors.New("outbox write failed")})
+	router := NewRouter(service.NewPantryService(mockQ), ingestSvc, nil)
+
+	jobID := uuid.New()
+	mockQ.EXPECT().CreateIngestionJob(mock.Anything, mock.Anything).
+		Return(db.IngestionJob{ID: jobID, Status: "pending"}, nil)
+	mockQ.EXPECT().FailIngestionJob(mock.Anything, db.FailIngestionJobParams{
+		ID:            jobID,
+		FailureReason: "enqueue ingest job: outbox write failed",
+	}).Return(1, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/pantry/ingest", strings.NewReader(`{"content":"2 cups flour"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Empty(t, rec.Header().Get("Retry-After"), "only a full queue is worth retrying shortly")
+	assert.NotContains(t, rec.Body.String(), "queue is full")
+}
+
+func TestPostIngest_ProviderUnhealthy(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	ingestSvc := service.NewIngestService(mockQ, &stubResolver{}, &stubExtractor{})
+	health := service.NewProviderHealth(nil, 1)
+	health.RecordFailure(errors.New("openai status 503"))
+	ingestSvc.SetProviderHealth(health)
+	router := NewRouter(service.NewPantryService(mockQ), ingestSvc, nil)
+
+	jobID := uuid.New()
+	mockQ.EXPECT().CreateIngestionJob(mock.Anything, mock.Anything).
+		Return(db.IngestionJob{ID: jobID, Status: "pending", Source: "api"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/pantry/ingest", strings.NewReader(`{"content":"2 cups flour"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	var result map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, jobID.String(), result["job_id"])
+	assert.Equal(t, "pending", result["status"])
+	assert.Equal(t, true, result["delayed"])
+
+	status, ok := ingestSvc.ProviderHealth()
+	require.True(t, ok)
+	assert.False(t, status.Healthy)
+	assert.Equal(t, 1, status.DeferredJobs)
+}
+
+func TestPostIngest_Source(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		body   string
+		header string
+		want   string
+	}{
+		{"body wins", `{"content":"milk","source":"mobile"}`, "email", "mobile"},
+		{"header fallback", `{"content":"milk"}`, "voice", "voice"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ, router := setupIngestRouter(t)
+			mockQ.EXPECT().CreateIngestionJob(mock.Anything, db.CreateIngestionJobParams{
+				Type:     "text_blob",
+				RawInput: "milk",
+				Source:   tc.want,
+				Priority: "interactive",
+			}).Return(db.IngestionJob{ID: uuid.New(), Status: "pending", Source: tc.want}, nil)
+			mockQ.On("TransitionIngestionJobStatus", mock.Anything, mock.Anything).
+				Return(db.IngestionJob{}, nil).Maybe()
+			mockQ.On("UpsertIngestionJobTimings", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockQ.On("UpsertIngestionJobTimings", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			req := httptest.NewRequest(http.MethodPost, "/pantry/ingest", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Ingest-Source", tc.header)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"source":"`+tc.want+`"`)
+		})
+	}
+}
+
+func TestPostIngest_Priority(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupIngestRouter(t)
+	mockQ.EXPECT().CreateIngestionJob(mock.Anything, db.CreateIngestionJobParams{
+		Type:     "text_blob",
+		RawInput: "50 lb rice",
+		Source:   "api",
+		Priority: "background",
+	}).Return(db.IngestionJob{ID: uuid.New(), Status: "pending", Source: "api", Priority: "background"}, nil)
+	mockQ.On("TransitionIngestionJobStatus", mock.Anything, mock.Anything).
+		Return(db.IngestionJob{}, nil).Maybe()
+	mockQ.On("UpsertIngestionJobTimings", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	for body, want := range map[string]int{
+		`{"content":"50 lb rice","priority":"background"}`: http.StatusAccepted,
+		`{"content":"50 lb rice","priority":"urgent"}`:     http.StatusBadRequest,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/pantry/ingest", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, want, rec.Code, body)
+		if want == http.StatusAccepted {
+			assert.Contains(t, rec.Body.String(), `"priority":"background"`)
+		}
+	}
+}
+
+func TestPostIngest_UnknownSource(t *testing.T) {
+	t.Parallel()
+
+	_, router := setupIngestRouter(t)
+
+	body := `{"content":"milk","source":"fax"}`
+	req := httptest.NewRequest(http.MethodPost, "/pantry/ingest", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "source must be one of")
+}
+
+func TestGetIngestJobs_FiltersBySource(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupIngestRouter(t)
+	jobID := uuid.New()
+	mockQ.EXPECT().ListIngestionJobs(mock.Anything, db.ListIngestionJobsParams{
+		Source: sql.NullString{String: "email", Valid: true},
+		Limit:  10,
+	}).Return([]db.IngestionJob{{ID: jobID, Type: "text_blob", Source: "email", Status: "staged"}}, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry/ingest?source=email&limit=10", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Jobs []map[string]any `json:"jobs"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Jobs, 1)
+	assert.Equal(t, jobID.String(), resp.Jobs[0]["job_id"])
+	assert.Equal(t, "email", resp.Jobs[0]["source"])
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry/ingest?source=fax", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetIngestStats(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupIngestRouter(t)
+	mockQ.EXPECT().IngestSourceStats(mock.Anything, mock.Anything).Return([]db.IngestSourceStatsRow{
+		{Source: "voice", Jobs: 4, StagedItems: 10, NeedsReviewItems: 4},
+		{Source: "web", Jobs: 2},
+	}, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry/ingest/stats?days=7", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Sources []service.SourceStats `json:"sources"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Sources, 2)
+	assert.Equal(t, "voice", resp.Sources[0].Source)
+	assert.InDelta(t, 0.4, resp.Sources[0].ReviewRate, 1e-9)
+	assert.Zero(t, resp.Sources[1].ReviewRate)
+}
+
 func TestPostIngest_MissingContent(t *testing.T) {
 	t.Parallel()
 
@@ -139,13 +405,26 @@ func TestGetIngestJob_Success(t *testing.T) {
 			NeedsReview:  false,
 		},
 	}
+	mockQ.EXPECT().StagedItemsFingerprint(mock.Anything, jobID).
+		Return(db.StagedItemsFingerprintRow{ItemCount: 1, Checksum: "0123456789abcdef0123"}, nil)
 	mockQ.EXPECT().ListStagedItemsByJob(mock.Anything, jobID).Return(stagedItems, nil)
+	mockQ.EXPECT().GetIngestionJobTimings(mock.Anything, jobID).Return(db.IngestionJobTiming{
+		JobID:           jobID,
+		Attempts:        2,
+		ExtractionMs:    41200,
+		ResolutionMs:    350,
+		ResolutionMaxMs: 350,
+		ResolvedItems:   1,
+		TotalMs:         41600,
+		RecordedAt:      now,
+	}, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/pantry/ingest/"+jobID.String(), nil)
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
 
 	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `W/"staged-1-0123456789abcdef"`, rec.Header().Get("ETag"))
 
 	var result map[string]any
 	err := json.Unmarshal(rec.Body.Bytes(), &result)
@@ -155,6 +434,82 @@ func TestGetIngestJob_Success(t *testing.T) {
 	items, ok := result["items"].([]any)
 	require.True(t, ok)
 	assert.Len(t, items, 1)
+	timings, ok := result["timings"].(map[string]any)
+	require.True(t, ok)
+	assert.InDelta(t, 41200, timings["extraction_ms"], 0)
+	assert.InDelta(t, 41600, timings["total_ms"], 0)
+	assert.InDelta(t, 1, timings["retries"], 0)
+}
+
+func TestGetIngestJob_ReportsTruncation(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupIngestRouter(t)
+
+	jobID := uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).
+		Return(db.IngestionJob{ID: jobID, Status: "staged", TruncatedItems: 300}, nil)
+	mockQ.EXPECT().StagedItemsFingerprint(mock.Anything, jobID).
+		Return(db.StagedItemsFingerprintRow{ItemCount: 200}, nil)
+	mockQ.EXPECT().ListStagedItemsByJob(mock.Anything, jobID).
+		Return(make([]db.StagedItem, 200), nil)
+	mockQ.EXPECT().GetIngestionJobTimings(mock.Anything, jobID).Return(db.IngestionJobTiming{}, sql.ErrNoRows)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry/ingest/"+jobID.String(), nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var result struct {
+		TruncatedItems int      `json:"truncated_items"`
+		Warnings       []string `json:"warnings"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, 300, result.TruncatedItems)
+	assert.Equal(t, []string{"extraction produced 500 items; only the first 200 were staged"}, result.Warnings)
+}
+
+func TestGetIngestJob_NotModified(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        int
+	}{
+		{name: "same tag", ifNoneMatch: `W/"pending-0-"`, want: http.StatusNotModified},
+		{name: "strong form of same tag", ifNoneMatch: `"pending-0-"`, want: http.StatusNotModified},
+		{name: "one of several", ifNoneMatch: `W/"other", W/"pending-0-"`, want: http.StatusNotModified},
+		{name: "wildcard", ifNoneMatch: `*`, want: http.StatusNotModified},
+		{name: "stale tag", ifNoneMatch: `W/"processing-0-"`, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ, router := setupIngestRouter(t)
+			jobID := uuid.New()
+			mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).
+				Return(db.IngestionJob{ID: jobID, Status: "pending"}, nil)
+			mockQ.EXPECT().StagedItemsFingerprint(mock.Anything, jobID).
+				Return(db.StagedItemsFingerprintRow{}, nil)
+			if tt.want == http.StatusOK {
+				mockQ.EXPECT().ListStagedItemsByJob(mock.Anything, jobID).Return(nil, nil)
+				mockQ.EXPECT().GetIngestionJobTimings(mock.Anything, jobID).
+					Return(db.IngestionJobTiming{}, sql.ErrNoRows)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/pantry/ingest/"+jobID.String(), nil)
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.want, rec.Code)
+			assert.Equal(t, `W/"pending-0-"`, rec.Header().Get("ETag"))
+			if tt.want == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			}
+		})
+	}
 }
 
 func TestGetIngestJob_NotFound(t *testing.T) {
@@ -202,17 +557,21 @@ func TestPostConfirmJob(t *testing.T) {
 			NeedsReview:  false,
 		},
 	}, nil)
-
-	mockQ.EXPECT().UpsertPantryItem(mock.Anything, db.UpsertPantryItemParams{
-		IngredientID: ingredientID,
-		Quantity:     2.0,
-		Unit:         "cup",
-		ExpiresAt:    sql.NullTime{},
-	}).Return(db.PantryItem{}, nil)
-
-	mockQ.EXPECT().UpdateIngestionJobStatus(mock.Anything, db.UpdateIngestionJobStatusParams{
-		ID:     jobID,
-		Status: "confirmed",
+	mockQ.EXPECT().ListPantryItemsByIngredients(mock.Anything, mock.Anything).Return(nil, nil)
+
+	mockQ.EXPECT().UpsertPantryItems(mock.Anything, db.UpsertPantryItemsParams{
+		IngredientIds:   []uuid.UUID{ingredientID},
+		Quantities:      []float64{2.0},
+		Units:           []string{"cup"},
+		ExpiresAt:       []time.Time{{}},
+		HasExpiry:       []bool{false},
+		QuantityUnknown: []bool{false},
+	}).Return([]db.PantryItem{{IngredientID: ingredientID}}, nil)
+
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, db.TransitionIngestionJobStatusParams{
+		ID:         jobID,
+		FromStatus: "staged",
+		ToStatus:   "confirmed",
 	}).Return(db.IngestionJob{}, nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/pantry/ingest/"+jobID.String()+"/confirm", nil)
@@ -220,5 +579,387 @@ func TestPostConfirmJob(t *testing.T) {
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusNoContent, rec.Code)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp struct {
+		Succeeded int `json:"succeeded"`
+		Results   []struct {
+			Status int       `json:"status"`
+			Ref    uuid.UUID `json:"ref"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.Succeeded)
+	require.Len(t, resp.Results, 1)
+	assert.Equal(t, stagedItemID, resp.Results[0].Ref)
+}
+
+func TestPostConfirmJob_PartialIsMultiStatus(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupIngestRouter(t)
+
+	jobID := uuid.New()
+	ingredientID := uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+	mockQ.EXPECT().ListStagedItemsByJob(mock.Anything, jobID).Return([]db.StagedItem{
+		{ID: uuid.New(), IngredientID: uuid.NullUUID{UUID: ingredientID, Valid: true}, Quantity: 1, Unit: "l"},
+		{ID: uuid.New(), RawText: "mystery", Quantity: 1, Unit: "piece"},
+	}, nil)
+	mockQ.EXPECT().ListPantryItemsByIngredients(mock.Anything, []uuid.UUID{ingredientID}).Return(nil, nil)
+	mockQ.EXPECT().UpsertPantryItems(mock.Anything, mock.Anything).
+		Return([]db.PantryItem{{ID: uuid.New(), IngredientID: ingredientID}}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/pantry/ingest/"+jobID.String()+"/confirm", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusMultiStatus, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"failed":1`)
+	assert.Contains(t, rec.Body.String(), `"error":"no ingredient_id resolved"`)
+}
+
+func TestPostReextractItem_NothingExtracted(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupIngestRouter(t)
+
+	jobID := uuid.New()
+	itemID := uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+	mockQ.EXPECT().GetStagedItem(mock.Anything, itemID).
+		Return(db.StagedItem{ID: itemID, JobID: jobID, RawText: "??"}, nil)
+
+	path := "/pantry/ingest/" + jobID.String() + "/items/" + itemID.String() + "/reextract"
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"hint":"this is a spice"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
+func TestPostConfirmJob_InvalidOverrideQuantity(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupIngestRouter(t)
+	jobID := uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+
+	body := `{"overrides":[{"staged_item_id":"` + uuid.NewString() + `","quantity":-1}]}`
+	req := httptest.NewRequest(http.MethodPost, "/pantry/ingest/"+jobID.String()+"/confirm", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid override")
+}
+
+func TestPostReextractItem_Errors(t *testing.T) {
+	t.Parallel()
+
+	jobID := uuid.New()
+	itemID := uuid.New()
+	jobWithStatus := func(status string) db.IngestionJob { return db.IngestionJob{ID: jobID, Status: status} }
+
+	tests := []struct {
+		name   string
+		setup  func(*mocks.MockQuerier)
+		status int
+	}{
+		{
+			name: "job already confirmed",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(jobWithStatus("confirmed"), nil)
+			},
+			status: http.StatusConflict,
+		},
+		{
+			name: "item belongs to another job",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(jobWithStatus("staged"), nil)
+				q.EXPECT().GetStagedItem(mock.Anything, itemID).
+					Return(db.StagedItem{ID: itemID, JobID: uuid.New()}, nil)
+			},
+			status: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ, router := setupIngestRouter(t)
+			tt.setup(mockQ)
+
+			path := "/pantry/ingest/" + jobID.String() + "/items/" + itemID.String() + "/reextract"
+			req := httptest.NewRequest(http.MethodPost, path, nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestPatchStagedItem_Errors(t *testing.T) {
+	t.Parallel()
+
+	jobID := uuid.New()
+	itemID := uuid.New()
+	tests := []struct {
+		name   string
+		body   string
+		setup  func(*mocks.MockQuerier)
+		status int
+	}{
+		{name: "empty patch", body: `{}`, status: http.StatusBadRequest},
+		{name: "zero quantity", body: `{"quantity":0}`, status: http.StatusBadRequest},
+		{name: "blank unit", body: `{"unit":" "}`, status: http.StatusBadRequest},
+		{
+			name: "job already confirmed",
+			body: `{"quantity":2}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().GetIngestionJob(mock.Anything, jobID).
+					Return(db.IngestionJob{ID: jobID, Status: "confirmed"}, nil)
+			},
+			status: http.StatusConflict,
+		},
+		{
+			name: "item not found",
+			body: `{"quantity":2}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().GetIngestionJob(mock.Anything, jobID).
+					Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+				q.EXPECT().GetStagedItem(mock.Anything, itemID).Return(db.StagedItem{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ, router := setupIngestRouter(t)
+			if tt.setup != nil {
+				tt.setup(mockQ)
+			}
+
+			path := "/pantry/ingest/" + jobID.String() + "/items/" + itemID.String()
+			req := httptest.NewRequest(http.MethodPatch, path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestPostCancelJob_Errors(t *testing.T) {
+	t.Parallel()
+
+	jobID := uuid.New()
+	tests := []struct {
+		name   string
+		setup  func(*mocks.MockQuerier)
+		status int
+	}{
+		{
+			name: "already staged",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().CancelIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{}, sql.ErrNoRows)
+				q.EXPECT().GetIngestionJob(mock.Anything, jobID).
+					Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+			},
+			status: http.StatusConflict,
+		},
+		{
+			name: "not found",
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().CancelIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{}, sql.ErrNoRows)
+				q.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ, router := setupIngestRouter(t)
+			tt.setup(mockQ)
+
+			req := httptest.NewRequest(http.MethodPost, "/pantry/ingest/"+jobID.String()+"/cancel", nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestPostConfirmJob_UnitConflict(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupIngestRouter(t)
+
+	jobID := uuid.New()
+	stagedID := uuid.New()
+	ingredientID := uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+	mockQ.EXPECT().ListStagedItemsByJob(mock.Anything, jobID).Return([]db.StagedItem{{
+		ID: stagedID, IngredientID: uuid.NullUUID{UUID: ingredientID, Valid: true},
+		RawText: "a bunch of parsley", Quantity: 1, Unit: "bunch",
+	}}, nil)
+	mockQ.EXPECT().ListPantryItemsByIngredients(mock.Anything, mock.Anything).
+		Return([]db.PantryItem{{IngredientID: ingredientID, Quantity: 30, Unit: "g"}}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/pantry/ingest/"+jobID.String()+"/confirm", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	var resp struct {
+		Error     string                 `json:"error"`
+		Conflicts []service.UnitConflict `json:"unit_conflicts"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.NotEmpty(t, resp.Error)
+	require.Len(t, resp.Conflicts, 1)
+	assert.Equal(t, service.UnitConflict{
+		StagedItemID: stagedID,
+		IngredientID: ingredientID,
+		RawText:      "a bunch of parsley",
+		StagedUnit:   "bunch",
+		PantryUnit:   "g",
+	}, resp.Conflicts[0])
+}
+
+func TestPostIngest_Photos(t *testing.T) {
+	t.Parallel()
+
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	wantInput := "data:image/png;base64," + base64.StdEncoding.EncodeToString(png)
+
+	var upload bytes.Buffer
+	mw := multipart.NewWriter(&upload)
+	part, err := mw.CreateFormFile("image", "receipt.png")
+	require.NoError(t, err)
+	_, err = part.Write(png)
+	require.NoError(t, err)
+	require.NoError(t, mw.WriteField("source", "mobile"))
+	require.NoError(t, mw.Close())
+	multipartBody := func() (io.Reader, string) {
+		return bytes.NewReader(upload.Bytes()), mw.FormDataContentType()
+	}
+	var fridgeUpload bytes.Buffer
+	fw := multipart.NewWriter(&fridgeUpload)
+	part, err = fw.CreateFormFile("image", "fridge.png")
+	require.NoError(t, err)
+	_, err = part.Write(png)
+	require.NoError(t, err)
+	require.NoError(t, fw.WriteField("type", "fridge_photo"))
+	require.NoError(t, fw.Close())
+	fridgeInput := "data:image/png;job=fridge_photo;base64," + base64.StdEncoding.EncodeToString(png)
+
+	tests := []struct {
+		name        string
+		body        func() (io.Reader, string)
+		wantStatus  int
+		wantSource  string
+		wantType    string // default receipt_image
+		wantCreated bool
+	}{
+		{
+			name: "json base64",
+			body: func() (io.Reader, string) {
+				body := `{"type":"receipt_image","content":"` + base64.StdEncoding.EncodeToString(png) + `"}`
+				return strings.NewReader(body), "application/json"
+			},
+			wantStatus: http.StatusAccepted, wantSource: "api", wantCreated: true,
+		},
+		{
+			name: "json data url",
+			body: func() (io.Reader, string) {
+				return strings.NewReader(`{"type":"receipt_image","content":"` + wantInput + `"}`), "application/json"
+			},
+			wantStatus: http.StatusAccepted, wantSource: "api", wantCreated: true,
+		},
+		{
+			name: "multipart", body: multipartBody,
+			wantStatus: http.StatusAccepted, wantSource: "mobile", wantCreated: true,
+		},
+		{
+			name: "json fridge photo",
+			body: func() (io.Reader, string) {
+				body := `{"type":"fridge_photo","content":"` + base64.StdEncoding.EncodeToString(png) + `"}`
+				return strings.NewReader(body), "application/json"
+			},
+			wantStatus: http.StatusAccepted, wantSource: "api", wantType: "fridge_photo", wantCreated: true,
+		},
+		{
+			name: "multipart fridge photo",
+			body: func() (io.Reader, string) {
+				return bytes.NewReader(fridgeUpload.Bytes()), fw.FormDataContentType()
+			},
+			wantStatus: http.StatusAccepted, wantSource: "api", wantType: "fridge_photo", wantCreated: true,
+		},
+		{
+			name: "not base64",
+			body: func() (io.Reader, string) {
+				return strings.NewReader(`{"type":"receipt_image","content":"%%%"}`), "application/json"
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "not an image",
+			body: func() (io.Reader, string) {
+				content := base64.StdEncoding.EncodeToString([]byte("%PDF-1.7"))
+				return strings.NewReader(`{"type":"receipt_image","content":"` + content + `"}`), "application/json"
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ, router := setupIngestRouter(t)
+			if tt.wantCreated {
+				jobType, input := "receipt_image", wantInput
+				if tt.wantType == "fridge_photo" {
+					jobType, input = tt.wantType, fridgeInput
+				}
+				mockQ.EXPECT().CreateIngestionJob(mock.Anything, db.CreateIngestionJobParams{
+					Type:     jobType,
+					RawInput: input,
+					Source:   tt.wantSource,
+					Priority: "interactive",
+				}).Return(db.IngestionJob{ID: uuid.New(), Status: "pending"}, nil)
+				mockQ.On("TransitionIngestionJobStatus", mock.Anything, mock.Anything).
+					Return(db.IngestionJob{}, nil).Maybe()
+				mockQ.On("UpsertIngestionJobTimings", mock.Anything, mock.Anything).Return(nil).Maybe()
+			}
+
+			body, contentType := tt.body()
+			req := httptest.NewRequest(http.MethodPost, "/pantry/ingest", body)
+			req.Header.Set("Content-Type", contentType)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestMultipart_OnlyOnIngest(t *testing.T) {
+	t.Parallel()
+
+	_, router := setupIngestRouter(t)
+	req := httptest.NewRequest(http.MethodPost, "/pantry/items", strings.NewReader("--x--"))
+	req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
 }
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// DefaultMaxBodyBytes caps request bodies on every route but those in
+// largeBodyRoutes.
+const DefaultMaxBodyBytes = 1 << 20
+
+// maxIngestBodyBytes fits a photo at its size limit, base64-encoded in JSON
+// or as a multipart upload.
+const maxIngestBodyBytes = service.MaxReceiptImageBytes/3*4 + multipartOverhead
+
+// largeBodyRoutes take bodies up to their own limit instead of the router's.
+var largeBodyRoutes = map[string]int64{
+	http.MethodPost + " /pantry/ingest": maxIngestBodyBytes,
+}
+
+// WithMaxBodyBytes caps request bodies at n bytes instead of
+// DefaultMaxBodyBytes. Ingest keeps the larger limit a photo needs.
+func WithMaxBodyBytes(n int64) Option {
+	return func(o *routerOptions) { o.maxBodyBytes = n }
+}
+
+// limitBody answers 413 when a request declares a body over its route's
+// limit, and stops reading one that grows past it without declaring its
+// length; the handler then fails to decode it.
+func limitBody(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := limit
+			if large, ok := largeBodyRoutes[r.Method+" "+r.URL.Path]; ok {
+				n = large
+			}
+			if r.ContentLength > n {
+				jsonError(r.Context(), w, fmt.Sprintf("request body is larger than %d bytes", n),
+					http.StatusRequestEntityTooLarge)
+				return
+			}
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(w, r.Body, n)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+func TestLimitBody(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	router := NewRouter(
+		service.NewPantryService(mockQ),
+		service.NewIngestService(mockQ, &stubResolver{}, &stubExtractor{}),
+		nil,
+		WithMaxBodyBytes(64),
+	)
+	// Valid apart from its size: past the limit nothing reaches the mocks.
+	big := `{"ingredient_id":"` + uuid.NewString() + `","quantity":1,"unit":"` + strings.Repeat("g", 100) + `"}`
+
+	tests := []struct {
+		name    string
+		target  string
+		chunked bool
+		want    int
+	}{
+		{name: "declared length over the limit", target: "/pantry/items", want: http.StatusRequestEntityTooLarge},
+		{name: "undeclared length stops at the limit", target: "/pantry/items", chunked: true,
+			want: http.StatusBadRequest},
+		{name: "ingest keeps the photo limit", target: "/pantry/ingest", want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(big))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code, rec.Body.String())
+		})
+	}
+}
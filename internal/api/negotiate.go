@@ -0,0 +1,145 @@
+package api
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	mediaJSON           = "application/json"
+	mediaCSV            = "text/csv"
+	mediaMultipart      = "multipart/form-data"
+	mediaPrometheusText = "text/plain"
+	mediaOpenMetrics    = "application/openmetrics-text"
+)
+
+// multipartRoutes also accept multipart/form-data uploads.
+var multipartRoutes = map[string]bool{
+	http.MethodPost + " /pantry/ingest": true,
+}
+
+// multipartOverhead allows for multipart boundaries and form fields on top
+// of an upload's size limit.
+const multipartOverhead = 1 << 20
+
+// requireJSONBody rejects requests that carry a body in anything but JSON.
+// Every endpoint that reads a body decodes JSON, so this runs router-wide;
+// multipartRoutes may take a file upload instead.
+func requireJSONBody(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != 0 && r.Body != nil && r.Body != http.NoBody && !isMultipart(r) {
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || (mediaType != mediaJSON && !strings.HasSuffix(mediaType, "+json")) {
+				jsonError(r.Context(), w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isMultipart reports whether r is a multipart upload to a route that
+// accepts one.
+func isMultipart(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == mediaMultipart && multipartRoutes[r.Method+" "+r.URL.Path]
+}
+
+// produces rejects requests whose Accept header matches none of offers with
+// 406. Handlers offering more than one type pick with negotiate.
+func produces(offers ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if negotiate(r, offers...) == "" {
+				jsonError(r.Context(), w, "not acceptable; supported: "+strings.Join(offers, ", "),
+					http.StatusNotAcceptable)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// wantsCSV reports whether r negotiates to the CSV export.
+func wantsCSV(r *http.Request) bool {
+	return negotiate(r, mediaJSON, mediaCSV) == mediaCSV
+}
+
+// negotiate returns the offer the Accept header prefers, the first offer when
+// there is no Accept header, or "" when none is acceptable. Ties go to the
+// earlier offer, so JSON stays the default for "*/*".
+func negotiate(r *http.Request, offers ...string) string {
+	accept := r.Header.Get("Accept")
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		if q := acceptQuality(accept, offer); q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best
+}
+
+// acceptQuality returns the q-value the Accept header gives offer, using the
+// most specific matching range.
+func acceptQuality(accept, offer string) float64 {
+	offerType, _, _ := strings.Cut(offer, "/")
+	q, specificity := 0.0, -1
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		s := -1
+		switch mediaRange {
+		case offer:
+			s = 2
+		case offerType + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		}
+		if s <= specificity {
+			continue
+		}
+		specificity, q = s, 1
+		if v, ok := params["q"]; ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+	}
+	return q
+}
+
+// methodNotAllowed answers 405 in JSON with an Allow header listing the
+// methods the path does support. chi's default sets Allow but replies in
+// plain text, and a custom handler does not get the list, so it is recomputed
+// from the routes.
+func methodNotAllowed(routes chi.Routes) http.HandlerFunc {
+	methods := []string{
+		http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+		http.MethodPatch, http.MethodDelete, http.MethodOptions,
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		var allowed []string
+		for _, m := range methods {
+			if routes.Match(chi.NewRouteContext(), m, r.URL.Path) {
+				allowed = append(allowed, m)
+			}
+		}
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		jsonError(r.Context(), w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func notFound(w http.ResponseWriter, r *http.Request) {
+	jsonError(r.Context(), w, "not found", http.StatusNotFound)
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+func TestNegotiate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", mediaJSON},
+		{"*/*", mediaJSON},
+		{"text/csv", mediaCSV},
+		{"text/*", mediaCSV},
+		{"application/json;q=0.5, text/csv", mediaCSV},
+		{"text/csv;q=0.2, */*;q=0.5", mediaJSON},
+		{"text/html", ""},
+		{"application/json;q=0", ""},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept", tt.accept)
+		assert.Equal(t, tt.want, negotiate(r, mediaJSON, mediaCSV), "Accept: %q", tt.accept)
+	}
+}
+
+func TestRouter_RejectsNonJSONBody(t *testing.T) {
+	t.Parallel()
+
+	_, router := setupRouter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/pantry/items", strings.NewReader("name=garlic"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+}
+
+func TestRouter_MethodNotAllowedListsAllow(t *testing.T) {
+	t.Parallel()
+
+	_, router := setupRouter(t)
+
+	req := httptest.NewRequest(http.MethodPut, "/pantry/items/"+uuid.NewString(), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "PATCH, DELETE", rec.Header().Get("Allow"))
+	assert.Contains(t, rec.Body.String(), "method not allowed")
+}
+
+func TestRouter_NotFoundIsJSON(t *testing.T) {
+	t.Parallel()
+
+	_, router := setupRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/nope", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+}
+
+func TestRouter_NotAcceptable(t *testing.T) {
+	t.Parallel()
+
+	_, router := setupRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/pantry/items/"+uuid.NewString()+"/lots", nil)
+	req.Header.Set("Accept", "text/csv")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+}
+
+func TestGetPantry_CSV(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	item := db.PantryItem{
+		ID: uuid.New(), IngredientID: uuid.New(), Quantity: 1.5, Unit: "kg", AddedAt: now, UpdatedAt: now,
+	}
+	mockQ.EXPECT().ListPantryItems(mock.Anything).Return([]db.PantryItem{item}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/pantry", nil)
+	req.Header.Set("Accept", "text/csv")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	rows, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{
+		item.ID.String(), item.IngredientID.String(), "1.5", "kg", "", "2026-03-01T12:00:00Z", "2026-03-01T12:00:00Z",
+		"false",
+	}, rows[1])
+}
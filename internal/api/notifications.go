@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// --- GET /pantry/notification-preferences ---
+
+func handleListNotificationPreferences(n *service.NotificationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prefs, err := n.Preferences(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to list notification preferences", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{"channels": n.Channels(), "preferences": prefs})
+	}
+}
+
+// --- PUT /pantry/notification-preferences/:kind ---
+
+type notificationPreferenceRequest struct {
+	Channel string `json:"channel"` // email|push|webhook|none
+	Target  string `json:"target"`  // address, URL or push token; omitted for none
+	Secret  string `json:"secret"`  // webhook only: signs payloads with HMAC-SHA256
+}
+
+func handleSetNotificationPreference(n *service.NotificationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req notificationPreferenceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Channel == "" {
+			jsonError(r.Context(), w, "channel is required", http.StatusBadRequest)
+			return
+		}
+
+		pref, err := n.SetPreference(r.Context(), chi.URLParam(r, "kind"), req.Channel, req.Target, req.Secret)
+		switch {
+		case err == nil:
+			jsonOK(w, pref)
+		case errors.Is(err, service.ErrInvalidPreference):
+			jsonError(r.Context(), w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrChannelUnavailable):
+			jsonError(r.Context(), w, err.Error(), http.StatusUnprocessableEntity)
+		default:
+			jsonError(r.Context(), w, "failed to save notification preference", http.StatusInternalServerError, err)
+		}
+	}
+}
+
+// --- GET /admin/notifications ---
+
+func handleListNotifications(n *service.NotificationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := service.DefaultNotificationListLimit
+		if v := r.URL.Query().Get("limit"); v != "" {
+			l, err := strconv.Atoi(v)
+			if err != nil || l <= 0 || l > service.MaxNotificationListLimit {
+				jsonError(r.Context(), w,
+					fmt.Sprintf("limit must be between 1 and %d", service.MaxNotificationListLimit),
+					http.StatusBadRequest)
+				return
+			}
+			limit = l
+		}
+
+		notifications, err := n.Recent(r.Context(), limit)
+		if err != nil {
+			jsonError(r.Context(), w, "failed to list notifications", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{"notifications": notifications})
+	}
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/notify"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+func withNotifications(q *mocks.MockQuerier, dict *clients.DictionaryClient) Option {
+	return WithNotifications(service.NewNotificationService(q, dict, map[string]service.NotificationSender{
+		"webhook": notify.NewWebhookSender(nil),
+	}))
+}
+
+func TestGetNotificationPreferences(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withNotifications)
+	mockQ.EXPECT().ListNotificationPreferences(mock.Anything).Return(nil, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry/notification-preferences", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Channels    []string                         `json:"channels"`
+		Preferences []service.NotificationPreference `json:"preferences"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, []string{"none", "webhook"}, resp.Channels)
+	require.Len(t, resp.Preferences, 2)
+	assert.Equal(t, "none", resp.Preferences[0].Channel)
+}
+
+func TestPutNotificationPreference(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withNotifications)
+
+	put := func(kind, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/pantry/notification-preferences/"+kind, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusBadRequest, put("expiring", `{}`).Code)
+	assert.Equal(t, http.StatusBadRequest, put("birthdays", `{"channel":"none"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, put("expiring", `{"channel":"webhook","target":"not a url"}`).Code)
+	rec := put("expiring", `{"channel":"email","target":"me@example.com"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, "no SMTP relay configured")
+
+	mockQ.EXPECT().UpsertNotificationPreference(mock.Anything, db.UpsertNotificationPreferenceParams{
+		Kind: "low_stock", Channel: "webhook", Target: "https://example.com/hook",
+	}).Return(db.NotificationPreference{Kind: "low_stock", Channel: "webhook", Target: "https://example.com/hook"}, nil)
+	rec = put("low_stock", `{"channel":"webhook","target":"https://example.com/hook"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"channel":"webhook"`)
+
+	mockQ.EXPECT().UpsertNotificationPreference(mock.Anything, db.UpsertNotificationPreferenceParams{
+		Kind: "expiring", Channel: "webhook", Target: "https://example.com/hook", Secret: "s3cret",
+	}).Return(db.NotificationPreference{
+		Kind: "expiring", Channel: "webhook", Target: "https://example.com/hook", Secret: "s3cret",
+	}, nil)
+	rec = put("expiring", `{"channel":"webhook","target":"https://example.com/hook","secret":"s3cret"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"signed":true`)
+	assert.NotContains(t, rec.Body.String(), "s3cret")
+}
+
+func TestGetNotifications(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withNotifications)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/notifications?limit=0", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	mockQ.EXPECT().ListRecentNotifications(mock.Anything, int32(service.DefaultNotificationListLimit)).
+		Return([]db.Notification{{ID: 7, Kind: "expiring", SentAt: sql.NullTime{Valid: true}}}, nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/notifications", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"sent"`)
+}
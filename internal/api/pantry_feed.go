@@ -0,0 +1,87 @@
+package api
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+const (
+	// feedBuffer is how many updates a WebSocket client may fall behind
+	// before it is disconnected.
+	feedBuffer = 16
+	// feedPingInterval keeps idle connections open through proxies and
+	// detects clients that went away without closing.
+	feedPingInterval = 30 * time.Second
+	feedWriteTimeout = 10 * time.Second
+)
+
+// WithPantryFeed mounts GET /pantry/ws, a WebSocket that pushes each
+// pantry.updated payload published through f.
+func WithPantryFeed(f *service.PantryFeed) Option {
+	return func(o *routerOptions) { o.feed = f }
+}
+
+// --- GET /pantry/ws ---
+
+func handlePantryFeed(feed *service.PantryFeed) http.HandlerFunc {
+	// Any origin is accepted: the route is already behind bearer auth when
+	// that is on, and the feed only carries item IDs.
+	server := websocket.Server{
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler:   func(ws *websocket.Conn) { streamPantryFeed(ws, feed) },
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			w.Header().Set("Upgrade", "websocket")
+			jsonError(r.Context(), w, "websocket upgrade required", http.StatusUpgradeRequired)
+			return
+		}
+		server.ServeHTTP(w, r)
+	}
+}
+
+func streamPantryFeed(ws *websocket.Conn, feed *service.PantryFeed) {
+	defer ws.Close()
+	updates, unsubscribe := feed.Subscribe(feedBuffer)
+	defer unsubscribe()
+
+	// Reading answers the client's pings and notices its close frame; the
+	// feed ignores anything the client sends.
+	closed := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(io.Discard, ws)
+		close(closed)
+	}()
+
+	ping := time.NewTicker(feedPingInterval)
+	defer ping.Stop()
+	// Write sends pings; JSON.Send chooses its own frame type.
+	ws.PayloadType = websocket.PingFrame
+	for {
+		select {
+		case <-closed:
+			return
+		case update, ok := <-updates:
+			if !ok {
+				slog.Warn("pantry feed client fell behind; disconnecting", "remote", ws.Request().RemoteAddr)
+				return
+			}
+			_ = ws.SetWriteDeadline(time.Now().Add(feedWriteTimeout))
+			if err := websocket.JSON.Send(ws, update); err != nil {
+				return
+			}
+		case <-ping.C:
+			_ = ws.SetWriteDeadline(time.Now().Add(feedWriteTimeout))
+			if _, err := ws.Write(nil); err != nil {
+				return
+			}
+		}
+	}
+}
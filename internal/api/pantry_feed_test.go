@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+func TestPantryFeed(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	feed := service.NewPantryFeed()
+	srv := httptest.NewServer(NewRouter(
+		service.NewPantryService(mockQ),
+		service.NewIngestService(mockQ, &stubResolver{}, &stubExtractor{}),
+		clients.NewDictionaryClient("http://dictionary.invalid", nil),
+		WithPantryFeed(feed),
+	))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/pantry/ws")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUpgradeRequired, resp.StatusCode)
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/pantry/ws", "", srv.URL)
+	require.NoError(t, err)
+	defer ws.Close()
+	require.Eventually(t, func() bool { return feed.Subscribers() == 1 }, time.Second, 5*time.Millisecond)
+
+	id := uuid.New()
+	require.NoError(t, feed.PublishPantryUpdated(context.Background(), []uuid.UUID{id}))
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(time.Second)))
+	var got service.PantryUpdate
+	require.NoError(t, websocket.JSON.Receive(ws, &got))
+	assert.Equal(t, 1, got.SchemaVersion)
+	assert.Equal(t, []uuid.UUID{id}, got.ChangedItemIDs)
+
+	require.NoError(t, ws.Close())
+	assert.Eventually(t, func() bool { return feed.Subscribers() == 0 }, time.Second, 5*time.Millisecond)
+}
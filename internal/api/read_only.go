@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// readOnlyRetryAfter is sent with rejected writes. Maintenance has no known
+// end, so clients are asked to back off for a few minutes at a time.
+const readOnlyRetryAfter = "300"
+
+// readOnlyAllowed are the writes that still run in read-only mode: POST
+// lookups and previews that only read, the toggle itself, and the
+// maintenance tasks and reconciliation checks that leave data untouched.
+var readOnlyAllowed = map[string]bool{
+	http.MethodPost + " /pantry/items/lookup":               true,
+	http.MethodPost + " /pantry/items/preview":              true,
+	http.MethodPut + " /admin/read-only":                    true,
+	http.MethodPost + " /admin/maintenance/analyze":         true,
+	http.MethodPost + " /admin/maintenance/reindex":         true,
+	http.MethodPost + " /admin/maintenance/integrity-check": true,
+	http.MethodPost + " /admin/reconciliation":              true,
+}
+
+// WithReadOnly rejects writes with 503 while m is enabled and mounts the
+// /admin/read-only endpoints.
+func WithReadOnly(m *service.ReadOnlyMode) Option {
+	return func(o *routerOptions) { o.readOnly = m }
+}
+
+// rejectWritesWhenReadOnly answers every request but GET, HEAD, OPTIONS and
+// readOnlyAllowed with 503 and the mode's message while m is enabled.
+func rejectWritesWhenReadOnly(m *service.ReadOnlyMode) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+				return
+			}
+			state := m.State()
+			if !state.Enabled || readOnlyAllowed[r.Method+" "+r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+			// Planned maintenance does not spend the error budget.
+			if shed, ok := r.Context().Value(shedKey{}).(*bool); ok {
+				*shed = true
+			}
+			w.Header().Set("Retry-After", readOnlyRetryAfter)
+			jsonError(r.Context(), w, state.Message, http.StatusServiceUnavailable)
+		})
+	}
+}
+
+// --- GET /admin/read-only ---
+
+func handleGetReadOnly(m *service.ReadOnlyMode) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jsonOK(w, m.State())
+	}
+}
+
+// --- PUT /admin/read-only ---
+
+type readOnlyRequest struct {
+	Enabled *bool  `json:"enabled"`
+	Message string `json:"message"`
+}
+
+func handleSetReadOnly(m *service.ReadOnlyMode) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req readOnlyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Enabled == nil {
+			jsonError(r.Context(), w, "enabled is required", http.StatusBadRequest)
+			return
+		}
+		state := m.Set(*req.Enabled, req.Message)
+		slog.Default().InfoContext(r.Context(), "read-only mode switched",
+			"enabled", state.Enabled, "message", state.Message)
+		jsonOK(w, state)
+	}
+}
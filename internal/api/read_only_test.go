@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+func withReadOnly(mode *service.ReadOnlyMode) routerOption {
+	return func(*mocks.MockQuerier, *clients.DictionaryClient) Option { return WithReadOnly(mode) }
+}
+
+func TestReadOnly_RejectsWrites(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withReadOnly(service.NewReadOnlyMode(true, "restoring from backup")))
+
+	for _, tc := range []struct{ method, target, body string }{
+		{http.MethodPost, "/pantry/items", `{"name":"flour","quantity":1,"unit":"kg"}`},
+		{http.MethodPost, "/pantry/ingest", `{"type":"text_blob","content":"2 eggs"}`},
+		{http.MethodDelete, "/pantry/reset", ""},
+	} {
+		req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code, tc.target)
+		assert.Equal(t, readOnlyRetryAfter, rec.Header().Get("Retry-After"))
+		assert.Contains(t, rec.Body.String(), "restoring from backup")
+	}
+
+	// Reads, including POST lookups, still work.
+	mockQ.EXPECT().ListPantryItems(mock.Anything).Return([]db.PantryItem{}, nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockQ.EXPECT().ListPantryItemsByIngredients(mock.Anything, mock.Anything).Return([]db.PantryItem{}, nil)
+	req := httptest.NewRequest(http.MethodPost, "/pantry/items/lookup",
+		strings.NewReader(`{"ingredient_ids":["`+goldenIngredient.String()+`"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestReadOnly_Toggle(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withReadOnly(service.NewReadOnlyMode(false, "")))
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/admin/read-only", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	deleteItem := func() int {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/pantry/items/"+goldenItemID.String(), nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusBadRequest, put(`{"message":"no flag"}`).Code)
+
+	rec := put(`{"enabled":true}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var state service.ReadOnlyState
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&state))
+	assert.True(t, state.Enabled)
+	assert.Equal(t, service.DefaultReadOnlyMessage, state.Message)
+	assert.Equal(t, http.StatusServiceUnavailable, deleteItem())
+
+	// The toggle itself stays writable so the mode can be switched off.
+	require.Equal(t, http.StatusOK, put(`{"enabled":false}`).Code)
+	mockQ.EXPECT().DeletePantryItem(mock.Anything, goldenItemID).Return(nil)
+	assert.Equal(t, http.StatusNoContent, deleteItem())
+}
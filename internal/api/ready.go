@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ReadinessCheck probes one dependency for GET /readyz.
+type ReadinessCheck struct {
+	Name  string
+	Check func(context.Context) error
+}
+
+// WithReadiness mounts GET /readyz, which runs every check and answers 503
+// when any fails so Kubernetes stops routing traffic to the pod.
+func WithReadiness(checks ...ReadinessCheck) Option {
+	return func(o *routerOptions) { o.readiness = checks }
+}
+
+// readinessTimeout bounds every check, inside the probe timeout the
+// deployment gives the kubelet.
+const readinessTimeout = 2 * time.Second
+
+type readinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// --- GET /readyz ---
+
+// handleReady runs the checks concurrently. The response names each
+// dependency "ok" or "unavailable"; failure details go to the log only,
+// since the route is unauthenticated.
+func handleReady(checks []ReadinessCheck) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+
+		resp := readinessResponse{Status: "ready", Checks: make(map[string]string, len(checks))}
+		var (
+			mu sync.Mutex
+			wg sync.WaitGroup
+		)
+		for _, c := range checks {
+			wg.Go(func() {
+				err := c.Check(ctx)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					slog.WarnContext(ctx, "readiness check failed", "dependency", c.Name, "error", err)
+					resp.Checks[c.Name] = "unavailable"
+					resp.Status = "not_ready"
+					return
+				}
+				resp.Checks[c.Name] = "ok"
+			})
+		}
+		wg.Wait()
+
+		if resp.Status != "ready" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		jsonOK(w, resp)
+	}
+}
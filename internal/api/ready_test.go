@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+func TestReady_ReportsEachDependency(t *testing.T) {
+	t.Parallel()
+
+	ok := func(context.Context) error { return nil }
+	tests := []struct {
+		name     string
+		rabbitmq func(context.Context) error
+		status   int
+		want     readinessResponse
+	}{
+		{
+			name:     "all up",
+			rabbitmq: ok,
+			status:   http.StatusOK,
+			want:     readinessResponse{Status: "ready", Checks: map[string]string{"postgres": "ok", "rabbitmq": "ok"}},
+		},
+		{
+			name:     "rabbitmq down",
+			rabbitmq: func(context.Context) error { return errors.New("connection refused") },
+			status:   http.StatusServiceUnavailable,
+			want: readinessResponse{
+				Status: "not_ready",
+				Checks: map[string]string{"postgres": "ok", "rabbitmq": "unavailable"},
+			},
+		},
+		{
+			name: "check outlives the timeout",
+			rabbitmq: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			status: http.StatusServiceUnavailable,
+			want: readinessResponse{
+				Status: "not_ready",
+				Checks: map[string]string{"postgres": "ok", "rabbitmq": "unavailable"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ := mocks.NewMockQuerier(t)
+			router := NewRouter(
+				service.NewPantryService(mockQ),
+				service.NewIngestService(mockQ, &stubResolver{}, &stubExtractor{}),
+				nil,
+				WithReadiness(
+					ReadinessCheck{Name: "postgres", Check: ok},
+					ReadinessCheck{Name: "rabbitmq", Check: tt.rabbitmq},
+				),
+			)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			require.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			var got readinessResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+			assert.Equal(t, tt.want, got)
+			assert.NotContains(t, rec.Body.String(), "refused", "failure details stay in the log")
+		})
+	}
+}
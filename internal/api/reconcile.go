@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// WithReconciler mounts the /admin/reconciliation endpoints and adds the
+// last run's results to /metrics.
+func WithReconciler(rc *service.Reconciler) Option {
+	return func(o *routerOptions) { o.reconciler = rc }
+}
+
+// --- GET /admin/reconciliation ---
+
+func handleGetReconciliation(rc *service.Reconciler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := rc.LastReport()
+		if report == nil {
+			jsonError(r.Context(), w, "no reconciliation has run yet", http.StatusNotFound)
+			return
+		}
+		jsonOK(w, report)
+	}
+}
+
+// --- POST /admin/reconciliation, POST /admin/reconciliation/repair ---
+
+func handleReconcile(rc *service.Reconciler, repair bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, err := rc.Reconcile(r.Context(), repair)
+		if err != nil {
+			jsonError(r.Context(), w, "failed to reconcile", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, report)
+	}
+}
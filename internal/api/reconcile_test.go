@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+	"github.com/mwhite7112/woodpantry-pantry/internal/slo"
+)
+
+func TestReconciliation_RunThenReportAndMetrics(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	pantry := service.NewPantryService(mockQ)
+	router := NewRouter(pantry, service.NewIngestService(mockQ, nil, nil), nil,
+		WithReconciler(service.NewReconciler(mockQ, pantry)),
+		WithSLO(slo.New(slo.Objective{Success: 0.99, Latency: time.Minute}, nil, time.Hour)))
+
+	item := db.PantryItem{ID: uuid.New(), IngredientID: uuid.New(), Quantity: 2, Unit: "l"}
+	mockQ.EXPECT().ListPantryItems(mock.Anything).Return([]db.PantryItem{item}, nil)
+	mockQ.EXPECT().ListAllPantryLots(mock.Anything).Return(nil, nil)
+	mockQ.EXPECT().ListLotHistoryTotals(mock.Anything).Return([]db.ListLotHistoryTotalsRow{
+		{PantryItemID: item.ID, LotID: uuid.New(), Unit: "l", Quantity: 1},
+	}, nil)
+
+	serve := func(method, target, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/admin/reconciliation", "").Code)
+	assert.NotContains(t, serve(http.MethodGet, "/metrics", "").Body.String(), "pantry_reconcile_")
+
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, "/admin/reconciliation", "").Code)
+	rec := serve(http.MethodGet, "/admin/reconciliation", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"kind":"lot_missing"`)
+
+	assert.Contains(t, serve(http.MethodGet, "/metrics", "").Body.String(),
+		`pantry_reconcile_drift{kind="lot_missing"} 1`)
+	open := serve(http.MethodGet, "/metrics", "application/openmetrics-text;version=1.0.0").Body.String()
+	assert.True(t, strings.HasSuffix(open, "pantry_reconcile_repaired 0\n# EOF\n"), open)
+	assert.Equal(t, 1, strings.Count(open, "# EOF"))
+}
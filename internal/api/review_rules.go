@@ -0,0 +1,87 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// --- GET /admin/review-rules ---
+
+func handleListReviewRules(rules *service.ReviewRules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		list, err := rules.List(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to list review rules", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{"review_rules": list})
+	}
+}
+
+// --- POST /admin/review-rules ---
+
+type reviewRuleRequest struct {
+	Action        string   `json:"action"`
+	Ingredient    string   `json:"ingredient"`
+	Category      string   `json:"category"`
+	BelowQuantity *float64 `json:"below_quantity"`
+	Unit          string   `json:"unit"`
+	Description   string   `json:"description"`
+}
+
+func handleCreateReviewRule(rules *service.ReviewRules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req reviewRuleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		rule, err := rules.Create(r.Context(), service.ReviewRule{
+			Action:        req.Action,
+			Ingredient:    req.Ingredient,
+			Category:      req.Category,
+			BelowQuantity: req.BelowQuantity,
+			Unit:          req.Unit,
+			Description:   req.Description,
+		})
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidReviewRule) {
+				jsonError(r.Context(), w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			jsonError(r.Context(), w, "failed to save review rule", http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(rule) //nolint:errcheck
+	}
+}
+
+// --- DELETE /admin/review-rules/:id ---
+
+func handleDeleteReviewRule(rules *service.ReviewRules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		if err := rules.Delete(r.Context(), id); err != nil {
+			if errors.Is(err, service.ErrReviewRuleNotFound) {
+				jsonError(r.Context(), w, err.Error(), http.StatusNotFound)
+				return
+			}
+			jsonError(r.Context(), w, "failed to delete review rule", http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
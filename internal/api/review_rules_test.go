@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+func withReviewRules(q *mocks.MockQuerier, _ *clients.DictionaryClient) Option {
+	return WithReviewRules(service.NewReviewRules(q))
+}
+
+func TestPostReviewRule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		body  string
+		setup func(q *mocks.MockQuerier)
+		want  int
+	}{
+		{
+			name: "created",
+			body: `{"action":"review","ingredient":"Salmon"}`,
+			setup: func(q *mocks.MockQuerier) {
+				q.EXPECT().CreateReviewRule(mock.Anything, mock.MatchedBy(func(p db.CreateReviewRuleParams) bool {
+					return p.Action == "review" && p.Ingredient.String == "salmon"
+				})).RunAndReturn(func(_ context.Context, p db.CreateReviewRuleParams) (db.ReviewRule, error) {
+					return db.ReviewRule{ID: uuid.New(), Action: p.Action, Ingredient: p.Ingredient}, nil
+				})
+			},
+			want: http.StatusCreated,
+		},
+		{name: "no condition", body: `{"action":"review"}`, want: http.StatusBadRequest},
+		{name: "unknown action", body: `{"action":"skip","category":"spices"}`, want: http.StatusBadRequest},
+		{name: "bad body", body: `{`, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ, router := setupRouter(t, withReviewRules)
+			if tt.setup != nil {
+				tt.setup(mockQ)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/admin/review-rules", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			require.Equal(t, tt.want, rec.Code, rec.Body.String())
+			if tt.want == http.StatusCreated {
+				var rule service.ReviewRule
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rule))
+				assert.Equal(t, "salmon", rule.Ingredient)
+			}
+		})
+	}
+}
+
+func TestDeleteReviewRule(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withReviewRules)
+	mockQ.EXPECT().DeleteReviewRule(mock.Anything, mock.Anything).Return(0, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/review-rules/"+uuid.NewString(), nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/review-rules/nope", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/slo"
+)
+
+// shedRetryAfter is sent with shed responses; the budget window moves in
+// slices of a minute at the default window.
+const shedRetryAfter = "60"
+
+// shedKey carries a flag that shedLowPriority sets so trackSLO leaves shed
+// requests out of the budget.
+type shedKey struct{}
+
+// WithSLO records every routed request against t's objectives and mounts
+// GET /admin/slo and GET /metrics. When t sheds, exports and stats answer
+// 503.
+func WithSLO(t *slo.Tracker) Option {
+	return func(o *routerOptions) { o.slo = t }
+}
+
+// trackSLO records each request under its route pattern once routing is
+// done. Unrouted requests and the probe and scrape endpoints are skipped so
+// they neither pad nor spend the budget.
+func trackSLO(t *slo.Tracker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			shed := new(bool)
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), shedKey{}, shed)))
+
+			rctx := chi.RouteContext(r.Context())
+			if rctx == nil || *shed {
+				return
+			}
+			pattern := rctx.RoutePattern()
+			if pattern == "" || publicPaths[pattern] {
+				return
+			}
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			t.RecordTrace(r.Method+" "+pattern, status, time.Since(start), sampledTraceID(r))
+		})
+	}
+}
+
+// sampledTraceID returns the trace ID from r's W3C traceparent header, or ""
+// when there is none or the trace was not sampled: an exemplar pointing at
+// a trace the collector dropped leads nowhere.
+func sampledTraceID(r *http.Request) string {
+	parts := strings.Split(r.Header.Get("traceparent"), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return ""
+	}
+	traceID, parentID, flags := parts[1], parts[2], parts[3]
+	if len(traceID) != 32 || len(parentID) != 16 || len(flags) != 2 ||
+		!isLowerHex(parts[0]+traceID+parentID+flags) || strings.Trim(traceID, "0") == "" {
+		return ""
+	}
+	if f, _ := strconv.ParseUint(flags, 16, 8); f&0x01 == 0 {
+		return ""
+	}
+	return traceID
+}
+
+func isLowerHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// shedLowPriority turns away requests that low selects while the error
+// budget is nearly spent. A nil low sheds every request on the route.
+func shedLowPriority(t *slo.Tracker, low func(*http.Request) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if t == nil || (low != nil && !low(r)) || !t.Shedding() {
+				next.ServeHTTP(w, r)
+				return
+			}
+			t.RecordShed()
+			if shed, ok := r.Context().Value(shedKey{}).(*bool); ok {
+				*shed = true
+			}
+			w.Header().Set("Retry-After", shedRetryAfter)
+			jsonError(r.Context(), w, "shedding low-priority traffic while the error budget is low",
+				http.StatusServiceUnavailable)
+		})
+	}
+}
+
+// --- GET /admin/slo ---
+
+func handleGetSLO(t *slo.Tracker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jsonOK(w, t.Report())
+	}
+}
+
+// --- GET /metrics ---
+
+// handleMetrics answers in the Prometheus text format unless the scraper
+// prefers OpenMetrics, the only one of the two that carries exemplars.
+// gauges write further gauge families, which read the same in both formats;
+// in OpenMetrics they go before the closing # EOF.
+func handleMetrics(t *slo.Tracker, gauges ...func(io.Writer) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var b bytes.Buffer
+		if negotiate(r, mediaPrometheusText, mediaOpenMetrics) == mediaOpenMetrics {
+			w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+			t.WriteOpenMetrics(&b) //nolint:errcheck
+			if bytes.HasSuffix(b.Bytes(), []byte(openMetricsEOF)) {
+				b.Truncate(b.Len() - len(openMetricsEOF))
+			}
+			writeGauges(&b, gauges)
+			b.WriteString(openMetricsEOF)
+		} else {
+			w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+			t.WriteMetrics(&b) //nolint:errcheck
+			writeGauges(&b, gauges)
+		}
+		w.Write(b.Bytes()) //nolint:errcheck
+	}
+}
+
+// openMetricsEOF ends every OpenMetrics exposition.
+const openMetricsEOF = "# EOF\n"
+
+func writeGauges(w io.Writer, gauges []func(io.Writer) error) {
+	for _, g := range gauges {
+		g(w) //nolint:errcheck
+	}
+}
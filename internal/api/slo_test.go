@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/slo"
+)
+
+func withSLO(tracker *slo.Tracker) routerOption {
+	return func(*mocks.MockQuerier, *clients.DictionaryClient) Option { return WithSLO(tracker) }
+}
+
+func TestSLO_RecordsRoutePattern(t *testing.T) {
+	t.Parallel()
+
+	tracker := slo.New(slo.Objective{Success: 0.99, Latency: time.Minute}, nil, time.Hour)
+	mockQ, router := setupRouter(t, withSLO(tracker))
+	mockQ.EXPECT().GetPantryItem(mock.Anything, mock.Anything).Return(db.PantryItem{}, nil)
+	mockQ.EXPECT().ListPantryLotsByItem(mock.Anything, mock.Anything).Return([]db.PantryLot{}, nil)
+
+	for _, path := range []string{"/pantry/items/" + uuid.NewString() + "/lots", "/healthz", "/nope"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/slo", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var report slo.Report
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&report))
+	require.Len(t, report.Endpoints, 1)
+	assert.Equal(t, "GET /pantry/items/{id}/lots", report.Endpoints[0].Endpoint)
+	assert.Equal(t, int64(1), report.Endpoints[0].Requests)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `pantry_slo_requests_total{endpoint="GET /pantry/items/{id}/lots"} 1`)
+}
+
+func TestSLO_ShedsExportsWhenBudgetIsLow(t *testing.T) {
+	t.Parallel()
+
+	tracker := slo.New(slo.Objective{Success: 0.99, Latency: time.Minute}, nil, time.Hour)
+	tracker.SetShedBelow(0.5)
+	for range 100 {
+		tracker.Record("POST /pantry/items", http.StatusInternalServerError, 0)
+	}
+	mockQ, router := setupRouter(t, withSLO(tracker))
+	mockQ.EXPECT().ListPantryItems(mock.Anything).Return([]db.PantryItem{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/pantry", nil)
+	req.Header.Set("Accept", "text/csv")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry", nil))
+	assert.Equal(t, http.StatusOK, rec.Code, "the JSON listing is not low priority")
+
+	report := tracker.Report()
+	assert.Equal(t, int64(1), report.ShedRequests)
+	assert.Equal(t, int64(101), report.Requests, "the shed request is not counted against the budget")
+}
+
+func TestSampledTraceID(t *testing.T) {
+	t.Parallel()
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	for header, want := range map[string]string{
+		"00-" + traceID + "-00f067aa0ba902b7-01":                  traceID,
+		"00-" + traceID + "-00f067aa0ba902b7-00":                  "",
+		"01-" + traceID + "-00f067aa0ba902b7-03-extra":            traceID,
+		"00-" + traceID + "-00f067aa0ba902b7-01-extra":            "",
+		"ff-" + traceID + "-00f067aa0ba902b7-01":                  "",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01": "",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01": "",
+		"": "",
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/pantry/ingest", nil)
+		req.Header.Set("traceparent", header)
+		assert.Equal(t, want, sampledTraceID(req), header)
+	}
+}
+
+func TestSLO_MetricsCarryExemplars(t *testing.T) {
+	t.Parallel()
+
+	tracker := slo.New(slo.Objective{Success: 0.99, Latency: time.Minute}, nil, time.Hour)
+	tracker.SetHistograms("GET /pantry")
+	mockQ, router := setupRouter(t, withSLO(tracker))
+	mockQ.EXPECT().ListPantryItems(mock.Anything).Return([]db.PantryItem{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/pantry", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	req = httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text;version=1.0.0,text/plain;q=0.5")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/openmetrics-text")
+	assert.Contains(t, rec.Body.String(), `# {trace_id="4bf92f3577b34da6a3ce929d0e0e4736"}`)
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// WithStale mounts GET /pantry/stale.
+func WithStale(s *service.StaleService) Option {
+	return func(o *routerOptions) { o.stale = s }
+}
+
+// --- GET /pantry/stale ---
+
+func handleListStale(stale *service.StaleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, err := stale.Stale(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to list stale items", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{
+			"shelf_life_days": stale.ShelfLives(),
+			"items":           items,
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+func withStale(q *mocks.MockQuerier, _ *clients.DictionaryClient) Option {
+	return WithStale(service.NewStaleService(q, nil, map[string]int{"produce": 7}))
+}
+
+func TestListStale_EmptyIsArray(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withStale)
+	mockQ.EXPECT().ListStaleItems(mock.Anything).Return(nil, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry/stale", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp struct {
+		ShelfLifeDays map[string]int    `json:"shelf_life_days"`
+		Items         []json.RawMessage `json:"items"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, map[string]int{"produce": 7}, resp.ShelfLifeDays)
+	assert.NotNil(t, resp.Items)
+	assert.Empty(t, resp.Items)
+}
+
+func TestListStale_QueryError(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withStale)
+	mockQ.EXPECT().ListStaleItems(mock.Anything).Return(nil, errors.New("db down"))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry/stale", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestListStale_NotMountedWithoutShelfLives(t *testing.T) {
+	t.Parallel()
+
+	_, router := setupRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry/stale", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// WithTaxonomy mounts GET /pantry/taxonomy.
+func WithTaxonomy(t *service.Taxonomy) Option {
+	return func(o *routerOptions) { o.taxonomy = t }
+}
+
+// --- GET /pantry/taxonomy ---
+
+// handleGetTaxonomy serves the cached Dictionary taxonomy. Shared caches and
+// browsers may reuse it for the cache's TTL, after which a conditional
+// request costs nothing unless the Dictionary changed.
+func handleGetTaxonomy(t *service.Taxonomy) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		snap, err := t.Get(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "dictionary taxonomy is unavailable", http.StatusBadGateway, err)
+			return
+		}
+		cacheControl := fmt.Sprintf("public, max-age=%d", int(t.TTL().Seconds()))
+		if notModifiedCached(w, r, snap.ETag, cacheControl) {
+			return
+		}
+		jsonOK(w, snap)
+	}
+}
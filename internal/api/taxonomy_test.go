@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+func setupTaxonomyRouter(t *testing.T, status int) (http.Handler, *int) {
+	t.Helper()
+
+	calls := new(int)
+	dictServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		ings := []clients.Ingredient{{ID: uuid.New(), Name: "garlic", Category: "produce"}}
+		json.NewEncoder(w).Encode(ings) //nolint:errcheck
+	}))
+	t.Cleanup(dictServer.Close)
+	dict := clients.NewDictionaryClient(dictServer.URL, dictServer.Client())
+
+	mockQ := mocks.NewMockQuerier(t)
+	router := NewRouter(
+		service.NewPantryService(mockQ),
+		service.NewIngestService(mockQ, &stubResolver{}, &stubExtractor{}),
+		dict,
+		WithTaxonomy(service.NewTaxonomy(dict, 10*time.Minute)),
+	)
+	return router, calls
+}
+
+func TestGetTaxonomy_CachedAndConditional(t *testing.T) {
+	t.Parallel()
+
+	router, calls := setupTaxonomyRouter(t, http.StatusOK)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry/taxonomy", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "public, max-age=600", rec.Header().Get("Cache-Control"))
+	assert.Contains(t, rec.Body.String(), `"name":"produce"`)
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	req := httptest.NewRequest(http.MethodGet, "/pantry/taxonomy", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Equal(t, 1, *calls, "the second request is served from the cache")
+}
+
+func TestGetTaxonomy_DictionaryDown(t *testing.T) {
+	t.Parallel()
+
+	router, _ := setupTaxonomyRouter(t, http.StatusServiceUnavailable)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pantry/taxonomy", nil))
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+}
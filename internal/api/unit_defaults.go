@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+	"github.com/mwhite7112/woodpantry-pantry/internal/units"
+)
+
+// --- GET /admin/category-units ---
+
+func handleListCategoryUnits(defaults *service.UnitDefaults) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rules, err := defaults.List(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to list category units", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{
+			"default_unit":   units.DefaultCountUnit,
+			"category_units": rules,
+		})
+	}
+}
+
+// --- PUT /admin/category-units/:category ---
+
+type categoryUnitRequest struct {
+	Unit string `json:"unit"`
+}
+
+func handleSetCategoryUnit(defaults *service.UnitDefaults) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req categoryUnitRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Unit == "" {
+			jsonError(r.Context(), w, "unit is required", http.StatusBadRequest)
+			return
+		}
+
+		rule, err := defaults.Set(r.Context(), chi.URLParam(r, "category"), req.Unit)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidUnitDefault) {
+				jsonError(r.Context(), w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			jsonError(r.Context(), w, "failed to save category unit", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, rule)
+	}
+}
+
+// --- DELETE /admin/category-units/:category ---
+
+func handleDeleteCategoryUnit(defaults *service.UnitDefaults) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := defaults.Delete(r.Context(), chi.URLParam(r, "category")); err != nil {
+			if errors.Is(err, service.ErrUnitDefaultNotFound) {
+				jsonError(r.Context(), w, err.Error(), http.StatusNotFound)
+				return
+			}
+			jsonError(r.Context(), w, "failed to delete category unit", http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
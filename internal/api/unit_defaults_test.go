@@ -0,0 +1,87 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+func withUnitDefaults(q *mocks.MockQuerier, _ *clients.DictionaryClient) Option {
+	return WithUnitDefaults(service.NewUnitDefaults(q))
+}
+
+func TestGetCategoryUnits(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withUnitDefaults)
+	mockQ.EXPECT().ListCategoryDefaultUnits(mock.Anything).
+		Return([]db.CategoryDefaultUnit{{Category: "grains", Unit: "g"}}, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/category-units", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		DefaultUnit   string                 `json:"default_unit"`
+		CategoryUnits []service.CategoryUnit `json:"category_units"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "piece", resp.DefaultUnit)
+	require.Len(t, resp.CategoryUnits, 1)
+	assert.Equal(t, "g", resp.CategoryUnits[0].Unit)
+}
+
+func TestPutCategoryUnit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "canonical alias", body: `{"unit":"millilitres"}`, want: http.StatusOK},
+		{name: "unknown unit", body: `{"unit":"glug"}`, want: http.StatusBadRequest},
+		{name: "missing unit", body: `{}`, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ, router := setupRouter(t, withUnitDefaults)
+			if tt.want == http.StatusOK {
+				mockQ.EXPECT().UpsertCategoryDefaultUnit(mock.Anything, db.UpsertCategoryDefaultUnitParams{
+					Category: "beverages",
+					Unit:     "ml",
+				}).Return(db.CategoryDefaultUnit{Category: "beverages", Unit: "ml"}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/category-units/Beverages", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
+
+func TestDeleteCategoryUnit_NotFound(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withUnitDefaults)
+	mockQ.EXPECT().DeleteCategoryDefaultUnit(mock.Anything, "spices").Return(0, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/category-units/spices", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// --- GET /pantry/value ---
+
+func handleGetValue(valuation *service.ValuationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v, err := valuation.Value(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to value pantry", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, v)
+	}
+}
+
+// --- GET /admin/prices ---
+
+func handleListPrices(valuation *service.ValuationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ingredients, categories, err := valuation.ListPrices(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to list prices", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{
+			"ingredient_prices": ingredients,
+			"category_prices":   categories,
+		})
+	}
+}
+
+// --- PUT /admin/prices/ingredients/:ingredient_id ---
+// --- PUT /admin/prices/categories/:category ---
+
+type priceRequest struct {
+	Price *float64 `json:"price"`
+	Unit  string   `json:"unit"`
+}
+
+func handleSetIngredientPrice(valuation *service.ValuationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "ingredient_id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid ingredient_id", http.StatusBadRequest)
+			return
+		}
+		req, ok := decodePrice(w, r)
+		if !ok {
+			return
+		}
+		price, err := valuation.SetIngredientPrice(r.Context(), id, *req.Price, req.Unit)
+		writePrice(w, r, price, err)
+	}
+}
+
+func handleSetCategoryPrice(valuation *service.ValuationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, ok := decodePrice(w, r)
+		if !ok {
+			return
+		}
+		price, err := valuation.SetCategoryPrice(r.Context(), chi.URLParam(r, "category"), *req.Price, req.Unit)
+		writePrice(w, r, price, err)
+	}
+}
+
+func decodePrice(w http.ResponseWriter, r *http.Request) (priceRequest, bool) {
+	var req priceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+		return req, false
+	}
+	if req.Price == nil || req.Unit == "" {
+		jsonError(r.Context(), w, "price and unit are required", http.StatusBadRequest)
+		return req, false
+	}
+	return req, true
+}
+
+func writePrice(w http.ResponseWriter, r *http.Request, price service.Price, err error) {
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPrice) {
+			jsonError(r.Context(), w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		jsonError(r.Context(), w, "failed to save price", http.StatusInternalServerError, err)
+		return
+	}
+	jsonOK(w, price)
+}
+
+// --- DELETE /admin/prices/ingredients/:ingredient_id ---
+// --- DELETE /admin/prices/categories/:category ---
+
+func handleDeleteIngredientPrice(valuation *service.ValuationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "ingredient_id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid ingredient_id", http.StatusBadRequest)
+			return
+		}
+		writePriceDeleted(w, r, valuation.DeleteIngredientPrice(r.Context(), id))
+	}
+}
+
+func handleDeleteCategoryPrice(valuation *service.ValuationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writePriceDeleted(w, r, valuation.DeleteCategoryPrice(r.Context(), chi.URLParam(r, "category")))
+	}
+}
+
+func writePriceDeleted(w http.ResponseWriter, r *http.Request, err error) {
+	if err != nil {
+		if errors.Is(err, service.ErrPriceNotFound) {
+			jsonError(r.Context(), w, err.Error(), http.StatusNotFound)
+			return
+		}
+		jsonError(r.Context(), w, "failed to delete price", http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+func TestPutPrice_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		target string
+		body   string
+	}{
+		{name: "missing price", target: "/admin/prices/categories/dairy", body: `{"unit":"l"}`},
+		{name: "negative price", target: "/admin/prices/categories/dairy", body: `{"price":-1,"unit":"l"}`},
+		{name: "unknown unit", target: "/admin/prices/categories/dairy", body: `{"price":1,"unit":"handful"}`},
+		{name: "bad ingredient id", target: "/admin/prices/ingredients/nope", body: `{"price":1,"unit":"l"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ := mocks.NewMockQuerier(t)
+			router := NewRouter(
+				service.NewPantryService(mockQ),
+				service.NewIngestService(mockQ, &stubResolver{}, &stubExtractor{}),
+				nil,
+				WithValuation(service.NewValuationService(mockQ, nil)),
+			)
+			req := httptest.NewRequest(http.MethodPut, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		})
+	}
+}
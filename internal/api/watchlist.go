@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// --- GET /pantry/watchlist ---
+
+func handleListWatchlist(wl *service.WatchlistService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		entries, err := wl.List(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to list watchlist", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{"items": entries})
+	}
+}
+
+// --- POST /pantry/watchlist ---
+
+type watchRequest struct {
+	Name         string   `json:"name"`          // raw text → resolved via Dictionary
+	IngredientID string   `json:"ingredient_id"` // direct canonical ID (takes precedence)
+	MinQuantity  *float64 `json:"min_quantity"`  // optional restock threshold
+	Unit         *string  `json:"unit"`          // required with min_quantity
+}
+
+func handleWatchIngredient(wl *service.WatchlistService, dict *clients.DictionaryClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req watchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		ingredientID, ok := resolveIngredientID(w, r, dict, req.IngredientID, req.Name)
+		if !ok {
+			return
+		}
+
+		entry, err := wl.Watch(r.Context(), ingredientID, req.MinQuantity, req.Unit)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidWatch) {
+				jsonError(r.Context(), w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			jsonError(r.Context(), w, "failed to save watchlist entry", http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(entry) //nolint:errcheck
+	}
+}
+
+// --- GET /pantry/watchlist/missing ---
+
+func handleListMissingWatched(wl *service.WatchlistService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		missing, err := wl.Missing(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to list missing watched ingredients", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{"items": missing})
+	}
+}
+
+// --- DELETE /pantry/watchlist/:ingredient_id ---
+
+func handleUnwatchIngredient(wl *service.WatchlistService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "ingredient_id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid ingredient_id", http.StatusBadRequest)
+			return
+		}
+		if err := wl.Unwatch(r.Context(), id); err != nil {
+			if errors.Is(err, service.ErrWatchNotFound) {
+				jsonError(r.Context(), w, "ingredient not watched", http.StatusNotFound)
+				return
+			}
+			jsonError(r.Context(), w, "failed to remove watchlist entry", http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+func withWatchlist(q *mocks.MockQuerier, _ *clients.DictionaryClient) Option {
+	return WithWatchlist(service.NewWatchlistService(q))
+}
+
+func TestPostWatchlist(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withWatchlist)
+
+	ingredientID := uuid.New()
+	mockQ.EXPECT().UpsertWatchlistEntry(mock.Anything, db.UpsertWatchlistEntryParams{
+		IngredientID: ingredientID,
+		MinQuantity:  sql.NullFloat64{Float64: 2, Valid: true},
+		Unit:         sql.NullString{String: "l", Valid: true},
+	}).Return(db.Watchlist{
+		IngredientID: ingredientID,
+		MinQuantity:  sql.NullFloat64{Float64: 2, Valid: true},
+		Unit:         sql.NullString{String: "l", Valid: true},
+	}, nil)
+
+	body := `{"ingredient_id":"` + ingredientID.String() + `","min_quantity":2,"unit":"l"}`
+	req := httptest.NewRequest(http.MethodPost, "/pantry/watchlist", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var got service.WatchedIngredient
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, ingredientID, got.IngredientID)
+	require.NotNil(t, got.MinQuantity)
+	assert.Equal(t, 2.0, *got.MinQuantity)
+}
+
+func TestPostWatchlist_MinQuantityWithoutUnit(t *testing.T) {
+	t.Parallel()
+
+	_, router := setupRouter(t, withWatchlist)
+
+	body := `{"ingredient_id":"` + uuid.New().String() + `","min_quantity":2}`
+	req := httptest.NewRequest(http.MethodPost, "/pantry/watchlist", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "min_quantity and unit must be set together")
+}
+
+func TestGetWatchlistMissing(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withWatchlist)
+
+	absent := uuid.New()
+	low := uuid.New()
+	itemID := uuid.New()
+	mockQ.EXPECT().ListMissingWatchedIngredients(mock.Anything).Return([]db.ListMissingWatchedIngredientsRow{
+		{IngredientID: absent},
+		{
+			IngredientID:    low,
+			MinQuantity:     sql.NullFloat64{Float64: 2, Valid: true},
+			Unit:            sql.NullString{String: "l", Valid: true},
+			PantryItemID:    uuid.NullUUID{UUID: itemID, Valid: true},
+			CurrentQuantity: sql.NullFloat64{Float64: 0.5, Valid: true},
+			CurrentUnit:     sql.NullString{String: "l", Valid: true},
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/pantry/watchlist/missing", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Items []service.MissingIngredient `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Items, 2)
+	assert.Nil(t, body.Items[0].PantryItemID)
+	require.NotNil(t, body.Items[1].CurrentQuantity)
+	assert.Equal(t, 0.5, *body.Items[1].CurrentQuantity)
+}
+
+func TestDeleteWatchlist_NotFound(t *testing.T) {
+	t.Parallel()
+
+	mockQ, router := setupRouter(t, withWatchlist)
+
+	id := uuid.New()
+	mockQ.EXPECT().DeleteWatchlistEntry(mock.Anything, id).Return(0, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/pantry/watchlist/"+id.String(), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// WithWebhookSubscriptions mounts the /webhooks endpoints.
+func WithWebhookSubscriptions(s *service.WebhookService) Option {
+	return func(o *routerOptions) { o.subscriptions = s }
+}
+
+// --- GET /webhooks ---
+
+func handleListWebhookSubscriptions(s *service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		subs, err := s.List(r.Context())
+		if err != nil {
+			jsonError(r.Context(), w, "failed to list webhook subscriptions", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{"event_types": service.WebhookEventTypes, "webhooks": subs})
+	}
+}
+
+// --- GET /webhooks/:id ---
+
+func handleGetWebhookSubscription(s *service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		sub, err := s.Get(r.Context(), id)
+		if err != nil {
+			writeWebhookError(w, r, err)
+			return
+		}
+		jsonOK(w, sub)
+	}
+}
+
+// --- POST /webhooks, PUT /webhooks/:id ---
+
+type webhookSubscriptionRequest struct {
+	URL         string   `json:"url"`
+	EventTypes  []string `json:"event_types"`
+	Secret      *string  `json:"secret"` // signs payloads with HMAC-SHA256; omit on PUT to keep it
+	Description string   `json:"description"`
+	Active      *bool    `json:"active"` // default true
+}
+
+func (req webhookSubscriptionRequest) input() service.WebhookSubscriptionInput {
+	return service.WebhookSubscriptionInput(req)
+}
+
+func handleCreateWebhookSubscription(s *service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req webhookSubscriptionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		sub, err := s.Create(r.Context(), req.input())
+		if err != nil {
+			writeWebhookError(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(sub) //nolint:errcheck
+	}
+}
+
+func handleUpdateWebhookSubscription(s *service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		var req webhookSubscriptionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(r.Context(), w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		sub, err := s.Update(r.Context(), id, req.input())
+		if err != nil {
+			writeWebhookError(w, r, err)
+			return
+		}
+		jsonOK(w, sub)
+	}
+}
+
+// --- DELETE /webhooks/:id ---
+
+func handleDeleteWebhookSubscription(s *service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			jsonError(r.Context(), w, "invalid id", http.StatusBadRequest)
+			return
+		}
+		if err := s.Delete(r.Context(), id); err != nil {
+			writeWebhookError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeWebhookError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidWebhook):
+		jsonError(r.Context(), w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrWebhookNotFound):
+		jsonError(r.Context(), w, err.Error(), http.StatusNotFound)
+	default:
+		jsonError(r.Context(), w, "webhook subscription request failed", http.StatusInternalServerError, err)
+	}
+}
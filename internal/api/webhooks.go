@@ -0,0 +1,49 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+// WithWebhookAudit mounts GET /admin/webhooks.
+func WithWebhookAudit(a *service.WebhookAudit) Option {
+	return func(o *routerOptions) { o.webhooks = a }
+}
+
+// --- GET /admin/webhooks ---
+
+func handleListWebhookDeliveries(a *service.WebhookAudit) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		limit := service.DefaultWebhookDeliveryListLimit
+		if v := q.Get("limit"); v != "" {
+			l, err := strconv.Atoi(v)
+			if err != nil || l <= 0 || l > service.MaxWebhookDeliveryListLimit {
+				jsonError(r.Context(), w,
+					fmt.Sprintf("limit must be between 1 and %d", service.MaxWebhookDeliveryListLimit),
+					http.StatusBadRequest)
+				return
+			}
+			limit = l
+		}
+		failedOnly := false
+		if v := q.Get("failed"); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				jsonError(r.Context(), w, "failed must be true or false", http.StatusBadRequest)
+				return
+			}
+			failedOnly = b
+		}
+
+		deliveries, err := a.List(r.Context(), q.Get("source"), failedOnly, limit)
+		if err != nil {
+			jsonError(r.Context(), w, "failed to list webhook deliveries", http.StatusInternalServerError, err)
+			return
+		}
+		jsonOK(w, map[string]any{"deliveries": deliveries})
+	}
+}
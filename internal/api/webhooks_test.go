@@ -0,0 +1,46 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/service"
+)
+
+func TestListWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	router := NewRouter(
+		service.NewPantryService(mockQ),
+		service.NewIngestService(mockQ, &stubResolver{}, &stubExtractor{}),
+		clients.NewDictionaryClient("http://dictionary.invalid", nil),
+		WithWebhookAudit(service.NewWebhookAudit(mockQ)),
+	)
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusBadRequest, get("/admin/webhooks?limit=0").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/admin/webhooks?failed=maybe").Code)
+
+	mockQ.EXPECT().ListWebhookDeliveries(mock.Anything, db.ListWebhookDeliveriesParams{
+		Source:     sql.NullString{String: "notification", Valid: true},
+		FailedOnly: true,
+		Limit:      int32(service.DefaultWebhookDeliveryListLimit),
+	}).Return([]db.WebhookDelivery{{ID: 1, Source: "notification", LatencyMs: 12}}, nil)
+	rec := get("/admin/webhooks?source=notification&failed=true")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"latency_ms":12`)
+}
@@ -0,0 +1,319 @@
+// Package auth verifies bearer JWTs against the signing keys an identity
+// provider publishes as a JWKS.
+//
+// Tokens must be signed with RS256, RS384, RS512, ES256 or ES384 by a key
+// in the set, must carry an exp, and must match the configured issuer and
+// audience when those are set. Scopes are read from the space-separated
+// "scope" claim or the "scp" claim (a string or a list).
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	_ "crypto/sha256" // registers SHA-256 for crypto.Hash.New
+	_ "crypto/sha512" // registers SHA-384 and SHA-512
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidToken is returned by Verify for a malformed, unsigned,
+	// expired or otherwise unacceptable token.
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrKeysUnavailable is returned by Verify when the key set cannot be
+	// fetched and none is cached.
+	ErrKeysUnavailable = errors.New("signing keys unavailable")
+)
+
+const (
+	// jwksTTL is how long a fetched key set is trusted before it is
+	// fetched again.
+	jwksTTL = time.Hour
+	// jwksMinRefresh limits refetches for tokens naming an unknown key, so
+	// forged kids cannot hammer the identity provider, and retries of a
+	// failed refresh while cached keys are still served.
+	jwksMinRefresh = time.Minute
+	// clockLeeway absorbs clock drift between the issuer and this service.
+	clockLeeway = 30 * time.Second
+)
+
+// Claims are the verified parts of a token the service uses.
+type Claims struct {
+	Subject   string
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
+// HasScope reports whether the token grants scope.
+func (c Claims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+// Verifier checks tokens against a JWKS, caching the keys.
+type Verifier struct {
+	jwksURL  string
+	issuer   string
+	audience string
+	client   *http.Client
+	now      func() time.Time
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+	// attemptAt is when the key set was last requested, whether or not
+	// the request succeeded.
+	attemptAt time.Time
+	// fetching is the request in flight, shared by every caller that
+	// needs the key set meanwhile.
+	fetching *jwksFetch
+}
+
+// jwksFetch is one request for the key set. done is closed once keys or
+// err is set.
+type jwksFetch struct {
+	done chan struct{}
+	keys map[string]crypto.PublicKey
+	err  error
+}
+
+// Option configures a Verifier.
+type Option func(*Verifier)
+
+// WithIssuer requires the iss claim to equal issuer.
+func WithIssuer(issuer string) Option {
+	return func(v *Verifier) { v.issuer = issuer }
+}
+
+// WithAudience requires the aud claim to contain audience.
+func WithAudience(audience string) Option {
+	return func(v *Verifier) { v.audience = audience }
+}
+
+// NewVerifier returns a Verifier that fetches keys from jwksURL through
+// client on first use.
+func NewVerifier(jwksURL string, client *http.Client, opts ...Option) *Verifier {
+	v := &Verifier{jwksURL: jwksURL, client: client, now: time.Now}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Verify checks token's signature and claims and returns its claims. Every
+// rejection wraps ErrInvalidToken, except a key set that cannot be fetched,
+// which wraps ErrKeysUnavailable.
+func (v *Verifier) Verify(ctx context.Context, token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, fmt.Errorf("%w: not a JWS compact token", ErrInvalidToken)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return Claims{}, fmt.Errorf("%w: header: %w", ErrInvalidToken, err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: signature encoding", ErrInvalidToken)
+	}
+	key, err := v.key(ctx, header.Kid)
+	if err != nil {
+		return Claims{}, err
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return Claims{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	var raw struct {
+		Sub   string     `json:"sub"`
+		Iss   string     `json:"iss"`
+		Aud   stringList `json:"aud"`
+		Exp   *float64   `json:"exp"`
+		Nbf   *float64   `json:"nbf"`
+		Scope string     `json:"scope"`
+		Scp   stringList `json:"scp"`
+	}
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return Claims{}, fmt.Errorf("%w: claims: %w", ErrInvalidToken, err)
+	}
+	now := v.now()
+	if raw.Exp == nil {
+		return Claims{}, fmt.Errorf("%w: no exp", ErrInvalidToken)
+	}
+	expires := numericDate(*raw.Exp)
+	if !now.Before(expires.Add(clockLeeway)) {
+		return Claims{}, fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+	if raw.Nbf != nil && now.Add(clockLeeway).Before(numericDate(*raw.Nbf)) {
+		return Claims{}, fmt.Errorf("%w: not yet valid", ErrInvalidToken)
+	}
+	if v.issuer != "" && raw.Iss != v.issuer {
+		return Claims{}, fmt.Errorf("%w: issuer %q", ErrInvalidToken, raw.Iss)
+	}
+	if v.audience != "" && !slices.Contains(raw.Aud, v.audience) {
+		return Claims{}, fmt.Errorf("%w: audience", ErrInvalidToken)
+	}
+
+	scopes := strings.Fields(raw.Scope)
+	for _, s := range raw.Scp {
+		scopes = append(scopes, strings.Fields(s)...)
+	}
+	return Claims{Subject: raw.Sub, Scopes: scopes, ExpiresAt: expires}, nil
+}
+
+// key returns the key for kid, fetching the key set when it is older than
+// jwksTTL or does not have kid. A token without a kid matches a set of one
+// key. If a refetch fails, a cached key is still used and the set is not
+// requested again for jwksMinRefresh.
+//
+// The request runs without v.mu held, so cached keys are served while it
+// is in flight, and concurrent callers share one request.
+func (v *Verifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	now := v.now()
+	cached, ok := lookupIn(v.keys, kid)
+	recent := now.Sub(v.attemptAt) < jwksMinRefresh
+	switch {
+	case ok && (now.Sub(v.fetchedAt) < jwksTTL || recent):
+		v.mu.Unlock()
+		return cached, nil
+	case !ok && v.keys != nil && recent:
+		v.mu.Unlock()
+		return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidToken, kid)
+	}
+	f := v.fetching
+	if f == nil {
+		f = &jwksFetch{done: make(chan struct{})}
+		v.fetching, v.attemptAt = f, now
+		// Detached from ctx: other callers wait on this request too.
+		go v.fetch(context.WithoutCancel(ctx), f, now)
+	}
+	v.mu.Unlock()
+
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+		if ok {
+			return cached, nil
+		}
+		return nil, fmt.Errorf("%w: %w", ErrKeysUnavailable, ctx.Err())
+	}
+	if f.err != nil {
+		if ok {
+			return cached, nil
+		}
+		return nil, fmt.Errorf("%w: %w", ErrKeysUnavailable, f.err)
+	}
+	if key, ok := lookupIn(f.keys, kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidToken, kid)
+}
+
+// fetch requests the key set for f and caches it if the request succeeds.
+func (v *Verifier) fetch(ctx context.Context, f *jwksFetch, at time.Time) {
+	keys, err := fetchJWKS(ctx, v.client, v.jwksURL)
+	v.mu.Lock()
+	if err == nil {
+		v.keys, v.fetchedAt = keys, at
+	}
+	f.keys, f.err = keys, err
+	v.fetching = nil
+	v.mu.Unlock()
+	close(f.done)
+}
+
+func lookupIn(keys map[string]crypto.PublicKey, kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	key, ok := keys[kid]
+	return key, ok
+}
+
+// verifySignature checks sig over input with key under alg. The key type
+// must match the algorithm, so an RSA key can never verify an ES token.
+func verifySignature(alg string, key crypto.PublicKey, input string, sig []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported alg %q", alg)
+	}
+	digest := digestOf(hash, input)
+
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("alg %s does not match an RSA key", alg)
+		}
+		return rsa.VerifyPKCS1v15(pub, hash, digest, sig)
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if !strings.HasPrefix(alg, "ES") || hash.Size() != size || len(sig) != 2*size {
+			return fmt.Errorf("alg %s does not match the EC key", alg)
+		}
+		r, s := new(big.Int).SetBytes(sig[:size]), new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("ecdsa verification failed")
+		}
+		return nil
+	default:
+		return errors.New("unsupported key type")
+	}
+}
+
+func digestOf(hash crypto.Hash, input string) []byte {
+	h := hash.New()
+	h.Write([]byte(input))
+	return h.Sum(nil)
+}
+
+func decodeSegment(seg string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// numericDate converts a JWT NumericDate, seconds since the epoch.
+func numericDate(secs float64) time.Time {
+	return time.Unix(0, int64(secs*float64(time.Second)))
+}
+
+// stringList is a claim that may be a single string or a list of strings.
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(b []byte) error {
+	var one string
+	if err := json.Unmarshal(b, &one); err == nil {
+		*l = []string{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*l = many
+	return nil
+}
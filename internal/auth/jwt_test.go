@@ -0,0 +1,192 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/testutil/jwksserver"
+)
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub":   "user-1",
+		"iss":   "https://id.example",
+		"aud":   []string{"pantry", "other"},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": "pantry:read pantry:write",
+	}
+}
+
+func newTestVerifier(idp *jwksserver.Server) *Verifier {
+	return NewVerifier(idp.JWKSURL(), idp.Client(), WithIssuer("https://id.example"), WithAudience("pantry"))
+}
+
+func TestVerify_AcceptsRSAAndEC(t *testing.T) {
+	t.Parallel()
+
+	idp := jwksserver.New(t)
+	v := newTestVerifier(idp)
+
+	for _, token := range []string{idp.SignRS256(t, validClaims()), idp.SignES256(t, validClaims())} {
+		claims, err := v.Verify(context.Background(), token)
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims.Subject)
+		assert.True(t, claims.HasScope("pantry:write"))
+		assert.False(t, claims.HasScope("admin"))
+	}
+	assert.Equal(t, 1, idp.Fetches(), "keys are cached")
+}
+
+func TestVerify_ScpClaim(t *testing.T) {
+	t.Parallel()
+
+	idp := jwksserver.New(t)
+	claims := validClaims()
+	delete(claims, "scope")
+	claims["scp"] = []string{"ingest:write", "admin"}
+
+	got, err := newTestVerifier(idp).Verify(context.Background(), idp.SignRS256(t, claims))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ingest:write", "admin"}, got.Scopes)
+}
+
+func TestVerify_Rejects(t *testing.T) {
+	t.Parallel()
+
+	idp := jwksserver.New(t)
+	v := newTestVerifier(idp)
+
+	with := func(key string, value any) string {
+		c := validClaims()
+		if value == nil {
+			delete(c, key)
+		} else {
+			c[key] = value
+		}
+		return idp.SignRS256(t, c)
+	}
+	valid := idp.SignRS256(t, validClaims())
+	parts := strings.Split(valid, ".")
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"rsa-1"}`)) + "." + parts[1] + "."
+
+	tests := map[string]string{
+		"expired":         with("exp", time.Now().Add(-time.Hour).Unix()),
+		"no exp":          with("exp", nil),
+		"not yet valid":   with("nbf", time.Now().Add(time.Hour).Unix()),
+		"wrong issuer":    with("iss", "https://evil.example"),
+		"wrong audience":  with("aud", "someone-else"),
+		"tampered claims": parts[0] + "." + strings.Split(with("sub", "admin"), ".")[1] + "." + parts[2],
+		"alg none":        none,
+		"garbage":         "not-a-token",
+	}
+	for name, token := range tests {
+		_, err := v.Verify(context.Background(), token)
+		require.ErrorIs(t, err, ErrInvalidToken, name)
+	}
+}
+
+func TestVerify_KeysUnavailable(t *testing.T) {
+	t.Parallel()
+
+	idp := jwksserver.New(t)
+	idp.SetStatus(http.StatusBadGateway)
+
+	_, err := newTestVerifier(idp).Verify(context.Background(), idp.SignRS256(t, validClaims()))
+	require.ErrorIs(t, err, ErrKeysUnavailable)
+}
+
+func TestVerify_ServesCachedKeysWhenRefreshFails(t *testing.T) {
+	t.Parallel()
+
+	idp := jwksserver.New(t)
+	v := newTestVerifier(idp)
+	now := time.Now()
+	v.now = func() time.Time { return now }
+
+	token := idp.SignRS256(t, validClaims())
+	_, err := v.Verify(context.Background(), token)
+	require.NoError(t, err)
+
+	idp.SetStatus(http.StatusInternalServerError)
+	now = now.Add(jwksTTL + time.Minute)
+	claims := validClaims()
+	claims["exp"] = now.Add(time.Hour).Unix()
+	_, err = v.Verify(context.Background(), idp.SignRS256(t, claims))
+	require.NoError(t, err)
+	assert.Equal(t, 2, idp.Fetches())
+}
+
+func TestVerify_FailedRefreshBacksOff(t *testing.T) {
+	t.Parallel()
+
+	idp := jwksserver.New(t)
+	v := newTestVerifier(idp)
+	now := time.Now()
+	v.now = func() time.Time { return now }
+	verify := func() {
+		t.Helper()
+		claims := validClaims()
+		claims["exp"] = now.Add(time.Hour).Unix()
+		_, err := v.Verify(context.Background(), idp.SignRS256(t, claims))
+		require.NoError(t, err)
+	}
+	verify()
+
+	idp.SetStatus(http.StatusInternalServerError)
+	now = now.Add(jwksTTL + time.Minute)
+	for range 3 {
+		verify()
+	}
+	assert.Equal(t, 2, idp.Fetches(), "a failed refresh is not retried on every request")
+
+	idp.SetStatus(http.StatusOK)
+	now = now.Add(jwksMinRefresh)
+	verify()
+	verify()
+	assert.Equal(t, 3, idp.Fetches())
+}
+
+func TestVerify_ConcurrentCallersShareOneFetch(t *testing.T) {
+	t.Parallel()
+
+	idp := jwksserver.New(t)
+	v := newTestVerifier(idp)
+	token := idp.SignRS256(t, validClaims())
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := v.Verify(context.Background(), token)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, idp.Fetches())
+}
+
+func TestVerify_UnknownKidRefetchIsRateLimited(t *testing.T) {
+	t.Parallel()
+
+	idp := jwksserver.New(t)
+	v := newTestVerifier(idp)
+	_, err := v.Verify(context.Background(), idp.SignRS256(t, validClaims()))
+	require.NoError(t, err)
+
+	parts := strings.Split(idp.SignRS256(t, validClaims()), ".")
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","kid":"rotated"}`))
+	for range 3 {
+		_, err = v.Verify(context.Background(), header+"."+parts[1]+"."+parts[2])
+		require.ErrorIs(t, err, ErrInvalidToken)
+	}
+	assert.Equal(t, 1, idp.Fetches())
+}
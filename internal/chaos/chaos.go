@@ -0,0 +1,233 @@
+// Package chaos injects delays and failures into outbound dependencies
+// (Dictionary, LLM, database) for resilience testing. It is opt-in via
+// CHAOS_ENABLED and must never be enabled in production.
+package chaos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+// Target names a dependency faults can be injected into.
+type Target string
+
+const (
+	Dictionary Target = "dictionary"
+	LLM        Target = "llm"
+	DB         Target = "db"
+)
+
+// Header carries per-request faults in the same syntax as Parse. They apply
+// only to calls made on the request's context, not to background work such
+// as async ingest jobs.
+const Header = "X-Chaos"
+
+// ErrInjected is returned by calls failed on purpose.
+var ErrInjected = errors.New("chaos: injected failure")
+
+// Fault describes what to do to calls to one target. Rates are 0..1.
+type Fault struct {
+	FailRate  float64
+	Delay     time.Duration
+	DelayRate float64
+}
+
+// Faults maps targets to their fault.
+type Faults map[Target]Fault
+
+// Parse reads a fault spec such as
+//
+//	dictionary=fail:0.2,llm=delay:2s:0.5+fail:0.1,db=fail
+//
+// Each target takes one or more actions joined by "+": fail[:rate] or
+// delay:duration[:rate]. A missing rate means every call.
+func Parse(spec string) (Faults, error) {
+	faults := Faults{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, actions, ok := strings.Cut(entry, "=")
+		target := Target(strings.TrimSpace(name))
+		if !ok || (target != Dictionary && target != LLM && target != DB) {
+			return nil, fmt.Errorf("chaos: invalid entry %q", entry)
+		}
+		f := faults[target]
+		for _, action := range strings.Split(actions, "+") {
+			parts := strings.Split(strings.TrimSpace(action), ":")
+			var err error
+			switch parts[0] {
+			case "fail":
+				f.FailRate, err = parseRate(parts[1:])
+			case "delay":
+				if len(parts) < 2 {
+					return nil, fmt.Errorf("chaos: delay needs a duration in %q", entry)
+				}
+				if f.Delay, err = time.ParseDuration(parts[1]); err == nil {
+					f.DelayRate, err = parseRate(parts[2:])
+				}
+			default:
+				err = fmt.Errorf("unknown action %q", parts[0])
+			}
+			if err != nil {
+				return nil, fmt.Errorf("chaos: %q: %w", entry, err)
+			}
+		}
+		faults[target] = f
+	}
+	return faults, nil
+}
+
+func parseRate(parts []string) (float64, error) {
+	if len(parts) == 0 {
+		return 1, nil
+	}
+	r, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || r < 0 || r > 1 {
+		return 0, fmt.Errorf("rate must be between 0 and 1, got %q", parts[0])
+	}
+	return r, nil
+}
+
+type ctxKey struct{}
+
+// Injector applies configured faults, overridden per request by Header.
+type Injector struct {
+	base Faults
+	roll func() float64
+}
+
+func New(base Faults) *Injector {
+	if base == nil {
+		base = Faults{}
+	}
+	return &Injector{base: base, roll: rand.Float64}
+}
+
+// Middleware reads Header into the request context. A malformed header is
+// rejected so a typo does not silently test nothing.
+func (i *Injector) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		spec := r.Header.Get(Header)
+		if spec == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		faults, err := Parse(spec)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKey{}, faults)))
+	})
+}
+
+// Inject applies target's fault: it may sleep, then may return ErrInjected.
+func (i *Injector) Inject(ctx context.Context, target Target) error {
+	f, ok := i.base[target]
+	if override, has := ctx.Value(ctxKey{}).(Faults); has {
+		if of, set := override[target]; set {
+			f, ok = of, true
+		}
+	}
+	if !ok {
+		return nil
+	}
+	if f.Delay > 0 && i.roll() < f.DelayRate {
+		logging.For("chaos").DebugContext(ctx, "injecting delay", "target", target, "delay", f.Delay)
+		select {
+		case <-time.After(f.Delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if i.roll() < f.FailRate {
+		logging.For("chaos").DebugContext(ctx, "injecting failure", "target", target)
+		return fmt.Errorf("%s: %w", target, ErrInjected)
+	}
+	return nil
+}
+
+// Transport wraps an HTTP transport so calls to target can be delayed or
+// failed before they are sent.
+func (i *Injector) Transport(base http.RoundTripper, target Target) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if err := i.Inject(r.Context(), target); err != nil {
+			return nil, err
+		}
+		return base.RoundTrip(r)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// DBTX wraps a database handle so queries can be delayed or failed. Single-row
+// queries cannot carry a custom error, so an injected QueryRowContext failure
+// runs on a cancelled context and surfaces as context.Canceled.
+func (i *Injector) DBTX(inner db.DBTX) db.DBTX {
+	return &faultyDB{inner: inner, i: i}
+}
+
+type faultyDB struct {
+	inner db.DBTX
+	i     *Injector
+}
+
+func (d *faultyDB) ExecContext(ctx context.Context, q string, args ...interface{}) (sql.Result, error) {
+	if err := d.i.Inject(ctx, DB); err != nil {
+		return nil, err
+	}
+	return d.inner.ExecContext(ctx, q, args...)
+}
+
+func (d *faultyDB) PrepareContext(ctx context.Context, q string) (*sql.Stmt, error) {
+	if err := d.i.Inject(ctx, DB); err != nil {
+		return nil, err
+	}
+	return d.inner.PrepareContext(ctx, q)
+}
+
+func (d *faultyDB) QueryContext(ctx context.Context, q string, args ...interface{}) (*sql.Rows, error) {
+	if err := d.i.Inject(ctx, DB); err != nil {
+		return nil, err
+	}
+	return d.inner.QueryContext(ctx, q, args...)
+}
+
+// BeginTx fails like any other query but does not wrap the transaction, so
+// faults hit whole transactions rather than statements within them.
+func (d *faultyDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	if err := d.i.Inject(ctx, DB); err != nil {
+		return nil, err
+	}
+	b, ok := d.inner.(db.TxBeginner)
+	if !ok {
+		return nil, errors.New("chaos: wrapped handle cannot begin transactions")
+	}
+	return b.BeginTx(ctx, opts)
+}
+
+func (d *faultyDB) QueryRowContext(ctx context.Context, q string, args ...interface{}) *sql.Row {
+	if err := d.i.Inject(ctx, DB); err != nil {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		return d.inner.QueryRowContext(cancelled, q, args...)
+	}
+	return d.inner.QueryRowContext(ctx, q, args...)
+}
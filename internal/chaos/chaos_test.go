@@ -0,0 +1,84 @@
+package chaos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	faults, err := Parse("dictionary=fail:0.2, llm=delay:2s:0.5+fail:0.1,db=fail")
+	require.NoError(t, err)
+	assert.Equal(t, Faults{
+		Dictionary: {FailRate: 0.2},
+		LLM:        {FailRate: 0.1, Delay: 2 * time.Second, DelayRate: 0.5},
+		DB:         {FailRate: 1},
+	}, faults)
+
+	for _, bad := range []string{"redis=fail", "db=explode", "db=fail:2", "llm=delay", "llm"} {
+		_, err := Parse(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestInject_UsesBaseFaults(t *testing.T) {
+	t.Parallel()
+
+	i := New(Faults{Dictionary: {FailRate: 0.5}})
+	i.roll = func() float64 { return 0.4 }
+	require.ErrorIs(t, i.Inject(context.Background(), Dictionary), ErrInjected)
+	require.NoError(t, i.Inject(context.Background(), LLM))
+
+	i.roll = func() float64 { return 0.6 }
+	require.NoError(t, i.Inject(context.Background(), Dictionary))
+}
+
+func TestInject_DelayRespectsContext(t *testing.T) {
+	t.Parallel()
+
+	i := New(Faults{LLM: {Delay: time.Minute, DelayRate: 1}})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, i.Inject(ctx, LLM), context.DeadlineExceeded)
+}
+
+func TestMiddleware_HeaderOverridesPerRequest(t *testing.T) {
+	t.Parallel()
+
+	i := New(nil)
+	var injected error
+	handler := i.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		injected = i.Inject(r.Context(), DB)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/pantry", nil)
+	req.Header.Set(Header, "db=fail")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.ErrorIs(t, injected, ErrInjected)
+
+	req = httptest.NewRequest(http.MethodGet, "/pantry", nil)
+	req.Header.Set(Header, "db=sometimes")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestTransport_FailsBeforeSending(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { calls++ }))
+	defer server.Close()
+
+	client := &http.Client{Transport: New(Faults{Dictionary: {FailRate: 1}}).Transport(nil, Dictionary)}
+	_, err := client.Get(server.URL) //nolint:noctx
+	require.ErrorIs(t, err, ErrInjected)
+	assert.Zero(t, calls)
+}
@@ -4,10 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/tracing"
 )
 
 // DictionaryClient calls the Ingredient Dictionary service.
@@ -20,18 +25,35 @@ func NewDictionaryClient(baseURL string, httpClient *http.Client) *DictionaryCli
 	return &DictionaryClient{baseURL: baseURL, httpClient: httpClient}
 }
 
+// ErrIngredientNotFound is returned when the Dictionary has no ingredient with
+// the requested ID.
+var ErrIngredientNotFound = errors.New("ingredient not found")
+
+// Ingredient is a canonical Dictionary ingredient.
+type Ingredient struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Category string    `json:"category,omitempty"`
+}
+
 // ResolveResult is the response from POST /ingredients/resolve.
 type ResolveResult struct {
-	Ingredient struct {
-		ID   uuid.UUID `json:"id"`
-		Name string    `json:"name"`
-	} `json:"ingredient"`
-	Confidence float64 `json:"confidence"`
-	Created    bool    `json:"created"`
+	Ingredient Ingredient `json:"ingredient"`
+	Confidence float64    `json:"confidence"`
+	Created    bool       `json:"created"`
 }
 
 // Resolve calls the Dictionary service to normalize rawName to a canonical ID.
-func (c *DictionaryClient) Resolve(ctx context.Context, rawName string) (ResolveResult, error) {
+func (c *DictionaryClient) Resolve(ctx context.Context, rawName string) (result ResolveResult, err error) {
+	ctx, span := tracing.Start(ctx, "dictionary.resolve", trace.SpanKindClient)
+	defer func() {
+		span.SetAttributes(
+			attribute.Float64("dictionary.confidence", result.Confidence),
+			attribute.Bool("dictionary.created", result.Created),
+		)
+		tracing.End(span, err)
+	}()
+
 	body, err := json.Marshal(map[string]string{"name": rawName})
 	if err != nil {
 		return ResolveResult{}, err
@@ -58,9 +80,80 @@ func (c *DictionaryClient) Resolve(ctx context.Context, rawName string) (Resolve
 		return ResolveResult{}, fmt.Errorf("dictionary resolve: unexpected status %d", resp.StatusCode)
 	}
 
-	var result ResolveResult
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return ResolveResult{}, fmt.Errorf("dictionary resolve decode: %w", err)
 	}
 	return result, nil
 }
+
+// GetIngredient fetches a canonical ingredient by ID via GET /ingredients/{id}.
+// It returns ErrIngredientNotFound on 404.
+func (c *DictionaryClient) GetIngredient(ctx context.Context, id uuid.UUID) (Ingredient, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/ingredients/"+id.String(), nil)
+	if err != nil {
+		return Ingredient{}, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return Ingredient{}, fmt.Errorf("dictionary get ingredient: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return Ingredient{}, ErrIngredientNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Ingredient{}, fmt.Errorf("dictionary get ingredient: unexpected status %d", resp.StatusCode)
+	}
+
+	var ing Ingredient
+	if err := json.NewDecoder(resp.Body).Decode(&ing); err != nil {
+		return Ingredient{}, fmt.Errorf("dictionary get ingredient decode: %w", err)
+	}
+	return ing, nil
+}
+
+// ListIngredients fetches every canonical ingredient via GET /ingredients.
+func (c *DictionaryClient) ListIngredients(ctx context.Context) ([]Ingredient, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/ingredients", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("dictionary list ingredients: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("dictionary list ingredients: unexpected status %d", resp.StatusCode)
+	}
+
+	var ings []Ingredient
+	if err := json.NewDecoder(resp.Body).Decode(&ings); err != nil {
+		return nil, fmt.Errorf("dictionary list ingredients decode: %w", err)
+	}
+	return ings, nil
+}
+
+// Ping HEADs the Dictionary's /healthz for the readiness probe. Any response
+// below 500 counts as reachable.
+func (c *DictionaryClient) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.baseURL+"/healthz", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("dictionary ping: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("dictionary ping: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
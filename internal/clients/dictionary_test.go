@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/google/uuid"
@@ -29,10 +30,7 @@ func TestResolve_Success200(t *testing.T) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(ResolveResult{
-			Ingredient: struct {
-				ID   uuid.UUID `json:"id"`
-				Name string    `json:"name"`
-			}{ID: ingredientID, Name: "garlic"},
+			Ingredient: Ingredient{ID: ingredientID, Name: "garlic"},
 			Confidence: 0.95,
 			Created:    false,
 		})
@@ -57,10 +55,7 @@ func TestResolve_Success201Created(t *testing.T) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
 		json.NewEncoder(w).Encode(ResolveResult{
-			Ingredient: struct {
-				ID   uuid.UUID `json:"id"`
-				Name string    `json:"name"`
-			}{ID: ingredientID, Name: "quinoa"},
+			Ingredient: Ingredient{ID: ingredientID, Name: "quinoa"},
 			Confidence: 1.0,
 			Created:    true,
 		})
@@ -107,3 +102,75 @@ func TestResolve_InvalidJSON(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "decode")
 }
+
+func TestGetIngredient_Success(t *testing.T) {
+	t.Parallel()
+
+	ingredientID := uuid.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/ingredients/"+ingredientID.String(), r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Ingredient{ID: ingredientID, Name: "garlic"})
+	}))
+	defer server.Close()
+
+	client := NewDictionaryClient(server.URL, server.Client())
+	ing, err := client.GetIngredient(context.Background(), ingredientID)
+
+	require.NoError(t, err)
+	assert.Equal(t, ingredientID, ing.ID)
+	assert.Equal(t, "garlic", ing.Name)
+}
+
+func TestGetIngredient_NotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewDictionaryClient(server.URL, server.Client())
+	_, err := client.GetIngredient(context.Background(), uuid.New())
+
+	require.ErrorIs(t, err, ErrIngredientNotFound)
+}
+
+func TestListIngredients(t *testing.T) {
+	t.Parallel()
+
+	ingredientID := uuid.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/ingredients", r.URL.Path)
+		json.NewEncoder(w).Encode([]Ingredient{{ID: ingredientID, Name: "garlic", Category: "produce"}})
+	}))
+	defer server.Close()
+
+	ings, err := NewDictionaryClient(server.URL, server.Client()).ListIngredients(context.Background())
+	require.NoError(t, err)
+	require.Len(t, ings, 1)
+	assert.Equal(t, ingredientID, ings[0].ID)
+	assert.Equal(t, "produce", ings[0].Category)
+}
+
+func TestPing(t *testing.T) {
+	t.Parallel()
+
+	var status atomic.Int32
+	status.Store(http.StatusMethodNotAllowed)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		assert.Equal(t, "/healthz", r.URL.Path)
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	client := NewDictionaryClient(server.URL, server.Client())
+	require.NoError(t, client.Ping(context.Background()), "a HEAD-less router still proves the service is up")
+
+	status.Store(http.StatusServiceUnavailable)
+	require.Error(t, client.Ping(context.Background()))
+}
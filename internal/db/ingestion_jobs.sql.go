@@ -7,48 +7,88 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const cancelIngestionJob = `-- name: CancelIngestionJob :one
+UPDATE ingestion_jobs
+SET status = 'cancelled'
+WHERE id = $1 AND status IN ('pending', 'processing')
+RETURNING id, type, raw_input, status, budget_exceeded, truncated_items, source, priority, created_at, failure_reason
+`
+
+func (q *Queries) CancelIngestionJob(ctx context.Context, id uuid.UUID) (IngestionJob, error) {
+	row := q.db.QueryRowContext(ctx, cancelIngestionJob, id)
+	var i IngestionJob
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.RawInput,
+		&i.Status,
+		&i.BudgetExceeded,
+		&i.TruncatedItems,
+		&i.Source,
+		&i.Priority,
+		&i.CreatedAt,
+		&i.FailureReason,
+	)
+	return i, err
+}
+
 const createIngestionJob = `-- name: CreateIngestionJob :one
-INSERT INTO ingestion_jobs (type, raw_input)
-VALUES ($1, $2)
-RETURNING id, type, raw_input, status, created_at
+INSERT INTO ingestion_jobs (type, raw_input, source, priority)
+VALUES ($1, $2, $3, $4)
+RETURNING id, type, raw_input, status, budget_exceeded, truncated_items, source, priority, created_at, failure_reason
 `
 
 type CreateIngestionJobParams struct {
 	Type     string
 	RawInput string
+	Source   string
+	Priority string
 }
 
 func (q *Queries) CreateIngestionJob(ctx context.Context, arg CreateIngestionJobParams) (IngestionJob, error) {
-	row := q.db.QueryRowContext(ctx, createIngestionJob, arg.Type, arg.RawInput)
+	row := q.db.QueryRowContext(ctx, createIngestionJob,
+		arg.Type,
+		arg.RawInput,
+		arg.Source,
+		arg.Priority,
+	)
 	var i IngestionJob
 	err := row.Scan(
 		&i.ID,
 		&i.Type,
 		&i.RawInput,
 		&i.Status,
+		&i.BudgetExceeded,
+		&i.TruncatedItems,
+		&i.Source,
+		&i.Priority,
 		&i.CreatedAt,
+		&i.FailureReason,
 	)
 	return i, err
 }
 
 const createStagedItem = `-- name: CreateStagedItem :one
-INSERT INTO staged_items (job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review
+INSERT INTO staged_items (job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review, quantity_unknown)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review, quantity_unknown
 `
 
 type CreateStagedItemParams struct {
-	JobID        uuid.UUID
-	IngredientID uuid.NullUUID
-	RawText      string
-	Quantity     float64
-	Unit         string
-	Confidence   float64
-	NeedsReview  bool
+	JobID           uuid.UUID
+	IngredientID    uuid.NullUUID
+	RawText         string
+	Quantity        float64
+	Unit            string
+	Confidence      float64
+	NeedsReview     bool
+	QuantityUnknown bool
 }
 
 func (q *Queries) CreateStagedItem(ctx context.Context, arg CreateStagedItemParams) (StagedItem, error) {
@@ -60,6 +100,7 @@ func (q *Queries) CreateStagedItem(ctx context.Context, arg CreateStagedItemPara
 		arg.Unit,
 		arg.Confidence,
 		arg.NeedsReview,
+		arg.QuantityUnknown,
 	)
 	var i StagedItem
 	err := row.Scan(
@@ -71,12 +112,113 @@ func (q *Queries) CreateStagedItem(ctx context.Context, arg CreateStagedItemPara
 		&i.Unit,
 		&i.Confidence,
 		&i.NeedsReview,
+		&i.QuantityUnknown,
 	)
 	return i, err
 }
 
+const deleteStagedItem = `-- name: DeleteStagedItem :execrows
+DELETE FROM staged_items
+WHERE id = $1 AND job_id = $2
+`
+
+type DeleteStagedItemParams struct {
+	ID    uuid.UUID
+	JobID uuid.UUID
+}
+
+func (q *Queries) DeleteStagedItem(ctx context.Context, arg DeleteStagedItemParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteStagedItem, arg.ID, arg.JobID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteStagedItemsByJob = `-- name: DeleteStagedItemsByJob :exec
+DELETE FROM staged_items
+WHERE job_id = $1
+`
+
+func (q *Queries) DeleteStagedItemsByJob(ctx context.Context, jobID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteStagedItemsByJob, jobID)
+	return err
+}
+
+const expireStagedJobs = `-- name: ExpireStagedJobs :many
+-- Archives jobs left staged since before $1: the job row is kept, as
+-- expired, and its staged items are deleted. The TTL runs from when the
+-- job was staged, not created, so a queued or retried job gets all of it.
+WITH expired AS (
+  UPDATE ingestion_jobs
+  SET status = 'expired'
+  WHERE status = 'staged' AND staged_at < $1
+  RETURNING id
+), deleted AS (
+  DELETE FROM staged_items
+  WHERE job_id IN (SELECT id FROM expired)
+  RETURNING job_id
+)
+SELECT e.id, COUNT(d.job_id)::bigint AS staged_items
+FROM expired e
+LEFT JOIN deleted d ON d.job_id = e.id
+GROUP BY e.id
+`
+
+type ExpireStagedJobsRow struct {
+	ID          uuid.UUID
+	StagedItems int64
+}
+
+func (q *Queries) ExpireStagedJobs(ctx context.Context, stagedAt time.Time) ([]ExpireStagedJobsRow, error) {
+	rows, err := q.db.QueryContext(ctx, expireStagedJobs, stagedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ExpireStagedJobsRow
+	for rows.Next() {
+		var i ExpireStagedJobsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.StagedItems,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const failIngestionJob = `-- name: FailIngestionJob :execrows
+-- Cancelled, rejected and expired jobs keep their status: a worker that
+-- missed the cancellation must not fail it afterwards.
+UPDATE ingestion_jobs
+SET status = 'failed', failure_reason = $2
+WHERE id = $1 AND status NOT IN ('cancelled', 'rejected', 'expired')
+`
+
+type FailIngestionJobParams struct {
+	ID            uuid.UUID
+	FailureReason string
+}
+
+func (q *Queries) FailIngestionJob(ctx context.Context, arg FailIngestionJobParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, failIngestionJob, arg.ID, arg.FailureReason)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getIngestionJob = `-- name: GetIngestionJob :one
-SELECT id, type, raw_input, status, created_at
+SELECT id, type, raw_input, status, budget_exceeded, truncated_items, source, priority, created_at, failure_reason
 FROM ingestion_jobs
 WHERE id = $1
 `
@@ -89,13 +231,40 @@ func (q *Queries) GetIngestionJob(ctx context.Context, id uuid.UUID) (IngestionJ
 		&i.Type,
 		&i.RawInput,
 		&i.Status,
+		&i.BudgetExceeded,
+		&i.TruncatedItems,
+		&i.Source,
+		&i.Priority,
 		&i.CreatedAt,
+		&i.FailureReason,
+	)
+	return i, err
+}
+
+const getIngestionJobTimings = `-- name: GetIngestionJobTimings :one
+SELECT job_id, attempts, extraction_ms, resolution_ms, resolution_max_ms, resolved_items, total_ms, recorded_at
+FROM ingestion_job_timings
+WHERE job_id = $1
+`
+
+func (q *Queries) GetIngestionJobTimings(ctx context.Context, jobID uuid.UUID) (IngestionJobTiming, error) {
+	row := q.db.QueryRowContext(ctx, getIngestionJobTimings, jobID)
+	var i IngestionJobTiming
+	err := row.Scan(
+		&i.JobID,
+		&i.Attempts,
+		&i.ExtractionMs,
+		&i.ResolutionMs,
+		&i.ResolutionMaxMs,
+		&i.ResolvedItems,
+		&i.TotalMs,
+		&i.RecordedAt,
 	)
 	return i, err
 }
 
 const getStagedItem = `-- name: GetStagedItem :one
-SELECT id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review
+SELECT id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review, quantity_unknown
 FROM staged_items
 WHERE id = $1
 `
@@ -112,12 +281,122 @@ func (q *Queries) GetStagedItem(ctx context.Context, id uuid.UUID) (StagedItem,
 		&i.Unit,
 		&i.Confidence,
 		&i.NeedsReview,
+		&i.QuantityUnknown,
 	)
 	return i, err
 }
 
+const ingestSourceStats = `-- name: IngestSourceStats :many
+SELECT j.source,
+       COUNT(DISTINCT j.id)::bigint                                        AS jobs,
+       COUNT(DISTINCT j.id) FILTER (WHERE j.status = 'confirmed')::bigint  AS confirmed_jobs,
+       COUNT(DISTINCT j.id) FILTER (WHERE j.status = 'failed')::bigint     AS failed_jobs,
+       COUNT(DISTINCT j.id) FILTER (WHERE j.status = 'rejected')::bigint   AS rejected_jobs,
+       COUNT(s.id)::bigint                                                 AS staged_items,
+       COUNT(s.id) FILTER (WHERE s.needs_review)::bigint                   AS needs_review_items
+FROM ingestion_jobs j
+LEFT JOIN staged_items s ON s.job_id = j.id
+WHERE j.created_at >= $1
+GROUP BY j.source
+ORDER BY jobs DESC, j.source
+`
+
+type IngestSourceStatsRow struct {
+	Source           string
+	Jobs             int64
+	ConfirmedJobs    int64
+	FailedJobs       int64
+	RejectedJobs     int64
+	StagedItems      int64
+	NeedsReviewItems int64
+}
+
+func (q *Queries) IngestSourceStats(ctx context.Context, createdAt time.Time) ([]IngestSourceStatsRow, error) {
+	rows, err := q.db.QueryContext(ctx, ingestSourceStats, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IngestSourceStatsRow
+	for rows.Next() {
+		var i IngestSourceStatsRow
+		if err := rows.Scan(
+			&i.Source,
+			&i.Jobs,
+			&i.ConfirmedJobs,
+			&i.FailedJobs,
+			&i.RejectedJobs,
+			&i.StagedItems,
+			&i.NeedsReviewItems,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listIngestionJobs = `-- name: ListIngestionJobs :many
+SELECT id, type, raw_input, status, budget_exceeded, truncated_items, source, priority, created_at, failure_reason
+FROM ingestion_jobs
+WHERE ($1::text IS NULL OR source = $1)
+  AND ($2::text IS NULL OR status = $2)
+ORDER BY created_at DESC
+LIMIT $3
+`
+
+type ListIngestionJobsParams struct {
+	Source sql.NullString
+	Status sql.NullString
+	Limit  int32
+}
+
+func (q *Queries) ListIngestionJobs(ctx context.Context, arg ListIngestionJobsParams) ([]IngestionJob, error) {
+	rows, err := q.db.QueryContext(ctx, listIngestionJobs,
+		arg.Source,
+		arg.Status,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IngestionJob
+	for rows.Next() {
+		var i IngestionJob
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.RawInput,
+			&i.Status,
+			&i.BudgetExceeded,
+			&i.TruncatedItems,
+			&i.Source,
+			&i.Priority,
+			&i.CreatedAt,
+			&i.FailureReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listStagedItemsByJob = `-- name: ListStagedItemsByJob :many
-SELECT id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review
+SELECT id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review, quantity_unknown
 FROM staged_items
 WHERE job_id = $1
 ORDER BY raw_text
@@ -141,6 +420,7 @@ func (q *Queries) ListStagedItemsByJob(ctx context.Context, jobID uuid.UUID) ([]
 			&i.Unit,
 			&i.Confidence,
 			&i.NeedsReview,
+			&i.QuantityUnknown,
 		); err != nil {
 			return nil, err
 		}
@@ -155,45 +435,112 @@ func (q *Queries) ListStagedItemsByJob(ctx context.Context, jobID uuid.UUID) ([]
 	return items, nil
 }
 
-const updateIngestionJobStatus = `-- name: UpdateIngestionJobStatus :one
+const markIngestionJobBudgetExceeded = `-- name: MarkIngestionJobBudgetExceeded :exec
 UPDATE ingestion_jobs
-SET status = $2
+SET budget_exceeded = true
 WHERE id = $1
-RETURNING id, type, raw_input, status, created_at
 `
 
-type UpdateIngestionJobStatusParams struct {
-	ID     uuid.UUID
-	Status string
+func (q *Queries) MarkIngestionJobBudgetExceeded(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, markIngestionJobBudgetExceeded, id)
+	return err
+}
+
+const markIngestionJobTruncated = `-- name: MarkIngestionJobTruncated :exec
+UPDATE ingestion_jobs
+SET truncated_items = $2
+WHERE id = $1
+`
+
+type MarkIngestionJobTruncatedParams struct {
+	ID             uuid.UUID
+	TruncatedItems int32
+}
+
+func (q *Queries) MarkIngestionJobTruncated(ctx context.Context, arg MarkIngestionJobTruncatedParams) error {
+	_, err := q.db.ExecContext(ctx, markIngestionJobTruncated, arg.ID, arg.TruncatedItems)
+	return err
+}
+
+const stagedItemsFingerprint = `-- name: StagedItemsFingerprint :one
+SELECT count(*) AS item_count,
+       coalesce(md5(string_agg(
+         id::text || ':' || coalesce(ingredient_id::text, '') || ':' || quantity::text || ':' ||
+         unit || ':' || confidence::text || ':' || needs_review::text || ':' || quantity_unknown::text,
+         ',' ORDER BY id)), '')::text AS checksum
+FROM staged_items
+WHERE job_id = $1
+`
+
+type StagedItemsFingerprintRow struct {
+	ItemCount int64
+	Checksum  string
+}
+
+func (q *Queries) StagedItemsFingerprint(ctx context.Context, jobID uuid.UUID) (StagedItemsFingerprintRow, error) {
+	row := q.db.QueryRowContext(ctx, stagedItemsFingerprint, jobID)
+	var i StagedItemsFingerprintRow
+	err := row.Scan(
+		&i.ItemCount,
+		&i.Checksum,
+	)
+	return i, err
+}
+
+const transitionIngestionJobStatus = `-- name: TransitionIngestionJobStatus :one
+UPDATE ingestion_jobs
+SET status = $1, failure_reason = '',
+    staged_at = CASE WHEN $1 = 'staged' THEN now() ELSE staged_at END
+WHERE id = $2 AND status = $3
+RETURNING id, type, raw_input, status, budget_exceeded, truncated_items, source, priority, created_at, failure_reason
+`
+
+type TransitionIngestionJobStatusParams struct {
+	ToStatus   string
+	ID         uuid.UUID
+	FromStatus string
 }
 
-func (q *Queries) UpdateIngestionJobStatus(ctx context.Context, arg UpdateIngestionJobStatusParams) (IngestionJob, error) {
-	row := q.db.QueryRowContext(ctx, updateIngestionJobStatus, arg.ID, arg.Status)
+func (q *Queries) TransitionIngestionJobStatus(ctx context.Context, arg TransitionIngestionJobStatusParams) (IngestionJob, error) {
+	row := q.db.QueryRowContext(ctx, transitionIngestionJobStatus,
+		arg.ToStatus,
+		arg.ID,
+		arg.FromStatus,
+	)
 	var i IngestionJob
 	err := row.Scan(
 		&i.ID,
 		&i.Type,
 		&i.RawInput,
 		&i.Status,
+		&i.BudgetExceeded,
+		&i.TruncatedItems,
+		&i.Source,
+		&i.Priority,
 		&i.CreatedAt,
+		&i.FailureReason,
 	)
 	return i, err
 }
 
 const updateStagedItem = `-- name: UpdateStagedItem :one
 UPDATE staged_items
-SET ingredient_id = $2,
-    quantity      = $3,
-    unit          = $4
+SET ingredient_id    = $2,
+    quantity         = $3,
+    unit             = $4,
+    needs_review     = $5,
+    quantity_unknown = $6
 WHERE id = $1
-RETURNING id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review
+RETURNING id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review, quantity_unknown
 `
 
 type UpdateStagedItemParams struct {
-	ID           uuid.UUID
-	IngredientID uuid.NullUUID
-	Quantity     float64
-	Unit         string
+	ID              uuid.UUID
+	IngredientID    uuid.NullUUID
+	Quantity        float64
+	Unit            string
+	NeedsReview     bool
+	QuantityUnknown bool
 }
 
 func (q *Queries) UpdateStagedItem(ctx context.Context, arg UpdateStagedItemParams) (StagedItem, error) {
@@ -202,6 +549,55 @@ func (q *Queries) UpdateStagedItem(ctx context.Context, arg UpdateStagedItemPara
 		arg.IngredientID,
 		arg.Quantity,
 		arg.Unit,
+		arg.NeedsReview,
+		arg.QuantityUnknown,
+	)
+	var i StagedItem
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.IngredientID,
+		&i.RawText,
+		&i.Quantity,
+		&i.Unit,
+		&i.Confidence,
+		&i.NeedsReview,
+		&i.QuantityUnknown,
+	)
+	return i, err
+}
+
+const updateStagedItemExtraction = `-- name: UpdateStagedItemExtraction :one
+UPDATE staged_items
+SET ingredient_id    = $2,
+    quantity         = $3,
+    unit             = $4,
+    confidence       = $5,
+    needs_review     = $6,
+    quantity_unknown = $7
+WHERE id = $1
+RETURNING id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review, quantity_unknown
+`
+
+type UpdateStagedItemExtractionParams struct {
+	ID              uuid.UUID
+	IngredientID    uuid.NullUUID
+	Quantity        float64
+	Unit            string
+	Confidence      float64
+	NeedsReview     bool
+	QuantityUnknown bool
+}
+
+func (q *Queries) UpdateStagedItemExtraction(ctx context.Context, arg UpdateStagedItemExtractionParams) (StagedItem, error) {
+	row := q.db.QueryRowContext(ctx, updateStagedItemExtraction,
+		arg.ID,
+		arg.IngredientID,
+		arg.Quantity,
+		arg.Unit,
+		arg.Confidence,
+		arg.NeedsReview,
+		arg.QuantityUnknown,
 	)
 	var i StagedItem
 	err := row.Scan(
@@ -213,6 +609,41 @@ func (q *Queries) UpdateStagedItem(ctx context.Context, arg UpdateStagedItemPara
 		&i.Unit,
 		&i.Confidence,
 		&i.NeedsReview,
+		&i.QuantityUnknown,
 	)
 	return i, err
 }
+
+const upsertIngestionJobTimings = `-- name: UpsertIngestionJobTimings :exec
+INSERT INTO ingestion_job_timings (job_id, extraction_ms, resolution_ms, resolution_max_ms, resolved_items, total_ms)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (job_id) DO UPDATE
+  SET attempts          = ingestion_job_timings.attempts + 1,
+      extraction_ms     = EXCLUDED.extraction_ms,
+      resolution_ms     = EXCLUDED.resolution_ms,
+      resolution_max_ms = EXCLUDED.resolution_max_ms,
+      resolved_items    = EXCLUDED.resolved_items,
+      total_ms          = EXCLUDED.total_ms,
+      recorded_at       = now()
+`
+
+type UpsertIngestionJobTimingsParams struct {
+	JobID           uuid.UUID
+	ExtractionMs    int64
+	ResolutionMs    int64
+	ResolutionMaxMs int64
+	ResolvedItems   int32
+	TotalMs         int64
+}
+
+func (q *Queries) UpsertIngestionJobTimings(ctx context.Context, arg UpsertIngestionJobTimingsParams) error {
+	_, err := q.db.ExecContext(ctx, upsertIngestionJobTimings,
+		arg.JobID,
+		arg.ExtractionMs,
+		arg.ResolutionMs,
+		arg.ResolutionMaxMs,
+		arg.ResolvedItems,
+		arg.TotalMs,
+	)
+	return err
+}
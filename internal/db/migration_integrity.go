@@ -0,0 +1,144 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrMigrationDrift is returned by VerifyMigrations when a migration that was
+// already applied no longer matches the embedded file.
+var ErrMigrationDrift = errors.New("applied migrations differ from this build")
+
+const upSuffix = ".up.sql"
+
+// MigrationFile is one embedded up migration.
+type MigrationFile struct {
+	Version  int64
+	Name     string
+	Checksum string
+}
+
+// EmbeddedMigrations lists the up migrations in fsys, the migrations
+// directory, by version. Checksums are SHA-256 over the file with CRLF line
+// endings turned into LF, so a checkout with Windows line endings hashes the
+// same as any other.
+func EmbeddedMigrations(fsys fs.FS) ([]MigrationFile, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+	var files []MigrationFile
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, upSuffix) {
+			continue
+		}
+		prefix, rest, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: version prefix is not a number", name)
+		}
+		body, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", name, err)
+		}
+		sum := sha256.Sum256(bytes.ReplaceAll(body, []byte("\r\n"), []byte("\n")))
+		files = append(files, MigrationFile{
+			Version:  version,
+			Name:     strings.TrimSuffix(rest, upSuffix),
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Version < files[j].Version })
+	return files, nil
+}
+
+// VerifyMigrations checks every recorded checksum against files and returns
+// ErrMigrationDrift naming each applied migration that was edited or is
+// missing from this build. A database that has recorded no checksums yet
+// passes.
+func VerifyMigrations(ctx context.Context, q Querier, files []MigrationFile) error {
+	recorded, err := q.MigrationChecksumsRecorded(ctx)
+	if err != nil {
+		return fmt.Errorf("check for migration checksums: %w", err)
+	}
+	if !recorded {
+		return nil
+	}
+	rows, err := q.ListMigrationChecksums(ctx)
+	if err != nil {
+		return fmt.Errorf("list migration checksums: %w", err)
+	}
+
+	embedded := make(map[int64]MigrationFile, len(files))
+	for _, f := range files {
+		embedded[f.Version] = f
+	}
+	var drift []string
+	for _, r := range rows {
+		f, ok := embedded[r.Version]
+		switch {
+		case !ok:
+			drift = append(drift, fmt.Sprintf("%d_%s is not in this build", r.Version, r.Name))
+		case f.Checksum != r.Checksum:
+			drift = append(drift, fmt.Sprintf("%d_%s was changed after it was applied", r.Version, r.Name))
+		}
+	}
+	if len(drift) > 0 {
+		return fmt.Errorf("%w: %s", ErrMigrationDrift, strings.Join(drift, "; "))
+	}
+	return nil
+}
+
+// RecordMigrations records the checksum of every file up to the applied
+// version. Versions already recorded keep their first checksum.
+func RecordMigrations(ctx context.Context, q Querier, files []MigrationFile, applied int64) error {
+	for _, f := range files {
+		if f.Version > applied {
+			break
+		}
+		if err := q.RecordMigrationChecksum(ctx, RecordMigrationChecksumParams{
+			Version:  f.Version,
+			Name:     f.Name,
+			Checksum: f.Checksum,
+		}); err != nil {
+			return fmt.Errorf("record checksum of migration %d: %w", f.Version, err)
+		}
+	}
+	return nil
+}
+
+// ErrNoMigrations is returned by AppliedMigration before any migration has
+// run.
+var ErrNoMigrations = errors.New("no migrations applied")
+
+// AppliedMigration reads the version golang-migrate last applied and whether
+// it stopped partway through (dirty). schema_migrations belongs to
+// golang-migrate, so this is not a generated query.
+func AppliedMigration(ctx context.Context, dbtx DBTX) (version int64, dirty bool, err error) {
+	var exists bool
+	if err := dbtx.QueryRowContext(ctx,
+		"SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists); err != nil {
+		return 0, false, fmt.Errorf("check for schema_migrations: %w", err)
+	}
+	if !exists {
+		return 0, false, ErrNoMigrations
+	}
+	err = dbtx.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, ErrNoMigrations
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("read schema_migrations: %w", err)
+	}
+	return version, dirty, nil
+}
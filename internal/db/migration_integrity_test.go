@@ -0,0 +1,108 @@
+package db_test
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestEmbeddedMigrations_NormalizesLineEndings(t *testing.T) {
+	t.Parallel()
+
+	unix, err := db.EmbeddedMigrations(fstest.MapFS{
+		"002_b.up.sql":   {Data: []byte("ALTER TABLE a ADD b INT;\n")},
+		"001_a.up.sql":   {Data: []byte("CREATE TABLE a (id INT);\n")},
+		"001_a.down.sql": {Data: []byte("DROP TABLE a;\n")},
+	})
+	require.NoError(t, err)
+	windows, err := db.EmbeddedMigrations(fstest.MapFS{
+		"001_a.up.sql": {Data: []byte("CREATE TABLE a (id INT);\r\n")},
+		"002_b.up.sql": {Data: []byte("ALTER TABLE a ADD b INT;\r\n")},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, unix, 2)
+	assert.Equal(t, int64(1), unix[0].Version)
+	assert.Equal(t, "a", unix[0].Name)
+	assert.Equal(t, unix, windows)
+
+	_, err = db.EmbeddedMigrations(fstest.MapFS{"first_a.up.sql": {Data: []byte("")}})
+	require.Error(t, err)
+}
+
+func TestEmbeddedMigrations_ShippedFiles(t *testing.T) {
+	t.Parallel()
+
+	dir, err := fs.Sub(db.MigrationsFS, "migrations")
+	require.NoError(t, err)
+	files, err := db.EmbeddedMigrations(dir)
+	require.NoError(t, err)
+	for i, f := range files {
+		assert.Equal(t, int64(i+1), f.Version, "migration versions are contiguous")
+	}
+}
+
+func TestVerifyMigrations(t *testing.T) {
+	t.Parallel()
+
+	files := []db.MigrationFile{
+		{Version: 1, Name: "init", Checksum: "aaa"},
+		{Version: 2, Name: "items", Checksum: "bbb"},
+	}
+	ctx := context.Background()
+
+	t.Run("nothing recorded yet", func(t *testing.T) {
+		t.Parallel()
+		q := mocks.NewMockQuerier(t)
+		q.EXPECT().MigrationChecksumsRecorded(mock.Anything).Return(false, nil)
+		require.NoError(t, db.VerifyMigrations(ctx, q, files))
+	})
+
+	t.Run("matching", func(t *testing.T) {
+		t.Parallel()
+		q := mocks.NewMockQuerier(t)
+		q.EXPECT().MigrationChecksumsRecorded(mock.Anything).Return(true, nil)
+		q.EXPECT().ListMigrationChecksums(mock.Anything).Return([]db.SchemaMigrationChecksum{
+			{Version: 1, Name: "init", Checksum: "aaa"},
+		}, nil)
+		require.NoError(t, db.VerifyMigrations(ctx, q, files))
+	})
+
+	t.Run("edited or missing", func(t *testing.T) {
+		t.Parallel()
+		q := mocks.NewMockQuerier(t)
+		q.EXPECT().MigrationChecksumsRecorded(mock.Anything).Return(true, nil)
+		q.EXPECT().ListMigrationChecksums(mock.Anything).Return([]db.SchemaMigrationChecksum{
+			{Version: 1, Name: "init", Checksum: "aaa"},
+			{Version: 2, Name: "items", Checksum: "changed"},
+			{Version: 3, Name: "future", Checksum: "ccc"},
+		}, nil)
+		err := db.VerifyMigrations(ctx, q, files)
+		require.ErrorIs(t, err, db.ErrMigrationDrift)
+		assert.ErrorContains(t, err, "2_items was changed after it was applied")
+		assert.ErrorContains(t, err, "3_future is not in this build")
+	})
+}
+
+func TestRecordMigrations_StopsAtAppliedVersion(t *testing.T) {
+	t.Parallel()
+
+	q := mocks.NewMockQuerier(t)
+	q.EXPECT().RecordMigrationChecksum(mock.Anything, db.RecordMigrationChecksumParams{
+		Version: 1, Name: "init", Checksum: "aaa",
+	}).Return(nil)
+
+	err := db.RecordMigrations(context.Background(), q, []db.MigrationFile{
+		{Version: 1, Name: "init", Checksum: "aaa"},
+		{Version: 2, Name: "items", Checksum: "bbb"},
+	}, 1)
+	require.NoError(t, err)
+}
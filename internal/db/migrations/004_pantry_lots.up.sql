@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS pantry_lots (
+  id             UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+  pantry_item_id UUID        NOT NULL REFERENCES pantry_items(id) ON DELETE CASCADE,
+  quantity       FLOAT8      NOT NULL,
+  unit           TEXT        NOT NULL,
+  expires_at     TIMESTAMPTZ,
+  source_job_id  UUID,
+  added_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS pantry_lots_item_expiry_idx ON pantry_lots (pantry_item_id, expires_at);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS pantry_lot_events (
+  id             UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+  pantry_item_id UUID        NOT NULL REFERENCES pantry_items(id) ON DELETE CASCADE,
+  lot_id         UUID        NOT NULL,
+  kind           TEXT        NOT NULL CHECK (kind IN ('added', 'consumed')),
+  quantity       FLOAT8      NOT NULL,
+  unit           TEXT        NOT NULL,
+  expires_at     TIMESTAMPTZ,
+  occurred_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS pantry_lot_events_item_idx ON pantry_lot_events (pantry_item_id, occurred_at);
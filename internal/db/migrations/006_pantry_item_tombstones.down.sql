@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS pantry_items_tombstone ON pantry_items;
+DROP FUNCTION IF EXISTS record_pantry_item_tombstone();
+DROP INDEX IF EXISTS pantry_items_updated_at_idx;
+DROP TABLE IF EXISTS pantry_item_tombstones;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS pantry_item_tombstones (
+  item_id       UUID        PRIMARY KEY,
+  ingredient_id UUID        NOT NULL,
+  deleted_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS pantry_item_tombstones_deleted_at_idx ON pantry_item_tombstones (deleted_at);
+CREATE INDEX IF NOT EXISTS pantry_items_updated_at_idx ON pantry_items (updated_at);
+
+CREATE OR REPLACE FUNCTION record_pantry_item_tombstone() RETURNS trigger AS $$
+BEGIN
+  INSERT INTO pantry_item_tombstones (item_id, ingredient_id)
+  VALUES (OLD.id, OLD.ingredient_id)
+  ON CONFLICT (item_id) DO UPDATE SET deleted_at = now();
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER pantry_items_tombstone
+AFTER DELETE ON pantry_items
+FOR EACH ROW EXECUTE FUNCTION record_pantry_item_tombstone();
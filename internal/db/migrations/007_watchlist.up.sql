@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS watchlist (
+  ingredient_id UUID        PRIMARY KEY,
+  min_quantity  FLOAT8,
+  unit          TEXT,
+  created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK ((min_quantity IS NULL) = (unit IS NULL))
+);
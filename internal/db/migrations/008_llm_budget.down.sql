@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS llm_usage;
+ALTER TABLE ingestion_jobs DROP COLUMN IF EXISTS budget_exceeded;
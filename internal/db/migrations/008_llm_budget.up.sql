@@ -0,0 +1,9 @@
+ALTER TABLE ingestion_jobs
+  ADD COLUMN IF NOT EXISTS budget_exceeded BOOLEAN NOT NULL DEFAULT false;
+
+-- One row per calendar month (UTC); tokens_used is reset by the admin endpoint.
+CREATE TABLE IF NOT EXISTS llm_usage (
+  month       DATE        PRIMARY KEY,
+  tokens_used BIGINT      NOT NULL DEFAULT 0,
+  updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,10 @@
+-- Events that could not be published because RabbitMQ was unreachable;
+-- drained in id order once the broker is back.
+CREATE TABLE IF NOT EXISTS event_outbox (
+  id          BIGSERIAL   PRIMARY KEY,
+  routing_key TEXT        NOT NULL,
+  body        BYTEA       NOT NULL,
+  attempts    INT         NOT NULL DEFAULT 0,
+  last_error  TEXT,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1 @@
+ALTER TABLE ingestion_jobs DROP COLUMN IF EXISTS truncated_items;
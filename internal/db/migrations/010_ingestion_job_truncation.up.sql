@@ -0,0 +1,3 @@
+-- Number of extracted items dropped because the job hit the staged item cap.
+ALTER TABLE ingestion_jobs
+  ADD COLUMN IF NOT EXISTS truncated_items INT NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- The data fix is not reversible; the original values are gone.
+SELECT 1;
@@ -0,0 +1,27 @@
+-- Clean up quantities that cannot survive the move to NUMERIC(12,3) with a
+-- non-negative check: NaN, infinities, negatives, and values past the
+-- precision limit. Lots left empty are removed, matching DeleteEmptyPantryLots.
+UPDATE pantry_items
+SET quantity   = 0,
+    updated_at = now()
+WHERE quantity = 'NaN'::float8 OR quantity < 0 OR quantity = '-Infinity'::float8;
+
+UPDATE pantry_items
+SET quantity   = 999999999.999,
+    updated_at = now()
+WHERE quantity > 999999999.999;
+
+DELETE FROM pantry_lots
+WHERE quantity = 'NaN'::float8 OR quantity <= 0;
+
+UPDATE pantry_lots
+SET quantity = 999999999.999
+WHERE quantity > 999999999.999;
+
+UPDATE pantry_lot_events
+SET quantity = 0
+WHERE quantity = 'NaN'::float8 OR quantity < 0 OR quantity = '-Infinity'::float8;
+
+UPDATE pantry_lot_events
+SET quantity = 999999999.999
+WHERE quantity > 999999999.999;
@@ -0,0 +1,10 @@
+ALTER TABLE pantry_lot_events
+  ALTER COLUMN quantity TYPE FLOAT8 USING quantity::float8;
+
+ALTER TABLE pantry_lots
+  DROP CONSTRAINT IF EXISTS pantry_lots_quantity_nonnegative,
+  ALTER COLUMN quantity TYPE FLOAT8 USING quantity::float8;
+
+ALTER TABLE pantry_items
+  DROP CONSTRAINT IF EXISTS pantry_items_quantity_nonnegative,
+  ALTER COLUMN quantity TYPE FLOAT8 USING quantity::float8;
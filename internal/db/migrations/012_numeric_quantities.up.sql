@@ -0,0 +1,15 @@
+-- Store stock quantities as exact decimals so repeated adds and lot
+-- decrements do not drift, and reject negative stock at the database.
+ALTER TABLE pantry_items
+  ALTER COLUMN quantity TYPE NUMERIC(12,3) USING round(quantity::numeric, 3),
+  ALTER COLUMN unit SET NOT NULL,
+  ADD CONSTRAINT pantry_items_quantity_nonnegative CHECK (quantity >= 0);
+
+ALTER TABLE pantry_lots
+  ALTER COLUMN quantity TYPE NUMERIC(12,3) USING round(quantity::numeric, 3),
+  ALTER COLUMN unit SET NOT NULL,
+  ADD CONSTRAINT pantry_lots_quantity_nonnegative CHECK (quantity >= 0);
+
+ALTER TABLE pantry_lot_events
+  ALTER COLUMN quantity TYPE NUMERIC(12,3) USING round(quantity::numeric, 3),
+  ALTER COLUMN unit SET NOT NULL;
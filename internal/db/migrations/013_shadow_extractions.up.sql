@@ -0,0 +1,14 @@
+-- Side-by-side results of running each ingest through a candidate extractor
+-- (SHADOW_EXTRACT_MODEL). Never read by staging; only by the comparison report.
+CREATE TABLE IF NOT EXISTS shadow_extractions (
+  job_id        UUID        PRIMARY KEY REFERENCES ingestion_jobs(id) ON DELETE CASCADE,
+  candidate     TEXT        NOT NULL,
+  primary_items JSONB       NOT NULL,
+  shadow_items  JSONB       NOT NULL DEFAULT '[]',
+  error         TEXT,
+  tokens_used   INT         NOT NULL DEFAULT 0,
+  duration_ms   INT         NOT NULL DEFAULT 0,
+  created_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS shadow_extractions_created_idx ON shadow_extractions (created_at DESC);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS ingestion_jobs_source_created_idx;
+ALTER TABLE ingestion_jobs DROP COLUMN IF EXISTS source;
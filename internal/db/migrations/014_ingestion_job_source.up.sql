@@ -0,0 +1,7 @@
+-- Which channel submitted the job. Jobs created before attribution existed
+-- are 'unknown'; new jobs default to 'api' in the handler.
+ALTER TABLE ingestion_jobs
+  ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'unknown'
+  CHECK (source IN ('web', 'mobile', 'email', 'voice', 'chatbot', 'api', 'unknown'));
+
+CREATE INDEX IF NOT EXISTS ingestion_jobs_source_created_idx ON ingestion_jobs (source, created_at DESC);
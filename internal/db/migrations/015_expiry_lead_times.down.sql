@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS pantry_items_expires_at_idx;
+DROP TABLE IF EXISTS expiry_lead_times;
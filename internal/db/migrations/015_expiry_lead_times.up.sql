@@ -0,0 +1,14 @@
+-- How many days before expiry an item counts as "expiring", per Dictionary
+-- ingredient category. Categories without a row use EXPIRY_DEFAULT_LEAD_DAYS.
+CREATE TABLE IF NOT EXISTS expiry_lead_times (
+  category   TEXT        PRIMARY KEY,
+  lead_days  INT         NOT NULL CHECK (lead_days >= 0),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO expiry_lead_times (category, lead_days)
+VALUES ('dairy', 3), ('produce', 2), ('frozen', 14)
+ON CONFLICT (category) DO NOTHING;
+
+CREATE INDEX IF NOT EXISTS pantry_items_expires_at_idx ON pantry_items (expires_at)
+  WHERE expires_at IS NOT NULL;
@@ -0,0 +1,25 @@
+-- Where each kind of notification goes. The service tracks a single
+-- household, so there is one row per kind; kinds without a row are not sent.
+CREATE TABLE IF NOT EXISTS notification_preferences (
+  kind       TEXT        PRIMARY KEY CHECK (kind IN ('expiring', 'low_stock')),
+  channel    TEXT        NOT NULL CHECK (channel IN ('email', 'push', 'webhook', 'none')),
+  target     TEXT        NOT NULL DEFAULT '',
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Notifications waiting to be sent, and a record of those already sent.
+-- The dispatch worker retries unsent rows until attempts reaches its limit.
+CREATE TABLE IF NOT EXISTS notifications (
+  id         BIGSERIAL   PRIMARY KEY,
+  kind       TEXT        NOT NULL,
+  channel    TEXT        NOT NULL,
+  target     TEXT        NOT NULL,
+  subject    TEXT        NOT NULL,
+  body       TEXT        NOT NULL,
+  attempts   INT         NOT NULL DEFAULT 0,
+  last_error TEXT,
+  sent_at    TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS notifications_unsent_idx ON notifications (id) WHERE sent_at IS NULL;
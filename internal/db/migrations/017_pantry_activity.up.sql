@@ -0,0 +1,13 @@
+-- A household-facing record of pantry changes, read newest first by
+-- GET /pantry/activity. Rows store facts only; descriptions are rendered at
+-- read time so wording can change without a backfill.
+CREATE TABLE IF NOT EXISTS pantry_activity (
+  id            BIGSERIAL   PRIMARY KEY,
+  kind          TEXT        NOT NULL CHECK (kind IN ('item_added', 'item_removed', 'pantry_reset', 'ingest_confirmed')),
+  ingredient_id UUID,
+  quantity      FLOAT8,
+  unit          TEXT,
+  source        TEXT,
+  item_count    INT         NOT NULL DEFAULT 0,
+  occurred_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,11 @@
+-- The unit staged for an item whose list entry gives none, per Dictionary
+-- ingredient category. Categories without a row use "piece".
+CREATE TABLE IF NOT EXISTS category_default_units (
+  category   TEXT        PRIMARY KEY,
+  unit       TEXT        NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO category_default_units (category, unit)
+VALUES ('produce', 'piece'), ('beverages', 'ml'), ('grains', 'g')
+ON CONFLICT (category) DO NOTHING;
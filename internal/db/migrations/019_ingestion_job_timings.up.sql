@@ -0,0 +1,13 @@
+-- Where an ingest job's processing time went, overwritten on each run.
+-- attempts counts runs, so a job re-run by a forced transition shows its
+-- retries.
+CREATE TABLE IF NOT EXISTS ingestion_job_timings (
+  job_id            UUID        PRIMARY KEY REFERENCES ingestion_jobs(id) ON DELETE CASCADE,
+  attempts          INT         NOT NULL DEFAULT 1,
+  extraction_ms     BIGINT      NOT NULL,
+  resolution_ms     BIGINT      NOT NULL,
+  resolution_max_ms BIGINT      NOT NULL,
+  resolved_items    INT         NOT NULL,
+  total_ms          BIGINT      NOT NULL,
+  recorded_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
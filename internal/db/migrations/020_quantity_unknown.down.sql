@@ -0,0 +1,2 @@
+ALTER TABLE staged_items DROP COLUMN IF EXISTS quantity_unknown;
+ALTER TABLE pantry_items DROP COLUMN IF EXISTS quantity_unknown;
@@ -0,0 +1,5 @@
+-- Items listed without an amount ("olive oil") are in stock, amount unknown.
+-- quantity then holds an estimate, or 0 when there is none; items without an
+-- estimate are left out of quantity comparisons.
+ALTER TABLE pantry_items ADD COLUMN IF NOT EXISTS quantity_unknown BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE staged_items ADD COLUMN IF NOT EXISTS quantity_unknown BOOLEAN NOT NULL DEFAULT false;
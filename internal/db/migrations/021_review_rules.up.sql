@@ -0,0 +1,14 @@
+-- Household rules applied when items are staged. A rule matches an item by
+-- resolved ingredient name, Dictionary category, and/or a quantity ceiling;
+-- every condition that is set must hold. "review" always flags the item,
+-- "accept" clears a low-confidence flag.
+CREATE TABLE IF NOT EXISTS review_rules (
+  id             UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+  action         TEXT        NOT NULL CHECK (action IN ('review', 'accept')),
+  ingredient     TEXT,
+  category       TEXT,
+  below_quantity FLOAT8,
+  unit           TEXT,
+  description    TEXT        NOT NULL DEFAULT '',
+  created_at     TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS category_prices;
+DROP TABLE IF EXISTS ingredient_prices;
@@ -0,0 +1,15 @@
+-- Price per unit used to estimate pantry value. An ingredient's own price
+-- wins; otherwise the average for its Dictionary category applies.
+CREATE TABLE IF NOT EXISTS ingredient_prices (
+  ingredient_id UUID        PRIMARY KEY,
+  price         FLOAT8      NOT NULL CHECK (price >= 0),
+  unit          TEXT        NOT NULL,
+  updated_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS category_prices (
+  category   TEXT        PRIMARY KEY,
+  price      FLOAT8      NOT NULL CHECK (price >= 0),
+  unit       TEXT        NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,18 @@
+-- Every outgoing webhook attempt, for auditing deliveries. status_code is
+-- NULL when no response arrived; endpoint is stored without query string or
+-- credentials.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  id           BIGSERIAL   PRIMARY KEY,
+  source       TEXT        NOT NULL,
+  endpoint     TEXT        NOT NULL,
+  signed       BOOLEAN     NOT NULL,
+  status_code  INT,
+  latency_ms   INT         NOT NULL,
+  error        TEXT,
+  attempted_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_attempted_at_idx ON webhook_deliveries (attempted_at);
+
+-- HMAC secret used to sign webhook notifications; empty sends them unsigned.
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS secret TEXT NOT NULL DEFAULT '';
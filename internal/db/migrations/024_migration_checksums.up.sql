@@ -0,0 +1,9 @@
+-- SHA-256 of each applied migration's up file, recorded after it runs. At
+-- startup the embedded files are checked against it so a migration edited
+-- after it was applied is caught before the schema silently diverges.
+CREATE TABLE IF NOT EXISTS schema_migration_checksums (
+  version     BIGINT      PRIMARY KEY,
+  name        TEXT        NOT NULL,
+  checksum    TEXT        NOT NULL,
+  recorded_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
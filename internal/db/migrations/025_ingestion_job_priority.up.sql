@@ -0,0 +1,5 @@
+-- How urgently a job should be processed: 'interactive' when someone is
+-- waiting on it in the app, 'background' for bulk imports.
+ALTER TABLE ingestion_jobs
+  ADD COLUMN IF NOT EXISTS priority TEXT NOT NULL DEFAULT 'interactive'
+  CHECK (priority IN ('interactive', 'background'));
@@ -0,0 +1,10 @@
+-- Perishable items left untouched past their category's shelf life. A flag
+-- only counts while the item's updated_at still equals item_updated_at, so
+-- any edit to the item clears it without touching this table.
+CREATE TABLE IF NOT EXISTS stale_flags (
+  item_id         UUID        PRIMARY KEY REFERENCES pantry_items (id) ON DELETE CASCADE,
+  category        TEXT        NOT NULL,
+  shelf_life_days INT         NOT NULL,
+  item_updated_at TIMESTAMPTZ NOT NULL,
+  flagged_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,4 @@
+DELETE FROM pantry_activity WHERE kind = 'item_consumed';
+ALTER TABLE pantry_activity DROP CONSTRAINT IF EXISTS pantry_activity_kind_check;
+ALTER TABLE pantry_activity ADD CONSTRAINT pantry_activity_kind_check
+  CHECK (kind IN ('item_added', 'item_removed', 'pantry_reset', 'ingest_confirmed'));
@@ -0,0 +1,4 @@
+-- POST /pantry/items/{id}/consume records what was used up.
+ALTER TABLE pantry_activity DROP CONSTRAINT IF EXISTS pantry_activity_kind_check;
+ALTER TABLE pantry_activity ADD CONSTRAINT pantry_activity_kind_check
+  CHECK (kind IN ('item_added', 'item_removed', 'item_consumed', 'pantry_reset', 'ingest_confirmed'));
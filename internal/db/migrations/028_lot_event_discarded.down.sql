@@ -0,0 +1,4 @@
+DELETE FROM pantry_lot_events WHERE kind = 'discarded';
+ALTER TABLE pantry_lot_events DROP CONSTRAINT IF EXISTS pantry_lot_events_kind_check;
+ALTER TABLE pantry_lot_events ADD CONSTRAINT pantry_lot_events_kind_check
+  CHECK (kind IN ('added', 'consumed'));
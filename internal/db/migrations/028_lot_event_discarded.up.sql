@@ -0,0 +1,5 @@
+-- Lots dropped when an item changes unit are recorded as 'discarded', so
+-- replaying pantry_lot_events accounts for every lot that left the pantry.
+ALTER TABLE pantry_lot_events DROP CONSTRAINT IF EXISTS pantry_lot_events_kind_check;
+ALTER TABLE pantry_lot_events ADD CONSTRAINT pantry_lot_events_kind_check
+  CHECK (kind IN ('added', 'consumed', 'discarded'));
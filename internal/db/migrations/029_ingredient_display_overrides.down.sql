@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ingredient_display_overrides;
@@ -0,0 +1,9 @@
+-- Household names and emojis shown for an ingredient in place of the
+-- Dictionary's. Resolution and availability still use ingredient_id.
+CREATE TABLE IF NOT EXISTS ingredient_display_overrides (
+  ingredient_id UUID        PRIMARY KEY,
+  display_name  TEXT,
+  emoji         TEXT,
+  updated_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK (display_name IS NOT NULL OR emoji IS NOT NULL)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS ingestion_jobs_pending_idx;
+DROP TABLE IF EXISTS ingest_job_claims;
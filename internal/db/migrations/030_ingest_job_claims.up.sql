@@ -0,0 +1,10 @@
+-- Claims on ingest jobs taken from the Postgres job queue (INGEST_QUEUE=db).
+-- attempts counts claims; claimed_at expires a claim whose worker died.
+CREATE TABLE IF NOT EXISTS ingest_job_claims (
+  job_id     UUID        PRIMARY KEY REFERENCES ingestion_jobs(id) ON DELETE CASCADE,
+  attempts   INT         NOT NULL DEFAULT 1,
+  claimed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ingestion_jobs_pending_idx
+  ON ingestion_jobs (created_at) WHERE status = 'pending';
@@ -0,0 +1,28 @@
+-- Endpoints that receive events over HTTP, for integrations that do not
+-- speak AMQP. event_types lists the events each one wants.
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+  id          UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+  url         TEXT        NOT NULL,
+  event_types TEXT[]      NOT NULL,
+  secret      TEXT        NOT NULL DEFAULT '',
+  description TEXT        NOT NULL DEFAULT '',
+  active      BOOLEAN     NOT NULL DEFAULT true,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- One row per event per subscription. The dispatcher retries unsent rows
+-- from next_attempt_at, backing off, until attempts reaches its limit.
+CREATE TABLE IF NOT EXISTS webhook_events (
+  id              BIGSERIAL   PRIMARY KEY,
+  subscription_id UUID        NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+  event_type      TEXT        NOT NULL,
+  payload         JSONB       NOT NULL,
+  attempts        INT         NOT NULL DEFAULT 0,
+  last_error      TEXT,
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  sent_at         TIMESTAMPTZ,
+  created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_events_due_idx ON webhook_events (next_attempt_at) WHERE sent_at IS NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS ingestion_jobs_staged_idx;
@@ -0,0 +1,4 @@
+-- Lets the job janitor find staged jobs past their TTL without scanning
+-- every job.
+CREATE INDEX IF NOT EXISTS ingestion_jobs_staged_idx
+  ON ingestion_jobs (created_at) WHERE status = 'staged';
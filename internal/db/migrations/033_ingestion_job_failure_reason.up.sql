@@ -0,0 +1,3 @@
+-- Why a failed job failed, so users can tell a transient provider error
+-- from input that needs fixing. Empty unless status is failed.
+ALTER TABLE ingestion_jobs ADD COLUMN IF NOT EXISTS failure_reason TEXT NOT NULL DEFAULT '';
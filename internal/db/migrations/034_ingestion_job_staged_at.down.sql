@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS ingestion_jobs_staged_idx;
+CREATE INDEX IF NOT EXISTS ingestion_jobs_staged_idx
+  ON ingestion_jobs (created_at) WHERE status = 'staged';
+ALTER TABLE ingestion_jobs DROP COLUMN IF EXISTS staged_at;
@@ -0,0 +1,9 @@
+-- When a job last became staged, so the staged TTL does not count time
+-- spent queued or failed before a retry. Jobs staged before this column
+-- existed keep the created_at the janitor used until now.
+ALTER TABLE ingestion_jobs ADD COLUMN IF NOT EXISTS staged_at TIMESTAMPTZ;
+UPDATE ingestion_jobs SET staged_at = created_at WHERE status = 'staged' AND staged_at IS NULL;
+
+DROP INDEX IF EXISTS ingestion_jobs_staged_idx;
+CREATE INDEX IF NOT EXISTS ingestion_jobs_staged_idx
+  ON ingestion_jobs (staged_at) WHERE status = 'staged';
@@ -6,36 +6,253 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type CategoryDefaultUnit struct {
+	Category  string
+	Unit      string
+	UpdatedAt time.Time
+}
+
+type CategoryPrice struct {
+	Category  string
+	Price     float64
+	Unit      string
+	UpdatedAt time.Time
+}
+
+type EventOutbox struct {
+	ID         int64
+	RoutingKey string
+	Body       []byte
+	Attempts   int32
+	LastError  sql.NullString
+	CreatedAt  time.Time
+}
+
+type ExpiryLeadTime struct {
+	Category  string
+	LeadDays  int32
+	UpdatedAt time.Time
+}
+
+type IngredientDisplayOverride struct {
+	IngredientID uuid.UUID
+	DisplayName  sql.NullString
+	Emoji        sql.NullString
+	UpdatedAt    time.Time
+}
+
+type IngredientPrice struct {
+	IngredientID uuid.UUID
+	Price        float64
+	Unit         string
+	UpdatedAt    time.Time
+}
+
+type IngestJobClaim struct {
+	JobID     uuid.UUID
+	Attempts  int32
+	ClaimedAt time.Time
+}
+
 type IngestionJob struct {
-	ID        uuid.UUID
-	Type      string
-	RawInput  string
-	Status    string
+	ID             uuid.UUID
+	Type           string
+	RawInput       string
+	Status         string
+	BudgetExceeded bool
+	TruncatedItems int32
+	Source         string
+	Priority       string
+	CreatedAt      time.Time
+	FailureReason  string
+}
+
+type IngestionJobTiming struct {
+	JobID           uuid.UUID
+	Attempts        int32
+	ExtractionMs    int64
+	ResolutionMs    int64
+	ResolutionMaxMs int64
+	ResolvedItems   int32
+	TotalMs         int64
+	RecordedAt      time.Time
+}
+
+type LlmUsage struct {
+	Month      time.Time
+	TokensUsed int64
+	UpdatedAt  time.Time
+}
+
+type Notification struct {
+	ID        int64
+	Kind      string
+	Channel   string
+	Target    string
+	Subject   string
+	Body      string
+	Attempts  int32
+	LastError sql.NullString
+	SentAt    sql.NullTime
 	CreatedAt time.Time
 }
 
+type NotificationPreference struct {
+	Kind      string
+	Channel   string
+	Target    string
+	Secret    string
+	UpdatedAt time.Time
+}
+
+type PantryActivity struct {
+	ID           int64
+	Kind         string
+	IngredientID uuid.NullUUID
+	Quantity     sql.NullFloat64
+	Unit         sql.NullString
+	Source       sql.NullString
+	ItemCount    int32
+	OccurredAt   time.Time
+}
+
 type PantryItem struct {
-	ID           uuid.UUID
+	ID              uuid.UUID
+	IngredientID    uuid.UUID
+	Quantity        float64
+	Unit            string
+	ExpiresAt       sql.NullTime
+	AddedAt         time.Time
+	UpdatedAt       time.Time
+	QuantityUnknown bool
+}
+
+type PantryItemTombstone struct {
+	ItemID       uuid.UUID
 	IngredientID uuid.UUID
+	DeletedAt    time.Time
+}
+
+type PantryLot struct {
+	ID           uuid.UUID
+	PantryItemID uuid.UUID
 	Quantity     float64
 	Unit         string
 	ExpiresAt    sql.NullTime
+	SourceJobID  uuid.NullUUID
 	AddedAt      time.Time
-	UpdatedAt    time.Time
 }
 
-type StagedItem struct {
+type PantryLotEvent struct {
 	ID           uuid.UUID
-	JobID        uuid.UUID
-	IngredientID uuid.NullUUID
-	RawText      string
+	PantryItemID uuid.UUID
+	LotID        uuid.UUID
+	Kind         string
 	Quantity     float64
 	Unit         string
-	Confidence   float64
-	NeedsReview  bool
+	ExpiresAt    sql.NullTime
+	OccurredAt   time.Time
+}
+
+type ProcessedMessage struct {
+	Consumer    string
+	MessageID   string
+	ProcessedAt time.Time
+}
+
+type ReviewRule struct {
+	ID            uuid.UUID
+	Action        string
+	Ingredient    sql.NullString
+	Category      sql.NullString
+	BelowQuantity sql.NullFloat64
+	Unit          sql.NullString
+	Description   string
+	CreatedAt     time.Time
+}
+
+type SchemaMigrationChecksum struct {
+	Version    int64
+	Name       string
+	Checksum   string
+	RecordedAt time.Time
+}
+
+type ShadowExtraction struct {
+	JobID        uuid.UUID
+	Candidate    string
+	PrimaryItems json.RawMessage
+	ShadowItems  json.RawMessage
+	Error        sql.NullString
+	TokensUsed   int32
+	DurationMs   int32
+	CreatedAt    time.Time
+}
+
+type StagedItem struct {
+	ID              uuid.UUID
+	JobID           uuid.UUID
+	IngredientID    uuid.NullUUID
+	RawText         string
+	Quantity        float64
+	Unit            string
+	Confidence      float64
+	NeedsReview     bool
+	QuantityUnknown bool
+}
+
+type StaleFlag struct {
+	ItemID        uuid.UUID
+	Category      string
+	ShelfLifeDays int32
+	ItemUpdatedAt time.Time
+	FlaggedAt     time.Time
+}
+
+type Watchlist struct {
+	IngredientID uuid.UUID
+	MinQuantity  sql.NullFloat64
+	Unit         sql.NullString
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type WebhookDelivery struct {
+	ID          int64
+	Source      string
+	Endpoint    string
+	Signed      bool
+	StatusCode  sql.NullInt32
+	LatencyMs   int32
+	Error       sql.NullString
+	AttemptedAt time.Time
+}
+
+type WebhookEvent struct {
+	ID             int64
+	SubscriptionID uuid.UUID
+	EventType      string
+	Payload        json.RawMessage
+	Attempts       int32
+	LastError      sql.NullString
+	NextAttemptAt  time.Time
+	SentAt         sql.NullTime
+	CreatedAt      time.Time
+}
+
+type WebhookSubscription struct {
+	ID          uuid.UUID
+	Url         string
+	EventTypes  []string
+	Secret      string
+	Description string
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
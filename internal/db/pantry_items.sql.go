@@ -8,10 +8,74 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const consumePantryItem = `-- name: ConsumePantryItem :one
+UPDATE pantry_items p
+SET quantity   = GREATEST(p.quantity - $1::float8, 0),
+    updated_at = now()
+FROM (SELECT id, quantity FROM pantry_items WHERE id = $2 FOR UPDATE) prev
+WHERE p.id = prev.id
+  AND (NOT p.quantity_unknown OR p.quantity > 0)
+  AND ($3::text IS NULL OR p.unit = $3)
+RETURNING p.id, p.ingredient_id, p.quantity, p.unit, p.expires_at, p.added_at, p.updated_at, p.quantity_unknown,
+          prev.quantity AS previous_quantity
+`
+
+type ConsumePantryItemParams struct {
+	Quantity float64
+	ID       uuid.UUID
+	Unit     sql.NullString
+}
+
+type ConsumePantryItemRow struct {
+	ID               uuid.UUID
+	IngredientID     uuid.UUID
+	Quantity         float64
+	Unit             string
+	ExpiresAt        sql.NullTime
+	AddedAt          time.Time
+	UpdatedAt        time.Time
+	QuantityUnknown  bool
+	PreviousQuantity float64
+}
+
+func (q *Queries) ConsumePantryItem(ctx context.Context, arg ConsumePantryItemParams) (ConsumePantryItemRow, error) {
+	row := q.db.QueryRowContext(ctx, consumePantryItem,
+		arg.Quantity,
+		arg.ID,
+		arg.Unit,
+	)
+	var i ConsumePantryItemRow
+	err := row.Scan(
+		&i.ID,
+		&i.IngredientID,
+		&i.Quantity,
+		&i.Unit,
+		&i.ExpiresAt,
+		&i.AddedAt,
+		&i.UpdatedAt,
+		&i.QuantityUnknown,
+		&i.PreviousQuantity,
+	)
+	return i, err
+}
+
+const countPantryItems = `-- name: CountPantryItems :one
+SELECT count(*) FROM pantry_items
+`
+
+func (q *Queries) CountPantryItems(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPantryItems)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const deleteAllPantryItems = `-- name: DeleteAllPantryItems :exec
 DELETE FROM pantry_items
 `
@@ -30,8 +94,21 @@ func (q *Queries) DeletePantryItem(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deletePantryTombstonesBefore = `-- name: DeletePantryTombstonesBefore :execrows
+DELETE FROM pantry_item_tombstones
+WHERE deleted_at < $1
+`
+
+func (q *Queries) DeletePantryTombstonesBefore(ctx context.Context, deletedAt time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deletePantryTombstonesBefore, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getPantryItem = `-- name: GetPantryItem :one
-SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
 FROM pantry_items
 WHERE id = $1
 `
@@ -47,12 +124,35 @@ func (q *Queries) GetPantryItem(ctx context.Context, id uuid.UUID) (PantryItem,
 		&i.ExpiresAt,
 		&i.AddedAt,
 		&i.UpdatedAt,
+		&i.QuantityUnknown,
+	)
+	return i, err
+}
+
+const getPantryItemByIngredient = `-- name: GetPantryItemByIngredient :one
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+FROM pantry_items
+WHERE ingredient_id = $1
+`
+
+func (q *Queries) GetPantryItemByIngredient(ctx context.Context, ingredientID uuid.UUID) (PantryItem, error) {
+	row := q.db.QueryRowContext(ctx, getPantryItemByIngredient, ingredientID)
+	var i PantryItem
+	err := row.Scan(
+		&i.ID,
+		&i.IngredientID,
+		&i.Quantity,
+		&i.Unit,
+		&i.ExpiresAt,
+		&i.AddedAt,
+		&i.UpdatedAt,
+		&i.QuantityUnknown,
 	)
 	return i, err
 }
 
 const listPantryItems = `-- name: ListPantryItems :many
-SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
 FROM pantry_items
 ORDER BY added_at
 `
@@ -74,6 +174,45 @@ func (q *Queries) ListPantryItems(ctx context.Context) ([]PantryItem, error) {
 			&i.ExpiresAt,
 			&i.AddedAt,
 			&i.UpdatedAt,
+			&i.QuantityUnknown,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPantryItemsByIDs = `-- name: ListPantryItemsByIDs :many
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+FROM pantry_items
+WHERE id = ANY($1::uuid[])
+`
+
+func (q *Queries) ListPantryItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]PantryItem, error) {
+	rows, err := q.db.QueryContext(ctx, listPantryItemsByIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PantryItem
+	for rows.Next() {
+		var i PantryItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.IngredientID,
+			&i.Quantity,
+			&i.Unit,
+			&i.ExpiresAt,
+			&i.AddedAt,
+			&i.UpdatedAt,
+			&i.QuantityUnknown,
 		); err != nil {
 			return nil, err
 		}
@@ -88,22 +227,277 @@ func (q *Queries) ListPantryItems(ctx context.Context) ([]PantryItem, error) {
 	return items, nil
 }
 
+const listPantryItemsByIngredients = `-- name: ListPantryItemsByIngredients :many
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+FROM pantry_items
+WHERE ingredient_id = ANY($1::uuid[])
+ORDER BY added_at
+`
+
+func (q *Queries) ListPantryItemsByIngredients(ctx context.Context, ingredientIds []uuid.UUID) ([]PantryItem, error) {
+	rows, err := q.db.QueryContext(ctx, listPantryItemsByIngredients, pq.Array(ingredientIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PantryItem
+	for rows.Next() {
+		var i PantryItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.IngredientID,
+			&i.Quantity,
+			&i.Unit,
+			&i.ExpiresAt,
+			&i.AddedAt,
+			&i.UpdatedAt,
+			&i.QuantityUnknown,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPantryItemsPage = `-- name: ListPantryItemsPage :many
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+FROM pantry_items
+WHERE ($1::timestamptz IS NULL
+       OR (added_at, id) > ($1, $2::uuid))
+ORDER BY added_at, id
+LIMIT $3
+`
+
+type ListPantryItemsPageParams struct {
+	AfterAddedAt sql.NullTime
+	AfterID      uuid.NullUUID
+	Limit        int32
+}
+
+func (q *Queries) ListPantryItemsPage(ctx context.Context, arg ListPantryItemsPageParams) ([]PantryItem, error) {
+	rows, err := q.db.QueryContext(ctx, listPantryItemsPage,
+		arg.AfterAddedAt,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PantryItem
+	for rows.Next() {
+		var i PantryItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.IngredientID,
+			&i.Quantity,
+			&i.Unit,
+			&i.ExpiresAt,
+			&i.AddedAt,
+			&i.UpdatedAt,
+			&i.QuantityUnknown,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPantryItemsUpdatedSince = `-- name: ListPantryItemsUpdatedSince :many
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+FROM pantry_items
+WHERE updated_at >= $1
+ORDER BY updated_at
+`
+
+func (q *Queries) ListPantryItemsUpdatedSince(ctx context.Context, updatedAt time.Time) ([]PantryItem, error) {
+	rows, err := q.db.QueryContext(ctx, listPantryItemsUpdatedSince, updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PantryItem
+	for rows.Next() {
+		var i PantryItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.IngredientID,
+			&i.Quantity,
+			&i.Unit,
+			&i.ExpiresAt,
+			&i.AddedAt,
+			&i.UpdatedAt,
+			&i.QuantityUnknown,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPantryTombstonesByItems = `-- name: ListPantryTombstonesByItems :many
+SELECT item_id, ingredient_id, deleted_at
+FROM pantry_item_tombstones
+WHERE item_id = ANY($1::uuid[])
+`
+
+func (q *Queries) ListPantryTombstonesByItems(ctx context.Context, itemIds []uuid.UUID) ([]PantryItemTombstone, error) {
+	rows, err := q.db.QueryContext(ctx, listPantryTombstonesByItems, pq.Array(itemIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PantryItemTombstone
+	for rows.Next() {
+		var i PantryItemTombstone
+		if err := rows.Scan(
+			&i.ItemID,
+			&i.IngredientID,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPantryTombstonesSince = `-- name: ListPantryTombstonesSince :many
+SELECT item_id, ingredient_id, deleted_at
+FROM pantry_item_tombstones
+WHERE deleted_at >= $1
+ORDER BY deleted_at
+`
+
+func (q *Queries) ListPantryTombstonesSince(ctx context.Context, deletedAt time.Time) ([]PantryItemTombstone, error) {
+	rows, err := q.db.QueryContext(ctx, listPantryTombstonesSince, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PantryItemTombstone
+	for rows.Next() {
+		var i PantryItemTombstone
+		if err := rows.Scan(
+			&i.ItemID,
+			&i.IngredientID,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const pantryChangesCutoff = `-- name: PantryChangesCutoff :one
+-- Rows are stamped with now(), their transaction's start time, but only
+-- become visible at commit. A cutoff later than the start of a transaction
+-- still open would skip its writes, so it stops at the oldest one.
+SELECT LEAST(now(), min(xact_start))::timestamptz AS cutoff
+FROM pg_stat_activity
+WHERE datname = current_database()
+`
+
+func (q *Queries) PantryChangesCutoff(ctx context.Context) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, pantryChangesCutoff)
+	var cutoff time.Time
+	err := row.Scan(&cutoff)
+	return cutoff, err
+}
+
+const updatePantryItem = `-- name: UpdatePantryItem :one
+UPDATE pantry_items
+SET quantity         = COALESCE($1, quantity),
+    quantity_unknown = quantity_unknown AND $1::float8 IS NULL,
+    unit             = COALESCE($2, unit),
+    expires_at       = CASE WHEN $3::bool THEN $4 ELSE expires_at END,
+    updated_at       = now()
+WHERE id = $5
+RETURNING id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+`
+
+type UpdatePantryItemParams struct {
+	Quantity     sql.NullFloat64
+	Unit         sql.NullString
+	SetExpiresAt bool
+	ExpiresAt    sql.NullTime
+	ID           uuid.UUID
+}
+
+func (q *Queries) UpdatePantryItem(ctx context.Context, arg UpdatePantryItemParams) (PantryItem, error) {
+	row := q.db.QueryRowContext(ctx, updatePantryItem,
+		arg.Quantity,
+		arg.Unit,
+		arg.SetExpiresAt,
+		arg.ExpiresAt,
+		arg.ID,
+	)
+	var i PantryItem
+	err := row.Scan(
+		&i.ID,
+		&i.IngredientID,
+		&i.Quantity,
+		&i.Unit,
+		&i.ExpiresAt,
+		&i.AddedAt,
+		&i.UpdatedAt,
+		&i.QuantityUnknown,
+	)
+	return i, err
+}
+
 const upsertPantryItem = `-- name: UpsertPantryItem :one
-INSERT INTO pantry_items (ingredient_id, quantity, unit, expires_at)
-VALUES ($1, $2, $3, $4)
+INSERT INTO pantry_items (ingredient_id, quantity, unit, expires_at, quantity_unknown)
+VALUES ($1, $2, $3, $4, $5)
 ON CONFLICT (ingredient_id) DO UPDATE
-  SET quantity   = EXCLUDED.quantity,
-      unit       = EXCLUDED.unit,
-      expires_at = EXCLUDED.expires_at,
-      updated_at = now()
-RETURNING id, ingredient_id, quantity, unit, expires_at, added_at, updated_at
+  SET quantity         = EXCLUDED.quantity,
+      unit             = EXCLUDED.unit,
+      expires_at       = EXCLUDED.expires_at,
+      quantity_unknown = EXCLUDED.quantity_unknown,
+      updated_at       = now()
+RETURNING id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
 `
 
 type UpsertPantryItemParams struct {
-	IngredientID uuid.UUID
-	Quantity     float64
-	Unit         string
-	ExpiresAt    sql.NullTime
+	IngredientID    uuid.UUID
+	Quantity        float64
+	Unit            string
+	ExpiresAt       sql.NullTime
+	QuantityUnknown bool
 }
 
 func (q *Queries) UpsertPantryItem(ctx context.Context, arg UpsertPantryItemParams) (PantryItem, error) {
@@ -112,6 +506,56 @@ func (q *Queries) UpsertPantryItem(ctx context.Context, arg UpsertPantryItemPara
 		arg.Quantity,
 		arg.Unit,
 		arg.ExpiresAt,
+		arg.QuantityUnknown,
+	)
+	var i PantryItem
+	err := row.Scan(
+		&i.ID,
+		&i.IngredientID,
+		&i.Quantity,
+		&i.Unit,
+		&i.ExpiresAt,
+		&i.AddedAt,
+		&i.UpdatedAt,
+		&i.QuantityUnknown,
+	)
+	return i, err
+}
+
+const upsertPantryItemAdd = `-- name: UpsertPantryItemAdd :one
+INSERT INTO pantry_items (ingredient_id, quantity, unit, expires_at, quantity_unknown)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (ingredient_id) DO UPDATE
+  SET quantity         = CASE
+                           WHEN pantry_items.unit = EXCLUDED.unit THEN pantry_items.quantity + EXCLUDED.quantity
+                           ELSE EXCLUDED.quantity
+                         END,
+      quantity_unknown = CASE
+                           WHEN pantry_items.unit = EXCLUDED.unit
+                             THEN pantry_items.quantity_unknown OR EXCLUDED.quantity_unknown
+                           ELSE EXCLUDED.quantity_unknown
+                         END,
+      unit             = EXCLUDED.unit,
+      expires_at       = COALESCE(EXCLUDED.expires_at, pantry_items.expires_at),
+      updated_at       = now()
+RETURNING id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+`
+
+type UpsertPantryItemAddParams struct {
+	IngredientID    uuid.UUID
+	Quantity        float64
+	Unit            string
+	ExpiresAt       sql.NullTime
+	QuantityUnknown bool
+}
+
+func (q *Queries) UpsertPantryItemAdd(ctx context.Context, arg UpsertPantryItemAddParams) (PantryItem, error) {
+	row := q.db.QueryRowContext(ctx, upsertPantryItemAdd,
+		arg.IngredientID,
+		arg.Quantity,
+		arg.Unit,
+		arg.ExpiresAt,
+		arg.QuantityUnknown,
 	)
 	var i PantryItem
 	err := row.Scan(
@@ -122,6 +566,128 @@ func (q *Queries) UpsertPantryItem(ctx context.Context, arg UpsertPantryItemPara
 		&i.ExpiresAt,
 		&i.AddedAt,
 		&i.UpdatedAt,
+		&i.QuantityUnknown,
 	)
 	return i, err
 }
+
+const upsertPantryItemMax = `-- name: UpsertPantryItemMax :one
+INSERT INTO pantry_items (ingredient_id, quantity, unit, expires_at, quantity_unknown)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (ingredient_id) DO UPDATE
+  SET quantity         = CASE
+                           WHEN pantry_items.unit = EXCLUDED.unit THEN GREATEST(pantry_items.quantity, EXCLUDED.quantity)
+                           ELSE EXCLUDED.quantity
+                         END,
+      quantity_unknown = CASE
+                           WHEN pantry_items.unit = EXCLUDED.unit AND pantry_items.quantity >= EXCLUDED.quantity
+                             THEN pantry_items.quantity_unknown
+                           ELSE EXCLUDED.quantity_unknown
+                         END,
+      unit             = EXCLUDED.unit,
+      expires_at       = COALESCE(EXCLUDED.expires_at, pantry_items.expires_at),
+      updated_at       = now()
+RETURNING id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+`
+
+type UpsertPantryItemMaxParams struct {
+	IngredientID    uuid.UUID
+	Quantity        float64
+	Unit            string
+	ExpiresAt       sql.NullTime
+	QuantityUnknown bool
+}
+
+func (q *Queries) UpsertPantryItemMax(ctx context.Context, arg UpsertPantryItemMaxParams) (PantryItem, error) {
+	row := q.db.QueryRowContext(ctx, upsertPantryItemMax,
+		arg.IngredientID,
+		arg.Quantity,
+		arg.Unit,
+		arg.ExpiresAt,
+		arg.QuantityUnknown,
+	)
+	var i PantryItem
+	err := row.Scan(
+		&i.ID,
+		&i.IngredientID,
+		&i.Quantity,
+		&i.Unit,
+		&i.ExpiresAt,
+		&i.AddedAt,
+		&i.UpdatedAt,
+		&i.QuantityUnknown,
+	)
+	return i, err
+}
+
+const upsertPantryItems = `-- name: UpsertPantryItems :many
+-- Bulk form of UpsertPantryItem: one row per array position. has_expiry
+-- marks which expires_at entries are set. ingredient_ids must be distinct.
+INSERT INTO pantry_items (ingredient_id, quantity, unit, expires_at, quantity_unknown)
+SELECT u.ingredient_id, u.quantity, u.unit,
+       CASE WHEN u.has_expiry THEN u.expires_at END,
+       u.quantity_unknown
+FROM unnest(
+  $1::uuid[],
+  $2::float8[],
+  $3::text[],
+  $4::timestamptz[],
+  $5::bool[],
+  $6::bool[]
+) AS u(ingredient_id, quantity, unit, expires_at, has_expiry, quantity_unknown)
+ON CONFLICT (ingredient_id) DO UPDATE
+  SET quantity         = EXCLUDED.quantity,
+      unit             = EXCLUDED.unit,
+      expires_at       = EXCLUDED.expires_at,
+      quantity_unknown = EXCLUDED.quantity_unknown,
+      updated_at       = now()
+RETURNING id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+`
+
+type UpsertPantryItemsParams struct {
+	IngredientIds   []uuid.UUID
+	Quantities      []float64
+	Units           []string
+	ExpiresAt       []time.Time
+	HasExpiry       []bool
+	QuantityUnknown []bool
+}
+
+func (q *Queries) UpsertPantryItems(ctx context.Context, arg UpsertPantryItemsParams) ([]PantryItem, error) {
+	rows, err := q.db.QueryContext(ctx, upsertPantryItems,
+		pq.Array(arg.IngredientIds),
+		pq.Array(arg.Quantities),
+		pq.Array(arg.Units),
+		pq.Array(arg.ExpiresAt),
+		pq.Array(arg.HasExpiry),
+		pq.Array(arg.QuantityUnknown),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PantryItem
+	for rows.Next() {
+		var i PantryItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.IngredientID,
+			&i.Quantity,
+			&i.Unit,
+			&i.ExpiresAt,
+			&i.AddedAt,
+			&i.UpdatedAt,
+			&i.QuantityUnknown,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
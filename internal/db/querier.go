@@ -6,23 +6,147 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AddLLMUsage(ctx context.Context, arg AddLLMUsageParams) (LlmUsage, error)
+	AnalyzeTables(ctx context.Context) error
+	CancelIngestionJob(ctx context.Context, id uuid.UUID) (IngestionJob, error)
+	ClaimDueWebhookEvents(ctx context.Context, arg ClaimDueWebhookEventsParams) ([]ClaimDueWebhookEventsRow, error)
+	ClaimIngestionJob(ctx context.Context, retryDelaySeconds float64) (ClaimIngestionJobRow, error)
+	ConsumePantryItem(ctx context.Context, arg ConsumePantryItemParams) (ConsumePantryItemRow, error)
+	CountOutboxEvents(ctx context.Context) (int64, error)
+	CountPantryItems(ctx context.Context) (int64, error)
 	CreateIngestionJob(ctx context.Context, arg CreateIngestionJobParams) (IngestionJob, error)
+	CreateReviewRule(ctx context.Context, arg CreateReviewRuleParams) (ReviewRule, error)
 	CreateStagedItem(ctx context.Context, arg CreateStagedItemParams) (StagedItem, error)
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
+	DecrementPantryLot(ctx context.Context, arg DecrementPantryLotParams) (PantryLot, error)
 	DeleteAllPantryItems(ctx context.Context) error
+	DeleteCategoryDefaultUnit(ctx context.Context, category string) (int64, error)
+	DeleteCategoryPrice(ctx context.Context, category string) (int64, error)
+	DeleteEmptyPantryLots(ctx context.Context, pantryItemID uuid.UUID) error
+	DeleteExpiryLeadTime(ctx context.Context, category string) (int64, error)
+	DeleteIngestJobClaim(ctx context.Context, jobID uuid.UUID) error
+	DeleteIngredientDisplayOverride(ctx context.Context, ingredientID uuid.UUID) (int64, error)
+	DeleteIngredientPrice(ctx context.Context, ingredientID uuid.UUID) (int64, error)
+	DeleteOrphanedStagedItems(ctx context.Context) (int64, error)
+	DeleteOutboxEvent(ctx context.Context, id int64) error
 	DeletePantryItem(ctx context.Context, id uuid.UUID) error
+	DeletePantryLotsWithOtherUnit(ctx context.Context, arg DeletePantryLotsWithOtherUnitParams) ([]PantryLot, error)
+	DeletePantryTombstonesBefore(ctx context.Context, deletedAt time.Time) (int64, error)
+	DeleteProcessedMessagesBefore(ctx context.Context, processedAt time.Time) (int64, error)
+	DeleteReviewRule(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteStagedItem(ctx context.Context, arg DeleteStagedItemParams) (int64, error)
+	DeleteStagedItemsByJob(ctx context.Context, jobID uuid.UUID) error
+	DeleteStaleFlagsExcept(ctx context.Context, itemIds []uuid.UUID) (int64, error)
+	DeleteWatchlistEntry(ctx context.Context, ingredientID uuid.UUID) (int64, error)
+	DeleteWebhookDeliveriesBefore(ctx context.Context, attemptedAt time.Time) (int64, error)
+	DeleteWebhookEventsBefore(ctx context.Context, arg DeleteWebhookEventsBeforeParams) (int64, error)
+	DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) (int64, error)
+	EnqueueNotification(ctx context.Context, arg EnqueueNotificationParams) error
+	EnqueueOutboxEvent(ctx context.Context, arg EnqueueOutboxEventParams) error
+	EnqueueWebhookEvent(ctx context.Context, arg EnqueueWebhookEventParams) (int64, error)
+	ExpireStagedJobs(ctx context.Context, stagedAt time.Time) ([]ExpireStagedJobsRow, error)
+	FailIngestionJob(ctx context.Context, arg FailIngestionJobParams) (int64, error)
 	GetIngestionJob(ctx context.Context, id uuid.UUID) (IngestionJob, error)
+	GetIngestionJobTimings(ctx context.Context, jobID uuid.UUID) (IngestionJobTiming, error)
+	GetLLMUsage(ctx context.Context, month time.Time) (LlmUsage, error)
+	GetNotificationPreference(ctx context.Context, kind string) (NotificationPreference, error)
 	GetPantryItem(ctx context.Context, id uuid.UUID) (PantryItem, error)
+	GetPantryItemByIngredient(ctx context.Context, ingredientID uuid.UUID) (PantryItem, error)
 	GetStagedItem(ctx context.Context, id uuid.UUID) (StagedItem, error)
+	GetWebhookSubscription(ctx context.Context, id uuid.UUID) (WebhookSubscription, error)
+	IngestSourceStats(ctx context.Context, createdAt time.Time) ([]IngestSourceStatsRow, error)
+	InsertPantryActivity(ctx context.Context, arg InsertPantryActivityParams) error
+	InsertPantryLot(ctx context.Context, arg InsertPantryLotParams) (PantryLot, error)
+	InsertPantryLotEvent(ctx context.Context, arg InsertPantryLotEventParams) error
+	InsertWebhookDelivery(ctx context.Context, arg InsertWebhookDeliveryParams) error
+	ListAllPantryLots(ctx context.Context) ([]PantryLot, error)
+	ListCategoryDefaultUnits(ctx context.Context) ([]CategoryDefaultUnit, error)
+	ListCategoryPrices(ctx context.Context) ([]CategoryPrice, error)
+	ListExpiryLeadTimes(ctx context.Context) ([]ExpiryLeadTime, error)
+	ListIngestionJobs(ctx context.Context, arg ListIngestionJobsParams) ([]IngestionJob, error)
+	ListIngredientDisplayOverrides(ctx context.Context) ([]IngredientDisplayOverride, error)
+	ListIngredientPrices(ctx context.Context) ([]IngredientPrice, error)
+	ListLotHistoryTotals(ctx context.Context) ([]ListLotHistoryTotalsRow, error)
+	ListMigrationChecksums(ctx context.Context) ([]SchemaMigrationChecksum, error)
+	ListMissingWatchedIngredients(ctx context.Context) ([]ListMissingWatchedIngredientsRow, error)
+	ListNotificationPreferences(ctx context.Context) ([]NotificationPreference, error)
+	ListOutboxEvents(ctx context.Context, limit int32) ([]EventOutbox, error)
+	ListPantryActivity(ctx context.Context, arg ListPantryActivityParams) ([]PantryActivity, error)
+	ListPantryIngredientIDs(ctx context.Context) ([]uuid.UUID, error)
 	ListPantryItems(ctx context.Context) ([]PantryItem, error)
+	ListPantryItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]PantryItem, error)
+	ListPantryItemsByIngredients(ctx context.Context, ingredientIds []uuid.UUID) ([]PantryItem, error)
+	ListPantryItemsExpiringBefore(ctx context.Context, expiresAt sql.NullTime) ([]PantryItem, error)
+	ListPantryItemsIdleSince(ctx context.Context, updatedAt time.Time) ([]PantryItem, error)
+	ListPantryItemsPage(ctx context.Context, arg ListPantryItemsPageParams) ([]PantryItem, error)
+	ListPantryItemsUpdatedSince(ctx context.Context, updatedAt time.Time) ([]PantryItem, error)
+	ListPantryLotEventsByItem(ctx context.Context, pantryItemID uuid.UUID) ([]PantryLotEvent, error)
+	ListPantryLotsByItem(ctx context.Context, pantryItemID uuid.UUID) ([]PantryLot, error)
+	ListPantryTombstonesByItems(ctx context.Context, itemIds []uuid.UUID) ([]PantryItemTombstone, error)
+	ListPantryTombstonesSince(ctx context.Context, deletedAt time.Time) ([]PantryItemTombstone, error)
+	ListRecentNotifications(ctx context.Context, limit int32) ([]Notification, error)
+	ListReferencedIngredients(ctx context.Context) ([]ListReferencedIngredientsRow, error)
+	ListReviewRules(ctx context.Context) ([]ReviewRule, error)
+	ListShadowExtractions(ctx context.Context, limit int32) ([]ShadowExtraction, error)
 	ListStagedItemsByJob(ctx context.Context, jobID uuid.UUID) ([]StagedItem, error)
-	UpdateIngestionJobStatus(ctx context.Context, arg UpdateIngestionJobStatusParams) (IngestionJob, error)
+	ListStaleItems(ctx context.Context) ([]ListStaleItemsRow, error)
+	ListTableStats(ctx context.Context) ([]ListTableStatsRow, error)
+	ListUnsentNotifications(ctx context.Context, arg ListUnsentNotificationsParams) ([]Notification, error)
+	ListWatchlist(ctx context.Context) ([]Watchlist, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error)
+	MarkIngestionJobBudgetExceeded(ctx context.Context, id uuid.UUID) error
+	MarkIngestionJobTruncated(ctx context.Context, arg MarkIngestionJobTruncatedParams) error
+	MarkMessageProcessed(ctx context.Context, arg MarkMessageProcessedParams) (int64, error)
+	MarkNotificationSent(ctx context.Context, id int64) error
+	MarkWebhookEventSent(ctx context.Context, id int64) error
+	MergeIntoPantryLot(ctx context.Context, arg MergeIntoPantryLotParams) (PantryLot, error)
+	MigrationChecksumsRecorded(ctx context.Context) (bool, error)
+	PantryChangesCutoff(ctx context.Context) (time.Time, error)
+	RecordMigrationChecksum(ctx context.Context, arg RecordMigrationChecksumParams) error
+	RecordNotificationFailure(ctx context.Context, arg RecordNotificationFailureParams) error
+	RecordOutboxFailure(ctx context.Context, arg RecordOutboxFailureParams) error
+	RecordWebhookEventFailure(ctx context.Context, arg RecordWebhookEventFailureParams) error
+	ReindexIngestionJobs(ctx context.Context) error
+	ReindexPantryItems(ctx context.Context) error
+	ReindexStagedItems(ctx context.Context) error
+	RemapActivityIngredient(ctx context.Context, arg RemapActivityIngredientParams) (int64, error)
+	RemapDisplayOverrideIngredient(ctx context.Context, arg RemapDisplayOverrideIngredientParams) (int64, error)
+	RemapPantryItemIngredient(ctx context.Context, arg RemapPantryItemIngredientParams) (int64, error)
+	RemapStagedItemIngredient(ctx context.Context, arg RemapStagedItemIngredientParams) (int64, error)
+	RemapTombstoneIngredient(ctx context.Context, arg RemapTombstoneIngredientParams) (int64, error)
+	RemapWatchlistIngredient(ctx context.Context, arg RemapWatchlistIngredientParams) (int64, error)
+	RequeueStaleIngestionJobs(ctx context.Context, arg RequeueStaleIngestionJobsParams) (int64, error)
+	ResetLLMUsage(ctx context.Context, month time.Time) error
+	StagedItemsFingerprint(ctx context.Context, jobID uuid.UUID) (StagedItemsFingerprintRow, error)
+	SyncPantryItemExpiryFromLots(ctx context.Context, id uuid.UUID) (PantryItem, error)
+	TransitionIngestionJobStatus(ctx context.Context, arg TransitionIngestionJobStatusParams) (IngestionJob, error)
+	UnmarkMessageProcessed(ctx context.Context, arg UnmarkMessageProcessedParams) error
+	UpdatePantryItem(ctx context.Context, arg UpdatePantryItemParams) (PantryItem, error)
 	UpdateStagedItem(ctx context.Context, arg UpdateStagedItemParams) (StagedItem, error)
+	UpdateStagedItemExtraction(ctx context.Context, arg UpdateStagedItemExtractionParams) (StagedItem, error)
+	UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (WebhookSubscription, error)
+	UpsertCategoryDefaultUnit(ctx context.Context, arg UpsertCategoryDefaultUnitParams) (CategoryDefaultUnit, error)
+	UpsertCategoryPrice(ctx context.Context, arg UpsertCategoryPriceParams) (CategoryPrice, error)
+	UpsertExpiryLeadTime(ctx context.Context, arg UpsertExpiryLeadTimeParams) (ExpiryLeadTime, error)
+	UpsertIngestionJobTimings(ctx context.Context, arg UpsertIngestionJobTimingsParams) error
+	UpsertIngredientDisplayOverride(ctx context.Context, arg UpsertIngredientDisplayOverrideParams) (IngredientDisplayOverride, error)
+	UpsertIngredientPrice(ctx context.Context, arg UpsertIngredientPriceParams) (IngredientPrice, error)
+	UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) (NotificationPreference, error)
 	UpsertPantryItem(ctx context.Context, arg UpsertPantryItemParams) (PantryItem, error)
+	UpsertPantryItemAdd(ctx context.Context, arg UpsertPantryItemAddParams) (PantryItem, error)
+	UpsertPantryItemMax(ctx context.Context, arg UpsertPantryItemMaxParams) (PantryItem, error)
+	UpsertPantryItems(ctx context.Context, arg UpsertPantryItemsParams) ([]PantryItem, error)
+	UpsertShadowExtraction(ctx context.Context, arg UpsertShadowExtractionParams) error
+	UpsertStaleFlag(ctx context.Context, arg UpsertStaleFlagParams) error
+	UpsertWatchlistEntry(ctx context.Context, arg UpsertWatchlistEntryParams) (Watchlist, error)
 }
 
 var _ Querier = (*Queries)(nil)
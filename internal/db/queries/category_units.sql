@@ -0,0 +1,16 @@
+-- name: ListCategoryDefaultUnits :many
+SELECT category, unit, updated_at
+FROM category_default_units
+ORDER BY category;
+
+-- name: UpsertCategoryDefaultUnit :one
+INSERT INTO category_default_units (category, unit)
+VALUES ($1, $2)
+ON CONFLICT (category) DO UPDATE
+  SET unit       = EXCLUDED.unit,
+      updated_at = now()
+RETURNING category, unit, updated_at;
+
+-- name: DeleteCategoryDefaultUnit :execrows
+DELETE FROM category_default_units
+WHERE category = $1;
@@ -0,0 +1,17 @@
+-- name: ListIngredientDisplayOverrides :many
+SELECT ingredient_id, display_name, emoji, updated_at
+FROM ingredient_display_overrides
+ORDER BY ingredient_id;
+
+-- name: UpsertIngredientDisplayOverride :one
+INSERT INTO ingredient_display_overrides (ingredient_id, display_name, emoji)
+VALUES ($1, $2, $3)
+ON CONFLICT (ingredient_id) DO UPDATE
+  SET display_name = EXCLUDED.display_name,
+      emoji        = EXCLUDED.emoji,
+      updated_at   = now()
+RETURNING ingredient_id, display_name, emoji, updated_at;
+
+-- name: DeleteIngredientDisplayOverride :execrows
+DELETE FROM ingredient_display_overrides
+WHERE ingredient_id = $1;
@@ -0,0 +1,21 @@
+-- name: EnqueueOutboxEvent :exec
+INSERT INTO event_outbox (routing_key, body)
+VALUES ($1, $2);
+
+-- name: ListOutboxEvents :many
+SELECT id, routing_key, body, attempts, last_error, created_at
+FROM event_outbox
+ORDER BY id
+LIMIT $1;
+
+-- name: DeleteOutboxEvent :exec
+DELETE FROM event_outbox WHERE id = $1;
+
+-- name: RecordOutboxFailure :exec
+UPDATE event_outbox
+SET attempts   = attempts + 1,
+    last_error = $2
+WHERE id = $1;
+
+-- name: CountOutboxEvents :one
+SELECT count(*) FROM event_outbox;
@@ -0,0 +1,22 @@
+-- name: ListExpiryLeadTimes :many
+SELECT category, lead_days, updated_at
+FROM expiry_lead_times
+ORDER BY category;
+
+-- name: UpsertExpiryLeadTime :one
+INSERT INTO expiry_lead_times (category, lead_days)
+VALUES ($1, $2)
+ON CONFLICT (category) DO UPDATE
+  SET lead_days  = EXCLUDED.lead_days,
+      updated_at = now()
+RETURNING category, lead_days, updated_at;
+
+-- name: DeleteExpiryLeadTime :execrows
+DELETE FROM expiry_lead_times
+WHERE category = $1;
+
+-- name: ListPantryItemsExpiringBefore :many
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+FROM pantry_items
+WHERE expires_at IS NOT NULL AND expires_at <= $1
+ORDER BY expires_at, id;
@@ -0,0 +1,47 @@
+-- name: ClaimIngestionJob :one
+-- Takes the oldest pending job, interactive first, that is not waiting out a
+-- retry delay, and marks it processing. Concurrent claimers skip each
+-- other's row.
+WITH next AS (
+  SELECT j.id
+  FROM ingestion_jobs j
+  LEFT JOIN ingest_job_claims c ON c.job_id = j.id
+  WHERE j.status = 'pending'
+    AND (c.job_id IS NULL
+         OR c.claimed_at < now() - make_interval(secs => sqlc.arg('retry_delay_seconds')::float8 * c.attempts))
+  ORDER BY j.priority = 'interactive' DESC, j.created_at
+  LIMIT 1
+  FOR UPDATE OF j SKIP LOCKED
+), claimed AS (
+  UPDATE ingestion_jobs j
+  SET status = 'processing'
+  FROM next
+  WHERE j.id = next.id
+  RETURNING j.id, j.raw_input, j.priority
+), claim AS (
+  INSERT INTO ingest_job_claims (job_id)
+  SELECT id FROM claimed
+  ON CONFLICT (job_id) DO UPDATE
+    SET attempts   = ingest_job_claims.attempts + 1,
+        claimed_at = now()
+  RETURNING job_id, attempts
+)
+SELECT claimed.id, claimed.raw_input, claimed.priority, claim.attempts
+FROM claimed
+JOIN claim ON claim.job_id = claimed.id;
+
+-- name: RequeueStaleIngestionJobs :execrows
+-- Returns jobs whose claim outlived the lease, e.g. because the worker died,
+-- to pending, or fails them once they have used every attempt.
+UPDATE ingestion_jobs j
+SET status = CASE WHEN c.attempts >= sqlc.arg('max_attempts')::int THEN 'failed' ELSE 'pending' END,
+    failure_reason = CASE WHEN c.attempts >= sqlc.arg('max_attempts')::int
+                          THEN 'worker stopped responding on every attempt' ELSE '' END
+FROM ingest_job_claims c
+WHERE c.job_id = j.id
+  AND j.status = 'processing'
+  AND c.claimed_at < now() - make_interval(secs => sqlc.arg('lease_seconds')::float8);
+
+-- name: DeleteIngestJobClaim :exec
+-- Resets a job's attempts when it is queued afresh.
+DELETE FROM ingest_job_claims WHERE job_id = $1;
@@ -1,39 +1,151 @@
 -- name: CreateIngestionJob :one
-INSERT INTO ingestion_jobs (type, raw_input)
-VALUES ($1, $2)
-RETURNING id, type, raw_input, status, created_at;
+INSERT INTO ingestion_jobs (type, raw_input, source, priority)
+VALUES ($1, $2, $3, $4)
+RETURNING id, type, raw_input, status, budget_exceeded, truncated_items, source, priority, created_at, failure_reason;
 
 -- name: GetIngestionJob :one
-SELECT id, type, raw_input, status, created_at
+SELECT id, type, raw_input, status, budget_exceeded, truncated_items, source, priority, created_at, failure_reason
 FROM ingestion_jobs
 WHERE id = $1;
 
--- name: UpdateIngestionJobStatus :one
+-- name: ListIngestionJobs :many
+SELECT id, type, raw_input, status, budget_exceeded, truncated_items, source, priority, created_at, failure_reason
+FROM ingestion_jobs
+WHERE (sqlc.narg('source')::text IS NULL OR source = sqlc.narg('source'))
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit');
+
+-- name: IngestSourceStats :many
+SELECT j.source,
+       COUNT(DISTINCT j.id)::bigint                                        AS jobs,
+       COUNT(DISTINCT j.id) FILTER (WHERE j.status = 'confirmed')::bigint  AS confirmed_jobs,
+       COUNT(DISTINCT j.id) FILTER (WHERE j.status = 'failed')::bigint     AS failed_jobs,
+       COUNT(DISTINCT j.id) FILTER (WHERE j.status = 'rejected')::bigint   AS rejected_jobs,
+       COUNT(s.id)::bigint                                                 AS staged_items,
+       COUNT(s.id) FILTER (WHERE s.needs_review)::bigint                   AS needs_review_items
+FROM ingestion_jobs j
+LEFT JOIN staged_items s ON s.job_id = j.id
+WHERE j.created_at >= $1
+GROUP BY j.source
+ORDER BY jobs DESC, j.source;
+
+-- name: FailIngestionJob :execrows
+-- Cancelled, rejected and expired jobs keep their status: a worker that
+-- missed the cancellation must not fail it afterwards.
 UPDATE ingestion_jobs
-SET status = $2
-WHERE id = $1
-RETURNING id, type, raw_input, status, created_at;
+SET status = 'failed', failure_reason = $2
+WHERE id = $1 AND status NOT IN ('cancelled', 'rejected', 'expired');
+
+-- name: TransitionIngestionJobStatus :one
+UPDATE ingestion_jobs
+SET status = sqlc.arg('to_status'), failure_reason = '',
+    staged_at = CASE WHEN sqlc.arg('to_status') = 'staged' THEN now() ELSE staged_at END
+WHERE id = sqlc.arg('id') AND status = sqlc.arg('from_status')
+RETURNING id, type, raw_input, status, budget_exceeded, truncated_items, source, priority, created_at, failure_reason;
+
+-- name: CancelIngestionJob :one
+UPDATE ingestion_jobs
+SET status = 'cancelled'
+WHERE id = $1 AND status IN ('pending', 'processing')
+RETURNING id, type, raw_input, status, budget_exceeded, truncated_items, source, priority, created_at, failure_reason;
+
+-- name: ExpireStagedJobs :many
+-- Archives jobs left staged since before $1: the job row is kept, as
+-- expired, and its staged items are deleted. The TTL runs from when the
+-- job was staged, not created, so a queued or retried job gets all of it.
+WITH expired AS (
+  UPDATE ingestion_jobs
+  SET status = 'expired'
+  WHERE status = 'staged' AND staged_at < $1
+  RETURNING id
+), deleted AS (
+  DELETE FROM staged_items
+  WHERE job_id IN (SELECT id FROM expired)
+  RETURNING job_id
+)
+SELECT e.id, COUNT(d.job_id)::bigint AS staged_items
+FROM expired e
+LEFT JOIN deleted d ON d.job_id = e.id
+GROUP BY e.id;
+
+-- name: MarkIngestionJobBudgetExceeded :exec
+UPDATE ingestion_jobs
+SET budget_exceeded = true
+WHERE id = $1;
+
+-- name: MarkIngestionJobTruncated :exec
+UPDATE ingestion_jobs
+SET truncated_items = $2
+WHERE id = $1;
 
 -- name: CreateStagedItem :one
-INSERT INTO staged_items (job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review;
+INSERT INTO staged_items (job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review, quantity_unknown)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review, quantity_unknown;
+
+-- name: DeleteStagedItemsByJob :exec
+DELETE FROM staged_items
+WHERE job_id = $1;
 
 -- name: ListStagedItemsByJob :many
-SELECT id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review
+SELECT id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review, quantity_unknown
 FROM staged_items
 WHERE job_id = $1
 ORDER BY raw_text;
 
 -- name: GetStagedItem :one
-SELECT id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review
+SELECT id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review, quantity_unknown
 FROM staged_items
 WHERE id = $1;
 
 -- name: UpdateStagedItem :one
 UPDATE staged_items
-SET ingredient_id = $2,
-    quantity      = $3,
-    unit          = $4
+SET ingredient_id    = $2,
+    quantity         = $3,
+    unit             = $4,
+    needs_review     = $5,
+    quantity_unknown = $6
+WHERE id = $1
+RETURNING id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review, quantity_unknown;
+
+-- name: DeleteStagedItem :execrows
+DELETE FROM staged_items
+WHERE id = $1 AND job_id = $2;
+
+-- name: UpdateStagedItemExtraction :one
+UPDATE staged_items
+SET ingredient_id    = $2,
+    quantity         = $3,
+    unit             = $4,
+    confidence       = $5,
+    needs_review     = $6,
+    quantity_unknown = $7
 WHERE id = $1
-RETURNING id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review;
+RETURNING id, job_id, ingredient_id, raw_text, quantity, unit, confidence, needs_review, quantity_unknown;
+
+-- name: StagedItemsFingerprint :one
+SELECT count(*) AS item_count,
+       coalesce(md5(string_agg(
+         id::text || ':' || coalesce(ingredient_id::text, '') || ':' || quantity::text || ':' ||
+         unit || ':' || confidence::text || ':' || needs_review::text || ':' || quantity_unknown::text,
+         ',' ORDER BY id)), '')::text AS checksum
+FROM staged_items
+WHERE job_id = $1;
+
+-- name: UpsertIngestionJobTimings :exec
+INSERT INTO ingestion_job_timings (job_id, extraction_ms, resolution_ms, resolution_max_ms, resolved_items, total_ms)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (job_id) DO UPDATE
+  SET attempts          = ingestion_job_timings.attempts + 1,
+      extraction_ms     = EXCLUDED.extraction_ms,
+      resolution_ms     = EXCLUDED.resolution_ms,
+      resolution_max_ms = EXCLUDED.resolution_max_ms,
+      resolved_items    = EXCLUDED.resolved_items,
+      total_ms          = EXCLUDED.total_ms,
+      recorded_at       = now();
+
+-- name: GetIngestionJobTimings :one
+SELECT job_id, attempts, extraction_ms, resolution_ms, resolution_max_ms, resolved_items, total_ms, recorded_at
+FROM ingestion_job_timings
+WHERE job_id = $1;
@@ -0,0 +1,46 @@
+-- name: ListReferencedIngredients :many
+SELECT ids.ingredient_id,
+       (SELECT s.raw_text
+        FROM staged_items s
+        WHERE s.ingredient_id = ids.ingredient_id
+        ORDER BY s.confidence DESC
+        LIMIT 1) AS alias
+FROM (
+  SELECT ingredient_id FROM pantry_items
+  UNION SELECT ingredient_id FROM watchlist
+  UNION SELECT ingredient_id FROM staged_items WHERE ingredient_id IS NOT NULL
+  UNION SELECT ingredient_id FROM pantry_item_tombstones
+  UNION SELECT ingredient_id FROM pantry_activity WHERE ingredient_id IS NOT NULL
+  UNION SELECT ingredient_id FROM ingredient_display_overrides
+) ids
+ORDER BY ids.ingredient_id;
+
+-- name: RemapPantryItemIngredient :execrows
+UPDATE pantry_items
+SET ingredient_id = sqlc.arg('new_id'), updated_at = now()
+WHERE ingredient_id = sqlc.arg('old_id');
+
+-- name: RemapWatchlistIngredient :execrows
+UPDATE watchlist
+SET ingredient_id = sqlc.arg('new_id'), updated_at = now()
+WHERE ingredient_id = sqlc.arg('old_id');
+
+-- name: RemapStagedItemIngredient :execrows
+UPDATE staged_items
+SET ingredient_id = sqlc.arg('new_id')
+WHERE ingredient_id = sqlc.arg('old_id');
+
+-- name: RemapTombstoneIngredient :execrows
+UPDATE pantry_item_tombstones
+SET ingredient_id = sqlc.arg('new_id')
+WHERE ingredient_id = sqlc.arg('old_id');
+
+-- name: RemapActivityIngredient :execrows
+UPDATE pantry_activity
+SET ingredient_id = sqlc.arg('new_id')
+WHERE ingredient_id = sqlc.arg('old_id');
+
+-- name: RemapDisplayOverrideIngredient :execrows
+UPDATE ingredient_display_overrides
+SET ingredient_id = sqlc.arg('new_id'), updated_at = now()
+WHERE ingredient_id = sqlc.arg('old_id');
@@ -0,0 +1,18 @@
+-- name: GetLLMUsage :one
+SELECT month, tokens_used, updated_at
+FROM llm_usage
+WHERE month = $1;
+
+-- name: AddLLMUsage :one
+INSERT INTO llm_usage (month, tokens_used)
+VALUES ($1, $2)
+ON CONFLICT (month) DO UPDATE
+  SET tokens_used = llm_usage.tokens_used + EXCLUDED.tokens_used,
+      updated_at  = now()
+RETURNING month, tokens_used, updated_at;
+
+-- name: ResetLLMUsage :exec
+UPDATE llm_usage
+SET tokens_used = 0,
+    updated_at  = now()
+WHERE month = $1;
@@ -0,0 +1,34 @@
+-- name: ListTableStats :many
+SELECT relname::text AS table_name,
+       n_live_tup,
+       n_dead_tup,
+       last_vacuum,
+       last_autovacuum,
+       last_analyze,
+       last_autoanalyze
+FROM pg_stat_user_tables
+WHERE relname IN ('pantry_items', 'ingestion_jobs', 'staged_items')
+ORDER BY relname;
+
+-- name: AnalyzeTables :exec
+ANALYZE pantry_items, ingestion_jobs, staged_items;
+
+-- name: ReindexPantryItems :exec
+REINDEX TABLE pantry_items;
+
+-- name: ReindexIngestionJobs :exec
+REINDEX TABLE ingestion_jobs;
+
+-- name: ReindexStagedItems :exec
+REINDEX TABLE staged_items;
+
+-- name: DeleteOrphanedStagedItems :execrows
+DELETE FROM staged_items s
+WHERE NOT EXISTS (
+  SELECT 1 FROM ingestion_jobs j WHERE j.id = s.job_id
+);
+
+-- name: ListPantryIngredientIDs :many
+SELECT DISTINCT ingredient_id
+FROM pantry_items
+ORDER BY ingredient_id;
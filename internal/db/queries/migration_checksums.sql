@@ -0,0 +1,12 @@
+-- name: MigrationChecksumsRecorded :one
+SELECT to_regclass('schema_migration_checksums') IS NOT NULL AS recorded;
+
+-- name: ListMigrationChecksums :many
+SELECT version, name, checksum, recorded_at
+FROM schema_migration_checksums
+ORDER BY version;
+
+-- name: RecordMigrationChecksum :exec
+INSERT INTO schema_migration_checksums (version, name, checksum)
+VALUES ($1, $2, $3)
+ON CONFLICT (version) DO NOTHING;
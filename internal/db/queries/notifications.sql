@@ -0,0 +1,46 @@
+-- name: ListNotificationPreferences :many
+SELECT kind, channel, target, secret, updated_at
+FROM notification_preferences
+ORDER BY kind;
+
+-- name: GetNotificationPreference :one
+SELECT kind, channel, target, secret, updated_at
+FROM notification_preferences
+WHERE kind = $1;
+
+-- name: UpsertNotificationPreference :one
+INSERT INTO notification_preferences (kind, channel, target, secret)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (kind) DO UPDATE
+  SET channel    = EXCLUDED.channel,
+      target     = EXCLUDED.target,
+      secret     = EXCLUDED.secret,
+      updated_at = now()
+RETURNING kind, channel, target, secret, updated_at;
+
+-- name: EnqueueNotification :exec
+INSERT INTO notifications (kind, channel, target, subject, body)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListUnsentNotifications :many
+SELECT id, kind, channel, target, subject, body, attempts, last_error, sent_at, created_at
+FROM notifications
+WHERE sent_at IS NULL AND attempts < $1
+ORDER BY id
+LIMIT $2;
+
+-- name: MarkNotificationSent :exec
+UPDATE notifications
+SET sent_at = now(), attempts = attempts + 1, last_error = NULL
+WHERE id = $1;
+
+-- name: RecordNotificationFailure :exec
+UPDATE notifications
+SET attempts = attempts + 1, last_error = $2
+WHERE id = $1;
+
+-- name: ListRecentNotifications :many
+SELECT id, kind, channel, target, subject, body, attempts, last_error, sent_at, created_at
+FROM notifications
+ORDER BY id DESC
+LIMIT $1;
@@ -0,0 +1,10 @@
+-- name: InsertPantryActivity :exec
+INSERT INTO pantry_activity (kind, ingredient_id, quantity, unit, source, item_count)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListPantryActivity :many
+SELECT id, kind, ingredient_id, quantity, unit, source, item_count, occurred_at
+FROM pantry_activity
+WHERE (sqlc.narg('before')::bigint IS NULL OR id < sqlc.narg('before'))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
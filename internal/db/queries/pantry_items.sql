@@ -1,25 +1,162 @@
 -- name: ListPantryItems :many
-SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
 FROM pantry_items
 ORDER BY added_at;
 
+-- name: ListPantryItemsPage :many
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+FROM pantry_items
+WHERE (sqlc.narg('after_added_at')::timestamptz IS NULL
+       OR (added_at, id) > (sqlc.narg('after_added_at'), sqlc.narg('after_id')::uuid))
+ORDER BY added_at, id
+LIMIT sqlc.arg('limit');
+
+-- name: CountPantryItems :one
+SELECT count(*) FROM pantry_items;
+
 -- name: GetPantryItem :one
-SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
 FROM pantry_items
 WHERE id = $1;
 
+-- name: GetPantryItemByIngredient :one
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+FROM pantry_items
+WHERE ingredient_id = $1;
+
+-- name: ListPantryItemsByIngredients :many
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+FROM pantry_items
+WHERE ingredient_id = ANY(sqlc.arg(ingredient_ids)::uuid[])
+ORDER BY added_at;
+
 -- name: UpsertPantryItem :one
-INSERT INTO pantry_items (ingredient_id, quantity, unit, expires_at)
-VALUES ($1, $2, $3, $4)
+INSERT INTO pantry_items (ingredient_id, quantity, unit, expires_at, quantity_unknown)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (ingredient_id) DO UPDATE
+  SET quantity         = EXCLUDED.quantity,
+      unit             = EXCLUDED.unit,
+      expires_at       = EXCLUDED.expires_at,
+      quantity_unknown = EXCLUDED.quantity_unknown,
+      updated_at       = now()
+RETURNING id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown;
+
+-- name: UpsertPantryItems :many
+-- Bulk form of UpsertPantryItem: one row per array position. has_expiry
+-- marks which expires_at entries are set. ingredient_ids must be distinct.
+INSERT INTO pantry_items (ingredient_id, quantity, unit, expires_at, quantity_unknown)
+SELECT u.ingredient_id, u.quantity, u.unit,
+       CASE WHEN u.has_expiry THEN u.expires_at END,
+       u.quantity_unknown
+FROM unnest(
+  sqlc.arg(ingredient_ids)::uuid[],
+  sqlc.arg(quantities)::float8[],
+  sqlc.arg(units)::text[],
+  sqlc.arg(expires_at)::timestamptz[],
+  sqlc.arg(has_expiry)::bool[],
+  sqlc.arg(quantity_unknown)::bool[]
+) AS u(ingredient_id, quantity, unit, expires_at, has_expiry, quantity_unknown)
 ON CONFLICT (ingredient_id) DO UPDATE
-  SET quantity   = EXCLUDED.quantity,
-      unit       = EXCLUDED.unit,
-      expires_at = EXCLUDED.expires_at,
-      updated_at = now()
-RETURNING id, ingredient_id, quantity, unit, expires_at, added_at, updated_at;
+  SET quantity         = EXCLUDED.quantity,
+      unit             = EXCLUDED.unit,
+      expires_at       = EXCLUDED.expires_at,
+      quantity_unknown = EXCLUDED.quantity_unknown,
+      updated_at       = now()
+RETURNING id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown;
+
+-- name: UpdatePantryItem :one
+UPDATE pantry_items
+SET quantity         = COALESCE(sqlc.narg('quantity'), quantity),
+    quantity_unknown = quantity_unknown AND sqlc.narg('quantity')::float8 IS NULL,
+    unit             = COALESCE(sqlc.narg('unit'), unit),
+    expires_at       = CASE WHEN sqlc.arg('set_expires_at')::bool THEN sqlc.narg('expires_at') ELSE expires_at END,
+    updated_at       = now()
+WHERE id = sqlc.arg('id')
+RETURNING id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown;
+
+-- name: ConsumePantryItem :one
+UPDATE pantry_items p
+SET quantity   = GREATEST(p.quantity - sqlc.arg('quantity')::float8, 0),
+    updated_at = now()
+FROM (SELECT id, quantity FROM pantry_items WHERE id = sqlc.arg('id') FOR UPDATE) prev
+WHERE p.id = prev.id
+  AND (NOT p.quantity_unknown OR p.quantity > 0)
+  AND (sqlc.narg('unit')::text IS NULL OR p.unit = sqlc.narg('unit'))
+RETURNING p.id, p.ingredient_id, p.quantity, p.unit, p.expires_at, p.added_at, p.updated_at, p.quantity_unknown,
+          prev.quantity AS previous_quantity;
 
 -- name: DeletePantryItem :exec
 DELETE FROM pantry_items WHERE id = $1;
 
 -- name: DeleteAllPantryItems :exec
 DELETE FROM pantry_items;
+
+-- name: UpsertPantryItemAdd :one
+INSERT INTO pantry_items (ingredient_id, quantity, unit, expires_at, quantity_unknown)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (ingredient_id) DO UPDATE
+  SET quantity         = CASE
+                           WHEN pantry_items.unit = EXCLUDED.unit THEN pantry_items.quantity + EXCLUDED.quantity
+                           ELSE EXCLUDED.quantity
+                         END,
+      quantity_unknown = CASE
+                           WHEN pantry_items.unit = EXCLUDED.unit
+                             THEN pantry_items.quantity_unknown OR EXCLUDED.quantity_unknown
+                           ELSE EXCLUDED.quantity_unknown
+                         END,
+      unit             = EXCLUDED.unit,
+      expires_at       = COALESCE(EXCLUDED.expires_at, pantry_items.expires_at),
+      updated_at       = now()
+RETURNING id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown;
+
+-- name: UpsertPantryItemMax :one
+INSERT INTO pantry_items (ingredient_id, quantity, unit, expires_at, quantity_unknown)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (ingredient_id) DO UPDATE
+  SET quantity         = CASE
+                           WHEN pantry_items.unit = EXCLUDED.unit THEN GREATEST(pantry_items.quantity, EXCLUDED.quantity)
+                           ELSE EXCLUDED.quantity
+                         END,
+      quantity_unknown = CASE
+                           WHEN pantry_items.unit = EXCLUDED.unit AND pantry_items.quantity >= EXCLUDED.quantity
+                             THEN pantry_items.quantity_unknown
+                           ELSE EXCLUDED.quantity_unknown
+                         END,
+      unit             = EXCLUDED.unit,
+      expires_at       = COALESCE(EXCLUDED.expires_at, pantry_items.expires_at),
+      updated_at       = now()
+RETURNING id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown;
+
+-- name: ListPantryItemsUpdatedSince :many
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+FROM pantry_items
+WHERE updated_at >= $1
+ORDER BY updated_at;
+
+-- name: ListPantryTombstonesSince :many
+SELECT item_id, ingredient_id, deleted_at
+FROM pantry_item_tombstones
+WHERE deleted_at >= $1
+ORDER BY deleted_at;
+
+-- name: DeletePantryTombstonesBefore :execrows
+DELETE FROM pantry_item_tombstones
+WHERE deleted_at < $1;
+
+-- name: PantryChangesCutoff :one
+-- Rows are stamped with now(), their transaction's start time, but only
+-- become visible at commit. A cutoff later than the start of a transaction
+-- still open would skip its writes, so it stops at the oldest one.
+SELECT LEAST(now(), min(xact_start))::timestamptz AS cutoff
+FROM pg_stat_activity
+WHERE datname = current_database();
+
+-- name: ListPantryItemsByIDs :many
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+FROM pantry_items
+WHERE id = ANY(sqlc.arg(ids)::uuid[]);
+
+-- name: ListPantryTombstonesByItems :many
+SELECT item_id, ingredient_id, deleted_at
+FROM pantry_item_tombstones
+WHERE item_id = ANY(sqlc.arg(item_ids)::uuid[]);
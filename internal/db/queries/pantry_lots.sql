@@ -0,0 +1,75 @@
+-- name: ListPantryLotsByItem :many
+SELECT id, pantry_item_id, quantity, unit, expires_at, source_job_id, added_at
+FROM pantry_lots
+WHERE pantry_item_id = $1
+ORDER BY expires_at NULLS LAST, added_at;
+
+-- name: InsertPantryLot :one
+INSERT INTO pantry_lots (pantry_item_id, quantity, unit, expires_at, source_job_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, pantry_item_id, quantity, unit, expires_at, source_job_id, added_at;
+
+-- name: MergeIntoPantryLot :one
+UPDATE pantry_lots
+SET quantity = quantity + $2
+WHERE id = (
+  SELECT l.id FROM pantry_lots l
+  WHERE l.pantry_item_id = $1
+    AND l.unit = $3
+    AND date_trunc('day', l.expires_at) IS NOT DISTINCT FROM date_trunc('day', sqlc.narg('expires_at')::timestamptz)
+  ORDER BY l.added_at
+  LIMIT 1
+)
+RETURNING id, pantry_item_id, quantity, unit, expires_at, source_job_id, added_at;
+
+-- name: DeletePantryLotsWithOtherUnit :many
+DELETE FROM pantry_lots
+WHERE pantry_item_id = $1 AND unit <> $2
+RETURNING id, pantry_item_id, quantity, unit, expires_at, source_job_id, added_at;
+
+-- name: SyncPantryItemExpiryFromLots :one
+UPDATE pantry_items
+SET expires_at = CASE
+                   WHEN EXISTS (SELECT 1 FROM pantry_lots l WHERE l.pantry_item_id = pantry_items.id)
+                   THEN (SELECT MIN(l.expires_at) FROM pantry_lots l WHERE l.pantry_item_id = pantry_items.id)
+                   ELSE expires_at
+                 END,
+    updated_at = now()
+WHERE id = $1
+RETURNING id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown;
+
+-- name: DecrementPantryLot :one
+UPDATE pantry_lots
+SET quantity = quantity - $2
+WHERE id = $1
+RETURNING id, pantry_item_id, quantity, unit, expires_at, source_job_id, added_at;
+
+-- name: DeleteEmptyPantryLots :exec
+DELETE FROM pantry_lots
+WHERE pantry_item_id = $1 AND quantity <= 0;
+
+-- name: InsertPantryLotEvent :exec
+INSERT INTO pantry_lot_events (pantry_item_id, lot_id, kind, quantity, unit, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListPantryLotEventsByItem :many
+SELECT id, pantry_item_id, lot_id, kind, quantity, unit, expires_at, occurred_at
+FROM pantry_lot_events
+WHERE pantry_item_id = $1
+ORDER BY occurred_at, id;
+
+-- name: ListAllPantryLots :many
+SELECT id, pantry_item_id, quantity, unit, expires_at, source_job_id, added_at
+FROM pantry_lots
+ORDER BY pantry_item_id, added_at;
+
+-- Replays pantry_lot_events into the quantity each lot should hold.
+-- name: ListLotHistoryTotals :many
+SELECT pantry_item_id,
+       lot_id,
+       unit,
+       SUM(CASE WHEN kind = 'added' THEN quantity ELSE -quantity END)::float8 AS quantity,
+       MAX(expires_at)::timestamptz AS expires_at
+FROM pantry_lot_events
+GROUP BY pantry_item_id, lot_id, unit
+ORDER BY pantry_item_id, lot_id;
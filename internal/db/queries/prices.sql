@@ -0,0 +1,35 @@
+-- name: ListIngredientPrices :many
+SELECT ingredient_id, price, unit, updated_at
+FROM ingredient_prices
+ORDER BY ingredient_id;
+
+-- name: UpsertIngredientPrice :one
+INSERT INTO ingredient_prices (ingredient_id, price, unit)
+VALUES ($1, $2, $3)
+ON CONFLICT (ingredient_id) DO UPDATE
+  SET price      = EXCLUDED.price,
+      unit       = EXCLUDED.unit,
+      updated_at = now()
+RETURNING ingredient_id, price, unit, updated_at;
+
+-- name: DeleteIngredientPrice :execrows
+DELETE FROM ingredient_prices
+WHERE ingredient_id = $1;
+
+-- name: ListCategoryPrices :many
+SELECT category, price, unit, updated_at
+FROM category_prices
+ORDER BY category;
+
+-- name: UpsertCategoryPrice :one
+INSERT INTO category_prices (category, price, unit)
+VALUES ($1, $2, $3)
+ON CONFLICT (category) DO UPDATE
+  SET price      = EXCLUDED.price,
+      unit       = EXCLUDED.unit,
+      updated_at = now()
+RETURNING category, price, unit, updated_at;
+
+-- name: DeleteCategoryPrice :execrows
+DELETE FROM category_prices
+WHERE category = $1;
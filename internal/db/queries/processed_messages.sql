@@ -0,0 +1,12 @@
+-- name: MarkMessageProcessed :execrows
+INSERT INTO processed_messages (consumer, message_id)
+VALUES ($1, $2)
+ON CONFLICT (consumer, message_id) DO NOTHING;
+
+-- name: UnmarkMessageProcessed :exec
+DELETE FROM processed_messages
+WHERE consumer = $1 AND message_id = $2;
+
+-- name: DeleteProcessedMessagesBefore :execrows
+DELETE FROM processed_messages
+WHERE processed_at < $1;
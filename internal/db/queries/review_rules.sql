@@ -0,0 +1,13 @@
+-- name: ListReviewRules :many
+SELECT id, action, ingredient, category, below_quantity, unit, description, created_at
+FROM review_rules
+ORDER BY created_at, id;
+
+-- name: CreateReviewRule :one
+INSERT INTO review_rules (action, ingredient, category, below_quantity, unit, description)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, action, ingredient, category, below_quantity, unit, description, created_at;
+
+-- name: DeleteReviewRule :execrows
+DELETE FROM review_rules
+WHERE id = $1;
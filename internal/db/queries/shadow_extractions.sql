@@ -0,0 +1,17 @@
+-- name: UpsertShadowExtraction :exec
+INSERT INTO shadow_extractions (job_id, candidate, primary_items, shadow_items, error, tokens_used, duration_ms)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (job_id) DO UPDATE
+  SET candidate     = EXCLUDED.candidate,
+      primary_items = EXCLUDED.primary_items,
+      shadow_items  = EXCLUDED.shadow_items,
+      error         = EXCLUDED.error,
+      tokens_used   = EXCLUDED.tokens_used,
+      duration_ms   = EXCLUDED.duration_ms,
+      created_at    = now();
+
+-- name: ListShadowExtractions :many
+SELECT job_id, candidate, primary_items, shadow_items, error, tokens_used, duration_ms, created_at
+FROM shadow_extractions
+ORDER BY created_at DESC
+LIMIT $1;
@@ -0,0 +1,28 @@
+-- name: ListPantryItemsIdleSince :many
+SELECT id, ingredient_id, quantity, unit, expires_at, added_at, updated_at, quantity_unknown
+FROM pantry_items
+WHERE updated_at < $1
+  AND (quantity > 0 OR quantity_unknown)
+ORDER BY updated_at;
+
+-- name: UpsertStaleFlag :exec
+INSERT INTO stale_flags (item_id, category, shelf_life_days, item_updated_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (item_id) DO UPDATE
+  SET category        = EXCLUDED.category,
+      shelf_life_days = EXCLUDED.shelf_life_days,
+      flagged_at      = CASE WHEN stale_flags.item_updated_at = EXCLUDED.item_updated_at
+                             THEN stale_flags.flagged_at ELSE now() END,
+      item_updated_at = EXCLUDED.item_updated_at;
+
+-- name: DeleteStaleFlagsExcept :execrows
+DELETE FROM stale_flags
+WHERE NOT (item_id = ANY(sqlc.arg(item_ids)::uuid[]));
+
+-- name: ListStaleItems :many
+SELECT p.id, p.ingredient_id, p.quantity, p.unit, p.expires_at, p.updated_at, p.quantity_unknown,
+       f.category, f.shelf_life_days, f.flagged_at
+FROM stale_flags f
+JOIN pantry_items p ON p.id = f.item_id
+WHERE p.updated_at = f.item_updated_at
+ORDER BY p.updated_at, p.id;
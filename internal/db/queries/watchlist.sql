@@ -0,0 +1,31 @@
+-- name: ListWatchlist :many
+SELECT ingredient_id, min_quantity, unit, created_at, updated_at
+FROM watchlist
+ORDER BY created_at;
+
+-- name: UpsertWatchlistEntry :one
+INSERT INTO watchlist (ingredient_id, min_quantity, unit)
+VALUES ($1, $2, $3)
+ON CONFLICT (ingredient_id) DO UPDATE
+  SET min_quantity = EXCLUDED.min_quantity,
+      unit         = EXCLUDED.unit,
+      updated_at   = now()
+RETURNING ingredient_id, min_quantity, unit, created_at, updated_at;
+
+-- name: DeleteWatchlistEntry :execrows
+DELETE FROM watchlist WHERE ingredient_id = $1;
+
+-- name: ListMissingWatchedIngredients :many
+SELECT w.ingredient_id,
+       w.min_quantity,
+       w.unit,
+       p.id       AS pantry_item_id,
+       p.quantity AS current_quantity,
+       p.unit     AS current_unit
+FROM watchlist w
+LEFT JOIN pantry_items p ON p.ingredient_id = w.ingredient_id
+WHERE p.id IS NULL
+   OR (NOT (p.quantity_unknown AND p.quantity = 0)
+       AND (p.quantity <= 0
+            OR (w.min_quantity IS NOT NULL AND p.unit = w.unit AND p.quantity < w.min_quantity)))
+ORDER BY w.created_at;
@@ -0,0 +1,15 @@
+-- name: InsertWebhookDelivery :exec
+INSERT INTO webhook_deliveries (source, endpoint, signed, status_code, latency_ms, error)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListWebhookDeliveries :many
+SELECT id, source, endpoint, signed, status_code, latency_ms, error, attempted_at
+FROM webhook_deliveries
+WHERE (sqlc.narg('source')::text IS NULL OR source = sqlc.narg('source'))
+  AND (NOT sqlc.arg('failed_only')::boolean OR error IS NOT NULL)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: DeleteWebhookDeliveriesBefore :execrows
+DELETE FROM webhook_deliveries
+WHERE attempted_at < $1;
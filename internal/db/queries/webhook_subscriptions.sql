@@ -0,0 +1,72 @@
+-- name: ListWebhookSubscriptions :many
+SELECT id, url, event_types, secret, description, active, created_at, updated_at
+FROM webhook_subscriptions
+ORDER BY created_at, id;
+
+-- name: GetWebhookSubscription :one
+SELECT id, url, event_types, secret, description, active, created_at, updated_at
+FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (url, event_types, secret, description, active)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, url, event_types, secret, description, active, created_at, updated_at;
+
+-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+SET url         = sqlc.arg('url'),
+    event_types = sqlc.arg('event_types'),
+    secret      = COALESCE(sqlc.narg('secret'), secret),
+    description = sqlc.arg('description'),
+    active      = sqlc.arg('active'),
+    updated_at  = now()
+WHERE id = sqlc.arg('id')
+RETURNING id, url, event_types, secret, description, active, created_at, updated_at;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions WHERE id = $1;
+
+-- name: EnqueueWebhookEvent :execrows
+INSERT INTO webhook_events (subscription_id, event_type, payload)
+SELECT id, sqlc.arg('event_type'), sqlc.arg('payload')
+FROM webhook_subscriptions
+WHERE active AND sqlc.arg('event_type')::text = ANY(event_types);
+
+-- name: ClaimDueWebhookEvents :many
+-- Takes up to limit due events of active subscriptions, oldest first, and
+-- pushes their next attempt out by the lease. Concurrent dispatchers skip
+-- each other's rows, and the events of a dispatcher that dies mid-batch
+-- come due again once the lease runs out.
+WITH due AS (
+  SELECT e.id
+  FROM webhook_events e
+  JOIN webhook_subscriptions s ON s.id = e.subscription_id
+  WHERE e.sent_at IS NULL AND e.attempts < sqlc.arg('attempts') AND e.next_attempt_at <= now() AND s.active
+  ORDER BY e.id
+  LIMIT sqlc.arg('limit')
+  FOR UPDATE OF e SKIP LOCKED
+), claimed AS (
+  UPDATE webhook_events e
+  SET next_attempt_at = now() + make_interval(secs => sqlc.arg('lease_seconds')::float8)
+  FROM due, webhook_subscriptions s
+  WHERE e.id = due.id AND s.id = e.subscription_id
+  RETURNING e.id, e.subscription_id, e.event_type, e.payload, e.attempts, s.url, s.secret
+)
+SELECT id, subscription_id, event_type, payload, attempts, url, secret
+FROM claimed
+ORDER BY id;
+
+-- name: MarkWebhookEventSent :exec
+UPDATE webhook_events
+SET sent_at = now(), attempts = attempts + 1, last_error = NULL
+WHERE id = $1;
+
+-- name: RecordWebhookEventFailure :exec
+UPDATE webhook_events
+SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+WHERE id = $1;
+
+-- name: DeleteWebhookEventsBefore :execrows
+DELETE FROM webhook_events
+WHERE created_at < $1 AND (sent_at IS NOT NULL OR attempts >= $2);
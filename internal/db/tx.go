@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// TxBeginner is a DBTX that can start transactions. *sql.DB satisfies it.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// Transactor is a Querier that can run a group of queries atomically.
+// *Queries implements it.
+type Transactor interface {
+	InTx(ctx context.Context, fn func(Querier) error) error
+}
+
+// InTx runs fn with queries bound to a new transaction, committing when fn
+// returns nil and rolling back otherwise. A handle that cannot begin a
+// transaction, such as one that already is a transaction, runs fn directly.
+func (q *Queries) InTx(ctx context.Context, fn func(Querier) error) error {
+	b, ok := q.db.(TxBeginner)
+	if !ok {
+		return fn(q)
+	}
+	tx, err := b.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+
+	if err := fn(q.WithTx(tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// InTx runs fn in a transaction when q is a Transactor and directly on q
+// otherwise, e.g. with a mock Querier in tests.
+func InTx(ctx context.Context, q Querier, fn func(Querier) error) error {
+	if t, ok := q.(Transactor); ok {
+		return t.InTx(ctx, fn)
+	}
+	return fn(q)
+}
@@ -0,0 +1,117 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event brokers selectable with EVENT_BROKER.
+const (
+	BrokerRabbitMQ = "rabbitmq"
+	BrokerKafka    = "kafka"
+)
+
+// Publisher is an event backend. Both brokers publish the same payloads
+// under the same routing keys; only the transport differs.
+type Publisher interface {
+	PublishPantryUpdated(ctx context.Context, changedItemIDs []uuid.UUID) error
+	PublishPantryExpiring(ctx context.Context, itemIDs []uuid.UUID) error
+	PublishItemChanges(ctx context.Context, changes []ItemChange, reset bool) error
+	PublishConfirmSummary(ctx context.Context, summary ConfirmSummary, confirmedAt time.Time) error
+	// Publish sends a raw payload, falling back to the outbox when the
+	// broker is unreachable.
+	Publish(ctx context.Context, key string, body []byte) error
+	// Deliver sends a raw payload with no fallback; the outbox drainer
+	// uses it.
+	Deliver(ctx context.Context, key string, body []byte) error
+	// Ping checks the broker is reachable, for readiness.
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// BrokerConfig selects and addresses the event broker.
+type BrokerConfig struct {
+	Broker      string // BrokerRabbitMQ or BrokerKafka
+	RabbitMQURL string
+	Kafka       KafkaConfig
+}
+
+// NewPublisher creates the publisher for cfg.Broker. Options that only
+// apply to one broker are ignored by the other.
+func NewPublisher(cfg BrokerConfig, opts ...PublisherOption) (Publisher, error) {
+	switch cfg.Broker {
+	case BrokerRabbitMQ:
+		return NewPantryUpdatedPublisher(cfg.RabbitMQURL, opts...)
+	case BrokerKafka:
+		return NewKafkaPublisher(cfg.Kafka, opts...)
+	default:
+		return nil, fmt.Errorf("unknown event broker %q", cfg.Broker)
+	}
+}
+
+// publisherOptions are the settings shared by every broker's publisher.
+type publisherOptions struct {
+	validate   bool
+	lazy       bool
+	outbox     OutboxStore
+	bufferSize int
+}
+
+func newPublisherOptions(opts []PublisherOption) publisherOptions {
+	o := publisherOptions{bufferSize: DefaultRetryBufferSize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// check validates body against the schema for key when validation is on.
+func (o publisherOptions) check(key string, body []byte) error {
+	if !o.validate {
+		return nil
+	}
+	if err := Validate(key, body); err != nil {
+		return fmt.Errorf("%s schema validation: %w", key, err)
+	}
+	return nil
+}
+
+// PublisherOption configures a publisher.
+type PublisherOption func(*publisherOptions)
+
+// WithSchemaValidation validates every payload against its embedded schema
+// before publishing and refuses to publish on mismatch. Intended for
+// development; it adds a decode pass per event.
+func WithSchemaValidation() PublisherOption {
+	return func(o *publisherOptions) { o.validate = true }
+}
+
+// WithOutbox writes events to store when the broker is unreachable instead of
+// returning the publish error.
+func WithOutbox(store OutboxStore) PublisherOption {
+	return func(o *publisherOptions) { o.outbox = store }
+}
+
+// WithLazyConnect lets NewPantryUpdatedPublisher succeed while the broker is
+// down; the connection is dialed on first publish. Pair with WithOutbox so
+// events published before then are not lost. The Kafka publisher always
+// connects lazily.
+func WithLazyConnect() PublisherOption {
+	return func(o *publisherOptions) { o.lazy = true }
+}
+
+// WithRetryBuffer holds up to n undeliverable events in memory instead of
+// DefaultRetryBufferSize; 0 disables the buffer. The outbox, when set, is
+// tried first since it survives a restart. RabbitMQ only: the Kafka writer
+// retries on its own.
+func WithRetryBuffer(n int) PublisherOption {
+	return func(o *publisherOptions) { o.bufferSize = max(n, 0) }
+}
+
+var (
+	_ Publisher = (*PantryUpdatedPublisher)(nil)
+	_ Publisher = (*KafkaPublisher)(nil)
+)
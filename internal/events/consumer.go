@@ -0,0 +1,253 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+	"github.com/mwhite7112/woodpantry-pantry/internal/tracing"
+)
+
+const (
+	// IngestQueue is the durable work queue ingest jobs wait in until a
+	// Pantry instance takes them.
+	IngestQueue        = "pantry.ingest.jobs"
+	ingestRequestedKey = "pantry.ingest.requested"
+	// attemptHeader counts deliveries of a job across retries.
+	attemptHeader = "x-attempt"
+
+	// DefaultJobAttempts is how many times a failing job is tried before it
+	// is marked failed.
+	DefaultJobAttempts = 3
+	// DefaultJobRetryDelay is the wait before the first retry; later retries
+	// wait proportionally longer.
+	DefaultJobRetryDelay = 10 * time.Second
+
+	consumerReconnectDelay = 5 * time.Second
+)
+
+// ErrJobDeferred is returned by a JobHandler that cannot run a job yet. The
+// job is requeued after the retry delay without spending an attempt.
+var ErrJobDeferred = errors.New("ingest job deferred")
+
+type ingestRequestedEvent struct {
+	SchemaVersion int       `json:"schema_version"`
+	Timestamp     string    `json:"timestamp"`
+	JobID         uuid.UUID `json:"job_id"`
+}
+
+// JobHandler processes one queued ingest job. final is set on the last
+// attempt, when the handler must record a failure on the job itself.
+type JobHandler func(ctx context.Context, jobID uuid.UUID, final bool) error
+
+// IngestJobConsumer takes ingest jobs off IngestQueue. A job is acked only
+// once handled, so jobs in flight when an instance stops are redelivered to
+// another. Failed jobs are republished with an attempt count until
+// maxAttempts.
+type IngestJobConsumer struct {
+	url         string
+	handle      JobHandler
+	prefetch    int
+	maxAttempts int
+	retryDelay  time.Duration
+	log         *slog.Logger
+}
+
+// ConsumerOption configures an IngestJobConsumer.
+type ConsumerOption func(*IngestJobConsumer)
+
+// WithMaxAttempts sets how many times a failing job is tried. Values below 1
+// are ignored.
+func WithMaxAttempts(n int) ConsumerOption {
+	return func(c *IngestJobConsumer) {
+		if n > 0 {
+			c.maxAttempts = n
+		}
+	}
+}
+
+// WithRetryDelay sets the wait before the first retry.
+func WithRetryDelay(d time.Duration) ConsumerOption {
+	return func(c *IngestJobConsumer) { c.retryDelay = d }
+}
+
+// NewIngestJobConsumer creates a consumer that runs up to prefetch jobs at
+// once through handle. Match prefetch to the worker pool size so deliveries
+// never wait on a full local queue.
+func NewIngestJobConsumer(
+	rabbitmqURL string,
+	handle JobHandler,
+	prefetch int,
+	opts ...ConsumerOption,
+) *IngestJobConsumer {
+	c := &IngestJobConsumer{
+		url:         rabbitmqURL,
+		handle:      handle,
+		prefetch:    max(prefetch, 1),
+		maxAttempts: DefaultJobAttempts,
+		retryDelay:  DefaultJobRetryDelay,
+		log:         logging.For("ingest-consumer"),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Run consumes until ctx is cancelled, reconnecting after the broker goes
+// away. Jobs in flight at cancellation are requeued.
+func (c *IngestJobConsumer) Run(ctx context.Context) {
+	for {
+		err := c.consume(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		c.log.Warn("ingest job consumer disconnected; reconnecting",
+			"error", err, "delay", consumerReconnectDelay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(consumerReconnectDelay):
+		}
+	}
+}
+
+func (c *IngestJobConsumer) consume(ctx context.Context) error {
+	conn, err := dial(c.url)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("open channel: %w", err)
+	}
+	defer ch.Close()
+	if err := ch.Qos(c.prefetch, 0, false); err != nil {
+		return fmt.Errorf("set prefetch: %w", err)
+	}
+	deliveries, err := ch.Consume(IngestQueue, "", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("consume %s: %w", IngestQueue, err)
+	}
+	c.log.Info("consuming ingest jobs", "queue", IngestQueue, "prefetch", c.prefetch)
+
+	republish := func(ctx context.Context, body []byte, attempt int) error {
+		headers := amqp.Table{attemptHeader: int32(attempt)} //nolint:gosec // bounded by maxAttempts
+		return ch.PublishWithContext(ctx, exchangeName, ingestRequestedKey, false, false, amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Timestamp:    time.Now().UTC(),
+			Headers:      tracing.InjectAMQP(ctx, headers),
+			Body:         body,
+		})
+	}
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case d, ok := <-deliveries:
+			if !ok {
+				return errors.New("delivery channel closed")
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.process(ctx, d, republish)
+			}()
+		}
+	}
+}
+
+// process handles one delivery and settles it: ack on success or after the
+// final attempt, republish with the next attempt count on failure, and
+// requeue when shutting down or when the retry cannot be published.
+func (c *IngestJobConsumer) process(
+	ctx context.Context,
+	d amqp.Delivery,
+	republish func(ctx context.Context, body []byte, attempt int) error,
+) {
+	var msg ingestRequestedEvent
+	if err := json.Unmarshal(d.Body, &msg); err != nil || msg.JobID == uuid.Nil {
+		c.log.Error("dropping malformed ingest job message", "error", err)
+		_ = d.Reject(false)
+		return
+	}
+	attempt := deliveryAttempt(d)
+	delay := c.retryDelay
+	// The job continues the trace of the request that enqueued it.
+	ctx, span := tracing.Start(tracing.ExtractAMQP(ctx, d.Headers), "process "+ingestRequestedKey,
+		trace.SpanKindConsumer, semconv.MessagingSystemRabbitMQ, semconv.MessagingOperationTypeProcess,
+		semconv.MessagingDestinationName(IngestQueue), attribute.Int("ingest.attempt", attempt),
+		attribute.String("ingest.job_id", msg.JobID.String()))
+	err := c.handle(ctx, msg.JobID, attempt >= c.maxAttempts)
+	tracing.End(span, err)
+	switch {
+	case err == nil:
+		_ = d.Ack(false)
+		return
+	case ctx.Err() != nil:
+		_ = d.Nack(false, true)
+		return
+	case errors.Is(err, ErrJobDeferred):
+		// Not the job's fault; try again later at the same attempt.
+	case attempt >= c.maxAttempts:
+		c.log.Error("ingest job failed on final attempt", "job_id", msg.JobID, "attempt", attempt, "error", err)
+		_ = d.Ack(false)
+		return
+	default:
+		c.log.Warn("ingest job failed; retrying", "job_id", msg.JobID, "attempt", attempt, "error", err)
+		delay *= time.Duration(attempt)
+		attempt++
+	}
+
+	select {
+	case <-ctx.Done():
+		_ = d.Nack(false, true)
+		return
+	case <-time.After(delay):
+	}
+	if err := republish(ctx, d.Body, attempt); err != nil {
+		c.log.Error("failed to requeue ingest job", "job_id", msg.JobID, "error", err)
+		_ = d.Nack(false, true)
+		return
+	}
+	_ = d.Ack(false)
+}
+
+// deliveryAttempt reads the attempt count a retry was republished with; a
+// first delivery has none.
+func deliveryAttempt(d amqp.Delivery) int {
+	switch n := d.Headers[attemptHeader].(type) {
+	case int32:
+		return max(int(n), 1)
+	case int64:
+		return max(int(n), 1)
+	}
+	return 1
+}
+
+// declareIngestQueue declares the durable job queue and binds it to the
+// shared exchange.
+func declareIngestQueue(ch *amqp.Channel) error {
+	if _, err := ch.QueueDeclare(IngestQueue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("declare queue %q: %w", IngestQueue, err)
+	}
+	if err := ch.QueueBind(IngestQueue, ingestRequestedKey, exchangeName, false, nil); err != nil {
+		return fmt.Errorf("bind queue %q: %w", IngestQueue, err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// settled records how a delivery was settled.
+type settled struct {
+	outcome string
+	requeue bool
+}
+
+func (s *settled) Ack(uint64, bool) error { s.outcome = "ack"; return nil }
+
+func (s *settled) Nack(_ uint64, _ bool, requeue bool) error {
+	s.outcome, s.requeue = "nack", requeue
+	return nil
+}
+
+func (s *settled) Reject(_ uint64, requeue bool) error {
+	s.outcome, s.requeue = "reject", requeue
+	return nil
+}
+
+func jobDelivery(t *testing.T, jobID uuid.UUID, attempt int) (amqp.Delivery, *settled) {
+	t.Helper()
+
+	body, err := marshalIngestRequested(jobID, time.Now())
+	require.NoError(t, err)
+	ack := &settled{}
+	d := amqp.Delivery{Acknowledger: ack, Body: body}
+	if attempt > 0 {
+		d.Headers = amqp.Table{attemptHeader: int32(attempt)} //nolint:gosec // test values
+	}
+	return d, ack
+}
+
+func TestIngestJobConsumer_Process(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		attempt     int
+		handlerErr  error
+		wantFinal   bool
+		wantOutcome string
+		wantRetry   int // attempt the job is republished with; 0 for none
+	}{
+		{name: "success", wantOutcome: "ack"},
+		{name: "failure retries", handlerErr: errors.New("boom"), wantOutcome: "ack", wantRetry: 2},
+		{name: "final failure", attempt: 3, handlerErr: errors.New("boom"), wantFinal: true, wantOutcome: "ack"},
+		{name: "deferred keeps attempt", attempt: 2, handlerErr: ErrJobDeferred, wantOutcome: "ack", wantRetry: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			jobID := uuid.New()
+			var gotFinal bool
+			c := NewIngestJobConsumer(unreachableBroker, func(_ context.Context, id uuid.UUID, final bool) error {
+				assert.Equal(t, jobID, id)
+				gotFinal = final
+				return tt.handlerErr
+			}, 1, WithRetryDelay(0))
+
+			retried := 0
+			d, ack := jobDelivery(t, jobID, tt.attempt)
+			c.process(context.Background(), d, func(_ context.Context, _ []byte, attempt int) error {
+				retried = attempt
+				return nil
+			})
+
+			assert.Equal(t, tt.wantFinal, gotFinal)
+			assert.Equal(t, tt.wantOutcome, ack.outcome)
+			assert.Equal(t, tt.wantRetry, retried)
+		})
+	}
+}
+
+func TestIngestJobConsumer_RequeuesWhenRetryCannotBePublished(t *testing.T) {
+	t.Parallel()
+
+	c := NewIngestJobConsumer(unreachableBroker, func(context.Context, uuid.UUID, bool) error {
+		return errors.New("boom")
+	}, 1, WithRetryDelay(0))
+
+	d, ack := jobDelivery(t, uuid.New(), 0)
+	c.process(context.Background(), d, func(context.Context, []byte, int) error {
+		return errors.New("channel closed")
+	})
+	assert.Equal(t, settled{outcome: "nack", requeue: true}, *ack)
+}
+
+func TestIngestJobConsumer_RejectsMalformedMessages(t *testing.T) {
+	t.Parallel()
+
+	c := NewIngestJobConsumer(unreachableBroker, func(context.Context, uuid.UUID, bool) error {
+		t.Fatal("handler must not run")
+		return nil
+	}, 1)
+
+	ack := &settled{}
+	c.process(context.Background(), amqp.Delivery{Acknowledger: ack, Body: []byte(`{"job_id":""}`)}, nil)
+	assert.Equal(t, settled{outcome: "reject"}, *ack)
+}
+
+func TestPublishIngestRequested_QueuesToOutboxWhileDisconnected(t *testing.T) {
+	t.Parallel()
+
+	outbox := &memoryOutbox{}
+	p, err := NewPantryUpdatedPublisher(unreachableBroker, WithLazyConnect(), WithOutbox(outbox),
+		WithSchemaValidation())
+	require.NoError(t, err)
+
+	require.NoError(t, p.PublishIngestRequested(context.Background(), uuid.New()))
+	assert.Equal(t, []string{ingestRequestedKey}, outbox.keys)
+}
@@ -0,0 +1,50 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DictionaryMergedQueue receives the Dictionary service's
+	// dictionary.merged events.
+	DictionaryMergedQueue = "pantry.dictionary.merged"
+	dictionaryMergedKey   = "dictionary.merged"
+)
+
+// DictionaryMerged is the dictionary.merged event: the ingredient MergedID
+// was a duplicate of SurvivingID and no longer exists.
+type DictionaryMerged struct {
+	MergedID    uuid.UUID `json:"merged_id"`
+	SurvivingID uuid.UUID `json:"surviving_id"`
+}
+
+// NewDictionaryMergedConsumer subscribes to dictionary.merged and passes
+// each decoded event to handle.
+func NewDictionaryMergedConsumer(
+	rabbitmqURL string,
+	handle func(ctx context.Context, messageID string, event DictionaryMerged) error,
+) *Subscriber {
+	return NewSubscriber(rabbitmqURL, DictionaryMergedQueue, dictionaryMergedKey,
+		func(ctx context.Context, messageID string, body []byte) error {
+			event, err := decodeDictionaryMerged(body)
+			if err != nil {
+				return err
+			}
+			return handle(ctx, messageID, event)
+		})
+}
+
+func decodeDictionaryMerged(body []byte) (DictionaryMerged, error) {
+	var event DictionaryMerged
+	if err := json.Unmarshal(body, &event); err != nil {
+		return DictionaryMerged{}, fmt.Errorf("%w: %w", ErrMalformedEvent, err)
+	}
+	if event.MergedID == uuid.Nil || event.SurvivingID == uuid.Nil {
+		return DictionaryMerged{}, fmt.Errorf("%w: merged_id and surviving_id are required", ErrMalformedEvent)
+	}
+	return event, nil
+}
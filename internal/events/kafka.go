@@ -0,0 +1,213 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/tracing"
+)
+
+const (
+	// DefaultKafkaTopic is the topic every event is written to.
+	DefaultKafkaTopic = "woodpantry.pantry"
+
+	// EventTypeHeader carries the routing key, which consumers of the single
+	// topic filter on.
+	EventTypeHeader = "event_type"
+
+	// kafkaBatchTimeout bounds how long a publish waits for others to share
+	// its batch. Publishes are synchronous, so it is added to each one.
+	kafkaBatchTimeout = 10 * time.Millisecond
+)
+
+// KafkaConfig addresses the Kafka cluster events are published to.
+type KafkaConfig struct {
+	Brokers []string
+	Topic   string // DefaultKafkaTopic when empty
+	// HouseholdID, when set, keys every record so all of the household's
+	// events land on one partition in order. Otherwise records are keyed by
+	// the first item or job ID in the payload.
+	HouseholdID string
+}
+
+// KafkaPublisher publishes the same events as PantryUpdatedPublisher to a
+// single Kafka topic, with the routing key in the EventTypeHeader header.
+// Each publish waits for every in-sync replica to acknowledge it. A record
+// the cluster does not take is stored in the outbox when one is configured.
+type KafkaPublisher struct {
+	publisherOptions
+	cfg    KafkaConfig
+	writer *kafka.Writer
+}
+
+// NewKafkaPublisher creates a Kafka publisher. It does not connect until the
+// first publish or Ping.
+func NewKafkaPublisher(cfg KafkaConfig, opts ...PublisherOption) (*KafkaPublisher, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("kafka: at least one broker is required")
+	}
+	if cfg.Topic == "" {
+		cfg.Topic = DefaultKafkaTopic
+	}
+	return &KafkaPublisher{
+		publisherOptions: newPublisherOptions(opts),
+		cfg:              cfg,
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(cfg.Brokers...),
+			Topic:        cfg.Topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			BatchTimeout: kafkaBatchTimeout,
+			WriteTimeout: confirmTimeout,
+		},
+	}, nil
+}
+
+// PublishPantryUpdated publishes the minimal pantry.updated payload.
+func (p *KafkaPublisher) PublishPantryUpdated(ctx context.Context, changedItemIDs []uuid.UUID) error {
+	body, err := marshalPantryUpdated(changedItemIDs, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := p.check(routingKey, body); err != nil {
+		return err
+	}
+	return p.Publish(ctx, routingKey, body)
+}
+
+// PublishPantryExpiring publishes the IDs of items that entered their expiry
+// window.
+func (p *KafkaPublisher) PublishPantryExpiring(ctx context.Context, itemIDs []uuid.UUID) error {
+	body, err := marshalPantryExpiring(itemIDs, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := p.check(expiringRoutingKey, body); err != nil {
+		return err
+	}
+	return p.Publish(ctx, expiringRoutingKey, body)
+}
+
+// PublishItemChanges publishes pantry.updated.v2, the snapshot companion
+// of pantry.updated.
+func (p *KafkaPublisher) PublishItemChanges(ctx context.Context, changes []ItemChange, reset bool) error {
+	body, err := marshalItemChanges(changes, reset, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := p.check(itemChangesKey, body); err != nil {
+		return err
+	}
+	return p.Publish(ctx, itemChangesKey, body)
+}
+
+// PublishConfirmSummary publishes the analytics summary of one confirmed
+// ingest job, stamped with confirmedAt.
+func (p *KafkaPublisher) PublishConfirmSummary(
+	ctx context.Context,
+	summary ConfirmSummary,
+	confirmedAt time.Time,
+) error {
+	body, err := marshalConfirmSummary(summary, confirmedAt)
+	if err != nil {
+		return err
+	}
+	if err := p.check(confirmSummaryKey, body); err != nil {
+		return err
+	}
+	return p.Publish(ctx, confirmSummaryKey, body)
+}
+
+// Publish writes an event, storing it in the outbox instead when the
+// cluster does not take it and an outbox is configured.
+func (p *KafkaPublisher) Publish(ctx context.Context, key string, body []byte) error {
+	err := p.Deliver(ctx, key, body)
+	if err == nil || p.outbox == nil {
+		return err
+	}
+	if outboxErr := p.outbox.Enqueue(ctx, key, body); outboxErr != nil {
+		return errors.Join(err, fmt.Errorf("enqueue to outbox: %w", outboxErr))
+	}
+	return nil
+}
+
+// Deliver writes an event with no outbox fallback and waits for the
+// acknowledgement. The record carries ctx's trace context in its headers.
+func (p *KafkaPublisher) Deliver(ctx context.Context, key string, body []byte) (err error) {
+	partitionKey := p.partitionKey(body)
+	ctx, span := tracing.Start(ctx, "publish "+key, trace.SpanKindProducer,
+		semconv.MessagingSystemKafka, semconv.MessagingOperationTypeSend,
+		semconv.MessagingDestinationName(p.cfg.Topic), semconv.MessagingKafkaMessageKey(string(partitionKey)))
+	defer func() { tracing.End(span, err) }()
+
+	err = p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   partitionKey,
+		Value: body,
+		Time:  time.Now().UTC(),
+		Headers: tracing.InjectKafka(ctx, []kafka.Header{
+			{Key: EventTypeHeader, Value: []byte(key)},
+			{Key: "content-type", Value: []byte("application/json")},
+		}),
+	})
+	if err != nil {
+		return fmt.Errorf("publish %s: %w", key, err)
+	}
+	return nil
+}
+
+// partitionKey is the household ID when configured, otherwise the first
+// changed or expiring item ID, or the job ID, in body. Events with none of
+// these are spread across partitions.
+func (p *KafkaPublisher) partitionKey(body []byte) []byte {
+	if p.cfg.HouseholdID != "" {
+		return []byte(p.cfg.HouseholdID)
+	}
+	var ids struct {
+		Changed  []uuid.UUID `json:"changed_item_ids"`
+		Expiring []uuid.UUID `json:"expiring_item_ids"`
+		Changes  []struct {
+			ItemID uuid.UUID `json:"item_id"`
+		} `json:"changes"`
+		JobID uuid.UUID `json:"job_id"`
+	}
+	if err := json.Unmarshal(body, &ids); err != nil {
+		return nil
+	}
+	switch {
+	case len(ids.Changed) > 0:
+		return []byte(ids.Changed[0].String())
+	case len(ids.Expiring) > 0:
+		return []byte(ids.Expiring[0].String())
+	case len(ids.Changes) > 0:
+		return []byte(ids.Changes[0].ItemID.String())
+	case ids.JobID != uuid.Nil:
+		return []byte(ids.JobID.String())
+	}
+	return nil
+}
+
+// Ping connects to the first reachable broker, for the readiness probe.
+func (p *KafkaPublisher) Ping(ctx context.Context) error {
+	var errs []error
+	for _, addr := range p.cfg.Brokers {
+		conn, err := kafka.DialContext(ctx, "tcp", addr)
+		if err == nil {
+			return conn.Close()
+		}
+		errs = append(errs, err)
+	}
+	return fmt.Errorf("connect kafka: %w", errors.Join(errs...))
+}
+
+// Close flushes pending writes and closes the broker connections.
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
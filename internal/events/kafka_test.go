@@ -0,0 +1,68 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaPublisher_PartitionKey(t *testing.T) {
+	t.Parallel()
+
+	item, job := uuid.New(), uuid.New()
+	updated, err := marshalPantryUpdated([]uuid.UUID{item, uuid.New()}, time.Now())
+	require.NoError(t, err)
+	summary, err := marshalConfirmSummary(ConfirmSummary{JobID: job}, time.Now())
+	require.NoError(t, err)
+	empty, err := marshalPantryUpdated(nil, time.Now())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		household string
+		body      []byte
+		want      string
+	}{
+		{"first changed item", "", updated, item.String()},
+		{"confirm summary by job", "", summary, job.String()},
+		{"no IDs spread across partitions", "", empty, ""},
+		{"household wins", "home-1", updated, "home-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := NewKafkaPublisher(KafkaConfig{Brokers: []string{"127.0.0.1:1"}, HouseholdID: tt.household})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(p.partitionKey(tt.body)))
+		})
+	}
+}
+
+func TestKafkaPublisher_QueuesToOutboxWhenUnreachable(t *testing.T) {
+	t.Parallel()
+
+	outbox := &memoryOutbox{}
+	p, err := NewKafkaPublisher(KafkaConfig{Brokers: []string{"127.0.0.1:1"}}, WithOutbox(outbox))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = p.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	require.NoError(t, p.PublishPantryUpdated(ctx, []uuid.UUID{uuid.New()}))
+	assert.Equal(t, []string{routingKey}, outbox.keys)
+	require.ErrorContains(t, p.Ping(ctx), "connect kafka")
+}
+
+func TestNewPublisher_UnknownBroker(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewPublisher(BrokerConfig{Broker: "nats"})
+	require.ErrorContains(t, err, `unknown event broker "nats"`)
+	_, err = NewPublisher(BrokerConfig{Broker: BrokerKafka})
+	require.ErrorContains(t, err, "at least one broker")
+}
@@ -3,31 +3,170 @@ package events
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	amqp "github.com/rabbitmq/amqp091-go"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+	"github.com/mwhite7112/woodpantry-pantry/internal/tracing"
 )
 
 const (
-	exchangeName = "woodpantry.topic"
-	routingKey   = "pantry.updated"
+	exchangeName       = "woodpantry.topic"
+	routingKey         = "pantry.updated"
+	expiringRoutingKey = "pantry.expiring"
+	confirmSummaryKey  = "pantry.ingest.confirm_summary"
+	itemChangesKey     = "pantry.updated.v2"
+)
+
+const (
+	// DefaultRetryBufferSize is how many events a publisher holds in memory
+	// while it cannot deliver them anywhere else.
+	DefaultRetryBufferSize = 1000
+
+	// confirmTimeout bounds the wait for the broker to confirm a publish.
+	confirmTimeout = 5 * time.Second
+	// Reconnect backoff after the connection drops or a dial fails.
+	reconnectMinDelay = time.Second
+	reconnectMaxDelay = 30 * time.Second
 )
 
-// PantryUpdatedPublisher publishes pantry.updated events.
+var (
+	// ErrDisconnected is returned while the publisher is reconnecting; it
+	// does not dial on the caller's behalf meanwhile.
+	ErrDisconnected = errors.New("rabbitmq disconnected; reconnecting")
+	// ErrNacked is returned when the broker refuses a publish.
+	ErrNacked = errors.New("broker did not confirm the publish")
+)
+
+// OutboxStore durably holds events that could not be published so they can
+// be delivered once the broker is reachable again.
+type OutboxStore interface {
+	Enqueue(ctx context.Context, routingKey string, body []byte) error
+}
+
+// PantryUpdatedPublisher publishes pantry.updated events. Every publish
+// waits for the broker's confirm. A dropped connection, or a dial that
+// fails, is re-dialed in the background with backoff; events that cannot be
+// delivered or stored in the outbox meanwhile wait in a bounded in-memory
+// buffer that is flushed once the connection is back.
 type PantryUpdatedPublisher struct {
+	publisherOptions
+	url string
+	log *slog.Logger
+
+	mu   sync.Mutex
 	conn *amqp.Connection
+	// ch is the confirm-mode channel shared by publishes.
+	ch           *amqp.Channel
+	reconnecting bool
+	closed       bool
+	done         chan struct{}
+
+	bufMu    sync.Mutex
+	buffer   []bufferedEvent
+	flushing bool
+}
+
+// bufferedEvent is an event waiting in the retry buffer.
+type bufferedEvent struct {
+	key  string
+	body []byte
 }
 
 type pantryUpdatedEvent struct {
+	SchemaVersion  int         `json:"schema_version"`
 	Timestamp      string      `json:"timestamp"`
 	ChangedItemIDs []uuid.UUID `json:"changed_item_ids"`
 }
 
+type pantryExpiringEvent struct {
+	SchemaVersion   int         `json:"schema_version"`
+	Timestamp       string      `json:"timestamp"`
+	ExpiringItemIDs []uuid.UUID `json:"expiring_item_ids"`
+}
+
+// Item change kinds in pantry.updated.v2.
+const (
+	ChangeCreated = "created"
+	ChangeUpdated = "updated"
+	ChangeDeleted = "deleted"
+)
+
+// ItemChange is one item in pantry.updated.v2 with its state after the
+// change. A deleted item carries only its IDs.
+type ItemChange struct {
+	Kind            string     `json:"kind"`
+	ItemID          uuid.UUID  `json:"item_id"`
+	IngredientID    uuid.UUID  `json:"ingredient_id"`
+	Quantity        *float64   `json:"quantity,omitempty"`
+	Unit            string     `json:"unit,omitempty"`
+	QuantityUnknown bool       `json:"quantity_unknown,omitempty"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+}
+
+type itemChangesEvent struct {
+	SchemaVersion int          `json:"schema_version"`
+	Timestamp     string       `json:"timestamp"`
+	Reset         bool         `json:"reset"`
+	Changes       []ItemChange `json:"changes"`
+}
+
+// ConfirmSummary is the body of pantry.ingest.confirm_summary, less the
+// envelope fields.
+type ConfirmSummary struct {
+	JobID         uuid.UUID          `json:"job_id"`
+	Source        string             `json:"source"`
+	ItemCount     int                `json:"item_count"`
+	SkippedCount  int                `json:"skipped_count"`
+	OverrideCount int                `json:"override_count"`
+	Categories    []CategoryQuantity `json:"categories"`
+}
+
+// CategoryQuantity totals one category's confirmed stock in one unit.
+type CategoryQuantity struct {
+	Category  string  `json:"category"`
+	Unit      string  `json:"unit"`
+	Quantity  float64 `json:"quantity"`
+	ItemCount int     `json:"item_count"`
+}
+
+type confirmSummaryEvent struct {
+	SchemaVersion int    `json:"schema_version"`
+	Timestamp     string `json:"timestamp"`
+	ConfirmSummary
+}
+
 // NewPantryUpdatedPublisher creates a RabbitMQ publisher and ensures the
 // shared topic exchange exists.
-func NewPantryUpdatedPublisher(rabbitmqURL string) (*PantryUpdatedPublisher, error) {
+func NewPantryUpdatedPublisher(rabbitmqURL string, opts ...PublisherOption) (*PantryUpdatedPublisher, error) {
+	p := &PantryUpdatedPublisher{
+		publisherOptions: newPublisherOptions(opts),
+		url:              rabbitmqURL,
+		log:              logging.For("publisher"),
+		done:             make(chan struct{}),
+	}
+
+	conn, err := dial(rabbitmqURL)
+	if err != nil {
+		if p.lazy {
+			return p, nil
+		}
+		return nil, err
+	}
+	p.setConn(conn)
+	return p, nil
+}
+
+// dial connects and declares the shared topic exchange.
+func dial(rabbitmqURL string) (*amqp.Connection, error) {
 	conn, err := amqp.Dial(rabbitmqURL)
 	if err != nil {
 		return nil, fmt.Errorf("connect rabbitmq: %w", err)
@@ -52,8 +191,155 @@ func NewPantryUpdatedPublisher(rabbitmqURL string) (*PantryUpdatedPublisher, err
 		_ = conn.Close()
 		return nil, fmt.Errorf("declare exchange %q: %w", exchangeName, err)
 	}
+	// Declared by publishers too, so jobs queued before any consumer starts
+	// are not dropped as unroutable.
+	if err := declareIngestQueue(ch); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Connected reports whether the publisher currently holds an open broker
+// connection.
+func (p *PantryUpdatedPublisher) Connected() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.conn != nil && !p.conn.IsClosed()
+}
+
+// Ping opens and closes a channel for the readiness probe. A failed dial
+// starts reconnecting, so a pod recovers without waiting for an event to
+// publish. A dial still running when ctx ends finishes in the background.
+func (p *PantryUpdatedPublisher) Ping(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		p.mu.Lock()
+		conn, err := p.connection()
+		p.mu.Unlock()
+		if err == nil {
+			var ch *amqp.Channel
+			if ch, err = conn.Channel(); err == nil {
+				err = ch.Close()
+			}
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
 
-	return &PantryUpdatedPublisher{conn: conn}, nil
+// connection returns the open connection, dialing when there is none and
+// no reconnect is running. p.mu must be held.
+func (p *PantryUpdatedPublisher) connection() (*amqp.Connection, error) {
+	if p.conn != nil && !p.conn.IsClosed() {
+		return p.conn, nil
+	}
+	if p.closed {
+		return nil, errors.New("publisher closed")
+	}
+	if p.reconnecting {
+		return nil, ErrDisconnected
+	}
+	conn, err := dial(p.url)
+	if err != nil {
+		p.startReconnect()
+		return nil, err
+	}
+	p.setConn(conn)
+	return conn, nil
+}
+
+// confirmChannel returns the shared channel, opening it in confirm mode when
+// it is missing or closed.
+func (p *PantryUpdatedPublisher) confirmChannel() (*amqp.Channel, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ch != nil && !p.ch.IsClosed() {
+		return p.ch, nil
+	}
+	conn, err := p.connection()
+	if err != nil {
+		return nil, err
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("open channel: %w", err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		_ = ch.Close()
+		return nil, fmt.Errorf("enable publisher confirms: %w", err)
+	}
+	p.ch = ch
+	return ch, nil
+}
+
+// setConn adopts conn and watches it for a drop. p.mu must be held, except
+// in the constructor.
+func (p *PantryUpdatedPublisher) setConn(conn *amqp.Connection) {
+	p.conn, p.ch = conn, nil
+	closed := conn.NotifyClose(make(chan *amqp.Error, 1))
+	go func() {
+		// A close we asked for sends nothing.
+		amqpErr, ok := <-closed
+		if !ok || amqpErr == nil {
+			return
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.conn == conn {
+			p.log.Warn("rabbitmq connection lost; reconnecting", "error", amqpErr)
+			p.startReconnect()
+		}
+	}()
+}
+
+// startReconnect re-dials in the background unless a reconnect is already
+// running. p.mu must be held.
+func (p *PantryUpdatedPublisher) startReconnect() {
+	if p.reconnecting || p.closed {
+		return
+	}
+	p.reconnecting = true
+	go p.reconnect()
+}
+
+// reconnect dials with exponential backoff until it connects or the
+// publisher is closed, then flushes the retry buffer.
+func (p *PantryUpdatedPublisher) reconnect() {
+	delay := reconnectMinDelay
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-time.After(delay):
+		}
+		conn, err := dial(p.url)
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			if conn != nil {
+				_ = conn.Close()
+			}
+			return
+		}
+		if err == nil {
+			p.setConn(conn)
+			p.reconnecting = false
+			p.mu.Unlock()
+			p.log.Info("rabbitmq reconnected")
+			p.flush()
+			return
+		}
+		p.mu.Unlock()
+		p.log.Warn("rabbitmq reconnect failed", "error", err, "retry_in", delay)
+		delay = min(delay*2, reconnectMaxDelay)
+	}
 }
 
 // PublishPantryUpdated publishes the minimal pantry.updated payload.
@@ -61,34 +347,290 @@ func (p *PantryUpdatedPublisher) PublishPantryUpdated(
 	ctx context.Context,
 	changedItemIDs []uuid.UUID,
 ) error {
-	ch, err := p.conn.Channel()
+	body, err := marshalPantryUpdated(changedItemIDs, time.Now())
 	if err != nil {
-		return fmt.Errorf("open channel: %w", err)
+		return err
 	}
-	defer ch.Close()
+	if err := p.check(routingKey, body); err != nil {
+		return err
+	}
+	return p.Publish(ctx, routingKey, body)
+}
 
-	event := pantryUpdatedEvent{
-		Timestamp:      time.Now().UTC().Format(time.RFC3339),
-		ChangedItemIDs: changedItemIDs,
+// PublishPantryExpiring publishes the IDs of items that entered their expiry
+// window. Consumers read details from GET /pantry/expiring.
+func (p *PantryUpdatedPublisher) PublishPantryExpiring(ctx context.Context, itemIDs []uuid.UUID) error {
+	body, err := marshalPantryExpiring(itemIDs, time.Now())
+	if err != nil {
+		return err
 	}
-	body, err := json.Marshal(event)
+	if err := p.check(expiringRoutingKey, body); err != nil {
+		return err
+	}
+	return p.Publish(ctx, expiringRoutingKey, body)
+}
+
+// PublishItemChanges publishes pantry.updated.v2, the snapshot companion
+// of pantry.updated. reset reports that every item was removed.
+func (p *PantryUpdatedPublisher) PublishItemChanges(ctx context.Context, changes []ItemChange, reset bool) error {
+	body, err := marshalItemChanges(changes, reset, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := p.check(itemChangesKey, body); err != nil {
+		return err
+	}
+	return p.Publish(ctx, itemChangesKey, body)
+}
+
+// PublishIngestRequested queues an ingest job for the consumers on
+// IngestQueue. With an outbox, a job accepted while the broker is down is
+// queued once it returns.
+func (p *PantryUpdatedPublisher) PublishIngestRequested(ctx context.Context, jobID uuid.UUID) error {
+	body, err := marshalIngestRequested(jobID, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := p.check(ingestRequestedKey, body); err != nil {
+		return err
+	}
+	return p.Publish(ctx, ingestRequestedKey, body)
+}
+
+// PublishConfirmSummary publishes the analytics summary of one confirmed
+// ingest job, stamped with confirmedAt.
+func (p *PantryUpdatedPublisher) PublishConfirmSummary(
+	ctx context.Context,
+	summary ConfirmSummary,
+	confirmedAt time.Time,
+) error {
+	body, err := marshalConfirmSummary(summary, confirmedAt)
+	if err != nil {
+		return err
+	}
+	if err := p.check(confirmSummaryKey, body); err != nil {
+		return err
+	}
+	return p.Publish(ctx, confirmSummaryKey, body)
+}
+
+// Publish sends a persistent JSON message to the shared topic exchange under
+// routingKey. Payloads are not schema-validated here. With an outbox
+// configured, an event is stored for later instead of failing when the broker
+// is down; while disconnected, publishing skips the dial entirely so request
+// paths do not wait on an unreachable broker. An event that cannot be
+// stored either waits in the retry buffer; an error is returned only once
+// that is full.
+func (p *PantryUpdatedPublisher) Publish(ctx context.Context, key string, body []byte) error {
+	var err error
+	if p.outbox == nil || p.Connected() {
+		if err = p.Deliver(ctx, key, body); err == nil {
+			p.flushAsync()
+			return nil
+		}
+	} else {
+		err = ErrDisconnected
+	}
+	if p.outbox != nil {
+		outboxErr := p.outbox.Enqueue(ctx, key, body)
+		if outboxErr == nil {
+			return nil
+		}
+		err = errors.Join(err, fmt.Errorf("enqueue to outbox: %w", outboxErr))
+	}
+	if p.bufferEvent(key, body) {
+		p.log.WarnContext(ctx, "event buffered until rabbitmq is reachable", "routing_key", key, "error", err)
+		return nil
+	}
+	return err
+}
+
+// bufferEvent adds an event to the retry buffer, reporting false when it is
+// full.
+func (p *PantryUpdatedPublisher) bufferEvent(key string, body []byte) bool {
+	p.bufMu.Lock()
+	defer p.bufMu.Unlock()
+	if len(p.buffer) >= p.bufferSize {
+		return false
+	}
+	p.buffer = append(p.buffer, bufferedEvent{key: key, body: body})
+	return true
+}
+
+// Buffered reports how many events wait in the retry buffer.
+func (p *PantryUpdatedPublisher) Buffered() int {
+	p.bufMu.Lock()
+	defer p.bufMu.Unlock()
+	return len(p.buffer)
+}
+
+// flushAsync starts a flush when events are buffered, so the caller does not
+// wait on it.
+func (p *PantryUpdatedPublisher) flushAsync() {
+	if p.Buffered() > 0 {
+		go p.flush()
+	}
+}
+
+// flush delivers buffered events oldest first, stopping at the first
+// failure. Only one flush runs at a time.
+func (p *PantryUpdatedPublisher) flush() {
+	p.bufMu.Lock()
+	if p.flushing {
+		p.bufMu.Unlock()
+		return
+	}
+	p.flushing = true
+	p.bufMu.Unlock()
+	defer func() {
+		p.bufMu.Lock()
+		p.flushing = false
+		p.bufMu.Unlock()
+	}()
+
+	for {
+		p.bufMu.Lock()
+		if len(p.buffer) == 0 {
+			p.bufMu.Unlock()
+			return
+		}
+		next := p.buffer[0]
+		p.bufMu.Unlock()
+
+		if err := p.Deliver(context.Background(), next.key, next.body); err != nil {
+			p.log.Warn("buffered event delivery failed", "routing_key", next.key, "error", err)
+			return
+		}
+		p.bufMu.Lock()
+		p.buffer = p.buffer[1:]
+		p.bufMu.Unlock()
+	}
+}
+
+// Deliver sends to the broker without the outbox or buffer fallback and
+// waits for the broker's confirm. It dials if the connection is down and no
+// reconnect is running, and fails fast with ErrDisconnected while one is.
+// The outbox drainer uses it so undeliverable events stay queued rather than
+// being re-enqueued. The message carries ctx's trace context in its headers.
+func (p *PantryUpdatedPublisher) Deliver(ctx context.Context, key string, body []byte) (err error) {
+	ctx, span := tracing.Start(ctx, "publish "+key, trace.SpanKindProducer,
+		semconv.MessagingSystemRabbitMQ, semconv.MessagingOperationTypeSend,
+		semconv.MessagingDestinationName(exchangeName), semconv.MessagingRabbitMQDestinationRoutingKey(key))
+	defer func() { tracing.End(span, err) }()
+
+	ch, err := p.confirmChannel()
 	if err != nil {
-		return fmt.Errorf("marshal pantry.updated event: %w", err)
+		return err
 	}
 
-	if err := ch.PublishWithContext(ctx, exchangeName, routingKey, false, false, amqp.Publishing{
+	confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchangeName, key, false, false, amqp.Publishing{
 		ContentType:  "application/json",
 		DeliveryMode: amqp.Persistent,
 		Timestamp:    time.Now().UTC(),
+		Headers:      tracing.InjectAMQP(ctx, nil),
 		Body:         body,
-	}); err != nil {
-		return fmt.Errorf("publish pantry.updated: %w", err)
+	})
+	if err != nil {
+		return fmt.Errorf("publish %s: %w", key, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, confirmTimeout)
+	defer cancel()
+	acked, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("confirm %s: %w", key, err)
+	}
+	if !acked {
+		return fmt.Errorf("publish %s: %w", key, ErrNacked)
 	}
-
 	return nil
 }
 
-// Close closes the RabbitMQ connection.
+func marshalPantryUpdated(changedItemIDs []uuid.UUID, now time.Time) ([]byte, error) {
+	if changedItemIDs == nil {
+		changedItemIDs = []uuid.UUID{}
+	}
+	event := pantryUpdatedEvent{
+		SchemaVersion:  SchemaVersion(routingKey),
+		Timestamp:      now.UTC().Format(time.RFC3339),
+		ChangedItemIDs: changedItemIDs,
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("marshal pantry.updated event: %w", err)
+	}
+	return body, nil
+}
+
+func marshalItemChanges(changes []ItemChange, reset bool, now time.Time) ([]byte, error) {
+	if changes == nil {
+		changes = []ItemChange{}
+	}
+	body, err := json.Marshal(itemChangesEvent{
+		SchemaVersion: SchemaVersion(itemChangesKey),
+		Timestamp:     now.UTC().Format(time.RFC3339),
+		Reset:         reset,
+		Changes:       changes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal pantry.updated.v2 event: %w", err)
+	}
+	return body, nil
+}
+
+func marshalPantryExpiring(itemIDs []uuid.UUID, now time.Time) ([]byte, error) {
+	body, err := json.Marshal(pantryExpiringEvent{
+		SchemaVersion:   SchemaVersion(expiringRoutingKey),
+		Timestamp:       now.UTC().Format(time.RFC3339),
+		ExpiringItemIDs: itemIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal pantry.expiring event: %w", err)
+	}
+	return body, nil
+}
+
+func marshalIngestRequested(jobID uuid.UUID, now time.Time) ([]byte, error) {
+	body, err := json.Marshal(ingestRequestedEvent{
+		SchemaVersion: SchemaVersion(ingestRequestedKey),
+		Timestamp:     now.UTC().Format(time.RFC3339),
+		JobID:         jobID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal pantry.ingest.requested event: %w", err)
+	}
+	return body, nil
+}
+
+func marshalConfirmSummary(summary ConfirmSummary, confirmedAt time.Time) ([]byte, error) {
+	if summary.Categories == nil {
+		summary.Categories = []CategoryQuantity{}
+	}
+	body, err := json.Marshal(confirmSummaryEvent{
+		SchemaVersion:  SchemaVersion(confirmSummaryKey),
+		Timestamp:      confirmedAt.UTC().Format(time.RFC3339),
+		ConfirmSummary: summary,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal pantry.ingest.confirm_summary event: %w", err)
+	}
+	return body, nil
+}
+
+// Close stops reconnecting and closes the RabbitMQ connection. Events still
+// in the retry buffer are dropped and counted in the log.
 func (p *PantryUpdatedPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	close(p.done)
+	if n := p.Buffered(); n > 0 {
+		p.log.Warn("closing publisher with undelivered events", "buffered", n)
+	}
+	if p.conn == nil || p.conn.IsClosed() {
+		return nil
+	}
 	return p.conn.Close()
 }
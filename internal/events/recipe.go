@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RecipeCookedQueue receives the Recipe service's recipe.cooked events.
+	RecipeCookedQueue = "pantry.recipe.cooked"
+	recipeCookedKey   = "recipe.cooked"
+)
+
+// RecipeCooked is the recipe.cooked event: a recipe was cooked, using the
+// listed amounts.
+type RecipeCooked struct {
+	RecipeID    uuid.UUID          `json:"recipe_id"`
+	Ingredients []CookedIngredient `json:"ingredients"`
+}
+
+// CookedIngredient is one ingredient a cooked recipe used. Unit may be empty
+// for a count of the pantry's own unit.
+type CookedIngredient struct {
+	IngredientID uuid.UUID `json:"ingredient_id"`
+	Quantity     float64   `json:"quantity"`
+	Unit         string    `json:"unit"`
+}
+
+// NewRecipeCookedConsumer subscribes to recipe.cooked and passes each
+// decoded event to handle.
+func NewRecipeCookedConsumer(
+	rabbitmqURL string,
+	handle func(ctx context.Context, messageID string, event RecipeCooked) error,
+) *Subscriber {
+	return NewSubscriber(rabbitmqURL, RecipeCookedQueue, recipeCookedKey,
+		func(ctx context.Context, messageID string, body []byte) error {
+			event, err := decodeRecipeCooked(body)
+			if err != nil {
+				return err
+			}
+			return handle(ctx, messageID, event)
+		})
+}
+
+func decodeRecipeCooked(body []byte) (RecipeCooked, error) {
+	var event RecipeCooked
+	if err := json.Unmarshal(body, &event); err != nil {
+		return RecipeCooked{}, fmt.Errorf("%w: %w", ErrMalformedEvent, err)
+	}
+	for _, in := range event.Ingredients {
+		if in.IngredientID == uuid.Nil {
+			return RecipeCooked{}, fmt.Errorf("%w: ingredient without ingredient_id", ErrMalformedEvent)
+		}
+	}
+	return event, nil
+}
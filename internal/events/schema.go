@@ -0,0 +1,207 @@
+package events
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// Schema is a published event contract.
+type Schema struct {
+	Name     string          `json:"name"`
+	Version  int             `json:"version"`
+	Document json.RawMessage `json:"schema"`
+}
+
+// schemaNode is the subset of JSON Schema understood by Validate.
+type schemaNode struct {
+	Type                 string                 `json:"type"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *schemaNode            `json:"items"`
+	Format               string                 `json:"format"`
+	Enum                 []any                  `json:"enum"`
+	Const                any                    `json:"const"`
+}
+
+type registeredSchema struct {
+	Schema
+
+	root *schemaNode
+}
+
+var registry = mustLoadSchemas()
+
+func mustLoadSchemas() map[string]registeredSchema {
+	entries, err := schemaFS.ReadDir("schemas")
+	if err != nil {
+		panic(fmt.Sprintf("read embedded event schemas: %v", err))
+	}
+
+	out := make(map[string]registeredSchema, len(entries))
+	for _, e := range entries {
+		raw, err := schemaFS.ReadFile(path.Join("schemas", e.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("read event schema %s: %v", e.Name(), err))
+		}
+		var root schemaNode
+		if err := json.Unmarshal(raw, &root); err != nil {
+			panic(fmt.Sprintf("parse event schema %s: %v", e.Name(), err))
+		}
+		prop, ok := root.Properties["schema_version"]
+		if !ok {
+			panic(fmt.Sprintf("event schema %s must define schema_version", e.Name()))
+		}
+		version, ok := prop.Const.(float64)
+		if !ok {
+			panic(fmt.Sprintf("event schema %s must pin schema_version with an integer const", e.Name()))
+		}
+		name := strings.TrimSuffix(e.Name(), ".json")
+		out[name] = registeredSchema{
+			Schema: Schema{Name: name, Version: int(version), Document: raw},
+			root:   &root,
+		}
+	}
+	return out
+}
+
+// Schemas returns every registered event schema sorted by name.
+func Schemas() []Schema {
+	out := make([]Schema, 0, len(registry))
+	for _, s := range registry {
+		out = append(out, s.Schema)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// SchemaVersion returns the current schema_version for the named event.
+func SchemaVersion(name string) int {
+	return registry[name].Version
+}
+
+// Validate checks payload against the named event schema. Only the keywords
+// used by the embedded schemas are supported: type, required, properties,
+// additionalProperties, items, format (date-time, uuid), enum, and const.
+func Validate(name string, payload []byte) error {
+	s, ok := registry[name]
+	if !ok {
+		return fmt.Errorf("no schema registered for event %q", name)
+	}
+	var doc any
+	if err := json.Unmarshal(payload, &doc); err != nil {
+		return fmt.Errorf("%s: invalid JSON: %w", name, err)
+	}
+	if err := validateNode(s.root, doc, "$"); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+func validateNode(n *schemaNode, v any, at string) error {
+	if err := validateType(n.Type, v, at); err != nil {
+		return err
+	}
+	if n.Const != nil && fmt.Sprint(n.Const) != fmt.Sprint(v) {
+		return fmt.Errorf("%s: must equal %v", at, n.Const)
+	}
+	if len(n.Enum) > 0 && !containsValue(n.Enum, v) {
+		return fmt.Errorf("%s: must be one of %v", at, n.Enum)
+	}
+	if err := validateFormat(n.Format, v, at); err != nil {
+		return err
+	}
+
+	switch val := v.(type) {
+	case map[string]any:
+		for _, req := range n.Required {
+			if _, ok := val[req]; !ok {
+				return fmt.Errorf("%s: missing required property %q", at, req)
+			}
+		}
+		for key, child := range val {
+			prop, ok := n.Properties[key]
+			if !ok {
+				if n.AdditionalProperties != nil && !*n.AdditionalProperties {
+					return fmt.Errorf("%s: unexpected property %q", at, key)
+				}
+				continue
+			}
+			if err := validateNode(prop, child, at+"."+key); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if n.Items != nil {
+			for i, child := range val {
+				if err := validateNode(n.Items, child, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validateType(typ string, v any, at string) error {
+	ok := true
+	switch typ {
+	case "":
+	case "object":
+		_, ok = v.(map[string]any)
+	case "array":
+		_, ok = v.([]any)
+	case "string":
+		_, ok = v.(string)
+	case "number":
+		_, ok = v.(float64)
+	case "integer":
+		f, isNum := v.(float64)
+		ok = isNum && f == float64(int64(f))
+	case "boolean":
+		_, ok = v.(bool)
+	case "null":
+		ok = v == nil
+	}
+	if !ok {
+		return fmt.Errorf("%s: expected %s", at, typ)
+	}
+	return nil
+}
+
+func validateFormat(format string, v any, at string) error {
+	s, isString := v.(string)
+	if format == "" || !isString {
+		return nil
+	}
+	var err error
+	switch format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, s)
+	case "uuid":
+		_, err = uuid.Parse(s)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: invalid %s: %w", at, format, err)
+	}
+	return nil
+}
+
+func containsValue(options []any, v any) bool {
+	for _, o := range options {
+		if fmt.Sprint(o) == fmt.Sprint(v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemas_RegistersPantryUpdated(t *testing.T) {
+	t.Parallel()
+
+	schemas := Schemas()
+	require.NotEmpty(t, schemas)
+
+	var found bool
+	for _, s := range schemas {
+		if s.Name == "pantry.updated" {
+			found = true
+			assert.Equal(t, 1, s.Version)
+			assert.Contains(t, string(s.Document), "changed_item_ids")
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestMarshalPantryUpdated_MatchesSchema(t *testing.T) {
+	t.Parallel()
+
+	body, err := marshalPantryUpdated([]uuid.UUID{uuid.New()}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, Validate("pantry.updated", body))
+
+	body, err = marshalPantryUpdated(nil, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, Validate("pantry.updated", body))
+	assert.Contains(t, string(body), `"changed_item_ids":[]`)
+}
+
+func TestMarshalPantryExpiring_MatchesSchema(t *testing.T) {
+	t.Parallel()
+
+	body, err := marshalPantryExpiring([]uuid.UUID{uuid.New()}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, Validate("pantry.expiring", body))
+	assert.Equal(t, 1, SchemaVersion("pantry.expiring"))
+}
+
+func TestMarshalConfirmSummary_MatchesSchema(t *testing.T) {
+	t.Parallel()
+
+	body, err := marshalConfirmSummary(ConfirmSummary{
+		JobID:         uuid.New(),
+		Source:        "mobile",
+		ItemCount:     3,
+		SkippedCount:  1,
+		OverrideCount: 2,
+		Categories: []CategoryQuantity{
+			{Category: "produce", Unit: "kg", Quantity: 1.5, ItemCount: 2},
+			{Category: "dairy", Unit: "l", Quantity: 2, ItemCount: 1},
+		},
+	}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, Validate("pantry.ingest.confirm_summary", body))
+
+	body, err = marshalConfirmSummary(ConfirmSummary{JobID: uuid.New(), Source: "unknown"}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, Validate("pantry.ingest.confirm_summary", body))
+	assert.Contains(t, string(body), `"categories":[]`)
+}
+
+func TestMarshalItemChanges_MatchesSchema(t *testing.T) {
+	t.Parallel()
+
+	qty, expires := 2.5, time.Now()
+	body, err := marshalItemChanges([]ItemChange{
+		{
+			Kind: ChangeCreated, ItemID: uuid.New(), IngredientID: uuid.New(),
+			Quantity: &qty, Unit: "kg", ExpiresAt: &expires,
+		},
+		{Kind: ChangeDeleted, ItemID: uuid.New(), IngredientID: uuid.New()},
+	}, false, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, Validate("pantry.updated.v2", body))
+	assert.Equal(t, 2, SchemaVersion("pantry.updated.v2"))
+
+	body, err = marshalItemChanges(nil, true, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, Validate("pantry.updated.v2", body))
+	assert.Contains(t, string(body), `"reset":true,"changes":[]`)
+}
+
+func TestValidate_RejectsContractViolations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		payload string
+		want    string
+	}{
+		{"missing field", `{"schema_version":1,"timestamp":"2026-02-25T12:34:56Z"}`, "missing required property"},
+		{
+			"wrong version",
+			`{"schema_version":2,"timestamp":"2026-02-25T12:34:56Z","changed_item_ids":[]}`,
+			"must equal 1",
+		},
+		{
+			"bad uuid",
+			`{"schema_version":1,"timestamp":"2026-02-25T12:34:56Z","changed_item_ids":["x"]}`,
+			"invalid uuid",
+		},
+		{"bad timestamp", `{"schema_version":1,"timestamp":"yesterday","changed_item_ids":[]}`, "invalid date-time"},
+		{
+			"extra field",
+			`{"schema_version":1,"timestamp":"2026-02-25T12:34:56Z","changed_item_ids":[],"x":1}`,
+			"unexpected property",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Validate("pantry.updated", []byte(tc.payload))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
+
+func TestValidate_UnknownEvent(t *testing.T) {
+	t.Parallel()
+
+	require.Error(t, Validate("pantry.exploded", []byte(`{}`)))
+}
@@ -0,0 +1,147 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+	"github.com/mwhite7112/woodpantry-pantry/internal/tracing"
+)
+
+// DefaultEventRetryDelay is the wait before a failed inbound event is
+// redelivered.
+const DefaultEventRetryDelay = 10 * time.Second
+
+// ErrMalformedEvent is returned, wrapped, by an EventHandler for a body it
+// cannot decode. The message is dropped instead of retried.
+var ErrMalformedEvent = errors.New("malformed event")
+
+// EventHandler processes one inbound event. messageID is the message ID the
+// publisher set, which stays the same across redeliveries, for
+// deduplication. It is empty when the publisher set none.
+type EventHandler func(ctx context.Context, messageID string, body []byte) error
+
+// Subscriber consumes events another service publishes on the shared
+// exchange through a durable queue of this service's own, so events sent
+// while Pantry is down wait for it. Events are handled one at a time. A
+// failed event is redelivered once after the retry delay and dropped if it
+// fails again.
+type Subscriber struct {
+	url        string
+	queue      string
+	routingKey string
+	handle     EventHandler
+	retryDelay time.Duration
+	log        *slog.Logger
+}
+
+// NewSubscriber creates a subscriber that binds queue to routingKey and
+// passes each event to handle.
+func NewSubscriber(rabbitmqURL, queue, routingKey string, handle EventHandler) *Subscriber {
+	return &Subscriber{
+		url:        rabbitmqURL,
+		queue:      queue,
+		routingKey: routingKey,
+		handle:     handle,
+		retryDelay: DefaultEventRetryDelay,
+		log:        logging.For("subscriber").With("queue", queue),
+	}
+}
+
+// Run consumes until ctx is cancelled, reconnecting after the broker goes
+// away.
+func (s *Subscriber) Run(ctx context.Context) {
+	for {
+		err := s.consume(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		s.log.Warn("subscriber disconnected; reconnecting", "error", err, "delay", consumerReconnectDelay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(consumerReconnectDelay):
+		}
+	}
+}
+
+func (s *Subscriber) consume(ctx context.Context) error {
+	conn, err := dial(s.url)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("open channel: %w", err)
+	}
+	defer ch.Close()
+	if _, err := ch.QueueDeclare(s.queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("declare queue %q: %w", s.queue, err)
+	}
+	if err := ch.QueueBind(s.queue, s.routingKey, exchangeName, false, nil); err != nil {
+		return fmt.Errorf("bind queue %q: %w", s.queue, err)
+	}
+	if err := ch.Qos(1, 0, false); err != nil {
+		return fmt.Errorf("set prefetch: %w", err)
+	}
+	deliveries, err := ch.Consume(s.queue, "", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("consume %s: %w", s.queue, err)
+	}
+	s.log.Info("consuming events", "routing_key", s.routingKey)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case d, ok := <-deliveries:
+			if !ok {
+				return errors.New("delivery channel closed")
+			}
+			s.process(ctx, d)
+		}
+	}
+}
+
+// process handles one delivery and settles it: ack on success, drop a
+// malformed event or a second failure, and requeue a first failure after the
+// retry delay.
+func (s *Subscriber) process(ctx context.Context, d amqp.Delivery) {
+	ctx, span := tracing.Start(tracing.ExtractAMQP(ctx, d.Headers), "process "+s.routingKey,
+		trace.SpanKindConsumer, semconv.MessagingSystemRabbitMQ, semconv.MessagingOperationTypeProcess,
+		semconv.MessagingDestinationName(s.queue))
+	messageID := d.MessageId
+	err := s.handle(ctx, messageID, d.Body)
+	tracing.End(span, err)
+	switch {
+	case err == nil:
+		_ = d.Ack(false)
+		return
+	case ctx.Err() != nil:
+		_ = d.Nack(false, true)
+		return
+	case errors.Is(err, ErrMalformedEvent):
+		s.log.Error("dropping malformed event", "message_id", messageID, "error", err)
+		_ = d.Reject(false)
+		return
+	case d.Redelivered:
+		s.log.Error("event failed again; dropping", "message_id", messageID, "error", err)
+		_ = d.Reject(false)
+		return
+	}
+
+	s.log.Warn("event failed; retrying", "message_id", messageID, "error", err, "delay", s.retryDelay)
+	select {
+	case <-ctx.Done():
+	case <-time.After(s.retryDelay):
+	}
+	_ = d.Nack(false, true)
+}
@@ -0,0 +1,87 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriber_Process(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		redelivered bool
+		handlerErr  error
+		wantOutcome string
+		wantRequeue bool
+	}{
+		{name: "success", wantOutcome: "ack"},
+		{name: "first failure requeues", handlerErr: errors.New("boom"), wantOutcome: "nack", wantRequeue: true},
+		{name: "second failure drops", redelivered: true, handlerErr: errors.New("boom"), wantOutcome: "reject"},
+		{name: "malformed drops", handlerErr: ErrMalformedEvent, wantOutcome: "reject"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewSubscriber(unreachableBroker, "test.queue", "test.key",
+				func(context.Context, string, []byte) error { return tt.handlerErr })
+			s.retryDelay = 0
+			ack := &settled{}
+			s.process(context.Background(), amqp.Delivery{Acknowledger: ack, Redelivered: tt.redelivered, Body: []byte(`{}`)})
+			assert.Equal(t, tt.wantOutcome, ack.outcome)
+			assert.Equal(t, tt.wantRequeue, ack.requeue)
+		})
+	}
+}
+
+func TestSubscriber_PassesPublisherMessageID(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	s := NewSubscriber(unreachableBroker, "test.queue", "test.key",
+		func(_ context.Context, messageID string, _ []byte) error {
+			got = append(got, messageID)
+			return nil
+		})
+	// Without a publisher ID there is nothing to deduplicate on; identical
+	// bodies are separate events.
+	for _, id := range []string{"m-1", "", ""} {
+		s.process(context.Background(), amqp.Delivery{Acknowledger: &settled{}, MessageId: id, Body: []byte(`{}`)})
+	}
+	assert.Equal(t, []string{"m-1", "", ""}, got)
+}
+
+func TestDecodeRecipeCooked(t *testing.T) {
+	t.Parallel()
+
+	ingredient := uuid.New()
+	event, err := decodeRecipeCooked([]byte(`{"recipe_id":"` + uuid.NewString() +
+		`","ingredients":[{"ingredient_id":"` + ingredient.String() + `","quantity":250,"unit":"g"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []CookedIngredient{{IngredientID: ingredient, Quantity: 250, Unit: "g"}}, event.Ingredients)
+
+	_, err = decodeRecipeCooked([]byte(`{"ingredients":[{"quantity":1}]}`))
+	require.ErrorIs(t, err, ErrMalformedEvent)
+	_, err = decodeRecipeCooked([]byte(`not json`))
+	require.ErrorIs(t, err, ErrMalformedEvent)
+}
+
+func TestDecodeDictionaryMerged(t *testing.T) {
+	t.Parallel()
+
+	merged, surviving := uuid.New(), uuid.New()
+	event, err := decodeDictionaryMerged([]byte(`{"merged_id":"` + merged.String() +
+		`","surviving_id":"` + surviving.String() + `"}`))
+	require.NoError(t, err)
+	assert.Equal(t, DictionaryMerged{MergedID: merged, SurvivingID: surviving}, event)
+
+	_, err = decodeDictionaryMerged([]byte(`{"merged_id":"` + merged.String() + `"}`))
+	require.ErrorIs(t, err, ErrMalformedEvent)
+}
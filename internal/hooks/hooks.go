@@ -0,0 +1,118 @@
+// Package hooks runs configurable actions after an ingest job is confirmed.
+// Each action is a Sink; deployments pick sinks in a JSON config file so new
+// automations (webhooks, extra events, spreadsheets) plug in without a
+// bespoke endpoint per integration.
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+// ConfirmEvent describes a completed ingest confirm.
+type ConfirmEvent struct {
+	JobID          uuid.UUID   `json:"job_id"`
+	ChangedItemIDs []uuid.UUID `json:"changed_item_ids"`
+	ConfirmedAt    time.Time   `json:"confirmed_at"`
+}
+
+// Sink is one post-confirm action. Implementations should be safe for
+// concurrent use.
+type Sink interface {
+	Name() string
+	Handle(ctx context.Context, ev ConfirmEvent) error
+}
+
+// defaultSinkTimeout bounds each sink so a slow integration cannot pile up
+// goroutines.
+const defaultSinkTimeout = 10 * time.Second
+
+// Runner fans a confirm event out to every configured sink.
+type Runner struct {
+	sinks   []Sink
+	timeout time.Duration
+	log     *slog.Logger
+}
+
+func NewRunner(sinks ...Sink) *Runner {
+	return &Runner{sinks: sinks, timeout: defaultSinkTimeout, log: logging.For("hooks")}
+}
+
+// OnConfirm runs all sinks in the background so confirm latency does not
+// depend on downstream integrations. Failures are logged, never returned.
+func (r *Runner) OnConfirm(_ context.Context, jobID uuid.UUID, changedItemIDs []uuid.UUID) {
+	if len(r.sinks) == 0 {
+		return
+	}
+	ev := ConfirmEvent{JobID: jobID, ChangedItemIDs: changedItemIDs, ConfirmedAt: time.Now().UTC()}
+	go r.Run(context.Background(), ev)
+}
+
+// Run invokes every sink sequentially, each with its own timeout, and returns
+// the number of sinks that failed.
+func (r *Runner) Run(ctx context.Context, ev ConfirmEvent) int {
+	if ev.ChangedItemIDs == nil {
+		ev.ChangedItemIDs = []uuid.UUID{}
+	}
+	failed := 0
+	for _, s := range r.sinks {
+		sinkCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		err := s.Handle(sinkCtx, ev)
+		cancel()
+		if err != nil {
+			failed++
+			r.log.WarnContext(ctx, "post-confirm hook failed", "sink", s.Name(), "job_id", ev.JobID, "error", err)
+			continue
+		}
+		r.log.DebugContext(ctx, "post-confirm hook ran", "sink", s.Name(), "job_id", ev.JobID)
+	}
+	return failed
+}
+
+// SinkConfig is one entry of the hooks config file.
+type SinkConfig struct {
+	Type       string            `json:"type"`        // webhook | event
+	URL        string            `json:"url"`         // webhook
+	Headers    map[string]string `json:"headers"`     // webhook
+	Secret     string            `json:"secret"`      // webhook; signs payloads when set
+	RoutingKey string            `json:"routing_key"` // event
+}
+
+// Factory builds a Sink from its config entry. Register additional types
+// (e.g. a spreadsheet sink) by adding to the map passed to Load.
+type Factory func(cfg SinkConfig) (Sink, error)
+
+// Load reads a JSON array of SinkConfig from path and builds a sink for each
+// entry using factories keyed by type.
+func Load(path string, factories map[string]Factory) ([]Sink, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read hooks config: %w", err)
+	}
+	var cfgs []SinkConfig
+	if err := json.Unmarshal(raw, &cfgs); err != nil {
+		return nil, fmt.Errorf("parse hooks config: %w", err)
+	}
+
+	sinks := make([]Sink, 0, len(cfgs))
+	for i, cfg := range cfgs {
+		factory, ok := factories[cfg.Type]
+		if !ok {
+			return nil, fmt.Errorf("hooks config entry %d: unknown type %q", i, cfg.Type)
+		}
+		sink, err := factory(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("hooks config entry %d (%s): %w", i, cfg.Type, err)
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
@@ -0,0 +1,156 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/webhook"
+)
+
+type stubSink struct {
+	name   string
+	err    error
+	events []ConfirmEvent
+}
+
+func (s *stubSink) Name() string { return s.name }
+
+func (s *stubSink) Handle(_ context.Context, ev ConfirmEvent) error {
+	s.events = append(s.events, ev)
+	return s.err
+}
+
+func TestRunner_ContinuesPastFailingSink(t *testing.T) {
+	t.Parallel()
+
+	failing := &stubSink{name: "failing", err: errors.New("down")}
+	ok := &stubSink{name: "ok"}
+	r := NewRunner(failing, ok)
+
+	failed := r.Run(context.Background(), ConfirmEvent{JobID: uuid.New()})
+	assert.Equal(t, 1, failed)
+	require.Len(t, ok.events, 1)
+	assert.NotNil(t, ok.events[0].ChangedItemIDs)
+}
+
+func TestWebhookSink_PostsEvent(t *testing.T) {
+	t.Parallel()
+
+	var got ConfirmEvent
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink, err := NewWebhookSink(server.URL, "", map[string]string{"Authorization": "Bearer t"},
+		webhook.NewClient(server.Client(), nil))
+	require.NoError(t, err)
+
+	ev := ConfirmEvent{JobID: uuid.New(), ChangedItemIDs: []uuid.UUID{uuid.New()}}
+	require.NoError(t, sink.Handle(context.Background(), ev))
+	assert.Equal(t, ev.JobID, got.JobID)
+	assert.Equal(t, "Bearer t", auth)
+}
+
+func TestWebhookSink_Non2xxIsError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	sink, err := NewWebhookSink(server.URL, "", nil, webhook.NewClient(server.Client(), nil))
+	require.NoError(t, err)
+	require.ErrorContains(t, sink.Handle(context.Background(), ConfirmEvent{}), "status 502")
+}
+
+type recordedAttempts []webhook.Attempt
+
+func (r *recordedAttempts) RecordAttempt(_ context.Context, a webhook.Attempt) { *r = append(*r, a) }
+
+func TestWebhookSink_SignsAndRecords(t *testing.T) {
+	t.Parallel()
+
+	const secret = "hook-secret"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, webhook.Verify(secret, r.Header, body, time.Now(), time.Minute))
+	}))
+	defer server.Close()
+
+	var attempts recordedAttempts
+	client := webhook.NewClient(server.Client(), &attempts)
+	sink, err := NewWebhookSink(server.URL+"/hook?token=abc", secret, nil, client)
+	require.NoError(t, err)
+	require.NoError(t, sink.Handle(context.Background(), ConfirmEvent{JobID: uuid.New()}))
+
+	require.Len(t, attempts, 1)
+	assert.Equal(t, WebhookSource, attempts[0].Source)
+	assert.Equal(t, server.URL+"/hook", attempts[0].Endpoint)
+	assert.True(t, attempts[0].Signed)
+	assert.Equal(t, http.StatusOK, attempts[0].StatusCode)
+	assert.NoError(t, attempts[0].Err)
+}
+
+type stubPublisher struct {
+	key  string
+	body []byte
+}
+
+func (p *stubPublisher) Publish(_ context.Context, key string, body []byte) error {
+	p.key, p.body = key, body
+	return nil
+}
+
+func TestEventSink_PublishesUnderRoutingKey(t *testing.T) {
+	t.Parallel()
+
+	pub := &stubPublisher{}
+	sink, err := NewEventSink("pantry.ingest.confirmed", pub)
+	require.NoError(t, err)
+
+	jobID := uuid.New()
+	require.NoError(t, sink.Handle(context.Background(), ConfirmEvent{JobID: jobID}))
+	assert.Equal(t, "pantry.ingest.confirmed", pub.key)
+	assert.Contains(t, string(pub.body), jobID.String())
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "hooks.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"type": "webhook", "url": "https://example.test/hook"},
+		{"type": "sheet"}
+	]`), 0o600))
+
+	factories := map[string]Factory{
+		"webhook": func(cfg SinkConfig) (Sink, error) { return NewWebhookSink(cfg.URL, cfg.Secret, cfg.Headers, nil) },
+		"sheet":   func(SinkConfig) (Sink, error) { return &stubSink{name: "sheet"}, nil },
+	}
+	sinks, err := Load(path, factories)
+	require.NoError(t, err)
+	require.Len(t, sinks, 2)
+	assert.Equal(t, "webhook:https://example.test/hook", sinks[0].Name())
+
+	delete(factories, "sheet")
+	_, err = Load(path, factories)
+	require.ErrorContains(t, err, `unknown type "sheet"`)
+}
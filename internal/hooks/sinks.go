@@ -0,0 +1,73 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/webhook"
+)
+
+// WebhookSource names post-confirm hook deliveries in the webhook audit.
+const WebhookSource = "hook"
+
+// WebhookSink POSTs the confirm event as JSON to a URL, signed when the sink
+// has a secret.
+type WebhookSink struct {
+	url     string
+	secret  string
+	headers map[string]string
+	client  *webhook.Client
+}
+
+func NewWebhookSink(url, secret string, headers map[string]string, client *webhook.Client) (*WebhookSink, error) {
+	if url == "" {
+		return nil, errors.New("webhook url is required")
+	}
+	if client == nil {
+		client = webhook.NewClient(nil, nil)
+	}
+	return &WebhookSink{url: url, secret: secret, headers: headers, client: client}, nil
+}
+
+func (s *WebhookSink) Name() string { return "webhook:" + webhook.Redact(s.url) }
+
+func (s *WebhookSink) Handle(ctx context.Context, ev ConfirmEvent) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("marshal confirm event: %w", err)
+	}
+	return s.client.Post(ctx, WebhookSource, s.url, s.secret, s.headers, body)
+}
+
+// Publisher publishes a raw payload to the event bus.
+type Publisher interface {
+	Publish(ctx context.Context, routingKey string, body []byte) error
+}
+
+// EventSink publishes the confirm event under an extra routing key.
+type EventSink struct {
+	routingKey string
+	publisher  Publisher
+}
+
+func NewEventSink(routingKey string, publisher Publisher) (*EventSink, error) {
+	if routingKey == "" {
+		return nil, errors.New("event routing_key is required")
+	}
+	if publisher == nil {
+		return nil, errors.New("event sink requires a message broker")
+	}
+	return &EventSink{routingKey: routingKey, publisher: publisher}, nil
+}
+
+func (s *EventSink) Name() string { return "event:" + s.routingKey }
+
+func (s *EventSink) Handle(ctx context.Context, ev ConfirmEvent) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("marshal confirm event: %w", err)
+	}
+	return s.publisher.Publish(ctx, s.routingKey, body)
+}
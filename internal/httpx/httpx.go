@@ -0,0 +1,310 @@
+// Package httpx builds the outbound HTTP clients, one per dependency, each
+// with its own timeout, connection pool, proxy and retry policy. Every client
+// logs its calls under the "httpx" module and identifies the service in
+// User-Agent.
+package httpx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+// Dependency names an outbound service.
+type Dependency string
+
+const (
+	Dictionary Dependency = "dictionary"
+	OpenAI     Dependency = "openai"
+	Anthropic  Dependency = "anthropic"
+	LocalLLM   Dependency = "local_llm" // a self-hosted OpenAI-compatible model server
+	Webhooks   Dependency = "webhooks"
+	Nutrition  Dependency = "nutrition"
+	// IdentityProvider is the JWKS endpoint bearer tokens are checked against.
+	IdentityProvider Dependency = "identity"
+)
+
+// Profile tunes the client for one dependency.
+type Profile struct {
+	// Timeout bounds a whole call, retries included.
+	Timeout time.Duration
+	// MaxIdleConnsPerHost and MaxConnsPerHost size the connection pool;
+	// zero MaxConnsPerHost means unlimited.
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	// Retries is how many times a GET or HEAD is retried after a transport
+	// error or a 502, 503 or 504. Other methods are never retried.
+	Retries int
+	// RetryBackoff is the wait before the first retry, doubled for each one
+	// after.
+	RetryBackoff time.Duration
+	// Proxy overrides the HTTPS_PROXY/HTTP_PROXY/NO_PROXY environment for
+	// this dependency; see WithProxy.
+	Proxy func(*http.Request) (*url.URL, error)
+}
+
+// DefaultProfiles are the built-in tunings. Dictionary and the identity
+// provider are few hosts called often, so they keep more idle connections;
+// webhooks go to many hosts that each get a small pool and no retries, as
+// their receivers are not required to be idempotent.
+var DefaultProfiles = map[Dependency]Profile{
+	Dictionary: {
+		Timeout: 30 * time.Second, MaxIdleConnsPerHost: 16, MaxConnsPerHost: 32,
+		Retries: 2, RetryBackoff: 100 * time.Millisecond,
+	},
+	OpenAI:    {Timeout: 60 * time.Second, MaxIdleConnsPerHost: 8, MaxConnsPerHost: 16},
+	Anthropic: {Timeout: 60 * time.Second, MaxIdleConnsPerHost: 8, MaxConnsPerHost: 16},
+	Webhooks:  {Timeout: 10 * time.Second, MaxIdleConnsPerHost: 2, MaxConnsPerHost: 4},
+	// A local model is slower than a hosted one and serves few requests at
+	// a time.
+	LocalLLM: {Timeout: 90 * time.Second, MaxIdleConnsPerHost: 4, MaxConnsPerHost: 4},
+	Nutrition: {
+		Timeout: 15 * time.Second, MaxIdleConnsPerHost: 4, MaxConnsPerHost: 8,
+		Retries: 2, RetryBackoff: 200 * time.Millisecond,
+	},
+	IdentityProvider: {
+		Timeout: 10 * time.Second, MaxIdleConnsPerHost: 2, MaxConnsPerHost: 4,
+		Retries: 2, RetryBackoff: 200 * time.Millisecond,
+	},
+}
+
+// defaultProfile applies to a dependency with no entry in DefaultProfiles.
+var defaultProfile = Profile{Timeout: 30 * time.Second, MaxIdleConnsPerHost: 2}
+
+// Middleware wraps a dependency's transport, e.g. with fault injection. It
+// runs below the retry loop, so each attempt passes through it.
+type Middleware func(Dependency, http.RoundTripper) http.RoundTripper
+
+// Factory hands out one shared client per dependency.
+type Factory struct {
+	profiles   map[Dependency]Profile
+	middleware []Middleware
+	userAgent  string
+
+	mu      sync.Mutex
+	clients map[Dependency]*http.Client
+}
+
+// Option configures a Factory.
+type Option func(*Factory)
+
+// WithTimeout overrides dep's timeout.
+func WithTimeout(dep Dependency, timeout time.Duration) Option {
+	return func(f *Factory) {
+		p := f.profile(dep)
+		p.Timeout = timeout
+		f.profiles[dep] = p
+	}
+}
+
+// WithProxy sends dep's calls through proxy, or directly when proxy is nil,
+// instead of the proxy named by the environment.
+func WithProxy(dep Dependency, proxy *url.URL) Option {
+	return func(f *Factory) {
+		p := f.profile(dep)
+		p.Proxy = func(*http.Request) (*url.URL, error) { return proxy, nil }
+		f.profiles[dep] = p
+	}
+}
+
+// WithMiddleware wraps every dependency's transport with m.
+func WithMiddleware(m Middleware) Option {
+	return func(f *Factory) { f.middleware = append(f.middleware, m) }
+}
+
+// NewFactory returns a Factory starting from DefaultProfiles.
+func NewFactory(opts ...Option) *Factory {
+	f := &Factory{
+		profiles:  make(map[Dependency]Profile, len(DefaultProfiles)),
+		userAgent: "woodpantry-pantry/" + logging.Version,
+		clients:   map[Dependency]*http.Client{},
+	}
+	for dep, p := range DefaultProfiles {
+		f.profiles[dep] = p
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// Client returns dep's client, building it on first use. Callers share it,
+// so they must not modify it.
+func (f *Factory) Client(dep Dependency) *http.Client {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if c, ok := f.clients[dep]; ok {
+		return c
+	}
+	p := f.profile(dep)
+	proxy := p.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.Proxy = proxy
+	base.MaxIdleConnsPerHost = p.MaxIdleConnsPerHost
+	base.MaxConnsPerHost = p.MaxConnsPerHost
+
+	var rt http.RoundTripper = base
+	for _, m := range f.middleware {
+		rt = m(dep, rt)
+	}
+	rt = &instrumented{dep: dep, next: rt, userAgent: f.userAgent}
+	if p.Retries > 0 {
+		rt = &retrying{dep: dep, next: rt, retries: p.Retries, backoff: p.RetryBackoff}
+	}
+	c := &http.Client{Timeout: p.Timeout, Transport: rt}
+	f.clients[dep] = c
+	return c
+}
+
+func (f *Factory) profile(dep Dependency) Profile {
+	if p, ok := f.profiles[dep]; ok {
+		return p
+	}
+	return defaultProfile
+}
+
+// ParseTimeouts reads per-dependency timeouts such as
+//
+//	dictionary=5s,openai=90s
+func ParseTimeouts(spec string) ([]Option, error) {
+	var opts []Option
+	err := parseSpec(spec, func(dep Dependency, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("timeout %q: must be a positive duration", value)
+		}
+		opts = append(opts, WithTimeout(dep, d))
+		return nil
+	})
+	return opts, err
+}
+
+// ParseProxies reads per-dependency proxies such as
+//
+//	openai=http://egress:3128,dictionary=direct
+//
+// where "direct" bypasses any proxy set in the environment.
+func ParseProxies(spec string) ([]Option, error) {
+	var opts []Option
+	err := parseSpec(spec, func(dep Dependency, value string) error {
+		if value == "direct" {
+			opts = append(opts, WithProxy(dep, nil))
+			return nil
+		}
+		u, err := url.Parse(value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("proxy %q: must be an absolute URL or \"direct\"", value)
+		}
+		opts = append(opts, WithProxy(dep, u))
+		return nil
+	})
+	return opts, err
+}
+
+func parseSpec(spec string, apply func(Dependency, string) error) error {
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("%q: want dependency=value", entry)
+		}
+		dep := Dependency(strings.TrimSpace(name))
+		if _, known := DefaultProfiles[dep]; !known {
+			return fmt.Errorf("%q: unknown dependency %q", entry, dep)
+		}
+		if err := apply(dep, strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("%s: %w", dep, err)
+		}
+	}
+	return nil
+}
+
+// instrumented logs each attempt and sets User-Agent when the caller has
+// not.
+type instrumented struct {
+	dep       Dependency
+	next      http.RoundTripper
+	userAgent string
+}
+
+func (t *instrumented) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Header.Get("User-Agent") == "" {
+		r = r.Clone(r.Context())
+		r.Header.Set("User-Agent", t.userAgent)
+	}
+	start := time.Now()
+	resp, err := t.next.RoundTrip(r)
+	log := logging.For("httpx").With(
+		"dependency", t.dep, "method", r.Method, "host", r.URL.Host,
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+	if err != nil {
+		log.DebugContext(r.Context(), "outbound call failed", "error", err)
+		return nil, err
+	}
+	log.DebugContext(r.Context(), "outbound call", "status", resp.StatusCode)
+	return resp, nil
+}
+
+// retrying retries idempotent requests after transport errors and gateway
+// statuses.
+type retrying struct {
+	dep     Dependency
+	next    http.RoundTripper
+	retries int
+	backoff time.Duration
+}
+
+func (t *retrying) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return t.next.RoundTrip(r)
+	}
+	wait := t.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(r)
+		if attempt == t.retries || !retryable(r.Context(), resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body) //nolint:errcheck
+			resp.Body.Close()
+		}
+		logging.For("httpx").WarnContext(r.Context(), "retrying outbound call",
+			"dependency", t.dep, "method", r.Method, "host", r.URL.Host, "attempt", attempt+1, "error", err)
+		select {
+		case <-time.After(wait):
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		}
+		wait *= 2
+	}
+}
+
+// retryable reports whether an attempt may be repeated: any transport error,
+// since GET and HEAD are safe to resend, or a gateway status.
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
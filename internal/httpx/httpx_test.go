@@ -0,0 +1,116 @@
+package httpx
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flaky answers 503 to the first failures requests, then 200.
+func flaky(t *testing.T, failures int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(r.Header.Get("User-Agent"))) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestClient_RetriesIdempotentRequests(t *testing.T) {
+	t.Parallel()
+
+	srv, calls := flaky(t, 2)
+	c := NewFactory().Client(Dictionary)
+	resp, err := c.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(body), "woodpantry-pantry/"), string(body))
+}
+
+func TestClient_DoesNotRetryPosts(t *testing.T) {
+	t.Parallel()
+
+	srv, calls := flaky(t, 1)
+	resp, err := NewFactory().Client(Dictionary).Post(srv.URL, "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClient_GivesUpAfterRetries(t *testing.T) {
+	t.Parallel()
+
+	srv, calls := flaky(t, 10)
+	resp, err := NewFactory().Client(IdentityProvider).Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(DefaultProfiles[IdentityProvider].Retries+1), calls.Load())
+}
+
+func TestFactory_OptionsAndMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var wrapped []Dependency
+	f := NewFactory(
+		WithTimeout(OpenAI, 90*time.Second),
+		WithMiddleware(func(dep Dependency, rt http.RoundTripper) http.RoundTripper {
+			wrapped = append(wrapped, dep)
+			return rt
+		}),
+	)
+	c := f.Client(OpenAI)
+	assert.Same(t, c, f.Client(OpenAI), "clients are shared")
+	assert.Equal(t, 90*time.Second, c.Timeout)
+	assert.Equal(t, DefaultProfiles[Webhooks].Timeout, f.Client(Webhooks).Timeout)
+	assert.Equal(t, []Dependency{OpenAI, Webhooks}, wrapped)
+}
+
+func TestParseTimeoutsAndProxies(t *testing.T) {
+	t.Parallel()
+
+	timeouts, err := ParseTimeouts("dictionary=5s, openai=90s")
+	require.NoError(t, err)
+	f := NewFactory(timeouts...)
+	assert.Equal(t, 5*time.Second, f.Client(Dictionary).Timeout)
+	assert.Equal(t, 90*time.Second, f.Client(OpenAI).Timeout)
+
+	proxies, err := ParseProxies("openai=http://egress:3128,dictionary=direct")
+	require.NoError(t, err)
+	f = NewFactory(proxies...)
+	req := httptest.NewRequest(http.MethodGet, "https://api.openai.com", nil)
+	got, err := f.profiles[OpenAI].Proxy(req)
+	require.NoError(t, err)
+	assert.Equal(t, &url.URL{Scheme: "http", Host: "egress:3128"}, got)
+	got, err = f.profiles[Dictionary].Proxy(req)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	for _, bad := range []string{"redis=5s", "openai=soon", "openai=-1s", "openai"} {
+		_, err := ParseTimeouts(bad)
+		assert.Error(t, err, bad)
+	}
+	for _, bad := range []string{"openai=egress", "smtp=http://x:1"} {
+		_, err := ParseProxies(bad)
+		assert.Error(t, err, bad)
+	}
+}
@@ -1,28 +1,188 @@
 package logging
 
 import (
+	"bufio"
+	"context"
+	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
+
+	"github.com/google/uuid"
+)
+
+// Version is the service version stamped on every record. Override at build
+// time with -ldflags "-X github.com/mwhite7112/woodpantry-pantry/internal/logging.Version=...".
+var Version = "dev"
+
+// config holds the settings parsed from the environment by Setup.
+type config struct {
+	level        slog.Level
+	moduleLevels map[string]slog.Level
+	sampleEvery  uint64
+}
+
+var (
+	// base is the unfiltered handler that module loggers wrap. It is nil until
+	// Setup runs, in which case loggers fall back to slog.Default.
+	base    slog.Handler
+	current = config{level: slog.LevelInfo, sampleEvery: 1}
 )
 
-// Setup configures the global slog default with a JSON handler at the level
-// specified by the LOG_LEVEL environment variable (debug, info, warn, error).
+// Setup configures the global slog default from the environment:
+//
+//   - LOG_LEVEL: debug, info, warn, error (default info)
+//   - LOG_FORMAT: json (default) or text
+//   - LOG_MODULE_LEVELS: per-module overrides, e.g. "ingest=debug,events=warn"
+//   - LOG_DEBUG_SAMPLE_RATE: keep 1 in N debug records from sampled loggers
+//
+// Every record carries the service name, Version, and an instance ID taken
+// from HOSTNAME (the pod name in Kubernetes) or a random UUID.
 func Setup() {
-	level := slog.LevelInfo
-	switch strings.ToLower(os.Getenv("LOG_LEVEL")) {
+	setup(os.Stdout, os.Getenv)
+}
+
+func setup(w io.Writer, getenv func(string) string) {
+	cfg := config{
+		level:        parseLevel(getenv("LOG_LEVEL"), slog.LevelInfo),
+		moduleLevels: parseModuleLevels(getenv("LOG_MODULE_LEVELS")),
+		sampleEvery:  1,
+	}
+	if n, err := strconv.ParseUint(getenv("LOG_DEBUG_SAMPLE_RATE"), 10, 64); err == nil && n > 1 {
+		cfg.sampleEvery = n
+	}
+
+	// The shared handler admits the most verbose configured level; per-logger
+	// levelHandlers apply the effective level for each module.
+	minLevel := cfg.level
+	for _, l := range cfg.moduleLevels {
+		minLevel = min(minLevel, l)
+	}
+	opts := &slog.HandlerOptions{Level: minLevel}
+
+	var h slog.Handler
+	if strings.EqualFold(getenv("LOG_FORMAT"), "text") {
+		h = slog.NewTextHandler(w, opts)
+	} else {
+		h = slog.NewJSONHandler(w, opts)
+	}
+
+	instanceID := getenv("HOSTNAME")
+	if instanceID == "" {
+		instanceID = uuid.NewString()
+	}
+	h = h.WithAttrs([]slog.Attr{
+		slog.String("service", "pantry"),
+		slog.String("version", Version),
+		slog.String("instance_id", instanceID),
+	})
+
+	base = h
+	current = cfg
+	slog.SetDefault(slog.New(&levelHandler{next: h, level: cfg.level}))
+}
+
+func parseLevel(s string, def slog.Level) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(s)) {
 	case "debug":
-		level = slog.LevelDebug
+		return slog.LevelDebug
+	case "info":
+		return slog.LevelInfo
 	case "warn":
-		level = slog.LevelWarn
+		return slog.LevelWarn
 	case "error":
-		level = slog.LevelError
+		return slog.LevelError
+	}
+	return def
+}
+
+func parseModuleLevels(s string) map[string]slog.Level {
+	levels := make(map[string]slog.Level)
+	for pair := range strings.SplitSeq(s, ",") {
+		module, level, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		levels[strings.TrimSpace(module)] = parseLevel(level, slog.LevelInfo)
 	}
-	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: level,
-	})))
+	return levels
+}
+
+// For returns a logger tagged with module and filtered at that module's
+// configured level (LOG_MODULE_LEVELS, falling back to LOG_LEVEL).
+func For(module string) *slog.Logger {
+	next := base
+	if next == nil {
+		next = slog.Default().Handler()
+	}
+	level, ok := current.moduleLevels[module]
+	if !ok {
+		level = current.level
+	}
+	return slog.New(&levelHandler{next: next, level: level}).With("module", module)
+}
+
+// Sampled wraps l so that only 1 in LOG_DEBUG_SAMPLE_RATE debug records is
+// emitted. Info and above always pass. Use it for per-item logging in hot
+// loops such as the ingest worker.
+func Sampled(l *slog.Logger) *slog.Logger {
+	if current.sampleEvery <= 1 {
+		return l
+	}
+	return slog.New(&samplingHandler{next: l.Handler(), every: current.sampleEvery, counter: new(atomic.Uint64)})
+}
+
+// levelHandler gates records below level before delegating to next.
+type levelHandler struct {
+	next  slog.Handler
+	level slog.Level
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return l >= h.level && h.next.Enabled(ctx, l)
+}
+
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{next: h.next.WithAttrs(attrs), level: h.level}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{next: h.next.WithGroup(name), level: h.level}
+}
+
+// samplingHandler drops all but every Nth debug record.
+type samplingHandler struct {
+	next    slog.Handler
+	every   uint64
+	counter *atomic.Uint64
+}
+
+func (h *samplingHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return h.next.Enabled(ctx, l)
+}
+
+func (h *samplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level <= slog.LevelDebug && (h.counter.Add(1)-1)%h.every != 0 {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{next: h.next.WithAttrs(attrs), every: h.every, counter: h.counter}
+}
+
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{next: h.next.WithGroup(name), every: h.every, counter: h.counter}
 }
 
 // responseWriter wraps [http.ResponseWriter] to capture the status code.
@@ -37,11 +197,25 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Hijack passes upgrades such as WebSockets through to the connection,
+// which the logged status then reports as 101.
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, buf, err := http.NewResponseController(rw.ResponseWriter).Hijack()
+	if err == nil {
+		rw.status = http.StatusSwitchingProtocols
+	}
+	return conn, buf, err
+}
+
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // Middleware is a chi-compatible HTTP request logger.
-// It skips /healthz to avoid Kubernetes probe noise.
+// It skips /healthz and /readyz to avoid Kubernetes probe noise.
 func Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == "/healthz" {
+		if r.URL.Path == "/healthz" || r.URL.Path == "/readyz" {
 			next.ServeHTTP(w, r)
 			return
 		}
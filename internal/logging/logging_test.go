@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envFunc(env map[string]string) func(string) string {
+	return func(k string) string { return env[k] }
+}
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+	for line := range strings.Lines(buf.String()) {
+		var rec map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		records = append(records, rec)
+	}
+	return records
+}
+
+// Setup mutates package state, so these tests do not run in parallel.
+
+func TestSetup_StampsServiceMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	setup(&buf, envFunc(map[string]string{"HOSTNAME": "pantry-abc"}))
+
+	For("api").Info("hello")
+
+	records := decodeLines(t, &buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "pantry", records[0]["service"])
+	assert.Equal(t, Version, records[0]["version"])
+	assert.Equal(t, "pantry-abc", records[0]["instance_id"])
+	assert.Equal(t, "api", records[0]["module"])
+}
+
+func TestSetup_ModuleLevelOverrides(t *testing.T) {
+	var buf bytes.Buffer
+	setup(&buf, envFunc(map[string]string{
+		"LOG_LEVEL":         "warn",
+		"LOG_MODULE_LEVELS": "ingest=debug",
+	}))
+
+	For("ingest").Debug("ingest debug")
+	For("pantry").Info("pantry info")
+
+	records := decodeLines(t, &buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "ingest debug", records[0]["msg"])
+}
+
+func TestSetup_TextFormat(t *testing.T) {
+	var buf bytes.Buffer
+	setup(&buf, envFunc(map[string]string{"LOG_FORMAT": "text"}))
+
+	For("api").Info("hello")
+
+	assert.Contains(t, buf.String(), "msg=hello")
+	assert.Contains(t, buf.String(), "module=api")
+}
+
+func TestSampled_KeepsOneInN(t *testing.T) {
+	var buf bytes.Buffer
+	setup(&buf, envFunc(map[string]string{
+		"LOG_LEVEL":             "debug",
+		"LOG_DEBUG_SAMPLE_RATE": "5",
+	}))
+
+	log := Sampled(For("ingest"))
+	for range 10 {
+		log.Debug("item")
+	}
+	log.Info("done")
+
+	records := decodeLines(t, &buf)
+	assert.Len(t, records, 3)
+}
@@ -4,6 +4,8 @@ package mocks
 
 import (
 	context "context"
+	sql "database/sql"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	db "github.com/mwhite7112/woodpantry-pantry/internal/db"
@@ -23,83 +25,26 @@ func (_m *MockQuerier) EXPECT() *MockQuerier_Expecter {
 	return &MockQuerier_Expecter{mock: &_m.Mock}
 }
 
-// CreateIngestionJob provides a mock function with given fields: ctx, arg
-func (_m *MockQuerier) CreateIngestionJob(ctx context.Context, arg db.CreateIngestionJobParams) (db.IngestionJob, error) {
-	ret := _m.Called(ctx, arg)
-
-	if len(ret) == 0 {
-		panic("no return value specified for CreateIngestionJob")
-	}
-
-	var r0 db.IngestionJob
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateIngestionJobParams) (db.IngestionJob, error)); ok {
-		return rf(ctx, arg)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateIngestionJobParams) db.IngestionJob); ok {
-		r0 = rf(ctx, arg)
-	} else {
-		r0 = ret.Get(0).(db.IngestionJob)
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateIngestionJobParams) error); ok {
-		r1 = rf(ctx, arg)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// MockQuerier_CreateIngestionJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIngestionJob'
-type MockQuerier_CreateIngestionJob_Call struct {
-	*mock.Call
-}
-
-// CreateIngestionJob is a helper method to define mock.On call
-//   - ctx context.Context
-//   - arg db.CreateIngestionJobParams
-func (_e *MockQuerier_Expecter) CreateIngestionJob(ctx interface{}, arg interface{}) *MockQuerier_CreateIngestionJob_Call {
-	return &MockQuerier_CreateIngestionJob_Call{Call: _e.mock.On("CreateIngestionJob", ctx, arg)}
-}
-
-func (_c *MockQuerier_CreateIngestionJob_Call) Run(run func(ctx context.Context, arg db.CreateIngestionJobParams)) *MockQuerier_CreateIngestionJob_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.CreateIngestionJobParams))
-	})
-	return _c
-}
-
-func (_c *MockQuerier_CreateIngestionJob_Call) Return(_a0 db.IngestionJob, _a1 error) *MockQuerier_CreateIngestionJob_Call {
-	_c.Call.Return(_a0, _a1)
-	return _c
-}
-
-func (_c *MockQuerier_CreateIngestionJob_Call) RunAndReturn(run func(context.Context, db.CreateIngestionJobParams) (db.IngestionJob, error)) *MockQuerier_CreateIngestionJob_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// CreateStagedItem provides a mock function with given fields: ctx, arg
-func (_m *MockQuerier) CreateStagedItem(ctx context.Context, arg db.CreateStagedItemParams) (db.StagedItem, error) {
+// AddLLMUsage provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) AddLLMUsage(ctx context.Context, arg db.AddLLMUsageParams) (db.LlmUsage, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateStagedItem")
+		panic("no return value specified for AddLLMUsage")
 	}
 
-	var r0 db.StagedItem
+	var r0 db.LlmUsage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStagedItemParams) (db.StagedItem, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddLLMUsageParams) (db.LlmUsage, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStagedItemParams) db.StagedItem); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.AddLLMUsageParams) db.LlmUsage); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.StagedItem)
+		r0 = ret.Get(0).(db.LlmUsage)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.CreateStagedItemParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.AddLLMUsageParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -108,41 +53,41 @@ func (_m *MockQuerier) CreateStagedItem(ctx context.Context, arg db.CreateStaged
 	return r0, r1
 }
 
-// MockQuerier_CreateStagedItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStagedItem'
-type MockQuerier_CreateStagedItem_Call struct {
+// MockQuerier_AddLLMUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddLLMUsage'
+type MockQuerier_AddLLMUsage_Call struct {
 	*mock.Call
 }
 
-// CreateStagedItem is a helper method to define mock.On call
+// AddLLMUsage is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.CreateStagedItemParams
-func (_e *MockQuerier_Expecter) CreateStagedItem(ctx interface{}, arg interface{}) *MockQuerier_CreateStagedItem_Call {
-	return &MockQuerier_CreateStagedItem_Call{Call: _e.mock.On("CreateStagedItem", ctx, arg)}
+//   - arg db.AddLLMUsageParams
+func (_e *MockQuerier_Expecter) AddLLMUsage(ctx interface{}, arg interface{}) *MockQuerier_AddLLMUsage_Call {
+	return &MockQuerier_AddLLMUsage_Call{Call: _e.mock.On("AddLLMUsage", ctx, arg)}
 }
 
-func (_c *MockQuerier_CreateStagedItem_Call) Run(run func(ctx context.Context, arg db.CreateStagedItemParams)) *MockQuerier_CreateStagedItem_Call {
+func (_c *MockQuerier_AddLLMUsage_Call) Run(run func(ctx context.Context, arg db.AddLLMUsageParams)) *MockQuerier_AddLLMUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.CreateStagedItemParams))
+		run(args[0].(context.Context), args[1].(db.AddLLMUsageParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_CreateStagedItem_Call) Return(_a0 db.StagedItem, _a1 error) *MockQuerier_CreateStagedItem_Call {
+func (_c *MockQuerier_AddLLMUsage_Call) Return(_a0 db.LlmUsage, _a1 error) *MockQuerier_AddLLMUsage_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_CreateStagedItem_Call) RunAndReturn(run func(context.Context, db.CreateStagedItemParams) (db.StagedItem, error)) *MockQuerier_CreateStagedItem_Call {
+func (_c *MockQuerier_AddLLMUsage_Call) RunAndReturn(run func(context.Context, db.AddLLMUsageParams) (db.LlmUsage, error)) *MockQuerier_AddLLMUsage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteAllPantryItems provides a mock function with given fields: ctx
-func (_m *MockQuerier) DeleteAllPantryItems(ctx context.Context) error {
+// AnalyzeTables provides a mock function with given fields: ctx
+func (_m *MockQuerier) AnalyzeTables(ctx context.Context) error {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteAllPantryItems")
+		panic("no return value specified for AnalyzeTables")
 	}
 
 	var r0 error
@@ -155,102 +100,114 @@ func (_m *MockQuerier) DeleteAllPantryItems(ctx context.Context) error {
 	return r0
 }
 
-// MockQuerier_DeleteAllPantryItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAllPantryItems'
-type MockQuerier_DeleteAllPantryItems_Call struct {
+// MockQuerier_AnalyzeTables_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnalyzeTables'
+type MockQuerier_AnalyzeTables_Call struct {
 	*mock.Call
 }
 
-// DeleteAllPantryItems is a helper method to define mock.On call
+// AnalyzeTables is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockQuerier_Expecter) DeleteAllPantryItems(ctx interface{}) *MockQuerier_DeleteAllPantryItems_Call {
-	return &MockQuerier_DeleteAllPantryItems_Call{Call: _e.mock.On("DeleteAllPantryItems", ctx)}
+func (_e *MockQuerier_Expecter) AnalyzeTables(ctx interface{}) *MockQuerier_AnalyzeTables_Call {
+	return &MockQuerier_AnalyzeTables_Call{Call: _e.mock.On("AnalyzeTables", ctx)}
 }
 
-func (_c *MockQuerier_DeleteAllPantryItems_Call) Run(run func(ctx context.Context)) *MockQuerier_DeleteAllPantryItems_Call {
+func (_c *MockQuerier_AnalyzeTables_Call) Run(run func(ctx context.Context)) *MockQuerier_AnalyzeTables_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_DeleteAllPantryItems_Call) Return(_a0 error) *MockQuerier_DeleteAllPantryItems_Call {
+func (_c *MockQuerier_AnalyzeTables_Call) Return(_a0 error) *MockQuerier_AnalyzeTables_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockQuerier_DeleteAllPantryItems_Call) RunAndReturn(run func(context.Context) error) *MockQuerier_DeleteAllPantryItems_Call {
+func (_c *MockQuerier_AnalyzeTables_Call) RunAndReturn(run func(context.Context) error) *MockQuerier_AnalyzeTables_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeletePantryItem provides a mock function with given fields: ctx, id
-func (_m *MockQuerier) DeletePantryItem(ctx context.Context, id uuid.UUID) error {
+// CancelIngestionJob provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) CancelIngestionJob(ctx context.Context, id uuid.UUID) (db.IngestionJob, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeletePantryItem")
+		panic("no return value specified for CancelIngestionJob")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+	var r0 db.IngestionJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.IngestionJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.IngestionJob); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.IngestionJob)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockQuerier_DeletePantryItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePantryItem'
-type MockQuerier_DeletePantryItem_Call struct {
+// MockQuerier_CancelIngestionJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelIngestionJob'
+type MockQuerier_CancelIngestionJob_Call struct {
 	*mock.Call
 }
 
-// DeletePantryItem is a helper method to define mock.On call
+// CancelIngestionJob is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id uuid.UUID
-func (_e *MockQuerier_Expecter) DeletePantryItem(ctx interface{}, id interface{}) *MockQuerier_DeletePantryItem_Call {
-	return &MockQuerier_DeletePantryItem_Call{Call: _e.mock.On("DeletePantryItem", ctx, id)}
+func (_e *MockQuerier_Expecter) CancelIngestionJob(ctx interface{}, id interface{}) *MockQuerier_CancelIngestionJob_Call {
+	return &MockQuerier_CancelIngestionJob_Call{Call: _e.mock.On("CancelIngestionJob", ctx, id)}
 }
 
-func (_c *MockQuerier_DeletePantryItem_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_DeletePantryItem_Call {
+func (_c *MockQuerier_CancelIngestionJob_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_CancelIngestionJob_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_DeletePantryItem_Call) Return(_a0 error) *MockQuerier_DeletePantryItem_Call {
-	_c.Call.Return(_a0)
+func (_c *MockQuerier_CancelIngestionJob_Call) Return(_a0 db.IngestionJob, _a1 error) *MockQuerier_CancelIngestionJob_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_DeletePantryItem_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_DeletePantryItem_Call {
+func (_c *MockQuerier_CancelIngestionJob_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.IngestionJob, error)) *MockQuerier_CancelIngestionJob_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetIngestionJob provides a mock function with given fields: ctx, id
-func (_m *MockQuerier) GetIngestionJob(ctx context.Context, id uuid.UUID) (db.IngestionJob, error) {
-	ret := _m.Called(ctx, id)
+// ClaimDueWebhookEvents provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ClaimDueWebhookEvents(ctx context.Context, arg db.ClaimDueWebhookEventsParams) ([]db.ClaimDueWebhookEventsRow, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetIngestionJob")
+		panic("no return value specified for ClaimDueWebhookEvents")
 	}
 
-	var r0 db.IngestionJob
+	var r0 []db.ClaimDueWebhookEventsRow
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.IngestionJob, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ClaimDueWebhookEventsParams) ([]db.ClaimDueWebhookEventsRow, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.IngestionJob); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ClaimDueWebhookEventsParams) []db.ClaimDueWebhookEventsRow); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.IngestionJob)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ClaimDueWebhookEventsRow)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, db.ClaimDueWebhookEventsParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -258,56 +215,56 @@ func (_m *MockQuerier) GetIngestionJob(ctx context.Context, id uuid.UUID) (db.In
 	return r0, r1
 }
 
-// MockQuerier_GetIngestionJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIngestionJob'
-type MockQuerier_GetIngestionJob_Call struct {
+// MockQuerier_ClaimDueWebhookEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDueWebhookEvents'
+type MockQuerier_ClaimDueWebhookEvents_Call struct {
 	*mock.Call
 }
 
-// GetIngestionJob is a helper method to define mock.On call
+// ClaimDueWebhookEvents is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *MockQuerier_Expecter) GetIngestionJob(ctx interface{}, id interface{}) *MockQuerier_GetIngestionJob_Call {
-	return &MockQuerier_GetIngestionJob_Call{Call: _e.mock.On("GetIngestionJob", ctx, id)}
+//   - arg db.ClaimDueWebhookEventsParams
+func (_e *MockQuerier_Expecter) ClaimDueWebhookEvents(ctx interface{}, arg interface{}) *MockQuerier_ClaimDueWebhookEvents_Call {
+	return &MockQuerier_ClaimDueWebhookEvents_Call{Call: _e.mock.On("ClaimDueWebhookEvents", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetIngestionJob_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_GetIngestionJob_Call {
+func (_c *MockQuerier_ClaimDueWebhookEvents_Call) Run(run func(ctx context.Context, arg db.ClaimDueWebhookEventsParams)) *MockQuerier_ClaimDueWebhookEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(db.ClaimDueWebhookEventsParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_GetIngestionJob_Call) Return(_a0 db.IngestionJob, _a1 error) *MockQuerier_GetIngestionJob_Call {
+func (_c *MockQuerier_ClaimDueWebhookEvents_Call) Return(_a0 []db.ClaimDueWebhookEventsRow, _a1 error) *MockQuerier_ClaimDueWebhookEvents_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_GetIngestionJob_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.IngestionJob, error)) *MockQuerier_GetIngestionJob_Call {
+func (_c *MockQuerier_ClaimDueWebhookEvents_Call) RunAndReturn(run func(context.Context, db.ClaimDueWebhookEventsParams) ([]db.ClaimDueWebhookEventsRow, error)) *MockQuerier_ClaimDueWebhookEvents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPantryItem provides a mock function with given fields: ctx, id
-func (_m *MockQuerier) GetPantryItem(ctx context.Context, id uuid.UUID) (db.PantryItem, error) {
-	ret := _m.Called(ctx, id)
+// ClaimIngestionJob provides a mock function with given fields: ctx, retryDelaySeconds
+func (_m *MockQuerier) ClaimIngestionJob(ctx context.Context, retryDelaySeconds float64) (db.ClaimIngestionJobRow, error) {
+	ret := _m.Called(ctx, retryDelaySeconds)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPantryItem")
+		panic("no return value specified for ClaimIngestionJob")
 	}
 
-	var r0 db.PantryItem
+	var r0 db.ClaimIngestionJobRow
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.PantryItem, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, float64) (db.ClaimIngestionJobRow, error)); ok {
+		return rf(ctx, retryDelaySeconds)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.PantryItem); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, float64) db.ClaimIngestionJobRow); ok {
+		r0 = rf(ctx, retryDelaySeconds)
 	} else {
-		r0 = ret.Get(0).(db.PantryItem)
+		r0 = ret.Get(0).(db.ClaimIngestionJobRow)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, float64) error); ok {
+		r1 = rf(ctx, retryDelaySeconds)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -315,56 +272,56 @@ func (_m *MockQuerier) GetPantryItem(ctx context.Context, id uuid.UUID) (db.Pant
 	return r0, r1
 }
 
-// MockQuerier_GetPantryItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPantryItem'
-type MockQuerier_GetPantryItem_Call struct {
+// MockQuerier_ClaimIngestionJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimIngestionJob'
+type MockQuerier_ClaimIngestionJob_Call struct {
 	*mock.Call
 }
 
-// GetPantryItem is a helper method to define mock.On call
+// ClaimIngestionJob is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *MockQuerier_Expecter) GetPantryItem(ctx interface{}, id interface{}) *MockQuerier_GetPantryItem_Call {
-	return &MockQuerier_GetPantryItem_Call{Call: _e.mock.On("GetPantryItem", ctx, id)}
+//   - retryDelaySeconds float64
+func (_e *MockQuerier_Expecter) ClaimIngestionJob(ctx interface{}, retryDelaySeconds interface{}) *MockQuerier_ClaimIngestionJob_Call {
+	return &MockQuerier_ClaimIngestionJob_Call{Call: _e.mock.On("ClaimIngestionJob", ctx, retryDelaySeconds)}
 }
 
-func (_c *MockQuerier_GetPantryItem_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_GetPantryItem_Call {
+func (_c *MockQuerier_ClaimIngestionJob_Call) Run(run func(ctx context.Context, retryDelaySeconds float64)) *MockQuerier_ClaimIngestionJob_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(float64))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_GetPantryItem_Call) Return(_a0 db.PantryItem, _a1 error) *MockQuerier_GetPantryItem_Call {
+func (_c *MockQuerier_ClaimIngestionJob_Call) Return(_a0 db.ClaimIngestionJobRow, _a1 error) *MockQuerier_ClaimIngestionJob_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_GetPantryItem_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.PantryItem, error)) *MockQuerier_GetPantryItem_Call {
+func (_c *MockQuerier_ClaimIngestionJob_Call) RunAndReturn(run func(context.Context, float64) (db.ClaimIngestionJobRow, error)) *MockQuerier_ClaimIngestionJob_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetStagedItem provides a mock function with given fields: ctx, id
-func (_m *MockQuerier) GetStagedItem(ctx context.Context, id uuid.UUID) (db.StagedItem, error) {
-	ret := _m.Called(ctx, id)
+// ConsumePantryItem provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ConsumePantryItem(ctx context.Context, arg db.ConsumePantryItemParams) (db.ConsumePantryItemRow, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStagedItem")
+		panic("no return value specified for ConsumePantryItem")
 	}
 
-	var r0 db.StagedItem
+	var r0 db.ConsumePantryItemRow
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.StagedItem, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ConsumePantryItemParams) (db.ConsumePantryItemRow, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.StagedItem); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.ConsumePantryItemParams) db.ConsumePantryItemRow); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.StagedItem)
+		r0 = ret.Get(0).(db.ConsumePantryItemRow)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, db.ConsumePantryItemParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -372,54 +329,52 @@ func (_m *MockQuerier) GetStagedItem(ctx context.Context, id uuid.UUID) (db.Stag
 	return r0, r1
 }
 
-// MockQuerier_GetStagedItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStagedItem'
-type MockQuerier_GetStagedItem_Call struct {
+// MockQuerier_ConsumePantryItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumePantryItem'
+type MockQuerier_ConsumePantryItem_Call struct {
 	*mock.Call
 }
 
-// GetStagedItem is a helper method to define mock.On call
+// ConsumePantryItem is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *MockQuerier_Expecter) GetStagedItem(ctx interface{}, id interface{}) *MockQuerier_GetStagedItem_Call {
-	return &MockQuerier_GetStagedItem_Call{Call: _e.mock.On("GetStagedItem", ctx, id)}
+//   - arg db.ConsumePantryItemParams
+func (_e *MockQuerier_Expecter) ConsumePantryItem(ctx interface{}, arg interface{}) *MockQuerier_ConsumePantryItem_Call {
+	return &MockQuerier_ConsumePantryItem_Call{Call: _e.mock.On("ConsumePantryItem", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetStagedItem_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_GetStagedItem_Call {
+func (_c *MockQuerier_ConsumePantryItem_Call) Run(run func(ctx context.Context, arg db.ConsumePantryItemParams)) *MockQuerier_ConsumePantryItem_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(db.ConsumePantryItemParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_GetStagedItem_Call) Return(_a0 db.StagedItem, _a1 error) *MockQuerier_GetStagedItem_Call {
+func (_c *MockQuerier_ConsumePantryItem_Call) Return(_a0 db.ConsumePantryItemRow, _a1 error) *MockQuerier_ConsumePantryItem_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_GetStagedItem_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.StagedItem, error)) *MockQuerier_GetStagedItem_Call {
+func (_c *MockQuerier_ConsumePantryItem_Call) RunAndReturn(run func(context.Context, db.ConsumePantryItemParams) (db.ConsumePantryItemRow, error)) *MockQuerier_ConsumePantryItem_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListPantryItems provides a mock function with given fields: ctx
-func (_m *MockQuerier) ListPantryItems(ctx context.Context) ([]db.PantryItem, error) {
+// CountOutboxEvents provides a mock function with given fields: ctx
+func (_m *MockQuerier) CountOutboxEvents(ctx context.Context) (int64, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListPantryItems")
+		panic("no return value specified for CountOutboxEvents")
 	}
 
-	var r0 []db.PantryItem
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]db.PantryItem, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []db.PantryItem); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
 		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.PantryItem)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -431,57 +386,55 @@ func (_m *MockQuerier) ListPantryItems(ctx context.Context) ([]db.PantryItem, er
 	return r0, r1
 }
 
-// MockQuerier_ListPantryItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPantryItems'
-type MockQuerier_ListPantryItems_Call struct {
+// MockQuerier_CountOutboxEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOutboxEvents'
+type MockQuerier_CountOutboxEvents_Call struct {
 	*mock.Call
 }
 
-// ListPantryItems is a helper method to define mock.On call
+// CountOutboxEvents is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockQuerier_Expecter) ListPantryItems(ctx interface{}) *MockQuerier_ListPantryItems_Call {
-	return &MockQuerier_ListPantryItems_Call{Call: _e.mock.On("ListPantryItems", ctx)}
+func (_e *MockQuerier_Expecter) CountOutboxEvents(ctx interface{}) *MockQuerier_CountOutboxEvents_Call {
+	return &MockQuerier_CountOutboxEvents_Call{Call: _e.mock.On("CountOutboxEvents", ctx)}
 }
 
-func (_c *MockQuerier_ListPantryItems_Call) Run(run func(ctx context.Context)) *MockQuerier_ListPantryItems_Call {
+func (_c *MockQuerier_CountOutboxEvents_Call) Run(run func(ctx context.Context)) *MockQuerier_CountOutboxEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_ListPantryItems_Call) Return(_a0 []db.PantryItem, _a1 error) *MockQuerier_ListPantryItems_Call {
+func (_c *MockQuerier_CountOutboxEvents_Call) Return(_a0 int64, _a1 error) *MockQuerier_CountOutboxEvents_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_ListPantryItems_Call) RunAndReturn(run func(context.Context) ([]db.PantryItem, error)) *MockQuerier_ListPantryItems_Call {
+func (_c *MockQuerier_CountOutboxEvents_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockQuerier_CountOutboxEvents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListStagedItemsByJob provides a mock function with given fields: ctx, jobID
-func (_m *MockQuerier) ListStagedItemsByJob(ctx context.Context, jobID uuid.UUID) ([]db.StagedItem, error) {
-	ret := _m.Called(ctx, jobID)
+// CountPantryItems provides a mock function with given fields: ctx
+func (_m *MockQuerier) CountPantryItems(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListStagedItemsByJob")
+		panic("no return value specified for CountPantryItems")
 	}
 
-	var r0 []db.StagedItem
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.StagedItem, error)); ok {
-		return rf(ctx, jobID)
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.StagedItem); ok {
-		r0 = rf(ctx, jobID)
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.StagedItem)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, jobID)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -489,55 +442,54 @@ func (_m *MockQuerier) ListStagedItemsByJob(ctx context.Context, jobID uuid.UUID
 	return r0, r1
 }
 
-// MockQuerier_ListStagedItemsByJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStagedItemsByJob'
-type MockQuerier_ListStagedItemsByJob_Call struct {
+// MockQuerier_CountPantryItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountPantryItems'
+type MockQuerier_CountPantryItems_Call struct {
 	*mock.Call
 }
 
-// ListStagedItemsByJob is a helper method to define mock.On call
+// CountPantryItems is a helper method to define mock.On call
 //   - ctx context.Context
-//   - jobID uuid.UUID
-func (_e *MockQuerier_Expecter) ListStagedItemsByJob(ctx interface{}, jobID interface{}) *MockQuerier_ListStagedItemsByJob_Call {
-	return &MockQuerier_ListStagedItemsByJob_Call{Call: _e.mock.On("ListStagedItemsByJob", ctx, jobID)}
+func (_e *MockQuerier_Expecter) CountPantryItems(ctx interface{}) *MockQuerier_CountPantryItems_Call {
+	return &MockQuerier_CountPantryItems_Call{Call: _e.mock.On("CountPantryItems", ctx)}
 }
 
-func (_c *MockQuerier_ListStagedItemsByJob_Call) Run(run func(ctx context.Context, jobID uuid.UUID)) *MockQuerier_ListStagedItemsByJob_Call {
+func (_c *MockQuerier_CountPantryItems_Call) Run(run func(ctx context.Context)) *MockQuerier_CountPantryItems_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_ListStagedItemsByJob_Call) Return(_a0 []db.StagedItem, _a1 error) *MockQuerier_ListStagedItemsByJob_Call {
+func (_c *MockQuerier_CountPantryItems_Call) Return(_a0 int64, _a1 error) *MockQuerier_CountPantryItems_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_ListStagedItemsByJob_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.StagedItem, error)) *MockQuerier_ListStagedItemsByJob_Call {
+func (_c *MockQuerier_CountPantryItems_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockQuerier_CountPantryItems_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateIngestionJobStatus provides a mock function with given fields: ctx, arg
-func (_m *MockQuerier) UpdateIngestionJobStatus(ctx context.Context, arg db.UpdateIngestionJobStatusParams) (db.IngestionJob, error) {
+// CreateIngestionJob provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateIngestionJob(ctx context.Context, arg db.CreateIngestionJobParams) (db.IngestionJob, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateIngestionJobStatus")
+		panic("no return value specified for CreateIngestionJob")
 	}
 
 	var r0 db.IngestionJob
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateIngestionJobStatusParams) (db.IngestionJob, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateIngestionJobParams) (db.IngestionJob, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateIngestionJobStatusParams) db.IngestionJob); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateIngestionJobParams) db.IngestionJob); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Get(0).(db.IngestionJob)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateIngestionJobStatusParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateIngestionJobParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -546,31 +498,6000 @@ func (_m *MockQuerier) UpdateIngestionJobStatus(ctx context.Context, arg db.Upda
 	return r0, r1
 }
 
-// MockQuerier_UpdateIngestionJobStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateIngestionJobStatus'
-type MockQuerier_UpdateIngestionJobStatus_Call struct {
+// MockQuerier_CreateIngestionJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIngestionJob'
+type MockQuerier_CreateIngestionJob_Call struct {
 	*mock.Call
 }
 
-// UpdateIngestionJobStatus is a helper method to define mock.On call
+// CreateIngestionJob is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.UpdateIngestionJobStatusParams
-func (_e *MockQuerier_Expecter) UpdateIngestionJobStatus(ctx interface{}, arg interface{}) *MockQuerier_UpdateIngestionJobStatus_Call {
-	return &MockQuerier_UpdateIngestionJobStatus_Call{Call: _e.mock.On("UpdateIngestionJobStatus", ctx, arg)}
+//   - arg db.CreateIngestionJobParams
+func (_e *MockQuerier_Expecter) CreateIngestionJob(ctx interface{}, arg interface{}) *MockQuerier_CreateIngestionJob_Call {
+	return &MockQuerier_CreateIngestionJob_Call{Call: _e.mock.On("CreateIngestionJob", ctx, arg)}
 }
 
-func (_c *MockQuerier_UpdateIngestionJobStatus_Call) Run(run func(ctx context.Context, arg db.UpdateIngestionJobStatusParams)) *MockQuerier_UpdateIngestionJobStatus_Call {
+func (_c *MockQuerier_CreateIngestionJob_Call) Run(run func(ctx context.Context, arg db.CreateIngestionJobParams)) *MockQuerier_CreateIngestionJob_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.UpdateIngestionJobStatusParams))
+		run(args[0].(context.Context), args[1].(db.CreateIngestionJobParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_UpdateIngestionJobStatus_Call) Return(_a0 db.IngestionJob, _a1 error) *MockQuerier_UpdateIngestionJobStatus_Call {
+func (_c *MockQuerier_CreateIngestionJob_Call) Return(_a0 db.IngestionJob, _a1 error) *MockQuerier_CreateIngestionJob_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_UpdateIngestionJobStatus_Call) RunAndReturn(run func(context.Context, db.UpdateIngestionJobStatusParams) (db.IngestionJob, error)) *MockQuerier_UpdateIngestionJobStatus_Call {
+func (_c *MockQuerier_CreateIngestionJob_Call) RunAndReturn(run func(context.Context, db.CreateIngestionJobParams) (db.IngestionJob, error)) *MockQuerier_CreateIngestionJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateReviewRule provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateReviewRule(ctx context.Context, arg db.CreateReviewRuleParams) (db.ReviewRule, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReviewRule")
+	}
+
+	var r0 db.ReviewRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReviewRuleParams) (db.ReviewRule, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateReviewRuleParams) db.ReviewRule); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.ReviewRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateReviewRuleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateReviewRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReviewRule'
+type MockQuerier_CreateReviewRule_Call struct {
+	*mock.Call
+}
+
+// CreateReviewRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateReviewRuleParams
+func (_e *MockQuerier_Expecter) CreateReviewRule(ctx interface{}, arg interface{}) *MockQuerier_CreateReviewRule_Call {
+	return &MockQuerier_CreateReviewRule_Call{Call: _e.mock.On("CreateReviewRule", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateReviewRule_Call) Run(run func(ctx context.Context, arg db.CreateReviewRuleParams)) *MockQuerier_CreateReviewRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateReviewRuleParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateReviewRule_Call) Return(_a0 db.ReviewRule, _a1 error) *MockQuerier_CreateReviewRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateReviewRule_Call) RunAndReturn(run func(context.Context, db.CreateReviewRuleParams) (db.ReviewRule, error)) *MockQuerier_CreateReviewRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateStagedItem provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateStagedItem(ctx context.Context, arg db.CreateStagedItemParams) (db.StagedItem, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStagedItem")
+	}
+
+	var r0 db.StagedItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStagedItemParams) (db.StagedItem, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateStagedItemParams) db.StagedItem); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.StagedItem)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateStagedItemParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateStagedItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStagedItem'
+type MockQuerier_CreateStagedItem_Call struct {
+	*mock.Call
+}
+
+// CreateStagedItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateStagedItemParams
+func (_e *MockQuerier_Expecter) CreateStagedItem(ctx interface{}, arg interface{}) *MockQuerier_CreateStagedItem_Call {
+	return &MockQuerier_CreateStagedItem_Call{Call: _e.mock.On("CreateStagedItem", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateStagedItem_Call) Run(run func(ctx context.Context, arg db.CreateStagedItemParams)) *MockQuerier_CreateStagedItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateStagedItemParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateStagedItem_Call) Return(_a0 db.StagedItem, _a1 error) *MockQuerier_CreateStagedItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateStagedItem_Call) RunAndReturn(run func(context.Context, db.CreateStagedItemParams) (db.StagedItem, error)) *MockQuerier_CreateStagedItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebhookSubscription provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateWebhookSubscription(ctx context.Context, arg db.CreateWebhookSubscriptionParams) (db.WebhookSubscription, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhookSubscription")
+	}
+
+	var r0 db.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateWebhookSubscriptionParams) (db.WebhookSubscription, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.CreateWebhookSubscriptionParams) db.WebhookSubscription); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.WebhookSubscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.CreateWebhookSubscriptionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhookSubscription'
+type MockQuerier_CreateWebhookSubscription_Call struct {
+	*mock.Call
+}
+
+// CreateWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateWebhookSubscriptionParams
+func (_e *MockQuerier_Expecter) CreateWebhookSubscription(ctx interface{}, arg interface{}) *MockQuerier_CreateWebhookSubscription_Call {
+	return &MockQuerier_CreateWebhookSubscription_Call{Call: _e.mock.On("CreateWebhookSubscription", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateWebhookSubscription_Call) Run(run func(ctx context.Context, arg db.CreateWebhookSubscriptionParams)) *MockQuerier_CreateWebhookSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.CreateWebhookSubscriptionParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateWebhookSubscription_Call) Return(_a0 db.WebhookSubscription, _a1 error) *MockQuerier_CreateWebhookSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateWebhookSubscription_Call) RunAndReturn(run func(context.Context, db.CreateWebhookSubscriptionParams) (db.WebhookSubscription, error)) *MockQuerier_CreateWebhookSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DecrementPantryLot provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) DecrementPantryLot(ctx context.Context, arg db.DecrementPantryLotParams) (db.PantryLot, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecrementPantryLot")
+	}
+
+	var r0 db.PantryLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DecrementPantryLotParams) (db.PantryLot, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DecrementPantryLotParams) db.PantryLot); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.PantryLot)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DecrementPantryLotParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DecrementPantryLot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecrementPantryLot'
+type MockQuerier_DecrementPantryLot_Call struct {
+	*mock.Call
+}
+
+// DecrementPantryLot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.DecrementPantryLotParams
+func (_e *MockQuerier_Expecter) DecrementPantryLot(ctx interface{}, arg interface{}) *MockQuerier_DecrementPantryLot_Call {
+	return &MockQuerier_DecrementPantryLot_Call{Call: _e.mock.On("DecrementPantryLot", ctx, arg)}
+}
+
+func (_c *MockQuerier_DecrementPantryLot_Call) Run(run func(ctx context.Context, arg db.DecrementPantryLotParams)) *MockQuerier_DecrementPantryLot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.DecrementPantryLotParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DecrementPantryLot_Call) Return(_a0 db.PantryLot, _a1 error) *MockQuerier_DecrementPantryLot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DecrementPantryLot_Call) RunAndReturn(run func(context.Context, db.DecrementPantryLotParams) (db.PantryLot, error)) *MockQuerier_DecrementPantryLot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAllPantryItems provides a mock function with given fields: ctx
+func (_m *MockQuerier) DeleteAllPantryItems(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllPantryItems")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteAllPantryItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAllPantryItems'
+type MockQuerier_DeleteAllPantryItems_Call struct {
+	*mock.Call
+}
+
+// DeleteAllPantryItems is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) DeleteAllPantryItems(ctx interface{}) *MockQuerier_DeleteAllPantryItems_Call {
+	return &MockQuerier_DeleteAllPantryItems_Call{Call: _e.mock.On("DeleteAllPantryItems", ctx)}
+}
+
+func (_c *MockQuerier_DeleteAllPantryItems_Call) Run(run func(ctx context.Context)) *MockQuerier_DeleteAllPantryItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteAllPantryItems_Call) Return(_a0 error) *MockQuerier_DeleteAllPantryItems_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteAllPantryItems_Call) RunAndReturn(run func(context.Context) error) *MockQuerier_DeleteAllPantryItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCategoryDefaultUnit provides a mock function with given fields: ctx, category
+func (_m *MockQuerier) DeleteCategoryDefaultUnit(ctx context.Context, category string) (int64, error) {
+	ret := _m.Called(ctx, category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategoryDefaultUnit")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, category)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, category)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, category)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteCategoryDefaultUnit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCategoryDefaultUnit'
+type MockQuerier_DeleteCategoryDefaultUnit_Call struct {
+	*mock.Call
+}
+
+// DeleteCategoryDefaultUnit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - category string
+func (_e *MockQuerier_Expecter) DeleteCategoryDefaultUnit(ctx interface{}, category interface{}) *MockQuerier_DeleteCategoryDefaultUnit_Call {
+	return &MockQuerier_DeleteCategoryDefaultUnit_Call{Call: _e.mock.On("DeleteCategoryDefaultUnit", ctx, category)}
+}
+
+func (_c *MockQuerier_DeleteCategoryDefaultUnit_Call) Run(run func(ctx context.Context, category string)) *MockQuerier_DeleteCategoryDefaultUnit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCategoryDefaultUnit_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteCategoryDefaultUnit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCategoryDefaultUnit_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockQuerier_DeleteCategoryDefaultUnit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCategoryPrice provides a mock function with given fields: ctx, category
+func (_m *MockQuerier) DeleteCategoryPrice(ctx context.Context, category string) (int64, error) {
+	ret := _m.Called(ctx, category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategoryPrice")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, category)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, category)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, category)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteCategoryPrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCategoryPrice'
+type MockQuerier_DeleteCategoryPrice_Call struct {
+	*mock.Call
+}
+
+// DeleteCategoryPrice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - category string
+func (_e *MockQuerier_Expecter) DeleteCategoryPrice(ctx interface{}, category interface{}) *MockQuerier_DeleteCategoryPrice_Call {
+	return &MockQuerier_DeleteCategoryPrice_Call{Call: _e.mock.On("DeleteCategoryPrice", ctx, category)}
+}
+
+func (_c *MockQuerier_DeleteCategoryPrice_Call) Run(run func(ctx context.Context, category string)) *MockQuerier_DeleteCategoryPrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCategoryPrice_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteCategoryPrice_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCategoryPrice_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockQuerier_DeleteCategoryPrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEmptyPantryLots provides a mock function with given fields: ctx, pantryItemID
+func (_m *MockQuerier) DeleteEmptyPantryLots(ctx context.Context, pantryItemID uuid.UUID) error {
+	ret := _m.Called(ctx, pantryItemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEmptyPantryLots")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, pantryItemID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteEmptyPantryLots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEmptyPantryLots'
+type MockQuerier_DeleteEmptyPantryLots_Call struct {
+	*mock.Call
+}
+
+// DeleteEmptyPantryLots is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pantryItemID uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteEmptyPantryLots(ctx interface{}, pantryItemID interface{}) *MockQuerier_DeleteEmptyPantryLots_Call {
+	return &MockQuerier_DeleteEmptyPantryLots_Call{Call: _e.mock.On("DeleteEmptyPantryLots", ctx, pantryItemID)}
+}
+
+func (_c *MockQuerier_DeleteEmptyPantryLots_Call) Run(run func(ctx context.Context, pantryItemID uuid.UUID)) *MockQuerier_DeleteEmptyPantryLots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteEmptyPantryLots_Call) Return(_a0 error) *MockQuerier_DeleteEmptyPantryLots_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteEmptyPantryLots_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_DeleteEmptyPantryLots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpiryLeadTime provides a mock function with given fields: ctx, category
+func (_m *MockQuerier) DeleteExpiryLeadTime(ctx context.Context, category string) (int64, error) {
+	ret := _m.Called(ctx, category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiryLeadTime")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, category)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, category)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, category)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteExpiryLeadTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiryLeadTime'
+type MockQuerier_DeleteExpiryLeadTime_Call struct {
+	*mock.Call
+}
+
+// DeleteExpiryLeadTime is a helper method to define mock.On call
+//   - ctx context.Context
+//   - category string
+func (_e *MockQuerier_Expecter) DeleteExpiryLeadTime(ctx interface{}, category interface{}) *MockQuerier_DeleteExpiryLeadTime_Call {
+	return &MockQuerier_DeleteExpiryLeadTime_Call{Call: _e.mock.On("DeleteExpiryLeadTime", ctx, category)}
+}
+
+func (_c *MockQuerier_DeleteExpiryLeadTime_Call) Run(run func(ctx context.Context, category string)) *MockQuerier_DeleteExpiryLeadTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteExpiryLeadTime_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteExpiryLeadTime_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteExpiryLeadTime_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockQuerier_DeleteExpiryLeadTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIngestJobClaim provides a mock function with given fields: ctx, jobID
+func (_m *MockQuerier) DeleteIngestJobClaim(ctx context.Context, jobID uuid.UUID) error {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIngestJobClaim")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteIngestJobClaim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIngestJobClaim'
+type MockQuerier_DeleteIngestJobClaim_Call struct {
+	*mock.Call
+}
+
+// DeleteIngestJobClaim is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteIngestJobClaim(ctx interface{}, jobID interface{}) *MockQuerier_DeleteIngestJobClaim_Call {
+	return &MockQuerier_DeleteIngestJobClaim_Call{Call: _e.mock.On("DeleteIngestJobClaim", ctx, jobID)}
+}
+
+func (_c *MockQuerier_DeleteIngestJobClaim_Call) Run(run func(ctx context.Context, jobID uuid.UUID)) *MockQuerier_DeleteIngestJobClaim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIngestJobClaim_Call) Return(_a0 error) *MockQuerier_DeleteIngestJobClaim_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIngestJobClaim_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_DeleteIngestJobClaim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIngredientDisplayOverride provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) DeleteIngredientDisplayOverride(ctx context.Context, ingredientID uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIngredientDisplayOverride")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, ingredientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ingredientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteIngredientDisplayOverride_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIngredientDisplayOverride'
+type MockQuerier_DeleteIngredientDisplayOverride_Call struct {
+	*mock.Call
+}
+
+// DeleteIngredientDisplayOverride is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteIngredientDisplayOverride(ctx interface{}, ingredientID interface{}) *MockQuerier_DeleteIngredientDisplayOverride_Call {
+	return &MockQuerier_DeleteIngredientDisplayOverride_Call{Call: _e.mock.On("DeleteIngredientDisplayOverride", ctx, ingredientID)}
+}
+
+func (_c *MockQuerier_DeleteIngredientDisplayOverride_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_DeleteIngredientDisplayOverride_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIngredientDisplayOverride_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteIngredientDisplayOverride_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIngredientDisplayOverride_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockQuerier_DeleteIngredientDisplayOverride_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIngredientPrice provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) DeleteIngredientPrice(ctx context.Context, ingredientID uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIngredientPrice")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, ingredientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ingredientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteIngredientPrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIngredientPrice'
+type MockQuerier_DeleteIngredientPrice_Call struct {
+	*mock.Call
+}
+
+// DeleteIngredientPrice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteIngredientPrice(ctx interface{}, ingredientID interface{}) *MockQuerier_DeleteIngredientPrice_Call {
+	return &MockQuerier_DeleteIngredientPrice_Call{Call: _e.mock.On("DeleteIngredientPrice", ctx, ingredientID)}
+}
+
+func (_c *MockQuerier_DeleteIngredientPrice_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_DeleteIngredientPrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIngredientPrice_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteIngredientPrice_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIngredientPrice_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockQuerier_DeleteIngredientPrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteOrphanedStagedItems provides a mock function with given fields: ctx
+func (_m *MockQuerier) DeleteOrphanedStagedItems(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOrphanedStagedItems")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteOrphanedStagedItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOrphanedStagedItems'
+type MockQuerier_DeleteOrphanedStagedItems_Call struct {
+	*mock.Call
+}
+
+// DeleteOrphanedStagedItems is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) DeleteOrphanedStagedItems(ctx interface{}) *MockQuerier_DeleteOrphanedStagedItems_Call {
+	return &MockQuerier_DeleteOrphanedStagedItems_Call{Call: _e.mock.On("DeleteOrphanedStagedItems", ctx)}
+}
+
+func (_c *MockQuerier_DeleteOrphanedStagedItems_Call) Run(run func(ctx context.Context)) *MockQuerier_DeleteOrphanedStagedItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteOrphanedStagedItems_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteOrphanedStagedItems_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteOrphanedStagedItems_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockQuerier_DeleteOrphanedStagedItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteOutboxEvent provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) DeleteOutboxEvent(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOutboxEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteOutboxEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOutboxEvent'
+type MockQuerier_DeleteOutboxEvent_Call struct {
+	*mock.Call
+}
+
+// DeleteOutboxEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockQuerier_Expecter) DeleteOutboxEvent(ctx interface{}, id interface{}) *MockQuerier_DeleteOutboxEvent_Call {
+	return &MockQuerier_DeleteOutboxEvent_Call{Call: _e.mock.On("DeleteOutboxEvent", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteOutboxEvent_Call) Run(run func(ctx context.Context, id int64)) *MockQuerier_DeleteOutboxEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteOutboxEvent_Call) Return(_a0 error) *MockQuerier_DeleteOutboxEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteOutboxEvent_Call) RunAndReturn(run func(context.Context, int64) error) *MockQuerier_DeleteOutboxEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePantryItem provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) DeletePantryItem(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePantryItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeletePantryItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePantryItem'
+type MockQuerier_DeletePantryItem_Call struct {
+	*mock.Call
+}
+
+// DeletePantryItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) DeletePantryItem(ctx interface{}, id interface{}) *MockQuerier_DeletePantryItem_Call {
+	return &MockQuerier_DeletePantryItem_Call{Call: _e.mock.On("DeletePantryItem", ctx, id)}
+}
+
+func (_c *MockQuerier_DeletePantryItem_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_DeletePantryItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeletePantryItem_Call) Return(_a0 error) *MockQuerier_DeletePantryItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeletePantryItem_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_DeletePantryItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePantryLotsWithOtherUnit provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) DeletePantryLotsWithOtherUnit(ctx context.Context, arg db.DeletePantryLotsWithOtherUnitParams) ([]db.PantryLot, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePantryLotsWithOtherUnit")
+	}
+
+	var r0 []db.PantryLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeletePantryLotsWithOtherUnitParams) ([]db.PantryLot, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeletePantryLotsWithOtherUnitParams) []db.PantryLot); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PantryLot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DeletePantryLotsWithOtherUnitParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeletePantryLotsWithOtherUnit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePantryLotsWithOtherUnit'
+type MockQuerier_DeletePantryLotsWithOtherUnit_Call struct {
+	*mock.Call
+}
+
+// DeletePantryLotsWithOtherUnit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.DeletePantryLotsWithOtherUnitParams
+func (_e *MockQuerier_Expecter) DeletePantryLotsWithOtherUnit(ctx interface{}, arg interface{}) *MockQuerier_DeletePantryLotsWithOtherUnit_Call {
+	return &MockQuerier_DeletePantryLotsWithOtherUnit_Call{Call: _e.mock.On("DeletePantryLotsWithOtherUnit", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeletePantryLotsWithOtherUnit_Call) Run(run func(ctx context.Context, arg db.DeletePantryLotsWithOtherUnitParams)) *MockQuerier_DeletePantryLotsWithOtherUnit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.DeletePantryLotsWithOtherUnitParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeletePantryLotsWithOtherUnit_Call) Return(_a0 []db.PantryLot, _a1 error) *MockQuerier_DeletePantryLotsWithOtherUnit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeletePantryLotsWithOtherUnit_Call) RunAndReturn(run func(context.Context, db.DeletePantryLotsWithOtherUnitParams) ([]db.PantryLot, error)) *MockQuerier_DeletePantryLotsWithOtherUnit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePantryTombstonesBefore provides a mock function with given fields: ctx, deletedAt
+func (_m *MockQuerier) DeletePantryTombstonesBefore(ctx context.Context, deletedAt time.Time) (int64, error) {
+	ret := _m.Called(ctx, deletedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePantryTombstonesBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, deletedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, deletedAt)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, deletedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeletePantryTombstonesBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePantryTombstonesBefore'
+type MockQuerier_DeletePantryTombstonesBefore_Call struct {
+	*mock.Call
+}
+
+// DeletePantryTombstonesBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deletedAt time.Time
+func (_e *MockQuerier_Expecter) DeletePantryTombstonesBefore(ctx interface{}, deletedAt interface{}) *MockQuerier_DeletePantryTombstonesBefore_Call {
+	return &MockQuerier_DeletePantryTombstonesBefore_Call{Call: _e.mock.On("DeletePantryTombstonesBefore", ctx, deletedAt)}
+}
+
+func (_c *MockQuerier_DeletePantryTombstonesBefore_Call) Run(run func(ctx context.Context, deletedAt time.Time)) *MockQuerier_DeletePantryTombstonesBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeletePantryTombstonesBefore_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeletePantryTombstonesBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeletePantryTombstonesBefore_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockQuerier_DeletePantryTombstonesBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteProcessedMessagesBefore provides a mock function with given fields: ctx, processedAt
+func (_m *MockQuerier) DeleteProcessedMessagesBefore(ctx context.Context, processedAt time.Time) (int64, error) {
+	ret := _m.Called(ctx, processedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProcessedMessagesBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, processedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, processedAt)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, processedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteProcessedMessagesBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProcessedMessagesBefore'
+type MockQuerier_DeleteProcessedMessagesBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteProcessedMessagesBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - processedAt time.Time
+func (_e *MockQuerier_Expecter) DeleteProcessedMessagesBefore(ctx interface{}, processedAt interface{}) *MockQuerier_DeleteProcessedMessagesBefore_Call {
+	return &MockQuerier_DeleteProcessedMessagesBefore_Call{Call: _e.mock.On("DeleteProcessedMessagesBefore", ctx, processedAt)}
+}
+
+func (_c *MockQuerier_DeleteProcessedMessagesBefore_Call) Run(run func(ctx context.Context, processedAt time.Time)) *MockQuerier_DeleteProcessedMessagesBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteProcessedMessagesBefore_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteProcessedMessagesBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteProcessedMessagesBefore_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockQuerier_DeleteProcessedMessagesBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteReviewRule provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) DeleteReviewRule(ctx context.Context, id uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReviewRule")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteReviewRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReviewRule'
+type MockQuerier_DeleteReviewRule_Call struct {
+	*mock.Call
+}
+
+// DeleteReviewRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteReviewRule(ctx interface{}, id interface{}) *MockQuerier_DeleteReviewRule_Call {
+	return &MockQuerier_DeleteReviewRule_Call{Call: _e.mock.On("DeleteReviewRule", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteReviewRule_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_DeleteReviewRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteReviewRule_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteReviewRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteReviewRule_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockQuerier_DeleteReviewRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteStagedItem provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) DeleteStagedItem(ctx context.Context, arg db.DeleteStagedItemParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStagedItem")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteStagedItemParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteStagedItemParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DeleteStagedItemParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteStagedItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteStagedItem'
+type MockQuerier_DeleteStagedItem_Call struct {
+	*mock.Call
+}
+
+// DeleteStagedItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.DeleteStagedItemParams
+func (_e *MockQuerier_Expecter) DeleteStagedItem(ctx interface{}, arg interface{}) *MockQuerier_DeleteStagedItem_Call {
+	return &MockQuerier_DeleteStagedItem_Call{Call: _e.mock.On("DeleteStagedItem", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeleteStagedItem_Call) Run(run func(ctx context.Context, arg db.DeleteStagedItemParams)) *MockQuerier_DeleteStagedItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.DeleteStagedItemParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteStagedItem_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteStagedItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteStagedItem_Call) RunAndReturn(run func(context.Context, db.DeleteStagedItemParams) (int64, error)) *MockQuerier_DeleteStagedItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteStagedItemsByJob provides a mock function with given fields: ctx, jobID
+func (_m *MockQuerier) DeleteStagedItemsByJob(ctx context.Context, jobID uuid.UUID) error {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStagedItemsByJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteStagedItemsByJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteStagedItemsByJob'
+type MockQuerier_DeleteStagedItemsByJob_Call struct {
+	*mock.Call
+}
+
+// DeleteStagedItemsByJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteStagedItemsByJob(ctx interface{}, jobID interface{}) *MockQuerier_DeleteStagedItemsByJob_Call {
+	return &MockQuerier_DeleteStagedItemsByJob_Call{Call: _e.mock.On("DeleteStagedItemsByJob", ctx, jobID)}
+}
+
+func (_c *MockQuerier_DeleteStagedItemsByJob_Call) Run(run func(ctx context.Context, jobID uuid.UUID)) *MockQuerier_DeleteStagedItemsByJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteStagedItemsByJob_Call) Return(_a0 error) *MockQuerier_DeleteStagedItemsByJob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteStagedItemsByJob_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_DeleteStagedItemsByJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteStaleFlagsExcept provides a mock function with given fields: ctx, itemIds
+func (_m *MockQuerier) DeleteStaleFlagsExcept(ctx context.Context, itemIds []uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, itemIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStaleFlagsExcept")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) (int64, error)); ok {
+		return rf(ctx, itemIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) int64); ok {
+		r0 = rf(ctx, itemIds)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, itemIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteStaleFlagsExcept_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteStaleFlagsExcept'
+type MockQuerier_DeleteStaleFlagsExcept_Call struct {
+	*mock.Call
+}
+
+// DeleteStaleFlagsExcept is a helper method to define mock.On call
+//   - ctx context.Context
+//   - itemIds []uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteStaleFlagsExcept(ctx interface{}, itemIds interface{}) *MockQuerier_DeleteStaleFlagsExcept_Call {
+	return &MockQuerier_DeleteStaleFlagsExcept_Call{Call: _e.mock.On("DeleteStaleFlagsExcept", ctx, itemIds)}
+}
+
+func (_c *MockQuerier_DeleteStaleFlagsExcept_Call) Run(run func(ctx context.Context, itemIds []uuid.UUID)) *MockQuerier_DeleteStaleFlagsExcept_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteStaleFlagsExcept_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteStaleFlagsExcept_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteStaleFlagsExcept_Call) RunAndReturn(run func(context.Context, []uuid.UUID) (int64, error)) *MockQuerier_DeleteStaleFlagsExcept_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWatchlistEntry provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) DeleteWatchlistEntry(ctx context.Context, ingredientID uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWatchlistEntry")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, ingredientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ingredientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteWatchlistEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWatchlistEntry'
+type MockQuerier_DeleteWatchlistEntry_Call struct {
+	*mock.Call
+}
+
+// DeleteWatchlistEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteWatchlistEntry(ctx interface{}, ingredientID interface{}) *MockQuerier_DeleteWatchlistEntry_Call {
+	return &MockQuerier_DeleteWatchlistEntry_Call{Call: _e.mock.On("DeleteWatchlistEntry", ctx, ingredientID)}
+}
+
+func (_c *MockQuerier_DeleteWatchlistEntry_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_DeleteWatchlistEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteWatchlistEntry_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteWatchlistEntry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteWatchlistEntry_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockQuerier_DeleteWatchlistEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhookDeliveriesBefore provides a mock function with given fields: ctx, attemptedAt
+func (_m *MockQuerier) DeleteWebhookDeliveriesBefore(ctx context.Context, attemptedAt time.Time) (int64, error) {
+	ret := _m.Called(ctx, attemptedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhookDeliveriesBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, attemptedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, attemptedAt)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, attemptedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteWebhookDeliveriesBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhookDeliveriesBefore'
+type MockQuerier_DeleteWebhookDeliveriesBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhookDeliveriesBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - attemptedAt time.Time
+func (_e *MockQuerier_Expecter) DeleteWebhookDeliveriesBefore(ctx interface{}, attemptedAt interface{}) *MockQuerier_DeleteWebhookDeliveriesBefore_Call {
+	return &MockQuerier_DeleteWebhookDeliveriesBefore_Call{Call: _e.mock.On("DeleteWebhookDeliveriesBefore", ctx, attemptedAt)}
+}
+
+func (_c *MockQuerier_DeleteWebhookDeliveriesBefore_Call) Run(run func(ctx context.Context, attemptedAt time.Time)) *MockQuerier_DeleteWebhookDeliveriesBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteWebhookDeliveriesBefore_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteWebhookDeliveriesBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteWebhookDeliveriesBefore_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockQuerier_DeleteWebhookDeliveriesBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhookEventsBefore provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) DeleteWebhookEventsBefore(ctx context.Context, arg db.DeleteWebhookEventsBeforeParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhookEventsBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteWebhookEventsBeforeParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.DeleteWebhookEventsBeforeParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.DeleteWebhookEventsBeforeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteWebhookEventsBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhookEventsBefore'
+type MockQuerier_DeleteWebhookEventsBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhookEventsBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.DeleteWebhookEventsBeforeParams
+func (_e *MockQuerier_Expecter) DeleteWebhookEventsBefore(ctx interface{}, arg interface{}) *MockQuerier_DeleteWebhookEventsBefore_Call {
+	return &MockQuerier_DeleteWebhookEventsBefore_Call{Call: _e.mock.On("DeleteWebhookEventsBefore", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeleteWebhookEventsBefore_Call) Run(run func(ctx context.Context, arg db.DeleteWebhookEventsBeforeParams)) *MockQuerier_DeleteWebhookEventsBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.DeleteWebhookEventsBeforeParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteWebhookEventsBefore_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteWebhookEventsBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteWebhookEventsBefore_Call) RunAndReturn(run func(context.Context, db.DeleteWebhookEventsBeforeParams) (int64, error)) *MockQuerier_DeleteWebhookEventsBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhookSubscription provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhookSubscription")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhookSubscription'
+type MockQuerier_DeleteWebhookSubscription_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) DeleteWebhookSubscription(ctx interface{}, id interface{}) *MockQuerier_DeleteWebhookSubscription_Call {
+	return &MockQuerier_DeleteWebhookSubscription_Call{Call: _e.mock.On("DeleteWebhookSubscription", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteWebhookSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_DeleteWebhookSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteWebhookSubscription_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteWebhookSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteWebhookSubscription_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockQuerier_DeleteWebhookSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueNotification provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) EnqueueNotification(ctx context.Context, arg db.EnqueueNotificationParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.EnqueueNotificationParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_EnqueueNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueNotification'
+type MockQuerier_EnqueueNotification_Call struct {
+	*mock.Call
+}
+
+// EnqueueNotification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.EnqueueNotificationParams
+func (_e *MockQuerier_Expecter) EnqueueNotification(ctx interface{}, arg interface{}) *MockQuerier_EnqueueNotification_Call {
+	return &MockQuerier_EnqueueNotification_Call{Call: _e.mock.On("EnqueueNotification", ctx, arg)}
+}
+
+func (_c *MockQuerier_EnqueueNotification_Call) Run(run func(ctx context.Context, arg db.EnqueueNotificationParams)) *MockQuerier_EnqueueNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.EnqueueNotificationParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_EnqueueNotification_Call) Return(_a0 error) *MockQuerier_EnqueueNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_EnqueueNotification_Call) RunAndReturn(run func(context.Context, db.EnqueueNotificationParams) error) *MockQuerier_EnqueueNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueOutboxEvent provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) EnqueueOutboxEvent(ctx context.Context, arg db.EnqueueOutboxEventParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueOutboxEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.EnqueueOutboxEventParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_EnqueueOutboxEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueOutboxEvent'
+type MockQuerier_EnqueueOutboxEvent_Call struct {
+	*mock.Call
+}
+
+// EnqueueOutboxEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.EnqueueOutboxEventParams
+func (_e *MockQuerier_Expecter) EnqueueOutboxEvent(ctx interface{}, arg interface{}) *MockQuerier_EnqueueOutboxEvent_Call {
+	return &MockQuerier_EnqueueOutboxEvent_Call{Call: _e.mock.On("EnqueueOutboxEvent", ctx, arg)}
+}
+
+func (_c *MockQuerier_EnqueueOutboxEvent_Call) Run(run func(ctx context.Context, arg db.EnqueueOutboxEventParams)) *MockQuerier_EnqueueOutboxEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.EnqueueOutboxEventParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_EnqueueOutboxEvent_Call) Return(_a0 error) *MockQuerier_EnqueueOutboxEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_EnqueueOutboxEvent_Call) RunAndReturn(run func(context.Context, db.EnqueueOutboxEventParams) error) *MockQuerier_EnqueueOutboxEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueWebhookEvent provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) EnqueueWebhookEvent(ctx context.Context, arg db.EnqueueWebhookEventParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueWebhookEvent")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.EnqueueWebhookEventParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.EnqueueWebhookEventParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.EnqueueWebhookEventParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_EnqueueWebhookEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueWebhookEvent'
+type MockQuerier_EnqueueWebhookEvent_Call struct {
+	*mock.Call
+}
+
+// EnqueueWebhookEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.EnqueueWebhookEventParams
+func (_e *MockQuerier_Expecter) EnqueueWebhookEvent(ctx interface{}, arg interface{}) *MockQuerier_EnqueueWebhookEvent_Call {
+	return &MockQuerier_EnqueueWebhookEvent_Call{Call: _e.mock.On("EnqueueWebhookEvent", ctx, arg)}
+}
+
+func (_c *MockQuerier_EnqueueWebhookEvent_Call) Run(run func(ctx context.Context, arg db.EnqueueWebhookEventParams)) *MockQuerier_EnqueueWebhookEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.EnqueueWebhookEventParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_EnqueueWebhookEvent_Call) Return(_a0 int64, _a1 error) *MockQuerier_EnqueueWebhookEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_EnqueueWebhookEvent_Call) RunAndReturn(run func(context.Context, db.EnqueueWebhookEventParams) (int64, error)) *MockQuerier_EnqueueWebhookEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExpireStagedJobs provides a mock function with given fields: ctx, stagedAt
+func (_m *MockQuerier) ExpireStagedJobs(ctx context.Context, stagedAt time.Time) ([]db.ExpireStagedJobsRow, error) {
+	ret := _m.Called(ctx, stagedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireStagedJobs")
+	}
+
+	var r0 []db.ExpireStagedJobsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.ExpireStagedJobsRow, error)); ok {
+		return rf(ctx, stagedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.ExpireStagedJobsRow); ok {
+		r0 = rf(ctx, stagedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ExpireStagedJobsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, stagedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ExpireStagedJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireStagedJobs'
+type MockQuerier_ExpireStagedJobs_Call struct {
+	*mock.Call
+}
+
+// ExpireStagedJobs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stagedAt time.Time
+func (_e *MockQuerier_Expecter) ExpireStagedJobs(ctx interface{}, stagedAt interface{}) *MockQuerier_ExpireStagedJobs_Call {
+	return &MockQuerier_ExpireStagedJobs_Call{Call: _e.mock.On("ExpireStagedJobs", ctx, stagedAt)}
+}
+
+func (_c *MockQuerier_ExpireStagedJobs_Call) Run(run func(ctx context.Context, stagedAt time.Time)) *MockQuerier_ExpireStagedJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ExpireStagedJobs_Call) Return(_a0 []db.ExpireStagedJobsRow, _a1 error) *MockQuerier_ExpireStagedJobs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ExpireStagedJobs_Call) RunAndReturn(run func(context.Context, time.Time) ([]db.ExpireStagedJobsRow, error)) *MockQuerier_ExpireStagedJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailIngestionJob provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) FailIngestionJob(ctx context.Context, arg db.FailIngestionJobParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailIngestionJob")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.FailIngestionJobParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.FailIngestionJobParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.FailIngestionJobParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_FailIngestionJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailIngestionJob'
+type MockQuerier_FailIngestionJob_Call struct {
+	*mock.Call
+}
+
+// FailIngestionJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.FailIngestionJobParams
+func (_e *MockQuerier_Expecter) FailIngestionJob(ctx interface{}, arg interface{}) *MockQuerier_FailIngestionJob_Call {
+	return &MockQuerier_FailIngestionJob_Call{Call: _e.mock.On("FailIngestionJob", ctx, arg)}
+}
+
+func (_c *MockQuerier_FailIngestionJob_Call) Run(run func(ctx context.Context, arg db.FailIngestionJobParams)) *MockQuerier_FailIngestionJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.FailIngestionJobParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_FailIngestionJob_Call) Return(_a0 int64, _a1 error) *MockQuerier_FailIngestionJob_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_FailIngestionJob_Call) RunAndReturn(run func(context.Context, db.FailIngestionJobParams) (int64, error)) *MockQuerier_FailIngestionJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIngestionJob provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetIngestionJob(ctx context.Context, id uuid.UUID) (db.IngestionJob, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIngestionJob")
+	}
+
+	var r0 db.IngestionJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.IngestionJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.IngestionJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.IngestionJob)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetIngestionJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIngestionJob'
+type MockQuerier_GetIngestionJob_Call struct {
+	*mock.Call
+}
+
+// GetIngestionJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) GetIngestionJob(ctx interface{}, id interface{}) *MockQuerier_GetIngestionJob_Call {
+	return &MockQuerier_GetIngestionJob_Call{Call: _e.mock.On("GetIngestionJob", ctx, id)}
+}
+
+func (_c *MockQuerier_GetIngestionJob_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_GetIngestionJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetIngestionJob_Call) Return(_a0 db.IngestionJob, _a1 error) *MockQuerier_GetIngestionJob_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetIngestionJob_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.IngestionJob, error)) *MockQuerier_GetIngestionJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIngestionJobTimings provides a mock function with given fields: ctx, jobID
+func (_m *MockQuerier) GetIngestionJobTimings(ctx context.Context, jobID uuid.UUID) (db.IngestionJobTiming, error) {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIngestionJobTimings")
+	}
+
+	var r0 db.IngestionJobTiming
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.IngestionJobTiming, error)); ok {
+		return rf(ctx, jobID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.IngestionJobTiming); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		r0 = ret.Get(0).(db.IngestionJobTiming)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetIngestionJobTimings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIngestionJobTimings'
+type MockQuerier_GetIngestionJobTimings_Call struct {
+	*mock.Call
+}
+
+// GetIngestionJobTimings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID uuid.UUID
+func (_e *MockQuerier_Expecter) GetIngestionJobTimings(ctx interface{}, jobID interface{}) *MockQuerier_GetIngestionJobTimings_Call {
+	return &MockQuerier_GetIngestionJobTimings_Call{Call: _e.mock.On("GetIngestionJobTimings", ctx, jobID)}
+}
+
+func (_c *MockQuerier_GetIngestionJobTimings_Call) Run(run func(ctx context.Context, jobID uuid.UUID)) *MockQuerier_GetIngestionJobTimings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetIngestionJobTimings_Call) Return(_a0 db.IngestionJobTiming, _a1 error) *MockQuerier_GetIngestionJobTimings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetIngestionJobTimings_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.IngestionJobTiming, error)) *MockQuerier_GetIngestionJobTimings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLLMUsage provides a mock function with given fields: ctx, month
+func (_m *MockQuerier) GetLLMUsage(ctx context.Context, month time.Time) (db.LlmUsage, error) {
+	ret := _m.Called(ctx, month)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLLMUsage")
+	}
+
+	var r0 db.LlmUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (db.LlmUsage, error)); ok {
+		return rf(ctx, month)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) db.LlmUsage); ok {
+		r0 = rf(ctx, month)
+	} else {
+		r0 = ret.Get(0).(db.LlmUsage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetLLMUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLLMUsage'
+type MockQuerier_GetLLMUsage_Call struct {
+	*mock.Call
+}
+
+// GetLLMUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - month time.Time
+func (_e *MockQuerier_Expecter) GetLLMUsage(ctx interface{}, month interface{}) *MockQuerier_GetLLMUsage_Call {
+	return &MockQuerier_GetLLMUsage_Call{Call: _e.mock.On("GetLLMUsage", ctx, month)}
+}
+
+func (_c *MockQuerier_GetLLMUsage_Call) Run(run func(ctx context.Context, month time.Time)) *MockQuerier_GetLLMUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetLLMUsage_Call) Return(_a0 db.LlmUsage, _a1 error) *MockQuerier_GetLLMUsage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetLLMUsage_Call) RunAndReturn(run func(context.Context, time.Time) (db.LlmUsage, error)) *MockQuerier_GetLLMUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotificationPreference provides a mock function with given fields: ctx, kind
+func (_m *MockQuerier) GetNotificationPreference(ctx context.Context, kind string) (db.NotificationPreference, error) {
+	ret := _m.Called(ctx, kind)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationPreference")
+	}
+
+	var r0 db.NotificationPreference
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (db.NotificationPreference, error)); ok {
+		return rf(ctx, kind)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.NotificationPreference); ok {
+		r0 = rf(ctx, kind)
+	} else {
+		r0 = ret.Get(0).(db.NotificationPreference)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, kind)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetNotificationPreference_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotificationPreference'
+type MockQuerier_GetNotificationPreference_Call struct {
+	*mock.Call
+}
+
+// GetNotificationPreference is a helper method to define mock.On call
+//   - ctx context.Context
+//   - kind string
+func (_e *MockQuerier_Expecter) GetNotificationPreference(ctx interface{}, kind interface{}) *MockQuerier_GetNotificationPreference_Call {
+	return &MockQuerier_GetNotificationPreference_Call{Call: _e.mock.On("GetNotificationPreference", ctx, kind)}
+}
+
+func (_c *MockQuerier_GetNotificationPreference_Call) Run(run func(ctx context.Context, kind string)) *MockQuerier_GetNotificationPreference_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetNotificationPreference_Call) Return(_a0 db.NotificationPreference, _a1 error) *MockQuerier_GetNotificationPreference_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetNotificationPreference_Call) RunAndReturn(run func(context.Context, string) (db.NotificationPreference, error)) *MockQuerier_GetNotificationPreference_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPantryItem provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetPantryItem(ctx context.Context, id uuid.UUID) (db.PantryItem, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPantryItem")
+	}
+
+	var r0 db.PantryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.PantryItem, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.PantryItem); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.PantryItem)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetPantryItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPantryItem'
+type MockQuerier_GetPantryItem_Call struct {
+	*mock.Call
+}
+
+// GetPantryItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) GetPantryItem(ctx interface{}, id interface{}) *MockQuerier_GetPantryItem_Call {
+	return &MockQuerier_GetPantryItem_Call{Call: _e.mock.On("GetPantryItem", ctx, id)}
+}
+
+func (_c *MockQuerier_GetPantryItem_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_GetPantryItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetPantryItem_Call) Return(_a0 db.PantryItem, _a1 error) *MockQuerier_GetPantryItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetPantryItem_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.PantryItem, error)) *MockQuerier_GetPantryItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPantryItemByIngredient provides a mock function with given fields: ctx, ingredientID
+func (_m *MockQuerier) GetPantryItemByIngredient(ctx context.Context, ingredientID uuid.UUID) (db.PantryItem, error) {
+	ret := _m.Called(ctx, ingredientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPantryItemByIngredient")
+	}
+
+	var r0 db.PantryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.PantryItem, error)); ok {
+		return rf(ctx, ingredientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.PantryItem); ok {
+		r0 = rf(ctx, ingredientID)
+	} else {
+		r0 = ret.Get(0).(db.PantryItem)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ingredientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetPantryItemByIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPantryItemByIngredient'
+type MockQuerier_GetPantryItemByIngredient_Call struct {
+	*mock.Call
+}
+
+// GetPantryItemByIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientID uuid.UUID
+func (_e *MockQuerier_Expecter) GetPantryItemByIngredient(ctx interface{}, ingredientID interface{}) *MockQuerier_GetPantryItemByIngredient_Call {
+	return &MockQuerier_GetPantryItemByIngredient_Call{Call: _e.mock.On("GetPantryItemByIngredient", ctx, ingredientID)}
+}
+
+func (_c *MockQuerier_GetPantryItemByIngredient_Call) Run(run func(ctx context.Context, ingredientID uuid.UUID)) *MockQuerier_GetPantryItemByIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetPantryItemByIngredient_Call) Return(_a0 db.PantryItem, _a1 error) *MockQuerier_GetPantryItemByIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetPantryItemByIngredient_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.PantryItem, error)) *MockQuerier_GetPantryItemByIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStagedItem provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetStagedItem(ctx context.Context, id uuid.UUID) (db.StagedItem, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStagedItem")
+	}
+
+	var r0 db.StagedItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.StagedItem, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.StagedItem); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.StagedItem)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetStagedItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStagedItem'
+type MockQuerier_GetStagedItem_Call struct {
+	*mock.Call
+}
+
+// GetStagedItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) GetStagedItem(ctx interface{}, id interface{}) *MockQuerier_GetStagedItem_Call {
+	return &MockQuerier_GetStagedItem_Call{Call: _e.mock.On("GetStagedItem", ctx, id)}
+}
+
+func (_c *MockQuerier_GetStagedItem_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_GetStagedItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetStagedItem_Call) Return(_a0 db.StagedItem, _a1 error) *MockQuerier_GetStagedItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetStagedItem_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.StagedItem, error)) *MockQuerier_GetStagedItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookSubscription provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetWebhookSubscription(ctx context.Context, id uuid.UUID) (db.WebhookSubscription, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookSubscription")
+	}
+
+	var r0 db.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.WebhookSubscription, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.WebhookSubscription); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.WebhookSubscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookSubscription'
+type MockQuerier_GetWebhookSubscription_Call struct {
+	*mock.Call
+}
+
+// GetWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) GetWebhookSubscription(ctx interface{}, id interface{}) *MockQuerier_GetWebhookSubscription_Call {
+	return &MockQuerier_GetWebhookSubscription_Call{Call: _e.mock.On("GetWebhookSubscription", ctx, id)}
+}
+
+func (_c *MockQuerier_GetWebhookSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_GetWebhookSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetWebhookSubscription_Call) Return(_a0 db.WebhookSubscription, _a1 error) *MockQuerier_GetWebhookSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetWebhookSubscription_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.WebhookSubscription, error)) *MockQuerier_GetWebhookSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IngestSourceStats provides a mock function with given fields: ctx, createdAt
+func (_m *MockQuerier) IngestSourceStats(ctx context.Context, createdAt time.Time) ([]db.IngestSourceStatsRow, error) {
+	ret := _m.Called(ctx, createdAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IngestSourceStats")
+	}
+
+	var r0 []db.IngestSourceStatsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.IngestSourceStatsRow, error)); ok {
+		return rf(ctx, createdAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.IngestSourceStatsRow); ok {
+		r0 = rf(ctx, createdAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.IngestSourceStatsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, createdAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_IngestSourceStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IngestSourceStats'
+type MockQuerier_IngestSourceStats_Call struct {
+	*mock.Call
+}
+
+// IngestSourceStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - createdAt time.Time
+func (_e *MockQuerier_Expecter) IngestSourceStats(ctx interface{}, createdAt interface{}) *MockQuerier_IngestSourceStats_Call {
+	return &MockQuerier_IngestSourceStats_Call{Call: _e.mock.On("IngestSourceStats", ctx, createdAt)}
+}
+
+func (_c *MockQuerier_IngestSourceStats_Call) Run(run func(ctx context.Context, createdAt time.Time)) *MockQuerier_IngestSourceStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_IngestSourceStats_Call) Return(_a0 []db.IngestSourceStatsRow, _a1 error) *MockQuerier_IngestSourceStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_IngestSourceStats_Call) RunAndReturn(run func(context.Context, time.Time) ([]db.IngestSourceStatsRow, error)) *MockQuerier_IngestSourceStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertPantryActivity provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) InsertPantryActivity(ctx context.Context, arg db.InsertPantryActivityParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertPantryActivity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertPantryActivityParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_InsertPantryActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertPantryActivity'
+type MockQuerier_InsertPantryActivity_Call struct {
+	*mock.Call
+}
+
+// InsertPantryActivity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.InsertPantryActivityParams
+func (_e *MockQuerier_Expecter) InsertPantryActivity(ctx interface{}, arg interface{}) *MockQuerier_InsertPantryActivity_Call {
+	return &MockQuerier_InsertPantryActivity_Call{Call: _e.mock.On("InsertPantryActivity", ctx, arg)}
+}
+
+func (_c *MockQuerier_InsertPantryActivity_Call) Run(run func(ctx context.Context, arg db.InsertPantryActivityParams)) *MockQuerier_InsertPantryActivity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertPantryActivityParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_InsertPantryActivity_Call) Return(_a0 error) *MockQuerier_InsertPantryActivity_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_InsertPantryActivity_Call) RunAndReturn(run func(context.Context, db.InsertPantryActivityParams) error) *MockQuerier_InsertPantryActivity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertPantryLot provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) InsertPantryLot(ctx context.Context, arg db.InsertPantryLotParams) (db.PantryLot, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertPantryLot")
+	}
+
+	var r0 db.PantryLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertPantryLotParams) (db.PantryLot, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertPantryLotParams) db.PantryLot); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.PantryLot)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.InsertPantryLotParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_InsertPantryLot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertPantryLot'
+type MockQuerier_InsertPantryLot_Call struct {
+	*mock.Call
+}
+
+// InsertPantryLot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.InsertPantryLotParams
+func (_e *MockQuerier_Expecter) InsertPantryLot(ctx interface{}, arg interface{}) *MockQuerier_InsertPantryLot_Call {
+	return &MockQuerier_InsertPantryLot_Call{Call: _e.mock.On("InsertPantryLot", ctx, arg)}
+}
+
+func (_c *MockQuerier_InsertPantryLot_Call) Run(run func(ctx context.Context, arg db.InsertPantryLotParams)) *MockQuerier_InsertPantryLot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertPantryLotParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_InsertPantryLot_Call) Return(_a0 db.PantryLot, _a1 error) *MockQuerier_InsertPantryLot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_InsertPantryLot_Call) RunAndReturn(run func(context.Context, db.InsertPantryLotParams) (db.PantryLot, error)) *MockQuerier_InsertPantryLot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertPantryLotEvent provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) InsertPantryLotEvent(ctx context.Context, arg db.InsertPantryLotEventParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertPantryLotEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertPantryLotEventParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_InsertPantryLotEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertPantryLotEvent'
+type MockQuerier_InsertPantryLotEvent_Call struct {
+	*mock.Call
+}
+
+// InsertPantryLotEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.InsertPantryLotEventParams
+func (_e *MockQuerier_Expecter) InsertPantryLotEvent(ctx interface{}, arg interface{}) *MockQuerier_InsertPantryLotEvent_Call {
+	return &MockQuerier_InsertPantryLotEvent_Call{Call: _e.mock.On("InsertPantryLotEvent", ctx, arg)}
+}
+
+func (_c *MockQuerier_InsertPantryLotEvent_Call) Run(run func(ctx context.Context, arg db.InsertPantryLotEventParams)) *MockQuerier_InsertPantryLotEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertPantryLotEventParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_InsertPantryLotEvent_Call) Return(_a0 error) *MockQuerier_InsertPantryLotEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_InsertPantryLotEvent_Call) RunAndReturn(run func(context.Context, db.InsertPantryLotEventParams) error) *MockQuerier_InsertPantryLotEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertWebhookDelivery provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) InsertWebhookDelivery(ctx context.Context, arg db.InsertWebhookDeliveryParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertWebhookDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertWebhookDeliveryParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_InsertWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertWebhookDelivery'
+type MockQuerier_InsertWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// InsertWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.InsertWebhookDeliveryParams
+func (_e *MockQuerier_Expecter) InsertWebhookDelivery(ctx interface{}, arg interface{}) *MockQuerier_InsertWebhookDelivery_Call {
+	return &MockQuerier_InsertWebhookDelivery_Call{Call: _e.mock.On("InsertWebhookDelivery", ctx, arg)}
+}
+
+func (_c *MockQuerier_InsertWebhookDelivery_Call) Run(run func(ctx context.Context, arg db.InsertWebhookDeliveryParams)) *MockQuerier_InsertWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertWebhookDeliveryParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_InsertWebhookDelivery_Call) Return(_a0 error) *MockQuerier_InsertWebhookDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_InsertWebhookDelivery_Call) RunAndReturn(run func(context.Context, db.InsertWebhookDeliveryParams) error) *MockQuerier_InsertWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAllPantryLots provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListAllPantryLots(ctx context.Context) ([]db.PantryLot, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAllPantryLots")
+	}
+
+	var r0 []db.PantryLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.PantryLot, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.PantryLot); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PantryLot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListAllPantryLots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAllPantryLots'
+type MockQuerier_ListAllPantryLots_Call struct {
+	*mock.Call
+}
+
+// ListAllPantryLots is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListAllPantryLots(ctx interface{}) *MockQuerier_ListAllPantryLots_Call {
+	return &MockQuerier_ListAllPantryLots_Call{Call: _e.mock.On("ListAllPantryLots", ctx)}
+}
+
+func (_c *MockQuerier_ListAllPantryLots_Call) Run(run func(ctx context.Context)) *MockQuerier_ListAllPantryLots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListAllPantryLots_Call) Return(_a0 []db.PantryLot, _a1 error) *MockQuerier_ListAllPantryLots_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListAllPantryLots_Call) RunAndReturn(run func(context.Context) ([]db.PantryLot, error)) *MockQuerier_ListAllPantryLots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCategoryDefaultUnits provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListCategoryDefaultUnits(ctx context.Context) ([]db.CategoryDefaultUnit, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCategoryDefaultUnits")
+	}
+
+	var r0 []db.CategoryDefaultUnit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.CategoryDefaultUnit, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.CategoryDefaultUnit); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.CategoryDefaultUnit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListCategoryDefaultUnits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCategoryDefaultUnits'
+type MockQuerier_ListCategoryDefaultUnits_Call struct {
+	*mock.Call
+}
+
+// ListCategoryDefaultUnits is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListCategoryDefaultUnits(ctx interface{}) *MockQuerier_ListCategoryDefaultUnits_Call {
+	return &MockQuerier_ListCategoryDefaultUnits_Call{Call: _e.mock.On("ListCategoryDefaultUnits", ctx)}
+}
+
+func (_c *MockQuerier_ListCategoryDefaultUnits_Call) Run(run func(ctx context.Context)) *MockQuerier_ListCategoryDefaultUnits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCategoryDefaultUnits_Call) Return(_a0 []db.CategoryDefaultUnit, _a1 error) *MockQuerier_ListCategoryDefaultUnits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListCategoryDefaultUnits_Call) RunAndReturn(run func(context.Context) ([]db.CategoryDefaultUnit, error)) *MockQuerier_ListCategoryDefaultUnits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCategoryPrices provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListCategoryPrices(ctx context.Context) ([]db.CategoryPrice, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCategoryPrices")
+	}
+
+	var r0 []db.CategoryPrice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.CategoryPrice, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.CategoryPrice); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.CategoryPrice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListCategoryPrices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCategoryPrices'
+type MockQuerier_ListCategoryPrices_Call struct {
+	*mock.Call
+}
+
+// ListCategoryPrices is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListCategoryPrices(ctx interface{}) *MockQuerier_ListCategoryPrices_Call {
+	return &MockQuerier_ListCategoryPrices_Call{Call: _e.mock.On("ListCategoryPrices", ctx)}
+}
+
+func (_c *MockQuerier_ListCategoryPrices_Call) Run(run func(ctx context.Context)) *MockQuerier_ListCategoryPrices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCategoryPrices_Call) Return(_a0 []db.CategoryPrice, _a1 error) *MockQuerier_ListCategoryPrices_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListCategoryPrices_Call) RunAndReturn(run func(context.Context) ([]db.CategoryPrice, error)) *MockQuerier_ListCategoryPrices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListExpiryLeadTimes provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListExpiryLeadTimes(ctx context.Context) ([]db.ExpiryLeadTime, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExpiryLeadTimes")
+	}
+
+	var r0 []db.ExpiryLeadTime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.ExpiryLeadTime, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.ExpiryLeadTime); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ExpiryLeadTime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListExpiryLeadTimes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExpiryLeadTimes'
+type MockQuerier_ListExpiryLeadTimes_Call struct {
+	*mock.Call
+}
+
+// ListExpiryLeadTimes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListExpiryLeadTimes(ctx interface{}) *MockQuerier_ListExpiryLeadTimes_Call {
+	return &MockQuerier_ListExpiryLeadTimes_Call{Call: _e.mock.On("ListExpiryLeadTimes", ctx)}
+}
+
+func (_c *MockQuerier_ListExpiryLeadTimes_Call) Run(run func(ctx context.Context)) *MockQuerier_ListExpiryLeadTimes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListExpiryLeadTimes_Call) Return(_a0 []db.ExpiryLeadTime, _a1 error) *MockQuerier_ListExpiryLeadTimes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListExpiryLeadTimes_Call) RunAndReturn(run func(context.Context) ([]db.ExpiryLeadTime, error)) *MockQuerier_ListExpiryLeadTimes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIngestionJobs provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListIngestionJobs(ctx context.Context, arg db.ListIngestionJobsParams) ([]db.IngestionJob, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngestionJobs")
+	}
+
+	var r0 []db.IngestionJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListIngestionJobsParams) ([]db.IngestionJob, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListIngestionJobsParams) []db.IngestionJob); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.IngestionJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListIngestionJobsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListIngestionJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngestionJobs'
+type MockQuerier_ListIngestionJobs_Call struct {
+	*mock.Call
+}
+
+// ListIngestionJobs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListIngestionJobsParams
+func (_e *MockQuerier_Expecter) ListIngestionJobs(ctx interface{}, arg interface{}) *MockQuerier_ListIngestionJobs_Call {
+	return &MockQuerier_ListIngestionJobs_Call{Call: _e.mock.On("ListIngestionJobs", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListIngestionJobs_Call) Run(run func(ctx context.Context, arg db.ListIngestionJobsParams)) *MockQuerier_ListIngestionJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListIngestionJobsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListIngestionJobs_Call) Return(_a0 []db.IngestionJob, _a1 error) *MockQuerier_ListIngestionJobs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListIngestionJobs_Call) RunAndReturn(run func(context.Context, db.ListIngestionJobsParams) ([]db.IngestionJob, error)) *MockQuerier_ListIngestionJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIngredientDisplayOverrides provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListIngredientDisplayOverrides(ctx context.Context) ([]db.IngredientDisplayOverride, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngredientDisplayOverrides")
+	}
+
+	var r0 []db.IngredientDisplayOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.IngredientDisplayOverride, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.IngredientDisplayOverride); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.IngredientDisplayOverride)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListIngredientDisplayOverrides_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredientDisplayOverrides'
+type MockQuerier_ListIngredientDisplayOverrides_Call struct {
+	*mock.Call
+}
+
+// ListIngredientDisplayOverrides is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListIngredientDisplayOverrides(ctx interface{}) *MockQuerier_ListIngredientDisplayOverrides_Call {
+	return &MockQuerier_ListIngredientDisplayOverrides_Call{Call: _e.mock.On("ListIngredientDisplayOverrides", ctx)}
+}
+
+func (_c *MockQuerier_ListIngredientDisplayOverrides_Call) Run(run func(ctx context.Context)) *MockQuerier_ListIngredientDisplayOverrides_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientDisplayOverrides_Call) Return(_a0 []db.IngredientDisplayOverride, _a1 error) *MockQuerier_ListIngredientDisplayOverrides_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientDisplayOverrides_Call) RunAndReturn(run func(context.Context) ([]db.IngredientDisplayOverride, error)) *MockQuerier_ListIngredientDisplayOverrides_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIngredientPrices provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListIngredientPrices(ctx context.Context) ([]db.IngredientPrice, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngredientPrices")
+	}
+
+	var r0 []db.IngredientPrice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.IngredientPrice, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.IngredientPrice); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.IngredientPrice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListIngredientPrices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredientPrices'
+type MockQuerier_ListIngredientPrices_Call struct {
+	*mock.Call
+}
+
+// ListIngredientPrices is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListIngredientPrices(ctx interface{}) *MockQuerier_ListIngredientPrices_Call {
+	return &MockQuerier_ListIngredientPrices_Call{Call: _e.mock.On("ListIngredientPrices", ctx)}
+}
+
+func (_c *MockQuerier_ListIngredientPrices_Call) Run(run func(ctx context.Context)) *MockQuerier_ListIngredientPrices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientPrices_Call) Return(_a0 []db.IngredientPrice, _a1 error) *MockQuerier_ListIngredientPrices_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListIngredientPrices_Call) RunAndReturn(run func(context.Context) ([]db.IngredientPrice, error)) *MockQuerier_ListIngredientPrices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLotHistoryTotals provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListLotHistoryTotals(ctx context.Context) ([]db.ListLotHistoryTotalsRow, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLotHistoryTotals")
+	}
+
+	var r0 []db.ListLotHistoryTotalsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.ListLotHistoryTotalsRow, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.ListLotHistoryTotalsRow); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListLotHistoryTotalsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListLotHistoryTotals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLotHistoryTotals'
+type MockQuerier_ListLotHistoryTotals_Call struct {
+	*mock.Call
+}
+
+// ListLotHistoryTotals is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListLotHistoryTotals(ctx interface{}) *MockQuerier_ListLotHistoryTotals_Call {
+	return &MockQuerier_ListLotHistoryTotals_Call{Call: _e.mock.On("ListLotHistoryTotals", ctx)}
+}
+
+func (_c *MockQuerier_ListLotHistoryTotals_Call) Run(run func(ctx context.Context)) *MockQuerier_ListLotHistoryTotals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListLotHistoryTotals_Call) Return(_a0 []db.ListLotHistoryTotalsRow, _a1 error) *MockQuerier_ListLotHistoryTotals_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListLotHistoryTotals_Call) RunAndReturn(run func(context.Context) ([]db.ListLotHistoryTotalsRow, error)) *MockQuerier_ListLotHistoryTotals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMigrationChecksums provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListMigrationChecksums(ctx context.Context) ([]db.SchemaMigrationChecksum, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMigrationChecksums")
+	}
+
+	var r0 []db.SchemaMigrationChecksum
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.SchemaMigrationChecksum, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.SchemaMigrationChecksum); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.SchemaMigrationChecksum)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListMigrationChecksums_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMigrationChecksums'
+type MockQuerier_ListMigrationChecksums_Call struct {
+	*mock.Call
+}
+
+// ListMigrationChecksums is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListMigrationChecksums(ctx interface{}) *MockQuerier_ListMigrationChecksums_Call {
+	return &MockQuerier_ListMigrationChecksums_Call{Call: _e.mock.On("ListMigrationChecksums", ctx)}
+}
+
+func (_c *MockQuerier_ListMigrationChecksums_Call) Run(run func(ctx context.Context)) *MockQuerier_ListMigrationChecksums_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListMigrationChecksums_Call) Return(_a0 []db.SchemaMigrationChecksum, _a1 error) *MockQuerier_ListMigrationChecksums_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListMigrationChecksums_Call) RunAndReturn(run func(context.Context) ([]db.SchemaMigrationChecksum, error)) *MockQuerier_ListMigrationChecksums_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMissingWatchedIngredients provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListMissingWatchedIngredients(ctx context.Context) ([]db.ListMissingWatchedIngredientsRow, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMissingWatchedIngredients")
+	}
+
+	var r0 []db.ListMissingWatchedIngredientsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.ListMissingWatchedIngredientsRow, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.ListMissingWatchedIngredientsRow); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListMissingWatchedIngredientsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListMissingWatchedIngredients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMissingWatchedIngredients'
+type MockQuerier_ListMissingWatchedIngredients_Call struct {
+	*mock.Call
+}
+
+// ListMissingWatchedIngredients is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListMissingWatchedIngredients(ctx interface{}) *MockQuerier_ListMissingWatchedIngredients_Call {
+	return &MockQuerier_ListMissingWatchedIngredients_Call{Call: _e.mock.On("ListMissingWatchedIngredients", ctx)}
+}
+
+func (_c *MockQuerier_ListMissingWatchedIngredients_Call) Run(run func(ctx context.Context)) *MockQuerier_ListMissingWatchedIngredients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListMissingWatchedIngredients_Call) Return(_a0 []db.ListMissingWatchedIngredientsRow, _a1 error) *MockQuerier_ListMissingWatchedIngredients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListMissingWatchedIngredients_Call) RunAndReturn(run func(context.Context) ([]db.ListMissingWatchedIngredientsRow, error)) *MockQuerier_ListMissingWatchedIngredients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNotificationPreferences provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListNotificationPreferences(ctx context.Context) ([]db.NotificationPreference, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNotificationPreferences")
+	}
+
+	var r0 []db.NotificationPreference
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.NotificationPreference, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.NotificationPreference); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NotificationPreference)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListNotificationPreferences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNotificationPreferences'
+type MockQuerier_ListNotificationPreferences_Call struct {
+	*mock.Call
+}
+
+// ListNotificationPreferences is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListNotificationPreferences(ctx interface{}) *MockQuerier_ListNotificationPreferences_Call {
+	return &MockQuerier_ListNotificationPreferences_Call{Call: _e.mock.On("ListNotificationPreferences", ctx)}
+}
+
+func (_c *MockQuerier_ListNotificationPreferences_Call) Run(run func(ctx context.Context)) *MockQuerier_ListNotificationPreferences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListNotificationPreferences_Call) Return(_a0 []db.NotificationPreference, _a1 error) *MockQuerier_ListNotificationPreferences_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListNotificationPreferences_Call) RunAndReturn(run func(context.Context) ([]db.NotificationPreference, error)) *MockQuerier_ListNotificationPreferences_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOutboxEvents provides a mock function with given fields: ctx, limit
+func (_m *MockQuerier) ListOutboxEvents(ctx context.Context, limit int32) ([]db.EventOutbox, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOutboxEvents")
+	}
+
+	var r0 []db.EventOutbox
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]db.EventOutbox, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []db.EventOutbox); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.EventOutbox)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListOutboxEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOutboxEvents'
+type MockQuerier_ListOutboxEvents_Call struct {
+	*mock.Call
+}
+
+// ListOutboxEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int32
+func (_e *MockQuerier_Expecter) ListOutboxEvents(ctx interface{}, limit interface{}) *MockQuerier_ListOutboxEvents_Call {
+	return &MockQuerier_ListOutboxEvents_Call{Call: _e.mock.On("ListOutboxEvents", ctx, limit)}
+}
+
+func (_c *MockQuerier_ListOutboxEvents_Call) Run(run func(ctx context.Context, limit int32)) *MockQuerier_ListOutboxEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListOutboxEvents_Call) Return(_a0 []db.EventOutbox, _a1 error) *MockQuerier_ListOutboxEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListOutboxEvents_Call) RunAndReturn(run func(context.Context, int32) ([]db.EventOutbox, error)) *MockQuerier_ListOutboxEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPantryActivity provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListPantryActivity(ctx context.Context, arg db.ListPantryActivityParams) ([]db.PantryActivity, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPantryActivity")
+	}
+
+	var r0 []db.PantryActivity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListPantryActivityParams) ([]db.PantryActivity, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListPantryActivityParams) []db.PantryActivity); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PantryActivity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListPantryActivityParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListPantryActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPantryActivity'
+type MockQuerier_ListPantryActivity_Call struct {
+	*mock.Call
+}
+
+// ListPantryActivity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListPantryActivityParams
+func (_e *MockQuerier_Expecter) ListPantryActivity(ctx interface{}, arg interface{}) *MockQuerier_ListPantryActivity_Call {
+	return &MockQuerier_ListPantryActivity_Call{Call: _e.mock.On("ListPantryActivity", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListPantryActivity_Call) Run(run func(ctx context.Context, arg db.ListPantryActivityParams)) *MockQuerier_ListPantryActivity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListPantryActivityParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryActivity_Call) Return(_a0 []db.PantryActivity, _a1 error) *MockQuerier_ListPantryActivity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryActivity_Call) RunAndReturn(run func(context.Context, db.ListPantryActivityParams) ([]db.PantryActivity, error)) *MockQuerier_ListPantryActivity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPantryIngredientIDs provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListPantryIngredientIDs(ctx context.Context) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPantryIngredientIDs")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]uuid.UUID, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []uuid.UUID); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListPantryIngredientIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPantryIngredientIDs'
+type MockQuerier_ListPantryIngredientIDs_Call struct {
+	*mock.Call
+}
+
+// ListPantryIngredientIDs is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListPantryIngredientIDs(ctx interface{}) *MockQuerier_ListPantryIngredientIDs_Call {
+	return &MockQuerier_ListPantryIngredientIDs_Call{Call: _e.mock.On("ListPantryIngredientIDs", ctx)}
+}
+
+func (_c *MockQuerier_ListPantryIngredientIDs_Call) Run(run func(ctx context.Context)) *MockQuerier_ListPantryIngredientIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryIngredientIDs_Call) Return(_a0 []uuid.UUID, _a1 error) *MockQuerier_ListPantryIngredientIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryIngredientIDs_Call) RunAndReturn(run func(context.Context) ([]uuid.UUID, error)) *MockQuerier_ListPantryIngredientIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPantryItems provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListPantryItems(ctx context.Context) ([]db.PantryItem, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPantryItems")
+	}
+
+	var r0 []db.PantryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.PantryItem, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.PantryItem); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PantryItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListPantryItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPantryItems'
+type MockQuerier_ListPantryItems_Call struct {
+	*mock.Call
+}
+
+// ListPantryItems is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListPantryItems(ctx interface{}) *MockQuerier_ListPantryItems_Call {
+	return &MockQuerier_ListPantryItems_Call{Call: _e.mock.On("ListPantryItems", ctx)}
+}
+
+func (_c *MockQuerier_ListPantryItems_Call) Run(run func(ctx context.Context)) *MockQuerier_ListPantryItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryItems_Call) Return(_a0 []db.PantryItem, _a1 error) *MockQuerier_ListPantryItems_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryItems_Call) RunAndReturn(run func(context.Context) ([]db.PantryItem, error)) *MockQuerier_ListPantryItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPantryItemsByIDs provides a mock function with given fields: ctx, ids
+func (_m *MockQuerier) ListPantryItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]db.PantryItem, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPantryItemsByIDs")
+	}
+
+	var r0 []db.PantryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]db.PantryItem, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []db.PantryItem); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PantryItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListPantryItemsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPantryItemsByIDs'
+type MockQuerier_ListPantryItemsByIDs_Call struct {
+	*mock.Call
+}
+
+// ListPantryItemsByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+func (_e *MockQuerier_Expecter) ListPantryItemsByIDs(ctx interface{}, ids interface{}) *MockQuerier_ListPantryItemsByIDs_Call {
+	return &MockQuerier_ListPantryItemsByIDs_Call{Call: _e.mock.On("ListPantryItemsByIDs", ctx, ids)}
+}
+
+func (_c *MockQuerier_ListPantryItemsByIDs_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockQuerier_ListPantryItemsByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryItemsByIDs_Call) Return(_a0 []db.PantryItem, _a1 error) *MockQuerier_ListPantryItemsByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryItemsByIDs_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]db.PantryItem, error)) *MockQuerier_ListPantryItemsByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPantryItemsByIngredients provides a mock function with given fields: ctx, ingredientIds
+func (_m *MockQuerier) ListPantryItemsByIngredients(ctx context.Context, ingredientIds []uuid.UUID) ([]db.PantryItem, error) {
+	ret := _m.Called(ctx, ingredientIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPantryItemsByIngredients")
+	}
+
+	var r0 []db.PantryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]db.PantryItem, error)); ok {
+		return rf(ctx, ingredientIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []db.PantryItem); ok {
+		r0 = rf(ctx, ingredientIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PantryItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, ingredientIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListPantryItemsByIngredients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPantryItemsByIngredients'
+type MockQuerier_ListPantryItemsByIngredients_Call struct {
+	*mock.Call
+}
+
+// ListPantryItemsByIngredients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ingredientIds []uuid.UUID
+func (_e *MockQuerier_Expecter) ListPantryItemsByIngredients(ctx interface{}, ingredientIds interface{}) *MockQuerier_ListPantryItemsByIngredients_Call {
+	return &MockQuerier_ListPantryItemsByIngredients_Call{Call: _e.mock.On("ListPantryItemsByIngredients", ctx, ingredientIds)}
+}
+
+func (_c *MockQuerier_ListPantryItemsByIngredients_Call) Run(run func(ctx context.Context, ingredientIds []uuid.UUID)) *MockQuerier_ListPantryItemsByIngredients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryItemsByIngredients_Call) Return(_a0 []db.PantryItem, _a1 error) *MockQuerier_ListPantryItemsByIngredients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryItemsByIngredients_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]db.PantryItem, error)) *MockQuerier_ListPantryItemsByIngredients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPantryItemsExpiringBefore provides a mock function with given fields: ctx, expiresAt
+func (_m *MockQuerier) ListPantryItemsExpiringBefore(ctx context.Context, expiresAt sql.NullTime) ([]db.PantryItem, error) {
+	ret := _m.Called(ctx, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPantryItemsExpiringBefore")
+	}
+
+	var r0 []db.PantryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullTime) ([]db.PantryItem, error)); ok {
+		return rf(ctx, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullTime) []db.PantryItem); ok {
+		r0 = rf(ctx, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PantryItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, sql.NullTime) error); ok {
+		r1 = rf(ctx, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListPantryItemsExpiringBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPantryItemsExpiringBefore'
+type MockQuerier_ListPantryItemsExpiringBefore_Call struct {
+	*mock.Call
+}
+
+// ListPantryItemsExpiringBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - expiresAt sql.NullTime
+func (_e *MockQuerier_Expecter) ListPantryItemsExpiringBefore(ctx interface{}, expiresAt interface{}) *MockQuerier_ListPantryItemsExpiringBefore_Call {
+	return &MockQuerier_ListPantryItemsExpiringBefore_Call{Call: _e.mock.On("ListPantryItemsExpiringBefore", ctx, expiresAt)}
+}
+
+func (_c *MockQuerier_ListPantryItemsExpiringBefore_Call) Run(run func(ctx context.Context, expiresAt sql.NullTime)) *MockQuerier_ListPantryItemsExpiringBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sql.NullTime))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryItemsExpiringBefore_Call) Return(_a0 []db.PantryItem, _a1 error) *MockQuerier_ListPantryItemsExpiringBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryItemsExpiringBefore_Call) RunAndReturn(run func(context.Context, sql.NullTime) ([]db.PantryItem, error)) *MockQuerier_ListPantryItemsExpiringBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPantryItemsIdleSince provides a mock function with given fields: ctx, updatedAt
+func (_m *MockQuerier) ListPantryItemsIdleSince(ctx context.Context, updatedAt time.Time) ([]db.PantryItem, error) {
+	ret := _m.Called(ctx, updatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPantryItemsIdleSince")
+	}
+
+	var r0 []db.PantryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.PantryItem, error)); ok {
+		return rf(ctx, updatedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.PantryItem); ok {
+		r0 = rf(ctx, updatedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PantryItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, updatedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListPantryItemsIdleSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPantryItemsIdleSince'
+type MockQuerier_ListPantryItemsIdleSince_Call struct {
+	*mock.Call
+}
+
+// ListPantryItemsIdleSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - updatedAt time.Time
+func (_e *MockQuerier_Expecter) ListPantryItemsIdleSince(ctx interface{}, updatedAt interface{}) *MockQuerier_ListPantryItemsIdleSince_Call {
+	return &MockQuerier_ListPantryItemsIdleSince_Call{Call: _e.mock.On("ListPantryItemsIdleSince", ctx, updatedAt)}
+}
+
+func (_c *MockQuerier_ListPantryItemsIdleSince_Call) Run(run func(ctx context.Context, updatedAt time.Time)) *MockQuerier_ListPantryItemsIdleSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryItemsIdleSince_Call) Return(_a0 []db.PantryItem, _a1 error) *MockQuerier_ListPantryItemsIdleSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryItemsIdleSince_Call) RunAndReturn(run func(context.Context, time.Time) ([]db.PantryItem, error)) *MockQuerier_ListPantryItemsIdleSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPantryItemsPage provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListPantryItemsPage(ctx context.Context, arg db.ListPantryItemsPageParams) ([]db.PantryItem, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPantryItemsPage")
+	}
+
+	var r0 []db.PantryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListPantryItemsPageParams) ([]db.PantryItem, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListPantryItemsPageParams) []db.PantryItem); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PantryItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListPantryItemsPageParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListPantryItemsPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPantryItemsPage'
+type MockQuerier_ListPantryItemsPage_Call struct {
+	*mock.Call
+}
+
+// ListPantryItemsPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListPantryItemsPageParams
+func (_e *MockQuerier_Expecter) ListPantryItemsPage(ctx interface{}, arg interface{}) *MockQuerier_ListPantryItemsPage_Call {
+	return &MockQuerier_ListPantryItemsPage_Call{Call: _e.mock.On("ListPantryItemsPage", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListPantryItemsPage_Call) Run(run func(ctx context.Context, arg db.ListPantryItemsPageParams)) *MockQuerier_ListPantryItemsPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListPantryItemsPageParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryItemsPage_Call) Return(_a0 []db.PantryItem, _a1 error) *MockQuerier_ListPantryItemsPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryItemsPage_Call) RunAndReturn(run func(context.Context, db.ListPantryItemsPageParams) ([]db.PantryItem, error)) *MockQuerier_ListPantryItemsPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPantryItemsUpdatedSince provides a mock function with given fields: ctx, updatedAt
+func (_m *MockQuerier) ListPantryItemsUpdatedSince(ctx context.Context, updatedAt time.Time) ([]db.PantryItem, error) {
+	ret := _m.Called(ctx, updatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPantryItemsUpdatedSince")
+	}
+
+	var r0 []db.PantryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.PantryItem, error)); ok {
+		return rf(ctx, updatedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.PantryItem); ok {
+		r0 = rf(ctx, updatedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PantryItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, updatedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListPantryItemsUpdatedSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPantryItemsUpdatedSince'
+type MockQuerier_ListPantryItemsUpdatedSince_Call struct {
+	*mock.Call
+}
+
+// ListPantryItemsUpdatedSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - updatedAt time.Time
+func (_e *MockQuerier_Expecter) ListPantryItemsUpdatedSince(ctx interface{}, updatedAt interface{}) *MockQuerier_ListPantryItemsUpdatedSince_Call {
+	return &MockQuerier_ListPantryItemsUpdatedSince_Call{Call: _e.mock.On("ListPantryItemsUpdatedSince", ctx, updatedAt)}
+}
+
+func (_c *MockQuerier_ListPantryItemsUpdatedSince_Call) Run(run func(ctx context.Context, updatedAt time.Time)) *MockQuerier_ListPantryItemsUpdatedSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryItemsUpdatedSince_Call) Return(_a0 []db.PantryItem, _a1 error) *MockQuerier_ListPantryItemsUpdatedSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryItemsUpdatedSince_Call) RunAndReturn(run func(context.Context, time.Time) ([]db.PantryItem, error)) *MockQuerier_ListPantryItemsUpdatedSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPantryLotEventsByItem provides a mock function with given fields: ctx, pantryItemID
+func (_m *MockQuerier) ListPantryLotEventsByItem(ctx context.Context, pantryItemID uuid.UUID) ([]db.PantryLotEvent, error) {
+	ret := _m.Called(ctx, pantryItemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPantryLotEventsByItem")
+	}
+
+	var r0 []db.PantryLotEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.PantryLotEvent, error)); ok {
+		return rf(ctx, pantryItemID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.PantryLotEvent); ok {
+		r0 = rf(ctx, pantryItemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PantryLotEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, pantryItemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListPantryLotEventsByItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPantryLotEventsByItem'
+type MockQuerier_ListPantryLotEventsByItem_Call struct {
+	*mock.Call
+}
+
+// ListPantryLotEventsByItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pantryItemID uuid.UUID
+func (_e *MockQuerier_Expecter) ListPantryLotEventsByItem(ctx interface{}, pantryItemID interface{}) *MockQuerier_ListPantryLotEventsByItem_Call {
+	return &MockQuerier_ListPantryLotEventsByItem_Call{Call: _e.mock.On("ListPantryLotEventsByItem", ctx, pantryItemID)}
+}
+
+func (_c *MockQuerier_ListPantryLotEventsByItem_Call) Run(run func(ctx context.Context, pantryItemID uuid.UUID)) *MockQuerier_ListPantryLotEventsByItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryLotEventsByItem_Call) Return(_a0 []db.PantryLotEvent, _a1 error) *MockQuerier_ListPantryLotEventsByItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryLotEventsByItem_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.PantryLotEvent, error)) *MockQuerier_ListPantryLotEventsByItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPantryLotsByItem provides a mock function with given fields: ctx, pantryItemID
+func (_m *MockQuerier) ListPantryLotsByItem(ctx context.Context, pantryItemID uuid.UUID) ([]db.PantryLot, error) {
+	ret := _m.Called(ctx, pantryItemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPantryLotsByItem")
+	}
+
+	var r0 []db.PantryLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.PantryLot, error)); ok {
+		return rf(ctx, pantryItemID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.PantryLot); ok {
+		r0 = rf(ctx, pantryItemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PantryLot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, pantryItemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListPantryLotsByItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPantryLotsByItem'
+type MockQuerier_ListPantryLotsByItem_Call struct {
+	*mock.Call
+}
+
+// ListPantryLotsByItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pantryItemID uuid.UUID
+func (_e *MockQuerier_Expecter) ListPantryLotsByItem(ctx interface{}, pantryItemID interface{}) *MockQuerier_ListPantryLotsByItem_Call {
+	return &MockQuerier_ListPantryLotsByItem_Call{Call: _e.mock.On("ListPantryLotsByItem", ctx, pantryItemID)}
+}
+
+func (_c *MockQuerier_ListPantryLotsByItem_Call) Run(run func(ctx context.Context, pantryItemID uuid.UUID)) *MockQuerier_ListPantryLotsByItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryLotsByItem_Call) Return(_a0 []db.PantryLot, _a1 error) *MockQuerier_ListPantryLotsByItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryLotsByItem_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.PantryLot, error)) *MockQuerier_ListPantryLotsByItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPantryTombstonesByItems provides a mock function with given fields: ctx, itemIds
+func (_m *MockQuerier) ListPantryTombstonesByItems(ctx context.Context, itemIds []uuid.UUID) ([]db.PantryItemTombstone, error) {
+	ret := _m.Called(ctx, itemIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPantryTombstonesByItems")
+	}
+
+	var r0 []db.PantryItemTombstone
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]db.PantryItemTombstone, error)); ok {
+		return rf(ctx, itemIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []db.PantryItemTombstone); ok {
+		r0 = rf(ctx, itemIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PantryItemTombstone)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, itemIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListPantryTombstonesByItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPantryTombstonesByItems'
+type MockQuerier_ListPantryTombstonesByItems_Call struct {
+	*mock.Call
+}
+
+// ListPantryTombstonesByItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - itemIds []uuid.UUID
+func (_e *MockQuerier_Expecter) ListPantryTombstonesByItems(ctx interface{}, itemIds interface{}) *MockQuerier_ListPantryTombstonesByItems_Call {
+	return &MockQuerier_ListPantryTombstonesByItems_Call{Call: _e.mock.On("ListPantryTombstonesByItems", ctx, itemIds)}
+}
+
+func (_c *MockQuerier_ListPantryTombstonesByItems_Call) Run(run func(ctx context.Context, itemIds []uuid.UUID)) *MockQuerier_ListPantryTombstonesByItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryTombstonesByItems_Call) Return(_a0 []db.PantryItemTombstone, _a1 error) *MockQuerier_ListPantryTombstonesByItems_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryTombstonesByItems_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]db.PantryItemTombstone, error)) *MockQuerier_ListPantryTombstonesByItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPantryTombstonesSince provides a mock function with given fields: ctx, deletedAt
+func (_m *MockQuerier) ListPantryTombstonesSince(ctx context.Context, deletedAt time.Time) ([]db.PantryItemTombstone, error) {
+	ret := _m.Called(ctx, deletedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPantryTombstonesSince")
+	}
+
+	var r0 []db.PantryItemTombstone
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.PantryItemTombstone, error)); ok {
+		return rf(ctx, deletedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.PantryItemTombstone); ok {
+		r0 = rf(ctx, deletedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PantryItemTombstone)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, deletedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListPantryTombstonesSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPantryTombstonesSince'
+type MockQuerier_ListPantryTombstonesSince_Call struct {
+	*mock.Call
+}
+
+// ListPantryTombstonesSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deletedAt time.Time
+func (_e *MockQuerier_Expecter) ListPantryTombstonesSince(ctx interface{}, deletedAt interface{}) *MockQuerier_ListPantryTombstonesSince_Call {
+	return &MockQuerier_ListPantryTombstonesSince_Call{Call: _e.mock.On("ListPantryTombstonesSince", ctx, deletedAt)}
+}
+
+func (_c *MockQuerier_ListPantryTombstonesSince_Call) Run(run func(ctx context.Context, deletedAt time.Time)) *MockQuerier_ListPantryTombstonesSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryTombstonesSince_Call) Return(_a0 []db.PantryItemTombstone, _a1 error) *MockQuerier_ListPantryTombstonesSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListPantryTombstonesSince_Call) RunAndReturn(run func(context.Context, time.Time) ([]db.PantryItemTombstone, error)) *MockQuerier_ListPantryTombstonesSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRecentNotifications provides a mock function with given fields: ctx, limit
+func (_m *MockQuerier) ListRecentNotifications(ctx context.Context, limit int32) ([]db.Notification, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRecentNotifications")
+	}
+
+	var r0 []db.Notification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]db.Notification, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []db.Notification); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListRecentNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRecentNotifications'
+type MockQuerier_ListRecentNotifications_Call struct {
+	*mock.Call
+}
+
+// ListRecentNotifications is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int32
+func (_e *MockQuerier_Expecter) ListRecentNotifications(ctx interface{}, limit interface{}) *MockQuerier_ListRecentNotifications_Call {
+	return &MockQuerier_ListRecentNotifications_Call{Call: _e.mock.On("ListRecentNotifications", ctx, limit)}
+}
+
+func (_c *MockQuerier_ListRecentNotifications_Call) Run(run func(ctx context.Context, limit int32)) *MockQuerier_ListRecentNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListRecentNotifications_Call) Return(_a0 []db.Notification, _a1 error) *MockQuerier_ListRecentNotifications_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListRecentNotifications_Call) RunAndReturn(run func(context.Context, int32) ([]db.Notification, error)) *MockQuerier_ListRecentNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListReferencedIngredients provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListReferencedIngredients(ctx context.Context) ([]db.ListReferencedIngredientsRow, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReferencedIngredients")
+	}
+
+	var r0 []db.ListReferencedIngredientsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.ListReferencedIngredientsRow, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.ListReferencedIngredientsRow); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListReferencedIngredientsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListReferencedIngredients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReferencedIngredients'
+type MockQuerier_ListReferencedIngredients_Call struct {
+	*mock.Call
+}
+
+// ListReferencedIngredients is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListReferencedIngredients(ctx interface{}) *MockQuerier_ListReferencedIngredients_Call {
+	return &MockQuerier_ListReferencedIngredients_Call{Call: _e.mock.On("ListReferencedIngredients", ctx)}
+}
+
+func (_c *MockQuerier_ListReferencedIngredients_Call) Run(run func(ctx context.Context)) *MockQuerier_ListReferencedIngredients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListReferencedIngredients_Call) Return(_a0 []db.ListReferencedIngredientsRow, _a1 error) *MockQuerier_ListReferencedIngredients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListReferencedIngredients_Call) RunAndReturn(run func(context.Context) ([]db.ListReferencedIngredientsRow, error)) *MockQuerier_ListReferencedIngredients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListReviewRules provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListReviewRules(ctx context.Context) ([]db.ReviewRule, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReviewRules")
+	}
+
+	var r0 []db.ReviewRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.ReviewRule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.ReviewRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ReviewRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListReviewRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReviewRules'
+type MockQuerier_ListReviewRules_Call struct {
+	*mock.Call
+}
+
+// ListReviewRules is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListReviewRules(ctx interface{}) *MockQuerier_ListReviewRules_Call {
+	return &MockQuerier_ListReviewRules_Call{Call: _e.mock.On("ListReviewRules", ctx)}
+}
+
+func (_c *MockQuerier_ListReviewRules_Call) Run(run func(ctx context.Context)) *MockQuerier_ListReviewRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListReviewRules_Call) Return(_a0 []db.ReviewRule, _a1 error) *MockQuerier_ListReviewRules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListReviewRules_Call) RunAndReturn(run func(context.Context) ([]db.ReviewRule, error)) *MockQuerier_ListReviewRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListShadowExtractions provides a mock function with given fields: ctx, limit
+func (_m *MockQuerier) ListShadowExtractions(ctx context.Context, limit int32) ([]db.ShadowExtraction, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListShadowExtractions")
+	}
+
+	var r0 []db.ShadowExtraction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]db.ShadowExtraction, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []db.ShadowExtraction); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ShadowExtraction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListShadowExtractions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListShadowExtractions'
+type MockQuerier_ListShadowExtractions_Call struct {
+	*mock.Call
+}
+
+// ListShadowExtractions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int32
+func (_e *MockQuerier_Expecter) ListShadowExtractions(ctx interface{}, limit interface{}) *MockQuerier_ListShadowExtractions_Call {
+	return &MockQuerier_ListShadowExtractions_Call{Call: _e.mock.On("ListShadowExtractions", ctx, limit)}
+}
+
+func (_c *MockQuerier_ListShadowExtractions_Call) Run(run func(ctx context.Context, limit int32)) *MockQuerier_ListShadowExtractions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListShadowExtractions_Call) Return(_a0 []db.ShadowExtraction, _a1 error) *MockQuerier_ListShadowExtractions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListShadowExtractions_Call) RunAndReturn(run func(context.Context, int32) ([]db.ShadowExtraction, error)) *MockQuerier_ListShadowExtractions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStagedItemsByJob provides a mock function with given fields: ctx, jobID
+func (_m *MockQuerier) ListStagedItemsByJob(ctx context.Context, jobID uuid.UUID) ([]db.StagedItem, error) {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStagedItemsByJob")
+	}
+
+	var r0 []db.StagedItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.StagedItem, error)); ok {
+		return rf(ctx, jobID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.StagedItem); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StagedItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListStagedItemsByJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStagedItemsByJob'
+type MockQuerier_ListStagedItemsByJob_Call struct {
+	*mock.Call
+}
+
+// ListStagedItemsByJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID uuid.UUID
+func (_e *MockQuerier_Expecter) ListStagedItemsByJob(ctx interface{}, jobID interface{}) *MockQuerier_ListStagedItemsByJob_Call {
+	return &MockQuerier_ListStagedItemsByJob_Call{Call: _e.mock.On("ListStagedItemsByJob", ctx, jobID)}
+}
+
+func (_c *MockQuerier_ListStagedItemsByJob_Call) Run(run func(ctx context.Context, jobID uuid.UUID)) *MockQuerier_ListStagedItemsByJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListStagedItemsByJob_Call) Return(_a0 []db.StagedItem, _a1 error) *MockQuerier_ListStagedItemsByJob_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListStagedItemsByJob_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]db.StagedItem, error)) *MockQuerier_ListStagedItemsByJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStaleItems provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListStaleItems(ctx context.Context) ([]db.ListStaleItemsRow, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStaleItems")
+	}
+
+	var r0 []db.ListStaleItemsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.ListStaleItemsRow, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.ListStaleItemsRow); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListStaleItemsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListStaleItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStaleItems'
+type MockQuerier_ListStaleItems_Call struct {
+	*mock.Call
+}
+
+// ListStaleItems is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListStaleItems(ctx interface{}) *MockQuerier_ListStaleItems_Call {
+	return &MockQuerier_ListStaleItems_Call{Call: _e.mock.On("ListStaleItems", ctx)}
+}
+
+func (_c *MockQuerier_ListStaleItems_Call) Run(run func(ctx context.Context)) *MockQuerier_ListStaleItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListStaleItems_Call) Return(_a0 []db.ListStaleItemsRow, _a1 error) *MockQuerier_ListStaleItems_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListStaleItems_Call) RunAndReturn(run func(context.Context) ([]db.ListStaleItemsRow, error)) *MockQuerier_ListStaleItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTableStats provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListTableStats(ctx context.Context) ([]db.ListTableStatsRow, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTableStats")
+	}
+
+	var r0 []db.ListTableStatsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.ListTableStatsRow, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.ListTableStatsRow); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListTableStatsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListTableStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTableStats'
+type MockQuerier_ListTableStats_Call struct {
+	*mock.Call
+}
+
+// ListTableStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListTableStats(ctx interface{}) *MockQuerier_ListTableStats_Call {
+	return &MockQuerier_ListTableStats_Call{Call: _e.mock.On("ListTableStats", ctx)}
+}
+
+func (_c *MockQuerier_ListTableStats_Call) Run(run func(ctx context.Context)) *MockQuerier_ListTableStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListTableStats_Call) Return(_a0 []db.ListTableStatsRow, _a1 error) *MockQuerier_ListTableStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListTableStats_Call) RunAndReturn(run func(context.Context) ([]db.ListTableStatsRow, error)) *MockQuerier_ListTableStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUnsentNotifications provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListUnsentNotifications(ctx context.Context, arg db.ListUnsentNotificationsParams) ([]db.Notification, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnsentNotifications")
+	}
+
+	var r0 []db.Notification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListUnsentNotificationsParams) ([]db.Notification, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListUnsentNotificationsParams) []db.Notification); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListUnsentNotificationsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListUnsentNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnsentNotifications'
+type MockQuerier_ListUnsentNotifications_Call struct {
+	*mock.Call
+}
+
+// ListUnsentNotifications is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListUnsentNotificationsParams
+func (_e *MockQuerier_Expecter) ListUnsentNotifications(ctx interface{}, arg interface{}) *MockQuerier_ListUnsentNotifications_Call {
+	return &MockQuerier_ListUnsentNotifications_Call{Call: _e.mock.On("ListUnsentNotifications", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListUnsentNotifications_Call) Run(run func(ctx context.Context, arg db.ListUnsentNotificationsParams)) *MockQuerier_ListUnsentNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListUnsentNotificationsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListUnsentNotifications_Call) Return(_a0 []db.Notification, _a1 error) *MockQuerier_ListUnsentNotifications_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListUnsentNotifications_Call) RunAndReturn(run func(context.Context, db.ListUnsentNotificationsParams) ([]db.Notification, error)) *MockQuerier_ListUnsentNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWatchlist provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListWatchlist(ctx context.Context) ([]db.Watchlist, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWatchlist")
+	}
+
+	var r0 []db.Watchlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.Watchlist, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.Watchlist); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Watchlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListWatchlist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWatchlist'
+type MockQuerier_ListWatchlist_Call struct {
+	*mock.Call
+}
+
+// ListWatchlist is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListWatchlist(ctx interface{}) *MockQuerier_ListWatchlist_Call {
+	return &MockQuerier_ListWatchlist_Call{Call: _e.mock.On("ListWatchlist", ctx)}
+}
+
+func (_c *MockQuerier_ListWatchlist_Call) Run(run func(ctx context.Context)) *MockQuerier_ListWatchlist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListWatchlist_Call) Return(_a0 []db.Watchlist, _a1 error) *MockQuerier_ListWatchlist_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListWatchlist_Call) RunAndReturn(run func(context.Context) ([]db.Watchlist, error)) *MockQuerier_ListWatchlist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookDeliveries provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListWebhookDeliveries(ctx context.Context, arg db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveries")
+	}
+
+	var r0 []db.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ListWebhookDeliveriesParams) []db.WebhookDelivery); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ListWebhookDeliveriesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeliveries'
+type MockQuerier_ListWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListWebhookDeliveriesParams
+func (_e *MockQuerier_Expecter) ListWebhookDeliveries(ctx interface{}, arg interface{}) *MockQuerier_ListWebhookDeliveries_Call {
+	return &MockQuerier_ListWebhookDeliveries_Call{Call: _e.mock.On("ListWebhookDeliveries", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListWebhookDeliveries_Call) Run(run func(ctx context.Context, arg db.ListWebhookDeliveriesParams)) *MockQuerier_ListWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ListWebhookDeliveriesParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListWebhookDeliveries_Call) Return(_a0 []db.WebhookDelivery, _a1 error) *MockQuerier_ListWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListWebhookDeliveries_Call) RunAndReturn(run func(context.Context, db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error)) *MockQuerier_ListWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookSubscriptions provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListWebhookSubscriptions(ctx context.Context) ([]db.WebhookSubscription, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookSubscriptions")
+	}
+
+	var r0 []db.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.WebhookSubscription, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.WebhookSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListWebhookSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookSubscriptions'
+type MockQuerier_ListWebhookSubscriptions_Call struct {
+	*mock.Call
+}
+
+// ListWebhookSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListWebhookSubscriptions(ctx interface{}) *MockQuerier_ListWebhookSubscriptions_Call {
+	return &MockQuerier_ListWebhookSubscriptions_Call{Call: _e.mock.On("ListWebhookSubscriptions", ctx)}
+}
+
+func (_c *MockQuerier_ListWebhookSubscriptions_Call) Run(run func(ctx context.Context)) *MockQuerier_ListWebhookSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListWebhookSubscriptions_Call) Return(_a0 []db.WebhookSubscription, _a1 error) *MockQuerier_ListWebhookSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListWebhookSubscriptions_Call) RunAndReturn(run func(context.Context) ([]db.WebhookSubscription, error)) *MockQuerier_ListWebhookSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkIngestionJobBudgetExceeded provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) MarkIngestionJobBudgetExceeded(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkIngestionJobBudgetExceeded")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_MarkIngestionJobBudgetExceeded_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkIngestionJobBudgetExceeded'
+type MockQuerier_MarkIngestionJobBudgetExceeded_Call struct {
+	*mock.Call
+}
+
+// MarkIngestionJobBudgetExceeded is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) MarkIngestionJobBudgetExceeded(ctx interface{}, id interface{}) *MockQuerier_MarkIngestionJobBudgetExceeded_Call {
+	return &MockQuerier_MarkIngestionJobBudgetExceeded_Call{Call: _e.mock.On("MarkIngestionJobBudgetExceeded", ctx, id)}
+}
+
+func (_c *MockQuerier_MarkIngestionJobBudgetExceeded_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_MarkIngestionJobBudgetExceeded_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_MarkIngestionJobBudgetExceeded_Call) Return(_a0 error) *MockQuerier_MarkIngestionJobBudgetExceeded_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_MarkIngestionJobBudgetExceeded_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockQuerier_MarkIngestionJobBudgetExceeded_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkIngestionJobTruncated provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) MarkIngestionJobTruncated(ctx context.Context, arg db.MarkIngestionJobTruncatedParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkIngestionJobTruncated")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkIngestionJobTruncatedParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_MarkIngestionJobTruncated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkIngestionJobTruncated'
+type MockQuerier_MarkIngestionJobTruncated_Call struct {
+	*mock.Call
+}
+
+// MarkIngestionJobTruncated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.MarkIngestionJobTruncatedParams
+func (_e *MockQuerier_Expecter) MarkIngestionJobTruncated(ctx interface{}, arg interface{}) *MockQuerier_MarkIngestionJobTruncated_Call {
+	return &MockQuerier_MarkIngestionJobTruncated_Call{Call: _e.mock.On("MarkIngestionJobTruncated", ctx, arg)}
+}
+
+func (_c *MockQuerier_MarkIngestionJobTruncated_Call) Run(run func(ctx context.Context, arg db.MarkIngestionJobTruncatedParams)) *MockQuerier_MarkIngestionJobTruncated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.MarkIngestionJobTruncatedParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_MarkIngestionJobTruncated_Call) Return(_a0 error) *MockQuerier_MarkIngestionJobTruncated_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_MarkIngestionJobTruncated_Call) RunAndReturn(run func(context.Context, db.MarkIngestionJobTruncatedParams) error) *MockQuerier_MarkIngestionJobTruncated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkMessageProcessed provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) MarkMessageProcessed(ctx context.Context, arg db.MarkMessageProcessedParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkMessageProcessed")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkMessageProcessedParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.MarkMessageProcessedParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.MarkMessageProcessedParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_MarkMessageProcessed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkMessageProcessed'
+type MockQuerier_MarkMessageProcessed_Call struct {
+	*mock.Call
+}
+
+// MarkMessageProcessed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.MarkMessageProcessedParams
+func (_e *MockQuerier_Expecter) MarkMessageProcessed(ctx interface{}, arg interface{}) *MockQuerier_MarkMessageProcessed_Call {
+	return &MockQuerier_MarkMessageProcessed_Call{Call: _e.mock.On("MarkMessageProcessed", ctx, arg)}
+}
+
+func (_c *MockQuerier_MarkMessageProcessed_Call) Run(run func(ctx context.Context, arg db.MarkMessageProcessedParams)) *MockQuerier_MarkMessageProcessed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.MarkMessageProcessedParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_MarkMessageProcessed_Call) Return(_a0 int64, _a1 error) *MockQuerier_MarkMessageProcessed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_MarkMessageProcessed_Call) RunAndReturn(run func(context.Context, db.MarkMessageProcessedParams) (int64, error)) *MockQuerier_MarkMessageProcessed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkNotificationSent provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) MarkNotificationSent(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkNotificationSent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_MarkNotificationSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkNotificationSent'
+type MockQuerier_MarkNotificationSent_Call struct {
+	*mock.Call
+}
+
+// MarkNotificationSent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockQuerier_Expecter) MarkNotificationSent(ctx interface{}, id interface{}) *MockQuerier_MarkNotificationSent_Call {
+	return &MockQuerier_MarkNotificationSent_Call{Call: _e.mock.On("MarkNotificationSent", ctx, id)}
+}
+
+func (_c *MockQuerier_MarkNotificationSent_Call) Run(run func(ctx context.Context, id int64)) *MockQuerier_MarkNotificationSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_MarkNotificationSent_Call) Return(_a0 error) *MockQuerier_MarkNotificationSent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_MarkNotificationSent_Call) RunAndReturn(run func(context.Context, int64) error) *MockQuerier_MarkNotificationSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkWebhookEventSent provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) MarkWebhookEventSent(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkWebhookEventSent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_MarkWebhookEventSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkWebhookEventSent'
+type MockQuerier_MarkWebhookEventSent_Call struct {
+	*mock.Call
+}
+
+// MarkWebhookEventSent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockQuerier_Expecter) MarkWebhookEventSent(ctx interface{}, id interface{}) *MockQuerier_MarkWebhookEventSent_Call {
+	return &MockQuerier_MarkWebhookEventSent_Call{Call: _e.mock.On("MarkWebhookEventSent", ctx, id)}
+}
+
+func (_c *MockQuerier_MarkWebhookEventSent_Call) Run(run func(ctx context.Context, id int64)) *MockQuerier_MarkWebhookEventSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_MarkWebhookEventSent_Call) Return(_a0 error) *MockQuerier_MarkWebhookEventSent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_MarkWebhookEventSent_Call) RunAndReturn(run func(context.Context, int64) error) *MockQuerier_MarkWebhookEventSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeIntoPantryLot provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) MergeIntoPantryLot(ctx context.Context, arg db.MergeIntoPantryLotParams) (db.PantryLot, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeIntoPantryLot")
+	}
+
+	var r0 db.PantryLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.MergeIntoPantryLotParams) (db.PantryLot, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.MergeIntoPantryLotParams) db.PantryLot); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.PantryLot)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.MergeIntoPantryLotParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_MergeIntoPantryLot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeIntoPantryLot'
+type MockQuerier_MergeIntoPantryLot_Call struct {
+	*mock.Call
+}
+
+// MergeIntoPantryLot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.MergeIntoPantryLotParams
+func (_e *MockQuerier_Expecter) MergeIntoPantryLot(ctx interface{}, arg interface{}) *MockQuerier_MergeIntoPantryLot_Call {
+	return &MockQuerier_MergeIntoPantryLot_Call{Call: _e.mock.On("MergeIntoPantryLot", ctx, arg)}
+}
+
+func (_c *MockQuerier_MergeIntoPantryLot_Call) Run(run func(ctx context.Context, arg db.MergeIntoPantryLotParams)) *MockQuerier_MergeIntoPantryLot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.MergeIntoPantryLotParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_MergeIntoPantryLot_Call) Return(_a0 db.PantryLot, _a1 error) *MockQuerier_MergeIntoPantryLot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_MergeIntoPantryLot_Call) RunAndReturn(run func(context.Context, db.MergeIntoPantryLotParams) (db.PantryLot, error)) *MockQuerier_MergeIntoPantryLot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MigrationChecksumsRecorded provides a mock function with given fields: ctx
+func (_m *MockQuerier) MigrationChecksumsRecorded(ctx context.Context) (bool, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrationChecksumsRecorded")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_MigrationChecksumsRecorded_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MigrationChecksumsRecorded'
+type MockQuerier_MigrationChecksumsRecorded_Call struct {
+	*mock.Call
+}
+
+// MigrationChecksumsRecorded is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) MigrationChecksumsRecorded(ctx interface{}) *MockQuerier_MigrationChecksumsRecorded_Call {
+	return &MockQuerier_MigrationChecksumsRecorded_Call{Call: _e.mock.On("MigrationChecksumsRecorded", ctx)}
+}
+
+func (_c *MockQuerier_MigrationChecksumsRecorded_Call) Run(run func(ctx context.Context)) *MockQuerier_MigrationChecksumsRecorded_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_MigrationChecksumsRecorded_Call) Return(_a0 bool, _a1 error) *MockQuerier_MigrationChecksumsRecorded_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_MigrationChecksumsRecorded_Call) RunAndReturn(run func(context.Context) (bool, error)) *MockQuerier_MigrationChecksumsRecorded_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PantryChangesCutoff provides a mock function with given fields: ctx
+func (_m *MockQuerier) PantryChangesCutoff(ctx context.Context) (time.Time, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PantryChangesCutoff")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (time.Time, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) time.Time); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_PantryChangesCutoff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PantryChangesCutoff'
+type MockQuerier_PantryChangesCutoff_Call struct {
+	*mock.Call
+}
+
+// PantryChangesCutoff is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) PantryChangesCutoff(ctx interface{}) *MockQuerier_PantryChangesCutoff_Call {
+	return &MockQuerier_PantryChangesCutoff_Call{Call: _e.mock.On("PantryChangesCutoff", ctx)}
+}
+
+func (_c *MockQuerier_PantryChangesCutoff_Call) Run(run func(ctx context.Context)) *MockQuerier_PantryChangesCutoff_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_PantryChangesCutoff_Call) Return(_a0 time.Time, _a1 error) *MockQuerier_PantryChangesCutoff_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_PantryChangesCutoff_Call) RunAndReturn(run func(context.Context) (time.Time, error)) *MockQuerier_PantryChangesCutoff_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordMigrationChecksum provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RecordMigrationChecksum(ctx context.Context, arg db.RecordMigrationChecksumParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordMigrationChecksum")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RecordMigrationChecksumParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_RecordMigrationChecksum_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordMigrationChecksum'
+type MockQuerier_RecordMigrationChecksum_Call struct {
+	*mock.Call
+}
+
+// RecordMigrationChecksum is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RecordMigrationChecksumParams
+func (_e *MockQuerier_Expecter) RecordMigrationChecksum(ctx interface{}, arg interface{}) *MockQuerier_RecordMigrationChecksum_Call {
+	return &MockQuerier_RecordMigrationChecksum_Call{Call: _e.mock.On("RecordMigrationChecksum", ctx, arg)}
+}
+
+func (_c *MockQuerier_RecordMigrationChecksum_Call) Run(run func(ctx context.Context, arg db.RecordMigrationChecksumParams)) *MockQuerier_RecordMigrationChecksum_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RecordMigrationChecksumParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RecordMigrationChecksum_Call) Return(_a0 error) *MockQuerier_RecordMigrationChecksum_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_RecordMigrationChecksum_Call) RunAndReturn(run func(context.Context, db.RecordMigrationChecksumParams) error) *MockQuerier_RecordMigrationChecksum_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordNotificationFailure provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RecordNotificationFailure(ctx context.Context, arg db.RecordNotificationFailureParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordNotificationFailure")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RecordNotificationFailureParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_RecordNotificationFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordNotificationFailure'
+type MockQuerier_RecordNotificationFailure_Call struct {
+	*mock.Call
+}
+
+// RecordNotificationFailure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RecordNotificationFailureParams
+func (_e *MockQuerier_Expecter) RecordNotificationFailure(ctx interface{}, arg interface{}) *MockQuerier_RecordNotificationFailure_Call {
+	return &MockQuerier_RecordNotificationFailure_Call{Call: _e.mock.On("RecordNotificationFailure", ctx, arg)}
+}
+
+func (_c *MockQuerier_RecordNotificationFailure_Call) Run(run func(ctx context.Context, arg db.RecordNotificationFailureParams)) *MockQuerier_RecordNotificationFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RecordNotificationFailureParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RecordNotificationFailure_Call) Return(_a0 error) *MockQuerier_RecordNotificationFailure_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_RecordNotificationFailure_Call) RunAndReturn(run func(context.Context, db.RecordNotificationFailureParams) error) *MockQuerier_RecordNotificationFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordOutboxFailure provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RecordOutboxFailure(ctx context.Context, arg db.RecordOutboxFailureParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordOutboxFailure")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RecordOutboxFailureParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_RecordOutboxFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordOutboxFailure'
+type MockQuerier_RecordOutboxFailure_Call struct {
+	*mock.Call
+}
+
+// RecordOutboxFailure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RecordOutboxFailureParams
+func (_e *MockQuerier_Expecter) RecordOutboxFailure(ctx interface{}, arg interface{}) *MockQuerier_RecordOutboxFailure_Call {
+	return &MockQuerier_RecordOutboxFailure_Call{Call: _e.mock.On("RecordOutboxFailure", ctx, arg)}
+}
+
+func (_c *MockQuerier_RecordOutboxFailure_Call) Run(run func(ctx context.Context, arg db.RecordOutboxFailureParams)) *MockQuerier_RecordOutboxFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RecordOutboxFailureParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RecordOutboxFailure_Call) Return(_a0 error) *MockQuerier_RecordOutboxFailure_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_RecordOutboxFailure_Call) RunAndReturn(run func(context.Context, db.RecordOutboxFailureParams) error) *MockQuerier_RecordOutboxFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordWebhookEventFailure provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RecordWebhookEventFailure(ctx context.Context, arg db.RecordWebhookEventFailureParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordWebhookEventFailure")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RecordWebhookEventFailureParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_RecordWebhookEventFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordWebhookEventFailure'
+type MockQuerier_RecordWebhookEventFailure_Call struct {
+	*mock.Call
+}
+
+// RecordWebhookEventFailure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RecordWebhookEventFailureParams
+func (_e *MockQuerier_Expecter) RecordWebhookEventFailure(ctx interface{}, arg interface{}) *MockQuerier_RecordWebhookEventFailure_Call {
+	return &MockQuerier_RecordWebhookEventFailure_Call{Call: _e.mock.On("RecordWebhookEventFailure", ctx, arg)}
+}
+
+func (_c *MockQuerier_RecordWebhookEventFailure_Call) Run(run func(ctx context.Context, arg db.RecordWebhookEventFailureParams)) *MockQuerier_RecordWebhookEventFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RecordWebhookEventFailureParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RecordWebhookEventFailure_Call) Return(_a0 error) *MockQuerier_RecordWebhookEventFailure_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_RecordWebhookEventFailure_Call) RunAndReturn(run func(context.Context, db.RecordWebhookEventFailureParams) error) *MockQuerier_RecordWebhookEventFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReindexIngestionJobs provides a mock function with given fields: ctx
+func (_m *MockQuerier) ReindexIngestionJobs(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReindexIngestionJobs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_ReindexIngestionJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReindexIngestionJobs'
+type MockQuerier_ReindexIngestionJobs_Call struct {
+	*mock.Call
+}
+
+// ReindexIngestionJobs is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ReindexIngestionJobs(ctx interface{}) *MockQuerier_ReindexIngestionJobs_Call {
+	return &MockQuerier_ReindexIngestionJobs_Call{Call: _e.mock.On("ReindexIngestionJobs", ctx)}
+}
+
+func (_c *MockQuerier_ReindexIngestionJobs_Call) Run(run func(ctx context.Context)) *MockQuerier_ReindexIngestionJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ReindexIngestionJobs_Call) Return(_a0 error) *MockQuerier_ReindexIngestionJobs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_ReindexIngestionJobs_Call) RunAndReturn(run func(context.Context) error) *MockQuerier_ReindexIngestionJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReindexPantryItems provides a mock function with given fields: ctx
+func (_m *MockQuerier) ReindexPantryItems(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReindexPantryItems")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_ReindexPantryItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReindexPantryItems'
+type MockQuerier_ReindexPantryItems_Call struct {
+	*mock.Call
+}
+
+// ReindexPantryItems is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ReindexPantryItems(ctx interface{}) *MockQuerier_ReindexPantryItems_Call {
+	return &MockQuerier_ReindexPantryItems_Call{Call: _e.mock.On("ReindexPantryItems", ctx)}
+}
+
+func (_c *MockQuerier_ReindexPantryItems_Call) Run(run func(ctx context.Context)) *MockQuerier_ReindexPantryItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ReindexPantryItems_Call) Return(_a0 error) *MockQuerier_ReindexPantryItems_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_ReindexPantryItems_Call) RunAndReturn(run func(context.Context) error) *MockQuerier_ReindexPantryItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReindexStagedItems provides a mock function with given fields: ctx
+func (_m *MockQuerier) ReindexStagedItems(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReindexStagedItems")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_ReindexStagedItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReindexStagedItems'
+type MockQuerier_ReindexStagedItems_Call struct {
+	*mock.Call
+}
+
+// ReindexStagedItems is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ReindexStagedItems(ctx interface{}) *MockQuerier_ReindexStagedItems_Call {
+	return &MockQuerier_ReindexStagedItems_Call{Call: _e.mock.On("ReindexStagedItems", ctx)}
+}
+
+func (_c *MockQuerier_ReindexStagedItems_Call) Run(run func(ctx context.Context)) *MockQuerier_ReindexStagedItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ReindexStagedItems_Call) Return(_a0 error) *MockQuerier_ReindexStagedItems_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_ReindexStagedItems_Call) RunAndReturn(run func(context.Context) error) *MockQuerier_ReindexStagedItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemapActivityIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RemapActivityIngredient(ctx context.Context, arg db.RemapActivityIngredientParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemapActivityIngredient")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RemapActivityIngredientParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RemapActivityIngredientParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RemapActivityIngredientParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RemapActivityIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemapActivityIngredient'
+type MockQuerier_RemapActivityIngredient_Call struct {
+	*mock.Call
+}
+
+// RemapActivityIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RemapActivityIngredientParams
+func (_e *MockQuerier_Expecter) RemapActivityIngredient(ctx interface{}, arg interface{}) *MockQuerier_RemapActivityIngredient_Call {
+	return &MockQuerier_RemapActivityIngredient_Call{Call: _e.mock.On("RemapActivityIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_RemapActivityIngredient_Call) Run(run func(ctx context.Context, arg db.RemapActivityIngredientParams)) *MockQuerier_RemapActivityIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RemapActivityIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RemapActivityIngredient_Call) Return(_a0 int64, _a1 error) *MockQuerier_RemapActivityIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RemapActivityIngredient_Call) RunAndReturn(run func(context.Context, db.RemapActivityIngredientParams) (int64, error)) *MockQuerier_RemapActivityIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemapDisplayOverrideIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RemapDisplayOverrideIngredient(ctx context.Context, arg db.RemapDisplayOverrideIngredientParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemapDisplayOverrideIngredient")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RemapDisplayOverrideIngredientParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RemapDisplayOverrideIngredientParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RemapDisplayOverrideIngredientParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RemapDisplayOverrideIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemapDisplayOverrideIngredient'
+type MockQuerier_RemapDisplayOverrideIngredient_Call struct {
+	*mock.Call
+}
+
+// RemapDisplayOverrideIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RemapDisplayOverrideIngredientParams
+func (_e *MockQuerier_Expecter) RemapDisplayOverrideIngredient(ctx interface{}, arg interface{}) *MockQuerier_RemapDisplayOverrideIngredient_Call {
+	return &MockQuerier_RemapDisplayOverrideIngredient_Call{Call: _e.mock.On("RemapDisplayOverrideIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_RemapDisplayOverrideIngredient_Call) Run(run func(ctx context.Context, arg db.RemapDisplayOverrideIngredientParams)) *MockQuerier_RemapDisplayOverrideIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RemapDisplayOverrideIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RemapDisplayOverrideIngredient_Call) Return(_a0 int64, _a1 error) *MockQuerier_RemapDisplayOverrideIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RemapDisplayOverrideIngredient_Call) RunAndReturn(run func(context.Context, db.RemapDisplayOverrideIngredientParams) (int64, error)) *MockQuerier_RemapDisplayOverrideIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemapPantryItemIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RemapPantryItemIngredient(ctx context.Context, arg db.RemapPantryItemIngredientParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemapPantryItemIngredient")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RemapPantryItemIngredientParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RemapPantryItemIngredientParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RemapPantryItemIngredientParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RemapPantryItemIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemapPantryItemIngredient'
+type MockQuerier_RemapPantryItemIngredient_Call struct {
+	*mock.Call
+}
+
+// RemapPantryItemIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RemapPantryItemIngredientParams
+func (_e *MockQuerier_Expecter) RemapPantryItemIngredient(ctx interface{}, arg interface{}) *MockQuerier_RemapPantryItemIngredient_Call {
+	return &MockQuerier_RemapPantryItemIngredient_Call{Call: _e.mock.On("RemapPantryItemIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_RemapPantryItemIngredient_Call) Run(run func(ctx context.Context, arg db.RemapPantryItemIngredientParams)) *MockQuerier_RemapPantryItemIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RemapPantryItemIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RemapPantryItemIngredient_Call) Return(_a0 int64, _a1 error) *MockQuerier_RemapPantryItemIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RemapPantryItemIngredient_Call) RunAndReturn(run func(context.Context, db.RemapPantryItemIngredientParams) (int64, error)) *MockQuerier_RemapPantryItemIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemapStagedItemIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RemapStagedItemIngredient(ctx context.Context, arg db.RemapStagedItemIngredientParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemapStagedItemIngredient")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RemapStagedItemIngredientParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RemapStagedItemIngredientParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RemapStagedItemIngredientParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RemapStagedItemIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemapStagedItemIngredient'
+type MockQuerier_RemapStagedItemIngredient_Call struct {
+	*mock.Call
+}
+
+// RemapStagedItemIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RemapStagedItemIngredientParams
+func (_e *MockQuerier_Expecter) RemapStagedItemIngredient(ctx interface{}, arg interface{}) *MockQuerier_RemapStagedItemIngredient_Call {
+	return &MockQuerier_RemapStagedItemIngredient_Call{Call: _e.mock.On("RemapStagedItemIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_RemapStagedItemIngredient_Call) Run(run func(ctx context.Context, arg db.RemapStagedItemIngredientParams)) *MockQuerier_RemapStagedItemIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RemapStagedItemIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RemapStagedItemIngredient_Call) Return(_a0 int64, _a1 error) *MockQuerier_RemapStagedItemIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RemapStagedItemIngredient_Call) RunAndReturn(run func(context.Context, db.RemapStagedItemIngredientParams) (int64, error)) *MockQuerier_RemapStagedItemIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemapTombstoneIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RemapTombstoneIngredient(ctx context.Context, arg db.RemapTombstoneIngredientParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemapTombstoneIngredient")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RemapTombstoneIngredientParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RemapTombstoneIngredientParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RemapTombstoneIngredientParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RemapTombstoneIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemapTombstoneIngredient'
+type MockQuerier_RemapTombstoneIngredient_Call struct {
+	*mock.Call
+}
+
+// RemapTombstoneIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RemapTombstoneIngredientParams
+func (_e *MockQuerier_Expecter) RemapTombstoneIngredient(ctx interface{}, arg interface{}) *MockQuerier_RemapTombstoneIngredient_Call {
+	return &MockQuerier_RemapTombstoneIngredient_Call{Call: _e.mock.On("RemapTombstoneIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_RemapTombstoneIngredient_Call) Run(run func(ctx context.Context, arg db.RemapTombstoneIngredientParams)) *MockQuerier_RemapTombstoneIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RemapTombstoneIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RemapTombstoneIngredient_Call) Return(_a0 int64, _a1 error) *MockQuerier_RemapTombstoneIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RemapTombstoneIngredient_Call) RunAndReturn(run func(context.Context, db.RemapTombstoneIngredientParams) (int64, error)) *MockQuerier_RemapTombstoneIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemapWatchlistIngredient provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RemapWatchlistIngredient(ctx context.Context, arg db.RemapWatchlistIngredientParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemapWatchlistIngredient")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RemapWatchlistIngredientParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RemapWatchlistIngredientParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RemapWatchlistIngredientParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RemapWatchlistIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemapWatchlistIngredient'
+type MockQuerier_RemapWatchlistIngredient_Call struct {
+	*mock.Call
+}
+
+// RemapWatchlistIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RemapWatchlistIngredientParams
+func (_e *MockQuerier_Expecter) RemapWatchlistIngredient(ctx interface{}, arg interface{}) *MockQuerier_RemapWatchlistIngredient_Call {
+	return &MockQuerier_RemapWatchlistIngredient_Call{Call: _e.mock.On("RemapWatchlistIngredient", ctx, arg)}
+}
+
+func (_c *MockQuerier_RemapWatchlistIngredient_Call) Run(run func(ctx context.Context, arg db.RemapWatchlistIngredientParams)) *MockQuerier_RemapWatchlistIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RemapWatchlistIngredientParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RemapWatchlistIngredient_Call) Return(_a0 int64, _a1 error) *MockQuerier_RemapWatchlistIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RemapWatchlistIngredient_Call) RunAndReturn(run func(context.Context, db.RemapWatchlistIngredientParams) (int64, error)) *MockQuerier_RemapWatchlistIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequeueStaleIngestionJobs provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) RequeueStaleIngestionJobs(ctx context.Context, arg db.RequeueStaleIngestionJobsParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequeueStaleIngestionJobs")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.RequeueStaleIngestionJobsParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.RequeueStaleIngestionJobsParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.RequeueStaleIngestionJobsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_RequeueStaleIngestionJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequeueStaleIngestionJobs'
+type MockQuerier_RequeueStaleIngestionJobs_Call struct {
+	*mock.Call
+}
+
+// RequeueStaleIngestionJobs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.RequeueStaleIngestionJobsParams
+func (_e *MockQuerier_Expecter) RequeueStaleIngestionJobs(ctx interface{}, arg interface{}) *MockQuerier_RequeueStaleIngestionJobs_Call {
+	return &MockQuerier_RequeueStaleIngestionJobs_Call{Call: _e.mock.On("RequeueStaleIngestionJobs", ctx, arg)}
+}
+
+func (_c *MockQuerier_RequeueStaleIngestionJobs_Call) Run(run func(ctx context.Context, arg db.RequeueStaleIngestionJobsParams)) *MockQuerier_RequeueStaleIngestionJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.RequeueStaleIngestionJobsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RequeueStaleIngestionJobs_Call) Return(_a0 int64, _a1 error) *MockQuerier_RequeueStaleIngestionJobs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_RequeueStaleIngestionJobs_Call) RunAndReturn(run func(context.Context, db.RequeueStaleIngestionJobsParams) (int64, error)) *MockQuerier_RequeueStaleIngestionJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetLLMUsage provides a mock function with given fields: ctx, month
+func (_m *MockQuerier) ResetLLMUsage(ctx context.Context, month time.Time) error {
+	ret := _m.Called(ctx, month)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetLLMUsage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, month)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_ResetLLMUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetLLMUsage'
+type MockQuerier_ResetLLMUsage_Call struct {
+	*mock.Call
+}
+
+// ResetLLMUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - month time.Time
+func (_e *MockQuerier_Expecter) ResetLLMUsage(ctx interface{}, month interface{}) *MockQuerier_ResetLLMUsage_Call {
+	return &MockQuerier_ResetLLMUsage_Call{Call: _e.mock.On("ResetLLMUsage", ctx, month)}
+}
+
+func (_c *MockQuerier_ResetLLMUsage_Call) Run(run func(ctx context.Context, month time.Time)) *MockQuerier_ResetLLMUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ResetLLMUsage_Call) Return(_a0 error) *MockQuerier_ResetLLMUsage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_ResetLLMUsage_Call) RunAndReturn(run func(context.Context, time.Time) error) *MockQuerier_ResetLLMUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StagedItemsFingerprint provides a mock function with given fields: ctx, jobID
+func (_m *MockQuerier) StagedItemsFingerprint(ctx context.Context, jobID uuid.UUID) (db.StagedItemsFingerprintRow, error) {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StagedItemsFingerprint")
+	}
+
+	var r0 db.StagedItemsFingerprintRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.StagedItemsFingerprintRow, error)); ok {
+		return rf(ctx, jobID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.StagedItemsFingerprintRow); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		r0 = ret.Get(0).(db.StagedItemsFingerprintRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_StagedItemsFingerprint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StagedItemsFingerprint'
+type MockQuerier_StagedItemsFingerprint_Call struct {
+	*mock.Call
+}
+
+// StagedItemsFingerprint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID uuid.UUID
+func (_e *MockQuerier_Expecter) StagedItemsFingerprint(ctx interface{}, jobID interface{}) *MockQuerier_StagedItemsFingerprint_Call {
+	return &MockQuerier_StagedItemsFingerprint_Call{Call: _e.mock.On("StagedItemsFingerprint", ctx, jobID)}
+}
+
+func (_c *MockQuerier_StagedItemsFingerprint_Call) Run(run func(ctx context.Context, jobID uuid.UUID)) *MockQuerier_StagedItemsFingerprint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_StagedItemsFingerprint_Call) Return(_a0 db.StagedItemsFingerprintRow, _a1 error) *MockQuerier_StagedItemsFingerprint_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_StagedItemsFingerprint_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.StagedItemsFingerprintRow, error)) *MockQuerier_StagedItemsFingerprint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SyncPantryItemExpiryFromLots provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) SyncPantryItemExpiryFromLots(ctx context.Context, id uuid.UUID) (db.PantryItem, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncPantryItemExpiryFromLots")
+	}
+
+	var r0 db.PantryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (db.PantryItem, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) db.PantryItem); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.PantryItem)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_SyncPantryItemExpiryFromLots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncPantryItemExpiryFromLots'
+type MockQuerier_SyncPantryItemExpiryFromLots_Call struct {
+	*mock.Call
+}
+
+// SyncPantryItemExpiryFromLots is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockQuerier_Expecter) SyncPantryItemExpiryFromLots(ctx interface{}, id interface{}) *MockQuerier_SyncPantryItemExpiryFromLots_Call {
+	return &MockQuerier_SyncPantryItemExpiryFromLots_Call{Call: _e.mock.On("SyncPantryItemExpiryFromLots", ctx, id)}
+}
+
+func (_c *MockQuerier_SyncPantryItemExpiryFromLots_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockQuerier_SyncPantryItemExpiryFromLots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_SyncPantryItemExpiryFromLots_Call) Return(_a0 db.PantryItem, _a1 error) *MockQuerier_SyncPantryItemExpiryFromLots_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_SyncPantryItemExpiryFromLots_Call) RunAndReturn(run func(context.Context, uuid.UUID) (db.PantryItem, error)) *MockQuerier_SyncPantryItemExpiryFromLots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransitionIngestionJobStatus provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) TransitionIngestionJobStatus(ctx context.Context, arg db.TransitionIngestionJobStatusParams) (db.IngestionJob, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransitionIngestionJobStatus")
+	}
+
+	var r0 db.IngestionJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.TransitionIngestionJobStatusParams) (db.IngestionJob, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.TransitionIngestionJobStatusParams) db.IngestionJob); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.IngestionJob)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.TransitionIngestionJobStatusParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_TransitionIngestionJobStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransitionIngestionJobStatus'
+type MockQuerier_TransitionIngestionJobStatus_Call struct {
+	*mock.Call
+}
+
+// TransitionIngestionJobStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.TransitionIngestionJobStatusParams
+func (_e *MockQuerier_Expecter) TransitionIngestionJobStatus(ctx interface{}, arg interface{}) *MockQuerier_TransitionIngestionJobStatus_Call {
+	return &MockQuerier_TransitionIngestionJobStatus_Call{Call: _e.mock.On("TransitionIngestionJobStatus", ctx, arg)}
+}
+
+func (_c *MockQuerier_TransitionIngestionJobStatus_Call) Run(run func(ctx context.Context, arg db.TransitionIngestionJobStatusParams)) *MockQuerier_TransitionIngestionJobStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.TransitionIngestionJobStatusParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_TransitionIngestionJobStatus_Call) Return(_a0 db.IngestionJob, _a1 error) *MockQuerier_TransitionIngestionJobStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_TransitionIngestionJobStatus_Call) RunAndReturn(run func(context.Context, db.TransitionIngestionJobStatusParams) (db.IngestionJob, error)) *MockQuerier_TransitionIngestionJobStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnmarkMessageProcessed provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UnmarkMessageProcessed(ctx context.Context, arg db.UnmarkMessageProcessedParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnmarkMessageProcessed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UnmarkMessageProcessedParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_UnmarkMessageProcessed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnmarkMessageProcessed'
+type MockQuerier_UnmarkMessageProcessed_Call struct {
+	*mock.Call
+}
+
+// UnmarkMessageProcessed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UnmarkMessageProcessedParams
+func (_e *MockQuerier_Expecter) UnmarkMessageProcessed(ctx interface{}, arg interface{}) *MockQuerier_UnmarkMessageProcessed_Call {
+	return &MockQuerier_UnmarkMessageProcessed_Call{Call: _e.mock.On("UnmarkMessageProcessed", ctx, arg)}
+}
+
+func (_c *MockQuerier_UnmarkMessageProcessed_Call) Run(run func(ctx context.Context, arg db.UnmarkMessageProcessedParams)) *MockQuerier_UnmarkMessageProcessed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UnmarkMessageProcessedParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UnmarkMessageProcessed_Call) Return(_a0 error) *MockQuerier_UnmarkMessageProcessed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_UnmarkMessageProcessed_Call) RunAndReturn(run func(context.Context, db.UnmarkMessageProcessedParams) error) *MockQuerier_UnmarkMessageProcessed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePantryItem provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdatePantryItem(ctx context.Context, arg db.UpdatePantryItemParams) (db.PantryItem, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePantryItem")
+	}
+
+	var r0 db.PantryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdatePantryItemParams) (db.PantryItem, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdatePantryItemParams) db.PantryItem); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.PantryItem)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdatePantryItemParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpdatePantryItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePantryItem'
+type MockQuerier_UpdatePantryItem_Call struct {
+	*mock.Call
+}
+
+// UpdatePantryItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpdatePantryItemParams
+func (_e *MockQuerier_Expecter) UpdatePantryItem(ctx interface{}, arg interface{}) *MockQuerier_UpdatePantryItem_Call {
+	return &MockQuerier_UpdatePantryItem_Call{Call: _e.mock.On("UpdatePantryItem", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdatePantryItem_Call) Run(run func(ctx context.Context, arg db.UpdatePantryItemParams)) *MockQuerier_UpdatePantryItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpdatePantryItemParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdatePantryItem_Call) Return(_a0 db.PantryItem, _a1 error) *MockQuerier_UpdatePantryItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpdatePantryItem_Call) RunAndReturn(run func(context.Context, db.UpdatePantryItemParams) (db.PantryItem, error)) *MockQuerier_UpdatePantryItem_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -580,21 +6501,524 @@ func (_m *MockQuerier) UpdateStagedItem(ctx context.Context, arg db.UpdateStaged
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateStagedItem")
+		panic("no return value specified for UpdateStagedItem")
+	}
+
+	var r0 db.StagedItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateStagedItemParams) (db.StagedItem, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateStagedItemParams) db.StagedItem); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.StagedItem)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateStagedItemParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpdateStagedItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStagedItem'
+type MockQuerier_UpdateStagedItem_Call struct {
+	*mock.Call
+}
+
+// UpdateStagedItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpdateStagedItemParams
+func (_e *MockQuerier_Expecter) UpdateStagedItem(ctx interface{}, arg interface{}) *MockQuerier_UpdateStagedItem_Call {
+	return &MockQuerier_UpdateStagedItem_Call{Call: _e.mock.On("UpdateStagedItem", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateStagedItem_Call) Run(run func(ctx context.Context, arg db.UpdateStagedItemParams)) *MockQuerier_UpdateStagedItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpdateStagedItemParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateStagedItem_Call) Return(_a0 db.StagedItem, _a1 error) *MockQuerier_UpdateStagedItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateStagedItem_Call) RunAndReturn(run func(context.Context, db.UpdateStagedItemParams) (db.StagedItem, error)) *MockQuerier_UpdateStagedItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStagedItemExtraction provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateStagedItemExtraction(ctx context.Context, arg db.UpdateStagedItemExtractionParams) (db.StagedItem, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStagedItemExtraction")
+	}
+
+	var r0 db.StagedItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateStagedItemExtractionParams) (db.StagedItem, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateStagedItemExtractionParams) db.StagedItem); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.StagedItem)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateStagedItemExtractionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpdateStagedItemExtraction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStagedItemExtraction'
+type MockQuerier_UpdateStagedItemExtraction_Call struct {
+	*mock.Call
+}
+
+// UpdateStagedItemExtraction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpdateStagedItemExtractionParams
+func (_e *MockQuerier_Expecter) UpdateStagedItemExtraction(ctx interface{}, arg interface{}) *MockQuerier_UpdateStagedItemExtraction_Call {
+	return &MockQuerier_UpdateStagedItemExtraction_Call{Call: _e.mock.On("UpdateStagedItemExtraction", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateStagedItemExtraction_Call) Run(run func(ctx context.Context, arg db.UpdateStagedItemExtractionParams)) *MockQuerier_UpdateStagedItemExtraction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpdateStagedItemExtractionParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateStagedItemExtraction_Call) Return(_a0 db.StagedItem, _a1 error) *MockQuerier_UpdateStagedItemExtraction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateStagedItemExtraction_Call) RunAndReturn(run func(context.Context, db.UpdateStagedItemExtractionParams) (db.StagedItem, error)) *MockQuerier_UpdateStagedItemExtraction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWebhookSubscription provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateWebhookSubscription(ctx context.Context, arg db.UpdateWebhookSubscriptionParams) (db.WebhookSubscription, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhookSubscription")
+	}
+
+	var r0 db.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateWebhookSubscriptionParams) (db.WebhookSubscription, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateWebhookSubscriptionParams) db.WebhookSubscription); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.WebhookSubscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateWebhookSubscriptionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpdateWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhookSubscription'
+type MockQuerier_UpdateWebhookSubscription_Call struct {
+	*mock.Call
+}
+
+// UpdateWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpdateWebhookSubscriptionParams
+func (_e *MockQuerier_Expecter) UpdateWebhookSubscription(ctx interface{}, arg interface{}) *MockQuerier_UpdateWebhookSubscription_Call {
+	return &MockQuerier_UpdateWebhookSubscription_Call{Call: _e.mock.On("UpdateWebhookSubscription", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateWebhookSubscription_Call) Run(run func(ctx context.Context, arg db.UpdateWebhookSubscriptionParams)) *MockQuerier_UpdateWebhookSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpdateWebhookSubscriptionParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateWebhookSubscription_Call) Return(_a0 db.WebhookSubscription, _a1 error) *MockQuerier_UpdateWebhookSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateWebhookSubscription_Call) RunAndReturn(run func(context.Context, db.UpdateWebhookSubscriptionParams) (db.WebhookSubscription, error)) *MockQuerier_UpdateWebhookSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertCategoryDefaultUnit provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertCategoryDefaultUnit(ctx context.Context, arg db.UpsertCategoryDefaultUnitParams) (db.CategoryDefaultUnit, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertCategoryDefaultUnit")
+	}
+
+	var r0 db.CategoryDefaultUnit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertCategoryDefaultUnitParams) (db.CategoryDefaultUnit, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertCategoryDefaultUnitParams) db.CategoryDefaultUnit); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.CategoryDefaultUnit)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertCategoryDefaultUnitParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpsertCategoryDefaultUnit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertCategoryDefaultUnit'
+type MockQuerier_UpsertCategoryDefaultUnit_Call struct {
+	*mock.Call
+}
+
+// UpsertCategoryDefaultUnit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertCategoryDefaultUnitParams
+func (_e *MockQuerier_Expecter) UpsertCategoryDefaultUnit(ctx interface{}, arg interface{}) *MockQuerier_UpsertCategoryDefaultUnit_Call {
+	return &MockQuerier_UpsertCategoryDefaultUnit_Call{Call: _e.mock.On("UpsertCategoryDefaultUnit", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertCategoryDefaultUnit_Call) Run(run func(ctx context.Context, arg db.UpsertCategoryDefaultUnitParams)) *MockQuerier_UpsertCategoryDefaultUnit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertCategoryDefaultUnitParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertCategoryDefaultUnit_Call) Return(_a0 db.CategoryDefaultUnit, _a1 error) *MockQuerier_UpsertCategoryDefaultUnit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertCategoryDefaultUnit_Call) RunAndReturn(run func(context.Context, db.UpsertCategoryDefaultUnitParams) (db.CategoryDefaultUnit, error)) *MockQuerier_UpsertCategoryDefaultUnit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertCategoryPrice provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertCategoryPrice(ctx context.Context, arg db.UpsertCategoryPriceParams) (db.CategoryPrice, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertCategoryPrice")
+	}
+
+	var r0 db.CategoryPrice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertCategoryPriceParams) (db.CategoryPrice, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertCategoryPriceParams) db.CategoryPrice); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.CategoryPrice)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertCategoryPriceParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpsertCategoryPrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertCategoryPrice'
+type MockQuerier_UpsertCategoryPrice_Call struct {
+	*mock.Call
+}
+
+// UpsertCategoryPrice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertCategoryPriceParams
+func (_e *MockQuerier_Expecter) UpsertCategoryPrice(ctx interface{}, arg interface{}) *MockQuerier_UpsertCategoryPrice_Call {
+	return &MockQuerier_UpsertCategoryPrice_Call{Call: _e.mock.On("UpsertCategoryPrice", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertCategoryPrice_Call) Run(run func(ctx context.Context, arg db.UpsertCategoryPriceParams)) *MockQuerier_UpsertCategoryPrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertCategoryPriceParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertCategoryPrice_Call) Return(_a0 db.CategoryPrice, _a1 error) *MockQuerier_UpsertCategoryPrice_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertCategoryPrice_Call) RunAndReturn(run func(context.Context, db.UpsertCategoryPriceParams) (db.CategoryPrice, error)) *MockQuerier_UpsertCategoryPrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertExpiryLeadTime provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertExpiryLeadTime(ctx context.Context, arg db.UpsertExpiryLeadTimeParams) (db.ExpiryLeadTime, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertExpiryLeadTime")
+	}
+
+	var r0 db.ExpiryLeadTime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertExpiryLeadTimeParams) (db.ExpiryLeadTime, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertExpiryLeadTimeParams) db.ExpiryLeadTime); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.ExpiryLeadTime)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertExpiryLeadTimeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpsertExpiryLeadTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertExpiryLeadTime'
+type MockQuerier_UpsertExpiryLeadTime_Call struct {
+	*mock.Call
+}
+
+// UpsertExpiryLeadTime is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertExpiryLeadTimeParams
+func (_e *MockQuerier_Expecter) UpsertExpiryLeadTime(ctx interface{}, arg interface{}) *MockQuerier_UpsertExpiryLeadTime_Call {
+	return &MockQuerier_UpsertExpiryLeadTime_Call{Call: _e.mock.On("UpsertExpiryLeadTime", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertExpiryLeadTime_Call) Run(run func(ctx context.Context, arg db.UpsertExpiryLeadTimeParams)) *MockQuerier_UpsertExpiryLeadTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertExpiryLeadTimeParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertExpiryLeadTime_Call) Return(_a0 db.ExpiryLeadTime, _a1 error) *MockQuerier_UpsertExpiryLeadTime_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertExpiryLeadTime_Call) RunAndReturn(run func(context.Context, db.UpsertExpiryLeadTimeParams) (db.ExpiryLeadTime, error)) *MockQuerier_UpsertExpiryLeadTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertIngestionJobTimings provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertIngestionJobTimings(ctx context.Context, arg db.UpsertIngestionJobTimingsParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertIngestionJobTimings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertIngestionJobTimingsParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_UpsertIngestionJobTimings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertIngestionJobTimings'
+type MockQuerier_UpsertIngestionJobTimings_Call struct {
+	*mock.Call
+}
+
+// UpsertIngestionJobTimings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertIngestionJobTimingsParams
+func (_e *MockQuerier_Expecter) UpsertIngestionJobTimings(ctx interface{}, arg interface{}) *MockQuerier_UpsertIngestionJobTimings_Call {
+	return &MockQuerier_UpsertIngestionJobTimings_Call{Call: _e.mock.On("UpsertIngestionJobTimings", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertIngestionJobTimings_Call) Run(run func(ctx context.Context, arg db.UpsertIngestionJobTimingsParams)) *MockQuerier_UpsertIngestionJobTimings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertIngestionJobTimingsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertIngestionJobTimings_Call) Return(_a0 error) *MockQuerier_UpsertIngestionJobTimings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertIngestionJobTimings_Call) RunAndReturn(run func(context.Context, db.UpsertIngestionJobTimingsParams) error) *MockQuerier_UpsertIngestionJobTimings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertIngredientDisplayOverride provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertIngredientDisplayOverride(ctx context.Context, arg db.UpsertIngredientDisplayOverrideParams) (db.IngredientDisplayOverride, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertIngredientDisplayOverride")
+	}
+
+	var r0 db.IngredientDisplayOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertIngredientDisplayOverrideParams) (db.IngredientDisplayOverride, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertIngredientDisplayOverrideParams) db.IngredientDisplayOverride); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.IngredientDisplayOverride)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertIngredientDisplayOverrideParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpsertIngredientDisplayOverride_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertIngredientDisplayOverride'
+type MockQuerier_UpsertIngredientDisplayOverride_Call struct {
+	*mock.Call
+}
+
+// UpsertIngredientDisplayOverride is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertIngredientDisplayOverrideParams
+func (_e *MockQuerier_Expecter) UpsertIngredientDisplayOverride(ctx interface{}, arg interface{}) *MockQuerier_UpsertIngredientDisplayOverride_Call {
+	return &MockQuerier_UpsertIngredientDisplayOverride_Call{Call: _e.mock.On("UpsertIngredientDisplayOverride", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertIngredientDisplayOverride_Call) Run(run func(ctx context.Context, arg db.UpsertIngredientDisplayOverrideParams)) *MockQuerier_UpsertIngredientDisplayOverride_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertIngredientDisplayOverrideParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertIngredientDisplayOverride_Call) Return(_a0 db.IngredientDisplayOverride, _a1 error) *MockQuerier_UpsertIngredientDisplayOverride_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertIngredientDisplayOverride_Call) RunAndReturn(run func(context.Context, db.UpsertIngredientDisplayOverrideParams) (db.IngredientDisplayOverride, error)) *MockQuerier_UpsertIngredientDisplayOverride_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertIngredientPrice provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertIngredientPrice(ctx context.Context, arg db.UpsertIngredientPriceParams) (db.IngredientPrice, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertIngredientPrice")
+	}
+
+	var r0 db.IngredientPrice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertIngredientPriceParams) (db.IngredientPrice, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertIngredientPriceParams) db.IngredientPrice); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.IngredientPrice)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertIngredientPriceParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpsertIngredientPrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertIngredientPrice'
+type MockQuerier_UpsertIngredientPrice_Call struct {
+	*mock.Call
+}
+
+// UpsertIngredientPrice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertIngredientPriceParams
+func (_e *MockQuerier_Expecter) UpsertIngredientPrice(ctx interface{}, arg interface{}) *MockQuerier_UpsertIngredientPrice_Call {
+	return &MockQuerier_UpsertIngredientPrice_Call{Call: _e.mock.On("UpsertIngredientPrice", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertIngredientPrice_Call) Run(run func(ctx context.Context, arg db.UpsertIngredientPriceParams)) *MockQuerier_UpsertIngredientPrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertIngredientPriceParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertIngredientPrice_Call) Return(_a0 db.IngredientPrice, _a1 error) *MockQuerier_UpsertIngredientPrice_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertIngredientPrice_Call) RunAndReturn(run func(context.Context, db.UpsertIngredientPriceParams) (db.IngredientPrice, error)) *MockQuerier_UpsertIngredientPrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertNotificationPreference provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertNotificationPreference(ctx context.Context, arg db.UpsertNotificationPreferenceParams) (db.NotificationPreference, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertNotificationPreference")
 	}
 
-	var r0 db.StagedItem
+	var r0 db.NotificationPreference
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateStagedItemParams) (db.StagedItem, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertNotificationPreferenceParams) (db.NotificationPreference, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.UpdateStagedItemParams) db.StagedItem); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertNotificationPreferenceParams) db.NotificationPreference); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.StagedItem)
+		r0 = ret.Get(0).(db.NotificationPreference)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.UpdateStagedItemParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertNotificationPreferenceParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -603,31 +7027,31 @@ func (_m *MockQuerier) UpdateStagedItem(ctx context.Context, arg db.UpdateStaged
 	return r0, r1
 }
 
-// MockQuerier_UpdateStagedItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStagedItem'
-type MockQuerier_UpdateStagedItem_Call struct {
+// MockQuerier_UpsertNotificationPreference_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertNotificationPreference'
+type MockQuerier_UpsertNotificationPreference_Call struct {
 	*mock.Call
 }
 
-// UpdateStagedItem is a helper method to define mock.On call
+// UpsertNotificationPreference is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.UpdateStagedItemParams
-func (_e *MockQuerier_Expecter) UpdateStagedItem(ctx interface{}, arg interface{}) *MockQuerier_UpdateStagedItem_Call {
-	return &MockQuerier_UpdateStagedItem_Call{Call: _e.mock.On("UpdateStagedItem", ctx, arg)}
+//   - arg db.UpsertNotificationPreferenceParams
+func (_e *MockQuerier_Expecter) UpsertNotificationPreference(ctx interface{}, arg interface{}) *MockQuerier_UpsertNotificationPreference_Call {
+	return &MockQuerier_UpsertNotificationPreference_Call{Call: _e.mock.On("UpsertNotificationPreference", ctx, arg)}
 }
 
-func (_c *MockQuerier_UpdateStagedItem_Call) Run(run func(ctx context.Context, arg db.UpdateStagedItemParams)) *MockQuerier_UpdateStagedItem_Call {
+func (_c *MockQuerier_UpsertNotificationPreference_Call) Run(run func(ctx context.Context, arg db.UpsertNotificationPreferenceParams)) *MockQuerier_UpsertNotificationPreference_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.UpdateStagedItemParams))
+		run(args[0].(context.Context), args[1].(db.UpsertNotificationPreferenceParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_UpdateStagedItem_Call) Return(_a0 db.StagedItem, _a1 error) *MockQuerier_UpdateStagedItem_Call {
+func (_c *MockQuerier_UpsertNotificationPreference_Call) Return(_a0 db.NotificationPreference, _a1 error) *MockQuerier_UpsertNotificationPreference_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockQuerier_UpdateStagedItem_Call) RunAndReturn(run func(context.Context, db.UpdateStagedItemParams) (db.StagedItem, error)) *MockQuerier_UpdateStagedItem_Call {
+func (_c *MockQuerier_UpsertNotificationPreference_Call) RunAndReturn(run func(context.Context, db.UpsertNotificationPreferenceParams) (db.NotificationPreference, error)) *MockQuerier_UpsertNotificationPreference_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -689,6 +7113,330 @@ func (_c *MockQuerier_UpsertPantryItem_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// UpsertPantryItemAdd provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertPantryItemAdd(ctx context.Context, arg db.UpsertPantryItemAddParams) (db.PantryItem, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertPantryItemAdd")
+	}
+
+	var r0 db.PantryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertPantryItemAddParams) (db.PantryItem, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertPantryItemAddParams) db.PantryItem); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.PantryItem)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertPantryItemAddParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpsertPantryItemAdd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertPantryItemAdd'
+type MockQuerier_UpsertPantryItemAdd_Call struct {
+	*mock.Call
+}
+
+// UpsertPantryItemAdd is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertPantryItemAddParams
+func (_e *MockQuerier_Expecter) UpsertPantryItemAdd(ctx interface{}, arg interface{}) *MockQuerier_UpsertPantryItemAdd_Call {
+	return &MockQuerier_UpsertPantryItemAdd_Call{Call: _e.mock.On("UpsertPantryItemAdd", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertPantryItemAdd_Call) Run(run func(ctx context.Context, arg db.UpsertPantryItemAddParams)) *MockQuerier_UpsertPantryItemAdd_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertPantryItemAddParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertPantryItemAdd_Call) Return(_a0 db.PantryItem, _a1 error) *MockQuerier_UpsertPantryItemAdd_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertPantryItemAdd_Call) RunAndReturn(run func(context.Context, db.UpsertPantryItemAddParams) (db.PantryItem, error)) *MockQuerier_UpsertPantryItemAdd_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertPantryItemMax provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertPantryItemMax(ctx context.Context, arg db.UpsertPantryItemMaxParams) (db.PantryItem, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertPantryItemMax")
+	}
+
+	var r0 db.PantryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertPantryItemMaxParams) (db.PantryItem, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertPantryItemMaxParams) db.PantryItem); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.PantryItem)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertPantryItemMaxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpsertPantryItemMax_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertPantryItemMax'
+type MockQuerier_UpsertPantryItemMax_Call struct {
+	*mock.Call
+}
+
+// UpsertPantryItemMax is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertPantryItemMaxParams
+func (_e *MockQuerier_Expecter) UpsertPantryItemMax(ctx interface{}, arg interface{}) *MockQuerier_UpsertPantryItemMax_Call {
+	return &MockQuerier_UpsertPantryItemMax_Call{Call: _e.mock.On("UpsertPantryItemMax", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertPantryItemMax_Call) Run(run func(ctx context.Context, arg db.UpsertPantryItemMaxParams)) *MockQuerier_UpsertPantryItemMax_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertPantryItemMaxParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertPantryItemMax_Call) Return(_a0 db.PantryItem, _a1 error) *MockQuerier_UpsertPantryItemMax_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertPantryItemMax_Call) RunAndReturn(run func(context.Context, db.UpsertPantryItemMaxParams) (db.PantryItem, error)) *MockQuerier_UpsertPantryItemMax_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertPantryItems provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertPantryItems(ctx context.Context, arg db.UpsertPantryItemsParams) ([]db.PantryItem, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertPantryItems")
+	}
+
+	var r0 []db.PantryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertPantryItemsParams) ([]db.PantryItem, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertPantryItemsParams) []db.PantryItem); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PantryItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertPantryItemsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpsertPantryItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertPantryItems'
+type MockQuerier_UpsertPantryItems_Call struct {
+	*mock.Call
+}
+
+// UpsertPantryItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertPantryItemsParams
+func (_e *MockQuerier_Expecter) UpsertPantryItems(ctx interface{}, arg interface{}) *MockQuerier_UpsertPantryItems_Call {
+	return &MockQuerier_UpsertPantryItems_Call{Call: _e.mock.On("UpsertPantryItems", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertPantryItems_Call) Run(run func(ctx context.Context, arg db.UpsertPantryItemsParams)) *MockQuerier_UpsertPantryItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertPantryItemsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertPantryItems_Call) Return(_a0 []db.PantryItem, _a1 error) *MockQuerier_UpsertPantryItems_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertPantryItems_Call) RunAndReturn(run func(context.Context, db.UpsertPantryItemsParams) ([]db.PantryItem, error)) *MockQuerier_UpsertPantryItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertShadowExtraction provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertShadowExtraction(ctx context.Context, arg db.UpsertShadowExtractionParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertShadowExtraction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertShadowExtractionParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_UpsertShadowExtraction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertShadowExtraction'
+type MockQuerier_UpsertShadowExtraction_Call struct {
+	*mock.Call
+}
+
+// UpsertShadowExtraction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertShadowExtractionParams
+func (_e *MockQuerier_Expecter) UpsertShadowExtraction(ctx interface{}, arg interface{}) *MockQuerier_UpsertShadowExtraction_Call {
+	return &MockQuerier_UpsertShadowExtraction_Call{Call: _e.mock.On("UpsertShadowExtraction", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertShadowExtraction_Call) Run(run func(ctx context.Context, arg db.UpsertShadowExtractionParams)) *MockQuerier_UpsertShadowExtraction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertShadowExtractionParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertShadowExtraction_Call) Return(_a0 error) *MockQuerier_UpsertShadowExtraction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertShadowExtraction_Call) RunAndReturn(run func(context.Context, db.UpsertShadowExtractionParams) error) *MockQuerier_UpsertShadowExtraction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertStaleFlag provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertStaleFlag(ctx context.Context, arg db.UpsertStaleFlagParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertStaleFlag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertStaleFlagParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_UpsertStaleFlag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertStaleFlag'
+type MockQuerier_UpsertStaleFlag_Call struct {
+	*mock.Call
+}
+
+// UpsertStaleFlag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertStaleFlagParams
+func (_e *MockQuerier_Expecter) UpsertStaleFlag(ctx interface{}, arg interface{}) *MockQuerier_UpsertStaleFlag_Call {
+	return &MockQuerier_UpsertStaleFlag_Call{Call: _e.mock.On("UpsertStaleFlag", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertStaleFlag_Call) Run(run func(ctx context.Context, arg db.UpsertStaleFlagParams)) *MockQuerier_UpsertStaleFlag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertStaleFlagParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertStaleFlag_Call) Return(_a0 error) *MockQuerier_UpsertStaleFlag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertStaleFlag_Call) RunAndReturn(run func(context.Context, db.UpsertStaleFlagParams) error) *MockQuerier_UpsertStaleFlag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertWatchlistEntry provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertWatchlistEntry(ctx context.Context, arg db.UpsertWatchlistEntryParams) (db.Watchlist, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertWatchlistEntry")
+	}
+
+	var r0 db.Watchlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertWatchlistEntryParams) (db.Watchlist, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.UpsertWatchlistEntryParams) db.Watchlist); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Watchlist)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.UpsertWatchlistEntryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpsertWatchlistEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertWatchlistEntry'
+type MockQuerier_UpsertWatchlistEntry_Call struct {
+	*mock.Call
+}
+
+// UpsertWatchlistEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.UpsertWatchlistEntryParams
+func (_e *MockQuerier_Expecter) UpsertWatchlistEntry(ctx interface{}, arg interface{}) *MockQuerier_UpsertWatchlistEntry_Call {
+	return &MockQuerier_UpsertWatchlistEntry_Call{Call: _e.mock.On("UpsertWatchlistEntry", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertWatchlistEntry_Call) Run(run func(ctx context.Context, arg db.UpsertWatchlistEntryParams)) *MockQuerier_UpsertWatchlistEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.UpsertWatchlistEntryParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertWatchlistEntry_Call) Return(_a0 db.Watchlist, _a1 error) *MockQuerier_UpsertWatchlistEntry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertWatchlistEntry_Call) RunAndReturn(run func(context.Context, db.UpsertWatchlistEntryParams) (db.Watchlist, error)) *MockQuerier_UpsertWatchlistEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockQuerier creates a new instance of MockQuerier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockQuerier(t interface {
@@ -0,0 +1,123 @@
+// Package notify delivers user-facing notifications over external channels.
+// Each channel has a Sender; the pantry service picks one per notification
+// from the household's preferences.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/webhook"
+)
+
+// Sender delivers one message to a channel-specific target: an email
+// address, a webhook URL, a push token.
+type Sender interface {
+	// Validate reports whether target is usable by this sender.
+	Validate(target string) error
+	Send(ctx context.Context, target, subject, body string) error
+}
+
+// WebhookSource names notification deliveries in the webhook audit.
+const WebhookSource = "notification"
+
+// WebhookSender POSTs the notification as JSON to the target URL.
+type WebhookSender struct {
+	client *webhook.Client
+}
+
+// NewWebhookSender returns a sender posting through client; nil posts with
+// http.DefaultClient and no audit.
+func NewWebhookSender(client *webhook.Client) *WebhookSender {
+	if client == nil {
+		client = webhook.NewClient(nil, nil)
+	}
+	return &WebhookSender{client: client}
+}
+
+func (s *WebhookSender) Validate(target string) error {
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("webhook target must be an http or https URL")
+	}
+	return nil
+}
+
+type webhookPayload struct {
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+func (s *WebhookSender) Send(ctx context.Context, target, subject, body string) error {
+	return s.SendSigned(ctx, target, "", subject, body)
+}
+
+// SendSigned sends like Send and signs the payload with secret; see package
+// webhook for the format. An empty secret sends it unsigned.
+func (s *WebhookSender) SendSigned(ctx context.Context, target, secret, subject, body string) error {
+	payload, err := json.Marshal(webhookPayload{Subject: subject, Body: body, SentAt: time.Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("marshal notification: %w", err)
+	}
+	return s.client.Post(ctx, WebhookSource, target, secret, nil, payload)
+}
+
+// EmailSender sends plain-text mail through an SMTP relay.
+type EmailSender struct {
+	addr string // host:port
+	from string
+	auth smtp.Auth
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmailSender returns a sender relaying through addr. Username may be
+// empty for relays that do not require authentication.
+func NewEmailSender(addr, from, username, password string) (*EmailSender, error) {
+	host, _, ok := strings.Cut(addr, ":")
+	if !ok || host == "" {
+		return nil, errors.New("smtp address must be host:port")
+	}
+	if _, err := mail.ParseAddress(from); err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &EmailSender{addr: addr, from: from, auth: auth, send: smtp.SendMail}, nil
+}
+
+func (s *EmailSender) Validate(target string) error {
+	if _, err := mail.ParseAddress(target); err != nil {
+		return errors.New("email target must be an email address")
+	}
+	return nil
+}
+
+// Send ignores ctx: net/smtp has no cancellation, so a hung relay holds the
+// dispatch worker until its connection times out.
+func (s *EmailSender) Send(_ context.Context, target, subject, body string) error {
+	if err := s.send(s.addr, s.auth, s.from, []string{target}, s.message(target, subject, body)); err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+	return nil
+}
+
+func (s *EmailSender) message(to, subject, body string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", strings.NewReplacer("\r", " ", "\n", " ").Replace(subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/webhook"
+)
+
+func TestWebhookSender_PostsJSON(t *testing.T) {
+	var got webhookPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer srv.Close()
+
+	s := NewWebhookSender(webhook.NewClient(srv.Client(), nil))
+	require.NoError(t, s.Send(context.Background(), srv.URL, "Milk expiring", "- milk"))
+	assert.Equal(t, "Milk expiring", got.Subject)
+	assert.Equal(t, "- milk", got.Body)
+}
+
+func TestWebhookSender_SendSignedSignsPayload(t *testing.T) {
+	const secret = "s3cret"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.NoError(t, webhook.Verify(secret, r.Header, body, time.Now(), time.Minute))
+	}))
+	defer srv.Close()
+
+	s := NewWebhookSender(webhook.NewClient(srv.Client(), nil))
+	require.NoError(t, s.SendSigned(context.Background(), srv.URL, secret, "Milk expiring", "- milk"))
+}
+
+func TestWebhookSender_Non2xxIsError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	err := NewWebhookSender(webhook.NewClient(srv.Client(), nil)).Send(context.Background(), srv.URL, "s", "b")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "502")
+}
+
+func TestWebhookSender_Validate(t *testing.T) {
+	s := NewWebhookSender(nil)
+	assert.NoError(t, s.Validate("https://example.com/hook"))
+	assert.Error(t, s.Validate("ftp://example.com"))
+	assert.Error(t, s.Validate("not a url"))
+}
+
+func TestNewEmailSender_Validation(t *testing.T) {
+	_, err := NewEmailSender("smtp.example.com", "pantry@example.com", "", "")
+	assert.Error(t, err, "missing port")
+	_, err = NewEmailSender("smtp.example.com:587", "nope", "", "")
+	assert.Error(t, err, "bad from")
+}
+
+func TestEmailSender_Send(t *testing.T) {
+	s, err := NewEmailSender("smtp.example.com:587", "pantry@example.com", "user", "pass")
+	require.NoError(t, err)
+
+	var gotTo []string
+	var gotMsg string
+	s.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		assert.Equal(t, "smtp.example.com:587", addr)
+		assert.NotNil(t, a)
+		gotTo, gotMsg = to, string(msg)
+		return nil
+	}
+
+	require.NoError(t, s.Send(context.Background(), "me@example.com", "Low\nstock", "- eggs\n- milk"))
+	assert.Equal(t, []string{"me@example.com"}, gotTo)
+	assert.Contains(t, gotMsg, "Subject: Low stock\r\n")
+	assert.True(t, strings.HasSuffix(gotMsg, "\r\n\r\n- eggs\r\n- milk"))
+
+	assert.NoError(t, s.Validate("me@example.com"))
+	assert.Error(t, s.Validate("me"))
+}
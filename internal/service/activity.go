@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+	"github.com/mwhite7112/woodpantry-pantry/internal/units"
+)
+
+const (
+	// DefaultActivityLimit is the page size for the activity feed.
+	DefaultActivityLimit = 20
+	// MaxActivityLimit caps the page size for the activity feed.
+	MaxActivityLimit = 100
+)
+
+// Activity kinds stored in pantry_activity.
+const (
+	ActivityItemAdded       = "item_added"
+	ActivityItemRemoved     = "item_removed"
+	ActivityItemConsumed    = "item_consumed"
+	ActivityPantryReset     = "pantry_reset"
+	ActivityIngestConfirmed = "ingest_confirmed"
+)
+
+// ingestSourcePhrases says how each ingest channel reads in a sentence.
+var ingestSourcePhrases = map[string]string{
+	"web":     "the web app",
+	"mobile":  "the mobile app",
+	"email":   "email",
+	"voice":   "voice",
+	"chatbot": "the chatbot",
+	"api":     "the API",
+}
+
+// ActivityLog records pantry changes for the household feed and renders them
+// as sentences. Recording is best effort: a failed insert is logged and never
+// fails the change it describes.
+type ActivityLog struct {
+	q      db.Querier
+	lookup IngredientLookup
+	log    *slog.Logger
+}
+
+func NewActivityLog(q db.Querier, lookup IngredientLookup) *ActivityLog {
+	return &ActivityLog{q: q, lookup: lookup, log: logging.For("activity")}
+}
+
+// ActivityEntry is one line of the activity feed.
+type ActivityEntry struct {
+	ID           int64      `json:"id"`
+	Kind         string     `json:"kind"`
+	Description  string     `json:"description"`
+	IngredientID *uuid.UUID `json:"ingredient_id,omitempty"`
+	OccurredAt   time.Time  `json:"occurred_at"`
+}
+
+// ActivityPage is one page of the feed, newest first. NextCursor is passed as
+// before to fetch the following page and is zero on the last page.
+type ActivityPage struct {
+	Entries    []ActivityEntry `json:"entries"`
+	NextCursor int64           `json:"next_cursor,omitempty"`
+}
+
+// Feed returns up to limit entries older than the before cursor, or the
+// newest entries when before is zero. Ingredient names come from the
+// Dictionary; an ingredient that cannot be looked up reads as "an item".
+func (a *ActivityLog) Feed(ctx context.Context, before int64, limit int) (ActivityPage, error) {
+	rows, err := a.q.ListPantryActivity(ctx, db.ListPantryActivityParams{
+		Before: sql.NullInt64{Int64: before, Valid: before > 0},
+		Limit:  int32(limit), //nolint:gosec // bounded by MaxActivityLimit in the handler
+	})
+	if err != nil {
+		return ActivityPage{}, err
+	}
+
+	names := make(map[uuid.UUID]string)
+	page := ActivityPage{Entries: make([]ActivityEntry, len(rows))}
+	for i, r := range rows {
+		entry := ActivityEntry{ID: r.ID, Kind: r.Kind, OccurredAt: r.OccurredAt}
+		if r.IngredientID.Valid {
+			entry.IngredientID = &r.IngredientID.UUID
+		}
+		entry.Description = a.describe(ctx, r, names)
+		page.Entries[i] = entry
+	}
+	if len(rows) == limit && limit > 0 {
+		page.NextCursor = rows[len(rows)-1].ID
+	}
+	return page, nil
+}
+
+func (a *ActivityLog) describe(ctx context.Context, r db.PantryActivity, names map[uuid.UUID]string) string {
+	switch r.Kind {
+	case ActivityItemAdded:
+		return fmt.Sprintf("Added %s%s", amount(r.Quantity, r.Unit), a.name(ctx, r.IngredientID, names))
+	case ActivityItemRemoved:
+		return "Removed " + a.name(ctx, r.IngredientID, names)
+	case ActivityItemConsumed:
+		return fmt.Sprintf("Used %s%s", amount(r.Quantity, r.Unit), a.name(ctx, r.IngredientID, names))
+	case ActivityPantryReset:
+		return "Cleared the pantry"
+	case ActivityIngestConfirmed:
+		noun := "items"
+		if r.ItemCount == 1 {
+			noun = "item"
+		}
+		desc := fmt.Sprintf("Added %d %s from a grocery list", r.ItemCount, noun)
+		if phrase, ok := ingestSourcePhrases[r.Source.String]; ok {
+			desc += " via " + phrase
+		}
+		return desc
+	default:
+		return r.Kind
+	}
+}
+
+// name returns the ingredient's Dictionary name, memoized in names for the
+// current page.
+func (a *ActivityLog) name(ctx context.Context, id uuid.NullUUID, names map[uuid.UUID]string) string {
+	if !id.Valid {
+		return "an item"
+	}
+	if n, ok := names[id.UUID]; ok {
+		return n
+	}
+	n := "an item"
+	if ing, err := a.lookup.GetIngredient(ctx, id.UUID); err != nil {
+		a.log.WarnContext(ctx, "could not fetch ingredient name for activity", "ingredient_id", id.UUID, "error", err)
+	} else if ing.Name != "" {
+		n = ing.Name
+	}
+	names[id.UUID] = n
+	return n
+}
+
+// amount renders "2 lb " for a quantity and unit, or "" when none was stored.
+func amount(quantity sql.NullFloat64, unit sql.NullString) string {
+	if !quantity.Valid {
+		return ""
+	}
+	s := strconv.FormatFloat(quantity.Float64, 'f', -1, 64) + " "
+	if unit.String != "" && unit.String != units.DefaultCountUnit {
+		s += unit.String + " "
+	}
+	return s
+}
+
+func (a *ActivityLog) record(ctx context.Context, arg db.InsertPantryActivityParams) {
+	if a == nil {
+		return
+	}
+	if err := a.q.InsertPantryActivity(ctx, arg); err != nil {
+		a.log.WarnContext(ctx, "failed to record pantry activity", "kind", arg.Kind, "error", err)
+	}
+}
+
+// recordAdded stores no quantity for an unknown amount, so the entry reads
+// "Added olive oil".
+func (a *ActivityLog) recordAdded(ctx context.Context, in ItemInput) {
+	a.record(ctx, db.InsertPantryActivityParams{
+		Kind:         ActivityItemAdded,
+		IngredientID: uuid.NullUUID{UUID: in.IngredientID, Valid: true},
+		Quantity:     sql.NullFloat64{Float64: in.Quantity, Valid: !in.QuantityUnknown},
+		Unit:         sql.NullString{String: in.Unit, Valid: true},
+	})
+}
+
+func (a *ActivityLog) recordRemoved(ctx context.Context, ingredientID uuid.UUID) {
+	a.record(ctx, db.InsertPantryActivityParams{
+		Kind:         ActivityItemRemoved,
+		IngredientID: uuid.NullUUID{UUID: ingredientID, Valid: true},
+	})
+}
+
+func (a *ActivityLog) recordConsumed(ctx context.Context, ingredientID uuid.UUID, quantity float64, unit string) {
+	a.record(ctx, db.InsertPantryActivityParams{
+		Kind:         ActivityItemConsumed,
+		IngredientID: uuid.NullUUID{UUID: ingredientID, Valid: true},
+		Quantity:     sql.NullFloat64{Float64: quantity, Valid: true},
+		Unit:         sql.NullString{String: unit, Valid: true},
+	})
+}
+
+func (a *ActivityLog) recordConfirmed(ctx context.Context, source string, count int) {
+	a.record(ctx, db.InsertPantryActivityParams{
+		Kind:      ActivityIngestConfirmed,
+		Source:    sql.NullString{String: source, Valid: true},
+		ItemCount: int32(count), //nolint:gosec // bounded by the staged item cap
+	})
+}
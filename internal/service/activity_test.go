@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestActivityFeed_Descriptions(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	lookup := NewMockIngredientLookup(t)
+	chicken, eggs, gone := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+
+	mockQ.EXPECT().ListPantryActivity(mock.Anything, db.ListPantryActivityParams{Limit: 7}).
+		Return([]db.PantryActivity{
+			{ID: 7, Kind: ActivityItemConsumed, IngredientID: uuid.NullUUID{UUID: chicken, Valid: true},
+				Quantity: sql.NullFloat64{Float64: 0.5, Valid: true},
+				Unit:     sql.NullString{String: "lb", Valid: true}},
+			{ID: 6, Kind: ActivityIngestConfirmed, Source: sql.NullString{String: "mobile", Valid: true},
+				ItemCount: 5, OccurredAt: now},
+			{ID: 5, Kind: ActivityItemAdded, IngredientID: uuid.NullUUID{UUID: chicken, Valid: true},
+				Quantity: sql.NullFloat64{Float64: 2, Valid: true},
+				Unit:     sql.NullString{String: "lb", Valid: true}},
+			{ID: 4, Kind: ActivityItemAdded, IngredientID: uuid.NullUUID{UUID: eggs, Valid: true},
+				Quantity: sql.NullFloat64{Float64: 12, Valid: true},
+				Unit:     sql.NullString{String: "piece", Valid: true}},
+			{ID: 3, Kind: ActivityItemRemoved, IngredientID: uuid.NullUUID{UUID: chicken, Valid: true}},
+			{ID: 2, Kind: ActivityItemRemoved, IngredientID: uuid.NullUUID{UUID: gone, Valid: true}},
+			{ID: 1, Kind: ActivityIngestConfirmed, Source: sql.NullString{String: "unknown", Valid: true},
+				ItemCount: 1},
+		}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, chicken).Return(clients.Ingredient{Name: "chicken breast"}, nil).Once()
+	lookup.EXPECT().GetIngredient(mock.Anything, eggs).Return(clients.Ingredient{Name: "eggs"}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, gone).Return(clients.Ingredient{}, errors.New("dictionary down"))
+
+	page, err := NewActivityLog(mockQ, lookup).Feed(context.Background(), 0, 7)
+	require.NoError(t, err)
+
+	descriptions := make([]string, len(page.Entries))
+	for i, e := range page.Entries {
+		descriptions[i] = e.Description
+	}
+	assert.Equal(t, []string{
+		"Used 0.5 lb chicken breast",
+		"Added 5 items from a grocery list via the mobile app",
+		"Added 2 lb chicken breast",
+		"Added 12 eggs",
+		"Removed chicken breast",
+		"Removed an item",
+		"Added 1 item from a grocery list",
+	}, descriptions)
+	assert.Equal(t, int64(1), page.NextCursor)
+}
+
+func TestActivityFeed_LastPageHasNoCursor(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().ListPantryActivity(mock.Anything, db.ListPantryActivityParams{
+		Before: sql.NullInt64{Int64: 40, Valid: true},
+		Limit:  20,
+	}).Return([]db.PantryActivity{{ID: 39, Kind: ActivityPantryReset}}, nil)
+
+	page, err := NewActivityLog(mockQ, NewMockIngredientLookup(t)).Feed(context.Background(), 40, 20)
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 1)
+	assert.Equal(t, "Cleared the pantry", page.Entries[0].Description)
+	assert.Zero(t, page.NextCursor)
+}
+
+func TestPantryService_RecordsActivity(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+	svc.SetActivityLog(NewActivityLog(mockQ, NewMockIngredientLookup(t)))
+	ctx := context.Background()
+	ingredientID, itemID := uuid.New(), uuid.New()
+
+	mockQ.EXPECT().UpsertPantryItem(mock.Anything, mock.Anything).
+		Return(db.PantryItem{ID: itemID, IngredientID: ingredientID, Quantity: 2, Unit: "lb"}, nil)
+	mockQ.EXPECT().InsertPantryActivity(mock.Anything, db.InsertPantryActivityParams{
+		Kind:         ActivityItemAdded,
+		IngredientID: uuid.NullUUID{UUID: ingredientID, Valid: true},
+		Quantity:     sql.NullFloat64{Float64: 2, Valid: true},
+		Unit:         sql.NullString{String: "lb", Valid: true},
+	}).Return(nil)
+	_, err := svc.UpsertItem(ctx, ingredientID, 2, "lb", sql.NullTime{})
+	require.NoError(t, err)
+
+	mockQ.EXPECT().GetPantryItem(mock.Anything, itemID).
+		Return(db.PantryItem{ID: itemID, IngredientID: ingredientID}, nil)
+	mockQ.EXPECT().DeletePantryItem(mock.Anything, itemID).Return(nil)
+	mockQ.EXPECT().InsertPantryActivity(mock.Anything, db.InsertPantryActivityParams{
+		Kind:         ActivityItemRemoved,
+		IngredientID: uuid.NullUUID{UUID: ingredientID, Valid: true},
+	}).Return(errors.New("insert failed"))
+	require.NoError(t, svc.DeleteItem(ctx, itemID), "a failed activity insert does not fail the delete")
+
+	mockQ.EXPECT().DeleteAllPantryItems(mock.Anything).Return(nil)
+	mockQ.EXPECT().InsertPantryActivity(mock.Anything, db.InsertPantryActivityParams{Kind: ActivityPantryReset}).
+		Return(nil)
+	require.NoError(t, svc.Reset(ctx))
+}
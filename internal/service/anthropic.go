@@ -0,0 +1,203 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/tracing"
+)
+
+// DefaultAnthropicBaseURL is the public Anthropic API root.
+const DefaultAnthropicBaseURL = "https://api.anthropic.com/v1"
+
+const (
+	// anthropicVersion is the Messages API version the requests are
+	// written against.
+	anthropicVersion = "2023-06-01"
+	// anthropicMaxTokens caps a reply. The Messages API requires a cap; this
+	// one leaves room for a long receipt.
+	anthropicMaxTokens = 8192
+)
+
+// AnthropicExtractor implements LLMExtractor using Anthropic's Messages API.
+// It sends the same prompts as OpenAIExtractor and parses the same JSON.
+type AnthropicExtractor struct {
+	llmClient
+}
+
+func NewAnthropicExtractor(apiKey, model string, opts ...ExtractorOption) *AnthropicExtractor {
+	return &AnthropicExtractor{newLLMClient(apiKey, model, DefaultAnthropicBaseURL, opts)}
+}
+
+// Ping lists the provider's models, a call that costs no tokens but fails
+// the same way extraction would on an outage or a revoked key.
+func (e *AnthropicExtractor) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.baseURL+"/models", nil)
+	if err != nil {
+		return err
+	}
+	e.setHeaders(req)
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("anthropic request: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck // drained for connection reuse
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("anthropic status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// DryRun sends a tiny message to the extraction model, and to the vision
+// model when it is a different one.
+func (e *AnthropicExtractor) DryRun(ctx context.Context) error {
+	for _, model := range e.models() {
+		if _, _, err := e.message(ctx, model, dryRunPrompt, "{}"); err != nil {
+			return fmt.Errorf("model %s: %w", model, err)
+		}
+	}
+	return nil
+}
+
+func (e *AnthropicExtractor) Extract(ctx context.Context, text string) (*ExtractionResponse, error) {
+	return e.extract(ctx, e.model, text)
+}
+
+// extract sends content with the extraction prompt and parses the items
+// from the reply.
+func (e *AnthropicExtractor) extract(ctx context.Context, model string, content any) (*ExtractionResponse, error) {
+	reply, tokens, err := e.call(ctx, model, func(ctx context.Context, model string) (string, int, error) {
+		return e.message(ctx, model, e.prompt, content)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseExtraction(reply, tokens)
+}
+
+// ExtractImage reads the items off a photographed receipt, or the stock on a
+// fridge photo, with the vision model, which defaults to the extraction
+// model.
+func (e *AnthropicExtractor) ExtractImage(
+	ctx context.Context,
+	jobType, mediaType string,
+	image []byte,
+) (*ExtractionResponse, error) {
+	return e.extract(ctx, e.imageModel(), []map[string]any{
+		{"type": "image", "source": map[string]string{
+			"type":       "base64",
+			"media_type": mediaType,
+			"data":       base64.StdEncoding.EncodeToString(image),
+		}},
+		{"type": "text", "text": imagePrompt(jobType)},
+	})
+}
+
+// DetectPII asks the model for the personal information in text.
+func (e *AnthropicExtractor) DetectPII(ctx context.Context, text string) ([]PIISpan, error) {
+	reply, _, err := e.call(ctx, e.model, func(ctx context.Context, model string) (string, int, error) {
+		return e.message(ctx, model, piiPrompt, text)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parsePII(reply)
+}
+
+func (e *AnthropicExtractor) setHeaders(req *http.Request) {
+	req.Header.Set("x-api-key", e.apiKey)
+	req.Header.Set("anthropic-version", anthropicVersion)
+}
+
+// message sends one Messages API request and returns the JSON object in the
+// reply and the tokens it used. The API has no JSON mode, so any prose or
+// code fence around the object is dropped.
+func (e *AnthropicExtractor) message(
+	ctx context.Context, model, system string, content any,
+) (_ string, tokens int, err error) {
+	ctx, span := tracing.Start(ctx, "chat "+model, trace.SpanKindClient,
+		semconv.GenAIOperationNameChat, semconv.GenAIProviderNameAnthropic, semconv.GenAIRequestModel(model))
+	defer func() {
+		span.SetAttributes(attribute.Int("gen_ai.usage.total_tokens", tokens))
+		tracing.End(span, err)
+	}()
+
+	body, err := json.Marshal(map[string]any{
+		"model":      model,
+		"max_tokens": anthropicMaxTokens,
+		"system":     system,
+		"messages":   []map[string]any{{"role": "user", "content": content}},
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/messages", bytes.NewReader(body))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	e.setHeaders(req)
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("anthropic request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, newLLMStatusError("anthropic", resp)
+	}
+
+	var msgResp struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		StopReason string `json:"stop_reason"`
+		Usage      struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&msgResp); err != nil {
+		return "", 0, fmt.Errorf("anthropic response decode: %w", err)
+	}
+	tokens = msgResp.Usage.InputTokens + msgResp.Usage.OutputTokens
+	if msgResp.StopReason == "max_tokens" {
+		return "", tokens, errors.New("anthropic reply truncated at max_tokens")
+	}
+	var reply strings.Builder
+	for _, block := range msgResp.Content {
+		if block.Type == "text" {
+			reply.WriteString(block.Text)
+		}
+	}
+	if reply.Len() == 0 {
+		return "", tokens, errors.New("anthropic returned no text")
+	}
+	return jsonObject(reply.String()), tokens, nil
+}
+
+// jsonObject trims reply to the span from its first '{' to its last '}'.
+// A reply without one is returned as is, for the caller's parse to reject.
+func jsonObject(reply string) string {
+	start, end := strings.IndexByte(reply, '{'), strings.LastIndexByte(reply, '}')
+	if start < 0 || end < start {
+		return reply
+	}
+	return reply[start : end+1]
+}
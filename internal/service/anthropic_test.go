@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// anthropicRequest is the part of a Messages API request the tests inspect.
+type anthropicRequest struct {
+	Model    string `json:"model"`
+	System   string `json:"system"`
+	Messages []struct {
+		Role    string `json:"role"`
+		Content any    `json:"content"`
+	} `json:"messages"`
+}
+
+// anthropicServer replies to every message with reply and sends the
+// requests it got on the returned channel.
+func anthropicServer(t *testing.T, reply string) (*httptest.Server, <-chan anthropicRequest) {
+	t.Helper()
+	reqs := make(chan anthropicRequest, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/messages", r.URL.Path)
+		assert.Equal(t, "sk-ant-test", r.Header.Get("x-api-key"))
+		assert.Equal(t, anthropicVersion, r.Header.Get("anthropic-version"))
+		var req anthropicRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		reqs <- req
+		json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck // test server
+			"content":     []map[string]string{{"type": "text", "text": reply}},
+			"stop_reason": "end_turn",
+			"usage":       map[string]int{"input_tokens": 120, "output_tokens": 30},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, reqs
+}
+
+func TestAnthropicExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	server, reqs := anthropicServer(t, "Here are the items:\n```json\n"+
+		`{"items":[{"raw_text":"2 lbs chicken breast","name":"chicken breast",`+
+		`"quantity":2,"unit":"lb","confidence":0.95}]}`+"\n```")
+	extractor := NewAnthropicExtractor("sk-ant-test", "claude-test", WithBaseURL(server.URL))
+
+	resp, err := extractor.Extract(context.Background(), "2 lbs chicken breast")
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, "chicken breast", resp.Items[0].Name)
+	assert.Equal(t, 150, resp.TokensUsed)
+
+	req := <-reqs
+	assert.Equal(t, "claude-test", req.Model)
+	assert.Equal(t, systemPrompt, req.System, "the extraction prompt is shared with OpenAI")
+	require.Len(t, req.Messages, 1)
+	assert.Equal(t, "2 lbs chicken breast", req.Messages[0].Content)
+}
+
+func TestAnthropicExtractor_ExtractImage(t *testing.T) {
+	t.Parallel()
+
+	server, reqs := anthropicServer(t, `{"items":[]}`)
+	extractor := NewAnthropicExtractor("sk-ant-test", "claude-test",
+		WithBaseURL(server.URL), WithVisionModel("claude-vision"))
+
+	_, err := extractor.ExtractImage(context.Background(), JobTypeReceiptImage, "image/png", testPNG)
+	require.NoError(t, err)
+
+	req := <-reqs
+	assert.Equal(t, "claude-vision", req.Model)
+	parts, ok := req.Messages[0].Content.([]any)
+	require.True(t, ok, "the user message carries content blocks")
+	require.Len(t, parts, 2)
+	image, _ := parts[0].(map[string]any)
+	assert.Equal(t, "image", image["type"])
+	assert.Equal(t, map[string]any{
+		"type":       "base64",
+		"media_type": "image/png",
+		"data":       base64.StdEncoding.EncodeToString(testPNG),
+	}, image["source"])
+}
+
+func TestAnthropicExtractor_ErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"type":"error","error":{"type":"overloaded_error"}}`, 529)
+	}))
+	t.Cleanup(server.Close)
+	extractor := NewAnthropicExtractor("sk-ant-test", "claude-test", WithBaseURL(server.URL))
+
+	_, err := extractor.Extract(context.Background(), "milk")
+	require.ErrorContains(t, err, "anthropic status 529")
+}
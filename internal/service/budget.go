@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+// LLMBudget is a soft monthly token budget for LLM extraction. It is checked
+// before each call, so the call that crosses the limit still completes; every
+// call after it falls back to the heuristic parser until the month rolls over
+// or an admin resets usage.
+type LLMBudget struct {
+	q             db.Querier
+	monthlyTokens int64
+	now           func() time.Time
+}
+
+func NewLLMBudget(q db.Querier, monthlyTokens int64) *LLMBudget {
+	return &LLMBudget{q: q, monthlyTokens: monthlyTokens, now: time.Now}
+}
+
+// BudgetStatus reports token usage for the current month (UTC).
+type BudgetStatus struct {
+	Month         string    `json:"month"`
+	MonthlyTokens int64     `json:"monthly_tokens"`
+	TokensUsed    int64     `json:"tokens_used"`
+	Remaining     int64     `json:"remaining"`
+	Exceeded      bool      `json:"exceeded"`
+	UpdatedAt     time.Time `json:"updated_at,omitzero"`
+}
+
+func (b *LLMBudget) month() time.Time {
+	now := b.now().UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (b *LLMBudget) Status(ctx context.Context) (BudgetStatus, error) {
+	month := b.month()
+	usage, err := b.q.GetLLMUsage(ctx, month)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return BudgetStatus{}, fmt.Errorf("get llm usage: %w", err)
+	}
+	return BudgetStatus{
+		Month:         month.Format("2006-01"),
+		MonthlyTokens: b.monthlyTokens,
+		TokensUsed:    usage.TokensUsed,
+		Remaining:     max(b.monthlyTokens-usage.TokensUsed, 0),
+		Exceeded:      usage.TokensUsed >= b.monthlyTokens,
+		UpdatedAt:     usage.UpdatedAt,
+	}, nil
+}
+
+// Exhausted reports whether this month's usage has reached the budget.
+func (b *LLMBudget) Exhausted(ctx context.Context) (bool, error) {
+	status, err := b.Status(ctx)
+	if err != nil {
+		return false, err
+	}
+	return status.Exceeded, nil
+}
+
+// Record adds tokens spent by one extraction to this month's usage.
+func (b *LLMBudget) Record(ctx context.Context, tokens int) error {
+	if tokens <= 0 {
+		return nil
+	}
+	_, err := b.q.AddLLMUsage(ctx, db.AddLLMUsageParams{Month: b.month(), TokensUsed: int64(tokens)})
+	if err != nil {
+		return fmt.Errorf("add llm usage: %w", err)
+	}
+	return nil
+}
+
+// Reset zeroes this month's usage and returns the resulting status.
+func (b *LLMBudget) Reset(ctx context.Context) (BudgetStatus, error) {
+	if err := b.q.ResetLLMUsage(ctx, b.month()); err != nil {
+		return BudgetStatus{}, fmt.Errorf("reset llm usage: %w", err)
+	}
+	return b.Status(ctx)
+}
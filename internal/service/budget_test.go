@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func newTestBudget(q db.Querier, limit int64) *LLMBudget {
+	b := NewLLMBudget(q, limit)
+	b.now = func() time.Time { return time.Date(2026, 3, 14, 22, 0, 0, 0, time.FixedZone("PDT", -7*3600)) }
+	return b
+}
+
+var budgetMonth = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func TestLLMBudget_StatusWithoutUsage(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().GetLLMUsage(mock.Anything, budgetMonth).Return(db.LlmUsage{}, sql.ErrNoRows)
+
+	status, err := newTestBudget(mockQ, 5000).Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, BudgetStatus{Month: "2026-03", MonthlyTokens: 5000, Remaining: 5000}, status)
+}
+
+func TestLLMBudget_Exhausted(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().GetLLMUsage(mock.Anything, budgetMonth).Return(db.LlmUsage{TokensUsed: 5200}, nil)
+
+	status, err := newTestBudget(mockQ, 5000).Status(context.Background())
+	require.NoError(t, err)
+	assert.True(t, status.Exceeded)
+	assert.Equal(t, int64(0), status.Remaining)
+}
+
+func TestLLMBudget_RecordSkipsZero(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	b := newTestBudget(mockQ, 5000)
+	require.NoError(t, b.Record(context.Background(), 0))
+
+	mockQ.EXPECT().AddLLMUsage(mock.Anything, db.AddLLMUsageParams{Month: budgetMonth, TokensUsed: 300}).
+		Return(db.LlmUsage{}, nil)
+	require.NoError(t, b.Record(context.Background(), 300))
+}
+
+func TestLLMBudget_Reset(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().ResetLLMUsage(mock.Anything, budgetMonth).Return(nil)
+	mockQ.EXPECT().GetLLMUsage(mock.Anything, budgetMonth).Return(db.LlmUsage{}, nil)
+
+	status, err := newTestBudget(mockQ, 5000).Reset(context.Background())
+	require.NoError(t, err)
+	assert.False(t, status.Exceeded)
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+// confirmSummaryTimeout bounds the category lookups and publish for one
+// summary.
+const confirmSummaryTimeout = 30 * time.Second
+
+// ConfirmSummary describes one confirmed ingest job for analytics: what was
+// stocked, in which categories, and how much the reviewer corrected.
+type ConfirmSummary struct {
+	JobID         uuid.UUID
+	Source        string
+	ItemCount     int // items written to the pantry
+	SkippedCount  int // staged items without an ingredient
+	OverrideCount int // staged items the reviewer changed
+	Categories    []CategoryQuantity
+	ConfirmedAt   time.Time
+}
+
+// CategoryQuantity totals one category's confirmed stock in one unit.
+// Quantities in different units are never added together, and unknown
+// amounts count as items but add no quantity.
+type CategoryQuantity struct {
+	Category  string
+	Unit      string
+	Quantity  float64
+	ItemCount int
+}
+
+// ConfirmSummaryPublisher announces a summary of every confirmed job.
+type ConfirmSummaryPublisher interface {
+	PublishConfirmSummary(ctx context.Context, summary ConfirmSummary) error
+}
+
+// SetConfirmSummary makes ConfirmJob publish a ConfirmSummary through p
+// after every confirm. Categories are fetched through lookup.
+func (s *IngestService) SetConfirmSummary(p ConfirmSummaryPublisher, lookup IngredientLookup) {
+	s.summaries = p
+	s.categories = newCategoryCache(lookup)
+}
+
+// publishConfirmSummary summarizes a confirm off the request path. A failure
+// is logged and never reaches the caller; the pantry.updated event already
+// carries the change itself.
+func (s *IngestService) publishConfirmSummary(job db.IngestionJob, plans []confirmPlan, overridden int) {
+	if s.summaries == nil {
+		return
+	}
+	confirmedAt := time.Now().UTC()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), confirmSummaryTimeout)
+		defer cancel()
+		summary := s.summarizeConfirm(ctx, job, plans, overridden)
+		summary.ConfirmedAt = confirmedAt
+		if err := s.summaries.PublishConfirmSummary(ctx, summary); err != nil {
+			s.log.WarnContext(ctx, "failed to publish confirm summary", "job_id", job.ID, "error", err)
+		}
+	}()
+}
+
+// summarizeConfirm totals the committed plans by category and unit, sorted
+// by both. An ingredient whose category cannot be fetched, or that has
+// none, counts as UncategorizedTaxonomy.
+func (s *IngestService) summarizeConfirm(
+	ctx context.Context,
+	job db.IngestionJob,
+	plans []confirmPlan,
+	overridden int,
+) ConfirmSummary {
+	summary := ConfirmSummary{JobID: job.ID, Source: job.Source, OverrideCount: overridden}
+	type key struct{ category, unit string }
+	totals := map[key]*CategoryQuantity{}
+	for _, plan := range plans {
+		if !plan.ingredientID.Valid {
+			summary.SkippedCount++
+			continue
+		}
+		summary.ItemCount++
+
+		category, err := s.categories.get(ctx, plan.ingredientID.UUID, time.Now())
+		if err != nil {
+			s.log.WarnContext(ctx, "could not fetch ingredient category for confirm summary",
+				"ingredient_id", plan.ingredientID.UUID, "error", err)
+		}
+		if category == "" {
+			category = UncategorizedTaxonomy
+		}
+		k := key{category, plan.in.Unit}
+		t, ok := totals[k]
+		if !ok {
+			t = &CategoryQuantity{Category: category, Unit: plan.in.Unit}
+			totals[k] = t
+		}
+		t.ItemCount++
+		if !plan.in.QuantityUnknown {
+			t.Quantity += plan.in.Quantity
+		}
+	}
+
+	summary.Categories = make([]CategoryQuantity, 0, len(totals))
+	for _, t := range totals {
+		// Staged quantities have three decimals; drop float noise from the sum.
+		t.Quantity = math.Round(t.Quantity*1000) / 1000
+		summary.Categories = append(summary.Categories, *t)
+	}
+	slices.SortFunc(summary.Categories, func(a, b CategoryQuantity) int {
+		return cmp.Or(strings.Compare(a.Category, b.Category), strings.Compare(a.Unit, b.Unit))
+	})
+	return summary
+}
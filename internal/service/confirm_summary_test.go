@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+type chanSummaryPublisher chan ConfirmSummary
+
+func (c chanSummaryPublisher) PublishConfirmSummary(_ context.Context, s ConfirmSummary) error {
+	c <- s
+	return nil
+}
+
+func TestSummarizeConfirm_TotalsByCategoryAndUnit(t *testing.T) {
+	t.Parallel()
+
+	lookup := NewMockIngredientLookup(t)
+	ingestSvc := NewIngestService(mocks.NewMockQuerier(t), NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	ingestSvc.SetConfirmSummary(make(chanSummaryPublisher, 1), lookup)
+
+	apples, pears, milk, salt, mystery := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	lookup.EXPECT().GetIngredient(mock.Anything, apples).Return(clients.Ingredient{Category: "Produce"}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, pears).Return(clients.Ingredient{Category: "produce"}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, milk).Return(clients.Ingredient{Category: "dairy"}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, salt).Return(clients.Ingredient{}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, mystery).Return(clients.Ingredient{}, errors.New("dictionary down"))
+
+	plan := func(id uuid.UUID, qty float64, unit string, unknown bool) confirmPlan {
+		return confirmPlan{
+			ingredientID: uuid.NullUUID{UUID: id, Valid: id != uuid.Nil},
+			in:           ItemInput{Quantity: qty, Unit: unit, QuantityUnknown: unknown},
+		}
+	}
+	job := db.IngestionJob{ID: uuid.New(), Source: "mobile"}
+	summary := ingestSvc.summarizeConfirm(context.Background(), job, []confirmPlan{
+		plan(apples, 0.1, "kg", false),
+		plan(pears, 0.2, "kg", false),
+		plan(apples, 3, "piece", false),
+		plan(milk, 0, "l", true),
+		plan(salt, 1, "g", false),
+		plan(mystery, 2, "g", false),
+		plan(uuid.Nil, 1, "cup", false),
+	}, 2)
+
+	assert.Equal(t, job.ID, summary.JobID)
+	assert.Equal(t, "mobile", summary.Source)
+	assert.Equal(t, 6, summary.ItemCount)
+	assert.Equal(t, 1, summary.SkippedCount)
+	assert.Equal(t, 2, summary.OverrideCount)
+	assert.Equal(t, []CategoryQuantity{
+		{Category: "dairy", Unit: "l", Quantity: 0, ItemCount: 1},
+		{Category: "produce", Unit: "kg", Quantity: 0.3, ItemCount: 2},
+		{Category: "produce", Unit: "piece", Quantity: 3, ItemCount: 1},
+		{Category: UncategorizedTaxonomy, Unit: "g", Quantity: 3, ItemCount: 2},
+	}, summary.Categories)
+}
+
+func TestConfirmJob_PublishesSummary(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	lookup := NewMockIngredientLookup(t)
+	ingestSvc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	published := make(chanSummaryPublisher, 1)
+	ingestSvc.SetConfirmSummary(published, lookup)
+
+	jobID, stagedID, flour := uuid.New(), uuid.New(), uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).
+		Return(db.IngestionJob{ID: jobID, Status: "staged", Source: "email"}, nil)
+	mockQ.EXPECT().ListStagedItemsByJob(mock.Anything, jobID).Return([]db.StagedItem{
+		{ID: stagedID, IngredientID: uuid.NullUUID{UUID: flour, Valid: true}, Quantity: 1, Unit: "kg"},
+	}, nil)
+	mockQ.EXPECT().ListPantryItemsByIngredients(mock.Anything, mock.Anything).Return(nil, nil)
+	mockQ.EXPECT().UpsertPantryItems(mock.Anything, mock.Anything).
+		Return([]db.PantryItem{{ID: uuid.New(), IngredientID: flour}}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, flour).Return(clients.Ingredient{Category: "baking"}, nil)
+
+	qty := 1.5
+	_, err := ingestSvc.ConfirmJob(context.Background(), jobID, NewPantryService(mockQ),
+		[]OverrideItem{{StagedItemID: stagedID, Quantity: &qty}})
+	require.NoError(t, err)
+
+	select {
+	case s := <-published:
+		assert.Equal(t, jobID, s.JobID)
+		assert.Equal(t, "email", s.Source)
+		assert.Equal(t, 1, s.OverrideCount)
+		assert.Equal(t, []CategoryQuantity{{Category: "baking", Unit: "kg", Quantity: 1.5, ItemCount: 1}}, s.Categories)
+		assert.False(t, s.ConfirmedAt.IsZero())
+	case <-time.After(time.Second):
+		t.Fatal("confirm summary was not published")
+	}
+}
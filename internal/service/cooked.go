@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// CookedIngredient is an amount of one ingredient used by a cooked recipe.
+// An empty Unit means the pantry item's own unit.
+type CookedIngredient struct {
+	IngredientID uuid.UUID
+	Quantity     float64
+	Unit         string
+}
+
+// ConsumeCooked subtracts a cooked recipe's ingredients from the pantry and
+// publishes one pantry.updated for every item it changed. Each amount goes
+// through ConsumeItem's conversion and stops at zero. Ingredients the pantry
+// does not stock, whose amount is unknown or whose unit cannot be converted
+// are skipped, since the cook has already happened and nothing can correct
+// them; any other failure is returned after the rest are applied.
+func (s *PantryService) ConsumeCooked(ctx context.Context, ingredients []CookedIngredient) ([]Consumption, error) {
+	var (
+		consumed []Consumption
+		changed  []uuid.UUID
+		errs     []error
+	)
+	for _, in := range ingredients {
+		if in.Quantity <= 0 {
+			continue
+		}
+		item, err := s.q.GetPantryItemByIngredient(ctx, in.IngredientID)
+		if errors.Is(err, sql.ErrNoRows) {
+			s.log.DebugContext(ctx, "cooked ingredient not stocked", "ingredient_id", in.IngredientID)
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		c, err := s.consumeItem(ctx, item.ID, in.Quantity, in.Unit)
+		switch {
+		case errors.Is(err, ErrQuantityUnknown), errors.Is(err, ErrIncompatibleUnit), errors.Is(err, sql.ErrNoRows):
+			s.log.InfoContext(ctx, "skipping cooked ingredient",
+				"ingredient_id", in.IngredientID, "item_id", item.ID, "reason", err)
+			continue
+		case err != nil:
+			errs = append(errs, err)
+			continue
+		}
+		consumed = append(consumed, c)
+		changed = append(changed, c.Item.ID)
+	}
+	if len(changed) > 0 {
+		s.publishPantryUpdated(ctx, changed)
+	}
+	return consumed, errors.Join(errs...)
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestConsumeCooked_SkipsWhatCannotBeConsumed(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	pub := &stubUpdatePublisher{}
+	svc := NewPantryService(mockQ, pub)
+
+	flour := db.PantryItem{ID: uuid.New(), IngredientID: uuid.New(), Quantity: 0.2, Unit: "kg"}
+	eggs := db.PantryItem{ID: uuid.New(), IngredientID: uuid.New(), Quantity: 6, Unit: "count"}
+	missing := uuid.New()
+
+	mockQ.EXPECT().GetPantryItemByIngredient(mock.Anything, flour.IngredientID).Return(flour, nil)
+	mockQ.EXPECT().GetPantryItem(mock.Anything, flour.ID).Return(flour, nil)
+	mockQ.EXPECT().ConsumePantryItem(mock.Anything, db.ConsumePantryItemParams{
+		Quantity: 0.5,
+		ID:       flour.ID,
+		Unit:     sql.NullString{String: "kg", Valid: true},
+	}).Return(db.ConsumePantryItemRow{ID: flour.ID, Unit: "kg", PreviousQuantity: 0.2}, nil)
+	mockQ.EXPECT().GetPantryItemByIngredient(mock.Anything, eggs.IngredientID).Return(eggs, nil)
+	mockQ.EXPECT().GetPantryItem(mock.Anything, eggs.ID).Return(eggs, nil)
+	mockQ.EXPECT().GetPantryItemByIngredient(mock.Anything, missing).Return(db.PantryItem{}, sql.ErrNoRows)
+
+	got, err := svc.ConsumeCooked(context.Background(), []CookedIngredient{
+		{IngredientID: flour.IngredientID, Quantity: 500, Unit: "g"},
+		{IngredientID: eggs.IngredientID, Quantity: 2, Unit: "cup"},
+		{IngredientID: missing, Quantity: 1},
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Zero(t, got[0].Item.Quantity, "the decrement stops at zero")
+	assert.InDelta(t, 0.2, got[0].Consumed, 0)
+	assert.Equal(t, [][]uuid.UUID{{flour.ID}}, pub.published, "one event covers the recipe")
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+// DefaultDBQueuePollInterval is how long an idle worker waits before looking
+// for a pending job again.
+const DefaultDBQueuePollInterval = 2 * time.Second
+
+// dbQueueLease is how long a claimed job may stay processing before it is
+// presumed abandoned by a worker that died; it outlasts processJobTimeout.
+const dbQueueLease = 5 * time.Minute
+
+// DBJobQueue queues ingest jobs in Postgres. A pending job is already
+// queued, so publishing only clears an earlier claim's attempts; RunDBQueue
+// claims and runs jobs on whichever instance polls first.
+type DBJobQueue struct {
+	q db.Querier
+}
+
+// NewDBJobQueue returns a job queue backed by the ingestion_jobs table.
+func NewDBJobQueue(q db.Querier) *DBJobQueue {
+	return &DBJobQueue{q: q}
+}
+
+// PublishIngestRequested makes a re-queued job start again from its first
+// attempt.
+func (d *DBJobQueue) PublishIngestRequested(ctx context.Context, jobID uuid.UUID) error {
+	return d.q.DeleteIngestJobClaim(ctx, jobID)
+}
+
+// DBQueueOptions configures RunDBQueue.
+type DBQueueOptions struct {
+	// Workers is the number of jobs run at once.
+	Workers int
+	// MaxAttempts is how many times a job is tried before it is marked failed.
+	MaxAttempts int
+	// RetryDelay is multiplied by the attempts so far to space out retries.
+	RetryDelay time.Duration
+	// PollInterval is how long an idle worker waits between claims.
+	PollInterval time.Duration
+}
+
+// RunDBQueue runs jobs queued with DBJobQueue until ctx is cancelled. Each
+// worker claims the oldest pending job, interactive first, and concurrent
+// workers on any instance skip each other's claims. A failed job goes back
+// to pending for another attempt after RetryDelay times the attempts so
+// far, and is marked failed after MaxAttempts. While the LLM provider is
+// unhealthy nothing is claimed. Jobs left processing by a worker that died
+// are requeued once their claim outlives dbQueueLease.
+func (s *IngestService) RunDBQueue(ctx context.Context, opts DBQueueOptions) {
+	for range opts.Workers {
+		go s.runDBWorker(ctx, opts)
+	}
+
+	ticker := time.NewTicker(dbQueueLease / 5)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.q.RequeueStaleIngestionJobs(ctx, db.RequeueStaleIngestionJobsParams{
+				MaxAttempts:  int32(opts.MaxAttempts), //nolint:gosec // attempts are small
+				LeaseSeconds: dbQueueLease.Seconds(),
+			})
+			if err != nil {
+				s.log.ErrorContext(ctx, "requeue stale ingest jobs failed", "error", err)
+				continue
+			}
+			if n > 0 {
+				s.log.WarnContext(ctx, "requeued abandoned ingest jobs", "jobs", n)
+			}
+		}
+	}
+}
+
+func (s *IngestService) runDBWorker(ctx context.Context, opts DBQueueOptions) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		if s.claimDBJob(ctx, opts) {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(opts.PollInterval):
+		}
+	}
+}
+
+// claimDBJob claims and runs one job, reporting whether there was one.
+func (s *IngestService) claimDBJob(ctx context.Context, opts DBQueueOptions) bool {
+	if s.health != nil && !s.health.Healthy() {
+		return false
+	}
+	job, err := s.q.ClaimIngestionJob(ctx, opts.RetryDelay.Seconds())
+	if errors.Is(err, sql.ErrNoRows) {
+		return false
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			s.log.ErrorContext(ctx, "claim ingest job failed", "error", err)
+		}
+		return false
+	}
+	s.runClaimedJob(ctx, job, int(job.Attempts) >= opts.MaxAttempts)
+	return true
+}
+
+// runClaimedJob runs a claimed job to completion even if ctx is cancelled
+// meanwhile, so a shutting-down worker does not strand it in processing.
+// Unless final, a failure puts the job back to pending for another attempt.
+func (s *IngestService) runClaimedJob(ctx context.Context, job db.ClaimIngestionJobRow, final bool) {
+	ctx = context.WithoutCancel(ctx)
+	// A failed earlier attempt may have staged some items.
+	if err := s.q.DeleteStagedItemsByJob(ctx, job.ID); err != nil {
+		s.log.ErrorContext(ctx, "discard staged items failed", "job_id", job.ID, "error", err)
+		s.retryClaimedJob(ctx, job.ID, final, fmt.Errorf("discard staged items: %w", err))
+		return
+	}
+	task := ingestTask{jobID: job.ID, rawInput: job.RawInput, priority: job.Priority, done: make(chan error, 1)}
+	if err := s.runJob(task); err != nil {
+		s.retryClaimedJob(ctx, job.ID, final, err)
+	}
+}
+
+// retryClaimedJob puts a claimed job that failed with cause back to pending
+// for another attempt, or marks it failed when final.
+func (s *IngestService) retryClaimedJob(ctx context.Context, jobID uuid.UUID, final bool, cause error) {
+	if final {
+		s.MarkJobFailed(ctx, jobID, cause)
+		return
+	}
+	_, err := s.q.TransitionIngestionJobStatus(ctx, db.TransitionIngestionJobStatusParams{
+		ToStatus:   "pending",
+		ID:         jobID,
+		FromStatus: "processing",
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		s.log.ErrorContext(ctx, "failed to requeue ingest job", "job_id", jobID, "error", err)
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+var testDBQueueOptions = DBQueueOptions{Workers: 1, MaxAttempts: 3, RetryDelay: 30 * time.Second}
+
+func TestDBJobQueue_PublishResetsClaim(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	jobID := uuid.New()
+	mockQ.EXPECT().DeleteIngestJobClaim(mock.Anything, jobID).Return(nil)
+
+	require.NoError(t, NewDBJobQueue(mockQ).PublishIngestRequested(context.Background(), jobID))
+}
+
+func TestClaimDBJob_NothingPending(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	mockQ.EXPECT().ClaimIngestionJob(mock.Anything, 30.0).Return(db.ClaimIngestionJobRow{}, sql.ErrNoRows)
+
+	assert.False(t, svc.claimDBJob(context.Background(), testDBQueueOptions))
+}
+
+func TestClaimDBJob_SkipsWhileProviderUnhealthy(t *testing.T) {
+	t.Parallel()
+
+	svc := NewIngestService(mocks.NewMockQuerier(t), NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	health := NewProviderHealth(nil, 1)
+	health.RecordFailure(errors.New("openai down"))
+	svc.SetProviderHealth(health)
+
+	assert.False(t, svc.claimDBJob(context.Background(), testDBQueueOptions))
+}
+
+func TestClaimDBJob_RetriesUntilFinalAttempt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		attempts int32
+	}{
+		{"earlier attempt goes back to pending", 1},
+		{"final attempt is marked failed", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ := mocks.NewMockQuerier(t)
+			mockLLM := NewMockLLMExtractor(t)
+			svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), mockLLM)
+
+			jobID := uuid.New()
+			mockQ.EXPECT().ClaimIngestionJob(mock.Anything, 30.0).Return(db.ClaimIngestionJobRow{
+				ID: jobID, RawInput: "milk", Priority: PriorityInteractive, Attempts: tt.attempts,
+			}, nil)
+			mockQ.EXPECT().DeleteStagedItemsByJob(mock.Anything, jobID).Return(nil)
+			mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+			mockLLM.EXPECT().Extract(mock.Anything, "milk").Return(nil, errors.New("openai timeout"))
+			if int(tt.attempts) < testDBQueueOptions.MaxAttempts {
+				mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, db.TransitionIngestionJobStatusParams{
+					ToStatus: "pending", ID: jobID, FromStatus: "processing",
+				}).Return(db.IngestionJob{}, nil)
+			} else {
+				mockQ.EXPECT().FailIngestionJob(mock.Anything, db.FailIngestionJobParams{
+					ID: jobID, FailureReason: "llm extraction: openai timeout",
+				}).Return(1, nil)
+			}
+
+			assert.True(t, svc.claimDBJob(context.Background(), testDBQueueOptions))
+		})
+	}
+}
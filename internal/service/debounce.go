@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+// DefaultPublishDebounce is how long pantry.updated events are held so that
+// rapid edits reach consumers as one event.
+const DefaultPublishDebounce = 500 * time.Millisecond
+
+// debouncedPublishTimeout bounds a flush, which runs detached from the
+// request that started the window.
+const debouncedPublishTimeout = 10 * time.Second
+
+// DebouncedPublisher coalesces pantry.updated events. The first change opens
+// a window; every item changed before it closes is published together in one
+// event, each ID once. Events carry only IDs and consumers re-read the items,
+// so the coalesced event still leads them to the final state. A reset (an
+// empty changed list, meaning everything changed) absorbs the rest of the
+// window into a single empty-list event.
+type DebouncedPublisher struct {
+	next   UpdatePublisher
+	window time.Duration
+	log    *slog.Logger
+
+	mu      sync.Mutex
+	pending map[uuid.UUID]struct{}
+	order   []uuid.UUID
+	reset   bool
+	timer   *time.Timer
+}
+
+// NewDebouncedPublisher wraps next. A window of zero or less publishes every
+// event immediately.
+func NewDebouncedPublisher(next UpdatePublisher, window time.Duration) *DebouncedPublisher {
+	return &DebouncedPublisher{
+		next:    next,
+		window:  window,
+		log:     logging.For("debounce"),
+		pending: make(map[uuid.UUID]struct{}),
+	}
+}
+
+// PublishPantryUpdated adds changedItemIDs to the open window. Errors from
+// the wrapped publisher surface in the log at flush time, not here.
+func (d *DebouncedPublisher) PublishPantryUpdated(ctx context.Context, changedItemIDs []uuid.UUID) error {
+	if d.window <= 0 {
+		return d.next.PublishPantryUpdated(ctx, changedItemIDs)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(changedItemIDs) == 0 {
+		d.reset = true
+	}
+	for _, id := range changedItemIDs {
+		if _, ok := d.pending[id]; ok {
+			continue
+		}
+		d.pending[id] = struct{}{}
+		d.order = append(d.order, id)
+	}
+	if d.timer == nil {
+		d.timer = time.AfterFunc(d.window, d.flushWindow)
+	}
+	return nil
+}
+
+// Flush publishes anything still held, e.g. on shutdown.
+func (d *DebouncedPublisher) Flush(ctx context.Context) error {
+	ids, ok := d.take()
+	if !ok {
+		return nil
+	}
+	return d.next.PublishPantryUpdated(ctx, ids)
+}
+
+func (d *DebouncedPublisher) flushWindow() {
+	ctx, cancel := context.WithTimeout(context.Background(), debouncedPublishTimeout)
+	defer cancel()
+	ids, ok := d.take()
+	if !ok {
+		return
+	}
+	if err := d.next.PublishPantryUpdated(ctx, ids); err != nil {
+		d.log.Warn("failed to publish coalesced pantry.updated", "changed_item_ids", ids, "error", err)
+	}
+}
+
+// take closes the current window and returns its changed IDs. ok is false
+// when nothing is pending.
+func (d *DebouncedPublisher) take() ([]uuid.UUID, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	if !d.reset && len(d.order) == 0 {
+		return nil, false
+	}
+	ids := d.order
+	if d.reset {
+		ids = []uuid.UUID{}
+	}
+	d.pending = make(map[uuid.UUID]struct{})
+	d.order, d.reset = nil, false
+	return ids, true
+}
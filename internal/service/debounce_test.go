@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type chanUpdatePublisher chan []uuid.UUID
+
+func (c chanUpdatePublisher) PublishPantryUpdated(_ context.Context, ids []uuid.UUID) error {
+	c <- ids
+	return nil
+}
+
+func TestDebouncedPublisher_CoalescesWindow(t *testing.T) {
+	t.Parallel()
+
+	next := &stubUpdatePublisher{}
+	d := NewDebouncedPublisher(next, time.Hour)
+	a, b := uuid.New(), uuid.New()
+
+	for range 20 {
+		require.NoError(t, d.PublishPantryUpdated(context.Background(), []uuid.UUID{a}))
+	}
+	require.NoError(t, d.PublishPantryUpdated(context.Background(), []uuid.UUID{b, a}))
+	assert.Empty(t, next.published)
+
+	require.NoError(t, d.Flush(context.Background()))
+	assert.Equal(t, [][]uuid.UUID{{a, b}}, next.published)
+
+	require.NoError(t, d.Flush(context.Background()))
+	assert.Len(t, next.published, 1, "nothing pending after a flush")
+}
+
+func TestDebouncedPublisher_ResetAbsorbsWindow(t *testing.T) {
+	t.Parallel()
+
+	next := &stubUpdatePublisher{}
+	d := NewDebouncedPublisher(next, time.Hour)
+
+	require.NoError(t, d.PublishPantryUpdated(context.Background(), []uuid.UUID{uuid.New()}))
+	require.NoError(t, d.PublishPantryUpdated(context.Background(), []uuid.UUID{}))
+	require.NoError(t, d.PublishPantryUpdated(context.Background(), []uuid.UUID{uuid.New()}))
+	require.NoError(t, d.Flush(context.Background()))
+
+	require.Len(t, next.published, 1)
+	assert.Empty(t, next.published[0])
+}
+
+func TestDebouncedPublisher_FlushesAfterWindow(t *testing.T) {
+	t.Parallel()
+
+	next := make(chanUpdatePublisher, 2)
+	d := NewDebouncedPublisher(next, 20*time.Millisecond)
+	a, b := uuid.New(), uuid.New()
+
+	require.NoError(t, d.PublishPantryUpdated(context.Background(), []uuid.UUID{a}))
+	require.NoError(t, d.PublishPantryUpdated(context.Background(), []uuid.UUID{b}))
+
+	select {
+	case ids := <-next:
+		assert.Equal(t, []uuid.UUID{a, b}, ids)
+	case <-time.After(5 * time.Second):
+		t.Fatal("window never flushed")
+	}
+
+	require.NoError(t, d.PublishPantryUpdated(context.Background(), []uuid.UUID{a}))
+	select {
+	case ids := <-next:
+		assert.Equal(t, []uuid.UUID{a}, ids, "a later edit opens a new window")
+	case <-time.After(5 * time.Second):
+		t.Fatal("second window never flushed")
+	}
+}
+
+func TestDebouncedPublisher_ZeroWindowPassesThrough(t *testing.T) {
+	t.Parallel()
+
+	next := &stubUpdatePublisher{}
+	d := NewDebouncedPublisher(next, 0)
+	id := uuid.New()
+
+	require.NoError(t, d.PublishPantryUpdated(context.Background(), []uuid.UUID{id}))
+	require.NoError(t, d.PublishPantryUpdated(context.Background(), []uuid.UUID{id}))
+
+	assert.Equal(t, [][]uuid.UUID{{id}, {id}}, next.published)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+// DefaultProcessedMessageTTL is how long processed message IDs are remembered.
+// Brokers only redeliver unacked messages, so this just needs to comfortably
+// exceed the longest plausible redelivery delay.
+const DefaultProcessedMessageTTL = 7 * 24 * time.Hour
+
+// MessageDeduper records which inbound messages each consumer has handled so
+// that broker redeliveries are acknowledged without being applied twice.
+type MessageDeduper struct {
+	q   db.Querier
+	ttl time.Duration
+	log *slog.Logger
+}
+
+func NewMessageDeduper(q db.Querier, ttl time.Duration) *MessageDeduper {
+	if ttl <= 0 {
+		ttl = DefaultProcessedMessageTTL
+	}
+	return &MessageDeduper{q: q, ttl: ttl, log: logging.For("dedup")}
+}
+
+// ProcessOnce runs handle unless consumer has already processed messageID,
+// reporting whether handle ran. The ID is claimed before handle runs and
+// released again if handle fails, so a failed message is retried on
+// redelivery while a concurrent duplicate is skipped. A crash between claim
+// and completion drops that message; consumers that cannot tolerate this
+// should make handle itself idempotent. A message without an ID cannot be
+// told apart from a new one with the same body, so handle always runs.
+func (d *MessageDeduper) ProcessOnce(
+	ctx context.Context,
+	consumer, messageID string,
+	handle func(context.Context) error,
+) (bool, error) {
+	if messageID == "" {
+		return true, handle(ctx)
+	}
+
+	claimed, err := d.q.MarkMessageProcessed(ctx, db.MarkMessageProcessedParams{
+		Consumer:  consumer,
+		MessageID: messageID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("mark message processed: %w", err)
+	}
+	if claimed == 0 {
+		d.log.DebugContext(ctx, "skipping duplicate message", "consumer", consumer, "message_id", messageID)
+		return false, nil
+	}
+
+	if err := handle(ctx); err != nil {
+		if unmarkErr := d.q.UnmarkMessageProcessed(ctx, db.UnmarkMessageProcessedParams{
+			Consumer:  consumer,
+			MessageID: messageID,
+		}); unmarkErr != nil {
+			d.log.ErrorContext(ctx, "failed to release message claim",
+				"consumer", consumer, "message_id", messageID, "error", unmarkErr)
+		}
+		return true, err
+	}
+	return true, nil
+}
+
+// Cleanup deletes processed message IDs older than the TTL.
+func (d *MessageDeduper) Cleanup(ctx context.Context) (int64, error) {
+	n, err := d.q.DeleteProcessedMessagesBefore(ctx, time.Now().Add(-d.ttl))
+	if err != nil {
+		return 0, fmt.Errorf("delete processed messages: %w", err)
+	}
+	return n, nil
+}
+
+// RunCleanup calls Cleanup every interval until ctx is cancelled.
+func (d *MessageDeduper) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := d.Cleanup(ctx)
+			if err != nil {
+				d.log.ErrorContext(ctx, "processed message cleanup failed", "error", err)
+				continue
+			}
+			if n > 0 {
+				d.log.InfoContext(ctx, "expired processed messages", "deleted", n)
+			}
+		}
+	}
+}
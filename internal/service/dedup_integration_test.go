@@ -0,0 +1,47 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/testutil"
+)
+
+func TestMessageDeduper_Redelivery(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	d := NewMessageDeduper(db.New(sqlDB), 0)
+	ctx := context.Background()
+
+	calls := 0
+	handle := func(context.Context) error {
+		calls++
+		return nil
+	}
+
+	ran, err := d.ProcessOnce(ctx, "recipe.cooked", "msg-1", handle)
+	require.NoError(t, err)
+	assert.True(t, ran)
+
+	// Redelivery of the same message is skipped.
+	ran, err = d.ProcessOnce(ctx, "recipe.cooked", "msg-1", handle)
+	require.NoError(t, err)
+	assert.False(t, ran)
+
+	// The same ID on another consumer is independent.
+	ran, err = d.ProcessOnce(ctx, "dictionary.merged", "msg-1", handle)
+	require.NoError(t, err)
+	assert.True(t, ran)
+
+	assert.Equal(t, 2, calls)
+
+	// Nothing is old enough to expire yet.
+	n, err := d.Cleanup(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestProcessOnce_RunsHandlerForNewMessage(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	d := NewMessageDeduper(mockQ, time.Hour)
+
+	mockQ.EXPECT().MarkMessageProcessed(mock.Anything, db.MarkMessageProcessedParams{
+		Consumer:  "recipe.cooked",
+		MessageID: "msg-1",
+	}).Return(1, nil)
+
+	calls := 0
+	ran, err := d.ProcessOnce(context.Background(), "recipe.cooked", "msg-1", func(context.Context) error {
+		calls++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, ran)
+	assert.Equal(t, 1, calls)
+}
+
+func TestProcessOnce_SkipsDuplicate(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	d := NewMessageDeduper(mockQ, time.Hour)
+
+	mockQ.EXPECT().MarkMessageProcessed(mock.Anything, mock.Anything).Return(0, nil)
+
+	ran, err := d.ProcessOnce(context.Background(), "recipe.cooked", "msg-1", func(context.Context) error {
+		t.Fatal("handler must not run for a duplicate")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.False(t, ran)
+}
+
+func TestProcessOnce_ReleasesClaimOnFailure(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	d := NewMessageDeduper(mockQ, time.Hour)
+
+	mockQ.EXPECT().MarkMessageProcessed(mock.Anything, mock.Anything).Return(1, nil)
+	mockQ.EXPECT().UnmarkMessageProcessed(mock.Anything, db.UnmarkMessageProcessedParams{
+		Consumer:  "recipe.cooked",
+		MessageID: "msg-1",
+	}).Return(nil)
+
+	handlerErr := errors.New("boom")
+	ran, err := d.ProcessOnce(context.Background(), "recipe.cooked", "msg-1", func(context.Context) error {
+		return handlerErr
+	})
+	require.ErrorIs(t, err, handlerErr)
+	assert.True(t, ran)
+}
+
+func TestProcessOnce_AlwaysRunsMessageWithoutID(t *testing.T) {
+	t.Parallel()
+
+	// No claim is recorded: two events with the same body are both applied.
+	d := NewMessageDeduper(mocks.NewMockQuerier(t), time.Hour)
+	calls := 0
+	for range 2 {
+		ran, err := d.ProcessOnce(context.Background(), "recipe.cooked", "", func(context.Context) error {
+			calls++
+			return nil
+		})
+		require.NoError(t, err)
+		assert.True(t, ran)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestDeduperCleanup_UsesTTL(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	d := NewMessageDeduper(mockQ, 24*time.Hour)
+
+	mockQ.EXPECT().DeleteProcessedMessagesBefore(mock.Anything, mock.MatchedBy(func(cutoff time.Time) bool {
+		return time.Since(cutoff) > 23*time.Hour && time.Since(cutoff) < 25*time.Hour
+	})).Return(3, nil)
+
+	n, err := d.Cleanup(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+var (
+	// ErrDisplayOverrideNotFound is returned when deleting an ingredient
+	// without a display override.
+	ErrDisplayOverrideNotFound = errors.New("no display override for ingredient")
+	// ErrInvalidDisplayOverride wraps display override validation failures.
+	ErrInvalidDisplayOverride = errors.New("invalid display override")
+)
+
+const (
+	maxDisplayNameLength = 80
+	// maxEmojiLength is in code points: flags, skin tones and ZWJ sequences
+	// take several.
+	maxEmojiLength = 10
+)
+
+// DisplayOverrides holds the household's own name and emoji for an
+// ingredient ("Dad's hot sauce" 🌶️). They only change what list responses
+// show: resolution, availability, events and the Dictionary mapping keep the
+// canonical ingredient.
+type DisplayOverrides struct {
+	q   db.Querier
+	log *slog.Logger
+}
+
+func NewDisplayOverrides(q db.Querier) *DisplayOverrides {
+	return &DisplayOverrides{q: q, log: logging.For("display-overrides")}
+}
+
+// DisplayOverride is the household's name and emoji for one ingredient.
+// Either may be empty.
+type DisplayOverride struct {
+	IngredientID uuid.UUID `json:"ingredient_id"`
+	DisplayName  string    `json:"display_name,omitempty"`
+	Emoji        string    `json:"emoji,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func toDisplayOverride(row db.IngredientDisplayOverride) DisplayOverride {
+	return DisplayOverride{
+		IngredientID: row.IngredientID,
+		DisplayName:  row.DisplayName.String,
+		Emoji:        row.Emoji.String,
+		UpdatedAt:    row.UpdatedAt,
+	}
+}
+
+func (d *DisplayOverrides) List(ctx context.Context) ([]DisplayOverride, error) {
+	rows, err := d.q.ListIngredientDisplayOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]DisplayOverride, len(rows))
+	for i, r := range rows {
+		out[i] = toDisplayOverride(r)
+	}
+	return out, nil
+}
+
+// Set replaces the override for ingredientID. At least one of displayName
+// and emoji is required; an empty one is cleared.
+func (d *DisplayOverrides) Set(
+	ctx context.Context, ingredientID uuid.UUID, displayName, emoji string,
+) (DisplayOverride, error) {
+	displayName, emoji = strings.TrimSpace(displayName), strings.TrimSpace(emoji)
+	switch {
+	case displayName == "" && emoji == "":
+		return DisplayOverride{}, fmt.Errorf("%w: display_name or emoji is required", ErrInvalidDisplayOverride)
+	case utf8.RuneCountInString(displayName) > maxDisplayNameLength:
+		return DisplayOverride{}, fmt.Errorf("%w: display_name is longer than %d characters",
+			ErrInvalidDisplayOverride, maxDisplayNameLength)
+	case !validEmoji(emoji):
+		return DisplayOverride{}, fmt.Errorf("%w: emoji must be a single emoji", ErrInvalidDisplayOverride)
+	}
+	row, err := d.q.UpsertIngredientDisplayOverride(ctx, db.UpsertIngredientDisplayOverrideParams{
+		IngredientID: ingredientID,
+		DisplayName:  sql.NullString{String: displayName, Valid: displayName != ""},
+		Emoji:        sql.NullString{String: emoji, Valid: emoji != ""},
+	})
+	if err != nil {
+		return DisplayOverride{}, err
+	}
+	return toDisplayOverride(row), nil
+}
+
+// validEmoji accepts an empty string or a short run of symbols: no letters,
+// digits or spaces, so a name cannot be smuggled in as an emoji.
+func validEmoji(s string) bool {
+	if utf8.RuneCountInString(s) > maxEmojiLength {
+		return false
+	}
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Delete removes an ingredient's override so it shows the Dictionary name.
+func (d *DisplayOverrides) Delete(ctx context.Context, ingredientID uuid.UUID) error {
+	n, err := d.q.DeleteIngredientDisplayOverride(ctx, ingredientID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrDisplayOverrideNotFound
+	}
+	return nil
+}
+
+// Names loads every override by ingredient for one response. A failed load
+// is logged and yields none, so lists still render with canonical names.
+func (d *DisplayOverrides) Names(ctx context.Context) map[uuid.UUID]DisplayOverride {
+	if d == nil {
+		return nil
+	}
+	rows, err := d.q.ListIngredientDisplayOverrides(ctx)
+	if err != nil {
+		d.log.WarnContext(ctx, "failed to load display overrides; using canonical names", "error", err)
+		return nil
+	}
+	names := make(map[uuid.UUID]DisplayOverride, len(rows))
+	for _, r := range rows {
+		names[r.IngredientID] = toDisplayOverride(r)
+	}
+	return names
+}
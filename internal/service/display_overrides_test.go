@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestDisplayOverrides_Set(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	overrides := NewDisplayOverrides(mockQ)
+	id := uuid.New()
+
+	mockQ.EXPECT().UpsertIngredientDisplayOverride(mock.Anything, db.UpsertIngredientDisplayOverrideParams{
+		IngredientID: id,
+		DisplayName:  sql.NullString{String: "Dad's hot sauce", Valid: true},
+	}).Return(db.IngredientDisplayOverride{
+		IngredientID: id, DisplayName: sql.NullString{String: "Dad's hot sauce", Valid: true},
+	}, nil)
+	got, err := overrides.Set(context.Background(), id, "  Dad's hot sauce ", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Dad's hot sauce", got.DisplayName)
+	assert.Empty(t, got.Emoji)
+
+	mockQ.EXPECT().UpsertIngredientDisplayOverride(mock.Anything, db.UpsertIngredientDisplayOverrideParams{
+		IngredientID: id,
+		Emoji:        sql.NullString{String: "🏳️‍🌈", Valid: true},
+	}).Return(db.IngredientDisplayOverride{IngredientID: id}, nil)
+	_, err = overrides.Set(context.Background(), id, "", "🏳️‍🌈")
+	require.NoError(t, err)
+
+	for _, bad := range []struct{ name, emoji string }{
+		{"", " "},
+		{strings.Repeat("a", maxDisplayNameLength+1), ""},
+		{"", "hot"},
+		{"", "🌶 🌶"},
+		{"", strings.Repeat("🌶", maxEmojiLength+1)},
+	} {
+		_, err := overrides.Set(context.Background(), id, bad.name, bad.emoji)
+		assert.ErrorIs(t, err, ErrInvalidDisplayOverride, "%q %q", bad.name, bad.emoji)
+	}
+}
+
+func TestDisplayOverrides_DeleteNotFound(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	id := uuid.New()
+	mockQ.EXPECT().DeleteIngredientDisplayOverride(mock.Anything, id).Return(0, nil)
+
+	assert.ErrorIs(t, NewDisplayOverrides(mockQ).Delete(context.Background(), id), ErrDisplayOverrideNotFound)
+}
+
+func TestDisplayOverrides_NamesFallsBackOnError(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().ListIngredientDisplayOverrides(mock.Anything).Return(nil, errors.New("db down"))
+
+	assert.Nil(t, NewDisplayOverrides(mockQ).Names(context.Background()))
+	var unset *DisplayOverrides
+	assert.Nil(t, unset.Names(context.Background()), "no overrides configured")
+}
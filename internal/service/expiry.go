@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+const (
+	// DefaultExpiryLeadDays applies to categories without a configured lead
+	// time, and to items whose category cannot be looked up.
+	DefaultExpiryLeadDays = 3
+	// DefaultExpiryScanInterval is how often RunScan looks for newly
+	// expiring items.
+	DefaultExpiryScanInterval = time.Hour
+	// categoryCacheTTL is how long an ingredient's Dictionary category is
+	// reused before it is fetched again.
+	categoryCacheTTL = time.Hour
+)
+
+var (
+	// ErrLeadTimeNotFound is returned when deleting a category without a
+	// configured lead time.
+	ErrLeadTimeNotFound = errors.New("no lead time configured for category")
+	// ErrInvalidLeadTime wraps lead time validation failures.
+	ErrInvalidLeadTime = errors.New("invalid lead time")
+)
+
+// ExpiringPublisher announces items that have entered their expiry window.
+type ExpiringPublisher interface {
+	PublishPantryExpiring(ctx context.Context, itemIDs []uuid.UUID) error
+}
+
+// ExpiryNotifier tells the household about items that have entered their
+// expiry window.
+type ExpiryNotifier interface {
+	NotifyExpiring(ctx context.Context, items []ExpiringItem) error
+}
+
+// ExpiryService finds pantry items that are about to expire. How early an
+// item counts as expiring depends on its Dictionary category: lettuce needs
+// warning days ahead, rice weeks. Categories come from the Dictionary at scan
+// time and are never stored here.
+type ExpiryService struct {
+	q           db.Querier
+	defaultLead int
+	notifier    ExpiryNotifier
+	now         func() time.Time
+	log         *slog.Logger
+
+	categories *categoryCache
+
+	mu        sync.Mutex
+	announced map[uuid.UUID]time.Time // item → expires_at already published
+}
+
+func NewExpiryService(q db.Querier, lookup IngredientLookup, defaultLeadDays int) *ExpiryService {
+	return &ExpiryService{
+		q:           q,
+		defaultLead: defaultLeadDays,
+		now:         time.Now,
+		log:         logging.For("expiry"),
+		categories:  newCategoryCache(lookup),
+		announced:   make(map[uuid.UUID]time.Time),
+	}
+}
+
+// LeadTime is the configured warning window for one ingredient category.
+type LeadTime struct {
+	Category  string    `json:"category"`
+	LeadDays  int       `json:"lead_days"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ExpiringItem is a pantry item inside its category's expiry window.
+type ExpiringItem struct {
+	Item     db.PantryItem
+	Category string
+	LeadDays int
+	Expired  bool
+}
+
+// SetNotifier makes Scan pass newly expiring items to n as well as
+// publishing them.
+func (s *ExpiryService) SetNotifier(n ExpiryNotifier) {
+	s.notifier = n
+}
+
+// DefaultLeadDays returns the lead time used for unconfigured categories.
+func (s *ExpiryService) DefaultLeadDays() int {
+	return s.defaultLead
+}
+
+func (s *ExpiryService) ListLeadTimes(ctx context.Context) ([]LeadTime, error) {
+	rows, err := s.q.ListExpiryLeadTimes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]LeadTime, len(rows))
+	for i, r := range rows {
+		out[i] = toLeadTime(r)
+	}
+	return out, nil
+}
+
+// SetLeadTime configures the lead time for category, matched
+// case-insensitively against Dictionary categories.
+func (s *ExpiryService) SetLeadTime(ctx context.Context, category string, leadDays int) (LeadTime, error) {
+	category = normalizeCategory(category)
+	if category == "" {
+		return LeadTime{}, fmt.Errorf("%w: category is required", ErrInvalidLeadTime)
+	}
+	if leadDays < 0 || leadDays > 365 {
+		return LeadTime{}, fmt.Errorf("%w: lead_days must be between 0 and 365", ErrInvalidLeadTime)
+	}
+	row, err := s.q.UpsertExpiryLeadTime(ctx, db.UpsertExpiryLeadTimeParams{
+		Category: category,
+		LeadDays: int32(leadDays), //nolint:gosec // bounded above
+	})
+	if err != nil {
+		return LeadTime{}, err
+	}
+	return toLeadTime(row), nil
+}
+
+// DeleteLeadTime removes a category's lead time so it falls back to the
+// default.
+func (s *ExpiryService) DeleteLeadTime(ctx context.Context, category string) error {
+	n, err := s.q.DeleteExpiryLeadTime(ctx, normalizeCategory(category))
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrLeadTimeNotFound
+	}
+	return nil
+}
+
+// Expiring returns items that expire within their category's lead time,
+// including already expired ones, soonest first. An item whose category
+// cannot be fetched uses the default lead time rather than being skipped.
+func (s *ExpiryService) Expiring(ctx context.Context) ([]ExpiringItem, error) {
+	rows, err := s.q.ListExpiryLeadTimes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list lead times: %w", err)
+	}
+	leads := make(map[string]int, len(rows))
+	maxLead := s.defaultLead
+	for _, r := range rows {
+		leads[r.Category] = int(r.LeadDays)
+		maxLead = max(maxLead, int(r.LeadDays))
+	}
+
+	now := s.now()
+	items, err := s.q.ListPantryItemsExpiringBefore(ctx, sql.NullTime{Time: now.AddDate(0, 0, maxLead), Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("list items: %w", err)
+	}
+
+	out := []ExpiringItem{}
+	for _, item := range items {
+		category := s.category(ctx, item.IngredientID)
+		lead, ok := leads[category]
+		if !ok {
+			lead = s.defaultLead
+		}
+		if item.ExpiresAt.Time.After(now.AddDate(0, 0, lead)) {
+			continue
+		}
+		out = append(out, ExpiringItem{
+			Item:     item,
+			Category: category,
+			LeadDays: lead,
+			Expired:  !item.ExpiresAt.Time.After(now),
+		})
+	}
+	return out, nil
+}
+
+// Scan publishes the IDs of items that entered their expiry window since the
+// last scan, and hands the items to the notifier if one is set. publisher may
+// be nil when no broker is configured. An item is announced again only if its
+// expiry date changes. If publishing or notifying fails, both are repeated on
+// the next scan. Announcements are kept in memory, so a restart repeats them
+// once.
+func (s *ExpiryService) Scan(ctx context.Context, publisher ExpiringPublisher) error {
+	items, err := s.Expiring(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	current := make(map[uuid.UUID]time.Time, len(items))
+	var fresh []ExpiringItem
+	for _, it := range items {
+		current[it.Item.ID] = it.Item.ExpiresAt.Time
+		if at, ok := s.announced[it.Item.ID]; !ok || !at.Equal(it.Item.ExpiresAt.Time) {
+			fresh = append(fresh, it)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(fresh) == 0 {
+		return nil
+	}
+	var errs []error
+	if publisher != nil {
+		ids := make([]uuid.UUID, len(fresh))
+		for i, it := range fresh {
+			ids[i] = it.Item.ID
+		}
+		if err := publisher.PublishPantryExpiring(ctx, ids); err != nil {
+			errs = append(errs, fmt.Errorf("publish pantry.expiring: %w", err))
+		}
+	}
+	if s.notifier != nil {
+		if err := s.notifier.NotifyExpiring(ctx, fresh); err != nil {
+			errs = append(errs, fmt.Errorf("notify expiring: %w", err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.announced = current
+	s.mu.Unlock()
+	s.log.InfoContext(ctx, "expiring items announced", "count", len(fresh))
+	return nil
+}
+
+// RunScan calls Scan every interval until ctx is cancelled.
+func (s *ExpiryService) RunScan(ctx context.Context, publisher ExpiringPublisher, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.Scan(ctx, publisher); err != nil {
+			s.log.WarnContext(ctx, "expiry scan failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// category returns the ingredient's normalized Dictionary category, or ""
+// if it has none or the lookup fails.
+func (s *ExpiryService) category(ctx context.Context, id uuid.UUID) string {
+	category, err := s.categories.get(ctx, id, s.now())
+	if err != nil {
+		s.log.WarnContext(ctx, "could not fetch ingredient category; using default lead time",
+			"ingredient_id", id, "error", err)
+		return ""
+	}
+	return category
+}
+
+// categoryCache remembers ingredients' normalized Dictionary categories for
+// categoryCacheTTL. Failed lookups are not cached.
+type categoryCache struct {
+	lookup IngredientLookup
+
+	mu      sync.Mutex
+	entries map[uuid.UUID]cachedCategory
+}
+
+type cachedCategory struct {
+	category string
+	expires  time.Time
+}
+
+func newCategoryCache(lookup IngredientLookup) *categoryCache {
+	return &categoryCache{lookup: lookup, entries: make(map[uuid.UUID]cachedCategory)}
+}
+
+func (c *categoryCache) get(ctx context.Context, id uuid.UUID, now time.Time) (string, error) {
+	c.mu.Lock()
+	cached, ok := c.entries[id]
+	c.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.category, nil
+	}
+
+	ing, err := c.lookup.GetIngredient(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	category := normalizeCategory(ing.Category)
+
+	c.mu.Lock()
+	c.entries[id] = cachedCategory{category: category, expires: now.Add(categoryCacheTTL)}
+	c.mu.Unlock()
+	return category, nil
+}
+
+func normalizeCategory(c string) string {
+	return strings.ToLower(strings.TrimSpace(c))
+}
+
+func toLeadTime(r db.ExpiryLeadTime) LeadTime {
+	return LeadTime{Category: r.Category, LeadDays: int(r.LeadDays), UpdatedAt: r.UpdatedAt}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+type recordingExpiringPublisher struct {
+	published [][]uuid.UUID
+}
+
+func (p *recordingExpiringPublisher) PublishPantryExpiring(_ context.Context, ids []uuid.UUID) error {
+	p.published = append(p.published, ids)
+	return nil
+}
+
+func newTestExpiry(t *testing.T, now time.Time) (*ExpiryService, *mocks.MockQuerier, *MockIngredientLookup) {
+	t.Helper()
+	mockQ := mocks.NewMockQuerier(t)
+	lookup := NewMockIngredientLookup(t)
+	svc := NewExpiryService(mockQ, lookup, 3)
+	svc.now = func() time.Time { return now }
+	return svc, mockQ, lookup
+}
+
+func pantryItemExpiring(ingredientID uuid.UUID, at time.Time) db.PantryItem {
+	return db.PantryItem{
+		ID:           uuid.New(),
+		IngredientID: ingredientID,
+		Quantity:     1,
+		Unit:         "piece",
+		ExpiresAt:    sql.NullTime{Time: at, Valid: true},
+	}
+}
+
+func TestExpiring_UsesCategoryLeadTimes(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc, mockQ, lookup := newTestExpiry(t, now)
+
+	lettuce, rice, mystery := uuid.New(), uuid.New(), uuid.New()
+	lettuceItem := pantryItemExpiring(lettuce, now.AddDate(0, 0, 1)) // produce: 2 days → expiring
+	riceItem := pantryItemExpiring(rice, now.AddDate(0, 0, 10))      // frozen: 14 days → expiring
+	mysteryItem := pantryItemExpiring(mystery, now.AddDate(0, 0, 5)) // default: 3 days → not yet
+
+	mockQ.EXPECT().ListExpiryLeadTimes(mock.Anything).Return([]db.ExpiryLeadTime{
+		{Category: "produce", LeadDays: 2},
+		{Category: "frozen", LeadDays: 14},
+	}, nil)
+	mockQ.EXPECT().ListPantryItemsExpiringBefore(mock.Anything, sql.NullTime{Time: now.AddDate(0, 0, 14), Valid: true}).
+		Return([]db.PantryItem{lettuceItem, mysteryItem, riceItem}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, lettuce).Return(clients.Ingredient{Category: "Produce"}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, rice).Return(clients.Ingredient{Category: "frozen"}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, mystery).Return(clients.Ingredient{}, errors.New("dictionary down"))
+
+	items, err := svc.Expiring(context.Background())
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, lettuceItem.ID, items[0].Item.ID)
+	assert.Equal(t, "produce", items[0].Category)
+	assert.Equal(t, 2, items[0].LeadDays)
+	assert.Equal(t, riceItem.ID, items[1].Item.ID)
+	assert.Equal(t, 14, items[1].LeadDays)
+	assert.False(t, items[1].Expired)
+}
+
+func TestScan_AnnouncesEachItemOnce(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc, mockQ, lookup := newTestExpiry(t, now)
+
+	milk := uuid.New()
+	first := pantryItemExpiring(milk, now.AddDate(0, 0, 1))
+	second := pantryItemExpiring(milk, now.Add(-time.Hour))
+
+	mockQ.EXPECT().ListExpiryLeadTimes(mock.Anything).Return(nil, nil)
+	mockQ.EXPECT().ListPantryItemsExpiringBefore(mock.Anything, mock.Anything).
+		Return([]db.PantryItem{first}, nil).Twice()
+	mockQ.EXPECT().ListPantryItemsExpiringBefore(mock.Anything, mock.Anything).
+		Return([]db.PantryItem{first, second}, nil).Once()
+	// The category is cached after the first lookup.
+	lookup.EXPECT().GetIngredient(mock.Anything, milk).Return(clients.Ingredient{Category: "dairy"}, nil).Once()
+
+	pub := &recordingExpiringPublisher{}
+	require.NoError(t, svc.Scan(context.Background(), pub))
+	require.NoError(t, svc.Scan(context.Background(), pub))
+	require.NoError(t, svc.Scan(context.Background(), pub))
+
+	require.Len(t, pub.published, 2)
+	assert.Equal(t, []uuid.UUID{first.ID}, pub.published[0])
+	assert.Equal(t, []uuid.UUID{second.ID}, pub.published[1])
+}
+
+func TestSetLeadTime_Validates(t *testing.T) {
+	t.Parallel()
+
+	svc, mockQ, _ := newTestExpiry(t, time.Now())
+
+	_, err := svc.SetLeadTime(context.Background(), " ", 3)
+	require.ErrorIs(t, err, ErrInvalidLeadTime)
+	_, err = svc.SetLeadTime(context.Background(), "dairy", -1)
+	require.ErrorIs(t, err, ErrInvalidLeadTime)
+
+	mockQ.EXPECT().UpsertExpiryLeadTime(mock.Anything, db.UpsertExpiryLeadTimeParams{Category: "dairy", LeadDays: 5}).
+		Return(db.ExpiryLeadTime{Category: "dairy", LeadDays: 5}, nil)
+	lead, err := svc.SetLeadTime(context.Background(), " Dairy ", 5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, lead.LeadDays)
+}
+
+type recordingExpiryNotifier struct {
+	notified [][]ExpiringItem
+}
+
+func (n *recordingExpiryNotifier) NotifyExpiring(_ context.Context, items []ExpiringItem) error {
+	n.notified = append(n.notified, items)
+	return nil
+}
+
+func TestScan_NotifiesWithoutPublisher(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc, mockQ, lookup := newTestExpiry(t, now)
+	notifier := &recordingExpiryNotifier{}
+	svc.SetNotifier(notifier)
+
+	milk := uuid.New()
+	item := pantryItemExpiring(milk, now.AddDate(0, 0, 1))
+	mockQ.EXPECT().ListExpiryLeadTimes(mock.Anything).Return(nil, nil)
+	mockQ.EXPECT().ListPantryItemsExpiringBefore(mock.Anything, mock.Anything).Return([]db.PantryItem{item}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, milk).Return(clients.Ingredient{Category: "dairy"}, nil)
+
+	require.NoError(t, svc.Scan(context.Background(), nil))
+	require.NoError(t, svc.Scan(context.Background(), nil))
+
+	require.Len(t, notifier.notified, 1)
+	assert.Equal(t, item.ID, notifier.notified[0][0].Item.ID)
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidExtraction is returned when a model's reply is valid JSON but
+// not a usable ExtractionResponse, e.g. from a provider that does not
+// enforce extractionSchema.
+var ErrInvalidExtraction = errors.New("invalid extraction")
+
+// extractionSchema is the JSON schema of an ExtractionResponse. It follows
+// the rules of OpenAI's strict structured outputs: every property is
+// required, optional ones are nullable, and no other properties are allowed.
+var extractionSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"items": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"raw_text":   map[string]any{"type": "string"},
+					"name":       map[string]any{"type": "string"},
+					"quantity":   map[string]any{"type": []string{"number", "null"}},
+					"unit":       map[string]any{"type": "string"},
+					"confidence": map[string]any{"type": "number"},
+					"fill_level": map[string]any{
+						"type": []string{"string", "null"},
+						"enum": []any{FillLevelFull, FillLevelHalf, FillLevelLow, nil},
+					},
+				},
+				"required":             []string{"raw_text", "name", "quantity", "unit", "confidence", "fill_level"},
+				"additionalProperties": false,
+			},
+		},
+	},
+	"required":             []string{"items"},
+	"additionalProperties": false,
+}
+
+// extractionResponseFormat makes an OpenAI-compatible server constrain its
+// reply to extractionSchema, so it cannot add prose or change field types.
+var extractionResponseFormat = map[string]any{
+	"type": "json_schema",
+	"json_schema": map[string]any{
+		"name":   "extraction",
+		"strict": true,
+		"schema": extractionSchema,
+	},
+}
+
+// jsonObjectFormat only asks for a JSON object, for replies without a
+// schema such as PII spans.
+var jsonObjectFormat = map[string]any{"type": "json_object"}
+
+// parseExtraction decodes a model's reply into the items it extracted and
+// checks them against what the schema and prompt require.
+func parseExtraction(reply string, tokens int) (*ExtractionResponse, error) {
+	var decoded struct {
+		Items json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(reply), &decoded); err != nil {
+		return nil, fmt.Errorf("parse extraction json: %w", err)
+	}
+	// A null list is an empty one; a missing one means the model answered
+	// something else.
+	if decoded.Items == nil {
+		return nil, fmt.Errorf("%w: no items array", ErrInvalidExtraction)
+	}
+	extracted := &ExtractionResponse{TokensUsed: tokens}
+	if err := json.Unmarshal(decoded.Items, &extracted.Items); err != nil {
+		return nil, fmt.Errorf("parse extraction json: %w", err)
+	}
+	if err := validateExtraction(extracted); err != nil {
+		return nil, err
+	}
+	return extracted, nil
+}
+
+// validateExtraction rejects items the rest of ingest cannot stage: no
+// name, a negative quantity or a confidence outside 0 to 1. A missing
+// quantity arrives as zero and is staged as unknown.
+func validateExtraction(resp *ExtractionResponse) error {
+	for i, item := range resp.Items {
+		switch {
+		case strings.TrimSpace(item.Name) == "":
+			return fmt.Errorf("%w: item %d has no name", ErrInvalidExtraction, i)
+		case item.Quantity < 0:
+			return fmt.Errorf("%w: item %d has quantity %g", ErrInvalidExtraction, i, item.Quantity)
+		case item.Confidence < 0 || item.Confidence > 1:
+			return fmt.Errorf("%w: item %d has confidence %g", ErrInvalidExtraction, i, item.Confidence)
+		}
+	}
+	return nil
+}
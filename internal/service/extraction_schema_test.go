@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/testutil/llmserver"
+)
+
+func TestOpenAIExtractor_SendsStrictSchema(t *testing.T) {
+	t.Parallel()
+
+	server := llmserver.New(t, llmserver.WithDefault(llmserver.HappyPath(ExtractionResponse{})))
+	extractor := NewOpenAIExtractor("sk-test", "gpt-test", WithBaseURL(server.URL))
+
+	_, err := extractor.Extract(context.Background(), "2 cups flour")
+	require.NoError(t, err)
+	require.NoError(t, extractor.DryRun(context.Background()))
+
+	reqs := server.Requests()
+	require.Len(t, reqs, 2)
+	assert.Equal(t, "json_schema", reqs[0].ResponseFormat["type"])
+	schema, _ := reqs[0].ResponseFormat["json_schema"].(map[string]any)
+	assert.Equal(t, true, schema["strict"])
+	assert.Equal(t, "json_object", reqs[1].ResponseFormat["type"], "the dry run needs no schema")
+}
+
+func TestParseExtraction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		reply   string
+		wantErr string
+	}{
+		{name: "valid", reply: `{"items":[{"name":"olive oil","quantity":null,"unit":"","confidence":0.8}]}`},
+		{name: "empty list", reply: `{"items":[]}`},
+		{name: "not json", reply: `Sure! {"items": [`, wantErr: "parse extraction json"},
+		{name: "wrong type", reply: `{"items":[{"name":"milk","quantity":"2"}]}`, wantErr: "parse extraction json"},
+		{name: "no items", reply: `{"ingredients":[]}`, wantErr: "no items array"},
+		{name: "no name", reply: `{"items":[{"name":" ","confidence":0.9}]}`, wantErr: "item 0 has no name"},
+		{name: "negative quantity", reply: `{"items":[{"name":"milk","quantity":-1}]}`, wantErr: "quantity -1"},
+		{
+			name:    "confidence out of range",
+			reply:   `{"items":[{"name":"milk","confidence":85}]}`,
+			wantErr: "confidence 85",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp, err := parseExtraction(tt.reply, 10)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, 10, resp.TokensUsed)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
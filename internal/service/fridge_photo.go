@@ -0,0 +1,80 @@
+package service
+
+import (
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// JobTypeFridgePhoto is the ingest job type for a photo of a fridge, freezer
+// or cupboard taken as a stock check. Confirming it sets the quantity of
+// every item seen instead of adding to it.
+const JobTypeFridgePhoto = "fridge_photo"
+
+// Fill levels the vision model reports for an opened package.
+const (
+	FillLevelFull = "full"
+	FillLevelHalf = "half"
+	FillLevelLow  = "low"
+)
+
+// fillFractions is the share of a package left at each fill level.
+var fillFractions = map[string]float64{
+	FillLevelFull: 1,
+	FillLevelHalf: 0.5,
+	FillLevelLow:  0.25,
+}
+
+// fillEstimateConfidence caps the confidence of a quantity estimated from a
+// fill level: a glance at a carton is never better than a guess.
+const fillEstimateConfidence = 0.5
+
+const fridgePhotoPrompt = `This is a photo of the inside of a fridge, freezer or cupboard, taken to check what is left.
+List the food and drink you can see. Describe each item as you see it in "raw_text" (e.g. "milk carton, about half full").
+For loose countable items (eggs, apples, yoghurt pots) give one item with the count as "quantity" and "piece" as "unit".
+For packaged products give one item per package. Set "quantity" and "unit" to the size of the full package,
+as printed or the usual size if it cannot be read (e.g. 1 and "l" for a milk carton), or null and "" if you cannot tell,
+and set "fill_level" to "full", "half" or "low" for how much is left.
+Skip empty packages and anything that is not food or drink.
+Set confidence below 0.7 for items you cannot identify clearly.`
+
+// applyFillLevel turns an opened package's full size into the amount left
+// and caps its confidence. estimated is false for an item without a fill
+// level. An unrecognized level keeps the package size, but still counts as
+// an estimate so the item is reviewed.
+func applyFillLevel(item ExtractedItem) (_ ExtractedItem, estimated bool) {
+	level := strings.ToLower(strings.TrimSpace(item.FillLevel))
+	if level == "" {
+		return item, false
+	}
+	if f, ok := fillFractions[level]; ok {
+		// Stored quantities are NUMERIC(12,3).
+		item.Quantity = math.Round(item.Quantity*f*1000) / 1000
+	}
+	item.Confidence = min(item.Confidence, fillEstimateConfidence)
+	return item, true
+}
+
+// totalStock prepares a stock check for UpsertItemsBulk, which keeps only the
+// last input per ingredient: that input gets the sum of the known quantities
+// of every input in its unit, so two cartons of milk count as both.
+func totalStock(inputs []ItemInput) []ItemInput {
+	last := make(map[uuid.UUID]int, len(inputs))
+	for i, in := range inputs {
+		last[in.IngredientID] = i
+	}
+	out := make([]ItemInput, len(inputs))
+	copy(out, inputs)
+	for i, in := range inputs {
+		j := last[in.IngredientID]
+		if i == j || in.QuantityUnknown || in.Unit != inputs[j].Unit {
+			continue
+		}
+		if out[j].QuantityUnknown {
+			out[j].Quantity, out[j].QuantityUnknown = 0, false
+		}
+		out[j].Quantity += in.Quantity
+	}
+	return out
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/testutil/llmserver"
+)
+
+func TestApplyFillLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		item          ExtractedItem
+		wantQuantity  float64
+		wantEstimated bool
+	}{
+		{"no fill level", ExtractedItem{Quantity: 6, Confidence: 0.9}, 6, false},
+		{"full", ExtractedItem{Quantity: 1, FillLevel: "full", Confidence: 0.9}, 1, true},
+		{"half", ExtractedItem{Quantity: 1, FillLevel: "Half", Confidence: 0.9}, 0.5, true},
+		{"low", ExtractedItem{Quantity: 0.75, FillLevel: "low", Confidence: 0.9}, 0.188, true},
+		{"unknown package size", ExtractedItem{FillLevel: "half", Confidence: 0.9}, 0, true},
+		{"unrecognized level", ExtractedItem{Quantity: 1, FillLevel: "a third", Confidence: 0.9}, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, estimated := applyFillLevel(tt.item)
+			assert.Equal(t, tt.wantEstimated, estimated)
+			assert.InDelta(t, tt.wantQuantity, got.Quantity, 1e-9)
+			if estimated {
+				assert.Less(t, got.Confidence, confidenceReviewThreshold)
+			} else {
+				assert.Equal(t, tt.item, got)
+			}
+		})
+	}
+}
+
+func TestTotalStock(t *testing.T) {
+	t.Parallel()
+
+	milk, eggs := uuid.New(), uuid.New()
+	got := totalStock([]ItemInput{
+		{IngredientID: milk, Quantity: 1, Unit: "l"},
+		{IngredientID: eggs, Quantity: 6, Unit: "piece"},
+		{IngredientID: milk, Quantity: 200, Unit: "ml"},
+		{IngredientID: milk, QuantityUnknown: true, Unit: "l"},
+		{IngredientID: milk, Quantity: 0.5, Unit: "l"},
+	})
+	require.Len(t, got, 5)
+	assert.InDelta(t, 1.5, got[4].Quantity, 1e-9, "known amounts in the last entry's unit are summed")
+	assert.False(t, got[4].QuantityUnknown)
+	assert.Equal(t, 6.0, got[1].Quantity)
+}
+
+func TestProcessJob_FridgePhoto(t *testing.T) {
+	t.Parallel()
+
+	server := llmserver.New(t, llmserver.WithDefault(llmserver.HappyPath(ExtractionResponse{
+		Items: []ExtractedItem{
+			{RawText: "milk carton, about half full", Name: "milk", Quantity: 1, Unit: "l", Confidence: 0.9,
+				FillLevel: FillLevelHalf},
+			{RawText: "six eggs", Name: "egg", Quantity: 6, Unit: "piece", Confidence: 0.95},
+		},
+	})))
+	mockQ := mocks.NewMockQuerier(t)
+	mockDict := NewMockDictionaryResolver(t)
+	svc := NewIngestService(mockQ, mockDict, NewOpenAIExtractor("sk-test", "gpt-test", WithBaseURL(server.URL)))
+
+	for _, name := range []string{"milk", "egg"} {
+		mockDict.EXPECT().Resolve(mock.Anything, name).
+			Return(clients.ResolveResult{Ingredient: clients.Ingredient{ID: uuid.New(), Name: name}}, nil)
+	}
+	mockQ.EXPECT().CreateStagedItem(mock.Anything, mock.MatchedBy(func(p db.CreateStagedItemParams) bool {
+		return p.RawText == "milk carton, about half full" &&
+			p.Quantity == 0.5 && p.Confidence == fillEstimateConfidence && p.NeedsReview
+	})).Return(db.StagedItem{}, nil)
+	mockQ.EXPECT().CreateStagedItem(mock.Anything, mock.MatchedBy(func(p db.CreateStagedItemParams) bool {
+		return p.RawText == "six eggs" && p.Quantity == 6 && !p.NeedsReview
+	})).Return(db.StagedItem{}, nil)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	input, err := EncodeImageInput(JobTypeFridgePhoto, testPNG)
+	require.NoError(t, err)
+	require.NoError(t, svc.processJob(context.Background(), uuid.New(), input))
+
+	reqs := server.Requests()
+	require.Len(t, reqs, 1)
+	parts, ok := reqs[0].Messages[len(reqs[0].Messages)-1].Content.([]any)
+	require.True(t, ok)
+	prompt, _ := parts[0].(map[string]any)
+	assert.True(t, strings.HasPrefix(prompt["text"].(string), "This is a photo of the inside of a fridge"))
+}
+
+func TestConfirmJob_FridgePhotoReplacesTrackedStock(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	ingestSvc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	pantrySvc := NewPantryService(mockQ)
+	pantrySvc.SetLotTracking(true)
+
+	jobID, itemID, milk := uuid.New(), uuid.New(), uuid.New()
+	staged := func(quantity float64) db.StagedItem {
+		return db.StagedItem{
+			ID: uuid.New(), JobID: jobID, IngredientID: uuid.NullUUID{UUID: milk, Valid: true},
+			Quantity: quantity, Unit: "l",
+		}
+	}
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).
+		Return(db.IngestionJob{ID: jobID, Type: JobTypeFridgePhoto, Status: "staged"}, nil)
+	mockQ.EXPECT().ListStagedItemsByJob(mock.Anything, jobID).Return([]db.StagedItem{staged(1), staged(0.5)}, nil)
+	mockQ.EXPECT().ListPantryItemsByIngredients(mock.Anything, mock.Anything).Return([]db.PantryItem{
+		{ID: itemID, IngredientID: milk, Quantity: 4, Unit: "l"},
+	}, nil)
+	// Both cartons set the stock to 1.5 l instead of adding to the 4 l held.
+	mockQ.EXPECT().UpsertPantryItems(mock.Anything, db.UpsertPantryItemsParams{
+		IngredientIds:   []uuid.UUID{milk},
+		Quantities:      []float64{1.5},
+		Units:           []string{"l"},
+		ExpiresAt:       []time.Time{{}},
+		HasExpiry:       []bool{false},
+		QuantityUnknown: []bool{false},
+	}).Return([]db.PantryItem{{ID: itemID, IngredientID: milk, Quantity: 1.5, Unit: "l"}}, nil)
+	mockQ.EXPECT().DeletePantryLotsWithOtherUnit(mock.Anything, mock.Anything).Return(nil, nil)
+	mockQ.EXPECT().ListPantryLotsByItem(mock.Anything, itemID).Return([]db.PantryLot{
+		{ID: uuid.New(), PantryItemID: itemID, Quantity: 4, Unit: "l"},
+	}, nil)
+	mockQ.EXPECT().DecrementPantryLot(mock.Anything, mock.MatchedBy(func(p db.DecrementPantryLotParams) bool {
+		return p.Quantity == 2.5
+	})).Return(db.PantryLot{}, nil)
+	mockQ.EXPECT().InsertPantryLotEvent(mock.Anything, mock.MatchedBy(func(p db.InsertPantryLotEventParams) bool {
+		return p.Kind == LotEventConsumed && p.Quantity == 2.5
+	})).Return(nil)
+	mockQ.EXPECT().DeleteEmptyPantryLots(mock.Anything, itemID).Return(nil)
+	mockQ.EXPECT().SyncPantryItemExpiryFromLots(mock.Anything, itemID).
+		Return(db.PantryItem{ID: itemID, IngredientID: milk, Quantity: 1.5, Unit: "l"}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	results, err := ingestSvc.ConfirmJob(context.Background(), jobID, pantrySvc, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, itemID, results[1].PantryItemID.UUID)
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// heuristicConfidence is below confidenceReviewThreshold so every item the
+// heuristic parser stages is flagged for review.
+const heuristicConfidence = 0.5
+
+// heuristicRangeConfidence applies when a range such as "2-3" was collapsed
+// to its midpoint.
+const heuristicRangeConfidence = 0.4
+
+// HeuristicExtractor parses "quantity unit name" lines without calling an LLM.
+// It is the fallback when the LLM budget is exhausted and handles simple
+// lists such as "2 lbs chicken breast, 1 dozen eggs, milk".
+type HeuristicExtractor struct{}
+
+func NewHeuristicExtractor() *HeuristicExtractor {
+	return &HeuristicExtractor{}
+}
+
+// heuristicUnits maps unit spellings to the canonical units the LLM prompt uses.
+var heuristicUnits = map[string]string{
+	"g": "g", "gram": "g", "grams": "g",
+	"kg": "kg", "kgs": "kg", "kilogram": "kg", "kilograms": "kg",
+	"mg": "mg",
+	"lb": "lb", "lbs": "lb", "pound": "lb", "pounds": "lb",
+	"oz": "oz", "ounce": "oz", "ounces": "oz",
+	"ml": "ml", "milliliter": "ml", "milliliters": "ml", "millilitre": "ml", "millilitres": "ml",
+	"l": "l", "liter": "l", "liters": "l", "litre": "l", "litres": "l",
+	"cup": "cup", "cups": "cup",
+	"tbsp": "tbsp", "tablespoon": "tbsp", "tablespoons": "tbsp",
+	"tsp": "tsp", "teaspoon": "tsp", "teaspoons": "tsp",
+	"pint": "pint", "pints": "pint",
+	"quart": "quart", "quarts": "quart",
+	"gallon": "gallon", "gallons": "gallon",
+	"bunch": "bunch", "bunches": "bunch",
+	"head": "head", "heads": "head",
+	"clove": "clove", "cloves": "clove",
+	"piece": "piece", "pieces": "piece", "pc": "piece", "pcs": "piece",
+	"carton": "carton", "cartons": "carton",
+	"can": "can", "cans": "can",
+	"jar": "jar", "jars": "jar",
+	"bottle": "bottle", "bottles": "bottle",
+	"bag": "bag", "bags": "bag",
+	"box": "box", "boxes": "box",
+	"pack": "pack", "packs": "pack", "package": "pack", "packages": "pack",
+	"loaf": "loaf", "loaves": "loaf",
+	"dozen": "dozen",
+}
+
+func (e *HeuristicExtractor) Extract(_ context.Context, text string) (*ExtractionResponse, error) {
+	resp := &ExtractionResponse{Items: []ExtractedItem{}}
+	for _, line := range splitListEntries(text) {
+		raw := strings.TrimSpace(line)
+		if item, ok := ParseItemLine(raw); ok {
+			resp.Items = append(resp.Items, item)
+		}
+	}
+	return resp, nil
+}
+
+// splitListEntries splits a list on newlines, semicolons and commas, except
+// a comma between two digits, which is a decimal comma ("1,5 kg").
+func splitListEntries(text string) []string {
+	var entries []string
+	start := 0
+	for i, r := range text {
+		switch r {
+		case '\n', ';':
+		case ',':
+			if i > 0 && isDigit(text[i-1]) && i+1 < len(text) && isDigit(text[i+1]) {
+				continue
+			}
+		default:
+			continue
+		}
+		entries = append(entries, text[start:i])
+		start = i + 1
+	}
+	return append(entries, text[start:])
+}
+
+func isDigit(b byte) bool { return b >= '0' && b <= '9' }
+
+// ParseItemLine parses one free-text list entry. Quantity defaults to 1 when
+// absent; a missing unit is left empty for staging to fill from the
+// ingredient's category. A range ("2-3 onions") stages its midpoint with
+// lower confidence. ok is false for lines with no ingredient name.
+func ParseItemLine(raw string) (ExtractedItem, bool) {
+	fields := strings.Fields(strings.TrimLeft(raw, "-*•· \t"))
+	quantity, n, ranged := parseLeadingQuantity(fields)
+	fields = fields[n:]
+
+	unit := ""
+	if len(fields) > 0 {
+		if u, ok := heuristicUnits[strings.TrimSuffix(strings.ToLower(fields[0]), ".")]; ok && len(fields) > 1 {
+			unit = u
+			fields = fields[1:]
+			if len(fields) > 1 && strings.EqualFold(fields[0], "of") {
+				fields = fields[1:]
+			}
+		}
+	}
+
+	name := singularize(strings.ToLower(strings.Join(fields, " ")))
+	if name == "" {
+		return ExtractedItem{}, false
+	}
+	confidence := heuristicConfidence
+	if ranged {
+		confidence = heuristicRangeConfidence
+	}
+	return ExtractedItem{
+		RawText:    raw,
+		Name:       name,
+		Quantity:   quantity,
+		Unit:       unit,
+		Confidence: confidence,
+	}, true
+}
+
+// ParseQuantity parses a quantity written the way people write lists:
+// "2", "1.5", "1,5", "1 1/2", "1½", "½" or a range such as "2-3", which
+// yields its midpoint. ok is false for anything else, including zero and
+// trailing words.
+func ParseQuantity(s string) (float64, bool) {
+	fields := strings.Fields(s)
+	q, n, _ := parseLeadingQuantity(fields)
+	return q, n > 0 && n == len(fields)
+}
+
+// parseLeadingQuantity reads a quantity from the start of fields: any form
+// ParseQuantity accepts, "2x", or a number glued to a unit ("500g",
+// "1,5kg"). It returns the quantity (zero when there is none, e.g. "olive
+// oil"), how many fields it consumed, and whether it was a range. A glued
+// unit is split back into fields[0].
+func parseLeadingQuantity(fields []string) (float64, int, bool) {
+	if len(fields) == 0 {
+		return 0, 0, false
+	}
+	first := strings.TrimSuffix(strings.ToLower(fields[0]), "x")
+	if lo, hi, ok := parseRange(first); ok {
+		return (lo + hi) / 2, 1, true
+	}
+	if len(fields) > 2 && isRangeSeparator(fields[1]) {
+		lo, ok1 := parseNumber(first)
+		hi, ok2 := parseNumber(fields[2])
+		if ok1 && ok2 && lo < hi {
+			return (lo + hi) / 2, 3, true
+		}
+	}
+	q, ok := parseNumber(first)
+	if !ok {
+		split := strings.IndexFunc(first, func(r rune) bool {
+			return !unicode.IsDigit(r) && r != '.' && r != ','
+		})
+		if split <= 0 {
+			return 0, 0, false
+		}
+		if _, isUnit := heuristicUnits[first[split:]]; !isUnit {
+			return 0, 0, false
+		}
+		if q, ok = parseNumber(first[:split]); !ok {
+			return 0, 0, false
+		}
+		fields[0] = first[split:]
+		return q, 0, false
+	}
+	if len(fields) > 1 && !strings.ContainsAny(first, "/") {
+		if frac, ok := parseNumber(fields[1]); ok && frac < 1 && isFraction(fields[1]) {
+			return q + frac, 2, false
+		}
+	}
+	return q, 1, false
+}
+
+// unicodeFractions are the vulgar fraction characters found in typed and
+// pasted lists.
+var unicodeFractions = map[rune]float64{
+	'½': 1.0 / 2, '⅓': 1.0 / 3, '⅔': 2.0 / 3, '¼': 1.0 / 4, '¾': 3.0 / 4,
+	'⅕': 1.0 / 5, '⅖': 2.0 / 5, '⅗': 3.0 / 5, '⅘': 4.0 / 5, '⅙': 1.0 / 6,
+	'⅚': 5.0 / 6, '⅛': 1.0 / 8, '⅜': 3.0 / 8, '⅝': 5.0 / 8, '⅞': 7.0 / 8,
+}
+
+// parseNumber parses one positive number: "2", "1.5", "1,5", "1.234,5",
+// "1/2", "½" or "1½".
+func parseNumber(s string) (float64, bool) {
+	if last, size := utf8.DecodeLastRuneInString(s); size > 0 {
+		if frac, ok := unicodeFractions[last]; ok {
+			whole := 0.0
+			if head := s[:len(s)-size]; head != "" {
+				w, err := strconv.ParseFloat(head, 64)
+				if err != nil || w < 0 {
+					return 0, false
+				}
+				whole = w
+			}
+			return whole + frac, true
+		}
+	}
+	if num, den, ok := strings.Cut(s, "/"); ok {
+		n, err1 := strconv.ParseFloat(num, 64)
+		d, err2 := strconv.ParseFloat(den, 64)
+		if err1 != nil || err2 != nil || d == 0 {
+			return 0, false
+		}
+		return n / d, n > 0
+	}
+	v, err := strconv.ParseFloat(normalizeDecimal(s), 64)
+	return v, err == nil && v > 0
+}
+
+// normalizeDecimal rewrites a number with a decimal comma, or with both
+// grouping and decimal separators, into Go syntax. Whichever of "." and ","
+// comes last is the decimal mark. A lone comma followed by exactly three
+// digits is taken as a thousands separator ("1,500"), since that is how a
+// comma decimal is rarely written.
+func normalizeDecimal(s string) string {
+	dot, comma := strings.LastIndex(s, "."), strings.LastIndex(s, ",")
+	switch {
+	case comma < 0:
+		return s
+	case dot > comma:
+		return strings.ReplaceAll(s, ",", "")
+	case dot >= 0:
+		return strings.Replace(strings.ReplaceAll(s, ".", ""), ",", ".", 1)
+	case strings.Count(s, ",") == 1 && len(s)-comma-1 != 3:
+		return strings.Replace(s, ",", ".", 1)
+	default:
+		return strings.ReplaceAll(s, ",", "")
+	}
+}
+
+// parseRange reads "2-3" or "2–3" as a single field. The low end must be
+// below the high end.
+func parseRange(s string) (lo, hi float64, ok bool) {
+	for _, sep := range []string{"-", "–"} {
+		a, b, found := strings.Cut(s, sep)
+		if !found {
+			continue
+		}
+		lo, ok1 := parseNumber(a)
+		hi, ok2 := parseNumber(b)
+		if ok1 && ok2 && lo < hi {
+			return lo, hi, true
+		}
+		return 0, 0, false
+	}
+	return 0, 0, false
+}
+
+func isRangeSeparator(s string) bool {
+	return s == "-" || s == "–" || strings.EqualFold(s, "to")
+}
+
+func isFraction(s string) bool {
+	if strings.Contains(s, "/") {
+		return true
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	_, ok := unicodeFractions[r]
+	return ok && size == len(s)
+}
+
+// singularize strips common English plural endings from the last word; the
+// dictionary resolver handles anything it misses.
+func singularize(name string) string {
+	head, last := "", name
+	if i := strings.LastIndex(name, " "); i >= 0 {
+		head, last = name[:i+1], name[i+1:]
+	}
+	switch {
+	case len(last) > 4 && strings.HasSuffix(last, "ies"):
+		last = last[:len(last)-3] + "y"
+	case len(last) > 4 && strings.HasSuffix(last, "oes"):
+		last = last[:len(last)-2]
+	case len(last) > 3 && strings.HasSuffix(last, "s") &&
+		!strings.HasSuffix(last, "ss") && !strings.HasSuffix(last, "us"):
+		last = last[:len(last)-1]
+	}
+	return head + last
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseItemLine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw      string
+		name     string
+		quantity float64
+		unit     string
+	}{
+		{"2 lbs chicken breasts", "chicken breast", 2, "lb"},
+		{"1 1/2 cups of flour", "flour", 1.5, "cup"},
+		{"500g pasta", "pasta", 500, "g"},
+		{"- 3x tomatoes", "tomato", 3, ""},
+		{"1 dozen eggs", "egg", 1, "dozen"},
+		{"Milk", "milk", 0, ""},
+		{"2 cans", "can", 2, ""},
+		{"hummus", "hummus", 0, ""},
+		{"strawberries", "strawberry", 0, ""},
+		{"1,5 kg potatoes", "potato", 1.5, "kg"},
+		{"1,5kg potatoes", "potato", 1.5, "kg"},
+		{"½ cup sugar", "sugar", 0.5, "cup"},
+		{"1½ cups milk", "milk", 1.5, "cup"},
+		{"1 ¾ l stock", "stock", 1.75, "l"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			t.Parallel()
+
+			item, ok := ParseItemLine(tt.raw)
+			require.True(t, ok)
+			assert.Equal(t, tt.name, item.Name)
+			assert.InDelta(t, tt.quantity, item.Quantity, 1e-9)
+			assert.Equal(t, tt.unit, item.Unit)
+			assert.Less(t, item.Confidence, confidenceReviewThreshold)
+		})
+	}
+}
+
+func TestParseItemLine_Range(t *testing.T) {
+	t.Parallel()
+
+	for _, raw := range []string{"2-3 onions", "2–3 onions", "2 - 3 onions", "2 to 3 onions"} {
+		item, ok := ParseItemLine(raw)
+		require.True(t, ok, raw)
+		assert.Equal(t, "onion", item.Name, raw)
+		assert.InDelta(t, 2.5, item.Quantity, 1e-9, raw)
+		assert.Less(t, item.Confidence, heuristicConfidence, "a range lowers confidence: %s", raw)
+	}
+}
+
+func TestParseQuantity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"2", 2, true},
+		{"1.5", 1.5, true},
+		{"1,5", 1.5, true},
+		{"1,25", 1.25, true},
+		{"1,500", 1500, true},
+		{"1.234,5", 1234.5, true},
+		{"1,234.5", 1234.5, true},
+		{"½", 0.5, true},
+		{"2⅓", 2 + 1.0/3, true},
+		{"1 1/2", 1.5, true},
+		{"2-3", 2.5, true},
+		{" 3 ", 3, true},
+		{"3-2", 0, false},
+		{"0", 0, false},
+		{"", 0, false},
+		{"2 kg", 0, false},
+		{"abc", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseQuantity(tt.in)
+		assert.Equal(t, tt.ok, ok, tt.in)
+		if tt.ok {
+			assert.InDelta(t, tt.want, got, 1e-9, tt.in)
+		}
+	}
+}
+
+func TestHeuristicExtractor_KeepsDecimalComma(t *testing.T) {
+	t.Parallel()
+
+	resp, err := NewHeuristicExtractor().Extract(context.Background(), "1,5 kg flour, 2 eggs,milk")
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 3)
+	assert.Equal(t, "1,5 kg flour", resp.Items[0].RawText)
+	assert.InDelta(t, 1.5, resp.Items[0].Quantity, 1e-9)
+	assert.Equal(t, "egg", resp.Items[1].Name)
+	assert.Equal(t, "milk", resp.Items[2].Name)
+}
+
+func TestOverrideItem_UnmarshalQuantity(t *testing.T) {
+	t.Parallel()
+
+	ptr := func(v float64) *float64 { return &v }
+	tests := []struct {
+		body string
+		want *float64
+		err  bool
+	}{
+		{body: `{"quantity": 2.5}`, want: ptr(2.5)},
+		{body: `{"quantity": "1,5"}`, want: ptr(1.5)},
+		{body: `{"quantity": "½"}`, want: ptr(0.5)},
+		{body: `{"quantity": null}`},
+		{body: `{}`},
+		{body: `{"quantity": "lots"}`, err: true},
+		{body: `{"quantity": true}`, err: true},
+	}
+	for _, tt := range tests {
+		var o OverrideItem
+		err := json.Unmarshal([]byte(tt.body), &o)
+		if tt.err {
+			assert.Error(t, err, tt.body)
+			continue
+		}
+		require.NoError(t, err, tt.body)
+		if tt.want == nil {
+			assert.Nil(t, o.Quantity, tt.body)
+		} else {
+			require.NotNil(t, o.Quantity, tt.body)
+			assert.InDelta(t, *tt.want, *o.Quantity, 1e-9, tt.body)
+		}
+	}
+
+	var o OverrideItem
+	body := `{"staged_item_id": "00000000-0000-0000-0000-000000000001", "unit": "kg"}`
+	require.NoError(t, json.Unmarshal([]byte(body), &o))
+	assert.Equal(t, "kg", *o.Unit, "other fields still decode")
+}
+
+func TestHeuristicExtractor_SplitsList(t *testing.T) {
+	t.Parallel()
+
+	resp, err := NewHeuristicExtractor().Extract(context.Background(), "2 lb apples, 1 l milk\n\n; 3")
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 2)
+	assert.Equal(t, "apple", resp.Items[0].Name)
+	assert.Equal(t, "1 l milk", resp.Items[1].RawText)
+	assert.Zero(t, resp.TokensUsed)
+}
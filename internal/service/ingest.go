@@ -10,11 +10,31 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+	"github.com/mwhite7112/woodpantry-pantry/internal/tracing"
+	"github.com/mwhite7112/woodpantry-pantry/internal/units"
+)
+
+var (
+	// ErrJobNotStaged is returned when an operation needs a job awaiting review.
+	ErrJobNotStaged = errors.New("job is not staged")
+	// ErrNothingExtracted is returned when re-extraction finds no item.
+	ErrNothingExtracted = errors.New("extraction returned no items")
+	// ErrInvalidOverride is returned when a confirm override holds a value
+	// the pantry cannot store.
+	ErrInvalidOverride = errors.New("invalid override")
 )
 
 // IngestService handles the staged ingest flow: LLM extraction → staging → confirm.
@@ -22,6 +42,26 @@ type IngestService struct {
 	q          db.Querier
 	dictionary DictionaryResolver
 	extractor  LLMExtractor
+	log        *slog.Logger
+
+	confirmHook ConfirmHook
+	statusHook  JobStatusHook
+	summaries   ConfirmSummaryPublisher
+	categories  *categoryCache
+	budget      *LLMBudget
+	fallback    LLMExtractor
+	maxStaged   int
+	pool        *workerPool
+	shadow      *shadowExtraction
+	health      *ProviderHealth
+	unitRules   *UnitDefaults
+	reviewRules *ReviewRules
+	jobQueue    IngestJobQueue
+	redactor    *Redactor
+	jobs        jobTracker
+
+	deferredMu sync.Mutex
+	deferred   []ingestTask
 }
 
 func NewIngestService(q db.Querier, dictionary DictionaryResolver, extractor LLMExtractor) *IngestService {
@@ -29,97 +69,646 @@ func NewIngestService(q db.Querier, dictionary DictionaryResolver, extractor LLM
 		q:          q,
 		dictionary: dictionary,
 		extractor:  extractor,
+		log:        logging.Sampled(logging.For("ingest")),
+		maxStaged:  DefaultMaxStagedItems,
 	}
 }
 
-// OpenAIExtractor implements LLMExtractor using the OpenAI API.
+// DefaultMaxStagedItems caps staged rows per job so a runaway extraction or a
+// pasted spreadsheet cannot flood the review screen.
+const DefaultMaxStagedItems = 200
+
+// SetMaxStagedItems sets the per-job staged item cap. Values below 1 are
+// ignored.
+func (s *IngestService) SetMaxStagedItems(n int) {
+	if n > 0 {
+		s.maxStaged = n
+	}
+}
+
+// SetConfirmHook registers a hook invoked after every successful confirm.
+func (s *IngestService) SetConfirmHook(h ConfirmHook) {
+	s.confirmHook = h
+}
+
+// SetJobStatusHook registers a hook invoked after job status changes.
+func (s *IngestService) SetJobStatusHook(h JobStatusHook) {
+	s.statusHook = h
+}
+
+// jobStatusChanged tells the status hook, if any, that jobID is now status.
+func (s *IngestService) jobStatusChanged(ctx context.Context, jobID uuid.UUID, status string) {
+	if s.statusHook != nil {
+		s.statusHook.OnJobStatus(ctx, jobID, status)
+	}
+}
+
+// SetBudget caps LLM extraction at a monthly token budget. Once it is
+// exhausted, jobs are parsed by fallback and flagged budget_exceeded.
+func (s *IngestService) SetBudget(b *LLMBudget, fallback LLMExtractor) {
+	s.budget = b
+	s.fallback = fallback
+}
+
+// SetUnitDefaults makes staging fill a missing unit from the item's
+// Dictionary category. Without it a missing unit becomes "piece".
+func (s *IngestService) SetUnitDefaults(u *UnitDefaults) {
+	s.unitRules = u
+}
+
+// SetReviewRules applies the household's review rules when items are
+// staged. Without it only low confidence, failed resolution and replaced
+// units flag an item.
+func (s *IngestService) SetReviewRules(r *ReviewRules) {
+	s.reviewRules = r
+}
+
+// SetRedactor scrubs personal information from text input before a job is
+// stored. Receipt images are stored as they are.
+func (s *IngestService) SetRedactor(r *Redactor) {
+	s.redactor = r
+}
+
+// llmClient is the configuration every LLM provider's extractor shares.
+type llmClient struct {
+	apiKey      string
+	model       string
+	visionModel string
+	prompt      string
+	baseURL     string
+	httpClient  *http.Client
+
+	retries       int
+	retryBackoff  time.Duration
+	fallbackModel string
+	log           *slog.Logger
+}
+
+// OpenAIExtractor implements LLMExtractor using the OpenAI API, or any
+// server that speaks its chat completions API.
 type OpenAIExtractor struct {
-	apiKey     string
-	model      string
-	httpClient *http.Client
+	llmClient
+	// provider names the server in errors and traces.
+	provider string
+	// apiVersion is set for Azure OpenAI, which routes by deployment and
+	// authenticates with an api-key header.
+	apiVersion string
+}
+
+// DefaultOpenAIBaseURL is the public OpenAI API root.
+const DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// DefaultAzureAPIVersion is the Azure OpenAI API version used unless
+// another is configured.
+const DefaultAzureAPIVersion = "2024-10-21"
+
+// DefaultLocalBaseURL is the OpenAI-compatible API root of an Ollama server
+// on the same host.
+const DefaultLocalBaseURL = "http://localhost:11434/v1"
+
+// ExtractorOption configures an OpenAIExtractor or AnthropicExtractor.
+type ExtractorOption func(*llmClient)
+
+// WithBaseURL points the extractor at another API root for its provider,
+// e.g. a mock server in tests.
+func WithBaseURL(baseURL string) ExtractorOption {
+	return func(e *llmClient) { e.baseURL = strings.TrimRight(baseURL, "/") }
+}
+
+// WithHTTPClient sends LLM calls through c, e.g. the shared client from
+// an httpx.Factory.
+func WithHTTPClient(c *http.Client) ExtractorOption {
+	return func(e *llmClient) { e.httpClient = c }
+}
+
+// WithTransport sets the HTTP transport used for LLM calls, e.g. to wrap
+// it with fault injection. The client is copied, so one passed to
+// WithHTTPClient is left as it was.
+func WithTransport(rt http.RoundTripper) ExtractorOption {
+	return func(e *llmClient) {
+		c := *e.httpClient
+		c.Transport = rt
+		e.httpClient = &c
+	}
+}
+
+// WithVisionModel sets the model used for receipt images when the
+// extraction model cannot read images.
+func WithVisionModel(model string) ExtractorOption {
+	return func(e *llmClient) { e.visionModel = model }
+}
+
+// WithSystemPrompt replaces the built-in extraction prompt, e.g. to trial a
+// candidate prompt in shadow mode.
+func WithSystemPrompt(prompt string) ExtractorOption {
+	return func(e *llmClient) { e.prompt = prompt }
 }
 
 const (
-	openAIClientTimeout       = 60 * time.Second
+	llmClientTimeout          = 60 * time.Second
 	processJobTimeout         = 90 * time.Second
 	confidenceReviewThreshold = 0.7
 )
 
-func NewOpenAIExtractor(apiKey, model string) *OpenAIExtractor {
+func NewOpenAIExtractor(apiKey, model string, opts ...ExtractorOption) *OpenAIExtractor {
+	return &OpenAIExtractor{llmClient: newLLMClient(apiKey, model, DefaultOpenAIBaseURL, opts), provider: "openai"}
+}
+
+// NewLocalExtractor returns an extractor for a self-hosted OpenAI-compatible
+// server, such as Ollama or the llama.cpp server, at DefaultLocalBaseURL
+// unless WithBaseURL says otherwise. apiKey may be empty, as local servers
+// usually do not check one.
+func NewLocalExtractor(apiKey, model string, opts ...ExtractorOption) *OpenAIExtractor {
+	return &OpenAIExtractor{llmClient: newLLMClient(apiKey, model, DefaultLocalBaseURL, opts), provider: "local"}
+}
+
+// NewAzureOpenAIExtractor returns an extractor for the Azure OpenAI resource
+// at endpoint, e.g. https://my-resource.openai.azure.com, calling API
+// version apiVersion. Azure routes by deployment, so deployment, and any
+// vision model set with WithVisionModel, name deployments rather than
+// models.
+func NewAzureOpenAIExtractor(
+	endpoint, apiVersion, apiKey, deployment string, opts ...ExtractorOption,
+) *OpenAIExtractor {
+	baseURL := strings.TrimRight(endpoint, "/") + "/openai"
 	return &OpenAIExtractor{
+		llmClient:  newLLMClient(apiKey, deployment, baseURL, opts),
+		provider:   "azure",
+		apiVersion: apiVersion,
+	}
+}
+
+// setAuth sends the API key, if there is one: as a bearer token, or in the
+// api-key header on Azure.
+func (e *OpenAIExtractor) setAuth(req *http.Request) {
+	switch {
+	case e.apiKey == "":
+	case e.apiVersion != "":
+		req.Header.Set("api-key", e.apiKey)
+	default:
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+}
+
+// endpointURL returns the URL of an API path. On Azure a non-empty
+// deployment scopes the path to that deployment, and the API version is
+// added to the query.
+func (e *OpenAIExtractor) endpointURL(path, deployment string) string {
+	if e.apiVersion == "" {
+		return e.baseURL + path
+	}
+	if deployment != "" {
+		path = "/deployments/" + url.PathEscape(deployment) + path
+	}
+	return e.baseURL + path + "?api-version=" + url.QueryEscape(e.apiVersion)
+}
+
+// newLLMClient applies opts over the defaults for a provider whose API lives
+// at baseURL.
+func newLLMClient(apiKey, model, baseURL string, opts []ExtractorOption) llmClient {
+	c := llmClient{
 		apiKey:     apiKey,
 		model:      model,
-		httpClient: &http.Client{Timeout: openAIClientTimeout},
+		prompt:     systemPrompt,
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: llmClientTimeout},
+		log:        logging.For("llm"),
+	}
+	for _, opt := range opts {
+		opt(&c)
 	}
+	return c
 }
 
-// CreateJob persists a new IngestionJob with status "pending".
-func (s *IngestService) CreateJob(ctx context.Context, jobType, rawInput string) (db.IngestionJob, error) {
+// CreateJob records a pending job. source is the channel it came from and
+// must be one of IngestSources; priority must be one of IngestPriorities.
+// With a redactor set, text input is redacted first; process the returned
+// job's RawInput, never the original.
+func (s *IngestService) CreateJob(
+	ctx context.Context, jobType, source, priority, rawInput string,
+) (db.IngestionJob, error) {
+	if s.redactor != nil && !IsImageJob(jobType) {
+		var counts map[string]int
+		rawInput, counts = s.redactor.Redact(ctx, rawInput)
+		if len(counts) > 0 {
+			s.log.InfoContext(ctx, "redacted ingest input", "spans", counts)
+		}
+	}
 	return s.q.CreateIngestionJob(ctx, db.CreateIngestionJobParams{
 		Type:     jobType,
 		RawInput: rawInput,
+		Source:   source,
+		Priority: priority,
 	})
 }
 
 // ProcessJobAsync kicks off LLM extraction and ingredient resolution in the
 // background. The job status is updated to "staged" on success or "failed" on
-// error. Phase 2+ will replace this with a RabbitMQ consumer.
-func (s *IngestService) ProcessJobAsync(jobID uuid.UUID, rawInput string) {
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), processJobTimeout)
-		defer cancel()
-
-		if err := s.processJob(ctx, jobID, rawInput); err != nil {
-			slog.Error("ingest job failed", "job_id", jobID, "error", err)
-			_, _ = s.q.UpdateIngestionJobStatus(ctx, db.UpdateIngestionJobStatusParams{
-				ID:     jobID,
-				Status: "failed",
-			})
+// error. With a job queue set, the job is published there and processed by
+// whichever instance consumes it (see ProcessQueuedJob). Otherwise, with a
+// worker pool started, the job is queued in memory and ErrIngestQueueFull is
+// returned when the queue has no room; without either it runs on its own
+// goroutine. The pool runs interactive jobs ahead of background ones. While
+// the LLM provider is unhealthy the job is held and ErrIngestDeferred is
+// returned; it still counts as accepted. ctx only supplies the trace the
+// job is recorded under; the job outlives it.
+func (s *IngestService) ProcessJobAsync(ctx context.Context, jobID uuid.UUID, rawInput, priority string) error {
+	if s.jobQueue != nil {
+		return s.enqueueJob(ctx, jobID)
+	}
+	task := ingestTask{jobID: jobID, rawInput: rawInput, priority: priority, trace: trace.SpanContextFromContext(ctx)}
+	if s.health != nil && !s.health.Healthy() {
+		return s.deferJob(task)
+	}
+	if s.pool == nil {
+		go s.runJob(task) //nolint:errcheck // logged and recorded on the job
+		return nil
+	}
+	select {
+	case s.pool.queueFor(priority) <- task:
+		return nil
+	default:
+		return ErrIngestQueueFull
+	}
+}
+
+// ProcessJobSync extracts and stages a job on the calling goroutine and
+// returns once it is staged or marked failed. It bypasses the job queue, the
+// worker pool and provider-health deferral, so callers see the outcome
+// deterministically. Cancelling ctx does not abort the job, so a dropped
+// request never leaves it pending or processing; CancelJob does, and ErrJobCancelled is
+// returned.
+func (s *IngestService) ProcessJobSync(ctx context.Context, jobID uuid.UUID, rawInput string) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), processJobTimeout)
+	defer cancel()
+	ctx, untrack := s.jobs.track(ctx, jobID)
+	defer untrack()
+
+	err := s.startJob(ctx, jobID)
+	if err == nil {
+		err = s.processJob(ctx, jobID, rawInput)
+	}
+	if err != nil {
+		if s.jobCancelled(ctx, jobID, err) {
+			return ErrJobCancelled
 		}
-	}()
+		s.log.ErrorContext(ctx, "ingest job failed", "job_id", jobID, "error", err)
+		s.MarkJobFailed(ctx, jobID, err)
+		return err
+	}
+	return nil
+}
+
+// runJob processes one job with a timeout. A failed job is marked failed
+// unless it came from the job queue, which retries it; ProcessQueuedJob
+// marks it on the last attempt. A cancelled job is not a failure.
+func (s *IngestService) runJob(task ingestTask) error {
+	ctx := trace.ContextWithSpanContext(context.Background(), task.trace)
+	ctx, cancel := context.WithTimeout(ctx, processJobTimeout)
+	defer cancel()
+	ctx, untrack := s.jobs.track(ctx, task.jobID)
+	defer untrack()
+
+	var err error
+	if task.done == nil {
+		err = s.startJob(ctx, task.jobID)
+	}
+	if err == nil {
+		err = s.processJob(ctx, task.jobID, task.rawInput)
+	}
+	if s.jobCancelled(ctx, task.jobID, err) {
+		err = nil
+	}
+	if err != nil {
+		s.log.Error("ingest job failed", "job_id", task.jobID, "error", err)
+		if task.done == nil {
+			s.MarkJobFailed(ctx, task.jobID, err)
+		}
+	}
+	if task.done != nil {
+		task.done <- err
+	}
+	return err
+}
+
+// maxFailureReasonLen caps the error text stored on a failed job; provider
+// errors can quote whole response bodies.
+const maxFailureReasonLen = 500
+
+// MarkJobFailed sets a job's status to "failed" and stores cause as its
+// failure_reason, logging rather than returning any error since callers are
+// already on a failure path. A cancelled job stays cancelled.
+func (s *IngestService) MarkJobFailed(ctx context.Context, jobID uuid.UUID, cause error) {
+	n, err := s.q.FailIngestionJob(ctx, db.FailIngestionJobParams{
+		ID:            jobID,
+		FailureReason: FailureReason(cause),
+	})
+	if err != nil {
+		s.log.ErrorContext(ctx, "failed to mark job failed", "job_id", jobID, "error", err)
+		return
+	}
+	if n == 0 {
+		return
+	}
+	s.jobStatusChanged(ctx, jobID, "failed")
+}
+
+// FailureReason is err's message, cut to maxFailureReasonLen on a rune
+// boundary.
+func FailureReason(err error) string {
+	if err == nil {
+		return ""
+	}
+	reason := err.Error()
+	if len(reason) <= maxFailureReasonLen {
+		return reason
+	}
+	cut := maxFailureReasonLen
+	for cut > 0 && !utf8.RuneStart(reason[cut]) {
+		cut--
+	}
+	return reason[:cut] + "…"
+}
+
+// startJob moves a job this process runs from memory from pending to
+// processing; jobs from a job queue were claimed into processing already.
+// It returns ErrJobCancelled if the job left pending before it started, e.g.
+// it was cancelled or forced to failed while queued.
+func (s *IngestService) startJob(ctx context.Context, jobID uuid.UUID) error {
+	if errors.Is(context.Cause(ctx), ErrJobCancelled) {
+		return ErrJobCancelled
+	}
+	_, err := s.q.TransitionIngestionJobStatus(ctx, db.TransitionIngestionJobStatusParams{
+		ToStatus:   "processing",
+		ID:         jobID,
+		FromStatus: "pending",
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrJobCancelled
+	}
+	if err != nil {
+		return fmt.Errorf("start job: %w", err)
+	}
+	return nil
 }
 
-func (s *IngestService) processJob(ctx context.Context, jobID uuid.UUID, rawInput string) error {
-	log := slog.Default()
+// processJob extracts and stages a processing job's items. Its timings are
+// recorded before the final status change, so a poller that sees the new
+// status also sees them. It returns ErrJobCancelled if the job was
+// cancelled meanwhile, or moved out of processing by anything else, such as
+// a forced transition.
+func (s *IngestService) processJob(ctx context.Context, jobID uuid.UUID, rawInput string) (err error) {
+	ctx, span := tracing.Start(ctx, "ingest.process_job", trace.SpanKindInternal,
+		attribute.String("ingest.job_id", jobID.String()), attribute.Int("ingest.input_length", len(rawInput)))
+	defer func() { tracing.End(span, err) }()
+
+	if errors.Is(context.Cause(ctx), ErrJobCancelled) {
+		return ErrJobCancelled
+	}
+	timings := jobTimings{start: time.Now()}
+	err = s.stageJob(ctx, jobID, rawInput, &timings)
+	s.recordTimings(ctx, jobID, timings)
+	if errors.Is(context.Cause(ctx), ErrJobCancelled) {
+		return ErrJobCancelled
+	}
+	if err != nil {
+		return err
+	}
+	_, err = s.q.TransitionIngestionJobStatus(ctx, db.TransitionIngestionJobStatusParams{
+		ToStatus:   "staged",
+		ID:         jobID,
+		FromStatus: "processing",
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrJobCancelled
+	}
+	if err != nil {
+		return err
+	}
+	s.jobStatusChanged(ctx, jobID, "staged")
+	return nil
+}
+
+func (s *IngestService) stageJob(ctx context.Context, jobID uuid.UUID, rawInput string, timings *jobTimings) error {
+	log := s.log
 	log.InfoContext(ctx, "LLM extraction starting", "job_id", jobID, "input_len", len(rawInput))
 
-	extracted, err := s.extractor.Extract(ctx, rawInput)
+	extractStart := time.Now()
+	extracted, err := s.extract(ctx, jobID, rawInput)
+	timings.extraction = time.Since(extractStart)
 	if err != nil {
 		return fmt.Errorf("llm extraction: %w", err)
 	}
 
 	log.InfoContext(ctx, "LLM extraction complete", "job_id", jobID, "items", len(extracted.Items))
+	if s.shadow != nil {
+		go s.runShadow(jobID, rawInput, extracted.Items)
+	}
 
-	for _, item := range extracted.Items {
-		var ingredientID uuid.NullUUID
-		needsReview := item.Confidence < confidenceReviewThreshold
+	if dropped := len(extracted.Items) - s.maxStaged; dropped > 0 {
+		log.WarnContext(ctx, "extraction exceeds staged item cap; truncating",
+			"job_id", jobID, "items", len(extracted.Items), "max", s.maxStaged)
+		extracted.Items = extracted.Items[:s.maxStaged]
+		if err := s.q.MarkIngestionJobTruncated(ctx, db.MarkIngestionJobTruncatedParams{
+			ID:             jobID,
+			TruncatedItems: int32(dropped), //nolint:gosec // bounded by the extracted item count
+		}); err != nil {
+			return fmt.Errorf("record truncation: %w", err)
+		}
+	}
 
-		result, resolveErr := s.dictionary.Resolve(ctx, item.Name)
-		if resolveErr != nil {
-			log.WarnContext(ctx, "dictionary resolve failed", "job_id", jobID, "name", item.Name, "error", resolveErr)
-			needsReview = true
-		} else {
-			ingredientID = uuid.NullUUID{UUID: result.Ingredient.ID, Valid: true}
+	resolver := newMemoResolver(s.dictionary)
+	unitRules := s.unitRules.rules(ctx)
+	reviewRules := s.reviewRules.rules(ctx)
+	for _, item := range extracted.Items {
+		item, estimated := applyFillLevel(item)
+		resolveStart := time.Now()
+		res := s.resolveExtracted(ctx, resolver, jobID, item)
+		timings.addResolution(time.Since(resolveStart))
+		unit, known := stagedUnit(item.Unit, defaultUnit(unitRules, res.category))
+		if !known {
+			log.InfoContext(ctx, "unknown unit replaced; flagging for review",
+				"job_id", jobID, "unit", item.Unit, "suggested", unit)
 		}
+		// A quantity read off a fill level is always reviewed, whatever the rules say.
+		needsReview := estimated || res.needsReview(reviewRules, item.Quantity, unit, known)
 
 		if _, err := s.q.CreateStagedItem(ctx, db.CreateStagedItemParams{
-			JobID:        jobID,
-			IngredientID: ingredientID,
-			RawText:      item.RawText,
-			Quantity:     item.Quantity,
-			Unit:         item.Unit,
-			Confidence:   item.Confidence,
-			NeedsReview:  needsReview,
+			JobID:           jobID,
+			IngredientID:    res.id,
+			RawText:         item.RawText,
+			Quantity:        max(item.Quantity, 0),
+			Unit:            unit,
+			Confidence:      item.Confidence,
+			NeedsReview:     needsReview,
+			QuantityUnknown: item.Quantity <= 0,
 		}); err != nil {
 			return fmt.Errorf("create staged item for %q: %w", item.RawText, err)
 		}
+		log.DebugContext(ctx, "staged item",
+			"job_id", jobID,
+			"name", item.Name,
+			"ingredient_id", res.id.UUID,
+			"confidence", item.Confidence,
+			"needs_review", needsReview,
+		)
 	}
+	return nil
+}
+
+// resolution is an extracted item matched against the Dictionary. id is
+// invalid when resolution failed.
+type resolution struct {
+	id            uuid.NullUUID
+	name          string
+	category      string
+	lowConfidence bool
+}
+
+// needsReview decides whether a staged item is flagged. Unresolved items and
+// replaced units always are; otherwise a matching review rule flags the item,
+// a matching accept rule clears it, and without either the LLM's confidence
+// decides.
+func (r resolution) needsReview(rules []ReviewRule, quantity float64, unit string, unitKnown bool) bool {
+	if !r.id.Valid || !unitKnown {
+		return true
+	}
+	switch reviewAction(rules, r.name, r.category, quantity, quantity <= 0, unit) {
+	case ReviewActionReview:
+		return true
+	case ReviewActionAccept:
+		return false
+	}
+	return r.lowConfidence
+}
 
-	_, err = s.q.UpdateIngestionJobStatus(ctx, db.UpdateIngestionJobStatusParams{
-		ID:     jobID,
-		Status: "staged",
+// resolveExtracted maps an extracted item to a Dictionary ingredient.
+func (s *IngestService) resolveExtracted(
+	ctx context.Context,
+	resolver DictionaryResolver,
+	jobID uuid.UUID,
+	item ExtractedItem,
+) resolution {
+	res := resolution{lowConfidence: item.Confidence < confidenceReviewThreshold}
+	result, err := resolver.Resolve(ctx, item.Name)
+	if err != nil {
+		s.log.WarnContext(ctx, "dictionary resolve failed", "job_id", jobID, "name", item.Name, "error", err)
+		return res
+	}
+	res.id = uuid.NullUUID{UUID: result.Ingredient.ID, Valid: true}
+	res.name = result.Ingredient.Name
+	res.category = result.Ingredient.Category
+	return res
+}
+
+// stagedUnit checks an extracted unit against the canonical unit table before
+// it is staged. Known units and aliases come back in canonical spelling with
+// known=true; a missing unit becomes fallback, the category default.
+// An invented unit ("handful", "splash") is replaced by the nearest canonical
+// unit with known=false so the caller flags the item for review and the
+// invented unit never reaches a pantry row on confirm. raw_text keeps the
+// original wording for the reviewer.
+func stagedUnit(unit, fallback string) (canonical string, known bool) {
+	if strings.TrimSpace(unit) == "" {
+		return fallback, true
+	}
+	if c, ok := units.Canonical(unit); ok {
+		return c, true
+	}
+	return units.Suggest(unit), false
+}
+
+// ReextractItem sends one staged item's raw text back through extraction,
+// optionally with a free-text hint such as "this is a spice", and overwrites
+// the staged row with the result. The job must still be staged.
+func (s *IngestService) ReextractItem(
+	ctx context.Context,
+	jobID, itemID uuid.UUID,
+	hint string,
+) (db.StagedItem, error) {
+	staged, err := s.stagedItem(ctx, jobID, itemID)
+	if err != nil {
+		return db.StagedItem{}, err
+	}
+
+	input := staged.RawText
+	if hint = strings.TrimSpace(hint); hint != "" {
+		input += "\n\nHint from the user about this item: " + hint
+	}
+
+	extracted, err := s.extract(ctx, jobID, input)
+	if err != nil {
+		return db.StagedItem{}, fmt.Errorf("llm extraction: %w", err)
+	}
+	if len(extracted.Items) == 0 {
+		return db.StagedItem{}, ErrNothingExtracted
+	}
+
+	// The input is a single line; if the model splits it anyway, keep the
+	// item it is most confident about.
+	best := extracted.Items[0]
+	for _, it := range extracted.Items[1:] {
+		if it.Confidence > best.Confidence {
+			best = it
+		}
+	}
+	res := s.resolveExtracted(ctx, s.dictionary, jobID, best)
+	unit, known := stagedUnit(best.Unit, defaultUnit(s.unitRules.rules(ctx), res.category))
+	return s.q.UpdateStagedItemExtraction(ctx, db.UpdateStagedItemExtractionParams{
+		ID:              itemID,
+		IngredientID:    res.id,
+		Quantity:        max(best.Quantity, 0),
+		Unit:            unit,
+		Confidence:      best.Confidence,
+		NeedsReview:     res.needsReview(s.reviewRules.rules(ctx), best.Quantity, unit, known),
+		QuantityUnknown: best.Quantity <= 0,
 	})
-	return err
+}
+
+// extract runs input through the LLM, or the fallback parser once the budget
+// is exhausted, and records the tokens spent and the provider's health.
+func (s *IngestService) extract(ctx context.Context, jobID uuid.UUID, input string) (*ExtractionResponse, error) {
+	extractor, overBudget := s.extractorFor(ctx, jobID)
+	extracted, err := extractInput(ctx, extractor, input)
+	// A cancelled job says nothing about the provider.
+	if s.health != nil && !overBudget && !errors.Is(context.Cause(ctx), ErrJobCancelled) {
+		if err != nil {
+			s.health.RecordFailure(err)
+		} else {
+			s.health.RecordSuccess()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if s.budget != nil && !overBudget {
+		if err := s.budget.Record(ctx, extracted.TokensUsed); err != nil {
+			s.log.WarnContext(ctx, "failed to record llm usage", "job_id", jobID, "error", err)
+		}
+	}
+	return extracted, nil
+}
+
+// extractorFor picks the LLM extractor, or the fallback parser when the
+// budget is exhausted, in which case the job is flagged budget_exceeded. A
+// failed budget lookup fails open to the LLM.
+func (s *IngestService) extractorFor(ctx context.Context, jobID uuid.UUID) (LLMExtractor, bool) {
+	if s.budget == nil {
+		return s.extractor, false
+	}
+	exhausted, err := s.budget.Exhausted(ctx)
+	if err != nil {
+		s.log.WarnContext(ctx, "llm budget check failed", "job_id", jobID, "error", err)
+		return s.extractor, false
+	}
+	if !exhausted {
+		return s.extractor, false
+	}
+	s.log.InfoContext(ctx, "llm budget exhausted; using heuristic parser", "job_id", jobID)
+	if err := s.q.MarkIngestionJobBudgetExceeded(ctx, jobID); err != nil {
+		s.log.WarnContext(ctx, "failed to flag job budget_exceeded", "job_id", jobID, "error", err)
+	}
+	return s.fallback, true
 }
 
 // GetJob returns a single IngestionJob by ID.
@@ -127,6 +716,21 @@ func (s *IngestService) GetJob(ctx context.Context, id uuid.UUID) (db.IngestionJ
 	return s.q.GetIngestionJob(ctx, id)
 }
 
+// JobETag returns a validator for the job's poll response. It changes when
+// the status changes or any staged item is added, removed or re-extracted,
+// and is computed without loading the items.
+func (s *IngestService) JobETag(ctx context.Context, job db.IngestionJob) (string, error) {
+	fp, err := s.q.StagedItemsFingerprint(ctx, job.ID)
+	if err != nil {
+		return "", err
+	}
+	checksum := fp.Checksum
+	if len(checksum) > 16 {
+		checksum = checksum[:16]
+	}
+	return fmt.Sprintf(`W/"%s-%d-%s"`, job.Status, fp.ItemCount, checksum), nil
+}
+
 // ListStagedItems returns the staged items for a job.
 func (s *IngestService) ListStagedItems(ctx context.Context, jobID uuid.UUID) ([]db.StagedItem, error) {
 	items, err := s.q.ListStagedItemsByJob(ctx, jobID)
@@ -145,97 +749,225 @@ type OverrideItem struct {
 	IngredientID *uuid.UUID `json:"ingredient_id,omitempty"`
 	Quantity     *float64   `json:"quantity,omitempty"`
 	Unit         *string    `json:"unit,omitempty"`
+	ExpiresAt    *string    `json:"expires_at,omitempty"` // RFC3339 or YYYY-MM-DD
+}
+
+// UnmarshalJSON accepts quantity either as a JSON number or as a string
+// written the way people write lists, e.g. "1,5", "½" or "1 1/2"; see
+// ParseQuantity.
+func (o *OverrideItem) UnmarshalJSON(data []byte) error {
+	type plain OverrideItem
+	var aux struct {
+		plain
+		Quantity json.RawMessage `json:"quantity,omitempty"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*o = OverrideItem(aux.plain)
+	if len(aux.Quantity) == 0 || string(aux.Quantity) == "null" {
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(aux.Quantity, &text); err != nil {
+		var q float64
+		if err := json.Unmarshal(aux.Quantity, &q); err != nil {
+			return fmt.Errorf("quantity must be a number or a numeric string: %w", err)
+		}
+		o.Quantity = &q
+		return nil
+	}
+	q, ok := ParseQuantity(text)
+	if !ok {
+		return fmt.Errorf("quantity %q is not a recognizable number", text)
+	}
+	o.Quantity = &q
+	return nil
+}
+
+// ConfirmedItem is the outcome of confirming one staged item. Exactly one of
+// PantryItemID and Skipped is set.
+type ConfirmedItem struct {
+	StagedItemID uuid.UUID
+	PantryItemID uuid.NullUUID
+	Skipped      string // why the item was not committed
 }
 
 // ConfirmJob commits staged items to the pantry. Optional overrides let the
-// caller adjust quantity, unit, or ingredient_id before commit. Items without a
-// resolved ingredient_id are skipped with a warning.
+// caller adjust quantity, unit, ingredient_id, or expires_at before commit.
+// Items without a resolved ingredient_id are skipped with a warning. The
+// result has one entry per staged item, in staging order. A staged unit that
+// differs from the pantry's unit for the same ingredient is converted when
+// possible; otherwise the confirm fails with a *UnitConflictError unless the
+// override set the unit explicitly. A fridge photo job sets the stock of
+// each ingredient it saw rather than adding to it.
 func (s *IngestService) ConfirmJob(
 	ctx context.Context,
 	jobID uuid.UUID,
 	pantry *PantryService,
 	overrides []OverrideItem,
-) error {
+) ([]ConfirmedItem, error) {
 	job, err := s.q.GetIngestionJob(ctx, jobID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if job.Status != "staged" {
-		return fmt.Errorf("job %s has status %q, must be staged to confirm", jobID, job.Status)
-	}
-
-	staged, err := s.q.ListStagedItemsByJob(ctx, jobID)
-	if err != nil {
-		return err
+		return nil, fmt.Errorf("job %s has status %q, must be staged to confirm", jobID, job.Status)
 	}
 
+	// Validate overrides before writing anything so a bad one does not
+	// leave the job half applied.
 	overrideMap := make(map[uuid.UUID]OverrideItem, len(overrides))
 	for _, o := range overrides {
+		if o.Quantity != nil && !(*o.Quantity >= 0 && *o.Quantity <= MaxQuantity) {
+			return nil, fmt.Errorf("%w: staged item %s has quantity %g; must be 0 to %g",
+				ErrInvalidOverride, o.StagedItemID, *o.Quantity, MaxQuantity)
+		}
+		if o.IngredientID != nil {
+			if err := pantry.ValidateIngredient(ctx, *o.IngredientID); err != nil {
+				return nil, fmt.Errorf("staged item %s: %w", o.StagedItemID, err)
+			}
+		}
 		overrideMap[o.StagedItemID] = o
 	}
 
-	changedItemIDs := make([]uuid.UUID, 0, len(staged))
+	var plans []confirmPlan
+	var changedItemIDs []uuid.UUID
+	var results []ConfirmedItem
+	overridden := 0
+	// The pantry writes and the status update commit together, so a failure
+	// partway leaves both the pantry and the staged job as they were.
+	err = db.InTx(ctx, s.q, func(q db.Querier) error {
+		// Claiming the job first locks its row: a concurrent confirm, reject
+		// or expiry waits for this one and then finds it no longer staged.
+		_, err := q.TransitionIngestionJobStatus(ctx, db.TransitionIngestionJobStatusParams{
+			ID:         jobID,
+			FromStatus: "staged",
+			ToStatus:   "confirmed",
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: job %s was closed during confirm", ErrJobNotStaged, jobID)
+		}
+		if err != nil {
+			return err
+		}
 
-	for _, item := range staged {
-		ingredientID := item.IngredientID
-		quantity := item.Quantity
-		unit := item.Unit
+		staged, err := q.ListStagedItemsByJob(ctx, jobID)
+		if err != nil {
+			return err
+		}
 
-		if o, ok := overrideMap[item.ID]; ok {
-			if o.IngredientID != nil {
-				ingredientID = uuid.NullUUID{UUID: *o.IngredientID, Valid: true}
+		// Apply overrides and check units against the pantry before writing,
+		// so a conflict rejects the whole confirm rather than part of it.
+		plans = make([]confirmPlan, len(staged))
+		overridden = 0
+		for i, item := range staged {
+			plan := confirmPlan{
+				staged:       item,
+				ingredientID: item.IngredientID,
+				in: ItemInput{
+					Quantity:        item.Quantity,
+					QuantityUnknown: item.QuantityUnknown,
+					Unit:            item.Unit,
+				},
 			}
-			if o.Quantity != nil {
-				quantity = *o.Quantity
-			}
-			if o.Unit != nil {
-				unit = *o.Unit
+			if o, ok := overrideMap[item.ID]; ok {
+				overridden++
+				if o.IngredientID != nil {
+					plan.ingredientID = uuid.NullUUID{UUID: *o.IngredientID, Valid: true}
+				}
+				if o.Quantity != nil {
+					plan.in.Quantity, plan.in.QuantityUnknown = *o.Quantity, false
+				}
+				if o.Unit != nil {
+					plan.in.Unit = *o.Unit
+					plan.unitOverridden = true
+				}
+				if o.ExpiresAt != nil {
+					t, err := pantry.ParseExpiresAt(*o.ExpiresAt)
+					if err != nil {
+						return fmt.Errorf("staged item %s: %w", item.ID, err)
+					}
+					plan.in.ExpiresAt = sql.NullTime{Time: t, Valid: true}
+				}
 			}
+			plan.in.IngredientID = plan.ingredientID.UUID
+			plans[i] = plan
 		}
-
-		if !ingredientID.Valid {
-			slog.Default().WarnContext(ctx,
-				"skipping staged item: no ingredient_id resolved",
-				"item_id", item.ID,
-				"raw_text", item.RawText,
-			)
-			continue
+		if err := matchPantryUnits(ctx, q, plans); err != nil {
+			return err
 		}
 
-		upserted, err := pantry.UpsertItemNoPublish(ctx, ingredientID.UUID, quantity, unit, sql.NullTime{})
+		txPantry := pantry.withQuerier(q)
+		changedItemIDs = make([]uuid.UUID, 0, len(staged))
+		results = make([]ConfirmedItem, 0, len(staged))
+
+		saved, err := txPantry.confirmStock(ctx, jobID, plans, job.Type == JobTypeFridgePhoto)
 		if err != nil {
-			return fmt.Errorf("upsert pantry item for staged item %s: %w", item.ID, err)
+			return err
 		}
-		changedItemIDs = append(changedItemIDs, upserted.ID)
-	}
 
-	_, err = s.q.UpdateIngestionJobStatus(ctx, db.UpdateIngestionJobStatusParams{
-		ID:     jobID,
-		Status: "confirmed",
+		next := 0
+		for _, plan := range plans {
+			item := plan.staged
+			if !plan.ingredientID.Valid {
+				s.log.WarnContext(ctx,
+					"skipping staged item: no ingredient_id resolved",
+					"item_id", item.ID,
+					"raw_text", item.RawText,
+				)
+				results = append(results, ConfirmedItem{StagedItemID: item.ID, Skipped: "no ingredient_id resolved"})
+				continue
+			}
+			upserted := saved[next]
+			next++
+			changedItemIDs = append(changedItemIDs, upserted.ID)
+			results = append(results, ConfirmedItem{
+				StagedItemID: item.ID,
+				PantryItemID: uuid.NullUUID{UUID: upserted.ID, Valid: true},
+			})
+		}
+		return nil
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	s.jobStatusChanged(ctx, jobID, "confirmed")
 	if len(changedItemIDs) > 0 {
+		pantry.activity.recordConfirmed(ctx, job.Source, len(changedItemIDs))
 		pantry.PublishUpdated(ctx, changedItemIDs)
 	}
+	if s.confirmHook != nil {
+		s.confirmHook.OnConfirm(ctx, jobID, changedItemIDs)
+	}
+	s.publishConfirmSummary(job, plans, overridden)
 
-	return nil
+	return results, nil
 }
 
 // --- LLM extraction ---
 
+// ExtractedItem is one item read from a list. A Quantity of zero (or null in
+// the model's JSON) means the entry gave no amount. FillLevel is only set
+// for an opened package on a fridge photo, whose Quantity is then the full
+// package size.
 type ExtractedItem struct {
 	RawText    string  `json:"raw_text"`
 	Name       string  `json:"name"`
 	Quantity   float64 `json:"quantity"`
 	Unit       string  `json:"unit"`
 	Confidence float64 `json:"confidence"`
+	FillLevel  string  `json:"fill_level,omitempty"`
 }
 
 type ExtractionResponse struct {
 	Items []ExtractedItem `json:"items"`
+
+	// TokensUsed is the total tokens the provider billed for the call; zero
+	// for extractors that do not call an LLM.
+	TokensUsed int `json:"-"`
 }
 
 const systemPrompt = `You are a grocery list parser. Extract ingredients with quantities from the user's text.
@@ -243,47 +975,152 @@ const systemPrompt = `You are a grocery list parser. Extract ingredients with qu
 Return a JSON object with an "items" array. Each item must have:
 - "raw_text": the original text snippet for this item
 - "name": the canonical ingredient name (lowercase, singular, normalized — e.g. "chicken breast" not "2 lbs chicken breasts")
-- "quantity": numeric quantity as a float (default 1.0 if unclear)
+- "quantity": numeric quantity as a float, or null if the item gives no amount at all (e.g. "olive oil")
 - "unit": unit of measure (e.g. "lb", "g", "cup", "oz", "bunch", "head", "clove", "piece", "carton")
 - "confidence": your confidence 0.0 to 1.0
 
 For ambiguous or unclear items set confidence below 0.7.
-For items where the unit is unclear, use "piece".`
+If an item gives no unit at all (e.g. "apples", "orange juice"), set "unit" to "".
+Do not guess one; a default is chosen from the ingredient's category.
+Text such as [REDACTED:NAME] stands in for removed personal details; it is never an item.`
+
+// Ping lists the provider's models, a call that costs no tokens but fails
+// the same way extraction would on an outage or a revoked key.
+func (e *OpenAIExtractor) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.endpointURL("/models", ""), nil)
+	if err != nil {
+		return err
+	}
+	e.setAuth(req)
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request: %w", e.provider, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck // drained for connection reuse
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s status %d", e.provider, resp.StatusCode)
+	}
+	return nil
+}
+
+// dryRunPrompt is a handful of tokens; JSON mode needs the word JSON in it.
+const dryRunPrompt = "Reply {} as JSON."
+
+// DryRun sends a tiny completion to the extraction model, and to the vision
+// model when it is a different one. Unlike Ping it spends a few tokens, but
+// it also catches a misspelt model or an account without quota.
+func (e *OpenAIExtractor) DryRun(ctx context.Context) error {
+	for _, model := range e.models() {
+		if _, _, err := e.chat(ctx, model, dryRunPrompt, "{}", jsonObjectFormat); err != nil {
+			return fmt.Errorf("model %s: %w", model, err)
+		}
+	}
+	return nil
+}
 
 func (e *OpenAIExtractor) Extract(ctx context.Context, text string) (*ExtractionResponse, error) {
+	return e.complete(ctx, e.model, text)
+}
+
+// ExtractImage reads the items off a photographed receipt, or the stock on a
+// fridge photo, with the vision model, which defaults to the extraction
+// model.
+func (e *OpenAIExtractor) ExtractImage(
+	ctx context.Context,
+	jobType, mediaType string,
+	image []byte,
+) (*ExtractionResponse, error) {
+	return e.complete(ctx, e.imageModel(), []map[string]any{
+		{"type": "text", "text": imagePrompt(jobType)},
+		{"type": "image_url", "image_url": map[string]string{"url": imageDataURL(mediaType, image)}},
+	})
+}
+
+// imageModel is the model that reads photos: the vision model, or the
+// extraction model when none is set.
+func (c *llmClient) imageModel() string {
+	if c.visionModel != "" {
+		return c.visionModel
+	}
+	return c.model
+}
+
+// models lists the distinct models the extractor calls.
+func (c *llmClient) models() []string {
+	if c.visionModel != "" && c.visionModel != c.model {
+		return []string{c.model, c.visionModel}
+	}
+	return []string{c.model}
+}
+
+// imagePrompt is the user instruction sent with a photo of jobType.
+func imagePrompt(jobType string) string {
+	if jobType == JobTypeFridgePhoto {
+		return fridgePhotoPrompt
+	}
+	return receiptPrompt
+}
+
+// complete sends one chat completion with the extraction prompt and parses
+// the items from the reply. content is the user message: a string or a list
+// of content parts.
+func (e *OpenAIExtractor) complete(ctx context.Context, model string, content any) (*ExtractionResponse, error) {
+	reply, tokens, err := e.call(ctx, model, func(ctx context.Context, model string) (string, int, error) {
+		return e.chat(ctx, model, e.prompt, content, extractionResponseFormat)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseExtraction(reply, tokens)
+}
+
+// chat sends one chat completion with the given response_format and
+// returns the reply's content and the tokens it used.
+func (e *OpenAIExtractor) chat(
+	ctx context.Context, model, system string, content any, format map[string]any,
+) (_ string, tokens int, err error) {
+	ctx, span := tracing.Start(ctx, "chat "+model, trace.SpanKindClient,
+		semconv.GenAIOperationNameChat, semconv.GenAIProviderNameKey.String(e.provider),
+		semconv.GenAIRequestModel(model))
+	defer func() {
+		span.SetAttributes(attribute.Int("gen_ai.usage.total_tokens", tokens))
+		tracing.End(span, err)
+	}()
+
 	payload := map[string]any{
-		"model": e.model,
-		"messages": []map[string]string{
-			{"role": "system", "content": systemPrompt},
-			{"role": "user", "content": text},
+		"model": model,
+		"messages": []map[string]any{
+			{"role": "system", "content": system},
+			{"role": "user", "content": content},
 		},
-		"response_format": map[string]string{"type": "json_object"},
+		"response_format": format,
 	}
 
 	body, err := json.Marshal(payload)
 	if err != nil {
-		return nil, err
+		return "", 0, err
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
-		"https://api.openai.com/v1/chat/completions",
+		e.endpointURL("/chat/completions", model),
 		bytes.NewReader(body),
 	)
 	if err != nil {
-		return nil, err
+		return "", 0, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	e.setAuth(req)
 
 	resp, err := e.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("openai request: %w", err)
+		return "", 0, fmt.Errorf("%s request: %w", e.provider, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		raw, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("openai status %d: %s", resp.StatusCode, string(raw))
+		return "", 0, newLLMStatusError(e.provider, resp)
 	}
 
 	var chatResp struct {
@@ -292,17 +1129,15 @@ func (e *OpenAIExtractor) Extract(ctx context.Context, text string) (*Extraction
 				Content string `json:"content"`
 			} `json:"message"`
 		} `json:"choices"`
+		Usage struct {
+			TotalTokens int `json:"total_tokens"`
+		} `json:"usage"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&chatResp); err != nil {
-		return nil, fmt.Errorf("openai response decode: %w", err)
+		return "", 0, fmt.Errorf("%s response decode: %w", e.provider, err)
 	}
 	if len(chatResp.Choices) == 0 {
-		return nil, errors.New("openai returned no choices")
-	}
-
-	var extracted ExtractionResponse
-	if err := json.Unmarshal([]byte(chatResp.Choices[0].Message.Content), &extracted); err != nil {
-		return nil, fmt.Errorf("parse extraction json: %w", err)
+		return "", 0, fmt.Errorf("%s returned no choices", e.provider)
 	}
-	return &extracted, nil
+	return chatResp.Choices[0].Message.Content, chatResp.Usage.TotalTokens, nil
 }
@@ -4,8 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -15,8 +21,14 @@ import (
 	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
 	"github.com/mwhite7112/woodpantry-pantry/internal/db"
 	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/testutil/llmserver"
 )
 
+// toProcessing is the pending → processing move that starts or claims a job.
+func toProcessing(jobID uuid.UUID) db.TransitionIngestionJobStatusParams {
+	return db.TransitionIngestionJobStatusParams{ToStatus: "processing", ID: jobID, FromStatus: "pending"}
+}
+
 func TestProcessJob_Success(t *testing.T) {
 	t.Parallel()
 
@@ -40,18 +52,12 @@ func TestProcessJob_Success(t *testing.T) {
 
 	// Dictionary resolves each item
 	mockDict.EXPECT().Resolve(mock.Anything, "flour").Return(clients.ResolveResult{
-		Ingredient: struct {
-			ID   uuid.UUID `json:"id"`
-			Name string    `json:"name"`
-		}{ID: garlicID, Name: "flour"},
+		Ingredient: clients.Ingredient{ID: garlicID, Name: "flour"},
 		Confidence: 0.95,
 		Created:    false,
 	}, nil)
 	mockDict.EXPECT().Resolve(mock.Anything, "chicken breast").Return(clients.ResolveResult{
-		Ingredient: struct {
-			ID   uuid.UUID `json:"id"`
-			Name string    `json:"name"`
-		}{ID: chickenID, Name: "chicken breast"},
+		Ingredient: clients.Ingredient{ID: chickenID, Name: "chicken breast"},
 		Confidence: 0.92,
 		Created:    false,
 	}, nil)
@@ -78,11 +84,18 @@ func TestProcessJob_Success(t *testing.T) {
 	}).Return(db.StagedItem{}, nil)
 
 	// Job status updated to staged
-	mockQ.EXPECT().UpdateIngestionJobStatus(mock.Anything, db.UpdateIngestionJobStatusParams{
-		ID:     jobID,
-		Status: "staged",
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, db.TransitionIngestionJobStatusParams{
+		ToStatus:   "staged",
+		ID:         jobID,
+		FromStatus: "processing",
 	}).Return(db.IngestionJob{}, nil)
 
+	// Timings recorded for both resolutions
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything,
+		mock.MatchedBy(func(p db.UpsertIngestionJobTimingsParams) bool {
+			return p.JobID == jobID && p.ResolvedItems == 2 && p.TotalMs >= p.ExtractionMs
+		})).Return(nil)
+
 	err := svc.processJob(context.Background(), jobID, rawInput)
 	require.NoError(t, err)
 }
@@ -99,16 +112,259 @@ func TestProcessJob_LLMFailure(t *testing.T) {
 	rawInput := "some groceries"
 
 	mockLLM.EXPECT().Extract(mock.Anything, rawInput).Return(nil, errors.New("openai timeout"))
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything,
+		mock.MatchedBy(func(p db.UpsertIngestionJobTimingsParams) bool {
+			return p.JobID == jobID && p.ResolvedItems == 0
+		})).Return(nil)
 
 	err := svc.processJob(context.Background(), jobID, rawInput)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "llm extraction")
 }
 
+func TestProcessJobSync_MarksFailedAndOutlivesRequest(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockLLM := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), mockLLM)
+
+	jobID := uuid.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, toProcessing(jobID)).Return(db.IngestionJob{}, nil)
+	mockLLM.EXPECT().Extract(mock.MatchedBy(func(ctx context.Context) bool { return ctx.Err() == nil }), "eggs").
+		Return(nil, errors.New("openai timeout"))
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockQ.EXPECT().FailIngestionJob(mock.Anything, db.FailIngestionJobParams{
+		ID:            jobID,
+		FailureReason: "llm extraction: openai timeout",
+	}).Return(1, nil)
+
+	err := svc.ProcessJobSync(ctx, jobID, "eggs")
+	require.ErrorContains(t, err, "llm extraction")
+}
+
+func TestProcessJob_RecordsTokensWithinBudget(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockLLM := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), mockLLM)
+	svc.SetBudget(NewLLMBudget(mockQ, 1000), NewHeuristicExtractor())
+
+	jobID := uuid.New()
+	mockQ.EXPECT().GetLLMUsage(mock.Anything, mock.Anything).Return(db.LlmUsage{TokensUsed: 999}, nil)
+	mockLLM.EXPECT().Extract(mock.Anything, "nothing").Return(&ExtractionResponse{TokensUsed: 120}, nil)
+	mockQ.EXPECT().AddLLMUsage(mock.Anything, mock.MatchedBy(func(p db.AddLLMUsageParams) bool {
+		return p.TokensUsed == 120
+	})).Return(db.LlmUsage{}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	require.NoError(t, svc.processJob(context.Background(), jobID, "nothing"))
+}
+
+func TestProcessJob_BudgetExceededUsesHeuristicParser(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockDict := NewMockDictionaryResolver(t)
+	svc := NewIngestService(mockQ, mockDict, NewMockLLMExtractor(t))
+	svc.SetBudget(NewLLMBudget(mockQ, 1000), NewHeuristicExtractor())
+
+	jobID := uuid.New()
+	milkID := uuid.New()
+	mockQ.EXPECT().GetLLMUsage(mock.Anything, mock.Anything).Return(db.LlmUsage{TokensUsed: 1000}, nil)
+	mockQ.EXPECT().MarkIngestionJobBudgetExceeded(mock.Anything, jobID).Return(nil)
+	mockDict.EXPECT().Resolve(mock.Anything, "milk").Return(clients.ResolveResult{
+		Ingredient: clients.Ingredient{ID: milkID, Name: "milk"},
+	}, nil)
+	mockQ.EXPECT().CreateStagedItem(mock.Anything, db.CreateStagedItemParams{
+		JobID:        jobID,
+		IngredientID: uuid.NullUUID{UUID: milkID, Valid: true},
+		RawText:      "2 cartons milk",
+		Quantity:     2,
+		Unit:         "carton",
+		Confidence:   heuristicConfidence,
+		NeedsReview:  true,
+	}).Return(db.StagedItem{}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	require.NoError(t, svc.processJob(context.Background(), jobID, "2 cartons milk"))
+}
+
+func TestReextractItem_WithHint(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockDict := NewMockDictionaryResolver(t)
+	mockLLM := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, mockDict, mockLLM)
+
+	jobID := uuid.New()
+	itemID := uuid.New()
+	cuminID := uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+	mockQ.EXPECT().GetStagedItem(mock.Anything, itemID).
+		Return(db.StagedItem{ID: itemID, JobID: jobID, RawText: "1 jar cmn"}, nil)
+	mockLLM.EXPECT().Extract(mock.Anything, mock.MatchedBy(func(in string) bool {
+		return strings.HasPrefix(in, "1 jar cmn") && strings.Contains(in, "this is a spice")
+	})).Return(&ExtractionResponse{Items: []ExtractedItem{
+		{RawText: "1 jar cmn", Name: "cumin", Quantity: 1, Unit: "jar", Confidence: 0.85},
+	}}, nil)
+	mockDict.EXPECT().Resolve(mock.Anything, "cumin").Return(clients.ResolveResult{
+		Ingredient: clients.Ingredient{ID: cuminID, Name: "cumin"},
+	}, nil)
+	mockQ.EXPECT().UpdateStagedItemExtraction(mock.Anything, db.UpdateStagedItemExtractionParams{
+		ID:           itemID,
+		IngredientID: uuid.NullUUID{UUID: cuminID, Valid: true},
+		Quantity:     1,
+		Unit:         "jar",
+		Confidence:   0.85,
+		NeedsReview:  false,
+	}).Return(db.StagedItem{ID: itemID}, nil)
+
+	_, err := svc.ReextractItem(context.Background(), jobID, itemID, " this is a spice ")
+	require.NoError(t, err)
+}
+
+func TestProcessJob_TruncatesAtStagedItemCap(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockDict := NewMockDictionaryResolver(t)
+	mockLLM := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, mockDict, mockLLM)
+	svc.SetMaxStagedItems(2)
+
+	jobID := uuid.New()
+	items := make([]ExtractedItem, 5)
+	for i := range items {
+		items[i] = ExtractedItem{
+			RawText: fmt.Sprintf("item %d", i), Name: "salt", Quantity: 1, Unit: "piece", Confidence: 0.9,
+		}
+	}
+	mockLLM.EXPECT().Extract(mock.Anything, "bulk").Return(&ExtractionResponse{Items: items}, nil)
+	mockQ.EXPECT().MarkIngestionJobTruncated(mock.Anything, db.MarkIngestionJobTruncatedParams{
+		ID:             jobID,
+		TruncatedItems: 3,
+	}).Return(nil)
+	mockDict.EXPECT().Resolve(mock.Anything, "salt").Return(clients.ResolveResult{}, nil).Once()
+	mockQ.EXPECT().CreateStagedItem(mock.Anything, mock.Anything).Return(db.StagedItem{}, nil).Times(2)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	require.NoError(t, svc.processJob(context.Background(), jobID, "bulk"))
+}
+
+func TestProcessJob_ResolvesRepeatedNamesOnce(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockDict := NewMockDictionaryResolver(t)
+	mockLLM := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, mockDict, mockLLM)
+
+	jobID := uuid.New()
+	milkID := uuid.New()
+	rawInput := "milk, 1 gal milk, Milk"
+
+	mockLLM.EXPECT().Extract(mock.Anything, rawInput).Return(&ExtractionResponse{
+		Items: []ExtractedItem{
+			{RawText: "milk", Name: "milk", Quantity: 1, Unit: "each", Confidence: 0.9},
+			{RawText: "1 gal milk", Name: "milk", Quantity: 1, Unit: "gal", Confidence: 0.9},
+			{RawText: "Milk", Name: "Milk ", Quantity: 1, Unit: "each", Confidence: 0.9},
+		},
+	}, nil)
+
+	mockDict.EXPECT().Resolve(mock.Anything, "milk").Return(clients.ResolveResult{
+		Ingredient: clients.Ingredient{ID: milkID, Name: "milk"},
+	}, nil).Once()
+
+	mockQ.EXPECT().CreateStagedItem(mock.Anything, mock.MatchedBy(func(p db.CreateStagedItemParams) bool {
+		return p.IngredientID.UUID == milkID
+	})).Return(db.StagedItem{}, nil).Times(3)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	require.NoError(t, svc.processJob(context.Background(), jobID, rawInput))
+}
+
+func TestProcessJob_ValidatesUnits(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockDict := NewMockDictionaryResolver(t)
+	mockLLM := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, mockDict, mockLLM)
+
+	jobID := uuid.New()
+	rawInput := "2 lbs chicken, a splash of cream, 3 eggs"
+
+	mockLLM.EXPECT().Extract(mock.Anything, rawInput).Return(&ExtractionResponse{
+		Items: []ExtractedItem{
+			{RawText: "2 lbs chicken", Name: "chicken", Quantity: 2, Unit: "lbs", Confidence: 0.95},
+			{RawText: "a splash of cream", Name: "cream", Quantity: 1, Unit: "splash", Confidence: 0.95},
+			{RawText: "3 eggs", Name: "eggs", Quantity: 3, Unit: "", Confidence: 0.95},
+		},
+	}, nil)
+	mockDict.EXPECT().Resolve(mock.Anything, mock.Anything).Return(clients.ResolveResult{
+		Ingredient: clients.Ingredient{ID: uuid.New()},
+	}, nil)
+
+	var staged []db.CreateStagedItemParams
+	mockQ.EXPECT().CreateStagedItem(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, p db.CreateStagedItemParams) (db.StagedItem, error) {
+			staged = append(staged, p)
+			return db.StagedItem{}, nil
+		}).Times(3)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	require.NoError(t, svc.processJob(context.Background(), jobID, rawInput))
+	require.Len(t, staged, 3)
+
+	assert.Equal(t, "lb", staged[0].Unit, "aliases are stored canonically")
+	assert.False(t, staged[0].NeedsReview)
+	assert.Equal(t, "tbsp", staged[1].Unit, "invented units are replaced by the nearest unit")
+	assert.True(t, staged[1].NeedsReview)
+	assert.Equal(t, "piece", staged[2].Unit)
+	assert.False(t, staged[2].NeedsReview)
+}
+
+func TestProcessJob_StagesUnknownQuantity(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockDict := NewMockDictionaryResolver(t)
+	mockLLM := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, mockDict, mockLLM)
+
+	jobID := uuid.New()
+	rawInput := "some flour"
+
+	mockLLM.EXPECT().Extract(mock.Anything, rawInput).Return(&ExtractionResponse{
+		Items: []ExtractedItem{{RawText: "some flour", Name: "flour", Unit: "", Confidence: 0.9}},
+	}, nil)
+	mockDict.EXPECT().Resolve(mock.Anything, "flour").Return(clients.ResolveResult{
+		Ingredient: clients.Ingredient{ID: uuid.New()}, Confidence: 0.9,
+	}, nil)
+	mockQ.EXPECT().CreateStagedItem(mock.Anything, mock.MatchedBy(func(p db.CreateStagedItemParams) bool {
+		return p.QuantityUnknown && p.Quantity == 0
+	})).Return(db.StagedItem{}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	require.NoError(t, svc.processJob(context.Background(), jobID, rawInput))
+}
+
 func TestProcessJob_DictionaryFailureSetsNeedsReview(t *testing.T) {
 	t.Parallel()
 
 	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
 	mockDict := NewMockDictionaryResolver(t)
 	mockLLM := NewMockLLMExtractor(t)
 	svc := NewIngestService(mockQ, mockDict, mockLLM)
@@ -138,9 +394,10 @@ func TestProcessJob_DictionaryFailureSetsNeedsReview(t *testing.T) {
 		NeedsReview:  true,
 	}).Return(db.StagedItem{}, nil)
 
-	mockQ.EXPECT().UpdateIngestionJobStatus(mock.Anything, db.UpdateIngestionJobStatusParams{
-		ID:     jobID,
-		Status: "staged",
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, db.TransitionIngestionJobStatusParams{
+		ToStatus:   "staged",
+		ID:         jobID,
+		FromStatus: "processing",
 	}).Return(db.IngestionJob{}, nil)
 
 	err := svc.processJob(context.Background(), jobID, rawInput)
@@ -196,21 +453,62 @@ func TestConfirmJob_SuccessWithOverrides(t *testing.T) {
 		},
 	}
 
+	mockQ.EXPECT().ListPantryItemsByIngredients(mock.Anything, []uuid.UUID{overrideIngredientID}).Return(nil, nil)
+
 	// UpsertPantryItem called with overridden values
-	mockQ.EXPECT().UpsertPantryItem(mock.Anything, db.UpsertPantryItemParams{
-		IngredientID: overrideIngredientID,
-		Quantity:     3.0,
-		Unit:         "cup",
-		ExpiresAt:    sql.NullTime{},
-	}).Return(db.PantryItem{}, nil)
+	mockQ.EXPECT().UpsertPantryItems(mock.Anything, db.UpsertPantryItemsParams{
+		IngredientIds:   []uuid.UUID{overrideIngredientID},
+		Quantities:      []float64{3.0},
+		Units:           []string{"cup"},
+		ExpiresAt:       []time.Time{{}},
+		HasExpiry:       []bool{false},
+		QuantityUnknown: []bool{false},
+	}).Return([]db.PantryItem{{IngredientID: overrideIngredientID}}, nil)
 
 	// Job status updated to confirmed
-	mockQ.EXPECT().UpdateIngestionJobStatus(mock.Anything, db.UpdateIngestionJobStatusParams{
-		ID:     jobID,
-		Status: "confirmed",
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, db.TransitionIngestionJobStatusParams{
+		ID:         jobID,
+		FromStatus: "staged",
+		ToStatus:   "confirmed",
 	}).Return(db.IngestionJob{}, nil)
 
-	err := ingestSvc.ConfirmJob(context.Background(), jobID, pantrySvc, overrides)
+	_, err := ingestSvc.ConfirmJob(context.Background(), jobID, pantrySvc, overrides)
+	require.NoError(t, err)
+}
+
+func TestConfirmJob_KeepsQuantityUnknown(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	ingestSvc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	pantrySvc := NewPantryService(mockQ)
+
+	jobID := uuid.New()
+	ingredientID := uuid.New()
+
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+	mockQ.EXPECT().ListStagedItemsByJob(mock.Anything, jobID).Return([]db.StagedItem{{
+		ID:              uuid.New(),
+		JobID:           jobID,
+		IngredientID:    uuid.NullUUID{UUID: ingredientID, Valid: true},
+		RawText:         "some flour",
+		Unit:            "piece",
+		QuantityUnknown: true,
+	}}, nil)
+	mockQ.EXPECT().ListPantryItemsByIngredients(mock.Anything, mock.Anything).Return([]db.PantryItem{
+		{IngredientID: ingredientID, Quantity: 500, Unit: "g"},
+	}, nil)
+	mockQ.EXPECT().UpsertPantryItems(mock.Anything, db.UpsertPantryItemsParams{
+		IngredientIds:   []uuid.UUID{ingredientID},
+		Quantities:      []float64{0},
+		Units:           []string{"g"},
+		ExpiresAt:       []time.Time{{}},
+		HasExpiry:       []bool{false},
+		QuantityUnknown: []bool{true},
+	}).Return([]db.PantryItem{{IngredientID: ingredientID}}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	_, err := ingestSvc.ConfirmJob(context.Background(), jobID, pantrySvc, nil)
 	require.NoError(t, err)
 }
 
@@ -236,9 +534,10 @@ func TestConfirmJob_SkipsItemsWithoutIngredientID(t *testing.T) {
 	}, nil)
 
 	// Staged item with no ingredient_id
+	stagedID := uuid.New()
 	mockQ.EXPECT().ListStagedItemsByJob(mock.Anything, jobID).Return([]db.StagedItem{
 		{
-			ID:           uuid.New(),
+			ID:           stagedID,
 			JobID:        jobID,
 			IngredientID: uuid.NullUUID{Valid: false},
 			RawText:      "mystery item",
@@ -252,13 +551,147 @@ func TestConfirmJob_SkipsItemsWithoutIngredientID(t *testing.T) {
 	// UpsertPantryItem should NOT be called — item is skipped
 
 	// Job status updated to confirmed
-	mockQ.EXPECT().UpdateIngestionJobStatus(mock.Anything, db.UpdateIngestionJobStatusParams{
-		ID:     jobID,
-		Status: "confirmed",
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, db.TransitionIngestionJobStatusParams{
+		ID:         jobID,
+		FromStatus: "staged",
+		ToStatus:   "confirmed",
 	}).Return(db.IngestionJob{}, nil)
 
-	err := ingestSvc.ConfirmJob(context.Background(), jobID, pantrySvc, nil)
+	results, err := ingestSvc.ConfirmJob(context.Background(), jobID, pantrySvc, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, stagedID, results[0].StagedItemID)
+	assert.False(t, results[0].PantryItemID.Valid)
+	assert.Equal(t, "no ingredient_id resolved", results[0].Skipped)
+}
+
+type recordingConfirmHook struct {
+	jobIDs []uuid.UUID
+}
+
+func (h *recordingConfirmHook) OnConfirm(_ context.Context, jobID uuid.UUID, _ []uuid.UUID) {
+	h.jobIDs = append(h.jobIDs, jobID)
+}
+
+func TestConfirmJob_InvokesConfirmHook(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	ingestSvc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	hook := &recordingConfirmHook{}
+	ingestSvc.SetConfirmHook(hook)
+
+	jobID := uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+	mockQ.EXPECT().ListStagedItemsByJob(mock.Anything, jobID).Return(nil, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	_, err := ingestSvc.ConfirmJob(context.Background(), jobID, NewPantryService(mockQ), nil)
 	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{jobID}, hook.jobIDs)
+}
+
+// txQuerier is a Transactor whose transactions run on tx, recording whether
+// they committed.
+type txQuerier struct {
+	*mocks.MockQuerier
+	tx        *mocks.MockQuerier
+	committed bool
+	rolled    bool
+}
+
+func (q *txQuerier) InTx(_ context.Context, fn func(db.Querier) error) error {
+	if err := fn(q.tx); err != nil {
+		q.rolled = true
+		return err
+	}
+	q.committed = true
+	return nil
+}
+
+func TestConfirmJob_WritesInOneTransaction(t *testing.T) {
+	t.Parallel()
+
+	jobID := uuid.New()
+	flour, sugar := uuid.New(), uuid.New()
+	setup := func(t *testing.T) (*txQuerier, *IngestService, *PantryService) {
+		t.Helper()
+		q := &txQuerier{MockQuerier: mocks.NewMockQuerier(t), tx: mocks.NewMockQuerier(t)}
+		q.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+		q.tx.EXPECT().TransitionIngestionJobStatus(mock.Anything, db.TransitionIngestionJobStatusParams{
+			ID: jobID, FromStatus: "staged", ToStatus: "confirmed",
+		}).Return(db.IngestionJob{}, nil)
+		q.tx.EXPECT().ListStagedItemsByJob(mock.Anything, jobID).Return([]db.StagedItem{
+			{ID: uuid.New(), IngredientID: uuid.NullUUID{UUID: flour, Valid: true}, Quantity: 1, Unit: "kg"},
+			{ID: uuid.New(), IngredientID: uuid.NullUUID{UUID: sugar, Valid: true}, Quantity: 2, Unit: "kg"},
+		}, nil)
+		q.tx.EXPECT().ListPantryItemsByIngredients(mock.Anything, mock.Anything).Return(nil, nil)
+		return q, NewIngestService(q, NewMockDictionaryResolver(t), NewMockLLMExtractor(t)), NewPantryService(q)
+	}
+
+	t.Run("failure rolls back", func(t *testing.T) {
+		t.Parallel()
+		q, ingestSvc, pantrySvc := setup(t)
+		hook := &recordingConfirmHook{}
+		ingestSvc.SetConfirmHook(hook)
+		q.tx.EXPECT().UpsertPantryItems(mock.Anything, mock.Anything).Return(nil, errors.New("connection reset"))
+
+		_, err := ingestSvc.ConfirmJob(context.Background(), jobID, pantrySvc, nil)
+		require.Error(t, err)
+		assert.True(t, q.rolled)
+		assert.False(t, q.committed)
+		assert.Empty(t, hook.jobIDs)
+	})
+
+	t.Run("success commits with the status update", func(t *testing.T) {
+		t.Parallel()
+		q, ingestSvc, pantrySvc := setup(t)
+		q.tx.EXPECT().UpsertPantryItems(mock.Anything, mock.Anything).Return([]db.PantryItem{
+			{ID: uuid.New(), IngredientID: sugar},
+			{ID: uuid.New(), IngredientID: flour},
+		}, nil)
+		results, err := ingestSvc.ConfirmJob(context.Background(), jobID, pantrySvc, nil)
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.True(t, q.committed)
+	})
+}
+
+func TestConfirmJob_RejectsUnknownOverrideIngredient(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	lookup := NewMockIngredientLookup(t)
+	ingestSvc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	pantrySvc := NewPantryService(mockQ)
+	pantrySvc.SetIngredientValidator(NewIngredientValidator(lookup, 0))
+
+	jobID := uuid.New()
+	bogusID := uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, bogusID).Return(clients.Ingredient{}, clients.ErrIngredientNotFound)
+
+	_, err := ingestSvc.ConfirmJob(context.Background(), jobID, pantrySvc, []OverrideItem{
+		{StagedItemID: uuid.New(), IngredientID: &bogusID},
+	})
+	require.ErrorIs(t, err, ErrUnknownIngredient)
+}
+
+func TestConfirmJob_RejectsOutOfRangeOverrideQuantity(t *testing.T) {
+	t.Parallel()
+
+	for _, quantity := range []float64{-1, 1e12} {
+		mockQ := mocks.NewMockQuerier(t)
+		ingestSvc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+		jobID := uuid.New()
+		mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+
+		// Rejected before the transaction, so nothing is written.
+		_, err := ingestSvc.ConfirmJob(context.Background(), jobID, NewPantryService(mockQ), []OverrideItem{
+			{StagedItemID: uuid.New(), Quantity: &quantity},
+		})
+		require.ErrorIs(t, err, ErrInvalidOverride, "quantity %g", quantity)
+	}
 }
 
 func TestConfirmJob_WrongStatusError(t *testing.T) {
@@ -282,7 +715,199 @@ func TestConfirmJob_WrongStatusError(t *testing.T) {
 		CreatedAt: now,
 	}, nil)
 
-	err := ingestSvc.ConfirmJob(context.Background(), jobID, pantrySvc, nil)
+	_, err := ingestSvc.ConfirmJob(context.Background(), jobID, pantrySvc, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "must be staged to confirm")
 }
+
+func TestOpenAIExtractor_UsesBaseURL(t *testing.T) {
+	t.Parallel()
+
+	scenario := llmserver.HappyPath(ExtractionResponse{
+		Items: []ExtractedItem{{RawText: "2 cups flour", Name: "flour", Quantity: 2, Unit: "cup", Confidence: 0.9}},
+	})
+	scenario.TotalTokens = 42
+	server := llmserver.New(t, llmserver.WithDefault(scenario))
+
+	extractor := NewOpenAIExtractor("sk-test", "gpt-test", WithBaseURL(server.URL+"/v1/"))
+	resp, err := extractor.Extract(context.Background(), "2 cups flour")
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, "flour", resp.Items[0].Name)
+	assert.Equal(t, 42, resp.TokensUsed)
+
+	reqs := server.Requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "Bearer sk-test", reqs[0].Authorization)
+	assert.Equal(t, "gpt-test", reqs[0].Model)
+	assert.Equal(t, "2 cups flour", reqs[0].LastUserText())
+}
+
+func TestLocalExtractor_NoAPIKey(t *testing.T) {
+	t.Parallel()
+
+	server := llmserver.New(t, llmserver.WithDefault(llmserver.ServerError()))
+	extractor := NewLocalExtractor("", "llama3.1", WithBaseURL(server.URL))
+	_, err := extractor.Extract(context.Background(), "2 cups flour")
+	require.ErrorContains(t, err, "local status 500", "errors name the local server, not OpenAI")
+
+	reqs := server.Requests()
+	require.Len(t, reqs, 1)
+	assert.Empty(t, reqs[0].Authorization, "no bearer token without a key")
+	assert.Equal(t, "llama3.1", reqs[0].Model)
+}
+
+func TestAzureOpenAIExtractor_RoutesByDeployment(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/openai/deployments/pantry-extract/chat/completions", r.URL.Path)
+		assert.Equal(t, "2024-10-21", r.URL.Query().Get("api-version"))
+		assert.Equal(t, "azure-key", r.Header.Get("api-key"))
+		assert.Empty(t, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		reply := `{"choices":[{"message":{"content":"{\"items\":[]}"}}],"usage":{"total_tokens":7}}`
+		io.WriteString(w, reply) //nolint:errcheck // test server
+	}))
+	t.Cleanup(server.Close)
+
+	extractor := NewAzureOpenAIExtractor(server.URL+"/", "2024-10-21", "azure-key", "pantry-extract")
+	resp, err := extractor.Extract(context.Background(), "2 cups flour")
+	require.NoError(t, err)
+	assert.Equal(t, 7, resp.TokensUsed)
+}
+
+func TestOpenAIExtractor_Scenarios(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		scenario llmserver.Scenario
+		timeout  time.Duration
+		wantErr  string
+	}{
+		{name: "malformed JSON", scenario: llmserver.MalformedJSON(), wantErr: "parse extraction json"},
+		{name: "rate limited", scenario: llmserver.RateLimited(time.Second), wantErr: "openai status 429"},
+		{
+			name:     "slow response",
+			scenario: llmserver.Slow(time.Second, llmserver.HappyPath(ExtractionResponse{})),
+			timeout:  50 * time.Millisecond,
+			wantErr:  "openai request",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := llmserver.New(t, llmserver.WithDefault(tt.scenario))
+			extractor := NewOpenAIExtractor("sk-test", "gpt-test", WithBaseURL(server.URL))
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			_, err := extractor.Extract(ctx, "2 cups flour")
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestOpenAIExtractor_DryRun(t *testing.T) {
+	t.Parallel()
+
+	server := llmserver.New(t,
+		llmserver.WithSequence(llmserver.HappyPath(ExtractionResponse{}), llmserver.ServerError()))
+	extractor := NewOpenAIExtractor("sk-test", "gpt-test", WithBaseURL(server.URL), WithVisionModel("gpt-vision"))
+
+	err := extractor.DryRun(context.Background())
+	require.ErrorContains(t, err, "model gpt-vision")
+
+	reqs := server.Requests()
+	require.Len(t, reqs, 2)
+	assert.Equal(t, "gpt-test", reqs[0].Model)
+	assert.Equal(t, "{}", reqs[0].LastUserText())
+	assert.Equal(t, "gpt-vision", reqs[1].Model)
+}
+
+func TestConfirmJob_MatchesPantryUnits(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	ingestSvc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	pantrySvc := NewPantryService(mockQ)
+
+	jobID := uuid.New()
+	rice, parsley, basil := uuid.New(), uuid.New(), uuid.New()
+	parsleyItem, basilItem := uuid.New(), uuid.New()
+	staged := []db.StagedItem{
+		{ID: uuid.New(), IngredientID: uuid.NullUUID{UUID: rice, Valid: true}, Quantity: 500, Unit: "g"},
+		{ID: parsleyItem, IngredientID: uuid.NullUUID{UUID: parsley, Valid: true}, Quantity: 1, Unit: "bunch"},
+		{ID: basilItem, IngredientID: uuid.NullUUID{UUID: basil, Valid: true}, Quantity: 1, Unit: "bunch"},
+	}
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+	mockQ.EXPECT().ListStagedItemsByJob(mock.Anything, jobID).Return(staged, nil)
+	mockQ.EXPECT().ListPantryItemsByIngredients(mock.Anything, []uuid.UUID{rice, parsley, basil}).
+		Return([]db.PantryItem{
+			{IngredientID: rice, Quantity: 1, Unit: "kg"},
+			{IngredientID: parsley, Quantity: 30, Unit: "g"},
+			{IngredientID: basil, Quantity: 20, Unit: "g"},
+		}, nil)
+
+	_, err := ingestSvc.ConfirmJob(context.Background(), jobID, pantrySvc, nil)
+	var conflict *UnitConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.ErrorIs(t, err, ErrUnitConflict)
+	require.Len(t, conflict.Conflicts, 2, "nothing is written while any conflict remains")
+	assert.Equal(t, parsleyItem, conflict.Conflicts[0].StagedItemID)
+	assert.Equal(t, "bunch", conflict.Conflicts[0].StagedUnit)
+	assert.Equal(t, "g", conflict.Conflicts[0].PantryUnit)
+
+	// Overriding the unit replaces it; the gram staging converts to kg.
+	mockQ.EXPECT().UpsertPantryItems(mock.Anything, db.UpsertPantryItemsParams{
+		IngredientIds:   []uuid.UUID{rice, parsley, basil},
+		Quantities:      []float64{0.5, 1, 25},
+		Units:           []string{"kg", "bunch", "g"},
+		ExpiresAt:       make([]time.Time, 3),
+		HasExpiry:       make([]bool, 3),
+		QuantityUnknown: make([]bool, 3),
+	}).Return([]db.PantryItem{{IngredientID: rice}, {IngredientID: parsley}, {IngredientID: basil}}, nil)
+
+	bunch, grams, qty := "bunch", "g", 25.0
+	_, err = ingestSvc.ConfirmJob(context.Background(), jobID, pantrySvc, []OverrideItem{
+		{StagedItemID: parsleyItem, Unit: &bunch},
+		{StagedItemID: basilItem, Unit: &grams, Quantity: &qty},
+	})
+	require.NoError(t, err)
+}
+
+func TestConfirmJob_ClosedDuringConfirm(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	ingestSvc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	pantrySvc := NewPantryService(mockQ)
+
+	jobID := uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+	// The job was rejected or confirmed by another request after it was
+	// read, so the transition matches nothing and nothing is written.
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, sql.ErrNoRows)
+
+	_, err := ingestSvc.ConfirmJob(context.Background(), jobID, pantrySvc, nil)
+	require.ErrorIs(t, err, ErrJobNotStaged)
+	assert.NotErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestFailureReason_Truncates(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, FailureReason(nil))
+	assert.Equal(t, "llm extraction: boom", FailureReason(fmt.Errorf("llm extraction: %w", errors.New("boom"))))
+
+	long := FailureReason(errors.New(strings.Repeat("é", maxFailureReasonLen)))
+	assert.True(t, utf8.ValidString(long))
+	assert.LessOrEqual(t, len(long), maxFailureReasonLen+len("…"))
+}
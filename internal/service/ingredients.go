@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+// DefaultIngredientCacheTTL is how long a confirmed ingredient ID is trusted
+// before the Dictionary is asked again.
+const DefaultIngredientCacheTTL = 10 * time.Minute
+
+// ErrUnknownIngredient is returned when the Dictionary has no ingredient with
+// a caller-supplied ID.
+var ErrUnknownIngredient = errors.New("unknown ingredient_id")
+
+// IngredientValidator checks caller-supplied ingredient IDs against the
+// Dictionary. Known IDs are cached; unknown ones are not, so an ingredient
+// created moments later is accepted. If the Dictionary cannot be reached the
+// ID is accepted and a warning logged, so writes do not depend on it being up.
+type IngredientValidator struct {
+	lookup IngredientLookup
+	ttl    time.Duration
+	now    func() time.Time
+	log    *slog.Logger
+
+	mu    sync.Mutex
+	known map[uuid.UUID]time.Time
+}
+
+func NewIngredientValidator(lookup IngredientLookup, ttl time.Duration) *IngredientValidator {
+	if ttl <= 0 {
+		ttl = DefaultIngredientCacheTTL
+	}
+	return &IngredientValidator{
+		lookup: lookup,
+		ttl:    ttl,
+		now:    time.Now,
+		log:    logging.For("ingredients"),
+		known:  make(map[uuid.UUID]time.Time),
+	}
+}
+
+// Validate returns ErrUnknownIngredient if the Dictionary reports id missing.
+func (v *IngredientValidator) Validate(ctx context.Context, id uuid.UUID) error {
+	v.mu.Lock()
+	expires, ok := v.known[id]
+	v.mu.Unlock()
+	if ok && v.now().Before(expires) {
+		return nil
+	}
+
+	_, err := v.lookup.GetIngredient(ctx, id)
+	switch {
+	case errors.Is(err, clients.ErrIngredientNotFound):
+		return fmt.Errorf("%w: %s", ErrUnknownIngredient, id)
+	case err != nil:
+		v.log.WarnContext(ctx, "could not verify ingredient_id; accepting", "ingredient_id", id, "error", err)
+		return nil
+	}
+
+	v.mu.Lock()
+	v.known[id] = v.now().Add(v.ttl)
+	v.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+)
+
+func TestIngredientValidator_CachesKnownIDs(t *testing.T) {
+	t.Parallel()
+
+	lookup := NewMockIngredientLookup(t)
+	id := uuid.New()
+	lookup.EXPECT().GetIngredient(mock.Anything, id).Return(clients.Ingredient{ID: id}, nil).Once()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	v := NewIngredientValidator(lookup, time.Minute)
+	v.now = func() time.Time { return now }
+
+	require.NoError(t, v.Validate(context.Background(), id))
+	require.NoError(t, v.Validate(context.Background(), id))
+
+	// After the TTL the Dictionary is asked again.
+	now = now.Add(2 * time.Minute)
+	lookup.EXPECT().GetIngredient(mock.Anything, id).Return(clients.Ingredient{ID: id}, nil).Once()
+	require.NoError(t, v.Validate(context.Background(), id))
+}
+
+func TestIngredientValidator_RejectsUnknown(t *testing.T) {
+	t.Parallel()
+
+	lookup := NewMockIngredientLookup(t)
+	id := uuid.New()
+	lookup.EXPECT().GetIngredient(mock.Anything, id).Return(clients.Ingredient{}, clients.ErrIngredientNotFound).Twice()
+
+	v := NewIngredientValidator(lookup, 0)
+	require.ErrorIs(t, v.Validate(context.Background(), id), ErrUnknownIngredient)
+	// Unknown IDs are not cached.
+	require.ErrorIs(t, v.Validate(context.Background(), id), ErrUnknownIngredient)
+}
+
+func TestIngredientValidator_AcceptsWhenDictionaryDown(t *testing.T) {
+	t.Parallel()
+
+	lookup := NewMockIngredientLookup(t)
+	lookup.EXPECT().GetIngredient(mock.Anything, mock.Anything).
+		Return(clients.Ingredient{}, errors.New("connection refused"))
+
+	assert.NoError(t, NewIngredientValidator(lookup, 0).Validate(context.Background(), uuid.New()))
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
 )
 
@@ -11,7 +13,37 @@ type DictionaryResolver interface {
 	Resolve(ctx context.Context, rawName string) (clients.ResolveResult, error)
 }
 
+// IngredientLookup abstracts fetching canonical ingredients by ID for testing.
+type IngredientLookup interface {
+	GetIngredient(ctx context.Context, id uuid.UUID) (clients.Ingredient, error)
+}
+
+// IngredientLister abstracts listing every canonical ingredient for testing.
+type IngredientLister interface {
+	ListIngredients(ctx context.Context) ([]clients.Ingredient, error)
+}
+
 // LLMExtractor abstracts LLM-based text extraction for testing.
 type LLMExtractor interface {
 	Extract(ctx context.Context, text string) (*ExtractionResponse, error)
 }
+
+// ImageExtractor is implemented by extractors that can also read a photo,
+// such as OpenAIExtractor with a vision-capable model. jobType says what the
+// photo shows: JobTypeReceiptImage or JobTypeFridgePhoto.
+type ImageExtractor interface {
+	ExtractImage(ctx context.Context, jobType, mediaType string, image []byte) (*ExtractionResponse, error)
+}
+
+// JobStatusHook is notified after an ingest job's status changes to staged,
+// confirmed, failed or expired, or is forced by an operator. Implementations
+// must not block.
+type JobStatusHook interface {
+	OnJobStatus(ctx context.Context, jobID uuid.UUID, status string)
+}
+
+// ConfirmHook is notified after an ingest job is confirmed. Implementations
+// must not block; the confirm response does not wait for them.
+type ConfirmHook interface {
+	OnConfirm(ctx context.Context, jobID uuid.UUID, changedItemIDs []uuid.UUID)
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+var (
+	// ErrJobCancelled is the cause of a cancelled job's context, and is
+	// returned by processJob once the job has been cancelled.
+	ErrJobCancelled = errors.New("job cancelled")
+	// ErrJobNotCancellable is returned when cancelling a job that has
+	// already been extracted, confirmed, failed or cancelled.
+	ErrJobNotCancellable = errors.New("only pending or processing jobs can be cancelled")
+)
+
+// jobTracker holds the cancel func of every job this process is extracting,
+// so CancelJob can abort the LLM call instead of waiting it out.
+type jobTracker struct {
+	mu      sync.Mutex
+	running map[uuid.UUID]*trackedJob
+	// early holds jobs cancelled while still queued in memory; they are
+	// cancelled as soon as they start.
+	early map[uuid.UUID]bool
+}
+
+// trackedJob is one run of a job. done is closed when the run returns.
+type trackedJob struct {
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+}
+
+// track returns a context that CancelJob cancels for jobID, and a func to
+// call once the job is done.
+func (t *jobTracker) track(ctx context.Context, jobID uuid.UUID) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	run := &trackedJob{cancel: cancel, done: make(chan struct{})}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.early[jobID] {
+		delete(t.early, jobID)
+		cancel(ErrJobCancelled)
+	} else {
+		if t.running == nil {
+			t.running = make(map[uuid.UUID]*trackedJob)
+		}
+		t.running[jobID] = run
+	}
+	return ctx, func() {
+		t.mu.Lock()
+		// A re-run may have replaced this run already.
+		if t.running[jobID] == run {
+			delete(t.running, jobID)
+		}
+		t.mu.Unlock()
+		cancel(nil)
+		close(run.done)
+	}
+}
+
+// cancel aborts jobID if it is running here and returns a channel closed
+// once that run has returned, or nil if none is running. Otherwise, when
+// queued is set, the job is waiting in this process's memory and is
+// remembered until it starts.
+func (t *jobTracker) cancel(jobID uuid.UUID, queued bool) <-chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if run, ok := t.running[jobID]; ok {
+		run.cancel(ErrJobCancelled)
+		return run.done
+	}
+	if queued {
+		if t.early == nil {
+			t.early = make(map[uuid.UUID]bool)
+		}
+		t.early[jobID] = true
+	}
+	return nil
+}
+
+// CancelJob marks a pending or processing job cancelled and aborts its
+// extraction if this process is running it. A job queued in RabbitMQ or
+// Postgres is skipped when its turn comes. A worker in another process that
+// is already extracting it finishes the LLM call, but its result is
+// discarded, since a cancelled job's status never changes again.
+func (s *IngestService) CancelJob(ctx context.Context, jobID uuid.UUID) (db.IngestionJob, error) {
+	job, err := s.q.CancelIngestionJob(ctx, jobID)
+	if errors.Is(err, sql.ErrNoRows) {
+		existing, err := s.q.GetIngestionJob(ctx, jobID)
+		if err != nil {
+			return db.IngestionJob{}, err
+		}
+		return db.IngestionJob{}, fmt.Errorf("%w: job is %s", ErrJobNotCancellable, existing.Status)
+	}
+	if err != nil {
+		return db.IngestionJob{}, err
+	}
+	// Without a job queue every accepted job runs in this process.
+	s.jobs.cancel(jobID, s.jobQueue == nil)
+	s.log.InfoContext(ctx, "ingest job cancelled", "job_id", jobID)
+	s.jobStatusChanged(ctx, jobID, job.Status)
+	return job, nil
+}
+
+// jobCancelled reports whether err, from processJob, means the job was
+// cancelled. If so it discards anything staged before the cancellation
+// landed.
+func (s *IngestService) jobCancelled(ctx context.Context, jobID uuid.UUID, err error) bool {
+	if !errors.Is(err, ErrJobCancelled) {
+		return false
+	}
+	s.log.InfoContext(ctx, "ingest job stopped: cancelled", "job_id", jobID)
+	if err := s.q.DeleteStagedItemsByJob(context.WithoutCancel(ctx), jobID); err != nil {
+		s.log.ErrorContext(ctx, "discard cancelled job's staged items failed", "job_id", jobID, "error", err)
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestCancelJob_AbortsRunningExtraction(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockLLM := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), mockLLM)
+	jobID := uuid.New()
+
+	started := make(chan struct{})
+	mockLLM.EXPECT().Extract(mock.Anything, "eggs").
+		RunAndReturn(func(ctx context.Context, _ string) (*ExtractionResponse, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, toProcessing(jobID)).
+		Return(db.IngestionJob{ID: jobID, Status: "processing"}, nil)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockQ.EXPECT().CancelIngestionJob(mock.Anything, jobID).
+		Return(db.IngestionJob{ID: jobID, Status: "cancelled"}, nil)
+	mockQ.EXPECT().DeleteStagedItemsByJob(mock.Anything, jobID).Return(nil)
+
+	done := make(chan error, 1)
+	go func() { done <- svc.runJob(ingestTask{jobID: jobID, rawInput: "eggs"}) }()
+	<-started
+	job, err := svc.CancelJob(context.Background(), jobID)
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", job.Status)
+	require.NoError(t, <-done, "a cancelled job is not marked failed")
+}
+
+func TestCancelJob_BeforeStartSkipsExtraction(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	jobID := uuid.New()
+	mockQ.EXPECT().CancelIngestionJob(mock.Anything, jobID).
+		Return(db.IngestionJob{ID: jobID, Status: "cancelled"}, nil)
+	mockQ.EXPECT().DeleteStagedItemsByJob(mock.Anything, jobID).Return(nil)
+
+	_, err := svc.CancelJob(context.Background(), jobID)
+	require.NoError(t, err)
+	require.NoError(t, svc.runJob(ingestTask{jobID: jobID, rawInput: "eggs"}))
+}
+
+func TestCancelJob_NotCancellable(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewIngestService(mockQ, nil, nil)
+	staged, missing := uuid.New(), uuid.New()
+	mockQ.EXPECT().CancelIngestionJob(mock.Anything, mock.Anything).Return(db.IngestionJob{}, sql.ErrNoRows)
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, staged).Return(db.IngestionJob{ID: staged, Status: "staged"}, nil)
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, missing).Return(db.IngestionJob{}, sql.ErrNoRows)
+
+	_, err := svc.CancelJob(context.Background(), staged)
+	require.ErrorIs(t, err, ErrJobNotCancellable)
+	assert.ErrorContains(t, err, "job is staged")
+	_, err = svc.CancelJob(context.Background(), missing)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestProcessJob_CancelledElsewhereIsNotStaged(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockLLM := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), mockLLM)
+	jobID := uuid.New()
+	mockLLM.EXPECT().Extract(mock.Anything, "eggs").Return(&ExtractionResponse{}, nil)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, sql.ErrNoRows)
+
+	require.ErrorIs(t, svc.processJob(context.Background(), jobID, "eggs"), ErrJobCancelled)
+}
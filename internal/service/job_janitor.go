@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+// DefaultStagedJobTTL is how long a job may sit staged before the janitor
+// expires it.
+const DefaultStagedJobTTL = 7 * 24 * time.Hour
+
+// DefaultJobJanitorInterval is how often the janitor looks for stale jobs.
+const DefaultJobJanitorInterval = time.Hour
+
+// JanitorReport is the outcome of one janitor run.
+type JanitorReport struct {
+	StartedAt    time.Time `json:"started_at"`
+	ExpiredJobs  int       `json:"expired_jobs"`
+	DeletedItems int64     `json:"deleted_items"`
+}
+
+// JobJanitor expires staged jobs that were never confirmed. An expired job
+// keeps its row, so history and source stats still count it, but its staged
+// items are deleted.
+type JobJanitor struct {
+	q          db.Querier
+	ttl        time.Duration
+	statusHook JobStatusHook
+	log        *slog.Logger
+	now        func() time.Time
+
+	mu           sync.Mutex
+	last         *JanitorReport
+	totalJobs    int
+	totalDeleted int64
+}
+
+// NewJobJanitor returns a janitor that expires jobs staged more than ttl
+// ago.
+func NewJobJanitor(q db.Querier, ttl time.Duration) *JobJanitor {
+	return &JobJanitor{
+		q:   q,
+		ttl: ttl,
+		log: logging.For("job_janitor"),
+		now: time.Now,
+	}
+}
+
+// SetJobStatusHook registers a hook invoked for each job the janitor
+// expires.
+func (j *JobJanitor) SetJobStatusHook(h JobStatusHook) {
+	j.statusHook = h
+}
+
+// Expire marks every job staged before the TTL expired and deletes its
+// staged items.
+func (j *JobJanitor) Expire(ctx context.Context) (JanitorReport, error) {
+	report := JanitorReport{StartedAt: j.now()}
+	rows, err := j.q.ExpireStagedJobs(ctx, report.StartedAt.Add(-j.ttl))
+	if err != nil {
+		return JanitorReport{}, fmt.Errorf("expire staged jobs: %w", err)
+	}
+	for _, row := range rows {
+		report.ExpiredJobs++
+		report.DeletedItems += row.StagedItems
+		if j.statusHook != nil {
+			j.statusHook.OnJobStatus(ctx, row.ID, "expired")
+		}
+	}
+
+	j.mu.Lock()
+	j.last = &report
+	j.totalJobs += report.ExpiredJobs
+	j.totalDeleted += report.DeletedItems
+	j.mu.Unlock()
+	return report, nil
+}
+
+// RunExpire expires stale jobs every interval until ctx is cancelled.
+func (j *JobJanitor) RunExpire(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := j.Expire(ctx)
+			if err != nil {
+				j.log.ErrorContext(ctx, "job janitor failed", "error", err)
+				continue
+			}
+			if report.ExpiredJobs > 0 {
+				j.log.InfoContext(ctx, "expired stale staged jobs",
+					"jobs", report.ExpiredJobs, "staged_items", report.DeletedItems)
+			}
+		}
+	}
+}
+
+// WriteMetrics writes the last run and the totals since startup as
+// Prometheus gauges. The series are the same in the OpenMetrics format.
+// Nothing is written before the first run.
+func (j *JobJanitor) WriteMetrics(w io.Writer) error {
+	j.mu.Lock()
+	last, totalJobs, totalDeleted := j.last, j.totalJobs, j.totalDeleted
+	j.mu.Unlock()
+	if last == nil {
+		return nil
+	}
+
+	gauge := func(name, help string) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	}
+	gauge("pantry_job_janitor_last_run_timestamp_seconds", "When the job janitor last ran.")
+	fmt.Fprintf(w, "pantry_job_janitor_last_run_timestamp_seconds %d\n", last.StartedAt.Unix())
+	gauge("pantry_job_janitor_expired_jobs", "Staged jobs expired by the last janitor run.")
+	fmt.Fprintf(w, "pantry_job_janitor_expired_jobs %d\n", last.ExpiredJobs)
+	gauge("pantry_job_janitor_deleted_items", "Staged items deleted by the last janitor run.")
+	fmt.Fprintf(w, "pantry_job_janitor_deleted_items %d\n", last.DeletedItems)
+	gauge("pantry_job_janitor_expired_jobs_since_start", "Staged jobs expired since the process started.")
+	fmt.Fprintf(w, "pantry_job_janitor_expired_jobs_since_start %d\n", totalJobs)
+	gauge("pantry_job_janitor_deleted_items_since_start", "Staged items deleted since the process started.")
+	_, err := fmt.Fprintf(w, "pantry_job_janitor_deleted_items_since_start %d\n", totalDeleted)
+	return err
+}
@@ -0,0 +1,47 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/testutil"
+)
+
+func TestExpireStagedJobs_CountsFromStaging(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	ctx := context.Background()
+
+	job, err := q.CreateIngestionJob(ctx, db.CreateIngestionJobParams{
+		Type: "text_blob", RawInput: "2 eggs", Source: "api", Priority: "background",
+	})
+	require.NoError(t, err)
+	// Queued for a month, e.g. behind a provider outage, then staged now.
+	_, err = sqlDB.ExecContext(ctx,
+		`UPDATE ingestion_jobs SET created_at = now() - interval '30 days' WHERE id = $1`, job.ID)
+	require.NoError(t, err)
+	_, err = q.TransitionIngestionJobStatus(ctx, db.TransitionIngestionJobStatusParams{
+		ToStatus: "staged", ID: job.ID, FromStatus: "pending",
+	})
+	require.NoError(t, err)
+
+	cutoff := time.Now().Add(-DefaultStagedJobTTL)
+	rows, err := q.ExpireStagedJobs(ctx, cutoff)
+	require.NoError(t, err)
+	assert.Empty(t, rows, "a freshly staged job keeps its whole TTL")
+
+	_, err = sqlDB.ExecContext(ctx,
+		`UPDATE ingestion_jobs SET staged_at = now() - interval '30 days' WHERE id = $1`, job.ID)
+	require.NoError(t, err)
+	rows, err = q.ExpireStagedJobs(ctx, cutoff)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, job.ID, rows[0].ID)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+type recordingStatusHook struct {
+	statuses map[uuid.UUID]string
+}
+
+func (h *recordingStatusHook) OnJobStatus(_ context.Context, jobID uuid.UUID, status string) {
+	h.statuses[jobID] = status
+}
+
+func TestJobJanitor_Expire(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	janitor := NewJobJanitor(mockQ, 48*time.Hour)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	janitor.now = func() time.Time { return now }
+	hook := &recordingStatusHook{statuses: map[uuid.UUID]string{}}
+	janitor.SetJobStatusHook(hook)
+
+	a, b := uuid.New(), uuid.New()
+	mockQ.EXPECT().ExpireStagedJobs(mock.Anything, now.Add(-48*time.Hour)).
+		Return([]db.ExpireStagedJobsRow{{ID: a, StagedItems: 3}, {ID: b}}, nil).Twice()
+
+	var metrics strings.Builder
+	require.NoError(t, janitor.WriteMetrics(&metrics))
+	assert.Empty(t, metrics.String(), "nothing is reported before the first run")
+
+	report, err := janitor.Expire(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, JanitorReport{StartedAt: now, ExpiredJobs: 2, DeletedItems: 3}, report)
+	assert.Equal(t, map[uuid.UUID]string{a: "expired", b: "expired"}, hook.statuses)
+
+	_, err = janitor.Expire(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, janitor.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), "pantry_job_janitor_expired_jobs 2\n")
+	assert.Contains(t, metrics.String(), "pantry_job_janitor_deleted_items_since_start 6\n")
+}
+
+func TestJobJanitor_ExpireError(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	janitor := NewJobJanitor(mockQ, DefaultStagedJobTTL)
+	mockQ.EXPECT().ExpireStagedJobs(mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	_, err := janitor.Expire(context.Background())
+	require.Error(t, err)
+
+	var metrics strings.Builder
+	require.NoError(t, janitor.WriteMetrics(&metrics))
+	assert.Empty(t, metrics.String())
+}
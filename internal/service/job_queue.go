@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+// enqueueTimeout bounds publishing a job to the queue on the request path.
+const enqueueTimeout = 5 * time.Second
+
+// IngestJobQueue durably queues ingest jobs so they survive a restart and
+// can be processed by any instance.
+type IngestJobQueue interface {
+	PublishIngestRequested(ctx context.Context, jobID uuid.UUID) error
+}
+
+// SetJobQueue makes ProcessJobAsync publish jobs to q instead of running
+// them in process. Something must consume q and call ProcessQueuedJob.
+func (s *IngestService) SetJobQueue(q IngestJobQueue) {
+	s.jobQueue = q
+}
+
+// enqueueJob publishes a job. It is queued even while the LLM provider is
+// unhealthy, since ProcessQueuedJob holds it back; the caller is still told
+// it is deferred.
+func (s *IngestService) enqueueJob(ctx context.Context, jobID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), enqueueTimeout)
+	defer cancel()
+	if err := s.jobQueue.PublishIngestRequested(ctx, jobID); err != nil {
+		return fmt.Errorf("enqueue ingest job: %w", err)
+	}
+	if s.health != nil && !s.health.Healthy() {
+		return ErrIngestDeferred
+	}
+	return nil
+}
+
+// ProcessQueuedJob runs a job delivered by the job queue, on the worker pool
+// when one is started. Jobs that are gone or no longer pending are skipped,
+// so a redelivered message is harmless. While the LLM provider is unhealthy
+// it returns ErrIngestDeferred without running the job. A failure is
+// returned for the queue to retry; only on the final attempt is the job
+// marked failed.
+func (s *IngestService) ProcessQueuedJob(ctx context.Context, jobID uuid.UUID, final bool) error {
+	job, err := s.q.GetIngestionJob(ctx, jobID)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.log.WarnContext(ctx, "queued ingest job no longer exists; skipping", "job_id", jobID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get job: %w", err)
+	}
+	if job.Status != "pending" {
+		s.log.InfoContext(ctx, "queued ingest job already processed; skipping",
+			"job_id", jobID, "status", job.Status)
+		return nil
+	}
+	if s.health != nil && !s.health.Healthy() {
+		return ErrIngestDeferred
+	}
+	// Claim the job as ClaimIngestionJob does, so that of two deliveries of
+	// one message only the first runs it.
+	job, err = s.q.TransitionIngestionJobStatus(ctx, db.TransitionIngestionJobStatusParams{
+		ToStatus:   "processing",
+		ID:         jobID,
+		FromStatus: "pending",
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		s.log.InfoContext(ctx, "queued ingest job claimed by another consumer; skipping", "job_id", jobID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("claim job: %w", err)
+	}
+
+	// A claimed job is run to completion even if ctx ends meanwhile, so it
+	// is not stranded in processing, and a failure puts it back to pending
+	// for the next delivery.
+	claimedCtx := context.WithoutCancel(ctx)
+	// A failed earlier attempt may have staged some items.
+	if err := s.q.DeleteStagedItemsByJob(claimedCtx, jobID); err != nil {
+		err = fmt.Errorf("discard staged items: %w", err)
+		s.retryClaimedJob(claimedCtx, jobID, final, err)
+		return err
+	}
+
+	done := make(chan error, 1)
+	task := ingestTask{
+		jobID: jobID, rawInput: job.RawInput, priority: job.Priority, done: done,
+		trace: trace.SpanContextFromContext(ctx),
+	}
+	if s.pool == nil {
+		err = s.runJob(task)
+	} else {
+		select {
+		case s.pool.queueFor(job.Priority) <- task:
+		case <-ctx.Done():
+			// Never started, so it is not an attempt.
+			s.retryClaimedJob(claimedCtx, jobID, false, ctx.Err())
+			return ctx.Err()
+		}
+		err = <-done
+	}
+	if err != nil {
+		s.retryClaimedJob(claimedCtx, jobID, final, err)
+	}
+	return err
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+type recordingJobQueue struct {
+	jobs []uuid.UUID
+	err  error
+}
+
+func (q *recordingJobQueue) PublishIngestRequested(_ context.Context, jobID uuid.UUID) error {
+	q.jobs = append(q.jobs, jobID)
+	return q.err
+}
+
+func TestProcessJobAsync_PublishesToJobQueue(t *testing.T) {
+	t.Parallel()
+
+	svc := NewIngestService(mocks.NewMockQuerier(t), NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	queue := &recordingJobQueue{}
+	svc.SetJobQueue(queue)
+
+	jobID := uuid.New()
+	require.NoError(t, svc.ProcessJobAsync(context.Background(), jobID, "milk", PriorityInteractive))
+	assert.Equal(t, []uuid.UUID{jobID}, queue.jobs)
+
+	queue.err = errors.New("outbox write failed")
+	err := svc.ProcessJobAsync(context.Background(), uuid.New(), "eggs", PriorityInteractive)
+	assert.ErrorContains(t, err, "enqueue ingest job")
+}
+
+func TestProcessQueuedJob_SkipsJobsNotPending(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+
+	staged, gone, raced := uuid.New(), uuid.New(), uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, staged).Return(db.IngestionJob{ID: staged, Status: "staged"}, nil)
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, gone).Return(db.IngestionJob{}, sql.ErrNoRows)
+	// A second delivery read the job as pending, but the first claimed it.
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, raced).Return(db.IngestionJob{ID: raced, Status: "pending"}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, toProcessing(raced)).
+		Return(db.IngestionJob{}, sql.ErrNoRows)
+
+	require.NoError(t, svc.ProcessQueuedJob(context.Background(), staged, false))
+	require.NoError(t, svc.ProcessQueuedJob(context.Background(), gone, false))
+	require.NoError(t, svc.ProcessQueuedJob(context.Background(), raced, false))
+}
+
+func TestProcessQueuedJob_MarksFailedOnlyOnFinalAttempt(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockLLM := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), mockLLM)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	svc.StartWorkers(ctx, 1, 1)
+
+	jobID := uuid.New()
+	pending := db.IngestionJob{ID: jobID, Status: "pending", RawInput: "milk"}
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(pending, nil).Times(2)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, toProcessing(jobID)).Return(pending, nil).Times(2)
+	mockQ.EXPECT().DeleteStagedItemsByJob(mock.Anything, jobID).Return(nil).Times(2)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil).Times(2)
+	mockLLM.EXPECT().Extract(mock.Anything, "milk").Return(nil, errors.New("openai timeout")).Times(2)
+
+	// The first failure puts the job back to pending for the redelivery.
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, db.TransitionIngestionJobStatusParams{
+		ToStatus: "pending", ID: jobID, FromStatus: "processing",
+	}).Return(pending, nil).Once()
+	require.ErrorContains(t, svc.ProcessQueuedJob(context.Background(), jobID, false), "openai timeout")
+
+	mockQ.EXPECT().FailIngestionJob(mock.Anything, db.FailIngestionJobParams{
+		ID:            jobID,
+		FailureReason: "llm extraction: openai timeout",
+	}).Return(1, nil).Once()
+	require.ErrorContains(t, svc.ProcessQueuedJob(context.Background(), jobID, true), "openai timeout")
+	assert.Equal(t, int64(2), svc.WorkerStatus().Failed)
+}
+
+func TestProcessQueuedJob_Stages(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockLLM := NewMockLLMExtractor(t)
+	mockDict := NewMockDictionaryResolver(t)
+	svc := NewIngestService(mockQ, mockDict, mockLLM)
+
+	jobID := uuid.New()
+	pending := db.IngestionJob{ID: jobID, Status: "pending", RawInput: "milk"}
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(pending, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, toProcessing(jobID)).Return(pending, nil)
+	mockQ.EXPECT().DeleteStagedItemsByJob(mock.Anything, jobID).Return(nil)
+	mockLLM.EXPECT().Extract(mock.Anything, "milk").Return(&ExtractionResponse{}, nil)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, db.TransitionIngestionJobStatusParams{
+		ToStatus:   "staged",
+		ID:         jobID,
+		FromStatus: "processing",
+	}).Return(db.IngestionJob{}, nil)
+
+	require.NoError(t, svc.ProcessQueuedJob(context.Background(), jobID, false))
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+// recordTimingsTimeout bounds the timings write, which runs even when the
+// job's own context has expired so that timeouts are explained too.
+const recordTimingsTimeout = 5 * time.Second
+
+// JobTimings is where a job's last processing run spent its time.
+// Resolution is the Dictionary lookups for all items; names repeated within
+// a job are memoized and cost close to nothing. Retries counts earlier runs
+// of the same job.
+type JobTimings struct {
+	ExtractionMS    int64     `json:"extraction_ms"`
+	ResolutionMS    int64     `json:"resolution_ms"`
+	ResolutionMaxMS int64     `json:"resolution_max_ms"`
+	ResolvedItems   int       `json:"resolved_items"`
+	TotalMS         int64     `json:"total_ms"`
+	Retries         int       `json:"retries"`
+	RecordedAt      time.Time `json:"recorded_at"`
+}
+
+// jobTimings accumulates one processing run.
+type jobTimings struct {
+	start         time.Time
+	extraction    time.Duration
+	resolution    time.Duration
+	resolutionMax time.Duration
+	resolved      int
+}
+
+func (t *jobTimings) addResolution(d time.Duration) {
+	t.resolution += d
+	t.resolutionMax = max(t.resolutionMax, d)
+	t.resolved++
+}
+
+// recordTimings stores t for the job. Failures are logged; timings are a
+// support aid and never fail the job.
+func (s *IngestService) recordTimings(ctx context.Context, jobID uuid.UUID, t jobTimings) {
+	total := time.Since(t.start)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimingsTimeout)
+	defer cancel()
+	if err := s.q.UpsertIngestionJobTimings(ctx, db.UpsertIngestionJobTimingsParams{
+		JobID:           jobID,
+		ExtractionMs:    t.extraction.Milliseconds(),
+		ResolutionMs:    t.resolution.Milliseconds(),
+		ResolutionMaxMs: t.resolutionMax.Milliseconds(),
+		ResolvedItems:   int32(t.resolved), //nolint:gosec // bounded by the staged item cap
+		TotalMs:         total.Milliseconds(),
+	}); err != nil {
+		s.log.WarnContext(ctx, "failed to record ingest job timings", "job_id", jobID, "error", err)
+	}
+}
+
+// JobTimings returns the timings of the job's last processing run, or nil if
+// it has not been processed yet.
+func (s *IngestService) JobTimings(ctx context.Context, jobID uuid.UUID) (*JobTimings, error) {
+	row, err := s.q.GetIngestionJobTimings(ctx, jobID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &JobTimings{
+		ExtractionMS:    row.ExtractionMs,
+		ResolutionMS:    row.ResolutionMs,
+		ResolutionMaxMS: row.ResolutionMaxMs,
+		ResolvedItems:   int(row.ResolvedItems),
+		TotalMS:         row.TotalMs,
+		Retries:         int(row.Attempts) - 1,
+		RecordedAt:      row.RecordedAt,
+	}, nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestJobTimings(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	processed, pending := uuid.New(), uuid.New()
+	now := time.Now()
+
+	mockQ.EXPECT().GetIngestionJobTimings(mock.Anything, processed).Return(db.IngestionJobTiming{
+		JobID:           processed,
+		Attempts:        3,
+		ExtractionMs:    78000,
+		ResolutionMs:    1200,
+		ResolutionMaxMs: 900,
+		ResolvedItems:   4,
+		TotalMs:         79400,
+		RecordedAt:      now,
+	}, nil)
+	mockQ.EXPECT().GetIngestionJobTimings(mock.Anything, pending).Return(db.IngestionJobTiming{}, sql.ErrNoRows)
+
+	timings, err := svc.JobTimings(context.Background(), processed)
+	require.NoError(t, err)
+	assert.Equal(t, &JobTimings{
+		ExtractionMS:    78000,
+		ResolutionMS:    1200,
+		ResolutionMaxMS: 900,
+		ResolvedItems:   4,
+		TotalMS:         79400,
+		Retries:         2,
+		RecordedAt:      now,
+	}, timings)
+
+	timings, err = svc.JobTimings(context.Background(), pending)
+	require.NoError(t, err)
+	assert.Nil(t, timings)
+}
+
+func TestJobTimings_AddResolution(t *testing.T) {
+	t.Parallel()
+
+	var timings jobTimings
+	timings.addResolution(30 * time.Millisecond)
+	timings.addResolution(120 * time.Millisecond)
+	timings.addResolution(0)
+
+	assert.Equal(t, 150*time.Millisecond, timings.resolution)
+	assert.Equal(t, 120*time.Millisecond, timings.resolutionMax)
+	assert.Equal(t, 3, timings.resolved)
+}
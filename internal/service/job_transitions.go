@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+var (
+	// ErrJobConfirmed is returned when forcing a transition on a confirmed
+	// job; its items are already in the pantry, so its status is final.
+	ErrJobConfirmed = errors.New("confirmed jobs cannot be transitioned")
+	// ErrTransitionNotAllowed is returned for a transition outside
+	// allowedJobTransitions, or when the job's status changed underneath.
+	ErrTransitionNotAllowed = errors.New("transition not allowed")
+	// ErrJobNotFailed is returned when retrying a job that has not failed.
+	ErrJobNotFailed = errors.New("only failed jobs can be retried")
+	// ErrJobNotRejectable is returned when rejecting a job that is not
+	// awaiting review.
+	ErrJobNotRejectable = errors.New("only staged jobs can be rejected")
+)
+
+// allowedJobTransitions lists, per current status, the statuses an operator
+// may force a job into. Moving to pending re-runs extraction, so it is only
+// offered for jobs that are stuck or failed.
+var allowedJobTransitions = map[string][]string{
+	"pending":    {"failed"},
+	"processing": {"failed", "pending"},
+	"staged":     {"failed"},
+	"failed":     {"pending"},
+}
+
+// ForceTransition moves a job from its current status to to, for operators
+// unsticking jobs. The update only applies if the status is unchanged since
+// it was read. A processing job's extraction is aborted if this process is
+// running it; a run elsewhere finds the job moved and discards its items.
+// Moving to pending discards any staged items and re-queues the job for
+// extraction. Every forced transition is logged with reason.
+func (s *IngestService) ForceTransition(
+	ctx context.Context,
+	jobID uuid.UUID,
+	to, reason string,
+) (db.IngestionJob, error) {
+	job, err := s.q.GetIngestionJob(ctx, jobID)
+	if err != nil {
+		return db.IngestionJob{}, err
+	}
+	if job.Status == "confirmed" {
+		return db.IngestionJob{}, ErrJobConfirmed
+	}
+	if !slices.Contains(allowedJobTransitions[job.Status], to) {
+		return db.IngestionJob{}, fmt.Errorf("%w: %s → %s", ErrTransitionNotAllowed, job.Status, to)
+	}
+
+	updated, err := s.q.TransitionIngestionJobStatus(ctx, db.TransitionIngestionJobStatusParams{
+		ToStatus:   to,
+		ID:         jobID,
+		FromStatus: job.Status,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.IngestionJob{}, fmt.Errorf("%w: job status changed from %s", ErrTransitionNotAllowed, job.Status)
+	}
+	if err != nil {
+		return db.IngestionJob{}, err
+	}
+	s.log.WarnContext(ctx, "ingest job transition forced",
+		"job_id", jobID, "from", job.Status, "to", to, "reason", reason)
+	s.jobStatusChanged(ctx, jobID, to)
+
+	if job.Status == "processing" {
+		// Wait for the aborted run to discard its items before a re-run
+		// stages new ones.
+		if done := s.jobs.cancel(jobID, false); done != nil && to == "pending" {
+			select {
+			case <-done:
+			case <-ctx.Done():
+				return db.IngestionJob{}, ctx.Err()
+			}
+		}
+	}
+	if to == "pending" {
+		if err := s.rerunJob(ctx, job); err != nil {
+			return db.IngestionJob{}, err
+		}
+	}
+	return updated, nil
+}
+
+// RetryJob re-runs extraction for a failed job, keeping its ID and history.
+// Items staged by the failed run are discarded first. It returns
+// ErrJobNotFailed for a job in any other status, including one that stopped
+// failing meanwhile.
+func (s *IngestService) RetryJob(ctx context.Context, jobID uuid.UUID) (db.IngestionJob, error) {
+	job, err := s.q.TransitionIngestionJobStatus(ctx, db.TransitionIngestionJobStatusParams{
+		ToStatus:   "pending",
+		ID:         jobID,
+		FromStatus: "failed",
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		existing, err := s.q.GetIngestionJob(ctx, jobID)
+		if err != nil {
+			return db.IngestionJob{}, err
+		}
+		return db.IngestionJob{}, fmt.Errorf("%w: job is %s", ErrJobNotFailed, existing.Status)
+	}
+	if err != nil {
+		return db.IngestionJob{}, err
+	}
+	s.log.InfoContext(ctx, "ingest job retried", "job_id", jobID)
+	s.jobStatusChanged(ctx, jobID, job.Status)
+	if err := s.rerunJob(ctx, job); err != nil {
+		return db.IngestionJob{}, err
+	}
+	return job, nil
+}
+
+// RejectJob marks a staged job rejected: the reviewer looked at it and
+// decided not to confirm it. The status is final, so the job can no longer
+// be confirmed, and listings tell it apart from jobs still awaiting review.
+// Its staged items are kept as a record of what was turned down.
+func (s *IngestService) RejectJob(ctx context.Context, jobID uuid.UUID) (db.IngestionJob, error) {
+	job, err := s.q.TransitionIngestionJobStatus(ctx, db.TransitionIngestionJobStatusParams{
+		ToStatus:   "rejected",
+		ID:         jobID,
+		FromStatus: "staged",
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		existing, err := s.q.GetIngestionJob(ctx, jobID)
+		if err != nil {
+			return db.IngestionJob{}, err
+		}
+		return db.IngestionJob{}, fmt.Errorf("%w: job is %s", ErrJobNotRejectable, existing.Status)
+	}
+	if err != nil {
+		return db.IngestionJob{}, err
+	}
+	s.log.InfoContext(ctx, "ingest job rejected", "job_id", jobID)
+	s.jobStatusChanged(ctx, jobID, job.Status)
+	return job, nil
+}
+
+// rerunJob discards a job's staged items and queues it for extraction
+// again. If it cannot be queued the job is marked failed once more.
+func (s *IngestService) rerunJob(ctx context.Context, job db.IngestionJob) error {
+	if err := s.q.DeleteStagedItemsByJob(ctx, job.ID); err != nil {
+		return fmt.Errorf("discard staged items: %w", err)
+	}
+	err := s.ProcessJobAsync(ctx, job.ID, job.RawInput, job.Priority)
+	if err != nil && !errors.Is(err, ErrIngestDeferred) {
+		s.MarkJobFailed(ctx, job.ID, fmt.Errorf("queue job: %w", err))
+		return err
+	}
+	return nil
+}
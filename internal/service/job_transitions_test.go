@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestForceTransition_RejectsConfirmedAndUnlistedTransitions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want error
+	}{
+		{"confirmed job", "confirmed", "failed", ErrJobConfirmed},
+		{"staged to pending", "staged", "pending", ErrTransitionNotAllowed},
+		{"failed to staged", "failed", "staged", ErrTransitionNotAllowed},
+		{"unknown target", "pending", "done", ErrTransitionNotAllowed},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ := mocks.NewMockQuerier(t)
+			svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+			jobID := uuid.New()
+			mockQ.EXPECT().GetIngestionJob(context.Background(), jobID).
+				Return(db.IngestionJob{ID: jobID, Status: tc.from}, nil)
+
+			_, err := svc.ForceTransition(context.Background(), jobID, tc.to, "test")
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestForceTransition_ConcurrentChange(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	jobID := uuid.New()
+	mockQ.EXPECT().GetIngestionJob(context.Background(), jobID).
+		Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(context.Background(), db.TransitionIngestionJobStatusParams{
+		ToStatus:   "failed",
+		ID:         jobID,
+		FromStatus: "staged",
+	}).Return(db.IngestionJob{}, sql.ErrNoRows)
+
+	_, err := svc.ForceTransition(context.Background(), jobID, "failed", "stuck")
+	assert.ErrorIs(t, err, ErrTransitionNotAllowed)
+}
+
+func TestForceTransition_StopsRunningExtraction(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockLLM := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), mockLLM)
+	jobID := uuid.New()
+
+	started := make(chan struct{})
+	mockLLM.EXPECT().Extract(mock.Anything, "eggs").
+		RunAndReturn(func(ctx context.Context, _ string) (*ExtractionResponse, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, toProcessing(jobID)).
+		Return(db.IngestionJob{ID: jobID, Status: "processing"}, nil)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockQ.EXPECT().GetIngestionJob(context.Background(), jobID).
+		Return(db.IngestionJob{ID: jobID, Status: "processing"}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(context.Background(), db.TransitionIngestionJobStatusParams{
+		ToStatus:   "failed",
+		ID:         jobID,
+		FromStatus: "processing",
+	}).Return(db.IngestionJob{ID: jobID, Status: "failed"}, nil)
+	mockQ.EXPECT().DeleteStagedItemsByJob(mock.Anything, jobID).Return(nil)
+
+	done := make(chan error, 1)
+	go func() { done <- svc.runJob(ingestTask{jobID: jobID, rawInput: "eggs"}) }()
+	<-started
+	job, err := svc.ForceTransition(context.Background(), jobID, "failed", "stuck on the llm")
+	require.NoError(t, err)
+	assert.Equal(t, "failed", job.Status)
+	// The aborted run neither stages the job nor fails it a second time.
+	require.NoError(t, <-done)
+}
+
+func TestForceTransition_FailedToPendingRequeues(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	svc.StartWorkers(ctx, 0, 1)
+
+	jobID := uuid.New()
+	mockQ.EXPECT().GetIngestionJob(context.Background(), jobID).
+		Return(db.IngestionJob{ID: jobID, Status: "failed", RawInput: "milk"}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(context.Background(), db.TransitionIngestionJobStatusParams{
+		ToStatus:   "pending",
+		ID:         jobID,
+		FromStatus: "failed",
+	}).Return(db.IngestionJob{ID: jobID, Status: "pending"}, nil)
+	mockQ.EXPECT().DeleteStagedItemsByJob(context.Background(), jobID).Return(nil)
+
+	job, err := svc.ForceTransition(context.Background(), jobID, "pending", "dictionary was down")
+	require.NoError(t, err)
+	assert.Equal(t, "pending", job.Status)
+	assert.Equal(t, 1, svc.WorkerStatus().QueueDepth)
+}
+
+func TestRetryJob_RequeuesFailedJob(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	svc.StartWorkers(ctx, 0, 1)
+
+	jobID := uuid.New()
+	mockQ.EXPECT().TransitionIngestionJobStatus(context.Background(), db.TransitionIngestionJobStatusParams{
+		ToStatus:   "pending",
+		ID:         jobID,
+		FromStatus: "failed",
+	}).Return(db.IngestionJob{ID: jobID, Status: "pending", RawInput: "milk"}, nil)
+	mockQ.EXPECT().DeleteStagedItemsByJob(context.Background(), jobID).Return(nil)
+
+	job, err := svc.RetryJob(context.Background(), jobID)
+	require.NoError(t, err)
+	assert.Equal(t, "pending", job.Status)
+	assert.Equal(t, 1, svc.WorkerStatus().QueueDepth)
+}
+
+func TestRetryJob_RejectsJobsThatHaveNotFailed(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewIngestService(mockQ, nil, nil)
+	jobID := uuid.New()
+	mockQ.EXPECT().TransitionIngestionJobStatus(context.Background(), db.TransitionIngestionJobStatusParams{
+		ToStatus:   "pending",
+		ID:         jobID,
+		FromStatus: "failed",
+	}).Return(db.IngestionJob{}, sql.ErrNoRows)
+	mockQ.EXPECT().GetIngestionJob(context.Background(), jobID).
+		Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+
+	_, err := svc.RetryJob(context.Background(), jobID)
+	require.ErrorIs(t, err, ErrJobNotFailed)
+	assert.ErrorContains(t, err, "job is staged")
+}
+
+func TestRejectJob(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewIngestService(mockQ, nil, nil)
+	staged, confirmed := uuid.New(), uuid.New()
+	reject := func(jobID uuid.UUID) db.TransitionIngestionJobStatusParams {
+		return db.TransitionIngestionJobStatusParams{ToStatus: "rejected", ID: jobID, FromStatus: "staged"}
+	}
+	mockQ.EXPECT().TransitionIngestionJobStatus(context.Background(), reject(staged)).
+		Return(db.IngestionJob{ID: staged, Status: "rejected"}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(context.Background(), reject(confirmed)).
+		Return(db.IngestionJob{}, sql.ErrNoRows)
+	mockQ.EXPECT().GetIngestionJob(context.Background(), confirmed).
+		Return(db.IngestionJob{ID: confirmed, Status: "confirmed"}, nil)
+
+	job, err := svc.RejectJob(context.Background(), staged)
+	require.NoError(t, err)
+	assert.Equal(t, "rejected", job.Status)
+
+	_, err = svc.RejectJob(context.Background(), confirmed)
+	require.ErrorIs(t, err, ErrJobNotRejectable)
+	assert.ErrorContains(t, err, "job is confirmed")
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultLLMRetries is how many times a failed LLM call is retried.
+	DefaultLLMRetries = 2
+	// DefaultLLMRetryBackoff is the wait before the first retry, doubled for
+	// each one after.
+	DefaultLLMRetryBackoff = time.Second
+	// maxLLMRetryWait caps a provider's Retry-After, which can ask for
+	// minutes once a quota runs out.
+	maxLLMRetryWait = 30 * time.Second
+)
+
+// WithRetries retries a call that failed with a 408, 429 or 5xx status or a
+// connection error up to retries times, waiting backoff before the first
+// retry and doubling it after. A Retry-After from the provider replaces the
+// wait. Without it each call is tried once.
+func WithRetries(retries int, backoff time.Duration) ExtractorOption {
+	return func(e *llmClient) { e.retries, e.retryBackoff = retries, backoff }
+}
+
+// WithFallbackModel sends a call to model when the extraction model still
+// fails after its retries, e.g. while one model is overloaded. Calls to a
+// separate vision model do not fall back.
+func WithFallbackModel(model string) ExtractorOption {
+	return func(e *llmClient) { e.fallbackModel = model }
+}
+
+// llmStatusError is a non-200 reply from an LLM provider.
+type llmStatusError struct {
+	provider   string
+	status     int
+	body       string
+	retryAfter time.Duration
+}
+
+// newLLMStatusError reads the error body and Retry-After of resp.
+func newLLMStatusError(provider string, resp *http.Response) *llmStatusError {
+	raw, _ := io.ReadAll(resp.Body)
+	return &llmStatusError{
+		provider:   provider,
+		status:     resp.StatusCode,
+		body:       string(raw),
+		retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+func (e *llmStatusError) Error() string {
+	return fmt.Sprintf("%s status %d: %s", e.provider, e.status, e.body)
+}
+
+// parseRetryAfter reads a Retry-After of delay seconds or an HTTP date. It
+// returns zero when there is none.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
+
+// retryableLLMError reports whether a call that failed with err may succeed
+// if tried again: the provider was overloaded, rate limited or unreachable.
+// A rejected request or an unreadable reply would fail the same way again.
+func retryableLLMError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var statusErr *llmStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.status == http.StatusRequestTimeout ||
+			statusErr.status == http.StatusTooManyRequests ||
+			statusErr.status >= http.StatusInternalServerError
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// llmCall sends one request to model and returns the reply and the tokens
+// it used.
+type llmCall func(ctx context.Context, model string) (string, int, error)
+
+// call runs send against model with the configured retries. When model is
+// the extraction model and still fails, the fallback model gets a turn.
+func (c *llmClient) call(ctx context.Context, model string, send llmCall) (string, int, error) {
+	reply, tokens, err := c.retry(ctx, model, send)
+	if err == nil || c.fallbackModel == "" || model != c.model || !retryableLLMError(err) {
+		return reply, tokens, err
+	}
+	c.log.WarnContext(ctx, "llm call failed; trying fallback model",
+		"model", model, "fallback_model", c.fallbackModel, "error", err)
+	reply, tokens, fallbackErr := c.retry(ctx, c.fallbackModel, send)
+	if fallbackErr != nil {
+		return "", 0, fmt.Errorf("%w; fallback model %s: %w", err, c.fallbackModel, fallbackErr)
+	}
+	return reply, tokens, nil
+}
+
+// retry runs send until it succeeds, fails for good or runs out of retries.
+// If ctx ends while waiting, the last failure is returned.
+func (c *llmClient) retry(ctx context.Context, model string, send llmCall) (string, int, error) {
+	wait := c.retryBackoff
+	for attempt := 0; ; attempt++ {
+		reply, tokens, err := send(ctx, model)
+		if err == nil || attempt >= c.retries || !retryableLLMError(err) {
+			return reply, tokens, err
+		}
+		delay := wait
+		var statusErr *llmStatusError
+		if errors.As(err, &statusErr) && statusErr.retryAfter > 0 {
+			delay = min(statusErr.retryAfter, maxLLMRetryWait)
+		}
+		c.log.DebugContext(ctx, "retrying llm call",
+			"model", model, "attempt", attempt+1, "delay", delay, "error", err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", 0, err
+		case <-timer.C:
+		}
+		wait *= 2
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/testutil/llmserver"
+)
+
+func TestLLMRetry_HonorsRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	server := llmserver.New(t, llmserver.WithSequence(
+		llmserver.RateLimited(time.Second),
+		llmserver.HappyPath(ExtractionResponse{Items: []ExtractedItem{{Name: "flour"}}}),
+	))
+	// The backoff alone would outlast the deadline; Retry-After does not.
+	extractor := NewOpenAIExtractor("sk-test", "gpt-test", WithBaseURL(server.URL), WithRetries(2, time.Hour))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := extractor.Extract(ctx, "2 cups flour")
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, 2, server.Calls())
+}
+
+func TestLLMRetry_GivesUp(t *testing.T) {
+	t.Parallel()
+
+	badRequest := llmserver.Scenario{Status: http.StatusBadRequest, RawBody: `{"error":{"message":"bad"}}`}
+	tests := []struct {
+		name      string
+		scenario  llmserver.Scenario
+		wantCalls int
+	}{
+		{name: "retries exhausted", scenario: llmserver.ServerError(), wantCalls: 3},
+		{name: "not retryable", scenario: badRequest, wantCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := llmserver.New(t, llmserver.WithDefault(tt.scenario))
+			extractor := NewOpenAIExtractor("sk-test", "gpt-test",
+				WithBaseURL(server.URL), WithRetries(2, time.Millisecond))
+
+			_, err := extractor.Extract(context.Background(), "2 cups flour")
+			require.Error(t, err)
+			assert.Equal(t, tt.wantCalls, server.Calls())
+		})
+	}
+}
+
+func TestLLMRetry_FallbackModel(t *testing.T) {
+	t.Parallel()
+
+	server := llmserver.New(t, llmserver.WithSequence(
+		llmserver.ServerError(),
+		llmserver.ServerError(),
+		llmserver.HappyPath(ExtractionResponse{Items: []ExtractedItem{{Name: "flour"}}}),
+	))
+	extractor := NewOpenAIExtractor("sk-test", "gpt-test", WithBaseURL(server.URL),
+		WithRetries(1, time.Millisecond), WithFallbackModel("gpt-fallback"))
+
+	resp, err := extractor.Extract(context.Background(), "2 cups flour")
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+
+	var models []string
+	for _, req := range server.Requests() {
+		models = append(models, req.Model)
+	}
+	assert.Equal(t, []string{"gpt-test", "gpt-test", "gpt-fallback"}, models)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, 7*time.Second, parseRetryAfter("7", now))
+	assert.Equal(t, 30*time.Second, parseRetryAfter("Tue, 10 Mar 2026 12:00:30 GMT", now))
+	assert.Zero(t, parseRetryAfter("", now))
+	assert.Zero(t, parseRetryAfter("soon", now))
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+// SetLotTracking enables batch-aware storage. When enabled, stock added by
+// AddStockNoPublish is summed into the pantry item and also recorded as a lot
+// carrying its own expiration, so a fresh batch does not inherit the date of
+// an older one. Items are still one row per ingredient; lots break that row
+// down by batch.
+func (s *PantryService) SetLotTracking(enabled bool) {
+	s.lotTracking = enabled
+}
+
+// LotTracking reports whether batch-aware storage is enabled.
+func (s *PantryService) LotTracking() bool {
+	return s.lotTracking
+}
+
+// AddStockNoPublish records newly acquired stock, e.g. from an ingest confirm.
+// Without lot tracking the stored quantity is replaced, matching
+// UpsertItemNoPublish. With lot tracking the quantity is added to the item
+// and a lot is recorded for the batch: a lot with the same unit and expiry
+// day absorbs it, otherwise a new lot is created. The item's expires_at
+// becomes the earliest lot expiry. A unit change discards lots in the old
+// unit, mirroring the add strategy's replace-on-unit-mismatch rule.
+func (s *PantryService) AddStockNoPublish(
+	ctx context.Context,
+	ingredientID uuid.UUID,
+	quantity float64,
+	unit string,
+	expiresAt sql.NullTime,
+	sourceJobID uuid.NullUUID,
+) (db.PantryItem, error) {
+	return s.addStock(ctx, ItemInput{
+		IngredientID: ingredientID,
+		Quantity:     quantity,
+		Unit:         unit,
+		ExpiresAt:    expiresAt,
+	}, sourceJobID)
+}
+
+// addStock is AddStockNoPublish for an ItemInput; in.Strategy is ignored. An
+// unknown quantity is merged into the item but records no lot, since lots
+// account for known amounts only.
+func (s *PantryService) addStock(ctx context.Context, in ItemInput, sourceJobID uuid.NullUUID) (db.PantryItem, error) {
+	if !s.lotTracking {
+		in.Strategy = ConflictReplace
+		return s.saveItem(ctx, in)
+	}
+
+	in.Strategy = ConflictAdd
+	item, err := s.upsertItem(ctx, in)
+	if err != nil {
+		return db.PantryItem{}, err
+	}
+	if in.QuantityUnknown {
+		return item, nil
+	}
+
+	if err := s.discardLotsInOtherUnits(ctx, item.ID, in.Unit); err != nil {
+		return db.PantryItem{}, err
+	}
+
+	lot, err := s.q.MergeIntoPantryLot(ctx, db.MergeIntoPantryLotParams{
+		PantryItemID: item.ID,
+		Quantity:     in.Quantity,
+		Unit:         in.Unit,
+		ExpiresAt:    in.ExpiresAt,
+	})
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		lot, err = s.q.InsertPantryLot(ctx, db.InsertPantryLotParams{
+			PantryItemID: item.ID,
+			Quantity:     in.Quantity,
+			Unit:         in.Unit,
+			ExpiresAt:    in.ExpiresAt,
+			SourceJobID:  sourceJobID,
+		})
+		if err != nil {
+			return db.PantryItem{}, fmt.Errorf("insert pantry lot: %w", err)
+		}
+	case err != nil:
+		return db.PantryItem{}, fmt.Errorf("merge pantry lot: %w", err)
+	}
+	if err := s.recordLotEvent(ctx, lot, LotEventAdded, in.Quantity); err != nil {
+		return db.PantryItem{}, err
+	}
+
+	item, err = s.q.SyncPantryItemExpiryFromLots(ctx, item.ID)
+	if err != nil {
+		return db.PantryItem{}, fmt.Errorf("sync item expiry from lots: %w", err)
+	}
+	return item, nil
+}
+
+// confirmStock adds the stock of every plan with a resolved ingredient and
+// returns the saved items in plan order. Without lot tracking confirm
+// replaces stock, so every item is saved in one statement; with it, each
+// item is merged into its lots one by one. A stock check replaces stock
+// either way, with each ingredient's plans totalled, and lot tracking
+// consumes lots down to the new quantity.
+func (s *PantryService) confirmStock(
+	ctx context.Context,
+	jobID uuid.UUID,
+	plans []confirmPlan,
+	stockCheck bool,
+) ([]db.PantryItem, error) {
+	inputs := make([]ItemInput, 0, len(plans))
+	stagedIDs := make([]uuid.UUID, 0, len(plans))
+	for _, plan := range plans {
+		if plan.ingredientID.Valid {
+			inputs = append(inputs, plan.in)
+			stagedIDs = append(stagedIDs, plan.staged.ID)
+		}
+	}
+	if stockCheck {
+		items, err := s.UpsertItemsBulk(ctx, totalStock(inputs))
+		if err != nil || !s.lotTracking {
+			return items, err
+		}
+		// Inputs for one ingredient share a row, which is reconciled once.
+		reconciled := make(map[uuid.UUID]db.PantryItem, len(items))
+		for i, item := range items {
+			if done, ok := reconciled[item.ID]; ok {
+				items[i] = done
+				continue
+			}
+			if items[i], err = s.reconcileLots(ctx, item); err != nil {
+				return nil, err
+			}
+			reconciled[item.ID] = items[i]
+		}
+		return items, nil
+	}
+	if !s.lotTracking {
+		return s.UpsertItemsBulk(ctx, inputs)
+	}
+
+	items := make([]db.PantryItem, len(inputs))
+	for i, in := range inputs {
+		item, err := s.addStock(ctx, in, uuid.NullUUID{UUID: jobID, Valid: true})
+		if err != nil {
+			return nil, fmt.Errorf("upsert pantry item for staged item %s: %w", stagedIDs[i], err)
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+// Lot history event kinds.
+const (
+	LotEventAdded     = "added"
+	LotEventConsumed  = "consumed"
+	LotEventDiscarded = "discarded"
+)
+
+// reconcileLots brings an item's lots in line with its stored quantity after
+// a write that may have lowered it. Lots in a unit other than the item's are
+// discarded; any excess of lot quantity over item quantity is consumed from
+// the soonest-expiring lot first (undated lots last, then oldest added), so
+// expiry tracking stays accurate for staples bought repeatedly. Quantity not
+// covered by lots, e.g. stock that predates lot tracking, is left untracked.
+// An item whose quantity is unknown leaves its lots alone.
+func (s *PantryService) reconcileLots(ctx context.Context, item db.PantryItem) (db.PantryItem, error) {
+	if item.QuantityUnknown {
+		return item, nil
+	}
+	if err := s.discardLotsInOtherUnits(ctx, item.ID, item.Unit); err != nil {
+		return db.PantryItem{}, err
+	}
+
+	lots, err := s.q.ListPantryLotsByItem(ctx, item.ID)
+	if err != nil {
+		return db.PantryItem{}, fmt.Errorf("list pantry lots: %w", err)
+	}
+	if len(lots) == 0 {
+		return item, nil
+	}
+
+	var total float64
+	for _, l := range lots {
+		total += l.Quantity
+	}
+	excess := total - max(item.Quantity, 0)
+	if excess <= 0 {
+		return item, nil
+	}
+
+	for _, l := range lots {
+		if excess <= 0 {
+			break
+		}
+		take := min(l.Quantity, excess)
+		if _, err := s.q.DecrementPantryLot(ctx, db.DecrementPantryLotParams{ID: l.ID, Quantity: take}); err != nil {
+			return db.PantryItem{}, fmt.Errorf("deplete lot %s: %w", l.ID, err)
+		}
+		if err := s.recordLotEvent(ctx, l, LotEventConsumed, take); err != nil {
+			return db.PantryItem{}, err
+		}
+		excess -= take
+	}
+
+	if err := s.q.DeleteEmptyPantryLots(ctx, item.ID); err != nil {
+		return db.PantryItem{}, fmt.Errorf("delete empty lots: %w", err)
+	}
+	item, err = s.q.SyncPantryItemExpiryFromLots(ctx, item.ID)
+	if err != nil {
+		return db.PantryItem{}, fmt.Errorf("sync item expiry from lots: %w", err)
+	}
+	return item, nil
+}
+
+// discardLotsInOtherUnits deletes an item's lots kept in a unit other than
+// unit, recording what each still held so the history balances.
+func (s *PantryService) discardLotsInOtherUnits(ctx context.Context, itemID uuid.UUID, unit string) error {
+	discarded, err := s.q.DeletePantryLotsWithOtherUnit(ctx, db.DeletePantryLotsWithOtherUnitParams{
+		PantryItemID: itemID,
+		Unit:         unit,
+	})
+	if err != nil {
+		return fmt.Errorf("discard lots in previous unit: %w", err)
+	}
+	for _, lot := range discarded {
+		if err := s.recordLotEvent(ctx, lot, LotEventDiscarded, lot.Quantity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *PantryService) recordLotEvent(ctx context.Context, lot db.PantryLot, kind string, quantity float64) error {
+	if err := s.q.InsertPantryLotEvent(ctx, db.InsertPantryLotEventParams{
+		PantryItemID: lot.PantryItemID,
+		LotID:        lot.ID,
+		Kind:         kind,
+		Quantity:     quantity,
+		Unit:         lot.Unit,
+		ExpiresAt:    lot.ExpiresAt,
+	}); err != nil {
+		return fmt.Errorf("record lot %s event: %w", kind, err)
+	}
+	return nil
+}
+
+// ListLotHistory returns the added/consumed history of an item's lots,
+// oldest first.
+func (s *PantryService) ListLotHistory(ctx context.Context, itemID uuid.UUID) ([]db.PantryLotEvent, error) {
+	if _, err := s.q.GetPantryItem(ctx, itemID); err != nil {
+		return nil, err
+	}
+	events, err := s.q.ListPantryLotEventsByItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if events == nil {
+		return []db.PantryLotEvent{}, nil
+	}
+	return events, nil
+}
+
+// ListLots returns the lots of a pantry item, soonest-expiring first.
+func (s *PantryService) ListLots(ctx context.Context, itemID uuid.UUID) ([]db.PantryLot, error) {
+	if _, err := s.q.GetPantryItem(ctx, itemID); err != nil {
+		return nil, err
+	}
+	lots, err := s.q.ListPantryLotsByItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if lots == nil {
+		return []db.PantryLot{}, nil
+	}
+	return lots, nil
+}
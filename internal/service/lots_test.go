@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestAddStockNoPublish_WithoutLotTrackingReplaces(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+
+	ingredientID := uuid.New()
+	mockQ.EXPECT().UpsertPantryItem(mock.Anything, db.UpsertPantryItemParams{
+		IngredientID: ingredientID,
+		Quantity:     2,
+		Unit:         "l",
+	}).Return(db.PantryItem{ID: uuid.New()}, nil)
+
+	_, err := svc.AddStockNoPublish(context.Background(), ingredientID, 2, "l", sql.NullTime{}, uuid.NullUUID{})
+	require.NoError(t, err)
+}
+
+func TestAddStockNoPublish_NewLot(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+	svc.SetLotTracking(true)
+
+	ingredientID := uuid.New()
+	itemID := uuid.New()
+	jobID := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	expires := sql.NullTime{Time: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	earlier := sql.NullTime{Time: time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC), Valid: true}
+
+	mockQ.EXPECT().UpsertPantryItemAdd(mock.Anything, db.UpsertPantryItemAddParams{
+		IngredientID: ingredientID,
+		Quantity:     1,
+		Unit:         "l",
+		ExpiresAt:    expires,
+	}).Return(db.PantryItem{ID: itemID, Quantity: 2, Unit: "l", ExpiresAt: expires}, nil)
+	mockQ.EXPECT().DeletePantryLotsWithOtherUnit(mock.Anything, db.DeletePantryLotsWithOtherUnitParams{
+		PantryItemID: itemID,
+		Unit:         "l",
+	}).Return(nil, nil)
+	mockQ.EXPECT().MergeIntoPantryLot(mock.Anything, db.MergeIntoPantryLotParams{
+		PantryItemID: itemID,
+		Quantity:     1,
+		Unit:         "l",
+		ExpiresAt:    expires,
+	}).Return(db.PantryLot{}, sql.ErrNoRows)
+	lot := db.PantryLot{ID: uuid.New(), PantryItemID: itemID, Quantity: 1, Unit: "l", ExpiresAt: expires}
+	mockQ.EXPECT().InsertPantryLot(mock.Anything, db.InsertPantryLotParams{
+		PantryItemID: itemID,
+		Quantity:     1,
+		Unit:         "l",
+		ExpiresAt:    expires,
+		SourceJobID:  jobID,
+	}).Return(lot, nil)
+	mockQ.EXPECT().InsertPantryLotEvent(mock.Anything, db.InsertPantryLotEventParams{
+		PantryItemID: itemID,
+		LotID:        lot.ID,
+		Kind:         LotEventAdded,
+		Quantity:     1,
+		Unit:         "l",
+		ExpiresAt:    expires,
+	}).Return(nil)
+	mockQ.EXPECT().SyncPantryItemExpiryFromLots(mock.Anything, itemID).
+		Return(db.PantryItem{ID: itemID, Quantity: 2, Unit: "l", ExpiresAt: earlier}, nil)
+
+	item, err := svc.AddStockNoPublish(context.Background(), ingredientID, 1, "l", expires, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, item.Quantity)
+	assert.Equal(t, earlier, item.ExpiresAt)
+}
+
+func TestAddStockNoPublish_MergesSameDayLot(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+	svc.SetLotTracking(true)
+
+	itemID := uuid.New()
+	mockQ.EXPECT().UpsertPantryItemAdd(mock.Anything, mock.Anything).Return(db.PantryItem{ID: itemID}, nil)
+	mockQ.EXPECT().DeletePantryLotsWithOtherUnit(mock.Anything, mock.Anything).Return(nil, nil)
+	mockQ.EXPECT().MergeIntoPantryLot(mock.Anything, mock.Anything).
+		Return(db.PantryLot{ID: uuid.New(), PantryItemID: itemID, Quantity: 2, Unit: "l"}, nil)
+	mockQ.EXPECT().InsertPantryLotEvent(mock.Anything, mock.MatchedBy(func(p db.InsertPantryLotEventParams) bool {
+		return p.Kind == LotEventAdded && p.Quantity == 1
+	})).Return(nil)
+	mockQ.EXPECT().SyncPantryItemExpiryFromLots(mock.Anything, itemID).Return(db.PantryItem{ID: itemID}, nil)
+
+	_, err := svc.AddStockNoPublish(context.Background(), uuid.New(), 1, "l", sql.NullTime{}, uuid.NullUUID{})
+	require.NoError(t, err)
+}
+
+func TestAddStockNoPublish_RecordsDiscardedLots(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+	svc.SetLotTracking(true)
+
+	itemID := uuid.New()
+	old := db.PantryLot{ID: uuid.New(), PantryItemID: itemID, Quantity: 3, Unit: "cup"}
+	mockQ.EXPECT().UpsertPantryItemAdd(mock.Anything, mock.Anything).Return(db.PantryItem{ID: itemID}, nil)
+	mockQ.EXPECT().DeletePantryLotsWithOtherUnit(mock.Anything, mock.Anything).Return([]db.PantryLot{old}, nil)
+	mockQ.EXPECT().InsertPantryLotEvent(mock.Anything, db.InsertPantryLotEventParams{
+		PantryItemID: itemID, LotID: old.ID, Kind: LotEventDiscarded, Quantity: 3, Unit: "cup",
+	}).Return(nil)
+	mockQ.EXPECT().MergeIntoPantryLot(mock.Anything, mock.Anything).
+		Return(db.PantryLot{ID: uuid.New(), PantryItemID: itemID, Quantity: 1, Unit: "l"}, nil)
+	mockQ.EXPECT().InsertPantryLotEvent(mock.Anything, mock.MatchedBy(func(p db.InsertPantryLotEventParams) bool {
+		return p.Kind == LotEventAdded
+	})).Return(nil)
+	mockQ.EXPECT().SyncPantryItemExpiryFromLots(mock.Anything, itemID).Return(db.PantryItem{ID: itemID}, nil)
+
+	_, err := svc.AddStockNoPublish(context.Background(), uuid.New(), 1, "l", sql.NullTime{}, uuid.NullUUID{})
+	require.NoError(t, err)
+}
+
+func TestListLots_ItemNotFound(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+
+	id := uuid.New()
+	mockQ.EXPECT().GetPantryItem(mock.Anything, id).Return(db.PantryItem{}, sql.ErrNoRows)
+
+	_, err := svc.ListLots(context.Background(), id)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUpsertItem_LotTrackingConsumesSoonestExpiringFirst(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+	svc.SetLotTracking(true)
+
+	itemID := uuid.New()
+	soon := db.PantryLot{
+		ID: uuid.New(), PantryItemID: itemID, Quantity: 1, Unit: "l",
+		ExpiresAt: sql.NullTime{Time: time.Now().Add(24 * time.Hour), Valid: true},
+	}
+	later := db.PantryLot{
+		ID: uuid.New(), PantryItemID: itemID, Quantity: 2, Unit: "l",
+		ExpiresAt: sql.NullTime{Time: time.Now().Add(7 * 24 * time.Hour), Valid: true},
+	}
+
+	// Stock drops from 3 l to 1.5 l: the soon lot is used up, then 0.5 l of the later one.
+	mockQ.EXPECT().UpsertPantryItem(mock.Anything, mock.Anything).
+		Return(db.PantryItem{ID: itemID, Quantity: 1.5, Unit: "l"}, nil)
+	mockQ.EXPECT().DeletePantryLotsWithOtherUnit(mock.Anything, mock.Anything).Return(nil, nil)
+	mockQ.EXPECT().ListPantryLotsByItem(mock.Anything, itemID).Return([]db.PantryLot{soon, later}, nil)
+	mockQ.EXPECT().DecrementPantryLot(mock.Anything, db.DecrementPantryLotParams{ID: soon.ID, Quantity: 1}).
+		Return(db.PantryLot{}, nil)
+	mockQ.EXPECT().DecrementPantryLot(mock.Anything, db.DecrementPantryLotParams{ID: later.ID, Quantity: 0.5}).
+		Return(db.PantryLot{}, nil)
+	mockQ.EXPECT().InsertPantryLotEvent(mock.Anything, mock.MatchedBy(func(p db.InsertPantryLotEventParams) bool {
+		return p.Kind == LotEventConsumed && p.LotID == soon.ID && p.Quantity == 1
+	})).Return(nil)
+	mockQ.EXPECT().InsertPantryLotEvent(mock.Anything, mock.MatchedBy(func(p db.InsertPantryLotEventParams) bool {
+		return p.Kind == LotEventConsumed && p.LotID == later.ID && p.Quantity == 0.5
+	})).Return(nil)
+	mockQ.EXPECT().DeleteEmptyPantryLots(mock.Anything, itemID).Return(nil)
+	mockQ.EXPECT().SyncPantryItemExpiryFromLots(mock.Anything, itemID).
+		Return(db.PantryItem{ID: itemID, Quantity: 1.5, Unit: "l", ExpiresAt: later.ExpiresAt}, nil)
+
+	item, err := svc.UpsertItem(context.Background(), uuid.New(), 1.5, "l", sql.NullTime{})
+	require.NoError(t, err)
+	assert.Equal(t, later.ExpiresAt, item.ExpiresAt)
+}
+
+func TestUpsertItem_LotTrackingNoExcessLeavesLots(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+	svc.SetLotTracking(true)
+
+	itemID := uuid.New()
+	mockQ.EXPECT().UpsertPantryItem(mock.Anything, mock.Anything).
+		Return(db.PantryItem{ID: itemID, Quantity: 5, Unit: "l"}, nil)
+	mockQ.EXPECT().DeletePantryLotsWithOtherUnit(mock.Anything, mock.Anything).Return(nil, nil)
+	mockQ.EXPECT().ListPantryLotsByItem(mock.Anything, itemID).
+		Return([]db.PantryLot{{ID: uuid.New(), PantryItemID: itemID, Quantity: 2, Unit: "l"}}, nil)
+
+	item, err := svc.UpsertItem(context.Background(), uuid.New(), 5, "l", sql.NullTime{})
+	require.NoError(t, err)
+	assert.Equal(t, 5.0, item.Quantity)
+}
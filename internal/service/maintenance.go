@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+// deadTupleVacuumRatio is the dead/live tuple ratio above which a table is
+// flagged as needing a manual VACUUM.
+const deadTupleVacuumRatio = 0.2
+
+// analyzeStaleAfter flags tables whose statistics have not been refreshed
+// (manually or by autovacuum) within this window.
+const analyzeStaleAfter = 7 * 24 * time.Hour
+
+// tombstoneRetention is how long deletion tombstones are kept for
+// GET /pantry?updated_since clients. Clients that fall further behind must
+// do a full refresh.
+const tombstoneRetention = 30 * 24 * time.Hour
+
+// MaintenanceService runs operator-triggered database maintenance tasks and
+// integrity checks. Every task returns a report suitable for JSON output.
+type MaintenanceService struct {
+	q           db.Querier
+	ingredients IngredientLookup
+}
+
+func NewMaintenanceService(q db.Querier, ingredients IngredientLookup) *MaintenanceService {
+	return &MaintenanceService{q: q, ingredients: ingredients}
+}
+
+// TableHealth summarizes dead-tuple and statistics freshness for one table.
+type TableHealth struct {
+	Table        string     `json:"table"`
+	LiveTuples   int64      `json:"live_tuples"`
+	DeadTuples   int64      `json:"dead_tuples"`
+	LastVacuum   *time.Time `json:"last_vacuum"`
+	LastAnalyze  *time.Time `json:"last_analyze"`
+	NeedsVacuum  bool       `json:"needs_vacuum"`
+	NeedsAnalyze bool       `json:"needs_analyze"`
+}
+
+// VacuumReport lists per-table vacuum/analyze hints.
+type VacuumReport struct {
+	Tables []TableHealth `json:"tables"`
+}
+
+// VacuumHints inspects pg_stat_user_tables and flags tables with a high
+// dead-tuple ratio or stale planner statistics. It does not run VACUUM.
+func (s *MaintenanceService) VacuumHints(ctx context.Context) (VacuumReport, error) {
+	stats, err := s.q.ListTableStats(ctx)
+	if err != nil {
+		return VacuumReport{}, fmt.Errorf("list table stats: %w", err)
+	}
+
+	now := time.Now()
+	report := VacuumReport{Tables: make([]TableHealth, 0, len(stats))}
+	for _, st := range stats {
+		lastVacuum := latest(st.LastVacuum.Time, st.LastAutovacuum.Time)
+		lastAnalyze := latest(st.LastAnalyze.Time, st.LastAutoanalyze.Time)
+
+		h := TableHealth{
+			Table:       st.TableName,
+			LiveTuples:  st.NLiveTup,
+			DeadTuples:  st.NDeadTup,
+			LastVacuum:  timePtr(lastVacuum),
+			LastAnalyze: timePtr(lastAnalyze),
+		}
+		if st.NLiveTup > 0 && float64(st.NDeadTup)/float64(st.NLiveTup) > deadTupleVacuumRatio {
+			h.NeedsVacuum = true
+		}
+		if st.NLiveTup > 0 && (lastAnalyze.IsZero() || now.Sub(lastAnalyze) > analyzeStaleAfter) {
+			h.NeedsAnalyze = true
+		}
+		report.Tables = append(report.Tables, h)
+	}
+	return report, nil
+}
+
+// TaskReport records the outcome of a maintenance command.
+type TaskReport struct {
+	Task       string   `json:"task"`
+	Tables     []string `json:"tables"`
+	DurationMS int64    `json:"duration_ms"`
+}
+
+// Analyze refreshes planner statistics for all pantry tables.
+func (s *MaintenanceService) Analyze(ctx context.Context) (TaskReport, error) {
+	start := time.Now()
+	if err := s.q.AnalyzeTables(ctx); err != nil {
+		return TaskReport{}, fmt.Errorf("analyze: %w", err)
+	}
+	return TaskReport{
+		Task:       "analyze",
+		Tables:     []string{"pantry_items", "ingestion_jobs", "staged_items"},
+		DurationMS: time.Since(start).Milliseconds(),
+	}, nil
+}
+
+// Reindex rebuilds the indexes of all pantry tables. REINDEX takes locks that
+// block writes, so it should be run during quiet periods.
+func (s *MaintenanceService) Reindex(ctx context.Context) (TaskReport, error) {
+	start := time.Now()
+	tasks := []struct {
+		table string
+		run   func(context.Context) error
+	}{
+		{"pantry_items", s.q.ReindexPantryItems},
+		{"ingestion_jobs", s.q.ReindexIngestionJobs},
+		{"staged_items", s.q.ReindexStagedItems},
+	}
+
+	report := TaskReport{Task: "reindex", Tables: make([]string, 0, len(tasks))}
+	for _, t := range tasks {
+		if err := t.run(ctx); err != nil {
+			return TaskReport{}, fmt.Errorf("reindex %s: %w", t.table, err)
+		}
+		report.Tables = append(report.Tables, t.table)
+	}
+	report.DurationMS = time.Since(start).Milliseconds()
+	return report, nil
+}
+
+// CleanupReport records how many orphaned or expired rows were removed.
+type CleanupReport struct {
+	DeletedStagedItems int64 `json:"deleted_staged_items"`
+	DeletedTombstones  int64 `json:"deleted_tombstones"`
+}
+
+// CleanupOrphans deletes staged items whose ingestion job no longer exists.
+// The foreign key cascades normally prevent these, but rows can be left
+// behind by manual edits or restores that skipped constraints. Deletion
+// tombstones older than the retention window are purged as well.
+func (s *MaintenanceService) CleanupOrphans(ctx context.Context) (CleanupReport, error) {
+	n, err := s.q.DeleteOrphanedStagedItems(ctx)
+	if err != nil {
+		return CleanupReport{}, fmt.Errorf("delete orphaned staged items: %w", err)
+	}
+	tombstones, err := s.q.DeletePantryTombstonesBefore(ctx, time.Now().Add(-tombstoneRetention))
+	if err != nil {
+		return CleanupReport{}, fmt.Errorf("delete expired tombstones: %w", err)
+	}
+	return CleanupReport{DeletedStagedItems: n, DeletedTombstones: tombstones}, nil
+}
+
+// IntegrityReport lists pantry ingredient references that no longer resolve
+// in the Dictionary.
+type IntegrityReport struct {
+	Checked      int         `json:"checked"`
+	Missing      []uuid.UUID `json:"missing"`
+	LookupErrors int         `json:"lookup_errors"`
+}
+
+// CheckIntegrity verifies that every ingredient_id referenced by a pantry item
+// still exists in the Dictionary. Lookups that fail for reasons other than
+// not-found are counted but not reported as missing.
+func (s *MaintenanceService) CheckIntegrity(ctx context.Context) (IntegrityReport, error) {
+	ids, err := s.q.ListPantryIngredientIDs(ctx)
+	if err != nil {
+		return IntegrityReport{}, fmt.Errorf("list pantry ingredient ids: %w", err)
+	}
+
+	report := IntegrityReport{Checked: len(ids), Missing: []uuid.UUID{}}
+	for _, id := range ids {
+		_, err := s.ingredients.GetIngredient(ctx, id)
+		switch {
+		case err == nil:
+		case errors.Is(err, clients.ErrIngredientNotFound):
+			report.Missing = append(report.Missing, id)
+		default:
+			report.LookupErrors++
+		}
+	}
+	return report, nil
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
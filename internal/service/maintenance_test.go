@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestVacuumHints_FlagsDeadTuplesAndStaleStats(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewMaintenanceService(mockQ, NewMockIngredientLookup(t))
+
+	recent := time.Now().Add(-time.Hour)
+	mockQ.EXPECT().ListTableStats(mock.Anything).Return([]db.ListTableStatsRow{
+		{
+			TableName:       "pantry_items",
+			NLiveTup:        100,
+			NDeadTup:        50,
+			LastAutoanalyze: sql.NullTime{Time: recent, Valid: true},
+		},
+		{
+			TableName: "staged_items",
+			NLiveTup:  100,
+			NDeadTup:  1,
+		},
+	}, nil)
+
+	report, err := svc.VacuumHints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, report.Tables, 2)
+
+	assert.True(t, report.Tables[0].NeedsVacuum)
+	assert.False(t, report.Tables[0].NeedsAnalyze)
+	require.NotNil(t, report.Tables[0].LastAnalyze)
+
+	assert.False(t, report.Tables[1].NeedsVacuum)
+	assert.True(t, report.Tables[1].NeedsAnalyze)
+	assert.Nil(t, report.Tables[1].LastAnalyze)
+}
+
+func TestReindex_StopsOnFirstError(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewMaintenanceService(mockQ, NewMockIngredientLookup(t))
+
+	mockQ.EXPECT().ReindexPantryItems(mock.Anything).Return(nil)
+	mockQ.EXPECT().ReindexIngestionJobs(mock.Anything).Return(errors.New("lock timeout"))
+
+	_, err := svc.Reindex(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ingestion_jobs")
+}
+
+func TestCleanupOrphans_ReportsDeletedCount(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewMaintenanceService(mockQ, NewMockIngredientLookup(t))
+
+	mockQ.EXPECT().DeleteOrphanedStagedItems(mock.Anything).Return(3, nil)
+	mockQ.EXPECT().DeletePantryTombstonesBefore(mock.Anything, mock.MatchedBy(func(cutoff time.Time) bool {
+		return time.Since(cutoff) >= tombstoneRetention
+	})).Return(4, nil)
+
+	report, err := svc.CleanupOrphans(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), report.DeletedStagedItems)
+	assert.Equal(t, int64(4), report.DeletedTombstones)
+}
+
+func TestCheckIntegrity_ReportsMissingIngredients(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	lookup := NewMockIngredientLookup(t)
+	svc := NewMaintenanceService(mockQ, lookup)
+
+	okID, missingID, flakyID := uuid.New(), uuid.New(), uuid.New()
+	mockQ.EXPECT().ListPantryIngredientIDs(mock.Anything).Return([]uuid.UUID{okID, missingID, flakyID}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, okID).Return(clients.Ingredient{ID: okID}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, missingID).Return(clients.Ingredient{}, clients.ErrIngredientNotFound)
+	lookup.EXPECT().GetIngredient(mock.Anything, flakyID).Return(clients.Ingredient{}, errors.New("timeout"))
+
+	report, err := svc.CheckIntegrity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Checked)
+	assert.Equal(t, []uuid.UUID{missingID}, report.Missing)
+	assert.Equal(t, 1, report.LookupErrors)
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+// What MergeIngredient did with the merged ingredient's pantry item.
+const (
+	MergeNoItem   = "none"     // the pantry did not stock it
+	MergeMoved    = "moved"    // the item now holds the surviving ingredient
+	MergeCombined = "combined" // added to the surviving ingredient's item and deleted
+	MergeKept     = "kept"     // units could not be combined; left as it was
+)
+
+// IngredientMerge is the outcome of MergeIngredient.
+type IngredientMerge struct {
+	PantryItem  string
+	StagedItems int64
+}
+
+// MergeIngredient follows a Dictionary merge of oldID into survivorID. The
+// pantry item for oldID is moved to survivorID, or, since the pantry holds
+// one item per ingredient, added to survivorID's item when there is one. The
+// combined item keeps the earlier expiry. An amount whose unit cannot be
+// converted to the surviving item's is left on its own item for someone to
+// sort out. Staged items are repointed too. Everything is written in one
+// transaction, and pantry.updated lists the items that changed.
+func (s *PantryService) MergeIngredient(ctx context.Context, oldID, survivorID uuid.UUID) (IngredientMerge, error) {
+	if oldID == survivorID {
+		return IngredientMerge{PantryItem: MergeNoItem}, nil
+	}
+	var (
+		merge   IngredientMerge
+		changed []uuid.UUID
+	)
+	err := db.InTx(ctx, s.q, func(q db.Querier) error {
+		var err error
+		merge, changed = IngredientMerge{}, nil
+		merge.PantryItem, changed, err = s.withQuerier(q).mergePantryItem(ctx, oldID, survivorID)
+		if err != nil {
+			return err
+		}
+		merge.StagedItems, err = q.RemapStagedItemIngredient(ctx, db.RemapStagedItemIngredientParams{
+			NewID: survivorID,
+			OldID: oldID,
+		})
+		if err != nil {
+			return fmt.Errorf("remap staged items: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return IngredientMerge{}, err
+	}
+	if merge.PantryItem == MergeKept {
+		s.log.WarnContext(ctx, "merged ingredient's item kept: units differ",
+			"old_ingredient_id", oldID, "surviving_ingredient_id", survivorID)
+	}
+	if len(changed) > 0 {
+		s.publishPantryUpdated(ctx, changed)
+	}
+	return merge, nil
+}
+
+func (s *PantryService) mergePantryItem(
+	ctx context.Context,
+	oldID, survivorID uuid.UUID,
+) (string, []uuid.UUID, error) {
+	old, err := s.q.GetPantryItemByIngredient(ctx, oldID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return MergeNoItem, nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	in := ItemInput{
+		IngredientID:    survivorID,
+		Quantity:        old.Quantity,
+		QuantityUnknown: old.QuantityUnknown,
+		Unit:            old.Unit,
+		ExpiresAt:       old.ExpiresAt,
+		Strategy:        ConflictAdd,
+	}
+	preview, err := s.PreviewItem(ctx, in)
+	if err != nil {
+		return "", nil, err
+	}
+	if preview.Existing == nil {
+		if _, err := s.q.RemapPantryItemIngredient(ctx, db.RemapPantryItemIngredientParams{
+			NewID: survivorID,
+			OldID: oldID,
+		}); err != nil {
+			return "", nil, fmt.Errorf("remap pantry item: %w", err)
+		}
+		return MergeMoved, []uuid.UUID{old.ID}, nil
+	}
+	if preview.UnitReplaced {
+		return MergeKept, nil, nil
+	}
+
+	in = preview.Input
+	if kept := preview.Existing.ExpiresAt; kept.Valid && (!in.ExpiresAt.Valid || kept.Time.Before(in.ExpiresAt.Time)) {
+		in.ExpiresAt = kept
+	}
+	if err := s.q.DeletePantryItem(ctx, old.ID); err != nil {
+		return "", nil, fmt.Errorf("delete merged item: %w", err)
+	}
+	item, err := s.saveItem(ctx, in)
+	if err != nil {
+		return "", nil, err
+	}
+	return MergeCombined, []uuid.UUID{item.ID, old.ID}, nil
+}
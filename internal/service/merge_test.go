@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestMergeIngredient_MovesItem(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	pub := &stubUpdatePublisher{}
+	svc := NewPantryService(mockQ, pub)
+
+	oldID, survivorID := uuid.New(), uuid.New()
+	item := db.PantryItem{ID: uuid.New(), IngredientID: oldID, Quantity: 2, Unit: "l"}
+	remap := db.RemapPantryItemIngredientParams{NewID: survivorID, OldID: oldID}
+	mockQ.EXPECT().GetPantryItemByIngredient(mock.Anything, oldID).Return(item, nil)
+	mockQ.EXPECT().GetPantryItemByIngredient(mock.Anything, survivorID).Return(db.PantryItem{}, sql.ErrNoRows)
+	mockQ.EXPECT().RemapPantryItemIngredient(mock.Anything, remap).Return(1, nil)
+	mockQ.EXPECT().RemapStagedItemIngredient(mock.Anything, db.RemapStagedItemIngredientParams(remap)).Return(3, nil)
+
+	got, err := svc.MergeIngredient(context.Background(), oldID, survivorID)
+	require.NoError(t, err)
+	assert.Equal(t, IngredientMerge{PantryItem: MergeMoved, StagedItems: 3}, got)
+	assert.Equal(t, [][]uuid.UUID{{item.ID}}, pub.published)
+}
+
+func TestMergeIngredient_CombinesItems(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	pub := &stubUpdatePublisher{}
+	svc := NewPantryService(mockQ, pub)
+
+	oldID, survivorID := uuid.New(), uuid.New()
+	sooner := sql.NullTime{Time: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	old := db.PantryItem{ID: uuid.New(), IngredientID: oldID, Quantity: 500, Unit: "ml"}
+	survivor := db.PantryItem{ID: uuid.New(), IngredientID: survivorID, Quantity: 1, Unit: "l", ExpiresAt: sooner}
+	mockQ.EXPECT().GetPantryItemByIngredient(mock.Anything, oldID).Return(old, nil)
+	mockQ.EXPECT().GetPantryItemByIngredient(mock.Anything, survivorID).Return(survivor, nil)
+	mockQ.EXPECT().DeletePantryItem(mock.Anything, old.ID).Return(nil)
+	mockQ.EXPECT().UpsertPantryItemAdd(mock.Anything, db.UpsertPantryItemAddParams{
+		IngredientID: survivorID,
+		Quantity:     0.5,
+		Unit:         "l",
+		ExpiresAt:    sooner,
+	}).Return(db.PantryItem{ID: survivor.ID, IngredientID: survivorID, Quantity: 1.5, Unit: "l"}, nil)
+	mockQ.EXPECT().RemapStagedItemIngredient(mock.Anything, mock.Anything).Return(0, nil)
+
+	got, err := svc.MergeIngredient(context.Background(), oldID, survivorID)
+	require.NoError(t, err)
+	assert.Equal(t, MergeCombined, got.PantryItem)
+	assert.Equal(t, [][]uuid.UUID{{survivor.ID, old.ID}}, pub.published)
+}
+
+func TestMergeIngredient_KeepsIncompatibleUnits(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	pub := &stubUpdatePublisher{}
+	svc := NewPantryService(mockQ, pub)
+
+	oldID, survivorID := uuid.New(), uuid.New()
+	mockQ.EXPECT().GetPantryItemByIngredient(mock.Anything, oldID).
+		Return(db.PantryItem{ID: uuid.New(), IngredientID: oldID, Quantity: 2, Unit: "count"}, nil)
+	mockQ.EXPECT().GetPantryItemByIngredient(mock.Anything, survivorID).
+		Return(db.PantryItem{ID: uuid.New(), IngredientID: survivorID, Quantity: 1, Unit: "kg"}, nil)
+	mockQ.EXPECT().RemapStagedItemIngredient(mock.Anything, mock.Anything).Return(1, nil)
+
+	got, err := svc.MergeIngredient(context.Background(), oldID, survivorID)
+	require.NoError(t, err)
+	assert.Equal(t, IngredientMerge{PantryItem: MergeKept, StagedItems: 1}, got)
+	assert.Empty(t, pub.published)
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	clients "github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockIngredientLister is an autogenerated mock type for the IngredientLister type
+type MockIngredientLister struct {
+	mock.Mock
+}
+
+type MockIngredientLister_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIngredientLister) EXPECT() *MockIngredientLister_Expecter {
+	return &MockIngredientLister_Expecter{mock: &_m.Mock}
+}
+
+// ListIngredients provides a mock function with given fields: ctx
+func (_m *MockIngredientLister) ListIngredients(ctx context.Context) ([]clients.Ingredient, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngredients")
+	}
+
+	var r0 []clients.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]clients.Ingredient, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []clients.Ingredient); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]clients.Ingredient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIngredientLister_ListIngredients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredients'
+type MockIngredientLister_ListIngredients_Call struct {
+	*mock.Call
+}
+
+// ListIngredients is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIngredientLister_Expecter) ListIngredients(ctx interface{}) *MockIngredientLister_ListIngredients_Call {
+	return &MockIngredientLister_ListIngredients_Call{Call: _e.mock.On("ListIngredients", ctx)}
+}
+
+func (_c *MockIngredientLister_ListIngredients_Call) Run(run func(ctx context.Context)) *MockIngredientLister_ListIngredients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockIngredientLister_ListIngredients_Call) Return(_a0 []clients.Ingredient, _a1 error) *MockIngredientLister_ListIngredients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIngredientLister_ListIngredients_Call) RunAndReturn(run func(context.Context) ([]clients.Ingredient, error)) *MockIngredientLister_ListIngredients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIngredientLister creates a new instance of MockIngredientLister. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIngredientLister(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIngredientLister {
+	mock := &MockIngredientLister{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	clients "github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockIngredientLookup is an autogenerated mock type for the IngredientLookup type
+type MockIngredientLookup struct {
+	mock.Mock
+}
+
+type MockIngredientLookup_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIngredientLookup) EXPECT() *MockIngredientLookup_Expecter {
+	return &MockIngredientLookup_Expecter{mock: &_m.Mock}
+}
+
+// GetIngredient provides a mock function with given fields: ctx, id
+func (_m *MockIngredientLookup) GetIngredient(ctx context.Context, id uuid.UUID) (clients.Ingredient, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIngredient")
+	}
+
+	var r0 clients.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (clients.Ingredient, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) clients.Ingredient); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(clients.Ingredient)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIngredientLookup_GetIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIngredient'
+type MockIngredientLookup_GetIngredient_Call struct {
+	*mock.Call
+}
+
+// GetIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockIngredientLookup_Expecter) GetIngredient(ctx interface{}, id interface{}) *MockIngredientLookup_GetIngredient_Call {
+	return &MockIngredientLookup_GetIngredient_Call{Call: _e.mock.On("GetIngredient", ctx, id)}
+}
+
+func (_c *MockIngredientLookup_GetIngredient_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockIngredientLookup_GetIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockIngredientLookup_GetIngredient_Call) Return(_a0 clients.Ingredient, _a1 error) *MockIngredientLookup_GetIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIngredientLookup_GetIngredient_Call) RunAndReturn(run func(context.Context, uuid.UUID) (clients.Ingredient, error)) *MockIngredientLookup_GetIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIngredientLookup creates a new instance of MockIngredientLookup. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIngredientLookup(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIngredientLookup {
+	mock := &MockIngredientLookup{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
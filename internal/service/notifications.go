@@ -0,0 +1,468 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+// Notification kinds, one preference each.
+const (
+	NotifyExpiring = "expiring"
+	NotifyLowStock = "low_stock"
+)
+
+// ChannelNone turns a kind of notification off.
+const ChannelNone = "none"
+
+const (
+	// MaxNotificationAttempts is how many times the dispatcher tries to send a
+	// notification before giving up on it.
+	MaxNotificationAttempts = 5
+	// DefaultNotificationDispatchInterval is how often RunDispatch sends
+	// queued notifications.
+	DefaultNotificationDispatchInterval = 30 * time.Second
+	// DefaultLowStockScanInterval is how often RunLowStockScan checks the
+	// watchlist.
+	DefaultLowStockScanInterval = time.Hour
+	// DefaultNotificationListLimit and MaxNotificationListLimit bound Recent.
+	DefaultNotificationListLimit = 50
+	MaxNotificationListLimit     = 500
+	// notificationBatchSize bounds how many notifications one dispatch pass
+	// sends.
+	notificationBatchSize = 50
+)
+
+var (
+	// NotificationKinds and NotificationChannels are the accepted preference
+	// values. A channel is only usable once a sender is registered for it.
+	NotificationKinds    = []string{NotifyExpiring, NotifyLowStock}
+	NotificationChannels = []string{"email", "push", "webhook", ChannelNone}
+
+	// ErrInvalidPreference wraps notification preference validation failures.
+	ErrInvalidPreference = errors.New("invalid notification preference")
+	// ErrChannelUnavailable is returned when a preference names a channel
+	// this deployment has no sender for.
+	ErrChannelUnavailable = errors.New("notification channel is not configured")
+)
+
+// NotificationSender delivers a notification over one channel. notify.Sender
+// implementations satisfy it.
+type NotificationSender interface {
+	Validate(target string) error
+	Send(ctx context.Context, target, subject, body string) error
+}
+
+// signedSender is implemented by senders that can sign a payload with a
+// per-target secret, such as notify.WebhookSender.
+type signedSender interface {
+	SendSigned(ctx context.Context, target, secret, subject, body string) error
+}
+
+// NotificationPreference says where one kind of notification goes.
+type NotificationPreference struct {
+	Kind      string    `json:"kind"`
+	Channel   string    `json:"channel"`
+	Target    string    `json:"target,omitempty"`
+	Signed    bool      `json:"signed"` // the secret itself is never returned
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+}
+
+// Notification is a queued or sent notification.
+type Notification struct {
+	ID        int64      `json:"id"`
+	Kind      string     `json:"kind"`
+	Channel   string     `json:"channel"`
+	Target    string     `json:"target"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	Status    string     `json:"status"` // pending|sent|failed
+	Attempts  int32      `json:"attempts"`
+	LastError *string    `json:"last_error"`
+	SentAt    *time.Time `json:"sent_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NotificationService turns expiring and low-stock findings into
+// notifications on each kind's preferred channel. Notifications are queued
+// in the database and sent by RunDispatch, so a channel outage delays them
+// instead of losing them.
+type NotificationService struct {
+	q       db.Querier
+	lookup  IngredientLookup
+	senders map[string]NotificationSender
+	log     *slog.Logger
+
+	mu       sync.Mutex
+	lowStock map[uuid.UUID]bool // ingredients already announced as low
+}
+
+// NewNotificationService returns a service sending through senders, keyed
+// by channel name. lookup supplies ingredient names for message bodies.
+func NewNotificationService(
+	q db.Querier,
+	lookup IngredientLookup,
+	senders map[string]NotificationSender,
+) *NotificationService {
+	return &NotificationService{
+		q:        q,
+		lookup:   lookup,
+		senders:  senders,
+		log:      logging.For("notifications"),
+		lowStock: make(map[uuid.UUID]bool),
+	}
+}
+
+// Channels returns the channels a preference may currently use.
+func (s *NotificationService) Channels() []string {
+	out := []string{ChannelNone}
+	for name := range s.senders {
+		out = append(out, name)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// Preferences returns one preference per kind; kinds never configured are
+// reported with channel "none".
+func (s *NotificationService) Preferences(ctx context.Context) ([]NotificationPreference, error) {
+	rows, err := s.q.ListNotificationPreferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byKind := make(map[string]db.NotificationPreference, len(rows))
+	for _, r := range rows {
+		byKind[r.Kind] = r
+	}
+	out := make([]NotificationPreference, len(NotificationKinds))
+	for i, kind := range NotificationKinds {
+		out[i] = NotificationPreference{Kind: kind, Channel: ChannelNone}
+		if r, ok := byKind[kind]; ok {
+			out[i] = toNotificationPreference(r)
+		}
+	}
+	return out, nil
+}
+
+// SetPreference routes kind to channel. target is required for every channel
+// except "none" and is checked by the channel's sender. secret, when set,
+// signs webhook payloads to target and is rejected for other channels.
+func (s *NotificationService) SetPreference(
+	ctx context.Context,
+	kind, channel, target, secret string,
+) (NotificationPreference, error) {
+	target = strings.TrimSpace(target)
+	if !slices.Contains(NotificationKinds, kind) {
+		return NotificationPreference{}, fmt.Errorf("%w: kind must be one of %s",
+			ErrInvalidPreference, strings.Join(NotificationKinds, ", "))
+	}
+	if !slices.Contains(NotificationChannels, channel) {
+		return NotificationPreference{}, fmt.Errorf("%w: channel must be one of %s",
+			ErrInvalidPreference, strings.Join(NotificationChannels, ", "))
+	}
+	if secret != "" && channel != "webhook" {
+		return NotificationPreference{}, fmt.Errorf("%w: secret is only supported for channel webhook",
+			ErrInvalidPreference)
+	}
+	if channel == ChannelNone {
+		target = ""
+	} else {
+		sender, ok := s.senders[channel]
+		if !ok {
+			return NotificationPreference{}, fmt.Errorf("%w: %s", ErrChannelUnavailable, channel)
+		}
+		if target == "" {
+			return NotificationPreference{}, fmt.Errorf("%w: target is required for channel %s",
+				ErrInvalidPreference, channel)
+		}
+		if err := sender.Validate(target); err != nil {
+			return NotificationPreference{}, fmt.Errorf("%w: %w", ErrInvalidPreference, err)
+		}
+	}
+
+	row, err := s.q.UpsertNotificationPreference(ctx, db.UpsertNotificationPreferenceParams{
+		Kind:    kind,
+		Channel: channel,
+		Target:  target,
+		Secret:  secret,
+	})
+	if err != nil {
+		return NotificationPreference{}, err
+	}
+	return toNotificationPreference(row), nil
+}
+
+// Notify queues a notification on kind's preferred channel. It does nothing
+// when the kind is unconfigured or turned off.
+func (s *NotificationService) Notify(ctx context.Context, kind, subject, body string) error {
+	pref, err := s.q.GetNotificationPreference(ctx, kind)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get notification preference: %w", err)
+	}
+	if pref.Channel == ChannelNone {
+		return nil
+	}
+	if err := s.q.EnqueueNotification(ctx, db.EnqueueNotificationParams{
+		Kind:    kind,
+		Channel: pref.Channel,
+		Target:  pref.Target,
+		Subject: subject,
+		Body:    body,
+	}); err != nil {
+		return fmt.Errorf("enqueue notification: %w", err)
+	}
+	return nil
+}
+
+// NotifyExpiring queues one notification listing items that entered their
+// expiry window. It satisfies ExpiryNotifier.
+func (s *NotificationService) NotifyExpiring(ctx context.Context, items []ExpiringItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	for _, it := range items {
+		verb := "expires"
+		if it.Expired {
+			verb = "expired"
+		}
+		amount := fmt.Sprintf("%g %s", it.Item.Quantity, it.Item.Unit)
+		if !QuantityCounts(it.Item) {
+			amount = "amount unknown"
+		}
+		fmt.Fprintf(&b, "- %s (%s): %s %s\n", s.ingredientName(ctx, it.Item.IngredientID),
+			amount, verb, it.Item.ExpiresAt.Time.Format(time.DateOnly))
+	}
+	subject := fmt.Sprintf("%d pantry items are expiring soon", len(items))
+	if len(items) == 1 {
+		subject = "1 pantry item is expiring soon"
+	}
+	return s.Notify(ctx, NotifyExpiring, subject, b.String())
+}
+
+// ScanLowStock queues a notification for watched ingredients that became
+// missing since the last scan. An ingredient is announced again only after
+// it has been restocked and run low once more. Announcements are kept in
+// memory, so a restart repeats them once.
+func (s *NotificationService) ScanLowStock(ctx context.Context, wl *WatchlistService) error {
+	missing, err := wl.Missing(ctx)
+	if err != nil {
+		return fmt.Errorf("list missing ingredients: %w", err)
+	}
+
+	s.mu.Lock()
+	current := make(map[uuid.UUID]bool, len(missing))
+	var fresh []MissingIngredient
+	for _, m := range missing {
+		current[m.IngredientID] = true
+		if !s.lowStock[m.IngredientID] {
+			fresh = append(fresh, m)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(fresh) > 0 {
+		var b strings.Builder
+		for _, m := range fresh {
+			fmt.Fprintf(&b, "- %s: %s\n", s.ingredientName(ctx, m.IngredientID), describeShortfall(m))
+		}
+		subject := fmt.Sprintf("%d watched ingredients are running low", len(fresh))
+		if len(fresh) == 1 {
+			subject = "1 watched ingredient is running low"
+		}
+		if err := s.Notify(ctx, NotifyLowStock, subject, b.String()); err != nil {
+			return err
+		}
+		s.log.InfoContext(ctx, "low stock announced", "count", len(fresh))
+	}
+
+	s.mu.Lock()
+	s.lowStock = current
+	s.mu.Unlock()
+	return nil
+}
+
+// Dispatch sends up to one batch of queued notifications, oldest first. A
+// failed send is recorded and retried on a later pass until it has been
+// attempted MaxNotificationAttempts times. It returns how many were sent.
+func (s *NotificationService) Dispatch(ctx context.Context) (int, error) {
+	batch, err := s.q.ListUnsentNotifications(ctx, db.ListUnsentNotificationsParams{
+		Attempts: MaxNotificationAttempts,
+		Limit:    notificationBatchSize,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list unsent notifications: %w", err)
+	}
+
+	sent := 0
+	for _, n := range batch {
+		if err := s.send(ctx, n); err != nil {
+			s.log.WarnContext(ctx, "notification send failed",
+				"id", n.ID, "channel", n.Channel, "attempt", n.Attempts+1, "error", err)
+			if n.Attempts+1 >= MaxNotificationAttempts {
+				s.log.ErrorContext(ctx, "giving up on notification", "id", n.ID, "kind", n.Kind)
+			}
+			if recErr := s.q.RecordNotificationFailure(ctx, db.RecordNotificationFailureParams{
+				ID:        n.ID,
+				LastError: sql.NullString{String: err.Error(), Valid: true},
+			}); recErr != nil {
+				return sent, fmt.Errorf("record notification failure %d: %w", n.ID, recErr)
+			}
+			continue
+		}
+		// A failed update resends this notification on the next pass.
+		if err := s.q.MarkNotificationSent(ctx, n.ID); err != nil {
+			return sent, fmt.Errorf("mark notification %d sent: %w", n.ID, err)
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func (s *NotificationService) send(ctx context.Context, n db.Notification) error {
+	sender, ok := s.senders[n.Channel]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrChannelUnavailable, n.Channel)
+	}
+	if signed, ok := sender.(signedSender); ok {
+		secret, err := s.secretFor(ctx, n)
+		if err != nil {
+			return err
+		}
+		return signed.SendSigned(ctx, n.Target, secret, n.Subject, n.Body)
+	}
+	return sender.Send(ctx, n.Target, n.Subject, n.Body)
+}
+
+// secretFor returns the signing secret of n's kind, as long as the
+// preference still points at n's channel and target. A notification queued
+// before the target changed goes out unsigned rather than signed with the
+// new endpoint's secret.
+func (s *NotificationService) secretFor(ctx context.Context, n db.Notification) (string, error) {
+	pref, err := s.q.GetNotificationPreference(ctx, n.Kind)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get notification preference: %w", err)
+	}
+	if pref.Channel != n.Channel || pref.Target != n.Target {
+		return "", nil
+	}
+	return pref.Secret, nil
+}
+
+// Recent returns the newest notifications, sent or not.
+func (s *NotificationService) Recent(ctx context.Context, limit int) ([]Notification, error) {
+	rows, err := s.q.ListRecentNotifications(ctx, int32(limit)) //nolint:gosec // bounded by callers
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Notification, len(rows))
+	for i, r := range rows {
+		out[i] = Notification{
+			ID:        r.ID,
+			Kind:      r.Kind,
+			Channel:   r.Channel,
+			Target:    r.Target,
+			Subject:   r.Subject,
+			Body:      r.Body,
+			Status:    notificationStatus(r),
+			Attempts:  r.Attempts,
+			LastError: nullString(r.LastError),
+			CreatedAt: r.CreatedAt,
+		}
+		if r.SentAt.Valid {
+			out[i].SentAt = &r.SentAt.Time
+		}
+	}
+	return out, nil
+}
+
+// RunDispatch calls Dispatch every interval until ctx is cancelled.
+func (s *NotificationService) RunDispatch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.Dispatch(ctx)
+			if n > 0 {
+				s.log.InfoContext(ctx, "notifications sent", "count", n)
+			}
+			if err != nil {
+				s.log.WarnContext(ctx, "notification dispatch stopped; will retry", "error", err)
+			}
+		}
+	}
+}
+
+// RunLowStockScan calls ScanLowStock every interval until ctx is cancelled.
+func (s *NotificationService) RunLowStockScan(ctx context.Context, wl *WatchlistService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.ScanLowStock(ctx, wl); err != nil {
+			s.log.WarnContext(ctx, "low stock scan failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ingredientName returns the Dictionary name for id, or the ID itself if the
+// lookup fails; a notification with an ID beats no notification.
+func (s *NotificationService) ingredientName(ctx context.Context, id uuid.UUID) string {
+	ing, err := s.lookup.GetIngredient(ctx, id)
+	if err != nil || ing.Name == "" {
+		return id.String()
+	}
+	return ing.Name
+}
+
+func describeShortfall(m MissingIngredient) string {
+	if m.CurrentQuantity == nil {
+		return "out of stock"
+	}
+	if m.MinQuantity == nil || m.Unit == nil {
+		return fmt.Sprintf("%g %s left", *m.CurrentQuantity, *m.CurrentUnit)
+	}
+	return fmt.Sprintf("%g %s left (minimum %g %s)", *m.CurrentQuantity, *m.CurrentUnit, *m.MinQuantity, *m.Unit)
+}
+
+func notificationStatus(n db.Notification) string {
+	switch {
+	case n.SentAt.Valid:
+		return "sent"
+	case n.Attempts >= MaxNotificationAttempts:
+		return "failed"
+	default:
+		return "pending"
+	}
+}
+
+func toNotificationPreference(r db.NotificationPreference) NotificationPreference {
+	return NotificationPreference{
+		Kind: r.Kind, Channel: r.Channel, Target: r.Target,
+		Signed: r.Secret != "", UpdatedAt: r.UpdatedAt,
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+type fakeSender struct {
+	sendErr error
+	sent    []string // targets
+}
+
+func (s *fakeSender) Validate(target string) error {
+	if target == "bad" {
+		return errors.New("bad target")
+	}
+	return nil
+}
+
+func (s *fakeSender) Send(_ context.Context, target, _, _ string) error {
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.sent = append(s.sent, target)
+	return nil
+}
+
+func newTestNotifications(
+	t *testing.T,
+	sender *fakeSender,
+) (*NotificationService, *mocks.MockQuerier, *MockIngredientLookup) {
+	t.Helper()
+	mockQ := mocks.NewMockQuerier(t)
+	lookup := NewMockIngredientLookup(t)
+	svc := NewNotificationService(mockQ, lookup, map[string]NotificationSender{"webhook": sender})
+	return svc, mockQ, lookup
+}
+
+func TestSetPreference_Validates(t *testing.T) {
+	t.Parallel()
+
+	svc, mockQ, _ := newTestNotifications(t, &fakeSender{})
+	ctx := context.Background()
+
+	_, err := svc.SetPreference(ctx, "birthdays", "webhook", "https://example.com", "")
+	require.ErrorIs(t, err, ErrInvalidPreference)
+	_, err = svc.SetPreference(ctx, NotifyExpiring, "carrier-pigeon", "", "")
+	require.ErrorIs(t, err, ErrInvalidPreference)
+	_, err = svc.SetPreference(ctx, NotifyExpiring, "push", "token", "")
+	require.ErrorIs(t, err, ErrChannelUnavailable)
+	_, err = svc.SetPreference(ctx, NotifyExpiring, "webhook", " ", "")
+	require.ErrorIs(t, err, ErrInvalidPreference)
+	_, err = svc.SetPreference(ctx, NotifyExpiring, "webhook", "bad", "")
+	require.ErrorIs(t, err, ErrInvalidPreference)
+	_, err = svc.SetPreference(ctx, NotifyExpiring, ChannelNone, "", "s3cret")
+	require.ErrorIs(t, err, ErrInvalidPreference)
+
+	mockQ.EXPECT().UpsertNotificationPreference(mock.Anything, db.UpsertNotificationPreferenceParams{
+		Kind: NotifyLowStock, Channel: ChannelNone, Target: "",
+	}).Return(db.NotificationPreference{Kind: NotifyLowStock, Channel: ChannelNone}, nil)
+	pref, err := svc.SetPreference(ctx, NotifyLowStock, ChannelNone, "ignored", "")
+	require.NoError(t, err)
+	assert.Equal(t, ChannelNone, pref.Channel)
+}
+
+func TestPreferences_DefaultsToNone(t *testing.T) {
+	t.Parallel()
+
+	svc, mockQ, _ := newTestNotifications(t, &fakeSender{})
+	mockQ.EXPECT().ListNotificationPreferences(mock.Anything).Return([]db.NotificationPreference{
+		{Kind: NotifyLowStock, Channel: "webhook", Target: "https://example.com"},
+	}, nil)
+
+	prefs, err := svc.Preferences(context.Background())
+	require.NoError(t, err)
+	require.Len(t, prefs, 2)
+	assert.Equal(t, NotificationPreference{Kind: NotifyExpiring, Channel: ChannelNone}, prefs[0])
+	assert.Equal(t, "webhook", prefs[1].Channel)
+	assert.Equal(t, []string{ChannelNone, "webhook"}, svc.Channels())
+}
+
+func TestNotify_SkipsUnconfiguredKinds(t *testing.T) {
+	t.Parallel()
+
+	svc, mockQ, _ := newTestNotifications(t, &fakeSender{})
+	mockQ.EXPECT().GetNotificationPreference(mock.Anything, NotifyExpiring).
+		Return(db.NotificationPreference{}, sql.ErrNoRows)
+	mockQ.EXPECT().GetNotificationPreference(mock.Anything, NotifyLowStock).
+		Return(db.NotificationPreference{Kind: NotifyLowStock, Channel: ChannelNone}, nil)
+
+	require.NoError(t, svc.Notify(context.Background(), NotifyExpiring, "s", "b"))
+	require.NoError(t, svc.Notify(context.Background(), NotifyLowStock, "s", "b"))
+	// No EnqueueNotification expectation: nothing is queued.
+}
+
+func TestScanLowStock_AnnouncesUntilRestocked(t *testing.T) {
+	t.Parallel()
+
+	svc, mockQ, lookup := newTestNotifications(t, &fakeSender{})
+	wl := NewWatchlistService(mockQ)
+
+	eggs := uuid.New()
+	missing := []db.ListMissingWatchedIngredientsRow{{IngredientID: eggs}}
+	mockQ.EXPECT().ListMissingWatchedIngredients(mock.Anything).Return(missing, nil).Twice()
+	mockQ.EXPECT().ListMissingWatchedIngredients(mock.Anything).Return(nil, nil).Once()
+	mockQ.EXPECT().ListMissingWatchedIngredients(mock.Anything).Return(missing, nil).Once()
+	lookup.EXPECT().GetIngredient(mock.Anything, eggs).Return(clients.Ingredient{Name: "eggs"}, nil)
+	mockQ.EXPECT().GetNotificationPreference(mock.Anything, NotifyLowStock).
+		Return(db.NotificationPreference{Kind: NotifyLowStock, Channel: "webhook", Target: "https://example.com"}, nil)
+	mockQ.EXPECT().EnqueueNotification(mock.Anything, db.EnqueueNotificationParams{
+		Kind:    NotifyLowStock,
+		Channel: "webhook",
+		Target:  "https://example.com",
+		Subject: "1 watched ingredient is running low",
+		Body:    "- eggs: out of stock\n",
+	}).Return(nil).Twice()
+
+	ctx := context.Background()
+	require.NoError(t, svc.ScanLowStock(ctx, wl)) // announced
+	require.NoError(t, svc.ScanLowStock(ctx, wl)) // still low: quiet
+	require.NoError(t, svc.ScanLowStock(ctx, wl)) // restocked
+	require.NoError(t, svc.ScanLowStock(ctx, wl)) // low again: announced
+}
+
+func TestDispatch_SendsAndRecordsFailures(t *testing.T) {
+	t.Parallel()
+
+	sender := &fakeSender{}
+	svc, mockQ, _ := newTestNotifications(t, sender)
+
+	mockQ.EXPECT().ListUnsentNotifications(mock.Anything, db.ListUnsentNotificationsParams{
+		Attempts: MaxNotificationAttempts,
+		Limit:    notificationBatchSize,
+	}).Return([]db.Notification{
+		{ID: 1, Channel: "webhook", Target: "https://example.com"},
+		{ID: 2, Channel: "email", Target: "me@example.com"},
+	}, nil)
+	mockQ.EXPECT().MarkNotificationSent(mock.Anything, int64(1)).Return(nil)
+	failed := mock.MatchedBy(func(p db.RecordNotificationFailureParams) bool {
+		return p.ID == 2 && p.LastError.Valid
+	})
+	mockQ.EXPECT().RecordNotificationFailure(mock.Anything, failed).Return(nil)
+
+	sent, err := svc.Dispatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []string{"https://example.com"}, sender.sent)
+}
+
+type signingSender struct {
+	fakeSender
+	secrets []string
+}
+
+func (s *signingSender) SendSigned(ctx context.Context, target, secret, subject, body string) error {
+	s.secrets = append(s.secrets, secret)
+	return s.Send(ctx, target, subject, body)
+}
+
+func TestDispatch_SignsWithCurrentTargetSecret(t *testing.T) {
+	t.Parallel()
+
+	sender := &signingSender{}
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewNotificationService(mockQ, NewMockIngredientLookup(t), map[string]NotificationSender{"webhook": sender})
+
+	mockQ.EXPECT().ListUnsentNotifications(mock.Anything, mock.Anything).Return([]db.Notification{
+		{ID: 1, Kind: NotifyLowStock, Channel: "webhook", Target: "https://example.com/new"},
+		{ID: 2, Kind: NotifyLowStock, Channel: "webhook", Target: "https://example.com/old"},
+	}, nil)
+	mockQ.EXPECT().GetNotificationPreference(mock.Anything, NotifyLowStock).Return(db.NotificationPreference{
+		Kind: NotifyLowStock, Channel: "webhook", Target: "https://example.com/new", Secret: "s3cret",
+	}, nil).Twice()
+	mockQ.EXPECT().MarkNotificationSent(mock.Anything, mock.Anything).Return(nil).Twice()
+
+	sent, err := svc.Dispatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	// The notification queued for the previous target goes out unsigned.
+	assert.Equal(t, []string{"s3cret", ""}, sender.secrets)
+}
+
+func TestRecent_DerivesStatus(t *testing.T) {
+	t.Parallel()
+
+	svc, mockQ, _ := newTestNotifications(t, &fakeSender{})
+	mockQ.EXPECT().ListRecentNotifications(mock.Anything, int32(10)).Return([]db.Notification{
+		{ID: 3, SentAt: sql.NullTime{Valid: true}},
+		{ID: 2, Attempts: MaxNotificationAttempts, LastError: sql.NullString{String: "boom", Valid: true}},
+		{ID: 1, Attempts: 1},
+	}, nil)
+
+	out, err := svc.Recent(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, out, 3)
+	assert.Equal(t, "sent", out[0].Status)
+	assert.Equal(t, "failed", out[1].Status)
+	assert.Equal(t, "boom", *out[1].LastError)
+	assert.Equal(t, "pending", out[2].Status)
+}
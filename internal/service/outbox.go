@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+// outboxBatchSize bounds how many queued events one drain pass reads at a time.
+const outboxBatchSize = 100
+
+// EventDeliverer sends an event straight to the broker, without any fallback.
+type EventDeliverer interface {
+	Deliver(ctx context.Context, routingKey string, body []byte) error
+}
+
+// EventOutbox is a durable local queue for events published while the broker
+// was unreachable. It satisfies events.OutboxStore.
+type EventOutbox struct {
+	q   db.Querier
+	log *slog.Logger
+}
+
+func NewEventOutbox(q db.Querier) *EventOutbox {
+	return &EventOutbox{q: q, log: logging.For("outbox")}
+}
+
+func (o *EventOutbox) Enqueue(ctx context.Context, routingKey string, body []byte) error {
+	if err := o.q.EnqueueOutboxEvent(ctx, db.EnqueueOutboxEventParams{RoutingKey: routingKey, Body: body}); err != nil {
+		return fmt.Errorf("enqueue outbox event: %w", err)
+	}
+	o.log.WarnContext(ctx, "broker unavailable; event queued in outbox", "routing_key", routingKey)
+	return nil
+}
+
+// Pending returns the number of queued events.
+func (o *EventOutbox) Pending(ctx context.Context) (int64, error) {
+	return o.q.CountOutboxEvents(ctx)
+}
+
+// Drain delivers queued events oldest first, deleting each once delivered.
+// It stops at the first delivery failure so events stay in order, and
+// returns how many were delivered.
+func (o *EventOutbox) Drain(ctx context.Context, d EventDeliverer) (int, error) {
+	delivered := 0
+	for {
+		batch, err := o.q.ListOutboxEvents(ctx, outboxBatchSize)
+		if err != nil {
+			return delivered, fmt.Errorf("list outbox events: %w", err)
+		}
+		for _, ev := range batch {
+			if err := d.Deliver(ctx, ev.RoutingKey, ev.Body); err != nil {
+				if recErr := o.q.RecordOutboxFailure(ctx, db.RecordOutboxFailureParams{
+					ID:        ev.ID,
+					LastError: sql.NullString{String: err.Error(), Valid: true},
+				}); recErr != nil {
+					o.log.ErrorContext(ctx, "failed to record outbox failure", "id", ev.ID, "error", recErr)
+				}
+				return delivered, fmt.Errorf("deliver outbox event %d: %w", ev.ID, err)
+			}
+			// A failed delete redelivers this event on the next pass;
+			// consumers already deduplicate redeliveries.
+			if err := o.q.DeleteOutboxEvent(ctx, ev.ID); err != nil {
+				return delivered, fmt.Errorf("delete outbox event %d: %w", ev.ID, err)
+			}
+			delivered++
+		}
+		if len(batch) < outboxBatchSize {
+			return delivered, nil
+		}
+	}
+}
+
+// RunDrain calls Drain every interval until ctx is cancelled.
+func (o *EventOutbox) RunDrain(ctx context.Context, d EventDeliverer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := o.Drain(ctx, d)
+			if n > 0 {
+				o.log.InfoContext(ctx, "drained outbox events", "delivered", n)
+			}
+			if err != nil {
+				o.log.WarnContext(ctx, "outbox drain stopped; will retry", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/testutil"
+)
+
+func TestEventOutbox_QueueAndDrain(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	o := NewEventOutbox(db.New(sqlDB))
+	ctx := context.Background()
+
+	require.NoError(t, o.Enqueue(ctx, "pantry.updated", []byte(`{"n":1}`)))
+	require.NoError(t, o.Enqueue(ctx, "pantry.updated", []byte(`{"n":2}`)))
+
+	// Broker still down: nothing is lost and the failure is recorded.
+	_, err := o.Drain(ctx, &recordingDeliverer{failOn: "pantry.updated"})
+	require.Error(t, err)
+	pending, err := o.Pending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), pending)
+
+	d := &recordingDeliverer{}
+	n, err := o.Drain(ctx, d)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	pending, err = o.Pending(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, pending)
+}
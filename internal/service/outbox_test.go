@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+type recordingDeliverer struct {
+	keys   []string
+	failOn string
+}
+
+func (d *recordingDeliverer) Deliver(_ context.Context, key string, _ []byte) error {
+	if key == d.failOn {
+		return errors.New("broker down")
+	}
+	d.keys = append(d.keys, key)
+	return nil
+}
+
+func TestEventOutbox_DrainDeliversInOrder(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().ListOutboxEvents(mock.Anything, int32(outboxBatchSize)).Return([]db.EventOutbox{
+		{ID: 1, RoutingKey: "pantry.updated", Body: []byte(`{}`)},
+		{ID: 2, RoutingKey: "pantry.ingest.confirmed", Body: []byte(`{}`)},
+	}, nil)
+	mockQ.EXPECT().DeleteOutboxEvent(mock.Anything, int64(1)).Return(nil)
+	mockQ.EXPECT().DeleteOutboxEvent(mock.Anything, int64(2)).Return(nil)
+
+	d := &recordingDeliverer{}
+	n, err := NewEventOutbox(mockQ).Drain(context.Background(), d)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"pantry.updated", "pantry.ingest.confirmed"}, d.keys)
+}
+
+func TestEventOutbox_DrainStopsAtFirstFailure(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().ListOutboxEvents(mock.Anything, int32(outboxBatchSize)).Return([]db.EventOutbox{
+		{ID: 1, RoutingKey: "pantry.updated"},
+		{ID: 2, RoutingKey: "pantry.updated"},
+	}, nil)
+	mockQ.EXPECT().RecordOutboxFailure(mock.Anything, mock.MatchedBy(func(p db.RecordOutboxFailureParams) bool {
+		return p.ID == 1 && p.LastError.String == "broker down"
+	})).Return(nil)
+
+	n, err := NewEventOutbox(mockQ).Drain(context.Background(), &recordingDeliverer{failOn: "pantry.updated"})
+	require.ErrorContains(t, err, "deliver outbox event 1")
+	assert.Zero(t, n)
+}
+
+func TestEventOutbox_Enqueue(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().EnqueueOutboxEvent(mock.Anything, db.EnqueueOutboxEventParams{
+		RoutingKey: "pantry.updated",
+		Body:       []byte(`{"schema_version":1}`),
+	}).Return(nil)
+
+	err := NewEventOutbox(mockQ).Enqueue(context.Background(), "pantry.updated", []byte(`{"schema_version":1}`))
+	require.NoError(t, err)
+}
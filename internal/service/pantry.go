@@ -3,11 +3,17 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"log/slog"
+	"math"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+	"github.com/mwhite7112/woodpantry-pantry/internal/units"
 )
 
 // UpdatePublisher publishes pantry.updated events after stock changes.
@@ -15,10 +21,56 @@ type UpdatePublisher interface {
 	PublishPantryUpdated(ctx context.Context, changedItemIDs []uuid.UUID) error
 }
 
+// ConflictStrategy controls how an upsert merges into an existing row for the
+// same ingredient.
+type ConflictStrategy string
+
+const (
+	// ConflictReplace overwrites the stored quantity (the default).
+	ConflictReplace ConflictStrategy = "replace"
+	// ConflictAdd sums the incoming quantity into the stored one.
+	ConflictAdd ConflictStrategy = "add"
+	// ConflictMax keeps the larger of the stored and incoming quantities.
+	ConflictMax ConflictStrategy = "max"
+)
+
+// ParseConflictStrategy validates s, treating an empty string as replace.
+func ParseConflictStrategy(s string) (ConflictStrategy, error) {
+	switch ConflictStrategy(s) {
+	case "", ConflictReplace:
+		return ConflictReplace, nil
+	case ConflictAdd:
+		return ConflictAdd, nil
+	case ConflictMax:
+		return ConflictMax, nil
+	default:
+		return "", fmt.Errorf("unknown on_conflict strategy %q", s)
+	}
+}
+
+var (
+	// ErrQuantityUnknown is returned when consuming from an item whose amount
+	// is unknown and has no estimate to subtract from.
+	ErrQuantityUnknown = errors.New("item quantity is unknown")
+	// ErrIncompatibleUnit is returned when a consumed amount's unit cannot be
+	// converted to the item's unit.
+	ErrIncompatibleUnit = errors.New("unit cannot be converted to the item's unit")
+)
+
+// MaxQuantity is the largest quantity a pantry item or lot can hold; the
+// columns are NUMERIC(12,3).
+const MaxQuantity = 999999999.999
+
 // PantryService handles pantry item CRUD.
 type PantryService struct {
 	q         db.Querier
 	publisher UpdatePublisher
+	log       *slog.Logger
+
+	lotTracking bool
+	loc         *time.Location
+	ingredients *IngredientValidator
+	activity    *ActivityLog
 }
 
 func NewPantryService(q db.Querier, publishers ...UpdatePublisher) *PantryService {
@@ -30,7 +82,65 @@ func NewPantryService(q db.Querier, publishers ...UpdatePublisher) *PantryServic
 	return &PantryService{
 		q:         q,
 		publisher: publisher,
+		log:       logging.For("pantry"),
+		loc:       time.UTC,
+	}
+}
+
+// withQuerier returns a copy of s that runs its queries on q, such as a
+// transaction.
+func (s *PantryService) withQuerier(q db.Querier) *PantryService {
+	c := *s
+	c.q = q
+	return &c
+}
+
+// SetTimeZone sets the zone used to interpret date-only expirations.
+func (s *PantryService) SetTimeZone(loc *time.Location) {
+	if loc != nil {
+		s.loc = loc
+	}
+}
+
+// Location returns the zone used to interpret date-only expirations.
+func (s *PantryService) Location() *time.Location {
+	return s.loc
+}
+
+// SetIngredientValidator enables verification of caller-supplied ingredient
+// IDs. Without one, ValidateIngredient accepts every ID.
+func (s *PantryService) SetIngredientValidator(v *IngredientValidator) {
+	s.ingredients = v
+}
+
+// SetActivityLog records adds, removals and resets made through the service
+// to a, for GET /pantry/activity. Without one nothing is recorded.
+func (s *PantryService) SetActivityLog(a *ActivityLog) {
+	s.activity = a
+}
+
+// ValidateIngredient returns ErrUnknownIngredient for an ingredient ID the
+// Dictionary does not know, when validation is enabled.
+func (s *PantryService) ValidateIngredient(ctx context.Context, id uuid.UUID) error {
+	if s.ingredients == nil {
+		return nil
+	}
+	return s.ingredients.Validate(ctx, id)
+}
+
+// ParseExpiresAt parses an expires_at value. RFC3339 timestamps are taken
+// as-is. A bare date such as "2026-03-12" means the item is good through the
+// end of that day in the pantry's time zone, so "expires on the 12th" does
+// not shift a day depending on where the server runs.
+func (s *PantryService) ParseExpiresAt(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseInLocation(time.DateOnly, v, s.loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expires_at must be RFC3339 or YYYY-MM-DD, got %q", v)
 	}
+	return d.AddDate(0, 0, 1).Add(-time.Second), nil
 }
 
 func (s *PantryService) ListItems(ctx context.Context) ([]db.PantryItem, error) {
@@ -44,6 +154,79 @@ func (s *PantryService) ListItems(ctx context.Context) ([]db.PantryItem, error)
 	return items, nil
 }
 
+// MaxIngredientLookup bounds how many ingredient IDs one LookupItems call
+// may ask for.
+const MaxIngredientLookup = 500
+
+// GetItemByIngredient returns the item holding ingredientID, or
+// sql.ErrNoRows if the pantry has none.
+func (s *PantryService) GetItemByIngredient(ctx context.Context, ingredientID uuid.UUID) (db.PantryItem, error) {
+	return s.q.GetPantryItemByIngredient(ctx, ingredientID)
+}
+
+// LookupItems returns the items holding any of ingredientIDs, plus the IDs
+// the pantry has no item for, in request order. Duplicate IDs are ignored.
+func (s *PantryService) LookupItems(
+	ctx context.Context,
+	ingredientIDs []uuid.UUID,
+) ([]db.PantryItem, []uuid.UUID, error) {
+	items, err := s.q.ListPantryItemsByIngredients(ctx, ingredientIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	found := make(map[uuid.UUID]bool, len(items))
+	for _, item := range items {
+		found[item.IngredientID] = true
+	}
+	missing := []uuid.UUID{}
+	for _, id := range ingredientIDs {
+		if !found[id] {
+			missing = append(missing, id)
+			found[id] = true // report duplicates once
+		}
+	}
+	if items == nil {
+		items = []db.PantryItem{}
+	}
+	return items, missing, nil
+}
+
+// PantryDelta is the set of changes since a point in time. AsOf is the
+// cutoff the read is complete up to; pass it as the next since value.
+type PantryDelta struct {
+	Items   []db.PantryItem
+	Deleted []db.PantryItemTombstone
+	AsOf    time.Time
+}
+
+// ListChangesSince returns items modified at or after since plus tombstones
+// for items deleted at or after since. A write is stamped with the start of
+// its transaction but only seen once it commits, so the cutoff is the oldest
+// start of any transaction still open, read before the changes. A write that
+// commits after the read is therefore returned next time rather than missed;
+// some changes may be returned twice.
+func (s *PantryService) ListChangesSince(ctx context.Context, since time.Time) (PantryDelta, error) {
+	asOf, err := s.q.PantryChangesCutoff(ctx)
+	if err != nil {
+		return PantryDelta{}, fmt.Errorf("read changes cutoff: %w", err)
+	}
+	items, err := s.q.ListPantryItemsUpdatedSince(ctx, since)
+	if err != nil {
+		return PantryDelta{}, fmt.Errorf("list updated items: %w", err)
+	}
+	deleted, err := s.q.ListPantryTombstonesSince(ctx, since)
+	if err != nil {
+		return PantryDelta{}, fmt.Errorf("list tombstones: %w", err)
+	}
+	if items == nil {
+		items = []db.PantryItem{}
+	}
+	if deleted == nil {
+		deleted = []db.PantryItemTombstone{}
+	}
+	return PantryDelta{Items: items, Deleted: deleted, AsOf: asOf}, nil
+}
+
 func (s *PantryService) UpsertItem(
 	ctx context.Context,
 	ingredientID uuid.UUID,
@@ -51,15 +234,127 @@ func (s *PantryService) UpsertItem(
 	unit string,
 	expiresAt sql.NullTime,
 ) (db.PantryItem, error) {
-	item, err := s.UpsertItemNoPublish(ctx, ingredientID, quantity, unit, expiresAt)
+	return s.UpsertItemOnConflict(ctx, ConflictReplace, ingredientID, quantity, unit, expiresAt)
+}
+
+// UpsertItemOnConflict upserts an item, merging into an existing row for the
+// same ingredient according to strategy. The add and max strategies only
+// combine quantities when the units match; otherwise the incoming values win.
+func (s *PantryService) UpsertItemOnConflict(
+	ctx context.Context,
+	strategy ConflictStrategy,
+	ingredientID uuid.UUID,
+	quantity float64,
+	unit string,
+	expiresAt sql.NullTime,
+) (db.PantryItem, error) {
+	return s.UpsertItemInput(ctx, ItemInput{
+		IngredientID: ingredientID,
+		Quantity:     quantity,
+		Unit:         unit,
+		ExpiresAt:    expiresAt,
+		Strategy:     strategy,
+	})
+}
+
+// UpsertItemInput is UpsertItemOnConflict for an ItemInput, which can also
+// carry an unknown quantity.
+func (s *PantryService) UpsertItemInput(ctx context.Context, in ItemInput) (db.PantryItem, error) {
+	item, err := s.saveItem(ctx, in)
 	if err != nil {
 		return db.PantryItem{}, err
 	}
-
+	s.activity.recordAdded(ctx, in)
 	s.publishPantryUpdated(ctx, []uuid.UUID{item.ID})
 	return item, nil
 }
 
+// ItemInput is one entry of a batch upsert. QuantityUnknown marks stock whose
+// amount was not given ("in stock, amount unknown"); Quantity is then an
+// estimate, or zero when there is none.
+type ItemInput struct {
+	IngredientID    uuid.UUID
+	Quantity        float64
+	QuantityUnknown bool
+	Unit            string
+	ExpiresAt       sql.NullTime
+	Strategy        ConflictStrategy
+}
+
+// UpsertItems upserts each input independently, so one failure does not stop
+// the rest. items[i] and errs[i] belong to inputs[i]; exactly one is set. A
+// single pantry.updated event covers every saved item.
+func (s *PantryService) UpsertItems(ctx context.Context, inputs []ItemInput) ([]db.PantryItem, []error) {
+	items := make([]db.PantryItem, len(inputs))
+	errs := make([]error, len(inputs))
+	changed := make([]uuid.UUID, 0, len(inputs))
+	for i, in := range inputs {
+		items[i], errs[i] = s.saveItem(ctx, in)
+		if errs[i] == nil {
+			changed = append(changed, items[i].ID)
+			s.activity.recordAdded(ctx, in)
+		}
+	}
+	if len(changed) > 0 {
+		s.publishPantryUpdated(ctx, changed)
+	}
+	return items, errs
+}
+
+// UpsertItemsBulk saves every input with ConflictReplace in one statement;
+// in.Strategy is ignored. Unlike UpsertItems it is all or nothing. An
+// ingredient listed more than once takes its last entry, as saving one by one
+// would. items[i] belongs to inputs[i]. Nothing is published.
+func (s *PantryService) UpsertItemsBulk(ctx context.Context, inputs []ItemInput) ([]db.PantryItem, error) {
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+	last := make(map[uuid.UUID]int, len(inputs))
+	for i, in := range inputs {
+		last[in.IngredientID] = i
+	}
+	// ON CONFLICT cannot touch a row twice, so duplicates are dropped here.
+	var params db.UpsertPantryItemsParams
+	for i, in := range inputs {
+		if last[in.IngredientID] != i {
+			continue
+		}
+		params.IngredientIds = append(params.IngredientIds, in.IngredientID)
+		params.Quantities = append(params.Quantities, in.Quantity)
+		params.Units = append(params.Units, in.Unit)
+		params.ExpiresAt = append(params.ExpiresAt, in.ExpiresAt.Time)
+		params.HasExpiry = append(params.HasExpiry, in.ExpiresAt.Valid)
+		params.QuantityUnknown = append(params.QuantityUnknown, in.QuantityUnknown)
+	}
+	rows, err := s.q.UpsertPantryItems(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("bulk upsert pantry items: %w", err)
+	}
+
+	byIngredient := make(map[uuid.UUID]db.PantryItem, len(rows))
+	for _, row := range rows {
+		byIngredient[row.IngredientID] = row
+	}
+	items := make([]db.PantryItem, len(inputs))
+	for i, in := range inputs {
+		item, ok := byIngredient[in.IngredientID]
+		if !ok {
+			return nil, fmt.Errorf("bulk upsert returned no row for ingredient %s", in.IngredientID)
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+// saveItem upserts an item and, with lot tracking, reconciles its lots.
+func (s *PantryService) saveItem(ctx context.Context, in ItemInput) (db.PantryItem, error) {
+	item, err := s.upsertItem(ctx, in)
+	if err != nil || !s.lotTracking {
+		return item, err
+	}
+	return s.reconcileLots(ctx, item)
+}
+
 func (s *PantryService) UpsertItemNoPublish(
 	ctx context.Context,
 	ingredientID uuid.UUID,
@@ -67,18 +362,266 @@ func (s *PantryService) UpsertItemNoPublish(
 	unit string,
 	expiresAt sql.NullTime,
 ) (db.PantryItem, error) {
-	return s.q.UpsertPantryItem(ctx, db.UpsertPantryItemParams{
+	return s.saveItem(ctx, ItemInput{
 		IngredientID: ingredientID,
 		Quantity:     quantity,
 		Unit:         unit,
 		ExpiresAt:    expiresAt,
+		Strategy:     ConflictReplace,
+	})
+}
+
+func (s *PantryService) upsertItem(ctx context.Context, in ItemInput) (db.PantryItem, error) {
+	switch in.Strategy {
+	case ConflictAdd:
+		return s.q.UpsertPantryItemAdd(ctx, db.UpsertPantryItemAddParams{
+			IngredientID:    in.IngredientID,
+			Quantity:        in.Quantity,
+			Unit:            in.Unit,
+			ExpiresAt:       in.ExpiresAt,
+			QuantityUnknown: in.QuantityUnknown,
+		})
+	case ConflictMax:
+		return s.q.UpsertPantryItemMax(ctx, db.UpsertPantryItemMaxParams{
+			IngredientID:    in.IngredientID,
+			Quantity:        in.Quantity,
+			Unit:            in.Unit,
+			ExpiresAt:       in.ExpiresAt,
+			QuantityUnknown: in.QuantityUnknown,
+		})
+	case ConflictReplace:
+		return s.q.UpsertPantryItem(ctx, db.UpsertPantryItemParams{
+			IngredientID:    in.IngredientID,
+			Quantity:        in.Quantity,
+			Unit:            in.Unit,
+			ExpiresAt:       in.ExpiresAt,
+			QuantityUnknown: in.QuantityUnknown,
+		})
+	default:
+		return db.PantryItem{}, fmt.Errorf("unknown on_conflict strategy %q", in.Strategy)
+	}
+}
+
+// ItemPreview is what saving an ItemInput would do, worked out without
+// writing anything. Input is the entry with its unit normalized; saving it
+// with UpsertItemInput produces Result, as long as the existing row does not
+// change in between. Existing is nil when the ingredient is not stocked yet,
+// and Result then has no ID or timestamps.
+type ItemPreview struct {
+	Input        ItemInput
+	Existing     *db.PantryItem
+	Result       db.PantryItem
+	UnitReplaced bool // the existing row's unit could not be kept
+}
+
+// PreviewItem normalizes in and computes its merge into any existing row for
+// the same ingredient, mirroring the upsert statements for in.Strategy. A
+// known unit takes its canonical spelling, and is converted to the existing
+// row's unit where possible so the quantities can combine.
+func (s *PantryService) PreviewItem(ctx context.Context, in ItemInput) (ItemPreview, error) {
+	if c, ok := units.Canonical(in.Unit); ok {
+		in.Unit = c
+	}
+	existing, err := s.q.GetPantryItemByIngredient(ctx, in.IngredientID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ItemPreview{Input: in, Result: db.PantryItem{
+			IngredientID:    in.IngredientID,
+			Quantity:        in.Quantity,
+			Unit:            in.Unit,
+			ExpiresAt:       in.ExpiresAt,
+			QuantityUnknown: in.QuantityUnknown,
+		}}, nil
+	}
+	if err != nil {
+		return ItemPreview{}, err
+	}
+
+	switch {
+	case sameUnit(in.Unit, existing.Unit) || in.QuantityUnknown:
+		in.Unit = existing.Unit
+	default:
+		if q, ok := units.Convert(in.Quantity, in.Unit, existing.Unit); ok {
+			// Stored quantities are NUMERIC(12,3); round off conversion noise.
+			in.Quantity, in.Unit = math.Round(q*1000)/1000, existing.Unit
+		}
+	}
+
+	result := existing
+	result.Unit = in.Unit
+	result.Quantity, result.QuantityUnknown = in.Quantity, in.QuantityUnknown
+	sameUnits := in.Unit == existing.Unit
+	switch in.Strategy {
+	case ConflictAdd:
+		if sameUnits {
+			result.Quantity = math.Round((existing.Quantity+in.Quantity)*1000) / 1000
+			result.QuantityUnknown = existing.QuantityUnknown || in.QuantityUnknown
+		}
+	case ConflictMax:
+		if sameUnits && existing.Quantity >= in.Quantity {
+			result.Quantity, result.QuantityUnknown = existing.Quantity, existing.QuantityUnknown
+		}
+	case ConflictReplace:
+	default:
+		return ItemPreview{}, fmt.Errorf("unknown on_conflict strategy %q", in.Strategy)
+	}
+	if in.Strategy == ConflictReplace || in.ExpiresAt.Valid {
+		result.ExpiresAt = in.ExpiresAt
+	}
+	return ItemPreview{Input: in, Existing: &existing, Result: result, UnitReplaced: !sameUnits}, nil
+}
+
+// QuantityCounts reports whether item's quantity can take part in
+// availability math: a known quantity or an estimate can, an unknown amount
+// without an estimate cannot.
+func QuantityCounts(item db.PantryItem) bool {
+	return !item.QuantityUnknown || item.Quantity > 0
+}
+
+// ItemPatch is a partial update to one item; nil fields are left alone. A
+// non-nil ExpiresAt that is not Valid clears the expiry.
+type ItemPatch struct {
+	Quantity  *float64
+	Unit      *string
+	ExpiresAt *sql.NullTime
+}
+
+// UpdateItem applies patch to the item with id, returning sql.ErrNoRows if
+// there is none. Setting a quantity makes it known. With lot tracking the
+// item's lots are reconciled to the new quantity.
+func (s *PantryService) UpdateItem(ctx context.Context, id uuid.UUID, patch ItemPatch) (db.PantryItem, error) {
+	params := db.UpdatePantryItemParams{ID: id}
+	if patch.Quantity != nil {
+		params.Quantity = sql.NullFloat64{Float64: *patch.Quantity, Valid: true}
+	}
+	if patch.Unit != nil {
+		params.Unit = sql.NullString{String: *patch.Unit, Valid: true}
+	}
+	if patch.ExpiresAt != nil {
+		params.SetExpiresAt, params.ExpiresAt = true, *patch.ExpiresAt
+	}
+	item, err := s.q.UpdatePantryItem(ctx, params)
+	if err != nil {
+		return db.PantryItem{}, err
+	}
+	if s.lotTracking {
+		if item, err = s.reconcileLots(ctx, item); err != nil {
+			return db.PantryItem{}, err
+		}
+	}
+	s.publishPantryUpdated(ctx, []uuid.UUID{item.ID})
+	return item, nil
+}
+
+// Consumption is the outcome of ConsumeItem. Consumed is in the item's unit
+// and is less than requested when the pantry held less than that.
+type Consumption struct {
+	Item     db.PantryItem
+	Consumed float64
+}
+
+// ConsumeItem subtracts quantity from the item with id in one statement, so
+// concurrent consumers never lose each other's decrements. The quantity
+// stops at zero; the item is kept so it can be restocked. A non-empty unit
+// is converted to the item's unit first. An item with an unknown amount and
+// no estimate returns ErrQuantityUnknown; a missing item, sql.ErrNoRows.
+func (s *PantryService) ConsumeItem(
+	ctx context.Context,
+	id uuid.UUID,
+	quantity float64,
+	unit string,
+) (Consumption, error) {
+	consumed, err := s.consumeItem(ctx, id, quantity, unit)
+	if err != nil {
+		return Consumption{}, err
+	}
+	s.publishPantryUpdated(ctx, []uuid.UUID{consumed.Item.ID})
+	return consumed, nil
+}
+
+// consumeItem is ConsumeItem without the pantry.updated event.
+func (s *PantryService) consumeItem(
+	ctx context.Context,
+	id uuid.UUID,
+	quantity float64,
+	unit string,
+) (Consumption, error) {
+	var expectUnit sql.NullString
+	if unit != "" {
+		item, err := s.q.GetPantryItem(ctx, id)
+		if err != nil {
+			return Consumption{}, err
+		}
+		if unit != item.Unit {
+			converted, ok := units.Convert(quantity, unit, item.Unit)
+			if !ok {
+				return Consumption{}, fmt.Errorf("%w: %s to %s", ErrIncompatibleUnit, unit, item.Unit)
+			}
+			quantity = converted
+		}
+		// The conversion only holds while the item keeps this unit.
+		expectUnit = sql.NullString{String: item.Unit, Valid: true}
+	}
+
+	row, err := s.q.ConsumePantryItem(ctx, db.ConsumePantryItemParams{
+		Quantity: quantity,
+		ID:       id,
+		Unit:     expectUnit,
 	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return Consumption{}, s.consumeRefused(ctx, id)
+	}
+	if err != nil {
+		return Consumption{}, err
+	}
+
+	item := db.PantryItem{
+		ID:              row.ID,
+		IngredientID:    row.IngredientID,
+		Quantity:        row.Quantity,
+		Unit:            row.Unit,
+		ExpiresAt:       row.ExpiresAt,
+		AddedAt:         row.AddedAt,
+		UpdatedAt:       row.UpdatedAt,
+		QuantityUnknown: row.QuantityUnknown,
+	}
+	if s.lotTracking {
+		if item, err = s.reconcileLots(ctx, item); err != nil {
+			return Consumption{}, err
+		}
+	}
+	// Both sides are NUMERIC(12,3); rounding drops float noise from the
+	// subtraction.
+	consumed := math.Round((row.PreviousQuantity-row.Quantity)*1000) / 1000
+	s.activity.recordConsumed(ctx, item.IngredientID, consumed, item.Unit)
+	return Consumption{Item: item, Consumed: consumed}, nil
+}
+
+// consumeRefused explains why ConsumePantryItem matched no row.
+func (s *PantryService) consumeRefused(ctx context.Context, id uuid.UUID) error {
+	item, err := s.q.GetPantryItem(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !QuantityCounts(item) {
+		return ErrQuantityUnknown
+	}
+	return fmt.Errorf("%w: item unit changed to %s", ErrIncompatibleUnit, item.Unit)
 }
 
 func (s *PantryService) DeleteItem(ctx context.Context, id uuid.UUID) error {
+	// The activity feed names the ingredient, which is gone after the delete.
+	var removed uuid.NullUUID
+	if s.activity != nil {
+		if item, err := s.q.GetPantryItem(ctx, id); err == nil {
+			removed = uuid.NullUUID{UUID: item.IngredientID, Valid: true}
+		}
+	}
 	if err := s.q.DeletePantryItem(ctx, id); err != nil {
 		return err
 	}
+	if removed.Valid {
+		s.activity.recordRemoved(ctx, removed.UUID)
+	}
 
 	s.publishPantryUpdated(ctx, []uuid.UUID{id})
 	return nil
@@ -88,6 +631,7 @@ func (s *PantryService) Reset(ctx context.Context) error {
 	if err := s.q.DeleteAllPantryItems(ctx); err != nil {
 		return err
 	}
+	s.activity.record(ctx, db.InsertPantryActivityParams{Kind: ActivityPantryReset})
 
 	// The reset operation affects all items; emit an empty changed_item_ids list.
 	s.publishPantryUpdated(ctx, []uuid.UUID{})
@@ -100,7 +644,7 @@ func (s *PantryService) PublishUpdated(ctx context.Context, changedItemIDs []uui
 
 func (s *PantryService) publishPantryUpdated(ctx context.Context, changedItemIDs []uuid.UUID) {
 	if err := s.publisher.PublishPantryUpdated(ctx, changedItemIDs); err != nil {
-		slog.Default().WarnContext(
+		s.log.WarnContext(
 			ctx,
 			"failed to publish pantry.updated",
 			"changed_item_ids",
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PantryUpdate is the pantry.updated v1 payload as pushed to live feed and
+// webhook subscribers.
+type PantryUpdate struct {
+	SchemaVersion  int         `json:"schema_version"`
+	Timestamp      string      `json:"timestamp"`
+	ChangedItemIDs []uuid.UUID `json:"changed_item_ids"`
+}
+
+func newPantryUpdate(now time.Time, changedItemIDs []uuid.UUID) PantryUpdate {
+	if changedItemIDs == nil {
+		changedItemIDs = []uuid.UUID{}
+	}
+	return PantryUpdate{
+		SchemaVersion:  1,
+		Timestamp:      now.UTC().Format(time.RFC3339),
+		ChangedItemIDs: changedItemIDs,
+	}
+}
+
+// PantryFeed fans pantry.updated out to subscribers in this process, such as
+// the WebSocket feed. Delivery is best effort: a subscriber that falls a
+// full buffer behind is dropped, and its channel closed, rather than slowing
+// the publisher. It only sees changes made by this process.
+type PantryFeed struct {
+	mu   sync.Mutex
+	subs map[chan PantryUpdate]struct{}
+	now  func() time.Time
+}
+
+// NewPantryFeed returns a feed with no subscribers.
+func NewPantryFeed() *PantryFeed {
+	return &PantryFeed{subs: make(map[chan PantryUpdate]struct{}), now: time.Now}
+}
+
+// Subscribe registers a subscriber holding up to buffer pending updates. The
+// returned func unsubscribes; it is safe to call after the feed has dropped
+// the subscriber.
+func (f *PantryFeed) Subscribe(buffer int) (<-chan PantryUpdate, func()) {
+	ch := make(chan PantryUpdate, buffer)
+	f.mu.Lock()
+	f.subs[ch] = struct{}{}
+	f.mu.Unlock()
+	return ch, func() { f.drop(ch) }
+}
+
+// Subscribers returns how many subscribers are connected.
+func (f *PantryFeed) Subscribers() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.subs)
+}
+
+func (f *PantryFeed) drop(ch chan PantryUpdate) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.subs[ch]; ok {
+		delete(f.subs, ch)
+		close(ch)
+	}
+}
+
+// PublishPantryUpdated sends the update to every subscriber. It never
+// fails.
+func (f *PantryFeed) PublishPantryUpdated(_ context.Context, changedItemIDs []uuid.UUID) error {
+	update := newPantryUpdate(f.now(), changedItemIDs)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for ch := range f.subs {
+		select {
+		case ch <- update:
+		default:
+			delete(f.subs, ch)
+			close(ch)
+		}
+	}
+	return nil
+}
+
+// Wrap returns a publisher that passes pantry.updated on to next and also
+// sends it to the feed's subscribers.
+func (f *PantryFeed) Wrap(next UpdatePublisher) UpdatePublisher {
+	return feedTee{next: next, feed: f}
+}
+
+type feedTee struct {
+	next UpdatePublisher
+	feed *PantryFeed
+}
+
+func (t feedTee) PublishPantryUpdated(ctx context.Context, changedItemIDs []uuid.UUID) error {
+	return errors.Join(
+		t.next.PublishPantryUpdated(ctx, changedItemIDs),
+		t.feed.PublishPantryUpdated(ctx, changedItemIDs),
+	)
+}
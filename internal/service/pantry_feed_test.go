@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPantryFeed_Wrap(t *testing.T) {
+	t.Parallel()
+
+	feed := NewPantryFeed()
+	next := &stubUpdatePublisher{}
+	updates, unsubscribe := feed.Subscribe(1)
+	defer unsubscribe()
+
+	id := uuid.New()
+	require.NoError(t, feed.Wrap(next).PublishPantryUpdated(context.Background(), []uuid.UUID{id}))
+	assert.Equal(t, [][]uuid.UUID{{id}}, next.published)
+	assert.Equal(t, []uuid.UUID{id}, (<-updates).ChangedItemIDs)
+}
+
+func TestPantryFeed_DropsSlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	feed := NewPantryFeed()
+	updates, unsubscribe := feed.Subscribe(1)
+	require.NoError(t, feed.PublishPantryUpdated(context.Background(), nil))
+	require.NoError(t, feed.PublishPantryUpdated(context.Background(), nil))
+	assert.Equal(t, 0, feed.Subscribers())
+
+	first, ok := <-updates
+	assert.True(t, ok)
+	assert.Equal(t, []uuid.UUID{}, first.ChangedItemIDs)
+	_, ok = <-updates
+	assert.False(t, ok, "the channel is closed once the subscriber is dropped")
+	unsubscribe()
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -41,6 +42,49 @@ func TestPantry_UpsertIdempotent(t *testing.T) {
 	assert.Len(t, items, 1)
 }
 
+func TestPantry_QuantitiesAreExactDecimals(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := NewPantryService(q)
+	ctx := context.Background()
+
+	ingID := uuid.New()
+	_, err := svc.UpsertItem(ctx, ingID, 0.1, "cup", sql.NullTime{})
+	require.NoError(t, err)
+	item, err := svc.UpsertItemOnConflict(ctx, ConflictAdd, ingID, 0.2, "cup", sql.NullTime{})
+	require.NoError(t, err)
+	assert.Equal(t, 0.3, item.Quantity)
+
+	_, err = svc.UpsertItem(ctx, ingID, -1, "cup", sql.NullTime{})
+	require.Error(t, err, "negative quantities violate the check constraint")
+}
+
+func TestPantry_UpsertOnConflictStrategies(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := NewPantryService(q)
+	ctx := context.Background()
+
+	ingID := uuid.New()
+
+	_, err := svc.UpsertItem(ctx, ingID, 2.0, "cup", sql.NullTime{})
+	require.NoError(t, err)
+
+	added, err := svc.UpsertItemOnConflict(ctx, ConflictAdd, ingID, 1.5, "cup", sql.NullTime{})
+	require.NoError(t, err)
+	assert.Equal(t, 3.5, added.Quantity)
+
+	maxed, err := svc.UpsertItemOnConflict(ctx, ConflictMax, ingID, 1.0, "cup", sql.NullTime{})
+	require.NoError(t, err)
+	assert.Equal(t, 3.5, maxed.Quantity)
+
+	// Mismatched units cannot be combined; the incoming values win.
+	converted, err := svc.UpsertItemOnConflict(ctx, ConflictAdd, ingID, 500, "g", sql.NullTime{})
+	require.NoError(t, err)
+	assert.Equal(t, 500.0, converted.Quantity)
+	assert.Equal(t, "g", converted.Unit)
+}
+
 func TestPantry_DeleteItem(t *testing.T) {
 	sqlDB := testutil.SetupDB(t)
 	q := db.New(sqlDB)
@@ -82,3 +126,191 @@ func TestPantry_Reset(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, items, 0)
 }
+
+func TestPantry_AddStockKeepsSeparateLots(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	svc := NewPantryService(db.New(sqlDB))
+	svc.SetLotTracking(true)
+	ctx := context.Background()
+
+	ingID := uuid.New()
+	older := sql.NullTime{Time: time.Now().Add(48 * time.Hour), Valid: true}
+	newer := sql.NullTime{Time: time.Now().Add(10 * 24 * time.Hour), Valid: true}
+
+	_, err := svc.AddStockNoPublish(ctx, ingID, 1, "l", older, uuid.NullUUID{})
+	require.NoError(t, err)
+	item, err := svc.AddStockNoPublish(ctx, ingID, 2, "l", newer, uuid.NullUUID{})
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, item.Quantity)
+	assert.WithinDuration(t, older.Time, item.ExpiresAt.Time, time.Second)
+
+	// Same unit and expiry day merges into the existing lot.
+	_, err = svc.AddStockNoPublish(ctx, ingID, 1, "l", newer, uuid.NullUUID{})
+	require.NoError(t, err)
+
+	lots, err := svc.ListLots(ctx, item.ID)
+	require.NoError(t, err)
+	require.Len(t, lots, 2)
+	assert.Equal(t, 1.0, lots[0].Quantity)
+	assert.Equal(t, 3.0, lots[1].Quantity)
+}
+
+func TestPantry_LotsDepleteFIFO(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	svc := NewPantryService(db.New(sqlDB))
+	svc.SetLotTracking(true)
+	ctx := context.Background()
+
+	ingID := uuid.New()
+	soon := sql.NullTime{Time: time.Now().Add(24 * time.Hour), Valid: true}
+	later := sql.NullTime{Time: time.Now().Add(7 * 24 * time.Hour), Valid: true}
+
+	_, err := svc.AddStockNoPublish(ctx, ingID, 2, "l", later, uuid.NullUUID{})
+	require.NoError(t, err)
+	_, err = svc.AddStockNoPublish(ctx, ingID, 1, "l", soon, uuid.NullUUID{})
+	require.NoError(t, err)
+
+	// Drinking 1.5 l uses up the soonest-expiring carton first.
+	item, err := svc.UpsertItem(ctx, ingID, 1.5, "l", sql.NullTime{})
+	require.NoError(t, err)
+	assert.WithinDuration(t, later.Time, item.ExpiresAt.Time, time.Second)
+
+	lots, err := svc.ListLots(ctx, item.ID)
+	require.NoError(t, err)
+	require.Len(t, lots, 1)
+	assert.Equal(t, 1.5, lots[0].Quantity)
+
+	history, err := svc.ListLotHistory(ctx, item.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 4)
+	assert.Equal(t, LotEventConsumed, history[2].Kind)
+	assert.Equal(t, 1.0, history[2].Quantity)
+	assert.Equal(t, LotEventConsumed, history[3].Kind)
+	assert.Equal(t, 0.5, history[3].Quantity)
+}
+
+func TestReconciler_FindsAndRepairsLotDrift(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	svc := NewPantryService(q)
+	svc.SetLotTracking(true)
+	rc := NewReconciler(q, svc)
+	ctx := context.Background()
+
+	ingID := uuid.New()
+	_, err := svc.AddStockNoPublish(ctx, ingID, 2, "l", sql.NullTime{}, uuid.NullUUID{})
+	require.NoError(t, err)
+	// A unit change discards the litre lot; the history records it.
+	item, err := svc.AddStockNoPublish(ctx, ingID, 3, "cup", sql.NullTime{}, uuid.NullUUID{})
+	require.NoError(t, err)
+
+	report, err := rc.Reconcile(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.ItemsChecked)
+	assert.Empty(t, report.Drift)
+
+	// A lot grown without its history event or its item, as after a crash
+	// mid-confirm.
+	_, err = q.MergeIntoPantryLot(ctx, db.MergeIntoPantryLotParams{PantryItemID: item.ID, Quantity: 1, Unit: "cup"})
+	require.NoError(t, err)
+	report, err = rc.Reconcile(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, report.Drift, 2)
+	assert.Equal(t, DriftLotQuantity, report.Drift[0].Kind)
+	assert.Equal(t, DriftItemBelowLots, report.Drift[1].Kind)
+	assert.Equal(t, 2, report.Repaired)
+
+	report, err = rc.Reconcile(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, report.Drift)
+}
+
+func TestPantry_ListChangesSince(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	svc := NewPantryService(db.New(sqlDB))
+	ctx := context.Background()
+
+	kept, err := svc.UpsertItem(ctx, uuid.New(), 1, "cup", sql.NullTime{})
+	require.NoError(t, err)
+	removed, err := svc.UpsertItem(ctx, uuid.New(), 1, "cup", sql.NullTime{})
+	require.NoError(t, err)
+
+	first, err := svc.ListChangesSince(ctx, time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, first.Items, 2)
+	assert.Empty(t, first.Deleted)
+
+	require.NoError(t, svc.DeleteItem(ctx, removed.ID))
+	_, err = svc.UpsertItem(ctx, kept.IngredientID, 3, "cup", sql.NullTime{})
+	require.NoError(t, err)
+
+	delta, err := svc.ListChangesSince(ctx, first.AsOf)
+	require.NoError(t, err)
+	require.Len(t, delta.Items, 1)
+	assert.Equal(t, kept.ID, delta.Items[0].ID)
+	require.Len(t, delta.Deleted, 1)
+	assert.Equal(t, removed.ID, delta.Deleted[0].ItemID)
+}
+
+func TestPantry_ListChangesSinceSeesWriteCommittedAfterRead(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	svc := NewPantryService(db.New(sqlDB))
+	ctx := context.Background()
+
+	start, err := svc.ListChangesSince(ctx, time.Time{})
+	require.NoError(t, err)
+
+	// The write is stamped when its transaction starts, before the read
+	// below, but only commits after it.
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+	slow, err := db.New(tx).UpsertPantryItem(ctx, db.UpsertPantryItemParams{
+		IngredientID: uuid.New(), Quantity: 1, Unit: "cup",
+	})
+	require.NoError(t, err)
+
+	during, err := svc.ListChangesSince(ctx, start.AsOf)
+	require.NoError(t, err)
+	assert.Empty(t, during.Items)
+	assert.False(t, during.AsOf.After(slow.UpdatedAt), "the cutoff must not pass an open write")
+
+	require.NoError(t, tx.Commit())
+	after, err := svc.ListChangesSince(ctx, during.AsOf)
+	require.NoError(t, err)
+	require.Len(t, after.Items, 1)
+	assert.Equal(t, slow.ID, after.Items[0].ID)
+}
+
+func TestWatchlist_Missing(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	pantry := NewPantryService(q)
+	wl := NewWatchlistService(q)
+	ctx := context.Background()
+
+	absent, low, stocked, mismatched := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	two, unit, cup := 2.0, "l", "cup"
+
+	_, err := wl.Watch(ctx, absent, nil, nil)
+	require.NoError(t, err)
+	_, err = wl.Watch(ctx, low, &two, &unit)
+	require.NoError(t, err)
+	_, err = wl.Watch(ctx, stocked, &two, &unit)
+	require.NoError(t, err)
+	_, err = wl.Watch(ctx, mismatched, &two, &cup)
+	require.NoError(t, err)
+
+	_, err = pantry.UpsertItem(ctx, low, 0.5, "l", sql.NullTime{})
+	require.NoError(t, err)
+	_, err = pantry.UpsertItem(ctx, stocked, 3, "l", sql.NullTime{})
+	require.NoError(t, err)
+	_, err = pantry.UpsertItem(ctx, mismatched, 1, "l", sql.NullTime{})
+	require.NoError(t, err)
+
+	missing, err := wl.Missing(ctx)
+	require.NoError(t, err)
+	require.Len(t, missing, 2)
+	assert.Equal(t, absent, missing[0].IngredientID)
+	assert.Equal(t, low, missing[1].IngredientID)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+// DefaultPantryPageLimit and MaxPantryPageLimit bound ListItemsPage.
+const (
+	DefaultPantryPageLimit = 100
+	MaxPantryPageLimit     = 500
+)
+
+// ErrInvalidCursor is returned for a pagination cursor this service did not
+// issue.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PantryPage is one page of pantry items in the order they were added.
+// NextCursor is empty on the last page. Total counts the whole pantry.
+type PantryPage struct {
+	Items      []db.PantryItem
+	Total      int64
+	NextCursor string
+}
+
+// ListItemsPage returns up to limit items after cursor, or from the start
+// when cursor is empty. The cursor is the position of the last item returned,
+// so items added or removed between requests do not shift later pages.
+func (s *PantryService) ListItemsPage(ctx context.Context, cursor string, limit int) (PantryPage, error) {
+	params := db.ListPantryItemsPageParams{
+		Limit: int32(limit + 1), //nolint:gosec // bounded by MaxPantryPageLimit in the handler
+	}
+	if cursor != "" {
+		addedAt, id, err := decodePantryCursor(cursor)
+		if err != nil {
+			return PantryPage{}, err
+		}
+		params.AfterAddedAt = sql.NullTime{Time: addedAt, Valid: true}
+		params.AfterID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	items, err := s.q.ListPantryItemsPage(ctx, params)
+	if err != nil {
+		return PantryPage{}, fmt.Errorf("list pantry page: %w", err)
+	}
+	total, err := s.q.CountPantryItems(ctx)
+	if err != nil {
+		return PantryPage{}, fmt.Errorf("count pantry items: %w", err)
+	}
+
+	page := PantryPage{Items: items, Total: total}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		last := page.Items[limit-1]
+		page.NextCursor = encodePantryCursor(last.AddedAt, last.ID)
+	}
+	if page.Items == nil {
+		page.Items = []db.PantryItem{}
+	}
+	return page, nil
+}
+
+func encodePantryCursor(addedAt time.Time, id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(addedAt.UTC().Format(time.RFC3339Nano) + "|" + id.String()))
+}
+
+func decodePantryCursor(cursor string) (time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+	ts, idStr, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+	addedAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+	return addedAt, id, nil
+}
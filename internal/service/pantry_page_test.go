@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestPantryCursor_RoundTrips(t *testing.T) {
+	t.Parallel()
+
+	addedAt := time.Date(2026, 2, 1, 8, 30, 0, 123456000, time.UTC)
+	id := uuid.New()
+	gotAt, gotID, err := decodePantryCursor(encodePantryCursor(addedAt, id))
+	require.NoError(t, err)
+	assert.True(t, addedAt.Equal(gotAt))
+	assert.Equal(t, id, gotID)
+
+	for _, bad := range []string{"!!", "bm8tc2VwYXJhdG9y", encodePantryCursor(addedAt, id)[:10]} {
+		_, _, err := decodePantryCursor(bad)
+		require.ErrorIs(t, err, ErrInvalidCursor, bad)
+	}
+}
+
+func TestListItemsPage_LastPage(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+
+	mockQ.EXPECT().ListPantryItemsPage(mock.Anything, db.ListPantryItemsPageParams{Limit: 11}).Return(nil, nil)
+	mockQ.EXPECT().CountPantryItems(mock.Anything).Return(int64(0), nil)
+
+	page, err := svc.ListItemsPage(context.Background(), "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []db.PantryItem{}, page.Items)
+	assert.Empty(t, page.NextCursor)
+
+	_, err = svc.ListItemsPage(context.Background(), "garbage", 10)
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
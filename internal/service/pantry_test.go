@@ -76,6 +76,83 @@ func TestListItems_ReturnsEmptySliceOnNil(t *testing.T) {
 	assert.Empty(t, items)
 }
 
+func TestLookupItems_ReportsMissingOnce(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+
+	have, missing := uuid.New(), uuid.New()
+	ids := []uuid.UUID{missing, have, missing}
+	mockQ.EXPECT().ListPantryItemsByIngredients(mock.Anything, ids).
+		Return([]db.PantryItem{{ID: uuid.New(), IngredientID: have}}, nil)
+
+	items, gone, err := svc.LookupItems(context.Background(), ids)
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, []uuid.UUID{missing}, gone)
+}
+
+func TestUpsertItems_ContinuesPastFailures(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+
+	bad, good := uuid.New(), uuid.New()
+	saved := db.PantryItem{ID: uuid.New(), IngredientID: good}
+	mockQ.EXPECT().UpsertPantryItem(mock.Anything, mock.MatchedBy(func(p db.UpsertPantryItemParams) bool {
+		return p.IngredientID == bad
+	})).Return(db.PantryItem{}, errors.New("constraint"))
+	mockQ.EXPECT().UpsertPantryItemMax(mock.Anything, mock.MatchedBy(func(p db.UpsertPantryItemMaxParams) bool {
+		return p.IngredientID == good
+	})).Return(saved, nil)
+
+	items, errs := svc.UpsertItems(context.Background(), []ItemInput{
+		{IngredientID: bad, Quantity: 1, Unit: "g", Strategy: ConflictReplace},
+		{IngredientID: good, Quantity: 1, Unit: "g", Strategy: ConflictMax},
+	})
+	require.Error(t, errs[0])
+	require.NoError(t, errs[1])
+	assert.Equal(t, saved, items[1])
+}
+
+func TestUpsertItemsBulk_OneStatementLastEntryWins(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+
+	milk, eggs := uuid.New(), uuid.New()
+	expires := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockQ.EXPECT().UpsertPantryItems(mock.Anything, db.UpsertPantryItemsParams{
+		IngredientIds:   []uuid.UUID{eggs, milk},
+		Quantities:      []float64{12, 2},
+		Units:           []string{"piece", "l"},
+		ExpiresAt:       []time.Time{{}, expires},
+		HasExpiry:       []bool{false, true},
+		QuantityUnknown: []bool{false, false},
+	}).Return([]db.PantryItem{
+		{ID: uuid.New(), IngredientID: milk},
+		{ID: uuid.New(), IngredientID: eggs},
+	}, nil)
+
+	items, err := svc.UpsertItemsBulk(context.Background(), []ItemInput{
+		{IngredientID: milk, Quantity: 1, Unit: "l"},
+		{IngredientID: eggs, Quantity: 12, Unit: "piece", Strategy: ConflictAdd},
+		{IngredientID: milk, Quantity: 2, Unit: "l", ExpiresAt: sql.NullTime{Time: expires, Valid: true}},
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, milk, items[0].IngredientID)
+	assert.Equal(t, eggs, items[1].IngredientID)
+	assert.Equal(t, items[0].ID, items[2].ID)
+
+	mockQ.EXPECT().UpsertPantryItems(mock.Anything, mock.Anything).Return(nil, errors.New("deadlock"))
+	_, err = svc.UpsertItemsBulk(context.Background(), []ItemInput{{IngredientID: milk, Quantity: 1, Unit: "l"}})
+	require.Error(t, err)
+}
+
 func TestUpsertItem_DelegatesToQuerier(t *testing.T) {
 	t.Parallel()
 
@@ -106,6 +183,102 @@ func TestUpsertItem_DelegatesToQuerier(t *testing.T) {
 	assert.Equal(t, expected, item)
 }
 
+func TestUpsertItemOnConflict_UsesStrategyQuery(t *testing.T) {
+	t.Parallel()
+
+	ingredientID := uuid.New()
+	expected := db.PantryItem{ID: uuid.New(), IngredientID: ingredientID, Quantity: 5, Unit: "cup"}
+
+	t.Run("add", func(t *testing.T) {
+		t.Parallel()
+
+		mockQ := mocks.NewMockQuerier(t)
+		svc := NewPantryService(mockQ)
+		mockQ.EXPECT().UpsertPantryItemAdd(mock.Anything, db.UpsertPantryItemAddParams{
+			IngredientID: ingredientID,
+			Quantity:     2,
+			Unit:         "cup",
+		}).Return(expected, nil)
+
+		item, err := svc.UpsertItemOnConflict(context.Background(), ConflictAdd, ingredientID, 2, "cup", sql.NullTime{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, item)
+	})
+
+	t.Run("max", func(t *testing.T) {
+		t.Parallel()
+
+		mockQ := mocks.NewMockQuerier(t)
+		svc := NewPantryService(mockQ)
+		mockQ.EXPECT().UpsertPantryItemMax(mock.Anything, db.UpsertPantryItemMaxParams{
+			IngredientID: ingredientID,
+			Quantity:     2,
+			Unit:         "cup",
+		}).Return(expected, nil)
+
+		item, err := svc.UpsertItemOnConflict(context.Background(), ConflictMax, ingredientID, 2, "cup", sql.NullTime{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, item)
+	})
+}
+
+func TestParseConflictStrategy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		want    ConflictStrategy
+		wantErr bool
+	}{
+		{"", ConflictReplace, false},
+		{"replace", ConflictReplace, false},
+		{"add", ConflictAdd, false},
+		{"max", ConflictMax, false},
+		{"sum", "", true},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseConflictStrategy(tc.in)
+		if tc.wantErr {
+			require.Error(t, err, tc.in)
+			continue
+		}
+		require.NoError(t, err, tc.in)
+		assert.Equal(t, tc.want, got)
+	}
+}
+
+func TestParseExpiresAt(t *testing.T) {
+	t.Parallel()
+
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	svc := NewPantryService(mocks.NewMockQuerier(t))
+	svc.SetTimeZone(ny)
+
+	got, err := svc.ParseExpiresAt("2026-03-12")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 12, 23, 59, 59, 0, ny), got)
+	assert.Equal(t, 12, got.In(ny).Day())
+
+	got, err = svc.ParseExpiresAt("2026-03-12T10:00:00Z")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 12, 10, 0, 0, 0, time.UTC), got.UTC())
+
+	_, err = svc.ParseExpiresAt("12/03/2026")
+	require.Error(t, err)
+}
+
+func TestParseExpiresAt_DefaultsToUTC(t *testing.T) {
+	t.Parallel()
+
+	svc := NewPantryService(mocks.NewMockQuerier(t))
+	got, err := svc.ParseExpiresAt("2026-03-12")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 12, 23, 59, 59, 0, time.UTC), got)
+}
+
 func TestDeleteItem_DelegatesToQuerier(t *testing.T) {
 	t.Parallel()
 
@@ -119,6 +292,207 @@ func TestDeleteItem_DelegatesToQuerier(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestUpdateItem_SetsOnlyPatchedFields(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	pub := &stubUpdatePublisher{}
+	svc := NewPantryService(mockQ, pub)
+
+	id := uuid.New()
+	unit := "kg"
+	mockQ.EXPECT().UpdatePantryItem(mock.Anything, db.UpdatePantryItemParams{
+		ID:           id,
+		Unit:         sql.NullString{String: "kg", Valid: true},
+		SetExpiresAt: true,
+	}).Return(db.PantryItem{ID: id, Unit: "kg"}, nil)
+
+	item, err := svc.UpdateItem(context.Background(), id, ItemPatch{Unit: &unit, ExpiresAt: &sql.NullTime{}})
+	require.NoError(t, err)
+	assert.Equal(t, "kg", item.Unit)
+	require.Len(t, pub.published, 1)
+	assert.Equal(t, []uuid.UUID{id}, pub.published[0])
+}
+
+func TestUpdateItem_NotFound(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+
+	qty := 2.0
+	mockQ.EXPECT().UpdatePantryItem(mock.Anything, mock.Anything).Return(db.PantryItem{}, sql.ErrNoRows)
+
+	_, err := svc.UpdateItem(context.Background(), uuid.New(), ItemPatch{Quantity: &qty})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestPreviewItem_NewIngredient(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+
+	ingredientID := uuid.New()
+	mockQ.EXPECT().GetPantryItemByIngredient(mock.Anything, ingredientID).Return(db.PantryItem{}, sql.ErrNoRows)
+
+	got, err := svc.PreviewItem(context.Background(), ItemInput{
+		IngredientID: ingredientID, Quantity: 2, Unit: "Cups", Strategy: ConflictAdd,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, got.Existing)
+	assert.Equal(t, "cup", got.Input.Unit)
+	assert.Equal(t, 2.0, got.Result.Quantity)
+	assert.Equal(t, "cup", got.Result.Unit)
+}
+
+func TestPreviewItem_MergesIntoExisting(t *testing.T) {
+	t.Parallel()
+
+	ingredientID := uuid.New()
+	expires := sql.NullTime{Time: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	existing := db.PantryItem{
+		ID: uuid.New(), IngredientID: ingredientID, Quantity: 1, Unit: "kg", ExpiresAt: expires,
+	}
+
+	tests := []struct {
+		name         string
+		in           ItemInput
+		wantQuantity float64
+		wantUnit     string
+		wantExpires  sql.NullTime
+		wantReplaced bool
+	}{
+		{
+			name:         "add converts to the stored unit",
+			in:           ItemInput{Quantity: 500, Unit: "g", Strategy: ConflictAdd},
+			wantQuantity: 1.5, wantUnit: "kg", wantExpires: expires,
+		},
+		{
+			name:         "max keeps the larger amount",
+			in:           ItemInput{Quantity: 200, Unit: "g", Strategy: ConflictMax},
+			wantQuantity: 1, wantUnit: "kg", wantExpires: expires,
+		},
+		{
+			name:         "replace overwrites the expiry",
+			in:           ItemInput{Quantity: 2, Unit: "kilograms", Strategy: ConflictReplace},
+			wantQuantity: 2, wantUnit: "kg",
+		},
+		{
+			name:         "unconvertible unit replaces the stored one",
+			in:           ItemInput{Quantity: 3, Unit: "bag", Strategy: ConflictAdd},
+			wantQuantity: 3, wantUnit: "bag", wantExpires: expires, wantReplaced: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ := mocks.NewMockQuerier(t)
+			svc := NewPantryService(mockQ)
+			mockQ.EXPECT().GetPantryItemByIngredient(mock.Anything, ingredientID).Return(existing, nil)
+
+			tc.in.IngredientID = ingredientID
+			got, err := svc.PreviewItem(context.Background(), tc.in)
+			require.NoError(t, err)
+			require.NotNil(t, got.Existing)
+			assert.Equal(t, existing.ID, got.Result.ID)
+			assert.Equal(t, tc.wantQuantity, got.Result.Quantity)
+			assert.Equal(t, tc.wantUnit, got.Result.Unit)
+			assert.Equal(t, tc.wantExpires, got.Result.ExpiresAt)
+			assert.Equal(t, tc.wantReplaced, got.UnitReplaced)
+		})
+	}
+}
+
+func TestConsumeItem_DecrementsAndPublishes(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	pub := &stubUpdatePublisher{}
+	svc := NewPantryService(mockQ, pub)
+
+	id := uuid.New()
+	mockQ.EXPECT().ConsumePantryItem(mock.Anything, db.ConsumePantryItemParams{Quantity: 0.3, ID: id}).
+		Return(db.ConsumePantryItemRow{ID: id, Quantity: 0.7, Unit: "kg", PreviousQuantity: 1}, nil)
+
+	got, err := svc.ConsumeItem(context.Background(), id, 0.3, "")
+	require.NoError(t, err)
+	assert.Equal(t, 0.7, got.Item.Quantity)
+	assert.Equal(t, 0.3, got.Consumed)
+	require.Len(t, pub.published, 1)
+	assert.Equal(t, []uuid.UUID{id}, pub.published[0])
+}
+
+func TestConsumeItem_ConvertsUnitAndStopsAtZero(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+
+	id := uuid.New()
+	mockQ.EXPECT().GetPantryItem(mock.Anything, id).Return(db.PantryItem{ID: id, Quantity: 0.2, Unit: "kg"}, nil)
+	mockQ.EXPECT().ConsumePantryItem(mock.Anything, db.ConsumePantryItemParams{
+		Quantity: 0.5,
+		ID:       id,
+		Unit:     sql.NullString{String: "kg", Valid: true},
+	}).Return(db.ConsumePantryItemRow{ID: id, Quantity: 0, Unit: "kg", PreviousQuantity: 0.2}, nil)
+
+	got, err := svc.ConsumeItem(context.Background(), id, 500, "g")
+	require.NoError(t, err)
+	assert.Zero(t, got.Item.Quantity)
+	assert.Equal(t, 0.2, got.Consumed)
+}
+
+func TestConsumeItem_Refused(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+	tests := []struct {
+		name    string
+		current db.PantryItem
+		want    error
+	}{
+		{
+			name:    "unknown amount",
+			current: db.PantryItem{ID: id, Unit: "bottle", QuantityUnknown: true},
+			want:    ErrQuantityUnknown,
+		},
+		{
+			name:    "unit changed",
+			current: db.PantryItem{ID: id, Quantity: 3, Unit: "cup"},
+			want:    ErrIncompatibleUnit,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockQ := mocks.NewMockQuerier(t)
+			svc := NewPantryService(mockQ)
+			mockQ.EXPECT().ConsumePantryItem(mock.Anything, mock.Anything).
+				Return(db.ConsumePantryItemRow{}, sql.ErrNoRows)
+			mockQ.EXPECT().GetPantryItem(mock.Anything, id).Return(tc.current, nil)
+
+			_, err := svc.ConsumeItem(context.Background(), id, 1, "")
+			require.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestConsumeItem_IncompatibleUnit(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewPantryService(mockQ)
+
+	id := uuid.New()
+	mockQ.EXPECT().GetPantryItem(mock.Anything, id).Return(db.PantryItem{ID: id, Quantity: 2, Unit: "kg"}, nil)
+
+	_, err := svc.ConsumeItem(context.Background(), id, 1, "cup")
+	require.ErrorIs(t, err, ErrIncompatibleUnit)
+}
+
 func TestReset_DelegatesToDeleteAllPantryItems(t *testing.T) {
 	t.Parallel()
 
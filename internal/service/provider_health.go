@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+const (
+	// DefaultProviderFailureThreshold is how many extractions in a row must
+	// fail before the LLM provider is treated as unhealthy.
+	DefaultProviderFailureThreshold = 3
+	// DefaultProviderProbeInterval is how often RunProbe checks an unhealthy
+	// provider for recovery.
+	DefaultProviderProbeInterval = 30 * time.Second
+)
+
+// ErrIngestDeferred is returned by ProcessJobAsync when the LLM provider is
+// unhealthy. The job was accepted and stays pending; it is queued once the
+// provider recovers.
+var ErrIngestDeferred = errors.New("llm provider unavailable; ingest deferred")
+
+// ProviderPinger is a cheap call that succeeds only when the LLM provider is
+// reachable and accepts our credentials.
+type ProviderPinger interface {
+	Ping(ctx context.Context) error
+}
+
+// ProviderHealthStatus is a point-in-time view of the LLM provider's health.
+type ProviderHealthStatus struct {
+	Healthy             bool       `json:"healthy"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	UnhealthySince      *time.Time `json:"unhealthy_since,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	DeferredJobs        int        `json:"deferred_jobs"`
+}
+
+// ProviderHealth tracks whether the LLM provider is worth sending work to.
+// Extraction outcomes drive it passively: threshold failures in a row mark
+// the provider unhealthy and one success marks it healthy again. While it is
+// unhealthy, RunProbe pings the provider so recovery is noticed without
+// spending a real extraction.
+type ProviderHealth struct {
+	pinger    ProviderPinger
+	threshold int
+	now       func() time.Time
+	log       *slog.Logger
+
+	mu             sync.Mutex
+	failures       int
+	unhealthySince time.Time
+	lastErr        string
+	onRecover      []func()
+}
+
+func NewProviderHealth(pinger ProviderPinger, threshold int) *ProviderHealth {
+	return &ProviderHealth{
+		pinger:    pinger,
+		threshold: max(threshold, 1),
+		now:       time.Now,
+		log:       logging.For("llm-health"),
+	}
+}
+
+// Healthy reports whether new extractions are expected to succeed.
+func (h *ProviderHealth) Healthy() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.unhealthySince.IsZero()
+}
+
+// RecordSuccess marks the provider healthy and, if it was not, runs the
+// recovery callbacks.
+func (h *ProviderHealth) RecordSuccess() {
+	h.mu.Lock()
+	recovered := !h.unhealthySince.IsZero()
+	h.failures, h.unhealthySince, h.lastErr = 0, time.Time{}, ""
+	callbacks := h.onRecover
+	h.mu.Unlock()
+
+	if recovered {
+		h.log.Info("llm provider recovered")
+		for _, fn := range callbacks {
+			fn()
+		}
+	}
+}
+
+// RecordFailure counts a failed call and marks the provider unhealthy once
+// the threshold is reached.
+func (h *ProviderHealth) RecordFailure(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.failures++
+	h.lastErr = err.Error()
+	if h.failures >= h.threshold && h.unhealthySince.IsZero() {
+		h.unhealthySince = h.now()
+		h.log.Warn("llm provider marked unhealthy", "consecutive_failures", h.failures, "error", err)
+	}
+}
+
+// OnRecover registers fn to run whenever the provider turns healthy again.
+func (h *ProviderHealth) OnRecover(fn func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onRecover = append(h.onRecover, fn)
+}
+
+// Status reports the current health. DeferredJobs is filled in by the
+// ingest service.
+func (h *ProviderHealth) Status() ProviderHealthStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	status := ProviderHealthStatus{
+		Healthy:             h.unhealthySince.IsZero(),
+		ConsecutiveFailures: h.failures,
+		LastError:           h.lastErr,
+	}
+	if !status.Healthy {
+		since := h.unhealthySince
+		status.UnhealthySince = &since
+	}
+	return status
+}
+
+// Probe pings the provider if it is unhealthy and records the outcome. A
+// healthy provider is not pinged.
+func (h *ProviderHealth) Probe(ctx context.Context) {
+	if h.Healthy() || h.pinger == nil {
+		return
+	}
+	if err := h.pinger.Ping(ctx); err != nil {
+		h.mu.Lock()
+		h.lastErr = err.Error()
+		h.mu.Unlock()
+		return
+	}
+	h.RecordSuccess()
+}
+
+// RunProbe calls Probe every interval until ctx is cancelled.
+func (h *ProviderHealth) RunProbe(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.Probe(ctx)
+		}
+	}
+}
+
+// maxDeferredJobs caps jobs held back while the provider is unhealthy; past
+// it, ProcessJobAsync reports ErrIngestQueueFull.
+const maxDeferredJobs = DefaultIngestQueueSize
+
+// SetProviderHealth gates ingest on h: extraction outcomes are recorded
+// against it, and while it is unhealthy ProcessJobAsync holds jobs back
+// instead of running them. Held jobs are queued when h recovers. They are
+// kept in memory, so a restart leaves them pending.
+func (s *IngestService) SetProviderHealth(h *ProviderHealth) {
+	s.health = h
+	h.OnRecover(s.resumeDeferred)
+}
+
+// ProviderHealth reports the LLM provider's health and how many jobs are
+// waiting for it. ok is false when no health tracking is configured.
+func (s *IngestService) ProviderHealth() (ProviderHealthStatus, bool) {
+	if s.health == nil {
+		return ProviderHealthStatus{}, false
+	}
+	status := s.health.Status()
+	s.deferredMu.Lock()
+	status.DeferredJobs = len(s.deferred)
+	s.deferredMu.Unlock()
+	return status, true
+}
+
+// deferJob holds task until the provider recovers.
+func (s *IngestService) deferJob(task ingestTask) error {
+	s.deferredMu.Lock()
+	defer s.deferredMu.Unlock()
+	if len(s.deferred) >= maxDeferredJobs {
+		return ErrIngestQueueFull
+	}
+	s.deferred = append(s.deferred, task)
+	s.log.Info("ingest job deferred; llm provider unhealthy", "job_id", task.jobID)
+	return ErrIngestDeferred
+}
+
+// resumeDeferred hands held jobs to the workers. It blocks on a full queue
+// rather than failing jobs that were already accepted, so it runs on its own
+// goroutine.
+func (s *IngestService) resumeDeferred() {
+	s.deferredMu.Lock()
+	tasks := s.deferred
+	s.deferred = nil
+	s.deferredMu.Unlock()
+	if len(tasks) == 0 {
+		return
+	}
+
+	s.log.Info("resuming deferred ingest jobs", "count", len(tasks))
+	go func() {
+		for _, task := range tasks {
+			if s.pool == nil {
+				go s.runJob(task) //nolint:errcheck // logged and recorded on the job
+				continue
+			}
+			s.pool.queueFor(task.priority) <- task
+		}
+	}()
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+type stubPinger struct{ err error }
+
+func (p *stubPinger) Ping(context.Context) error { return p.err }
+
+func TestProviderHealth_Threshold(t *testing.T) {
+	t.Parallel()
+
+	h := NewProviderHealth(nil, 2)
+	h.RecordFailure(errors.New("timeout"))
+	assert.True(t, h.Healthy(), "one failure is below the threshold")
+
+	h.RecordFailure(errors.New("status 503"))
+	require.False(t, h.Healthy())
+	status := h.Status()
+	assert.Equal(t, 2, status.ConsecutiveFailures)
+	assert.Equal(t, "status 503", status.LastError)
+	assert.NotNil(t, status.UnhealthySince)
+
+	h.RecordSuccess()
+	assert.True(t, h.Healthy())
+	assert.Zero(t, h.Status().ConsecutiveFailures)
+}
+
+func TestProviderHealth_Probe(t *testing.T) {
+	t.Parallel()
+
+	pinger := &stubPinger{err: errors.New("connection refused")}
+	h := NewProviderHealth(pinger, 1)
+	recovered := 0
+	h.OnRecover(func() { recovered++ })
+	h.RecordFailure(errors.New("status 500"))
+
+	h.Probe(context.Background())
+	assert.False(t, h.Healthy())
+	assert.Equal(t, "connection refused", h.Status().LastError)
+	assert.Zero(t, recovered)
+
+	pinger.err = nil
+	h.Probe(context.Background())
+	assert.True(t, h.Healthy())
+	assert.Equal(t, 1, recovered)
+
+	h.Probe(context.Background())
+	assert.Equal(t, 1, recovered, "a healthy provider is not probed")
+}
+
+func TestProcessJobAsync_DefersUntilProviderRecovers(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockLLM := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), mockLLM)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	svc.StartWorkers(ctx, 1, 1)
+	pinger := &stubPinger{err: errors.New("down")}
+	health := NewProviderHealth(pinger, 1)
+	svc.SetProviderHealth(health)
+	health.RecordFailure(errors.New("status 503"))
+
+	jobID := uuid.New()
+	require.ErrorIs(t, svc.ProcessJobAsync(context.Background(), jobID, "milk", PriorityInteractive), ErrIngestDeferred)
+	status, ok := svc.ProviderHealth()
+	require.True(t, ok)
+	assert.Equal(t, 1, status.DeferredJobs)
+
+	mockLLM.EXPECT().Extract(mock.Anything, "milk").Return(&ExtractionResponse{}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, toProcessing(jobID)).Return(db.IngestionJob{}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, db.TransitionIngestionJobStatusParams{
+		ToStatus:   "staged",
+		ID:         jobID,
+		FromStatus: "processing",
+	}).Return(db.IngestionJob{}, nil)
+
+	pinger.err = nil
+	health.Probe(ctx)
+	require.Eventually(t, func() bool {
+		return svc.WorkerStatus().Processed == 1
+	}, time.Second, 5*time.Millisecond)
+	status, _ = svc.ProviderHealth()
+	assert.True(t, status.Healthy)
+	assert.Zero(t, status.DeferredJobs)
+}
+
+func TestExtract_RecordsProviderFailures(t *testing.T) {
+	t.Parallel()
+
+	mockLLM := NewMockLLMExtractor(t)
+	svc := NewIngestService(mocks.NewMockQuerier(t), NewMockDictionaryResolver(t), mockLLM)
+	health := NewProviderHealth(nil, 2)
+	svc.SetProviderHealth(health)
+
+	mockLLM.EXPECT().Extract(mock.Anything, mock.Anything).Return(nil, errors.New("openai timeout")).Times(2)
+	for range 2 {
+		_, err := svc.extract(context.Background(), uuid.New(), "milk")
+		require.Error(t, err)
+	}
+	assert.False(t, health.Healthy())
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultReadOnlyMessage is returned to writers when read-only mode is on
+// without a message of its own.
+const DefaultReadOnlyMessage = "the pantry is in read-only mode for maintenance; try again later"
+
+// ReadOnlyMode is an operational switch that turns away writes while reads
+// keep working, for migrations, Dictionary remaps and restores. It is held
+// in memory, so a toggle applies to this instance only; set READ_ONLY_MODE
+// to start every instance read-only.
+type ReadOnlyMode struct {
+	mu    sync.RWMutex
+	state ReadOnlyState
+	now   func() time.Time
+}
+
+// ReadOnlyState is the current mode. Since is when it was last switched on.
+type ReadOnlyState struct {
+	Enabled bool       `json:"enabled"`
+	Message string     `json:"message,omitempty"`
+	Since   *time.Time `json:"since,omitempty"`
+}
+
+func NewReadOnlyMode(enabled bool, message string) *ReadOnlyMode {
+	m := &ReadOnlyMode{now: time.Now}
+	m.Set(enabled, message)
+	return m
+}
+
+// Set switches the mode. An empty message while enabling uses
+// DefaultReadOnlyMessage.
+func (m *ReadOnlyMode) Set(enabled bool, message string) ReadOnlyState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !enabled {
+		m.state = ReadOnlyState{}
+		return m.state
+	}
+	message = strings.TrimSpace(message)
+	if message == "" {
+		message = DefaultReadOnlyMessage
+	}
+	since := m.state.Since
+	if !m.state.Enabled {
+		now := m.now().UTC()
+		since = &now
+	}
+	m.state = ReadOnlyState{Enabled: true, Message: message, Since: since}
+	return m.state
+}
+
+func (m *ReadOnlyMode) State() ReadOnlyState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyMode_Set(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m := NewReadOnlyMode(false, "")
+	m.now = func() time.Time { return now }
+	assert.Equal(t, ReadOnlyState{}, m.State())
+
+	state := m.Set(true, "  ")
+	assert.True(t, state.Enabled)
+	assert.Equal(t, DefaultReadOnlyMessage, state.Message)
+	require.NotNil(t, state.Since)
+	assert.Equal(t, now, *state.Since)
+
+	// Changing the message keeps the original start.
+	m.now = func() time.Time { return now.Add(time.Hour) }
+	state = m.Set(true, "remapping ingredients")
+	assert.Equal(t, "remapping ingredients", state.Message)
+	assert.Equal(t, now, *state.Since)
+
+	assert.Equal(t, ReadOnlyState{}, m.Set(false, "ignored"))
+	assert.Equal(t, ReadOnlyState{}, m.State())
+}
+
+func TestNewReadOnlyMode_StartsEnabled(t *testing.T) {
+	t.Parallel()
+
+	state := NewReadOnlyMode(true, "migrating").State()
+	assert.True(t, state.Enabled)
+	assert.Equal(t, "migrating", state.Message)
+	assert.NotNil(t, state.Since)
+}
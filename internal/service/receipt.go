@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// JobTypeReceiptImage is the ingest job type for a photographed receipt.
+const JobTypeReceiptImage = "receipt_image"
+
+// MaxReceiptImageBytes caps a receipt or fridge photo. Phone photos are a few
+// megabytes; the image is stored base64-encoded on the job until it is
+// processed.
+const MaxReceiptImageBytes = 8 << 20
+
+// receiptMediaTypes are the image formats vision models accept.
+var receiptMediaTypes = []string{"image/jpeg", "image/png", "image/webp", "image/gif"}
+
+var (
+	// ErrInvalidImage wraps receipt image validation failures.
+	ErrInvalidImage = errors.New("invalid receipt image")
+	// ErrImageUnsupported is returned when an image job reaches an extractor
+	// that cannot read images, such as the heuristic fallback once the LLM
+	// budget is exhausted.
+	ErrImageUnsupported = errors.New("extractor cannot read images")
+)
+
+const receiptPrompt = `This is a photo of a grocery receipt. Extract the food and household items that were bought.
+Use the printed line as "raw_text" and expand store abbreviations in "name"
+(e.g. "ORG BNLS CHKN BRST" is "chicken breast").
+Use the quantity and unit printed on the line (e.g. "2 @", "0.82 kg");
+if none is printed, the quantity is 1 and the unit is "".
+Skip prices, totals, taxes, discounts, deposits, bag fees, loyalty lines and payment details.
+Set confidence below 0.7 for lines you cannot read clearly.`
+
+// imageJobParam is the data URL parameter naming a photo's job type when it
+// is not a receipt, so extraction can pick the prompt from the input alone.
+const imageJobParam = ";job="
+
+// IsImageJob reports whether jobType carries a photo rather than text.
+func IsImageJob(jobType string) bool {
+	return jobType == JobTypeReceiptImage || jobType == JobTypeFridgePhoto
+}
+
+// EncodeImageInput checks a photo for an image job type and encodes it as a
+// job's raw input: a base64 data URL, so queued, deferred and re-run jobs
+// carry the image like any other input. The format is sniffed from the
+// bytes.
+func EncodeImageInput(jobType string, image []byte) (string, error) {
+	if len(image) == 0 {
+		return "", fmt.Errorf("%w: image is empty", ErrInvalidImage)
+	}
+	if len(image) > MaxReceiptImageBytes {
+		return "", fmt.Errorf("%w: image is larger than %d MB", ErrInvalidImage, MaxReceiptImageBytes>>20)
+	}
+	mediaType := http.DetectContentType(image)
+	if !slices.Contains(receiptMediaTypes, mediaType) {
+		return "", fmt.Errorf("%w: unsupported format %s; use %s", ErrInvalidImage, mediaType,
+			strings.Join(receiptMediaTypes, ", "))
+	}
+	if jobType != JobTypeReceiptImage {
+		mediaType += imageJobParam + jobType
+	}
+	return imageDataURL(mediaType, image), nil
+}
+
+func imageDataURL(mediaType string, image []byte) string {
+	return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(image)
+}
+
+// decodeImageInput reverses EncodeImageInput. ok is false for text input.
+func decodeImageInput(raw string) (jobType, mediaType string, image []byte, ok bool) {
+	rest, found := strings.CutPrefix(raw, "data:image/")
+	if !found {
+		return "", "", nil, false
+	}
+	params, data, found := strings.Cut(rest, ";base64,")
+	if !found {
+		return "", "", nil, false
+	}
+	subtype, jobType, found := strings.Cut(params, imageJobParam)
+	if !found {
+		jobType = JobTypeReceiptImage
+	}
+	if !IsImageJob(jobType) || !slices.Contains(receiptMediaTypes, "image/"+subtype) {
+		return "", "", nil, false
+	}
+	image, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", "", nil, false
+	}
+	return jobType, "image/" + subtype, image, true
+}
+
+// extractInput runs a job's raw input through extractor, sending photos to
+// its ImageExtractor side.
+func extractInput(ctx context.Context, extractor LLMExtractor, input string) (*ExtractionResponse, error) {
+	jobType, mediaType, image, ok := decodeImageInput(input)
+	if !ok {
+		return extractor.Extract(ctx, input)
+	}
+	vision, ok := extractor.(ImageExtractor)
+	if !ok {
+		return nil, ErrImageUnsupported
+	}
+	return vision.ExtractImage(ctx, jobType, mediaType, image)
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/testutil/llmserver"
+)
+
+var testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestEncodeImageInput(t *testing.T) {
+	t.Parallel()
+
+	input, err := EncodeImageInput(JobTypeReceiptImage, testPNG)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(input, "data:image/png;base64,"))
+
+	jobType, mediaType, image, ok := decodeImageInput(input)
+	require.True(t, ok)
+	assert.Equal(t, JobTypeReceiptImage, jobType)
+	assert.Equal(t, "image/png", mediaType)
+	assert.Equal(t, testPNG, image)
+
+	input, err = EncodeImageInput(JobTypeFridgePhoto, testPNG)
+	require.NoError(t, err)
+	jobType, mediaType, image, ok = decodeImageInput(input)
+	require.True(t, ok)
+	assert.Equal(t, JobTypeFridgePhoto, jobType)
+	assert.Equal(t, "image/png", mediaType)
+	assert.Equal(t, testPNG, image)
+
+	_, _, _, ok = decodeImageInput("2 cups flour")
+	assert.False(t, ok, "text input is not an image")
+
+	_, err = EncodeImageInput(JobTypeReceiptImage, []byte("%PDF-1.7"))
+	require.ErrorIs(t, err, ErrInvalidImage)
+	_, err = EncodeImageInput(JobTypeReceiptImage, nil)
+	require.ErrorIs(t, err, ErrInvalidImage)
+}
+
+func TestOpenAIExtractor_ExtractImage(t *testing.T) {
+	t.Parallel()
+
+	server := llmserver.New(t, llmserver.WithDefault(llmserver.HappyPath(ExtractionResponse{
+		Items: []ExtractedItem{
+			{RawText: "ORG BANANAS 1.2 kg", Name: "banana", Quantity: 1.2, Unit: "kg", Confidence: 0.9},
+		},
+	})))
+	extractor := NewOpenAIExtractor("sk-test", "gpt-test", WithBaseURL(server.URL), WithVisionModel("gpt-vision"))
+
+	resp, err := extractor.ExtractImage(context.Background(), JobTypeReceiptImage, "image/png", testPNG)
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, "banana", resp.Items[0].Name)
+
+	reqs := server.Requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "gpt-vision", reqs[0].Model)
+	parts, ok := reqs[0].Messages[len(reqs[0].Messages)-1].Content.([]any)
+	require.True(t, ok, "the user message carries content parts")
+	require.Len(t, parts, 2)
+	image, _ := parts[1].(map[string]any)
+	assert.Equal(t, "image_url", image["type"])
+	url, _ := image["image_url"].(map[string]any)
+	assert.Equal(t, imageDataURL("image/png", testPNG), url["url"])
+}
+
+func TestProcessJob_ReceiptImage(t *testing.T) {
+	t.Parallel()
+
+	server := llmserver.New(t, llmserver.WithDefault(llmserver.HappyPath(ExtractionResponse{
+		Items: []ExtractedItem{{RawText: "WHL MLK 2L", Name: "milk", Quantity: 2, Unit: "l", Confidence: 0.9}},
+	})))
+	mockQ := mocks.NewMockQuerier(t)
+	mockDict := NewMockDictionaryResolver(t)
+	svc := NewIngestService(mockQ, mockDict, NewOpenAIExtractor("sk-test", "gpt-test", WithBaseURL(server.URL)))
+
+	mockDict.EXPECT().Resolve(mock.Anything, "milk").
+		Return(clients.ResolveResult{Ingredient: clients.Ingredient{ID: uuid.New(), Name: "milk"}}, nil)
+	mockQ.EXPECT().CreateStagedItem(mock.Anything, mock.MatchedBy(func(p db.CreateStagedItemParams) bool {
+		return p.RawText == "WHL MLK 2L" && p.Unit == "l"
+	})).Return(db.StagedItem{}, nil)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	input, err := EncodeImageInput(JobTypeReceiptImage, testPNG)
+	require.NoError(t, err)
+	require.NoError(t, svc.processJob(context.Background(), uuid.New(), input))
+}
+
+func TestExtractInput_ImageNeedsVision(t *testing.T) {
+	t.Parallel()
+
+	input, err := EncodeImageInput(JobTypeReceiptImage, testPNG)
+	require.NoError(t, err)
+	_, err = extractInput(context.Background(), NewHeuristicExtractor(), input)
+	assert.ErrorIs(t, err, ErrImageUnsupported)
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+// DefaultReconcileInterval is how often the scheduled reconciliation runs.
+const DefaultReconcileInterval = 24 * time.Hour
+
+// reconcileTolerance absorbs float rounding when comparing quantities.
+const reconcileTolerance = 1e-6
+
+// Drift kinds found by reconciliation.
+const (
+	// DriftLotQuantity is a lot holding a different quantity than its
+	// history adds up to.
+	DriftLotQuantity = "lot_quantity"
+	// DriftLotUnrecorded is a lot with no history at all.
+	DriftLotUnrecorded = "lot_unrecorded"
+	// DriftLotMissing is a lot whose history still holds stock but whose row
+	// is gone.
+	DriftLotMissing = "lot_missing"
+	// DriftItemBelowLots is an item whose quantity is less than its lots
+	// hold, which reconcileLots otherwise never allows.
+	DriftItemBelowLots = "item_below_lots"
+)
+
+// driftKinds lists every kind, sorted, as metrics report them.
+var driftKinds = []string{DriftItemBelowLots, DriftLotMissing, DriftLotQuantity, DriftLotUnrecorded}
+
+// Drift is one disagreement between the pantry rows and their history.
+// Expected is what the history implies (for item_below_lots, the total its
+// lots hold) and Actual is what is stored.
+type Drift struct {
+	Kind         string     `json:"kind"`
+	ItemID       uuid.UUID  `json:"item_id"`
+	IngredientID uuid.UUID  `json:"ingredient_id"`
+	LotID        *uuid.UUID `json:"lot_id,omitempty"`
+	Unit         string     `json:"unit"`
+	Expected     float64    `json:"expected"`
+	Actual       float64    `json:"actual"`
+	Repaired     bool       `json:"repaired"`
+	RepairError  string     `json:"repair_error,omitempty"`
+}
+
+// ReconcileReport is the outcome of one reconciliation run.
+type ReconcileReport struct {
+	StartedAt    time.Time `json:"started_at"`
+	DurationMS   int64     `json:"duration_ms"`
+	Repair       bool      `json:"repair"`
+	ItemsChecked int       `json:"items_checked"`
+	LotsChecked  int       `json:"lots_checked"`
+	Drift        []Drift   `json:"drift"`
+	Repaired     int       `json:"repaired"`
+}
+
+// Reconciler replays the lot history in pantry_lot_events and compares the
+// result with the pantry_lots and pantry_items rows. Writes change the rows
+// before they record history, so a crash between the two leaves the rows
+// right and the history short; repair therefore appends the missing history
+// events rather than rewriting stock, and trims lots that exceed their item
+// the way a normal write would. Items stored without lot tracking have no
+// lot history and are not checked.
+type Reconciler struct {
+	q      db.Querier
+	pantry *PantryService
+	log    *slog.Logger
+	now    func() time.Time
+
+	mu   sync.Mutex
+	last *ReconcileReport
+}
+
+func NewReconciler(q db.Querier, pantry *PantryService) *Reconciler {
+	return &Reconciler{q: q, pantry: pantry, log: logging.For("reconcile"), now: time.Now}
+}
+
+// lotKey identifies a lot within its item.
+type lotKey struct {
+	item, lot uuid.UUID
+}
+
+// Reconcile compares every item's lots with their history and, when repair
+// is set, fixes what it finds. The report is kept for LastReport.
+func (r *Reconciler) Reconcile(ctx context.Context, repair bool) (ReconcileReport, error) {
+	report := ReconcileReport{StartedAt: r.now(), Repair: repair, Drift: []Drift{}}
+
+	items, err := r.q.ListPantryItems(ctx)
+	if err != nil {
+		return ReconcileReport{}, fmt.Errorf("list pantry items: %w", err)
+	}
+	lots, err := r.q.ListAllPantryLots(ctx)
+	if err != nil {
+		return ReconcileReport{}, fmt.Errorf("list pantry lots: %w", err)
+	}
+	totals, err := r.q.ListLotHistoryTotals(ctx)
+	if err != nil {
+		return ReconcileReport{}, fmt.Errorf("replay lot history: %w", err)
+	}
+
+	history := make(map[lotKey]db.ListLotHistoryTotalsRow, len(totals))
+	historyByItem := make(map[uuid.UUID][]db.ListLotHistoryTotalsRow)
+	for _, t := range totals {
+		history[lotKey{t.PantryItemID, t.LotID}] = t
+		historyByItem[t.PantryItemID] = append(historyByItem[t.PantryItemID], t)
+	}
+	lotsByItem := make(map[uuid.UUID][]db.PantryLot)
+	for _, l := range lots {
+		lotsByItem[l.PantryItemID] = append(lotsByItem[l.PantryItemID], l)
+	}
+
+	for _, item := range items {
+		live, past := lotsByItem[item.ID], historyByItem[item.ID]
+		if len(live) == 0 && len(past) == 0 {
+			continue
+		}
+		report.ItemsChecked++
+		report.LotsChecked += len(live)
+
+		var drift []Drift
+		seen := make(map[uuid.UUID]bool, len(live))
+		var lotTotal float64
+		for _, l := range live {
+			seen[l.ID] = true
+			lotTotal += l.Quantity
+			h, ok := history[lotKey{item.ID, l.ID}]
+			switch {
+			case !ok:
+				drift = append(drift, lotDrift(DriftLotUnrecorded, item, l.ID, l.Unit, 0, l.Quantity))
+			case math.Abs(h.Quantity-l.Quantity) > reconcileTolerance:
+				drift = append(drift, lotDrift(DriftLotQuantity, item, l.ID, l.Unit, h.Quantity, l.Quantity))
+			}
+		}
+		for _, h := range past {
+			if !seen[h.LotID] && h.Quantity > reconcileTolerance {
+				drift = append(drift, lotDrift(DriftLotMissing, item, h.LotID, h.Unit, h.Quantity, 0))
+			}
+		}
+		if !item.QuantityUnknown && lotTotal > item.Quantity+reconcileTolerance {
+			drift = append(drift, Drift{
+				Kind: DriftItemBelowLots, ItemID: item.ID, IngredientID: item.IngredientID,
+				Unit: item.Unit, Expected: lotTotal, Actual: item.Quantity,
+			})
+		}
+
+		if repair {
+			r.repair(ctx, item, live, history, drift)
+		}
+		report.Drift = append(report.Drift, drift...)
+	}
+
+	for _, d := range report.Drift {
+		if d.Repaired {
+			report.Repaired++
+		}
+	}
+	report.DurationMS = r.now().Sub(report.StartedAt).Milliseconds()
+	r.mu.Lock()
+	r.last = &report
+	r.mu.Unlock()
+	return report, nil
+}
+
+func lotDrift(kind string, item db.PantryItem, lotID uuid.UUID, unit string, expected, actual float64) Drift {
+	return Drift{
+		Kind: kind, ItemID: item.ID, IngredientID: item.IngredientID, LotID: &lotID,
+		Unit: unit, Expected: expected, Actual: actual,
+	}
+}
+
+// repair appends the history events that bring each lot's replayed quantity
+// in line with its row, then trims lots that exceed the item. Failures are
+// recorded on the drift and do not stop the run.
+func (r *Reconciler) repair(
+	ctx context.Context,
+	item db.PantryItem,
+	live []db.PantryLot,
+	history map[lotKey]db.ListLotHistoryTotalsRow,
+	drift []Drift,
+) {
+	rows := make(map[uuid.UUID]db.PantryLot, len(live))
+	for _, l := range live {
+		rows[l.ID] = l
+	}
+	for i := range drift {
+		d := &drift[i]
+		var err error
+		switch d.Kind {
+		case DriftLotUnrecorded:
+			err = r.pantry.recordLotEvent(ctx, rows[*d.LotID], LotEventAdded, d.Actual)
+		case DriftLotQuantity:
+			kind, diff := LotEventAdded, d.Actual-d.Expected
+			if diff < 0 {
+				kind, diff = LotEventConsumed, -diff
+			}
+			err = r.pantry.recordLotEvent(ctx, rows[*d.LotID], kind, diff)
+		case DriftLotMissing:
+			h := history[lotKey{item.ID, *d.LotID}]
+			lot := db.PantryLot{ID: h.LotID, PantryItemID: item.ID, Unit: h.Unit, ExpiresAt: h.ExpiresAt}
+			err = r.pantry.recordLotEvent(ctx, lot, LotEventDiscarded, d.Expected)
+		case DriftItemBelowLots:
+			_, err = r.pantry.reconcileLots(ctx, item)
+		}
+		if err != nil {
+			d.RepairError = err.Error()
+			r.log.WarnContext(ctx, "reconciliation repair failed",
+				"kind", d.Kind, "item_id", item.ID, "error", err)
+			continue
+		}
+		d.Repaired = true
+	}
+}
+
+// LastReport returns the most recent run's report, or nil before the first
+// run.
+func (r *Reconciler) LastReport() *ReconcileReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
+
+// RunScheduled reconciles every interval until ctx is cancelled, repairing
+// when repair is set.
+func (r *Reconciler) RunScheduled(ctx context.Context, interval time.Duration, repair bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := r.Reconcile(ctx, repair)
+			if err != nil {
+				r.log.ErrorContext(ctx, "reconciliation failed", "error", err)
+				continue
+			}
+			if len(report.Drift) > 0 {
+				r.log.WarnContext(ctx, "pantry drifted from its lot history",
+					"drift", len(report.Drift), "repaired", report.Repaired)
+			}
+		}
+	}
+}
+
+// WriteMetrics writes the last run's results as Prometheus gauges. The
+// series are the same in the OpenMetrics format. Nothing is written before
+// the first run.
+func (r *Reconciler) WriteMetrics(w io.Writer) error {
+	last := r.LastReport()
+	if last == nil {
+		return nil
+	}
+	counts := make(map[string]int, len(driftKinds))
+	for _, d := range last.Drift {
+		counts[d.Kind]++
+	}
+
+	gauge := func(name, help string) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	}
+	gauge("pantry_reconcile_last_run_timestamp_seconds", "When the last reconciliation started.")
+	fmt.Fprintf(w, "pantry_reconcile_last_run_timestamp_seconds %d\n", last.StartedAt.Unix())
+	gauge("pantry_reconcile_items_checked", "Items with lots or lot history checked by the last reconciliation.")
+	fmt.Fprintf(w, "pantry_reconcile_items_checked %d\n", last.ItemsChecked)
+	gauge("pantry_reconcile_drift", "Drift found by the last reconciliation, by kind.")
+	for _, kind := range driftKinds {
+		fmt.Fprintf(w, "pantry_reconcile_drift{kind=%q} %d\n", kind, counts[kind])
+	}
+	gauge("pantry_reconcile_repaired", "Drift repaired by the last reconciliation.")
+	_, err := fmt.Fprintf(w, "pantry_reconcile_repaired %d\n", last.Repaired)
+	return err
+}
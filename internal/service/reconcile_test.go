@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+// driftFixture is one item per lot-level drift kind, plus an item whose lots
+// outgrow it and an item that agrees with its history.
+type driftFixture struct {
+	items               []db.PantryItem
+	lots                []db.PantryLot
+	totals              []db.ListLotHistoryTotalsRow
+	changed, unrecorded db.PantryLot
+	missing             db.ListLotHistoryTotalsRow
+	below               db.PantryItem
+}
+
+func newDriftFixture() driftFixture {
+	var f driftFixture
+	item := func(quantity float64) db.PantryItem {
+		it := db.PantryItem{ID: uuid.New(), IngredientID: uuid.New(), Quantity: quantity, Unit: "l"}
+		f.items = append(f.items, it)
+		return it
+	}
+	lot := func(it db.PantryItem, quantity float64) db.PantryLot {
+		l := db.PantryLot{ID: uuid.New(), PantryItemID: it.ID, Quantity: quantity, Unit: "l"}
+		f.lots = append(f.lots, l)
+		return l
+	}
+	history := func(l db.PantryLot, quantity float64) db.ListLotHistoryTotalsRow {
+		h := db.ListLotHistoryTotalsRow{PantryItemID: l.PantryItemID, LotID: l.ID, Unit: l.Unit, Quantity: quantity}
+		f.totals = append(f.totals, h)
+		return h
+	}
+
+	ok := item(5)
+	history(lot(ok, 2), 2)
+	// A lot emptied by consumption is deleted and balances to zero.
+	history(db.PantryLot{ID: uuid.New(), PantryItemID: ok.ID, Unit: "l"}, 0)
+
+	f.changed = lot(item(5), 3)
+	history(f.changed, 2)
+	f.unrecorded = lot(item(5), 1)
+	gone := item(5)
+	f.missing = history(db.PantryLot{ID: uuid.New(), PantryItemID: gone.ID, Unit: "l"}, 1.5)
+	f.below = item(1)
+	history(lot(f.below, 2), 2)
+	item(4) // never lot-tracked
+	return f
+}
+
+func (f driftFixture) expect(mockQ *mocks.MockQuerier) {
+	mockQ.EXPECT().ListPantryItems(mock.Anything).Return(f.items, nil)
+	mockQ.EXPECT().ListAllPantryLots(mock.Anything).Return(f.lots, nil)
+	mockQ.EXPECT().ListLotHistoryTotals(mock.Anything).Return(f.totals, nil)
+}
+
+func TestReconcile_ReportsDrift(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	f := newDriftFixture()
+	f.expect(mockQ)
+	rc := NewReconciler(mockQ, NewPantryService(mockQ))
+	assert.Nil(t, rc.LastReport())
+
+	report, err := rc.Reconcile(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 5, report.ItemsChecked)
+	assert.Equal(t, 4, report.LotsChecked)
+	require.Len(t, report.Drift, 4)
+
+	byKind := map[string]Drift{}
+	for _, d := range report.Drift {
+		byKind[d.Kind] = d
+		assert.False(t, d.Repaired)
+	}
+	assert.Equal(t, f.changed.ID, *byKind[DriftLotQuantity].LotID)
+	assert.Equal(t, [2]float64{2, 3}, [2]float64{byKind[DriftLotQuantity].Expected, byKind[DriftLotQuantity].Actual})
+	assert.Equal(t, f.unrecorded.ID, *byKind[DriftLotUnrecorded].LotID)
+	assert.Equal(t, f.missing.LotID, *byKind[DriftLotMissing].LotID)
+	assert.Equal(t, 1.5, byKind[DriftLotMissing].Expected)
+	assert.Equal(t, f.below.ID, byKind[DriftItemBelowLots].ItemID)
+	assert.Equal(t, &report, rc.LastReport())
+
+	var metrics strings.Builder
+	require.NoError(t, rc.WriteMetrics(&metrics))
+	assert.Contains(t, metrics.String(), `pantry_reconcile_drift{kind="lot_missing"} 1`)
+	assert.Contains(t, metrics.String(), "pantry_reconcile_repaired 0\n")
+}
+
+func TestReconcile_RepairAppendsHistory(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	f := newDriftFixture()
+	f.expect(mockQ)
+	event := func(lotID uuid.UUID, kind string, quantity float64) {
+		mockQ.EXPECT().InsertPantryLotEvent(mock.Anything, mock.MatchedBy(func(p db.InsertPantryLotEventParams) bool {
+			return p.LotID == lotID && p.Kind == kind && p.Quantity == quantity
+		})).Return(nil).Once()
+	}
+	event(f.changed.ID, LotEventAdded, 1)
+	event(f.unrecorded.ID, LotEventAdded, 1)
+	event(f.missing.LotID, LotEventDiscarded, 1.5)
+
+	// The item below its lots is trimmed the way a write would trim it.
+	belowLot := f.lots[len(f.lots)-1]
+	mockQ.EXPECT().DeletePantryLotsWithOtherUnit(mock.Anything, mock.Anything).Return(nil, nil)
+	mockQ.EXPECT().ListPantryLotsByItem(mock.Anything, f.below.ID).Return([]db.PantryLot{belowLot}, nil)
+	mockQ.EXPECT().DecrementPantryLot(mock.Anything, db.DecrementPantryLotParams{ID: belowLot.ID, Quantity: 1}).
+		Return(db.PantryLot{}, nil)
+	event(belowLot.ID, LotEventConsumed, 1)
+	mockQ.EXPECT().DeleteEmptyPantryLots(mock.Anything, f.below.ID).Return(nil)
+	mockQ.EXPECT().SyncPantryItemExpiryFromLots(mock.Anything, f.below.ID).Return(f.below, nil)
+
+	report, err := NewReconciler(mockQ, NewPantryService(mockQ)).Reconcile(context.Background(), true)
+	require.NoError(t, err)
+	assert.True(t, report.Repair)
+	assert.Equal(t, 4, report.Repaired)
+	for _, d := range report.Drift {
+		assert.True(t, d.Repaired, d.Kind)
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+// Redaction kinds. Each replaced span becomes a marker such as
+// [REDACTED:CARD], so the line keeps its shape and the extractor still sees
+// that something stood there.
+const (
+	RedactCard    = "card"
+	RedactEmail   = "email"
+	RedactPhone   = "phone"
+	RedactAddress = "address"
+	RedactName    = "name"
+	RedactOther   = "pii"
+)
+
+// redactionMarker returns the placeholder for a span of kind.
+func redactionMarker(kind string) string {
+	return "[REDACTED:" + strings.ToUpper(kind) + "]"
+}
+
+// redactionRule replaces group of every match of re with kind's marker;
+// group 0 is the whole match. valid, if set, rejects false positives.
+type redactionRule struct {
+	kind  string
+	re    *regexp.Regexp
+	group int
+	valid func(string) bool
+}
+
+// addressSuffixes are the street types an address line ends in. The suffix
+// must end the line or precede a comma, so "12 oz Dr Pepper" is left alone.
+const addressSuffixes = `street|st|avenue|ave|road|rd|boulevard|blvd|lane|ln|drive|dr|court|way|place|pl|` +
+	`terrace|ter|circle|cir|parkway|pkwy|highway|hwy`
+
+// redactionRules run in order, so card numbers are gone before the phone
+// pattern could match part of one.
+var redactionRules = []redactionRule{
+	{kind: RedactEmail, re: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)},
+	{kind: RedactCard, re: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`), valid: luhnValid},
+	// Masked numbers and "ending in 1234" still identify the card.
+	{kind: RedactCard, re: regexp.MustCompile(`(?i)(?:[x*#•]{4,}[ -]?)+\d{4}\b`)},
+	{kind: RedactCard, re: regexp.MustCompile(`(?i)\b(?:ending|ends)(?:[ \t]+(?:in|with))?[: \t]+\d{4}\b`)},
+	{kind: RedactPhone, re: regexp.MustCompile(`(?:\+?1[ .-]?)?(?:\(\d{3}\)|\b\d{3})[ .-]?\d{3}[ .-]\d{4}\b`)},
+	{kind: RedactAddress, re: regexp.MustCompile(`(?im)\b\d{1,6}[ \t]+(?:[A-Za-z][A-Za-z0-9.'-]*[ \t]+){1,4}` +
+		`(?:` + addressSuffixes + `)\.?(?:,[^\n]*)?$`)},
+	{kind: RedactAddress, group: 2, re: regexp.MustCompile(
+		`(?im)^([ \t]*(?:bill|ship|deliver|sold)(?:ed)?[ \t]+to[ \t]*:[ \t]*)(\S[^\n]*)$`)},
+	{kind: RedactName, group: 2, re: regexp.MustCompile(
+		`(?im)^([ \t]*(?:cashier|server|customer|member|card ?holder|name|attn|recipient)` +
+			`[ \t]*[:#][ \t]*)(\S[^\n]*)$`)},
+	{kind: RedactName, group: 2, re: regexp.MustCompile(
+		`(?m)^([ \t]*(?:Hi|Hello|Hey|Dear)[ \t]+)([A-Z][a-z]+(?:[ \t]+[A-Z][a-z]+)?)`)},
+	{kind: RedactName, group: 2, re: regexp.MustCompile(
+		`(?m)^([ \t]*(?:Thanks|Thank you|Cheers|Best|Regards|Best regards|Sincerely),?[ \t]*\n[ \t]*)` +
+			`([A-Z][a-z]+(?:[ \t]+[A-Z][a-z]+)?)[ \t]*$`)},
+}
+
+// luhnValid reports whether the digits in s pass the Luhn check every
+// payment card number does, which rules out most order and barcode numbers.
+func luhnValid(s string) bool {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// PIISpan is one piece of personal information found in a text, quoted
+// exactly as it appears.
+type PIISpan struct {
+	Text string `json:"text"`
+	Kind string `json:"kind"` // card|email|phone|address|name|pii
+}
+
+// PIIDetector finds personal information the patterns miss, such as names
+// and free-form addresses. OpenAIExtractor implements it.
+type PIIDetector interface {
+	DetectPII(ctx context.Context, text string) ([]PIISpan, error)
+}
+
+// Redactor scrubs personal information from ingest input before it is
+// stored. Patterns catch card numbers, emails, phone numbers, street
+// addresses and labelled names; an optional PIIDetector then looks for
+// anything left.
+type Redactor struct {
+	detector PIIDetector
+	log      *slog.Logger
+}
+
+// NewRedactor returns a Redactor. detector may be nil to use the patterns
+// alone.
+func NewRedactor(detector PIIDetector) *Redactor {
+	return &Redactor{detector: detector, log: logging.For("redact")}
+}
+
+// Redact returns text with every sensitive span replaced by its marker, and
+// the number of spans replaced per kind. The detector only sees text the
+// patterns have already scrubbed. If it fails, the pattern result is
+// returned and the failure logged, so a detector outage never holds up an
+// ingest.
+func (r *Redactor) Redact(ctx context.Context, text string) (string, map[string]int) {
+	counts := map[string]int{}
+	for _, rule := range redactionRules {
+		text = rule.apply(text, counts)
+	}
+	if r.detector == nil {
+		return text, counts
+	}
+
+	spans, err := r.detector.DetectPII(ctx, text)
+	if err != nil {
+		r.log.WarnContext(ctx, "pii detection failed; keeping pattern redaction only", "error", err)
+		return text, counts
+	}
+	// Longer spans first, so a name inside an address is not replaced
+	// before the address is.
+	slices.SortFunc(spans, func(a, b PIISpan) int { return cmp.Compare(len(b.Text), len(a.Text)) })
+	for _, span := range spans {
+		s := strings.TrimSpace(span.Text)
+		if len(s) < 3 || strings.Contains(s, "[REDACTED:") {
+			continue
+		}
+		if n := strings.Count(text, s); n > 0 {
+			kind := detectedKind(span.Kind)
+			text = strings.ReplaceAll(text, s, redactionMarker(kind))
+			counts[kind] += n
+		}
+	}
+	return text, counts
+}
+
+// apply replaces every valid match of rule in text, counting them.
+func (rule redactionRule) apply(text string, counts map[string]int) string {
+	matches := rule.re.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[2*rule.group], m[2*rule.group+1]
+		if start < 0 || (rule.valid != nil && !rule.valid(text[start:end])) {
+			continue
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(redactionMarker(rule.kind))
+		last = end
+		counts[rule.kind]++
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// detectedKind maps a detector's kind onto the known kinds.
+func detectedKind(kind string) string {
+	switch kind = strings.ToLower(strings.TrimSpace(kind)); kind {
+	case RedactCard, RedactEmail, RedactPhone, RedactAddress, RedactName:
+		return kind
+	default:
+		return RedactOther
+	}
+}
+
+const piiPrompt = `You find personal information in grocery lists, receipts and emails so it can be removed.
+
+Return a JSON object with a "spans" array. Each span must have:
+- "text": the exact characters as they appear in the input, copied verbatim
+- "kind": one of "name", "address", "email", "phone", "card", "pii"
+
+Report people's names, street or postal addresses, email addresses, phone numbers, payment card or account
+numbers, loyalty or membership numbers, and any other detail that identifies a person.
+Never report grocery items, brands, quantities, prices, dates or store names.
+Text such as [REDACTED:NAME] has already been removed; ignore it.
+Return {"spans": []} when there is nothing to report.`
+
+// DetectPII asks the model for the personal information in text.
+func (e *OpenAIExtractor) DetectPII(ctx context.Context, text string) ([]PIISpan, error) {
+	reply, _, err := e.call(ctx, e.model, func(ctx context.Context, model string) (string, int, error) {
+		return e.chat(ctx, model, piiPrompt, text, jsonObjectFormat)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parsePII(reply)
+}
+
+// parsePII decodes a model's reply into the spans it found.
+func parsePII(reply string) ([]PIISpan, error) {
+	var detected struct {
+		Spans []PIISpan `json:"spans"`
+	}
+	if err := json.Unmarshal([]byte(reply), &detected); err != nil {
+		return nil, fmt.Errorf("parse pii json: %w", err)
+	}
+	return detected.Spans, nil
+}
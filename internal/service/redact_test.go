@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/testutil/llmserver"
+)
+
+func TestRedact_Patterns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "card number", in: "VISA 4111 1111 1111 1111", want: "VISA [REDACTED:CARD]"},
+		{name: "order number fails luhn", in: "Order 1234567890123", want: "Order 1234567890123"},
+		{name: "masked card", in: "Card: ************4242", want: "Card: [REDACTED:CARD]"},
+		{name: "card ending in", in: "Mastercard ending in 5454", want: "Mastercard [REDACTED:CARD]"},
+		{name: "email", in: "Sent to jane.doe@example.com", want: "Sent to [REDACTED:EMAIL]"},
+		{name: "phone", in: "Questions? (555) 123-4567", want: "Questions? [REDACTED:PHONE]"},
+		{
+			name: "street address",
+			in:   "123 Main St, Springfield, IL 62704\n2 lb chicken",
+			want: "[REDACTED:ADDRESS]\n2 lb chicken",
+		},
+		{name: "ship to", in: "Ship to: Jane Doe, Apt 4", want: "Ship to: [REDACTED:ADDRESS]"},
+		{name: "cashier", in: "CASHIER: Bob\nMILK 1 GAL", want: "CASHIER: [REDACTED:NAME]\nMILK 1 GAL"},
+		{name: "greeting", in: "Hi Jane Doe,\nyour order", want: "Hi [REDACTED:NAME],\nyour order"},
+		{name: "sign-off", in: "Thanks,\nSam", want: "Thanks,\n[REDACTED:NAME]"},
+		{name: "groceries untouched", in: "12 oz Dr Pepper\n2 cups flour", want: "12 oz Dr Pepper\n2 cups flour"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, _ := NewRedactor(nil).Redact(context.Background(), tc.in)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+type stubPIIDetector struct {
+	spans []PIISpan
+	err   error
+	seen  string
+}
+
+func (d *stubPIIDetector) DetectPII(_ context.Context, text string) ([]PIISpan, error) {
+	d.seen = text
+	return d.spans, d.err
+}
+
+func TestRedact_Detector(t *testing.T) {
+	t.Parallel()
+
+	detector := &stubPIIDetector{spans: []PIISpan{
+		{Text: "Jane", Kind: "name"},
+		{Text: "Jane Doe", Kind: "name"},
+		{Text: "Rewards #88812", Kind: "loyalty"},
+	}}
+	got, counts := NewRedactor(detector).Redact(context.Background(),
+		"jane@example.com\nJane Doe, Rewards #88812\n2 lb flour")
+
+	assert.Equal(t, "[REDACTED:EMAIL]\nJane Doe, Rewards #88812\n2 lb flour", detector.seen)
+	assert.Equal(t, "[REDACTED:EMAIL]\n[REDACTED:NAME], [REDACTED:PII]\n2 lb flour", got)
+	assert.Equal(t, map[string]int{RedactEmail: 1, RedactName: 1, RedactOther: 1}, counts)
+}
+
+func TestRedact_DetectorFailureKeepsPatterns(t *testing.T) {
+	t.Parallel()
+
+	detector := &stubPIIDetector{err: errors.New("provider down")}
+	got, counts := NewRedactor(detector).Redact(context.Background(), "call 555-123-4567 for eggs")
+
+	assert.Equal(t, "call [REDACTED:PHONE] for eggs", got)
+	assert.Equal(t, map[string]int{RedactPhone: 1}, counts)
+}
+
+func TestOpenAIExtractor_DetectPII(t *testing.T) {
+	t.Parallel()
+
+	server := llmserver.New(t, llmserver.WithDefault(llmserver.HappyPath(map[string]any{
+		"spans": []PIISpan{{Text: "Jane Doe", Kind: "name"}},
+	})))
+	extractor := NewOpenAIExtractor("sk-test", "gpt-pii", WithBaseURL(server.URL))
+
+	spans, err := extractor.DetectPII(context.Background(), "Jane Doe\n2 lb flour")
+	require.NoError(t, err)
+	assert.Equal(t, []PIISpan{{Text: "Jane Doe", Kind: "name"}}, spans)
+
+	reqs := server.Requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "gpt-pii", reqs[0].Model)
+	assert.Equal(t, "Jane Doe\n2 lb flour", reqs[0].LastUserText())
+}
+
+func TestCreateJob_RedactsTextInput(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	svc.SetRedactor(NewRedactor(nil))
+
+	mockQ.EXPECT().CreateIngestionJob(mock.Anything, db.CreateIngestionJobParams{
+		Type:     "text_blob",
+		RawInput: "eggs\nCard: [REDACTED:CARD]",
+		Source:   "email",
+		Priority: "interactive",
+	}).Return(db.IngestionJob{RawInput: "eggs\nCard: [REDACTED:CARD]"}, nil)
+	mockQ.EXPECT().CreateIngestionJob(mock.Anything, db.CreateIngestionJobParams{
+		Type:     JobTypeReceiptImage,
+		RawInput: "data:image/png;base64,xxxx1234",
+		Source:   "mobile",
+		Priority: "interactive",
+	}).Return(db.IngestionJob{}, nil)
+
+	_, err := svc.CreateJob(context.Background(), "text_blob", "email", "interactive", "eggs\nCard: xxxx1234")
+	require.NoError(t, err)
+	_, err = svc.CreateJob(context.Background(), JobTypeReceiptImage, "mobile", "interactive",
+		"data:image/png;base64,xxxx1234")
+	require.NoError(t, err)
+}
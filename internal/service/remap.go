@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+// ErrRemapBlocked is returned by Apply when the plan has entries that are
+// unresolved or conflict with each other. Nothing is rewritten.
+var ErrRemapBlocked = errors.New("remap plan has unresolved or conflicting ingredients")
+
+// IngredientRemapper moves stored ingredient IDs from one Dictionary
+// instance to another, e.g. when the service is repointed from staging to
+// production. Each referenced ID is named through the source Dictionary (or,
+// failing that, the raw list text it was resolved from) and the name is
+// resolved against the target Dictionary.
+//
+// The caller owns the transaction: q should be bound to one so that Plan and
+// Apply see the same rows and a failed Apply leaves no partial rewrite.
+type IngredientRemapper struct {
+	q      db.Querier
+	source IngredientLookup
+	target DictionaryResolver
+}
+
+func NewIngredientRemapper(q db.Querier, source IngredientLookup, target DictionaryResolver) *IngredientRemapper {
+	return &IngredientRemapper{q: q, source: source, target: target}
+}
+
+// RemapEntry is the plan for one stored ingredient ID. Problem is set when
+// the entry blocks Apply.
+type RemapEntry struct {
+	OldID         uuid.UUID  `json:"old_id"`
+	Name          string     `json:"name,omitempty"`
+	Alias         string     `json:"alias,omitempty"`
+	NewID         *uuid.UUID `json:"new_id,omitempty"`
+	NewName       string     `json:"new_name,omitempty"`
+	Confidence    float64    `json:"confidence,omitempty"`
+	Created       bool       `json:"created,omitempty"`
+	LowConfidence bool       `json:"low_confidence,omitempty"`
+	Problem       string     `json:"problem,omitempty"`
+}
+
+// RemapPlan lists every stored ingredient ID and where it would move.
+type RemapPlan struct {
+	Entries       []RemapEntry `json:"entries"`
+	Unresolved    int          `json:"unresolved"`
+	Conflicts     int          `json:"conflicts"`
+	LowConfidence int          `json:"low_confidence"`
+}
+
+// Blocked reports whether Apply would refuse the plan.
+func (p RemapPlan) Blocked() bool {
+	return p.Unresolved > 0 || p.Conflicts > 0
+}
+
+// RemapCounts records how many rows Apply rewrote per table.
+type RemapCounts struct {
+	PantryItems int64 `json:"pantry_items"`
+	Watchlist   int64 `json:"watchlist"`
+	StagedItems int64 `json:"staged_items"`
+	Tombstones  int64 `json:"pantry_item_tombstones"`
+	Activity    int64 `json:"pantry_activity"`
+	Overrides   int64 `json:"ingredient_display_overrides"`
+}
+
+// Plan builds the mapping without writing anything to the pantry database.
+// Resolving against the target Dictionary may create ingredients there;
+// those entries are marked Created. A resolution below the ingest review
+// threshold is flagged but does not block Apply.
+func (r *IngredientRemapper) Plan(ctx context.Context) (RemapPlan, error) {
+	rows, err := r.q.ListReferencedIngredients(ctx)
+	if err != nil {
+		return RemapPlan{}, fmt.Errorf("list referenced ingredients: %w", err)
+	}
+
+	plan := RemapPlan{Entries: make([]RemapEntry, len(rows))}
+	for i, row := range rows {
+		entry := RemapEntry{OldID: row.IngredientID, Alias: row.Alias.String}
+		r.resolve(ctx, &entry)
+		if entry.LowConfidence {
+			plan.LowConfidence++
+		}
+		plan.Entries[i] = entry
+	}
+	markRemapConflicts(plan.Entries)
+	for _, e := range plan.Entries {
+		switch {
+		case e.NewID == nil:
+			plan.Unresolved++
+		case e.Problem != "":
+			plan.Conflicts++
+		}
+	}
+	return plan, nil
+}
+
+func (r *IngredientRemapper) resolve(ctx context.Context, entry *RemapEntry) {
+	ing, err := r.source.GetIngredient(ctx, entry.OldID)
+	switch {
+	case err == nil:
+		entry.Name = ing.Name
+	case errors.Is(err, clients.ErrIngredientNotFound):
+	default:
+		entry.Problem = "source lookup failed: " + err.Error()
+	}
+
+	name := entry.Name
+	if name == "" {
+		name = entry.Alias
+	}
+	if name == "" {
+		if entry.Problem == "" {
+			entry.Problem = "no name in the source dictionary and no alias"
+		}
+		return
+	}
+
+	res, err := r.target.Resolve(ctx, name)
+	if err != nil {
+		entry.Problem = "target resolve failed: " + err.Error()
+		return
+	}
+	id := res.Ingredient.ID
+	entry.NewID = &id
+	entry.NewName = res.Ingredient.Name
+	entry.Confidence = res.Confidence
+	entry.Created = res.Created
+	entry.LowConfidence = res.Confidence < confidenceReviewThreshold
+	entry.Problem = ""
+}
+
+// markRemapConflicts flags entries that cannot be rewritten one by one:
+// several old IDs merging into one new ID (pantry_items and watchlist allow
+// one row per ingredient), or a new ID that is itself still a stored old ID.
+func markRemapConflicts(entries []RemapEntry) {
+	olds := make(map[uuid.UUID]bool, len(entries))
+	targets := make(map[uuid.UUID]int, len(entries))
+	for _, e := range entries {
+		olds[e.OldID] = true
+		if e.NewID != nil {
+			targets[*e.NewID]++
+		}
+	}
+	for i := range entries {
+		e := &entries[i]
+		if e.NewID == nil || *e.NewID == e.OldID {
+			continue
+		}
+		switch {
+		case targets[*e.NewID] > 1:
+			e.Problem = fmt.Sprintf("%d stored ingredients map to %s", targets[*e.NewID], *e.NewID)
+		case olds[*e.NewID]:
+			e.Problem = fmt.Sprintf("new id %s is also a stored id", *e.NewID)
+		}
+	}
+}
+
+// Apply rewrites every stored reference according to plan. It refuses a
+// blocked plan with ErrRemapBlocked. Entries whose ID is unchanged are
+// skipped.
+func (r *IngredientRemapper) Apply(ctx context.Context, plan RemapPlan) (RemapCounts, error) {
+	if plan.Blocked() {
+		return RemapCounts{}, ErrRemapBlocked
+	}
+
+	var counts RemapCounts
+	for _, e := range plan.Entries {
+		if *e.NewID == e.OldID {
+			continue
+		}
+		tables := []struct {
+			table string
+			run   func(context.Context, uuid.UUID, uuid.UUID) (int64, error)
+			count *int64
+		}{
+			{"pantry_items", r.remapPantryItems, &counts.PantryItems},
+			{"watchlist", r.remapWatchlist, &counts.Watchlist},
+			{"staged_items", r.remapStagedItems, &counts.StagedItems},
+			{"pantry_item_tombstones", r.remapTombstones, &counts.Tombstones},
+			{"pantry_activity", r.remapActivity, &counts.Activity},
+			{"ingredient_display_overrides", r.remapDisplayOverrides, &counts.Overrides},
+		}
+		for _, t := range tables {
+			n, err := t.run(ctx, e.OldID, *e.NewID)
+			if err != nil {
+				return RemapCounts{}, fmt.Errorf("remap %s in %s: %w", e.OldID, t.table, err)
+			}
+			*t.count += n
+		}
+	}
+	return counts, nil
+}
+
+func (r *IngredientRemapper) remapPantryItems(ctx context.Context, oldID, newID uuid.UUID) (int64, error) {
+	return r.q.RemapPantryItemIngredient(ctx, db.RemapPantryItemIngredientParams{NewID: newID, OldID: oldID})
+}
+
+func (r *IngredientRemapper) remapWatchlist(ctx context.Context, oldID, newID uuid.UUID) (int64, error) {
+	return r.q.RemapWatchlistIngredient(ctx, db.RemapWatchlistIngredientParams{NewID: newID, OldID: oldID})
+}
+
+func (r *IngredientRemapper) remapStagedItems(ctx context.Context, oldID, newID uuid.UUID) (int64, error) {
+	return r.q.RemapStagedItemIngredient(ctx, db.RemapStagedItemIngredientParams{NewID: newID, OldID: oldID})
+}
+
+func (r *IngredientRemapper) remapTombstones(ctx context.Context, oldID, newID uuid.UUID) (int64, error) {
+	return r.q.RemapTombstoneIngredient(ctx, db.RemapTombstoneIngredientParams{NewID: newID, OldID: oldID})
+}
+
+func (r *IngredientRemapper) remapActivity(ctx context.Context, oldID, newID uuid.UUID) (int64, error) {
+	return r.q.RemapActivityIngredient(ctx, db.RemapActivityIngredientParams{NewID: newID, OldID: oldID})
+}
+
+func (r *IngredientRemapper) remapDisplayOverrides(ctx context.Context, oldID, newID uuid.UUID) (int64, error) {
+	return r.q.RemapDisplayOverrideIngredient(ctx, db.RemapDisplayOverrideIngredientParams{NewID: newID, OldID: oldID})
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestIngredientRemapper_PlanAndApply(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	source := NewMockIngredientLookup(t)
+	target := NewMockDictionaryResolver(t)
+	r := NewIngredientRemapper(mockQ, source, target)
+
+	flour, gone := uuid.New(), uuid.New()
+	newFlour, newGone := uuid.New(), uuid.New()
+
+	mockQ.EXPECT().ListReferencedIngredients(mock.Anything).Return([]db.ListReferencedIngredientsRow{
+		{IngredientID: flour, Alias: sql.NullString{String: "AP flour", Valid: true}},
+		{IngredientID: gone, Alias: sql.NullString{String: "scallions", Valid: true}},
+	}, nil)
+	source.EXPECT().GetIngredient(mock.Anything, flour).Return(clients.Ingredient{ID: flour, Name: "flour"}, nil)
+	source.EXPECT().GetIngredient(mock.Anything, gone).Return(clients.Ingredient{}, clients.ErrIngredientNotFound)
+	target.EXPECT().Resolve(mock.Anything, "flour").Return(clients.ResolveResult{
+		Ingredient: clients.Ingredient{ID: newFlour, Name: "flour"}, Confidence: 1,
+	}, nil)
+	target.EXPECT().Resolve(mock.Anything, "scallions").Return(clients.ResolveResult{
+		Ingredient: clients.Ingredient{ID: newGone, Name: "green onion"}, Confidence: 0.5,
+	}, nil)
+
+	plan, err := r.Plan(context.Background())
+	require.NoError(t, err)
+	require.Len(t, plan.Entries, 2)
+	assert.False(t, plan.Blocked())
+	assert.Equal(t, 1, plan.LowConfidence)
+	assert.Equal(t, newFlour, *plan.Entries[0].NewID)
+	assert.Equal(t, "scallions", plan.Entries[1].Alias, "alias used when the source has no name")
+	assert.True(t, plan.Entries[1].LowConfidence)
+
+	for _, m := range []struct{ o, n uuid.UUID }{{flour, newFlour}, {gone, newGone}} {
+		mockQ.EXPECT().RemapPantryItemIngredient(mock.Anything,
+			db.RemapPantryItemIngredientParams{NewID: m.n, OldID: m.o}).Return(1, nil)
+		mockQ.EXPECT().RemapWatchlistIngredient(mock.Anything,
+			db.RemapWatchlistIngredientParams{NewID: m.n, OldID: m.o}).Return(0, nil)
+		mockQ.EXPECT().RemapStagedItemIngredient(mock.Anything,
+			db.RemapStagedItemIngredientParams{NewID: m.n, OldID: m.o}).Return(2, nil)
+		mockQ.EXPECT().RemapTombstoneIngredient(mock.Anything,
+			db.RemapTombstoneIngredientParams{NewID: m.n, OldID: m.o}).Return(0, nil)
+		mockQ.EXPECT().RemapActivityIngredient(mock.Anything,
+			db.RemapActivityIngredientParams{NewID: m.n, OldID: m.o}).Return(3, nil)
+		mockQ.EXPECT().RemapDisplayOverrideIngredient(mock.Anything,
+			db.RemapDisplayOverrideIngredientParams{NewID: m.n, OldID: m.o}).Return(0, nil)
+	}
+
+	counts, err := r.Apply(context.Background(), plan)
+	require.NoError(t, err)
+	assert.Equal(t, RemapCounts{PantryItems: 2, StagedItems: 4, Activity: 6}, counts)
+}
+
+func TestIngredientRemapper_BlocksUnresolvedAndConflicts(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	source := NewMockIngredientLookup(t)
+	target := NewMockDictionaryResolver(t)
+	r := NewIngredientRemapper(mockQ, source, target)
+
+	a, b, orphan, down := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	merged := uuid.New()
+
+	mockQ.EXPECT().ListReferencedIngredients(mock.Anything).Return([]db.ListReferencedIngredientsRow{
+		{IngredientID: a}, {IngredientID: b}, {IngredientID: orphan}, {IngredientID: down},
+	}, nil)
+	source.EXPECT().GetIngredient(mock.Anything, a).Return(clients.Ingredient{Name: "scallion"}, nil)
+	source.EXPECT().GetIngredient(mock.Anything, b).Return(clients.Ingredient{Name: "green onion"}, nil)
+	source.EXPECT().GetIngredient(mock.Anything, orphan).Return(clients.Ingredient{}, clients.ErrIngredientNotFound)
+	source.EXPECT().GetIngredient(mock.Anything, down).Return(clients.Ingredient{Name: "basil"}, nil)
+	target.EXPECT().Resolve(mock.Anything, "scallion").
+		Return(clients.ResolveResult{Ingredient: clients.Ingredient{ID: merged}, Confidence: 1}, nil)
+	target.EXPECT().Resolve(mock.Anything, "green onion").
+		Return(clients.ResolveResult{Ingredient: clients.Ingredient{ID: merged}, Confidence: 1}, nil)
+	target.EXPECT().Resolve(mock.Anything, "basil").Return(clients.ResolveResult{}, errors.New("connection refused"))
+
+	plan, err := r.Plan(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, plan.Unresolved)
+	assert.Equal(t, 2, plan.Conflicts)
+	assert.Contains(t, plan.Entries[0].Problem, "2 stored ingredients map to")
+	assert.Contains(t, plan.Entries[2].Problem, "no alias")
+	assert.Contains(t, plan.Entries[3].Problem, "connection refused")
+
+	_, err = r.Apply(context.Background(), plan)
+	require.ErrorIs(t, err, ErrRemapBlocked)
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+)
+
+// memoResolver memoizes Dictionary resolutions for the life of one ingest
+// job, so a name repeated in the same input ("milk ... more milk") costs one
+// network call. Failures are remembered too; the job already flags those
+// items for review and retrying within the same job rarely helps. It is not
+// a cache across jobs: each job gets a fresh one.
+type memoResolver struct {
+	inner DictionaryResolver
+
+	mu      sync.Mutex
+	results map[string]memoResult
+}
+
+type memoResult struct {
+	result clients.ResolveResult
+	err    error
+}
+
+func newMemoResolver(inner DictionaryResolver) *memoResolver {
+	return &memoResolver{inner: inner, results: map[string]memoResult{}}
+}
+
+// Resolve returns the memoized result for rawName, matching names
+// case-insensitively and ignoring surrounding whitespace.
+func (m *memoResolver) Resolve(ctx context.Context, rawName string) (clients.ResolveResult, error) {
+	key := strings.ToLower(strings.TrimSpace(rawName))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if r, ok := m.results[key]; ok {
+		return r.result, r.err
+	}
+	result, err := m.inner.Resolve(ctx, rawName)
+	m.results[key] = memoResult{result: result, err: err}
+	return result, err
+}
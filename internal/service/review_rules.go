@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+	"github.com/mwhite7112/woodpantry-pantry/internal/units"
+)
+
+var (
+	// ErrReviewRuleNotFound is returned when deleting a rule that does not exist.
+	ErrReviewRuleNotFound = errors.New("review rule not found")
+	// ErrInvalidReviewRule wraps review rule validation failures.
+	ErrInvalidReviewRule = errors.New("invalid review rule")
+)
+
+// Review rule actions. Review always flags a matching item; accept clears
+// the low-confidence flag but never overrides an unresolved ingredient or a
+// replaced unit, which need a person regardless.
+const (
+	ReviewActionReview = "review"
+	ReviewActionAccept = "accept"
+)
+
+// ReviewRules holds the household's rules for which staged items need
+// review, e.g. "always review fish", "accept spices under 1 unit", or
+// "always review alcohol" so it is never waved through on a glance. Rules
+// are applied when items are staged and re-extracted.
+type ReviewRules struct {
+	q   db.Querier
+	log *slog.Logger
+}
+
+func NewReviewRules(q db.Querier) *ReviewRules {
+	return &ReviewRules{q: q, log: logging.For("review-rules")}
+}
+
+// ReviewRule matches staged items by resolved ingredient name, Dictionary
+// category and a quantity ceiling. Every condition that is set must hold.
+type ReviewRule struct {
+	ID            uuid.UUID `json:"id"`
+	Action        string    `json:"action"`
+	Ingredient    string    `json:"ingredient,omitempty"`
+	Category      string    `json:"category,omitempty"`
+	BelowQuantity *float64  `json:"below_quantity,omitempty"`
+	Unit          string    `json:"unit,omitempty"`
+	Description   string    `json:"description,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (r ReviewRule) matches(ingredient, category string, quantity float64, quantityUnknown bool, unit string) bool {
+	if r.Ingredient != "" && !strings.EqualFold(r.Ingredient, strings.TrimSpace(ingredient)) {
+		return false
+	}
+	if r.Category != "" && r.Category != normalizeCategory(category) {
+		return false
+	}
+	if r.Unit != "" && r.Unit != unit {
+		return false
+	}
+	// An unknown quantity is never "under" anything.
+	if r.BelowQuantity != nil && (quantityUnknown || quantity >= *r.BelowQuantity) {
+		return false
+	}
+	return true
+}
+
+func (u *ReviewRules) List(ctx context.Context) ([]ReviewRule, error) {
+	rows, err := u.q.ListReviewRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ReviewRule, len(rows))
+	for i, r := range rows {
+		out[i] = toReviewRule(r)
+	}
+	return out, nil
+}
+
+// Create validates and stores a rule. Ingredient and category are matched
+// case-insensitively; the unit must be a canonical unit or alias and is
+// stored in canonical spelling.
+func (u *ReviewRules) Create(ctx context.Context, rule ReviewRule) (ReviewRule, error) {
+	rule.Action = strings.ToLower(strings.TrimSpace(rule.Action))
+	if rule.Action != ReviewActionReview && rule.Action != ReviewActionAccept {
+		return ReviewRule{}, fmt.Errorf("%w: action must be %q or %q",
+			ErrInvalidReviewRule, ReviewActionReview, ReviewActionAccept)
+	}
+	rule.Ingredient = strings.ToLower(strings.TrimSpace(rule.Ingredient))
+	rule.Category = normalizeCategory(rule.Category)
+	if rule.Ingredient == "" && rule.Category == "" && rule.BelowQuantity == nil {
+		return ReviewRule{}, fmt.Errorf("%w: set at least one of ingredient, category or below_quantity",
+			ErrInvalidReviewRule)
+	}
+	if rule.BelowQuantity != nil && *rule.BelowQuantity <= 0 {
+		return ReviewRule{}, fmt.Errorf("%w: below_quantity must be positive", ErrInvalidReviewRule)
+	}
+	if rule.Unit != "" {
+		canonical, ok := units.Canonical(rule.Unit)
+		if !ok {
+			return ReviewRule{}, fmt.Errorf("%w: unknown unit %q", ErrInvalidReviewRule, rule.Unit)
+		}
+		rule.Unit = canonical
+	}
+
+	params := db.CreateReviewRuleParams{
+		Action:      rule.Action,
+		Ingredient:  sql.NullString{String: rule.Ingredient, Valid: rule.Ingredient != ""},
+		Category:    sql.NullString{String: rule.Category, Valid: rule.Category != ""},
+		Unit:        sql.NullString{String: rule.Unit, Valid: rule.Unit != ""},
+		Description: strings.TrimSpace(rule.Description),
+	}
+	if rule.BelowQuantity != nil {
+		params.BelowQuantity = sql.NullFloat64{Float64: *rule.BelowQuantity, Valid: true}
+	}
+	row, err := u.q.CreateReviewRule(ctx, params)
+	if err != nil {
+		return ReviewRule{}, err
+	}
+	return toReviewRule(row), nil
+}
+
+func (u *ReviewRules) Delete(ctx context.Context, id uuid.UUID) error {
+	n, err := u.q.DeleteReviewRule(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrReviewRuleNotFound
+	}
+	return nil
+}
+
+// rules loads the rules for one job. A failed load is logged and yields no
+// rules, so items get the built-in confidence check rather than failing the
+// job.
+func (u *ReviewRules) rules(ctx context.Context) []ReviewRule {
+	if u == nil {
+		return nil
+	}
+	rules, err := u.List(ctx)
+	if err != nil {
+		u.log.WarnContext(ctx, "failed to load review rules; using confidence only", "error", err)
+		return nil
+	}
+	return rules
+}
+
+// reviewAction returns the action of the rules matching a staged item:
+// review if any review rule matches, else accept if an accept rule does,
+// else "".
+func reviewAction(rules []ReviewRule, ingredient, category string, quantity float64, quantityUnknown bool,
+	unit string,
+) string {
+	action := ""
+	for _, r := range rules {
+		if !r.matches(ingredient, category, quantity, quantityUnknown, unit) {
+			continue
+		}
+		if r.Action == ReviewActionReview {
+			return ReviewActionReview
+		}
+		action = ReviewActionAccept
+	}
+	return action
+}
+
+func toReviewRule(r db.ReviewRule) ReviewRule {
+	rule := ReviewRule{
+		ID:          r.ID,
+		Action:      r.Action,
+		Ingredient:  r.Ingredient.String,
+		Category:    r.Category.String,
+		Unit:        r.Unit.String,
+		Description: r.Description,
+		CreatedAt:   r.CreatedAt,
+	}
+	if r.BelowQuantity.Valid {
+		rule.BelowQuantity = &r.BelowQuantity.Float64
+	}
+	return rule
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestReviewRules_Create(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	rules := NewReviewRules(mockQ)
+
+	mockQ.EXPECT().CreateReviewRule(mock.Anything, db.CreateReviewRuleParams{
+		Action:        "accept",
+		Category:      sql.NullString{String: "spices", Valid: true},
+		BelowQuantity: sql.NullFloat64{Float64: 1, Valid: true},
+		Unit:          sql.NullString{String: "tbsp", Valid: true},
+		Description:   "small spice amounts",
+	}).Return(db.ReviewRule{
+		ID:            uuid.New(),
+		Action:        "accept",
+		Category:      sql.NullString{String: "spices", Valid: true},
+		BelowQuantity: sql.NullFloat64{Float64: 1, Valid: true},
+		Unit:          sql.NullString{String: "tbsp", Valid: true},
+	}, nil)
+	one := 1.0
+	rule, err := rules.Create(context.Background(), ReviewRule{
+		Action: " Accept", Category: "Spices", BelowQuantity: &one, Unit: "tablespoons",
+		Description: "small spice amounts ",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "tbsp", rule.Unit)
+	require.NotNil(t, rule.BelowQuantity)
+	assert.InDelta(t, 1, *rule.BelowQuantity, 1e-9)
+
+	zero := 0.0
+	for _, bad := range []ReviewRule{
+		{Action: "ignore", Ingredient: "fish"},
+		{Action: "review"},
+		{Action: "review", BelowQuantity: &zero},
+		{Action: "review", Category: "spices", Unit: "handful"},
+	} {
+		_, err := rules.Create(context.Background(), bad)
+		require.ErrorIs(t, err, ErrInvalidReviewRule, "%+v", bad)
+	}
+}
+
+func TestReviewRules_DeleteNotFound(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	id := uuid.New()
+	mockQ.EXPECT().DeleteReviewRule(mock.Anything, id).Return(0, nil)
+
+	assert.ErrorIs(t, NewReviewRules(mockQ).Delete(context.Background(), id), ErrReviewRuleNotFound)
+}
+
+func TestProcessJob_ReviewRules(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockDict := NewMockDictionaryResolver(t)
+	mockLLM := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, mockDict, mockLLM)
+	svc.SetReviewRules(NewReviewRules(mockQ))
+
+	mockQ.EXPECT().ListReviewRules(mock.Anything).Return([]db.ReviewRule{
+		{Action: "review", Ingredient: sql.NullString{String: "salmon", Valid: true}},
+		{Action: "review", Category: sql.NullString{String: "alcohol", Valid: true}},
+		{
+			Action:        "accept",
+			Category:      sql.NullString{String: "spices", Valid: true},
+			BelowQuantity: sql.NullFloat64{Float64: 1, Valid: true},
+		},
+		{Action: "accept", Category: sql.NullString{String: "alcohol", Valid: true}},
+	}, nil)
+	mockLLM.EXPECT().Extract(mock.Anything, mock.Anything).Return(&ExtractionResponse{Items: []ExtractedItem{
+		{RawText: "salmon fillet", Name: "Salmon", Quantity: 1, Unit: "piece", Confidence: 0.95},
+		{RawText: "red wine", Name: "wine", Quantity: 1, Unit: "bottle", Confidence: 0.95},
+		{RawText: "pinch of cumin", Name: "cumin", Quantity: 0.5, Unit: "tsp", Confidence: 0.4},
+		{RawText: "lots of paprika", Name: "paprika", Quantity: 0, Confidence: 0.4},
+		{RawText: "cumin seeds", Name: "cumin seeds", Quantity: 0.5, Unit: "handful", Confidence: 0.4},
+		{RawText: "rice", Name: "rice", Quantity: 1, Unit: "kg", Confidence: 0.95},
+	}}, nil)
+	categories := map[string]string{
+		"Salmon": "fish", "wine": "Alcohol", "cumin": "spices", "paprika": "spices", "cumin seeds": "spices",
+		"rice": "grains",
+	}
+	mockDict.EXPECT().Resolve(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, name string) (clients.ResolveResult, error) {
+			ing := clients.Ingredient{ID: uuid.New(), Name: name, Category: categories[name]}
+			return clients.ResolveResult{Ingredient: ing}, nil
+		})
+
+	review := map[string]bool{}
+	mockQ.EXPECT().CreateStagedItem(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, p db.CreateStagedItemParams) (db.StagedItem, error) {
+			review[p.RawText] = p.NeedsReview
+			return db.StagedItem{}, nil
+		})
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	require.NoError(t, svc.processJob(context.Background(), uuid.New(), "groceries"))
+	assert.Equal(t, map[string]bool{
+		"salmon fillet":   true,  // ingredient rule, despite high confidence
+		"red wine":        true,  // review beats accept
+		"pinch of cumin":  false, // accepted despite low confidence
+		"lots of paprika": true,  // an unknown quantity is not under 1
+		"cumin seeds":     true,  // a replaced unit is never accepted
+		"rice":            false,
+	}, review)
+}
+
+func TestProcessJob_ReviewRulesUnavailable(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockDict := NewMockDictionaryResolver(t)
+	svc := NewIngestService(mockQ, mockDict, NewHeuristicExtractor())
+	svc.SetReviewRules(NewReviewRules(mockQ))
+
+	mockQ.EXPECT().ListReviewRules(mock.Anything).Return(nil, errors.New("connection reset"))
+	mockDict.EXPECT().Resolve(mock.Anything, "rice").Return(clients.ResolveResult{
+		Ingredient: clients.Ingredient{ID: uuid.New(), Name: "rice", Category: "grains"},
+	}, nil)
+	mockQ.EXPECT().CreateStagedItem(mock.Anything, mock.Anything).Return(db.StagedItem{}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	require.NoError(t, svc.processJob(context.Background(), uuid.New(), "rice"))
+}
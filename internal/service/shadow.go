@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+const (
+	// DefaultShadowReportLimit is how many recent shadow runs the comparison
+	// report covers when no limit is given.
+	DefaultShadowReportLimit = 500
+	// MaxShadowReportLimit caps the report sample.
+	MaxShadowReportLimit = 5000
+	// maxShadowDivergences caps the per-job examples in a report.
+	maxShadowDivergences = 20
+	// shadowQuantityTolerance is the relative difference under which two
+	// quantities for the same item count as agreeing.
+	shadowQuantityTolerance = 0.01
+)
+
+type shadowExtraction struct {
+	extractor LLMExtractor
+	candidate string
+}
+
+// SetShadow runs every ingest through extractor as well, after the primary
+// extraction, storing both results for comparison under the candidate label
+// (e.g. the model name). Shadow results never affect staging, and shadow runs
+// are skipped while the LLM budget is exhausted.
+func (s *IngestService) SetShadow(extractor LLMExtractor, candidate string) {
+	s.shadow = &shadowExtraction{extractor: extractor, candidate: candidate}
+}
+
+// runShadow extracts rawInput with the candidate and stores it next to the
+// primary items. Failures are stored and logged, never returned.
+func (s *IngestService) runShadow(jobID uuid.UUID, rawInput string, primary []ExtractedItem) {
+	ctx, cancel := context.WithTimeout(context.Background(), processJobTimeout)
+	defer cancel()
+
+	if s.budget != nil {
+		if exhausted, err := s.budget.Exhausted(ctx); err != nil || exhausted {
+			return
+		}
+	}
+
+	started := time.Now()
+	shadow, extractErr := extractInput(ctx, s.shadow.extractor, rawInput)
+	elapsed := time.Since(started)
+
+	primaryJSON, err := json.Marshal(nonNilItems(primary))
+	if err != nil {
+		s.log.WarnContext(ctx, "failed to encode primary items for shadow", "job_id", jobID, "error", err)
+		return
+	}
+	params := db.UpsertShadowExtractionParams{
+		JobID:        jobID,
+		Candidate:    s.shadow.candidate,
+		PrimaryItems: primaryJSON,
+		ShadowItems:  json.RawMessage("[]"),
+		DurationMs:   int32(min(elapsed.Milliseconds(), math.MaxInt32)), //nolint:gosec // clamped
+	}
+	if extractErr != nil {
+		params.Error = sql.NullString{String: extractErr.Error(), Valid: true}
+	} else {
+		if params.ShadowItems, err = json.Marshal(nonNilItems(shadow.Items)); err != nil {
+			s.log.WarnContext(ctx, "failed to encode shadow items", "job_id", jobID, "error", err)
+			return
+		}
+		params.TokensUsed = int32(min(shadow.TokensUsed, math.MaxInt32)) //nolint:gosec // clamped
+		if s.budget != nil {
+			if err := s.budget.Record(ctx, shadow.TokensUsed); err != nil {
+				s.log.WarnContext(ctx, "failed to record shadow llm usage", "job_id", jobID, "error", err)
+			}
+		}
+	}
+
+	if err := s.q.UpsertShadowExtraction(ctx, params); err != nil {
+		s.log.WarnContext(ctx, "failed to store shadow extraction", "job_id", jobID, "error", err)
+	}
+}
+
+func nonNilItems(items []ExtractedItem) []ExtractedItem {
+	if items == nil {
+		return []ExtractedItem{}
+	}
+	return items
+}
+
+// ShadowCandidateReport summarizes how one candidate's extractions diverge
+// from the primary extractor's. Agreement rates are 0–1.
+type ShadowCandidateReport struct {
+	Candidate string `json:"candidate"`
+	Jobs      int    `json:"jobs"`
+	Errors    int    `json:"errors"`
+	// IdenticalJobs counts jobs where both produced the same items with the
+	// same quantities and units.
+	IdenticalJobs int `json:"identical_jobs"`
+	PrimaryItems  int `json:"primary_items"`
+	ShadowItems   int `json:"shadow_items"`
+	MatchedItems  int `json:"matched_items"`
+	// ItemAgreement is matched names over the union of names (Jaccard).
+	ItemAgreement float64 `json:"item_agreement"`
+	// QuantityAgreement is the share of matched items whose unit matches and
+	// whose quantity is within 1%.
+	QuantityAgreement float64 `json:"quantity_agreement"`
+	AvgTokens         float64 `json:"avg_tokens"`
+	AvgDurationMs     float64 `json:"avg_duration_ms"`
+}
+
+// ShadowDivergence describes how one job's shadow result differed.
+type ShadowDivergence struct {
+	JobID     uuid.UUID `json:"job_id"`
+	Candidate string    `json:"candidate"`
+	Error     string    `json:"error,omitempty"`
+	Missing   []string  `json:"missing"` // in primary only
+	Extra     []string  `json:"extra"`   // in shadow only
+	Changed   []string  `json:"changed"` // same name, different quantity or unit
+}
+
+// ShadowReport compares the most recent shadow runs with what was staged.
+type ShadowReport struct {
+	ActiveCandidate string                  `json:"active_candidate"`
+	Sample          int                     `json:"sample"`
+	Candidates      []ShadowCandidateReport `json:"candidates"`
+	Divergences     []ShadowDivergence      `json:"divergences"`
+}
+
+// ShadowReport compares up to limit recent shadow runs per candidate, with
+// a few of the most recent divergent jobs as examples. Items are matched by
+// name, case-insensitively.
+func (s *IngestService) ShadowReport(ctx context.Context, limit int) (ShadowReport, error) {
+	rows, err := s.q.ListShadowExtractions(ctx, int32(limit)) //nolint:gosec // bounded by MaxShadowReportLimit
+	if err != nil {
+		return ShadowReport{}, err
+	}
+
+	report := ShadowReport{
+		Sample:      len(rows),
+		Candidates:  []ShadowCandidateReport{},
+		Divergences: []ShadowDivergence{},
+	}
+	if s.shadow != nil {
+		report.ActiveCandidate = s.shadow.candidate
+	}
+
+	byCandidate := map[string]*shadowTally{}
+	var order []string
+	for _, row := range rows {
+		t, ok := byCandidate[row.Candidate]
+		if !ok {
+			t = &shadowTally{report: ShadowCandidateReport{Candidate: row.Candidate}}
+			byCandidate[row.Candidate] = t
+			order = append(order, row.Candidate)
+		}
+		div, err := t.add(row)
+		if err != nil {
+			return ShadowReport{}, fmt.Errorf("shadow extraction for job %s: %w", row.JobID, err)
+		}
+		if div != nil && len(report.Divergences) < maxShadowDivergences {
+			report.Divergences = append(report.Divergences, *div)
+		}
+	}
+	for _, c := range order {
+		report.Candidates = append(report.Candidates, byCandidate[c].finish())
+	}
+	return report, nil
+}
+
+type shadowTally struct {
+	report          ShadowCandidateReport
+	union           int
+	quantityMatches int
+	tokens          int64
+	durationMs      int64
+}
+
+// add folds one run into the tally, returning a divergence when the shadow
+// result differs from the primary one.
+func (t *shadowTally) add(row db.ShadowExtraction) (*ShadowDivergence, error) {
+	t.report.Jobs++
+	t.tokens += int64(row.TokensUsed)
+	t.durationMs += int64(row.DurationMs)
+
+	if row.Error.Valid {
+		t.report.Errors++
+		return &ShadowDivergence{
+			JobID: row.JobID, Candidate: row.Candidate, Error: row.Error.String,
+			Missing: []string{}, Extra: []string{}, Changed: []string{},
+		}, nil
+	}
+
+	var primary, shadow []ExtractedItem
+	if err := json.Unmarshal(row.PrimaryItems, &primary); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(row.ShadowItems, &shadow); err != nil {
+		return nil, err
+	}
+	t.report.PrimaryItems += len(primary)
+	t.report.ShadowItems += len(shadow)
+
+	div := ShadowDivergence{
+		JobID: row.JobID, Candidate: row.Candidate,
+		Missing: []string{}, Extra: []string{}, Changed: []string{},
+	}
+	primaryByName := itemsByName(primary)
+	shadowByName := itemsByName(shadow)
+	for name, p := range primaryByName {
+		sh, ok := shadowByName[name]
+		if !ok {
+			div.Missing = append(div.Missing, name)
+			continue
+		}
+		t.report.MatchedItems++
+		if quantitiesAgree(p, sh) {
+			t.quantityMatches++
+		} else {
+			div.Changed = append(div.Changed, name)
+		}
+	}
+	for name := range shadowByName {
+		if _, ok := primaryByName[name]; !ok {
+			div.Extra = append(div.Extra, name)
+		}
+	}
+	t.union += len(primaryByName) + len(div.Extra)
+
+	if len(div.Missing) == 0 && len(div.Extra) == 0 && len(div.Changed) == 0 {
+		t.report.IdenticalJobs++
+		return nil, nil
+	}
+	slices.Sort(div.Missing)
+	slices.Sort(div.Extra)
+	slices.Sort(div.Changed)
+	return &div, nil
+}
+
+func (t *shadowTally) finish() ShadowCandidateReport {
+	r := t.report
+	if t.union > 0 {
+		r.ItemAgreement = float64(r.MatchedItems) / float64(t.union)
+	}
+	if r.MatchedItems > 0 {
+		r.QuantityAgreement = float64(t.quantityMatches) / float64(r.MatchedItems)
+	}
+	if r.Jobs > 0 {
+		r.AvgTokens = float64(t.tokens) / float64(r.Jobs)
+		r.AvgDurationMs = float64(t.durationMs) / float64(r.Jobs)
+	}
+	return r
+}
+
+// itemsByName keys items by normalized name; on duplicates the first wins.
+func itemsByName(items []ExtractedItem) map[string]ExtractedItem {
+	out := make(map[string]ExtractedItem, len(items))
+	for _, it := range items {
+		key := strings.ToLower(strings.TrimSpace(it.Name))
+		if _, ok := out[key]; !ok {
+			out[key] = it
+		}
+	}
+	return out
+}
+
+func quantitiesAgree(a, b ExtractedItem) bool {
+	if !strings.EqualFold(strings.TrimSpace(a.Unit), strings.TrimSpace(b.Unit)) {
+		return false
+	}
+	scale := math.Max(math.Abs(a.Quantity), math.Abs(b.Quantity))
+	return scale == 0 || math.Abs(a.Quantity-b.Quantity)/scale <= shadowQuantityTolerance
+}
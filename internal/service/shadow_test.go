@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestRunShadow_StoresBothResults(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	candidate := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	svc.SetShadow(candidate, "gpt-next")
+
+	jobID := uuid.New()
+	primary := []ExtractedItem{{RawText: "milk", Name: "milk", Quantity: 1, Unit: "gal"}}
+	candidate.EXPECT().Extract(mock.Anything, "milk").Return(&ExtractionResponse{
+		Items:      []ExtractedItem{{RawText: "milk", Name: "milk", Quantity: 1, Unit: "l"}},
+		TokensUsed: 42,
+	}, nil)
+	mockQ.EXPECT().UpsertShadowExtraction(mock.Anything, mock.MatchedBy(func(p db.UpsertShadowExtractionParams) bool {
+		return p.JobID == jobID && p.Candidate == "gpt-next" && !p.Error.Valid && p.TokensUsed == 42 &&
+			string(p.ShadowItems) != "[]" && json.Valid(p.PrimaryItems)
+	})).Return(nil)
+
+	svc.runShadow(jobID, "milk", primary)
+}
+
+func TestRunShadow_RecordsCandidateError(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	candidate := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	svc.SetShadow(candidate, "gpt-next")
+
+	candidate.EXPECT().Extract(mock.Anything, "milk").Return(nil, errors.New("model not found"))
+	mockQ.EXPECT().UpsertShadowExtraction(mock.Anything, mock.MatchedBy(func(p db.UpsertShadowExtractionParams) bool {
+		return p.Error.String == "model not found" && string(p.ShadowItems) == "[]"
+	})).Return(nil)
+
+	svc.runShadow(uuid.New(), "milk", nil)
+}
+
+func TestShadowReport(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+
+	items := func(v ...ExtractedItem) json.RawMessage {
+		raw, err := json.Marshal(v)
+		require.NoError(t, err)
+		return raw
+	}
+	milk := ExtractedItem{Name: "milk", Quantity: 1, Unit: "gal"}
+	eggs := ExtractedItem{Name: "eggs", Quantity: 12, Unit: "piece"}
+	divergentJob := uuid.New()
+
+	mockQ.EXPECT().ListShadowExtractions(mock.Anything, int32(100)).Return([]db.ShadowExtraction{
+		{JobID: uuid.New(), Candidate: "gpt-next", PrimaryItems: items(milk), ShadowItems: items(milk), TokensUsed: 10},
+		{
+			JobID: divergentJob, Candidate: "gpt-next", TokensUsed: 30,
+			PrimaryItems: items(milk, eggs),
+			ShadowItems:  items(ExtractedItem{Name: "Milk", Quantity: 2, Unit: "gal"}, ExtractedItem{Name: "butter"}),
+		},
+		{
+			JobID: uuid.New(), Candidate: "gpt-next", PrimaryItems: items(milk), ShadowItems: json.RawMessage("[]"),
+			Error: sql.NullString{String: "timeout", Valid: true},
+		},
+	}, nil)
+
+	report, err := svc.ShadowReport(context.Background(), 100)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, report.Sample)
+	require.Len(t, report.Candidates, 1)
+	c := report.Candidates[0]
+	assert.Equal(t, 3, c.Jobs)
+	assert.Equal(t, 1, c.Errors)
+	assert.Equal(t, 1, c.IdenticalJobs)
+	assert.Equal(t, 2, c.MatchedItems)
+	// Union of names: {milk} + {milk, eggs, butter}.
+	assert.InDelta(t, 2.0/4.0, c.ItemAgreement, 1e-9)
+	assert.InDelta(t, 0.5, c.QuantityAgreement, 1e-9)
+	assert.InDelta(t, 40.0/3.0, c.AvgTokens, 1e-9)
+
+	require.Len(t, report.Divergences, 2)
+	assert.Equal(t, divergentJob, report.Divergences[0].JobID)
+	assert.Equal(t, []string{"eggs"}, report.Divergences[0].Missing)
+	assert.Equal(t, []string{"butter"}, report.Divergences[0].Extra)
+	assert.Equal(t, []string{"milk"}, report.Divergences[0].Changed)
+	assert.Equal(t, "timeout", report.Divergences[1].Error)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+// Item change kinds.
+const (
+	ChangeCreated = "created"
+	ChangeUpdated = "updated"
+	ChangeDeleted = "deleted"
+)
+
+// ItemChange is a changed item's state after the change. A deleted item
+// carries only its IDs.
+type ItemChange struct {
+	Kind            string
+	ItemID          uuid.UUID
+	IngredientID    uuid.UUID
+	Quantity        *float64
+	Unit            string
+	QuantityUnknown bool
+	ExpiresAt       *time.Time
+}
+
+// ItemChangePublisher publishes item snapshots. reset reports that every
+// item was removed.
+type ItemChangePublisher interface {
+	PublishItemChanges(ctx context.Context, changes []ItemChange, reset bool) error
+}
+
+// SnapshotPublisher passes pantry.updated on to next and then publishes the
+// changed items' current state through changes, so consumers need not call
+// back into the API for every ID. Wrap it in the DebouncedPublisher so a
+// window's items are read once, after its last change.
+type SnapshotPublisher struct {
+	next    UpdatePublisher
+	q       db.Querier
+	changes ItemChangePublisher
+}
+
+func NewSnapshotPublisher(next UpdatePublisher, q db.Querier, changes ItemChangePublisher) *SnapshotPublisher {
+	return &SnapshotPublisher{next: next, q: q, changes: changes}
+}
+
+// PublishPantryUpdated publishes the ID-only event and the snapshot event.
+// An empty list is a reset and publishes no snapshots.
+func (p *SnapshotPublisher) PublishPantryUpdated(ctx context.Context, changedItemIDs []uuid.UUID) error {
+	err := p.next.PublishPantryUpdated(ctx, changedItemIDs)
+	if len(changedItemIDs) == 0 {
+		return errors.Join(err, p.changes.PublishItemChanges(ctx, nil, true))
+	}
+	changes, snapErr := p.itemChanges(ctx, changedItemIDs)
+	if snapErr != nil {
+		return errors.Join(err, snapErr)
+	}
+	return errors.Join(err, p.changes.PublishItemChanges(ctx, changes, false))
+}
+
+// itemChanges reads the current state of the given items in order. An item
+// whose row is gone is reported deleted from its tombstone. An item added
+// and not modified since counts as created. IDs with neither a row nor a
+// tombstone, such as a tombstone already purged, are left out.
+func (p *SnapshotPublisher) itemChanges(ctx context.Context, ids []uuid.UUID) ([]ItemChange, error) {
+	items, err := p.q.ListPantryItemsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("load changed items: %w", err)
+	}
+	byID := make(map[uuid.UUID]db.PantryItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
+	var gone []uuid.UUID
+	for _, id := range ids {
+		if _, ok := byID[id]; !ok {
+			gone = append(gone, id)
+		}
+	}
+	deleted := make(map[uuid.UUID]uuid.UUID, len(gone)) // item ID → ingredient ID
+	if len(gone) > 0 {
+		tombstones, err := p.q.ListPantryTombstonesByItems(ctx, gone)
+		if err != nil {
+			return nil, fmt.Errorf("load deleted items: %w", err)
+		}
+		for _, t := range tombstones {
+			deleted[t.ItemID] = t.IngredientID
+		}
+	}
+
+	changes := make([]ItemChange, 0, len(ids))
+	for _, id := range ids {
+		if item, ok := byID[id]; ok {
+			changes = append(changes, itemChange(item))
+		} else if ingredientID, ok := deleted[id]; ok {
+			changes = append(changes, ItemChange{Kind: ChangeDeleted, ItemID: id, IngredientID: ingredientID})
+		}
+	}
+	return changes, nil
+}
+
+func itemChange(item db.PantryItem) ItemChange {
+	kind := ChangeUpdated
+	if item.AddedAt.Equal(item.UpdatedAt) {
+		kind = ChangeCreated
+	}
+	change := ItemChange{
+		Kind:            kind,
+		ItemID:          item.ID,
+		IngredientID:    item.IngredientID,
+		Quantity:        &item.Quantity,
+		Unit:            item.Unit,
+		QuantityUnknown: item.QuantityUnknown,
+	}
+	if item.ExpiresAt.Valid {
+		change.ExpiresAt = &item.ExpiresAt.Time
+	}
+	return change
+}
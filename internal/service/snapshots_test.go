@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+type stubItemChangePublisher struct {
+	changes []ItemChange
+	reset   bool
+}
+
+func (s *stubItemChangePublisher) PublishItemChanges(_ context.Context, changes []ItemChange, reset bool) error {
+	s.changes, s.reset = changes, reset
+	return nil
+}
+
+func TestSnapshotPublisher_ReportsKinds(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	next, sink := &stubUpdatePublisher{}, &stubItemChangePublisher{}
+	p := NewSnapshotPublisher(next, mockQ, sink)
+
+	added := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	expires := added.AddDate(0, 0, 7)
+	created := db.PantryItem{
+		ID: uuid.New(), IngredientID: uuid.New(), Quantity: 2, Unit: "l",
+		ExpiresAt: sql.NullTime{Time: expires, Valid: true}, AddedAt: added, UpdatedAt: added,
+	}
+	updated := db.PantryItem{
+		ID: uuid.New(), IngredientID: uuid.New(), Quantity: 1, Unit: "kg",
+		AddedAt: added, UpdatedAt: added.Add(time.Hour),
+	}
+	deleted, purged := uuid.New(), uuid.New()
+	ids := []uuid.UUID{deleted, created.ID, purged, updated.ID}
+	deletedIngredient := uuid.New()
+
+	mockQ.EXPECT().ListPantryItemsByIDs(mock.Anything, ids).Return([]db.PantryItem{updated, created}, nil)
+	mockQ.EXPECT().ListPantryTombstonesByItems(mock.Anything, []uuid.UUID{deleted, purged}).
+		Return([]db.PantryItemTombstone{{ItemID: deleted, IngredientID: deletedIngredient}}, nil)
+
+	require.NoError(t, p.PublishPantryUpdated(context.Background(), ids))
+	assert.Equal(t, [][]uuid.UUID{ids}, next.published, "the ID-only event is still published")
+	assert.False(t, sink.reset)
+	require.Len(t, sink.changes, 3, "an ID with neither row nor tombstone is left out")
+
+	assert.Equal(t, ItemChange{Kind: ChangeDeleted, ItemID: deleted, IngredientID: deletedIngredient}, sink.changes[0])
+	assert.Equal(t, ChangeCreated, sink.changes[1].Kind)
+	assert.InDelta(t, 2, *sink.changes[1].Quantity, 0)
+	assert.Equal(t, expires, *sink.changes[1].ExpiresAt)
+	assert.Equal(t, ChangeUpdated, sink.changes[2].Kind)
+	assert.Nil(t, sink.changes[2].ExpiresAt)
+}
+
+func TestSnapshotPublisher_Reset(t *testing.T) {
+	t.Parallel()
+
+	next, sink := &stubUpdatePublisher{}, &stubItemChangePublisher{}
+	p := NewSnapshotPublisher(next, mocks.NewMockQuerier(t), sink)
+
+	require.NoError(t, p.PublishPantryUpdated(context.Background(), []uuid.UUID{}))
+	assert.True(t, sink.reset)
+	assert.Empty(t, sink.changes)
+}
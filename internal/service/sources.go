@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+// IngestSources are the channels a job can be attributed to. Jobs from
+// before attribution existed report "unknown", which callers cannot send.
+var IngestSources = []string{"web", "mobile", "email", "voice", "chatbot", "api"}
+
+// DefaultIngestSource is used when neither the body nor a gateway header
+// names a channel.
+const DefaultIngestSource = "api"
+
+const (
+	// DefaultJobListLimit is how many jobs ListJobs returns by default.
+	DefaultJobListLimit = 50
+	// MaxJobListLimit caps a job listing page.
+	MaxJobListLimit = 500
+)
+
+// ParseIngestSource validates a caller-supplied source, treating an empty
+// string as DefaultIngestSource.
+func ParseIngestSource(v string) (string, error) {
+	if v == "" {
+		return DefaultIngestSource, nil
+	}
+	if !slices.Contains(IngestSources, v) {
+		return "", fmt.Errorf("unknown source %q", v)
+	}
+	return v, nil
+}
+
+// JobFilter narrows ListJobs; empty fields match everything.
+type JobFilter struct {
+	Source string
+	Status string
+	Limit  int
+}
+
+// ListJobs returns the most recent jobs, newest first.
+func (s *IngestService) ListJobs(ctx context.Context, f JobFilter) ([]db.IngestionJob, error) {
+	jobs, err := s.q.ListIngestionJobs(ctx, db.ListIngestionJobsParams{
+		Source: sql.NullString{String: f.Source, Valid: f.Source != ""},
+		Status: sql.NullString{String: f.Status, Valid: f.Status != ""},
+		Limit:  int32(f.Limit), //nolint:gosec // bounded by MaxJobListLimit
+	})
+	if err != nil {
+		return nil, err
+	}
+	if jobs == nil {
+		return []db.IngestionJob{}, nil
+	}
+	return jobs, nil
+}
+
+// SourceStats describes how much traffic and review work one channel
+// produced. ReviewRate is the share of staged items flagged needs_review.
+type SourceStats struct {
+	Source           string  `json:"source"`
+	Jobs             int64   `json:"jobs"`
+	ConfirmedJobs    int64   `json:"confirmed_jobs"`
+	FailedJobs       int64   `json:"failed_jobs"`
+	RejectedJobs     int64   `json:"rejected_jobs"`
+	StagedItems      int64   `json:"staged_items"`
+	NeedsReviewItems int64   `json:"needs_review_items"`
+	ReviewRate       float64 `json:"review_rate"`
+}
+
+// SourceStats aggregates jobs created at or after since by source, busiest
+// channel first.
+func (s *IngestService) SourceStats(ctx context.Context, since time.Time) ([]SourceStats, error) {
+	rows, err := s.q.IngestSourceStats(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]SourceStats, len(rows))
+	for i, r := range rows {
+		stats[i] = SourceStats{
+			Source:           r.Source,
+			Jobs:             r.Jobs,
+			ConfirmedJobs:    r.ConfirmedJobs,
+			FailedJobs:       r.FailedJobs,
+			RejectedJobs:     r.RejectedJobs,
+			StagedItems:      r.StagedItems,
+			NeedsReviewItems: r.NeedsReviewItems,
+		}
+		if r.StagedItems > 0 {
+			stats[i].ReviewRate = float64(r.NeedsReviewItems) / float64(r.StagedItems)
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/units"
+)
+
+// StagedItemPatch is a reviewer's correction to a staged item. Nil fields
+// are left as extracted.
+type StagedItemPatch struct {
+	IngredientID *uuid.UUID
+	Quantity     *float64
+	Unit         *string
+}
+
+// stagedItem returns itemID if it belongs to jobID and the job is still
+// staged. An item of another job is reported as sql.ErrNoRows.
+func (s *IngestService) stagedItem(ctx context.Context, jobID, itemID uuid.UUID) (db.StagedItem, error) {
+	job, err := s.q.GetIngestionJob(ctx, jobID)
+	if err != nil {
+		return db.StagedItem{}, err
+	}
+	if job.Status != "staged" {
+		return db.StagedItem{}, fmt.Errorf("%w: job %s has status %q", ErrJobNotStaged, jobID, job.Status)
+	}
+	staged, err := s.q.GetStagedItem(ctx, itemID)
+	if err != nil {
+		return db.StagedItem{}, err
+	}
+	if staged.JobID != jobID {
+		return db.StagedItem{}, sql.ErrNoRows
+	}
+	return staged, nil
+}
+
+// EditStagedItem applies a reviewer's correction to a staged item, so it no
+// longer has to be carried as a confirm-time override. A known unit takes
+// its canonical spelling. The edit counts as the review: the item stays
+// flagged only while its ingredient is unresolved.
+func (s *IngestService) EditStagedItem(
+	ctx context.Context,
+	jobID, itemID uuid.UUID,
+	pantry *PantryService,
+	patch StagedItemPatch,
+) (db.StagedItem, error) {
+	staged, err := s.stagedItem(ctx, jobID, itemID)
+	if err != nil {
+		return db.StagedItem{}, err
+	}
+
+	params := db.UpdateStagedItemParams{
+		ID:              itemID,
+		IngredientID:    staged.IngredientID,
+		Quantity:        staged.Quantity,
+		Unit:            staged.Unit,
+		QuantityUnknown: staged.QuantityUnknown,
+	}
+	if patch.IngredientID != nil {
+		if err := pantry.ValidateIngredient(ctx, *patch.IngredientID); err != nil {
+			return db.StagedItem{}, err
+		}
+		params.IngredientID = uuid.NullUUID{UUID: *patch.IngredientID, Valid: true}
+	}
+	if patch.Quantity != nil {
+		params.Quantity, params.QuantityUnknown = *patch.Quantity, false
+	}
+	if patch.Unit != nil {
+		params.Unit = *patch.Unit
+		if c, ok := units.Canonical(params.Unit); ok {
+			params.Unit = c
+		}
+	}
+	params.NeedsReview = !params.IngredientID.Valid
+	return s.q.UpdateStagedItem(ctx, params)
+}
+
+// DeleteStagedItem drops a staged item, such as a line the model misread,
+// so confirming the job does not add it.
+func (s *IngestService) DeleteStagedItem(ctx context.Context, jobID, itemID uuid.UUID) error {
+	if _, err := s.stagedItem(ctx, jobID, itemID); err != nil {
+		return err
+	}
+	n, err := s.q.DeleteStagedItem(ctx, db.DeleteStagedItemParams{ID: itemID, JobID: jobID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
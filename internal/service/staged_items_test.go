@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestEditStagedItem_ResolvesReview(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	ingestSvc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	pantrySvc := NewPantryService(mockQ)
+
+	jobID, itemID, flourID := uuid.New(), uuid.New(), uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+	mockQ.EXPECT().GetStagedItem(mock.Anything, itemID).Return(db.StagedItem{
+		ID: itemID, JobID: jobID, RawText: "some flour", Unit: "piece", NeedsReview: true, QuantityUnknown: true,
+	}, nil)
+	mockQ.EXPECT().UpdateStagedItem(mock.Anything, db.UpdateStagedItemParams{
+		ID:           itemID,
+		IngredientID: uuid.NullUUID{UUID: flourID, Valid: true},
+		Quantity:     500,
+		Unit:         "g",
+	}).Return(db.StagedItem{ID: itemID}, nil)
+
+	quantity, unit := 500.0, "grams"
+	_, err := ingestSvc.EditStagedItem(context.Background(), jobID, itemID, pantrySvc, StagedItemPatch{
+		IngredientID: &flourID,
+		Quantity:     &quantity,
+		Unit:         &unit,
+	})
+	require.NoError(t, err)
+}
+
+func TestEditStagedItem_RejectsUnknownIngredient(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	lookup := NewMockIngredientLookup(t)
+	ingestSvc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	pantrySvc := NewPantryService(mockQ)
+	pantrySvc.SetIngredientValidator(NewIngredientValidator(lookup, 0))
+
+	jobID, itemID, bogusID := uuid.New(), uuid.New(), uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+	mockQ.EXPECT().GetStagedItem(mock.Anything, itemID).Return(db.StagedItem{ID: itemID, JobID: jobID}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, bogusID).Return(clients.Ingredient{}, clients.ErrIngredientNotFound)
+
+	_, err := ingestSvc.EditStagedItem(context.Background(), jobID, itemID, pantrySvc, StagedItemPatch{
+		IngredientID: &bogusID,
+	})
+	require.ErrorIs(t, err, ErrUnknownIngredient)
+}
+
+func TestDeleteStagedItem(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	ingestSvc := NewIngestService(mockQ, NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+
+	jobID, itemID := uuid.New(), uuid.New()
+	mockQ.EXPECT().GetIngestionJob(mock.Anything, jobID).Return(db.IngestionJob{ID: jobID, Status: "staged"}, nil)
+	mockQ.EXPECT().GetStagedItem(mock.Anything, itemID).Return(db.StagedItem{ID: itemID, JobID: jobID}, nil)
+	mockQ.EXPECT().DeleteStagedItem(mock.Anything, db.DeleteStagedItemParams{ID: itemID, JobID: jobID}).
+		Return(0, nil)
+
+	// Deleted by a concurrent request between the lookup and the delete.
+	require.ErrorIs(t, ingestSvc.DeleteStagedItem(context.Background(), jobID, itemID), sql.ErrNoRows)
+}
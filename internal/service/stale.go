@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+// DefaultStaleScanInterval is how often RunScan re-flags stale items. Shelf
+// lives are measured in days, so there is no point looking more often.
+const DefaultStaleScanInterval = 6 * time.Hour
+
+// ParseShelfLives reads per-category shelf lives in days, such as
+//
+//	produce=7,dairy=14,bakery=5
+//
+// Categories are matched case-insensitively against Dictionary categories.
+func ParseShelfLives(spec string) (map[string]int, error) {
+	lives := map[string]int{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		category, days, ok := strings.Cut(entry, "=")
+		category = normalizeCategory(category)
+		if !ok || category == "" {
+			return nil, fmt.Errorf("shelf life: invalid entry %q", entry)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(days))
+		if err != nil || n < 1 || n > 365 {
+			return nil, fmt.Errorf("shelf life: %q: days must be between 1 and 365", entry)
+		}
+		lives[category] = n
+	}
+	return lives, nil
+}
+
+// StaleItem is a perishable pantry item nobody has touched for longer than
+// its category's shelf life, so its quantity probably no longer matches the
+// shelf.
+type StaleItem struct {
+	ID              uuid.UUID `json:"id"`
+	IngredientID    uuid.UUID `json:"ingredient_id"`
+	Quantity        float64   `json:"quantity"`
+	Unit            string    `json:"unit"`
+	QuantityUnknown bool      `json:"quantity_unknown"`
+	Category        string    `json:"category"`
+	ShelfLifeDays   int       `json:"shelf_life_days"`
+	LastActivityAt  time.Time `json:"last_activity_at"`
+	FlaggedAt       time.Time `json:"flagged_at"`
+}
+
+// StaleService flags perishables that have sat unchanged past their shelf
+// life. Flags are a prompt to verify, never a deletion: the item stays as
+// it is, and any update to it clears the flag.
+type StaleService struct {
+	q          db.Querier
+	categories *categoryCache
+	shelfLives map[string]int
+	now        func() time.Time
+	log        *slog.Logger
+}
+
+// NewStaleService returns a service that treats the categories in
+// shelfLives as perishable. Items in other categories are never flagged.
+func NewStaleService(q db.Querier, lookup IngredientLookup, shelfLives map[string]int) *StaleService {
+	return &StaleService{
+		q:          q,
+		categories: newCategoryCache(lookup),
+		shelfLives: shelfLives,
+		now:        time.Now,
+		log:        logging.For("stale"),
+	}
+}
+
+// ShelfLives returns the configured shelf life, in days, per category.
+func (s *StaleService) ShelfLives() map[string]int {
+	return maps.Clone(s.shelfLives)
+}
+
+// Scan flags every item whose last update is older than its category's
+// shelf life and drops flags that no longer apply. Items whose category
+// cannot be fetched are skipped this round rather than guessed at.
+func (s *StaleService) Scan(ctx context.Context) error {
+	if len(s.shelfLives) == 0 {
+		return nil
+	}
+	now := s.now()
+	shortest := slices.Min(slices.Collect(maps.Values(s.shelfLives)))
+	items, err := s.q.ListPantryItemsIdleSince(ctx, now.AddDate(0, 0, -shortest))
+	if err != nil {
+		return fmt.Errorf("list idle items: %w", err)
+	}
+
+	flagged := []uuid.UUID{}
+	for _, item := range items {
+		category, err := s.categories.get(ctx, item.IngredientID, now)
+		if err != nil {
+			s.log.WarnContext(ctx, "could not fetch ingredient category; skipping stale check",
+				"ingredient_id", item.IngredientID, "error", err)
+			continue
+		}
+		days, ok := s.shelfLives[category]
+		if !ok || item.UpdatedAt.After(now.AddDate(0, 0, -days)) {
+			continue
+		}
+		err = s.q.UpsertStaleFlag(ctx, db.UpsertStaleFlagParams{
+			ItemID:        item.ID,
+			Category:      category,
+			ShelfLifeDays: int32(days), //nolint:gosec // bounded by ParseShelfLives
+			ItemUpdatedAt: item.UpdatedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("flag item %s: %w", item.ID, err)
+		}
+		flagged = append(flagged, item.ID)
+	}
+
+	cleared, err := s.q.DeleteStaleFlagsExcept(ctx, flagged)
+	if err != nil {
+		return fmt.Errorf("clear stale flags: %w", err)
+	}
+	if len(flagged) > 0 || cleared > 0 {
+		s.log.InfoContext(ctx, "stale scan complete", "flagged", len(flagged), "cleared", cleared)
+	}
+	return nil
+}
+
+// Stale returns the flagged items that have not been updated since, least
+// recently touched first.
+func (s *StaleService) Stale(ctx context.Context) ([]StaleItem, error) {
+	rows, err := s.q.ListStaleItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]StaleItem, len(rows))
+	for i, r := range rows {
+		out[i] = StaleItem{
+			ID:              r.ID,
+			IngredientID:    r.IngredientID,
+			Quantity:        r.Quantity,
+			Unit:            r.Unit,
+			QuantityUnknown: r.QuantityUnknown,
+			Category:        r.Category,
+			ShelfLifeDays:   int(r.ShelfLifeDays),
+			LastActivityAt:  r.UpdatedAt,
+			FlaggedAt:       r.FlaggedAt,
+		}
+	}
+	return out, nil
+}
+
+// RunScan calls Scan every interval until ctx is cancelled.
+func (s *StaleService) RunScan(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.Scan(ctx); err != nil {
+			s.log.WarnContext(ctx, "stale scan failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestParseShelfLives(t *testing.T) {
+	t.Parallel()
+
+	lives, err := ParseShelfLives(" Produce=7, dairy=14,,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"produce": 7, "dairy": 14}, lives)
+
+	lives, err = ParseShelfLives("")
+	require.NoError(t, err)
+	assert.Empty(t, lives)
+
+	for _, spec := range []string{"produce", "=7", "produce=0", "produce=soon", "produce=400"} {
+		_, err := ParseShelfLives(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestStaleScan_FlagsPerishablesPastShelfLife(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	mockQ := mocks.NewMockQuerier(t)
+	lookup := NewMockIngredientLookup(t)
+	svc := NewStaleService(mockQ, lookup, map[string]int{"produce": 7, "dairy": 14})
+	svc.now = func() time.Time { return now }
+
+	lettuce, milk, rice, mystery := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	lettuceItem := db.PantryItem{ID: uuid.New(), IngredientID: lettuce, UpdatedAt: now.AddDate(0, 0, -10)}
+	milkItem := db.PantryItem{ID: uuid.New(), IngredientID: milk, UpdatedAt: now.AddDate(0, 0, -10)} // 14 days → fine
+	riceItem := db.PantryItem{ID: uuid.New(), IngredientID: rice, UpdatedAt: now.AddDate(0, -6, 0)}  // not perishable
+	mysteryItem := db.PantryItem{ID: uuid.New(), IngredientID: mystery, UpdatedAt: now.AddDate(0, 0, -30)}
+
+	mockQ.EXPECT().ListPantryItemsIdleSince(mock.Anything, now.AddDate(0, 0, -7)).
+		Return([]db.PantryItem{riceItem, mysteryItem, lettuceItem, milkItem}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, lettuce).Return(clients.Ingredient{Category: "Produce"}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, milk).Return(clients.Ingredient{Category: "dairy"}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, rice).Return(clients.Ingredient{Category: "grains"}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, mystery).Return(clients.Ingredient{}, errors.New("dictionary down"))
+	mockQ.EXPECT().UpsertStaleFlag(mock.Anything, db.UpsertStaleFlagParams{
+		ItemID:        lettuceItem.ID,
+		Category:      "produce",
+		ShelfLifeDays: 7,
+		ItemUpdatedAt: lettuceItem.UpdatedAt,
+	}).Return(nil)
+	mockQ.EXPECT().DeleteStaleFlagsExcept(mock.Anything, []uuid.UUID{lettuceItem.ID}).Return(2, nil)
+
+	require.NoError(t, svc.Scan(context.Background()))
+}
+
+func TestStaleScan_NoShelfLivesIsNoop(t *testing.T) {
+	t.Parallel()
+
+	svc := NewStaleService(mocks.NewMockQuerier(t), NewMockIngredientLookup(t), nil)
+	require.NoError(t, svc.Scan(context.Background()))
+}
+
+func TestStale_MapsRows(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewStaleService(mockQ, NewMockIngredientLookup(t), map[string]int{"produce": 7})
+
+	id := uuid.New()
+	touched := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockQ.EXPECT().ListStaleItems(mock.Anything).Return([]db.ListStaleItemsRow{{
+		ID:            id,
+		Quantity:      2,
+		Unit:          "head",
+		Category:      "produce",
+		ShelfLifeDays: 7,
+		UpdatedAt:     touched,
+	}}, nil)
+
+	items, err := svc.Stale(context.Background())
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, id, items[0].ID)
+	assert.Equal(t, 7, items[0].ShelfLifeDays)
+	assert.Equal(t, touched, items[0].LastActivityAt)
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+const (
+	// DefaultTaxonomyTTL is how long a fetched taxonomy is served before the
+	// Dictionary is asked again.
+	DefaultTaxonomyTTL = time.Hour
+
+	// UncategorizedTaxonomy holds ingredients the Dictionary has no category
+	// for.
+	UncategorizedTaxonomy = "uncategorized"
+
+	// taxonomyRetryDelay spaces out refreshes while the Dictionary is
+	// failing and a stale copy is being served.
+	taxonomyRetryDelay = time.Minute
+)
+
+// TaxonomyIngredient is one entry in a picker.
+type TaxonomyIngredient struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// TaxonomyCategory groups a category's ingredients, sorted by name.
+type TaxonomyCategory struct {
+	Name        string               `json:"name"`
+	Ingredients []TaxonomyIngredient `json:"ingredients"`
+}
+
+// TaxonomySnapshot is the Dictionary's category and ingredient tree as of
+// FetchedAt. ETag changes only when the tree does.
+type TaxonomySnapshot struct {
+	Categories []TaxonomyCategory `json:"categories"`
+	FetchedAt  time.Time          `json:"fetched_at"`
+	ETag       string             `json:"-"`
+}
+
+// Taxonomy caches the Dictionary's taxonomy for GET /pantry/taxonomy, so
+// frontends can fill pickers without calling the Dictionary themselves.
+type Taxonomy struct {
+	src IngredientLister
+	ttl time.Duration
+	now func() time.Time
+	log *slog.Logger
+
+	// mu is held across a refresh so concurrent requests wait for one
+	// Dictionary call instead of each making their own.
+	mu        sync.Mutex
+	cached    *TaxonomySnapshot
+	refreshAt time.Time
+}
+
+// NewTaxonomy returns a cache over src. A ttl of zero or less uses
+// DefaultTaxonomyTTL.
+func NewTaxonomy(src IngredientLister, ttl time.Duration) *Taxonomy {
+	if ttl <= 0 {
+		ttl = DefaultTaxonomyTTL
+	}
+	return &Taxonomy{src: src, ttl: ttl, now: time.Now, log: logging.For("taxonomy")}
+}
+
+// TTL is how long a snapshot is served before it is refreshed.
+func (t *Taxonomy) TTL() time.Duration {
+	return t.ttl
+}
+
+// Get returns the cached taxonomy, refreshing it once it is older than the
+// TTL. If the refresh fails, the previous snapshot is served and the next
+// attempt waits a minute; only a failure with nothing cached is returned.
+func (t *Taxonomy) Get(ctx context.Context) (TaxonomySnapshot, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if t.cached != nil && now.Before(t.refreshAt) {
+		return *t.cached, nil
+	}
+	ings, err := t.src.ListIngredients(ctx)
+	if err != nil {
+		if t.cached == nil {
+			return TaxonomySnapshot{}, fmt.Errorf("fetch taxonomy: %w", err)
+		}
+		t.log.WarnContext(ctx, "taxonomy refresh failed; serving stale copy",
+			"fetched_at", t.cached.FetchedAt, "error", err)
+		t.refreshAt = now.Add(taxonomyRetryDelay)
+		return *t.cached, nil
+	}
+
+	snap := TaxonomySnapshot{Categories: groupTaxonomy(ings), FetchedAt: now}
+	body, err := json.Marshal(snap.Categories)
+	if err != nil {
+		return TaxonomySnapshot{}, err
+	}
+	sum := sha256.Sum256(body)
+	snap.ETag = `"` + hex.EncodeToString(sum[:16]) + `"`
+	t.cached, t.refreshAt = &snap, now.Add(t.ttl)
+	return snap, nil
+}
+
+// groupTaxonomy buckets ingredients by lower-cased category, both levels
+// sorted by name.
+func groupTaxonomy(ings []clients.Ingredient) []TaxonomyCategory {
+	byName := map[string]*TaxonomyCategory{}
+	for _, ing := range ings {
+		category := normalizeCategory(ing.Category)
+		if category == "" {
+			category = UncategorizedTaxonomy
+		}
+		c, ok := byName[category]
+		if !ok {
+			c = &TaxonomyCategory{Name: category}
+			byName[category] = c
+		}
+		c.Ingredients = append(c.Ingredients, TaxonomyIngredient{ID: ing.ID, Name: ing.Name})
+	}
+
+	categories := make([]TaxonomyCategory, 0, len(byName))
+	for _, c := range byName {
+		slices.SortFunc(c.Ingredients, func(a, b TaxonomyIngredient) int {
+			return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.ID.String(), b.ID.String()))
+		})
+		categories = append(categories, *c)
+	}
+	slices.SortFunc(categories, func(a, b TaxonomyCategory) int { return strings.Compare(a.Name, b.Name) })
+	return categories
+}
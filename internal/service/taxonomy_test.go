@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+)
+
+func TestTaxonomy_GroupsAndCaches(t *testing.T) {
+	t.Parallel()
+
+	garlic, basil, salt := uuid.New(), uuid.New(), uuid.New()
+	lister := NewMockIngredientLister(t)
+	lister.EXPECT().ListIngredients(mock.Anything).Return([]clients.Ingredient{
+		{ID: garlic, Name: "garlic", Category: "Produce"},
+		{ID: salt, Name: "salt"},
+		{ID: basil, Name: "basil", Category: "produce"},
+	}, nil).Once()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tax := NewTaxonomy(lister, time.Hour)
+	tax.now = func() time.Time { return now }
+
+	snap, err := tax.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []TaxonomyCategory{
+		{Name: "produce", Ingredients: []TaxonomyIngredient{{ID: basil, Name: "basil"}, {ID: garlic, Name: "garlic"}}},
+		{Name: UncategorizedTaxonomy, Ingredients: []TaxonomyIngredient{{ID: salt, Name: "salt"}}},
+	}, snap.Categories)
+	assert.NotEmpty(t, snap.ETag)
+
+	now = now.Add(59 * time.Minute)
+	again, err := tax.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, snap.ETag, again.ETag, "served from cache within the TTL")
+}
+
+func TestTaxonomy_ServesStaleWhenDictionaryFails(t *testing.T) {
+	t.Parallel()
+
+	lister := NewMockIngredientLister(t)
+	lister.EXPECT().ListIngredients(mock.Anything).
+		Return([]clients.Ingredient{{ID: uuid.New(), Name: "salt"}}, nil).Once()
+	lister.EXPECT().ListIngredients(mock.Anything).Return(nil, errors.New("dictionary down")).Once()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tax := NewTaxonomy(lister, time.Hour)
+	tax.now = func() time.Time { return now }
+
+	first, err := tax.Get(context.Background())
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Hour)
+	stale, err := tax.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, first, stale)
+
+	now = now.Add(taxonomyRetryDelay / 2)
+	_, err = tax.Get(context.Background())
+	require.NoError(t, err, "no second Dictionary call before the retry delay")
+}
+
+func TestTaxonomy_ErrorWithoutCache(t *testing.T) {
+	t.Parallel()
+
+	lister := NewMockIngredientLister(t)
+	lister.EXPECT().ListIngredients(mock.Anything).Return(nil, errors.New("dictionary down"))
+
+	_, err := NewTaxonomy(lister, 0).Get(context.Background())
+	require.ErrorContains(t, err, "dictionary down")
+}
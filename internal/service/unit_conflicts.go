@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/units"
+)
+
+// ErrUnitConflict is matched by a *UnitConflictError.
+var ErrUnitConflict = errors.New("staged units conflict with pantry units")
+
+// UnitConflict is a staged item whose unit cannot be converted to the unit
+// the pantry already stores for its ingredient, e.g. "bunch" against "g".
+type UnitConflict struct {
+	StagedItemID uuid.UUID `json:"staged_item_id"`
+	IngredientID uuid.UUID `json:"ingredient_id"`
+	RawText      string    `json:"raw_text"`
+	StagedUnit   string    `json:"staged_unit"`
+	PantryUnit   string    `json:"pantry_unit"`
+}
+
+// UnitConflictError lists every unit conflict in a confirm. Resolve each by
+// overriding the staged item's unit (and quantity) explicitly.
+type UnitConflictError struct {
+	Conflicts []UnitConflict
+}
+
+func (e *UnitConflictError) Error() string {
+	return fmt.Sprintf("%d staged item(s) use a unit that cannot be converted to the pantry's unit; "+
+		"override the unit to replace it", len(e.Conflicts))
+}
+
+func (e *UnitConflictError) Unwrap() error { return ErrUnitConflict }
+
+// confirmPlan is one staged item with its overrides applied, ready to write.
+type confirmPlan struct {
+	staged         db.StagedItem
+	ingredientID   uuid.NullUUID
+	in             ItemInput
+	unitOverridden bool
+}
+
+// matchPantryUnits expresses each plan in the unit the pantry already stores
+// for its ingredient, so confirming adds to existing stock instead of
+// silently replacing its unit. A convertible quantity is converted, and an
+// unknown quantity simply takes the pantry's unit. Anything else is a
+// conflict, unless the override named the unit, which is taken as the
+// decision to replace it.
+func matchPantryUnits(ctx context.Context, q db.Querier, plans []confirmPlan) error {
+	ids := make([]uuid.UUID, 0, len(plans))
+	for _, p := range plans {
+		if p.ingredientID.Valid {
+			ids = append(ids, p.ingredientID.UUID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	existing, err := q.ListPantryItemsByIngredients(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("list pantry items: %w", err)
+	}
+	pantryUnits := make(map[uuid.UUID]string, len(existing))
+	for _, item := range existing {
+		pantryUnits[item.IngredientID] = item.Unit
+	}
+
+	var conflicts []UnitConflict
+	for i := range plans {
+		p := &plans[i]
+		pantryUnit, ok := pantryUnits[p.ingredientID.UUID]
+		if !p.ingredientID.Valid || !ok || p.unitOverridden {
+			continue
+		}
+		if sameUnit(p.in.Unit, pantryUnit) || p.in.QuantityUnknown {
+			p.in.Unit = pantryUnit
+			continue
+		}
+		if q, ok := units.Convert(p.in.Quantity, p.in.Unit, pantryUnit); ok {
+			p.in.Quantity, p.in.Unit = q, pantryUnit
+			continue
+		}
+		conflicts = append(conflicts, UnitConflict{
+			StagedItemID: p.staged.ID,
+			IngredientID: p.ingredientID.UUID,
+			RawText:      p.staged.RawText,
+			StagedUnit:   p.in.Unit,
+			PantryUnit:   pantryUnit,
+		})
+	}
+	if len(conflicts) > 0 {
+		return &UnitConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// sameUnit compares two unit spellings after canonicalization.
+func sameUnit(a, b string) bool {
+	if ca, ok := units.Canonical(a); ok {
+		a = ca
+	}
+	if cb, ok := units.Canonical(b); ok {
+		b = cb
+	}
+	return a == b
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+	"github.com/mwhite7112/woodpantry-pantry/internal/units"
+)
+
+var (
+	// ErrUnitDefaultNotFound is returned when deleting a category without a
+	// configured default unit.
+	ErrUnitDefaultNotFound = errors.New("no default unit configured for category")
+	// ErrInvalidUnitDefault wraps default unit validation failures.
+	ErrInvalidUnitDefault = errors.New("invalid default unit")
+)
+
+// UnitDefaults holds the unit staged for an item whose list entry gives
+// none, per Dictionary category: a bare "apples" means pieces, "orange juice"
+// millilitres, "rice" grams. Both the LLM and heuristic extraction paths leave
+// the unit empty in that case and staging fills it from here.
+type UnitDefaults struct {
+	q   db.Querier
+	log *slog.Logger
+}
+
+func NewUnitDefaults(q db.Querier) *UnitDefaults {
+	return &UnitDefaults{q: q, log: logging.For("unit-defaults")}
+}
+
+// CategoryUnit is the configured default unit for one ingredient category.
+type CategoryUnit struct {
+	Category  string    `json:"category"`
+	Unit      string    `json:"unit"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (u *UnitDefaults) List(ctx context.Context) ([]CategoryUnit, error) {
+	rows, err := u.q.ListCategoryDefaultUnits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]CategoryUnit, len(rows))
+	for i, r := range rows {
+		out[i] = CategoryUnit{Category: r.Category, Unit: r.Unit, UpdatedAt: r.UpdatedAt}
+	}
+	return out, nil
+}
+
+// Set configures the default unit for category, matched case-insensitively
+// against Dictionary categories. The unit must be a canonical unit or alias
+// and is stored in canonical spelling.
+func (u *UnitDefaults) Set(ctx context.Context, category, unit string) (CategoryUnit, error) {
+	category = normalizeCategory(category)
+	if category == "" {
+		return CategoryUnit{}, fmt.Errorf("%w: category is required", ErrInvalidUnitDefault)
+	}
+	canonical, ok := units.Canonical(unit)
+	if !ok {
+		return CategoryUnit{}, fmt.Errorf("%w: unknown unit %q", ErrInvalidUnitDefault, unit)
+	}
+	row, err := u.q.UpsertCategoryDefaultUnit(ctx, db.UpsertCategoryDefaultUnitParams{
+		Category: category,
+		Unit:     canonical,
+	})
+	if err != nil {
+		return CategoryUnit{}, err
+	}
+	return CategoryUnit{Category: row.Category, Unit: row.Unit, UpdatedAt: row.UpdatedAt}, nil
+}
+
+// Delete removes a category's default unit so it falls back to "piece".
+func (u *UnitDefaults) Delete(ctx context.Context, category string) error {
+	n, err := u.q.DeleteCategoryDefaultUnit(ctx, normalizeCategory(category))
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrUnitDefaultNotFound
+	}
+	return nil
+}
+
+// rules loads every category's default unit for one job. A failed load is
+// logged and yields no rules, so items fall back to "piece" rather than
+// failing the job.
+func (u *UnitDefaults) rules(ctx context.Context) map[string]string {
+	if u == nil {
+		return nil
+	}
+	rows, err := u.q.ListCategoryDefaultUnits(ctx)
+	if err != nil {
+		u.log.WarnContext(ctx, "failed to load category default units; using piece", "error", err)
+		return nil
+	}
+	rules := make(map[string]string, len(rows))
+	for _, r := range rows {
+		rules[r.Category] = r.Unit
+	}
+	return rules
+}
+
+// defaultUnit picks the staged unit for an item in category that arrived
+// without one.
+func defaultUnit(rules map[string]string, category string) string {
+	if unit, ok := rules[normalizeCategory(category)]; ok {
+		return unit
+	}
+	return units.DefaultCountUnit
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestUnitDefaults_Set(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	defaults := NewUnitDefaults(mockQ)
+
+	mockQ.EXPECT().UpsertCategoryDefaultUnit(mock.Anything, db.UpsertCategoryDefaultUnitParams{
+		Category: "grains",
+		Unit:     "g",
+	}).Return(db.CategoryDefaultUnit{Category: "grains", Unit: "g"}, nil)
+	rule, err := defaults.Set(context.Background(), " Grains ", "grams")
+	require.NoError(t, err)
+	assert.Equal(t, "g", rule.Unit)
+
+	_, err = defaults.Set(context.Background(), "grains", "handful")
+	require.ErrorIs(t, err, ErrInvalidUnitDefault)
+	_, err = defaults.Set(context.Background(), " ", "g")
+	require.ErrorIs(t, err, ErrInvalidUnitDefault)
+}
+
+func TestUnitDefaults_DeleteNotFound(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().DeleteCategoryDefaultUnit(mock.Anything, "spices").Return(0, nil)
+
+	err := NewUnitDefaults(mockQ).Delete(context.Background(), "Spices")
+	assert.ErrorIs(t, err, ErrUnitDefaultNotFound)
+}
+
+func TestProcessJob_CategoryDefaultUnits(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockDict := NewMockDictionaryResolver(t)
+	// The heuristic path must leave a missing unit empty just like the LLM
+	// prompt asks, so both get the same category default.
+	svc := NewIngestService(mockQ, mockDict, NewHeuristicExtractor())
+	svc.SetUnitDefaults(NewUnitDefaults(mockQ))
+
+	mockQ.EXPECT().ListCategoryDefaultUnits(mock.Anything).Return([]db.CategoryDefaultUnit{
+		{Category: "beverages", Unit: "ml"},
+		{Category: "grains", Unit: "g"},
+	}, nil)
+	categories := map[string]string{"orange juice": "Beverages", "rice": "grains", "apple": "produce", "flour": ""}
+	mockDict.EXPECT().Resolve(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, name string) (clients.ResolveResult, error) {
+			ing := clients.Ingredient{ID: uuid.New(), Category: categories[name]}
+			return clients.ResolveResult{Ingredient: ing}, nil
+		})
+
+	staged := map[string]string{}
+	mockQ.EXPECT().CreateStagedItem(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, p db.CreateStagedItemParams) (db.StagedItem, error) {
+			staged[p.RawText] = p.Unit
+			return db.StagedItem{}, nil
+		})
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	input := "orange juice, 2 rice, apples, 2 cups flour"
+	require.NoError(t, svc.processJob(context.Background(), uuid.New(), input))
+	assert.Equal(t, map[string]string{
+		"orange juice": "ml",
+		"2 rice":       "g",
+		"apples":       "piece",
+		"2 cups flour": "cup",
+	}, staged)
+}
+
+func TestProcessJob_UnitDefaultsUnavailable(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockDict := NewMockDictionaryResolver(t)
+	svc := NewIngestService(mockQ, mockDict, NewHeuristicExtractor())
+	svc.SetUnitDefaults(NewUnitDefaults(mockQ))
+
+	mockQ.EXPECT().ListCategoryDefaultUnits(mock.Anything).Return(nil, errors.New("connection reset"))
+	mockDict.EXPECT().Resolve(mock.Anything, "rice").Return(clients.ResolveResult{
+		Ingredient: clients.Ingredient{ID: uuid.New(), Category: "grains"},
+	}, nil)
+	mockQ.EXPECT().CreateStagedItem(mock.Anything, mock.MatchedBy(func(p db.CreateStagedItemParams) bool {
+		return p.Unit == "piece"
+	})).Return(db.StagedItem{}, nil)
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, mock.Anything).Return(db.IngestionJob{}, nil)
+
+	require.NoError(t, svc.processJob(context.Background(), uuid.New(), "rice"))
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+	"github.com/mwhite7112/woodpantry-pantry/internal/units"
+)
+
+// ValueAtRiskDays is how far ahead an expiry date puts an item's value at
+// risk.
+const ValueAtRiskDays = 7
+
+var (
+	// ErrPriceNotFound is returned when deleting a price that is not set.
+	ErrPriceNotFound = errors.New("no price configured")
+	// ErrInvalidPrice wraps price validation failures.
+	ErrInvalidPrice = errors.New("invalid price")
+)
+
+// ValuationService estimates what the current stock is worth for the
+// budgeting dashboard. Prices are per unit: an ingredient's own price wins,
+// otherwise the average price of its Dictionary category applies.
+type ValuationService struct {
+	q      db.Querier
+	lookup IngredientLookup
+	now    func() time.Time
+	log    *slog.Logger
+}
+
+func NewValuationService(q db.Querier, lookup IngredientLookup) *ValuationService {
+	return &ValuationService{q: q, lookup: lookup, now: time.Now, log: logging.For("valuation")}
+}
+
+// Price is the cost of one unit of an ingredient or of a category's
+// ingredients on average. Exactly one of IngredientID and Category is set.
+type Price struct {
+	IngredientID *uuid.UUID `json:"ingredient_id,omitempty"`
+	Category     string     `json:"category,omitempty"`
+	Price        float64    `json:"price"`
+	Unit         string     `json:"unit"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// Valuation is the estimated value of the pantry. Items without a usable
+// price, with an unknown quantity, or in a unit that does not convert to
+// the price's unit are counted in UnvaluedItems and left out of the totals.
+type Valuation struct {
+	TotalValue            float64     `json:"total_value"`
+	AtRiskValue           float64     `json:"at_risk_value"`
+	AtRiskDays            int         `json:"at_risk_days"`
+	ValuedItems           int         `json:"valued_items"`
+	UnvaluedItems         int         `json:"unvalued_items"`
+	UnvaluedIngredientIDs []uuid.UUID `json:"unvalued_ingredient_ids"`
+}
+
+// ListPrices returns the ingredient and category prices.
+func (s *ValuationService) ListPrices(ctx context.Context) (ingredients, categories []Price, err error) {
+	ip, err := s.q.ListIngredientPrices(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	cp, err := s.q.ListCategoryPrices(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	ingredients = make([]Price, len(ip))
+	for i, r := range ip {
+		ingredients[i] = Price{IngredientID: &r.IngredientID, Price: r.Price, Unit: r.Unit, UpdatedAt: r.UpdatedAt}
+	}
+	categories = make([]Price, len(cp))
+	for i, r := range cp {
+		categories[i] = Price{Category: r.Category, Price: r.Price, Unit: r.Unit, UpdatedAt: r.UpdatedAt}
+	}
+	return ingredients, categories, nil
+}
+
+// SetIngredientPrice records what one unit of an ingredient costs.
+func (s *ValuationService) SetIngredientPrice(
+	ctx context.Context,
+	ingredientID uuid.UUID,
+	price float64,
+	unit string,
+) (Price, error) {
+	canonical, err := validatePrice(price, unit)
+	if err != nil {
+		return Price{}, err
+	}
+	row, err := s.q.UpsertIngredientPrice(ctx, db.UpsertIngredientPriceParams{
+		IngredientID: ingredientID,
+		Price:        price,
+		Unit:         canonical,
+	})
+	if err != nil {
+		return Price{}, err
+	}
+	return Price{IngredientID: &row.IngredientID, Price: row.Price, Unit: row.Unit, UpdatedAt: row.UpdatedAt}, nil
+}
+
+// SetCategoryPrice records the average cost of one unit in category,
+// matched case-insensitively against Dictionary categories.
+func (s *ValuationService) SetCategoryPrice(ctx context.Context, category string, price float64, unit string) (Price, error) {
+	category = normalizeCategory(category)
+	if category == "" {
+		return Price{}, fmt.Errorf("%w: category is required", ErrInvalidPrice)
+	}
+	canonical, err := validatePrice(price, unit)
+	if err != nil {
+		return Price{}, err
+	}
+	row, err := s.q.UpsertCategoryPrice(ctx, db.UpsertCategoryPriceParams{
+		Category: category,
+		Price:    price,
+		Unit:     canonical,
+	})
+	if err != nil {
+		return Price{}, err
+	}
+	return Price{Category: row.Category, Price: row.Price, Unit: row.Unit, UpdatedAt: row.UpdatedAt}, nil
+}
+
+func (s *ValuationService) DeleteIngredientPrice(ctx context.Context, ingredientID uuid.UUID) error {
+	return deleted(s.q.DeleteIngredientPrice(ctx, ingredientID))
+}
+
+func (s *ValuationService) DeleteCategoryPrice(ctx context.Context, category string) error {
+	return deleted(s.q.DeleteCategoryPrice(ctx, normalizeCategory(category)))
+}
+
+func deleted(n int64, err error) error {
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrPriceNotFound
+	}
+	return nil
+}
+
+// validatePrice checks a price and returns its unit in canonical spelling.
+func validatePrice(price float64, unit string) (string, error) {
+	if price < 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+		return "", fmt.Errorf("%w: price must be zero or more", ErrInvalidPrice)
+	}
+	canonical, ok := units.Canonical(unit)
+	if !ok {
+		return "", fmt.Errorf("%w: unknown unit %q", ErrInvalidPrice, unit)
+	}
+	return canonical, nil
+}
+
+// Value estimates the worth of the current stock and of the part expiring
+// within ValueAtRiskDays, already expired items included. Categories are
+// looked up only for ingredients without their own price, and only when
+// category prices exist; a failed lookup leaves the item unvalued.
+func (s *ValuationService) Value(ctx context.Context) (Valuation, error) {
+	items, err := s.q.ListPantryItems(ctx)
+	if err != nil {
+		return Valuation{}, fmt.Errorf("list items: %w", err)
+	}
+	ip, err := s.q.ListIngredientPrices(ctx)
+	if err != nil {
+		return Valuation{}, fmt.Errorf("list ingredient prices: %w", err)
+	}
+	cp, err := s.q.ListCategoryPrices(ctx)
+	if err != nil {
+		return Valuation{}, fmt.Errorf("list category prices: %w", err)
+	}
+	byIngredient := make(map[uuid.UUID]db.IngredientPrice, len(ip))
+	for _, p := range ip {
+		byIngredient[p.IngredientID] = p
+	}
+	byCategory := make(map[string]db.CategoryPrice, len(cp))
+	for _, p := range cp {
+		byCategory[p.Category] = p
+	}
+
+	categories := map[uuid.UUID]string{}
+	priceFor := func(id uuid.UUID) (float64, string, bool) {
+		if p, ok := byIngredient[id]; ok {
+			return p.Price, p.Unit, true
+		}
+		if len(byCategory) == 0 {
+			return 0, "", false
+		}
+		category, ok := categories[id]
+		if !ok {
+			ing, err := s.lookup.GetIngredient(ctx, id)
+			if err != nil {
+				s.log.WarnContext(ctx, "could not fetch ingredient category; leaving unvalued",
+					"ingredient_id", id, "error", err)
+			}
+			category = normalizeCategory(ing.Category)
+			categories[id] = category
+		}
+		p, ok := byCategory[category]
+		return p.Price, p.Unit, ok
+	}
+
+	v := Valuation{AtRiskDays: ValueAtRiskDays, UnvaluedIngredientIDs: []uuid.UUID{}}
+	riskBefore := s.now().AddDate(0, 0, ValueAtRiskDays)
+	unvalued := map[uuid.UUID]bool{}
+	for _, item := range items {
+		value, ok := itemValue(item, priceFor)
+		if !ok {
+			v.UnvaluedItems++
+			if !unvalued[item.IngredientID] {
+				unvalued[item.IngredientID] = true
+				v.UnvaluedIngredientIDs = append(v.UnvaluedIngredientIDs, item.IngredientID)
+			}
+			continue
+		}
+		v.ValuedItems++
+		v.TotalValue += value
+		if item.ExpiresAt.Valid && !item.ExpiresAt.Time.After(riskBefore) {
+			v.AtRiskValue += value
+		}
+	}
+	v.TotalValue = math.Round(v.TotalValue*100) / 100
+	v.AtRiskValue = math.Round(v.AtRiskValue*100) / 100
+	return v, nil
+}
+
+// itemValue prices one item, converting its quantity to the price's unit.
+func itemValue(item db.PantryItem, priceFor func(uuid.UUID) (float64, string, bool)) (float64, bool) {
+	if !QuantityCounts(item) {
+		return 0, false
+	}
+	price, unit, ok := priceFor(item.IngredientID)
+	if !ok {
+		return 0, false
+	}
+	quantity := item.Quantity
+	if item.Unit != unit {
+		if quantity, ok = units.Convert(item.Quantity, item.Unit, unit); !ok {
+			return 0, false
+		}
+	}
+	return quantity * price, true
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/clients"
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestValuationService_Value(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	lookup := NewMockIngredientLookup(t)
+	svc := NewValuationService(mockQ, lookup)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	flour, rice, milk, saffron, mystery := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	soon := sql.NullTime{Time: now.AddDate(0, 0, 3), Valid: true}
+	mockQ.EXPECT().ListPantryItems(mock.Anything).Return([]db.PantryItem{
+		{IngredientID: flour, Quantity: 500, Unit: "g"},
+		{IngredientID: rice, Quantity: 2, Unit: "kg", ExpiresAt: soon},
+		{IngredientID: milk, Quantity: 1, Unit: "l", ExpiresAt: sql.NullTime{Time: now.AddDate(0, 1, 0), Valid: true}},
+		{IngredientID: saffron, Unit: "g", QuantityUnknown: true},
+		{IngredientID: mystery, Quantity: 1, Unit: "piece"},
+		{IngredientID: mystery, Quantity: 2, Unit: "piece"},
+	}, nil)
+	mockQ.EXPECT().ListIngredientPrices(mock.Anything).Return([]db.IngredientPrice{
+		{IngredientID: flour, Price: 2, Unit: "kg"},
+		{IngredientID: saffron, Price: 10, Unit: "g"},
+	}, nil)
+	mockQ.EXPECT().ListCategoryPrices(mock.Anything).Return([]db.CategoryPrice{
+		{Category: "grains", Price: 0.004, Unit: "g"},
+		{Category: "dairy", Price: 1.2, Unit: "l"},
+	}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, rice).Return(clients.Ingredient{Category: "Grains"}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, milk).Return(clients.Ingredient{Category: "dairy"}, nil)
+	lookup.EXPECT().GetIngredient(mock.Anything, mystery).Return(clients.Ingredient{}, errors.New("timeout")).Once()
+
+	v, err := svc.Value(context.Background())
+	require.NoError(t, err)
+	assert.InDelta(t, 1+8+1.2, v.TotalValue, 1e-9, "flour from its own price, rice and milk from their categories")
+	assert.InDelta(t, 8, v.AtRiskValue, 1e-9, "only the rice expires within a week")
+	assert.Equal(t, ValueAtRiskDays, v.AtRiskDays)
+	assert.Equal(t, 3, v.ValuedItems)
+	assert.Equal(t, 3, v.UnvaluedItems)
+	assert.Equal(t, []uuid.UUID{saffron, mystery}, v.UnvaluedIngredientIDs)
+}
+
+func TestValuationService_SetPrice(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewValuationService(mockQ, NewMockIngredientLookup(t))
+
+	mockQ.EXPECT().UpsertCategoryPrice(mock.Anything, db.UpsertCategoryPriceParams{
+		Category: "dairy", Price: 1.2, Unit: "l",
+	}).Return(db.CategoryPrice{Category: "dairy", Price: 1.2, Unit: "l"}, nil)
+	p, err := svc.SetCategoryPrice(context.Background(), " Dairy", 1.2, "litres")
+	require.NoError(t, err)
+	assert.Equal(t, "l", p.Unit)
+
+	_, err = svc.SetCategoryPrice(context.Background(), "dairy", -1, "l")
+	require.ErrorIs(t, err, ErrInvalidPrice)
+	_, err = svc.SetIngredientPrice(context.Background(), uuid.New(), 1, "handful")
+	require.ErrorIs(t, err, ErrInvalidPrice)
+
+	mockQ.EXPECT().DeleteCategoryPrice(mock.Anything, "dairy").Return(0, nil)
+	assert.ErrorIs(t, svc.DeleteCategoryPrice(context.Background(), "Dairy"), ErrPriceNotFound)
+}
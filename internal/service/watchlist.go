@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+var (
+	// ErrWatchNotFound is returned when removing an ingredient that is not watched.
+	ErrWatchNotFound = errors.New("ingredient is not on the watchlist")
+	// ErrInvalidWatch wraps watchlist input validation failures.
+	ErrInvalidWatch = errors.New("invalid watchlist entry")
+)
+
+// WatchlistService manages ingredients the household always wants in stock.
+type WatchlistService struct {
+	q db.Querier
+}
+
+func NewWatchlistService(q db.Querier) *WatchlistService {
+	return &WatchlistService{q: q}
+}
+
+// WatchedIngredient is a watchlist entry. MinQuantity and Unit are set
+// together; without them any stock at all satisfies the watch.
+type WatchedIngredient struct {
+	IngredientID uuid.UUID `json:"ingredient_id"`
+	MinQuantity  *float64  `json:"min_quantity"`
+	Unit         *string   `json:"unit"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// MissingIngredient is a watched ingredient that needs restocking, either
+// because it is absent or because it is below its minimum.
+type MissingIngredient struct {
+	IngredientID    uuid.UUID  `json:"ingredient_id"`
+	MinQuantity     *float64   `json:"min_quantity"`
+	Unit            *string    `json:"unit"`
+	PantryItemID    *uuid.UUID `json:"pantry_item_id"`
+	CurrentQuantity *float64   `json:"current_quantity"`
+	CurrentUnit     *string    `json:"current_unit"`
+}
+
+func (s *WatchlistService) List(ctx context.Context) ([]WatchedIngredient, error) {
+	rows, err := s.q.ListWatchlist(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]WatchedIngredient, len(rows))
+	for i, r := range rows {
+		out[i] = toWatchedIngredient(r)
+	}
+	return out, nil
+}
+
+// Watch adds or updates a watchlist entry. minQuantity and unit must be given
+// together or not at all.
+func (s *WatchlistService) Watch(
+	ctx context.Context,
+	ingredientID uuid.UUID,
+	minQuantity *float64,
+	unit *string,
+) (WatchedIngredient, error) {
+	if (minQuantity == nil) != (unit == nil || *unit == "") {
+		return WatchedIngredient{}, fmt.Errorf("%w: min_quantity and unit must be set together", ErrInvalidWatch)
+	}
+	if minQuantity != nil && *minQuantity <= 0 {
+		return WatchedIngredient{}, fmt.Errorf("%w: min_quantity must be positive", ErrInvalidWatch)
+	}
+
+	params := db.UpsertWatchlistEntryParams{IngredientID: ingredientID}
+	if minQuantity != nil {
+		params.MinQuantity = sql.NullFloat64{Float64: *minQuantity, Valid: true}
+		params.Unit = sql.NullString{String: *unit, Valid: true}
+	}
+	row, err := s.q.UpsertWatchlistEntry(ctx, params)
+	if err != nil {
+		return WatchedIngredient{}, fmt.Errorf("upsert watchlist entry: %w", err)
+	}
+	return toWatchedIngredient(row), nil
+}
+
+func (s *WatchlistService) Unwatch(ctx context.Context, ingredientID uuid.UUID) error {
+	n, err := s.q.DeleteWatchlistEntry(ctx, ingredientID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrWatchNotFound
+	}
+	return nil
+}
+
+// Missing returns watched ingredients that are out of stock or below their
+// minimum. Minimums are only compared when the pantry unit matches the
+// watched unit; a unit mismatch counts as in stock.
+func (s *WatchlistService) Missing(ctx context.Context) ([]MissingIngredient, error) {
+	rows, err := s.q.ListMissingWatchedIngredients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]MissingIngredient, len(rows))
+	for i, r := range rows {
+		out[i] = MissingIngredient{
+			IngredientID:    r.IngredientID,
+			MinQuantity:     nullFloat(r.MinQuantity),
+			Unit:            nullString(r.Unit),
+			CurrentQuantity: nullFloat(r.CurrentQuantity),
+			CurrentUnit:     nullString(r.CurrentUnit),
+		}
+		if r.PantryItemID.Valid {
+			out[i].PantryItemID = &r.PantryItemID.UUID
+		}
+	}
+	return out, nil
+}
+
+func toWatchedIngredient(r db.Watchlist) WatchedIngredient {
+	return WatchedIngredient{
+		IngredientID: r.IngredientID,
+		MinQuantity:  nullFloat(r.MinQuantity),
+		Unit:         nullString(r.Unit),
+		CreatedAt:    r.CreatedAt,
+		UpdatedAt:    r.UpdatedAt,
+	}
+}
+
+func nullFloat(v sql.NullFloat64) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Float64
+}
+
+func nullString(v sql.NullString) *string {
+	if !v.Valid {
+		return nil
+	}
+	return &v.String
+}
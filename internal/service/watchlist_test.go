@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestWatch_WithoutThreshold(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewWatchlistService(mockQ)
+
+	id := uuid.New()
+	mockQ.EXPECT().UpsertWatchlistEntry(mock.Anything, db.UpsertWatchlistEntryParams{IngredientID: id}).
+		Return(db.Watchlist{IngredientID: id}, nil)
+
+	entry, err := svc.Watch(context.Background(), id, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, id, entry.IngredientID)
+	assert.Nil(t, entry.MinQuantity)
+	assert.Nil(t, entry.Unit)
+}
+
+func TestWatch_RejectsInvalidThreshold(t *testing.T) {
+	t.Parallel()
+
+	svc := NewWatchlistService(mocks.NewMockQuerier(t))
+	unit := "l"
+	zero := 0.0
+	one := 1.0
+
+	_, err := svc.Watch(context.Background(), uuid.New(), &one, nil)
+	require.ErrorIs(t, err, ErrInvalidWatch)
+	_, err = svc.Watch(context.Background(), uuid.New(), nil, &unit)
+	require.ErrorIs(t, err, ErrInvalidWatch)
+	_, err = svc.Watch(context.Background(), uuid.New(), &zero, &unit)
+	require.ErrorIs(t, err, ErrInvalidWatch)
+}
+
+func TestUnwatch(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewWatchlistService(mockQ)
+
+	id := uuid.New()
+	mockQ.EXPECT().DeleteWatchlistEntry(mock.Anything, id).Return(1, nil).Once()
+	mockQ.EXPECT().DeleteWatchlistEntry(mock.Anything, id).Return(0, nil).Once()
+
+	require.NoError(t, svc.Unwatch(context.Background(), id))
+	require.ErrorIs(t, svc.Unwatch(context.Background(), id), ErrWatchNotFound)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+	"github.com/mwhite7112/woodpantry-pantry/internal/webhook"
+)
+
+const (
+	// DefaultWebhookDeliveryRetention is how long delivery attempts are kept.
+	DefaultWebhookDeliveryRetention = 30 * 24 * time.Hour
+
+	DefaultWebhookDeliveryListLimit = 100
+	MaxWebhookDeliveryListLimit     = 500
+
+	// recordAttemptTimeout bounds the audit insert, which runs after the
+	// delivery's own deadline may have passed.
+	recordAttemptTimeout = 5 * time.Second
+)
+
+// WebhookAudit stores every outgoing webhook attempt, from notifications and
+// post-confirm hooks alike, for GET /admin/webhooks. It satisfies
+// webhook.Recorder.
+type WebhookAudit struct {
+	q         db.Querier
+	retention time.Duration
+	log       *slog.Logger
+}
+
+func NewWebhookAudit(q db.Querier) *WebhookAudit {
+	return &WebhookAudit{q: q, retention: DefaultWebhookDeliveryRetention, log: logging.For("webhooks")}
+}
+
+// WebhookDelivery is one recorded attempt. StatusCode is nil when no
+// response arrived.
+type WebhookDelivery struct {
+	ID          int64     `json:"id"`
+	Source      string    `json:"source"`
+	Endpoint    string    `json:"endpoint"`
+	Signed      bool      `json:"signed"`
+	StatusCode  *int32    `json:"status_code"`
+	LatencyMs   int32     `json:"latency_ms"`
+	Error       *string   `json:"error"`
+	AttemptedAt time.Time `json:"attempted_at"`
+}
+
+// RecordAttempt stores a. Failures are logged; auditing never fails a
+// delivery.
+func (a *WebhookAudit) RecordAttempt(ctx context.Context, at webhook.Attempt) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordAttemptTimeout)
+	defer cancel()
+
+	params := db.InsertWebhookDeliveryParams{
+		Source:    at.Source,
+		Endpoint:  at.Endpoint,
+		Signed:    at.Signed,
+		LatencyMs: int32(min(at.Latency.Milliseconds(), int64(^uint32(0)>>1))), //nolint:gosec // clamped
+	}
+	if at.StatusCode != 0 {
+		params.StatusCode = sql.NullInt32{Int32: int32(at.StatusCode), Valid: true} //nolint:gosec // HTTP status
+	}
+	if at.Err != nil {
+		params.Error = sql.NullString{String: at.Err.Error(), Valid: true}
+	}
+	if err := a.q.InsertWebhookDelivery(ctx, params); err != nil {
+		a.log.WarnContext(ctx, "failed to record webhook delivery",
+			"source", at.Source, "endpoint", at.Endpoint, "error", err)
+	}
+}
+
+// List returns the newest attempts, optionally only from source and only
+// failed ones.
+func (a *WebhookAudit) List(ctx context.Context, source string, failedOnly bool, limit int) ([]WebhookDelivery, error) {
+	rows, err := a.q.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+		Source:     sql.NullString{String: source, Valid: source != ""},
+		FailedOnly: failedOnly,
+		Limit:      int32(limit), //nolint:gosec // bounded by callers
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]WebhookDelivery, len(rows))
+	for i, r := range rows {
+		out[i] = WebhookDelivery{
+			ID:          r.ID,
+			Source:      r.Source,
+			Endpoint:    r.Endpoint,
+			Signed:      r.Signed,
+			LatencyMs:   r.LatencyMs,
+			Error:       nullString(r.Error),
+			AttemptedAt: r.AttemptedAt,
+		}
+		if r.StatusCode.Valid {
+			out[i].StatusCode = &r.StatusCode.Int32
+		}
+	}
+	return out, nil
+}
+
+// Prune deletes attempts older than the retention period.
+func (a *WebhookAudit) Prune(ctx context.Context) (int64, error) {
+	n, err := a.q.DeleteWebhookDeliveriesBefore(ctx, time.Now().Add(-a.retention))
+	if err != nil {
+		return 0, fmt.Errorf("delete webhook deliveries: %w", err)
+	}
+	return n, nil
+}
+
+// RunPrune calls Prune every interval until ctx is cancelled.
+func (a *WebhookAudit) RunPrune(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := a.Prune(ctx)
+			if err != nil {
+				a.log.ErrorContext(ctx, "webhook delivery prune failed", "error", err)
+				continue
+			}
+			if n > 0 {
+				a.log.InfoContext(ctx, "pruned webhook deliveries", "deleted", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+	"github.com/mwhite7112/woodpantry-pantry/internal/webhook"
+)
+
+func TestWebhookAudit_RecordAttempt(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	audit := NewWebhookAudit(mockQ)
+
+	mockQ.EXPECT().InsertWebhookDelivery(mock.Anything, db.InsertWebhookDeliveryParams{
+		Source:     "hook",
+		Endpoint:   "https://example.com/hook",
+		Signed:     true,
+		StatusCode: sql.NullInt32{Int32: 502, Valid: true},
+		LatencyMs:  120,
+		Error:      sql.NullString{String: "webhook status 502", Valid: true},
+	}).Return(nil)
+	mockQ.EXPECT().InsertWebhookDelivery(mock.Anything, db.InsertWebhookDeliveryParams{
+		Source:    "notification",
+		Endpoint:  "https://example.com/n",
+		LatencyMs: 3,
+	}).Return(errors.New("db down"))
+
+	// A cancelled request context still records the attempt.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	audit.RecordAttempt(ctx, webhook.Attempt{
+		Source: "hook", Endpoint: "https://example.com/hook", Signed: true,
+		StatusCode: 502, Latency: 120 * time.Millisecond, Err: errors.New("webhook status 502"),
+	})
+	// Insert failures are only logged.
+	audit.RecordAttempt(context.Background(), webhook.Attempt{
+		Source: "notification", Endpoint: "https://example.com/n", StatusCode: 0, Latency: 3 * time.Millisecond,
+	})
+}
+
+func TestWebhookAudit_List(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	audit := NewWebhookAudit(mockQ)
+
+	mockQ.EXPECT().ListWebhookDeliveries(mock.Anything, db.ListWebhookDeliveriesParams{
+		Source:     sql.NullString{String: "hook", Valid: true},
+		FailedOnly: true,
+		Limit:      10,
+	}).Return([]db.WebhookDelivery{
+		{ID: 2, Source: "hook", Endpoint: "https://example.com", Error: sql.NullString{String: "timeout", Valid: true}},
+		{ID: 1, Source: "hook", Endpoint: "https://example.com", StatusCode: sql.NullInt32{Int32: 500, Valid: true}},
+	}, nil)
+
+	out, err := audit.List(context.Background(), "hook", true, 10)
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+	assert.Nil(t, out[0].StatusCode)
+	assert.Equal(t, "timeout", *out[0].Error)
+	assert.Equal(t, int32(500), *out[1].StatusCode)
+	assert.Nil(t, out[1].Error)
+}
+
+func TestWebhookAudit_Prune(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	audit := NewWebhookAudit(mockQ)
+
+	cutoff := mock.MatchedBy(func(ts time.Time) bool {
+		return time.Since(ts) >= DefaultWebhookDeliveryRetention
+	})
+	mockQ.EXPECT().DeleteWebhookDeliveriesBefore(mock.Anything, cutoff).Return(4, nil)
+
+	n, err := audit.Prune(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, n)
+}
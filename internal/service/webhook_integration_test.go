@@ -0,0 +1,54 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/testutil"
+)
+
+func TestWebhookEvents_ClaimOnceForActiveSubscriptions(t *testing.T) {
+	sqlDB := testutil.SetupDB(t)
+	q := db.New(sqlDB)
+	ctx := context.Background()
+
+	subscribe := func(url string) db.WebhookSubscription {
+		t.Helper()
+		sub, err := q.CreateWebhookSubscription(ctx, db.CreateWebhookSubscriptionParams{
+			Url: url, EventTypes: []string{WebhookPantryUpdated}, Active: true,
+		})
+		require.NoError(t, err)
+		return sub
+	}
+	active, paused := subscribe("https://a.example.com"), subscribe("https://b.example.com")
+	n, err := q.EnqueueWebhookEvent(ctx, db.EnqueueWebhookEventParams{
+		EventType: WebhookPantryUpdated, Payload: json.RawMessage(`{}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+	// Paused after its event was queued.
+	_, err = q.UpdateWebhookSubscription(ctx, db.UpdateWebhookSubscriptionParams{
+		ID: paused.ID, Url: paused.Url, EventTypes: paused.EventTypes, Active: false,
+	})
+	require.NoError(t, err)
+
+	params := db.ClaimDueWebhookEventsParams{
+		Attempts: MaxWebhookAttempts, Limit: webhookBatchSize, LeaseSeconds: 60,
+	}
+	first, err := q.ClaimDueWebhookEvents(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	assert.Equal(t, active.ID, first[0].SubscriptionID)
+
+	// The claimed event is leased, so another dispatcher does not send it too.
+	second, err := q.ClaimDueWebhookEvents(ctx, params)
+	require.NoError(t, err)
+	assert.Empty(t, second)
+}
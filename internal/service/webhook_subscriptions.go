@@ -0,0 +1,395 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+// Event types a webhook subscription may ask for.
+const (
+	WebhookPantryUpdated   = "pantry.updated"
+	WebhookIngestJobStatus = "ingest.job.status_changed"
+)
+
+// Headers set on subscription deliveries, next to the signature headers.
+const (
+	WebhookEventHeader    = "X-Pantry-Event"
+	WebhookDeliveryHeader = "X-Pantry-Delivery"
+)
+
+const (
+	// WebhookSubscriptionSource names subscription deliveries in the webhook
+	// audit.
+	WebhookSubscriptionSource = "subscription"
+	// MaxWebhookAttempts is how many times an event is sent to a subscriber
+	// before it is given up on.
+	MaxWebhookAttempts = 8
+	// DefaultWebhookDispatchInterval is how often RunDispatch sends due
+	// events.
+	DefaultWebhookDispatchInterval = 10 * time.Second
+	// webhookRetryBase is the wait after the first failure; each later one
+	// doubles it, up to webhookRetryMax.
+	webhookRetryBase = 30 * time.Second
+	webhookRetryMax  = time.Hour
+	// webhookEventRetention is how long sent and abandoned events are kept.
+	webhookEventRetention = 7 * 24 * time.Hour
+	webhookBatchSize      = 50
+	// webhookClaimLease is how long a claimed batch is hidden from other
+	// dispatchers. It outlasts a batch of sends that all time out.
+	webhookClaimLease = 15 * time.Minute
+)
+
+var (
+	// WebhookEventTypes are the accepted subscription event types.
+	WebhookEventTypes = []string{WebhookIngestJobStatus, WebhookPantryUpdated}
+
+	// ErrInvalidWebhook wraps webhook subscription validation failures.
+	ErrInvalidWebhook = errors.New("invalid webhook subscription")
+	// ErrWebhookNotFound is returned for a subscription that does not exist.
+	ErrWebhookNotFound = errors.New("webhook subscription not found")
+)
+
+// WebhookSender posts one webhook. webhook.Client satisfies it.
+type WebhookSender interface {
+	Post(ctx context.Context, source, endpoint, secret string, headers map[string]string, body []byte) error
+}
+
+// WebhookSubscription is an endpoint receiving events over HTTP.
+type WebhookSubscription struct {
+	ID          uuid.UUID `json:"id"`
+	URL         string    `json:"url"`
+	EventTypes  []string  `json:"event_types"`
+	Description string    `json:"description,omitempty"`
+	Active      bool      `json:"active"`
+	Signed      bool      `json:"signed"` // the secret itself is never returned
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WebhookSubscriptionInput creates or replaces a subscription. A nil Secret
+// keeps the current one on update; an empty one turns signing off. A nil
+// Active means active.
+type WebhookSubscriptionInput struct {
+	URL         string
+	EventTypes  []string
+	Secret      *string
+	Description string
+	Active      *bool
+}
+
+// WebhookService manages webhook subscriptions and delivers events to them.
+// Events are queued per subscription in the database and sent by
+// RunDispatch, signed with the subscription's secret, so a subscriber that
+// is down gets them once it is back, within MaxWebhookAttempts.
+type WebhookService struct {
+	q      db.Querier
+	sender WebhookSender
+	log    *slog.Logger
+	now    func() time.Time
+}
+
+func NewWebhookService(q db.Querier, sender WebhookSender) *WebhookService {
+	return &WebhookService{q: q, sender: sender, log: logging.For("webhooks"), now: time.Now}
+}
+
+func (s *WebhookService) List(ctx context.Context) ([]WebhookSubscription, error) {
+	rows, err := s.q.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]WebhookSubscription, len(rows))
+	for i, r := range rows {
+		out[i] = toWebhookSubscription(r)
+	}
+	return out, nil
+}
+
+func (s *WebhookService) Get(ctx context.Context, id uuid.UUID) (WebhookSubscription, error) {
+	row, err := s.q.GetWebhookSubscription(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return WebhookSubscription{}, ErrWebhookNotFound
+	}
+	if err != nil {
+		return WebhookSubscription{}, err
+	}
+	return toWebhookSubscription(row), nil
+}
+
+// Create validates and stores a subscription.
+func (s *WebhookService) Create(ctx context.Context, in WebhookSubscriptionInput) (WebhookSubscription, error) {
+	in, err := normalizeWebhookInput(in)
+	if err != nil {
+		return WebhookSubscription{}, err
+	}
+	params := db.CreateWebhookSubscriptionParams{
+		Url:         in.URL,
+		EventTypes:  in.EventTypes,
+		Description: in.Description,
+		Active:      *in.Active,
+	}
+	if in.Secret != nil {
+		params.Secret = *in.Secret
+	}
+	row, err := s.q.CreateWebhookSubscription(ctx, params)
+	if err != nil {
+		return WebhookSubscription{}, err
+	}
+	return toWebhookSubscription(row), nil
+}
+
+// Update replaces subscription id. Events already queued keep their place
+// and go to the new URL.
+func (s *WebhookService) Update(
+	ctx context.Context,
+	id uuid.UUID,
+	in WebhookSubscriptionInput,
+) (WebhookSubscription, error) {
+	in, err := normalizeWebhookInput(in)
+	if err != nil {
+		return WebhookSubscription{}, err
+	}
+	params := db.UpdateWebhookSubscriptionParams{
+		Url:         in.URL,
+		EventTypes:  in.EventTypes,
+		Description: in.Description,
+		Active:      *in.Active,
+		ID:          id,
+	}
+	if in.Secret != nil {
+		params.Secret = sql.NullString{String: *in.Secret, Valid: true}
+	}
+	row, err := s.q.UpdateWebhookSubscription(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return WebhookSubscription{}, ErrWebhookNotFound
+	}
+	if err != nil {
+		return WebhookSubscription{}, err
+	}
+	return toWebhookSubscription(row), nil
+}
+
+// Delete removes a subscription and its queued events.
+func (s *WebhookService) Delete(ctx context.Context, id uuid.UUID) error {
+	n, err := s.q.DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+func normalizeWebhookInput(in WebhookSubscriptionInput) (WebhookSubscriptionInput, error) {
+	in.URL = strings.TrimSpace(in.URL)
+	u, err := url.Parse(in.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return in, fmt.Errorf("%w: url must be an http or https URL", ErrInvalidWebhook)
+	}
+	if len(in.EventTypes) == 0 {
+		return in, fmt.Errorf("%w: event_types is required", ErrInvalidWebhook)
+	}
+	types := make([]string, 0, len(in.EventTypes))
+	for _, t := range in.EventTypes {
+		if !slices.Contains(WebhookEventTypes, t) {
+			return in, fmt.Errorf("%w: event type must be one of %s",
+				ErrInvalidWebhook, strings.Join(WebhookEventTypes, ", "))
+		}
+		if !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	in.EventTypes = types
+	in.Description = strings.TrimSpace(in.Description)
+	if in.Active == nil {
+		active := true
+		in.Active = &active
+	}
+	return in, nil
+}
+
+func toWebhookSubscription(r db.WebhookSubscription) WebhookSubscription {
+	return WebhookSubscription{
+		ID:          r.ID,
+		URL:         r.Url,
+		EventTypes:  r.EventTypes,
+		Description: r.Description,
+		Active:      r.Active,
+		Signed:      r.Secret != "",
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+// --- events ---
+
+type webhookJobStatus struct {
+	SchemaVersion int       `json:"schema_version"`
+	Timestamp     string    `json:"timestamp"`
+	JobID         uuid.UUID `json:"job_id"`
+	Status        string    `json:"status"`
+}
+
+// PublishPantryUpdated queues the pantry.updated payload for subscribers.
+func (s *WebhookService) PublishPantryUpdated(ctx context.Context, changedItemIDs []uuid.UUID) error {
+	return s.enqueue(ctx, WebhookPantryUpdated, newPantryUpdate(s.now(), changedItemIDs))
+}
+
+// OnJobStatus queues an ingest job's new status for subscribers. Failures
+// are logged; it satisfies JobStatusHook.
+func (s *WebhookService) OnJobStatus(ctx context.Context, jobID uuid.UUID, status string) {
+	err := s.enqueue(ctx, WebhookIngestJobStatus, webhookJobStatus{
+		SchemaVersion: 1,
+		Timestamp:     s.now().UTC().Format(time.RFC3339),
+		JobID:         jobID,
+		Status:        status,
+	})
+	if err != nil {
+		s.log.WarnContext(ctx, "failed to queue job status webhook", "job_id", jobID, "status", status, "error", err)
+	}
+}
+
+func (s *WebhookService) enqueue(ctx context.Context, eventType string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", eventType, err)
+	}
+	if _, err := s.q.EnqueueWebhookEvent(ctx, db.EnqueueWebhookEventParams{
+		EventType: eventType,
+		Payload:   body,
+	}); err != nil {
+		return fmt.Errorf("queue %s webhooks: %w", eventType, err)
+	}
+	return nil
+}
+
+// Wrap returns a publisher that passes pantry.updated on to next and also
+// queues it for subscribers.
+func (s *WebhookService) Wrap(next UpdatePublisher) UpdatePublisher {
+	return webhookTee{next: next, hooks: s}
+}
+
+type webhookTee struct {
+	next  UpdatePublisher
+	hooks *WebhookService
+}
+
+func (t webhookTee) PublishPantryUpdated(ctx context.Context, changedItemIDs []uuid.UUID) error {
+	return errors.Join(
+		t.next.PublishPantryUpdated(ctx, changedItemIDs),
+		t.hooks.PublishPantryUpdated(ctx, changedItemIDs),
+	)
+}
+
+// --- dispatch ---
+
+// Dispatch claims up to one batch of due events, oldest first, and sends
+// them. Events of inactive subscriptions wait until they are reactivated. A
+// failed send is retried after a backoff doubling from webhookRetryBase
+// until it has been attempted MaxWebhookAttempts times. It returns how many
+// were sent.
+func (s *WebhookService) Dispatch(ctx context.Context) (int, error) {
+	batch, err := s.q.ClaimDueWebhookEvents(ctx, db.ClaimDueWebhookEventsParams{
+		Attempts:     MaxWebhookAttempts,
+		Limit:        webhookBatchSize,
+		LeaseSeconds: webhookClaimLease.Seconds(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("claim due webhook events: %w", err)
+	}
+
+	sent := 0
+	for _, e := range batch {
+		headers := map[string]string{
+			WebhookEventHeader:    e.EventType,
+			WebhookDeliveryHeader: strconv.FormatInt(e.ID, 10),
+		}
+		if err := s.sender.Post(ctx, WebhookSubscriptionSource, e.Url, e.Secret, headers, e.Payload); err != nil {
+			attempt := e.Attempts + 1
+			s.log.WarnContext(ctx, "webhook delivery failed",
+				"id", e.ID, "subscription_id", e.SubscriptionID, "attempt", attempt, "error", err)
+			if attempt >= MaxWebhookAttempts {
+				s.log.ErrorContext(ctx, "giving up on webhook event", "id", e.ID, "subscription_id", e.SubscriptionID)
+			}
+			if recErr := s.q.RecordWebhookEventFailure(ctx, db.RecordWebhookEventFailureParams{
+				ID:            e.ID,
+				LastError:     sql.NullString{String: err.Error(), Valid: true},
+				NextAttemptAt: s.now().Add(webhookRetryDelay(attempt)),
+			}); recErr != nil {
+				return sent, fmt.Errorf("record webhook failure %d: %w", e.ID, recErr)
+			}
+			continue
+		}
+		// A failed update resends this event on the next pass.
+		if err := s.q.MarkWebhookEventSent(ctx, e.ID); err != nil {
+			return sent, fmt.Errorf("mark webhook event %d sent: %w", e.ID, err)
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// webhookRetryDelay is the wait after the given failed attempt.
+func webhookRetryDelay(attempt int32) time.Duration {
+	d := webhookRetryBase
+	for range attempt - 1 {
+		d *= 2
+		if d >= webhookRetryMax {
+			return webhookRetryMax
+		}
+	}
+	return d
+}
+
+// Prune deletes sent and abandoned events older than the retention.
+func (s *WebhookService) Prune(ctx context.Context) (int64, error) {
+	n, err := s.q.DeleteWebhookEventsBefore(ctx, db.DeleteWebhookEventsBeforeParams{
+		CreatedAt: s.now().Add(-webhookEventRetention),
+		Attempts:  MaxWebhookAttempts,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("delete webhook events: %w", err)
+	}
+	return n, nil
+}
+
+// RunDispatch calls Dispatch every interval, and Prune hourly, until ctx is
+// cancelled.
+func (s *WebhookService) RunDispatch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	prune := time.NewTicker(time.Hour)
+	defer prune.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.Dispatch(ctx)
+			if n > 0 {
+				s.log.InfoContext(ctx, "webhooks sent", "count", n)
+			}
+			if err != nil {
+				s.log.WarnContext(ctx, "webhook dispatch stopped; will retry", "error", err)
+			}
+		case <-prune.C:
+			if _, err := s.Prune(ctx); err != nil {
+				s.log.ErrorContext(ctx, "webhook event cleanup failed", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+type webhookPost struct {
+	endpoint, secret string
+	headers          map[string]string
+	body             []byte
+}
+
+// stubWebhookSender records posts and fails those to failFor.
+type stubWebhookSender struct {
+	posts   []webhookPost
+	failFor string
+}
+
+func (s *stubWebhookSender) Post(
+	_ context.Context,
+	_, endpoint, secret string,
+	headers map[string]string,
+	body []byte,
+) error {
+	s.posts = append(s.posts, webhookPost{endpoint: endpoint, secret: secret, headers: headers, body: body})
+	if endpoint == s.failFor {
+		return errors.New("webhook status 500")
+	}
+	return nil
+}
+
+func TestWebhookService_CreateValidates(t *testing.T) {
+	t.Parallel()
+
+	svc := NewWebhookService(mocks.NewMockQuerier(t), nil)
+	tests := []WebhookSubscriptionInput{
+		{URL: "ftp://example.com", EventTypes: []string{WebhookPantryUpdated}},
+		{URL: "https://example.com"},
+		{URL: "https://example.com", EventTypes: []string{"pantry.exploded"}},
+	}
+	for _, in := range tests {
+		_, err := svc.Create(context.Background(), in)
+		require.ErrorIs(t, err, ErrInvalidWebhook, in)
+	}
+}
+
+func TestWebhookService_CreateDefaultsActive(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	svc := NewWebhookService(mockQ, nil)
+	mockQ.EXPECT().CreateWebhookSubscription(mock.Anything, db.CreateWebhookSubscriptionParams{
+		Url:        "https://example.com/hook",
+		EventTypes: []string{WebhookPantryUpdated},
+		Active:     true,
+	}).Return(db.WebhookSubscription{ID: uuid.New(), Active: true}, nil)
+
+	_, err := svc.Create(context.Background(), WebhookSubscriptionInput{
+		URL:        " https://example.com/hook ",
+		EventTypes: []string{WebhookPantryUpdated, WebhookPantryUpdated},
+	})
+	require.NoError(t, err)
+}
+
+func TestWebhookService_Wrap(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	next := &stubUpdatePublisher{}
+	svc := NewWebhookService(mockQ, nil)
+	id := uuid.New()
+
+	var payload json.RawMessage
+	mockQ.EXPECT().EnqueueWebhookEvent(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, arg db.EnqueueWebhookEventParams) (int64, error) {
+			assert.Equal(t, WebhookPantryUpdated, arg.EventType)
+			payload = arg.Payload
+			return 2, nil
+		})
+
+	require.NoError(t, svc.Wrap(next).PublishPantryUpdated(context.Background(), []uuid.UUID{id}))
+	assert.Equal(t, [][]uuid.UUID{{id}}, next.published)
+	assert.Contains(t, string(payload), `"changed_item_ids":["`+id.String()+`"]`)
+}
+
+func TestWebhookService_Dispatch(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	sender := &stubWebhookSender{failFor: "https://b.example.com"}
+	svc := NewWebhookService(mockQ, sender)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	ok := db.ClaimDueWebhookEventsRow{
+		ID: 1, EventType: WebhookPantryUpdated, Payload: json.RawMessage(`{}`),
+		Url: "https://a.example.com", Secret: "s3cret",
+	}
+	failing := db.ClaimDueWebhookEventsRow{
+		ID: 2, EventType: WebhookIngestJobStatus, Payload: json.RawMessage(`{}`),
+		Url: "https://b.example.com", Attempts: 2,
+	}
+	mockQ.EXPECT().ClaimDueWebhookEvents(mock.Anything, db.ClaimDueWebhookEventsParams{
+		Attempts:     MaxWebhookAttempts,
+		Limit:        webhookBatchSize,
+		LeaseSeconds: webhookClaimLease.Seconds(),
+	}).Return([]db.ClaimDueWebhookEventsRow{ok, failing}, nil)
+	mockQ.EXPECT().MarkWebhookEventSent(mock.Anything, int64(1)).Return(nil)
+	mockQ.EXPECT().RecordWebhookEventFailure(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, arg db.RecordWebhookEventFailureParams) error {
+			assert.Equal(t, int64(2), arg.ID)
+			assert.Equal(t, now.Add(2*time.Minute), arg.NextAttemptAt, "the third failure waits 4× the base")
+			return nil
+		})
+
+	sent, err := svc.Dispatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, sender.posts, 2)
+	assert.Equal(t, "s3cret", sender.posts[0].secret)
+	assert.Equal(t, map[string]string{
+		WebhookEventHeader:    WebhookPantryUpdated,
+		WebhookDeliveryHeader: "1",
+	}, sender.posts[0].headers)
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 30*time.Second, webhookRetryDelay(1))
+	assert.Equal(t, time.Minute, webhookRetryDelay(2))
+	assert.Equal(t, time.Hour, webhookRetryDelay(MaxWebhookAttempts))
+}
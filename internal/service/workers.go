@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrIngestQueueFull is returned when the ingest worker queue has no room;
+// callers should ask the client to retry later.
+var ErrIngestQueueFull = errors.New("ingest queue is full")
+
+const (
+	// DefaultIngestWorkers is the default number of concurrent ingest jobs.
+	DefaultIngestWorkers = 4
+	// DefaultIngestQueueSize is the default number of jobs that may wait for
+	// a worker before new ingests are rejected.
+	DefaultIngestQueueSize = 100
+)
+
+const (
+	// PriorityInteractive is for jobs someone is waiting on in the app.
+	PriorityInteractive = "interactive"
+	// PriorityBackground is for bulk imports that can wait.
+	PriorityBackground = "background"
+)
+
+// IngestPriorities are the priorities a job can be created with.
+var IngestPriorities = []string{PriorityInteractive, PriorityBackground}
+
+// interactiveBurst is how many interactive jobs workers take in a row while
+// background jobs wait. The next pick goes to a background job, so a steady
+// stream of interactive work cannot starve bulk imports.
+const interactiveBurst = 4
+
+// ParseIngestPriority validates a caller-supplied priority, treating an
+// empty string as PriorityInteractive.
+func ParseIngestPriority(v string) (string, error) {
+	if v == "" {
+		return PriorityInteractive, nil
+	}
+	if !slices.Contains(IngestPriorities, v) {
+		return "", fmt.Errorf("unknown priority %q", v)
+	}
+	return v, nil
+}
+
+// WorkerStatus describes one ingest worker.
+type WorkerStatus struct {
+	ID          int        `json:"id"`
+	Busy        bool       `json:"busy"`
+	JobID       *uuid.UUID `json:"job_id,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	Processed   int64      `json:"processed"`
+	Failed      int64      `json:"failed"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+// WorkerPoolStatus is a point-in-time view of the ingest worker pool. Each
+// priority has its own queue of QueueCapacity jobs; QueueDepth is the total
+// waiting across both.
+type WorkerPoolStatus struct {
+	PoolSize         int            `json:"pool_size"`
+	QueueCapacity    int            `json:"queue_capacity"`
+	QueueDepth       int            `json:"queue_depth"`
+	InteractiveDepth int            `json:"interactive_depth"`
+	BackgroundDepth  int            `json:"background_depth"`
+	InFlight         int            `json:"in_flight"`
+	Processed        int64          `json:"processed"`
+	Failed           int64          `json:"failed"`
+	AvgJobDurationMs float64        `json:"avg_job_duration_ms"`
+	Workers          []WorkerStatus `json:"workers"`
+}
+
+// ingestTask is one job for a worker. done, when set, receives the outcome
+// of a job taken from the job queue, which claimed it into processing
+// already.
+type ingestTask struct {
+	jobID    uuid.UUID
+	rawInput string
+	priority string
+	done     chan<- error
+	// trace is the span the job was started under; its own span is a child.
+	trace trace.SpanContext
+}
+
+type workerPool struct {
+	interactive chan ingestTask
+	background  chan ingestTask
+
+	mu            sync.Mutex
+	workers       []WorkerStatus
+	totalDuration time.Duration
+	// streak counts interactive jobs taken since the last background one.
+	streak int
+}
+
+// StartWorkers switches ProcessJobAsync and ProcessQueuedJob from one
+// goroutine per job to a bounded pool of size workers. Interactive and
+// background jobs wait in separate queues of queueSize jobs each; workers
+// prefer interactive ones. Workers stop when ctx is cancelled.
+func (s *IngestService) StartWorkers(ctx context.Context, size, queueSize int) {
+	p := &workerPool{
+		interactive: make(chan ingestTask, queueSize),
+		background:  make(chan ingestTask, queueSize),
+		workers:     make([]WorkerStatus, size),
+	}
+	for id := range size {
+		p.workers[id].ID = id
+		go s.runWorker(ctx, p, id)
+	}
+	s.pool = p
+}
+
+func (s *IngestService) runWorker(ctx context.Context, p *workerPool, id int) {
+	for {
+		task, ok := p.next(ctx)
+		if !ok {
+			return
+		}
+		started := time.Now()
+		p.begin(id, task.jobID, started)
+		err := s.runJob(task)
+		p.finish(id, time.Since(started), err)
+	}
+}
+
+// queueFor returns the queue for priority; anything but background is
+// interactive.
+func (p *workerPool) queueFor(priority string) chan ingestTask {
+	if priority == PriorityBackground {
+		return p.background
+	}
+	return p.interactive
+}
+
+// next blocks for the next task, taking interactive jobs first except that
+// a background job goes next once interactiveBurst interactive jobs have
+// run in a row. ok is false when ctx is cancelled.
+func (p *workerPool) next(ctx context.Context) (task ingestTask, ok bool) {
+	if p.backgroundDue() {
+		select {
+		case task = <-p.background:
+			return p.took(task), true
+		default:
+		}
+	}
+	select {
+	case task = <-p.interactive:
+		return p.took(task), true
+	default:
+	}
+	select {
+	case <-ctx.Done():
+		return ingestTask{}, false
+	case task = <-p.interactive:
+	case task = <-p.background:
+	}
+	return p.took(task), true
+}
+
+func (p *workerPool) backgroundDue() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.streak >= interactiveBurst
+}
+
+// took updates the interactive streak for task.
+func (p *workerPool) took(task ingestTask) ingestTask {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if task.priority == PriorityBackground {
+		p.streak = 0
+	} else {
+		p.streak++
+	}
+	return task
+}
+
+func (p *workerPool) begin(id int, jobID uuid.UUID, started time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	w := &p.workers[id]
+	w.Busy, w.JobID, w.StartedAt = true, &jobID, &started
+}
+
+func (p *workerPool) finish(id int, d time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	w := &p.workers[id]
+	w.Busy, w.JobID, w.StartedAt = false, nil, nil
+	w.Processed++
+	p.totalDuration += d
+	if err != nil {
+		now := time.Now()
+		w.Failed++
+		w.LastError, w.LastErrorAt = err.Error(), &now
+	}
+}
+
+// WorkerStatus reports pool size, queue depth, in-flight jobs, average job
+// duration, and each worker's last error. Without StartWorkers the pool size
+// is zero.
+func (s *IngestService) WorkerStatus() WorkerPoolStatus {
+	p := s.pool
+	if p == nil {
+		return WorkerPoolStatus{Workers: []WorkerStatus{}}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	status := WorkerPoolStatus{
+		PoolSize:         len(p.workers),
+		QueueCapacity:    cap(p.interactive),
+		QueueDepth:       len(p.interactive) + len(p.background),
+		InteractiveDepth: len(p.interactive),
+		BackgroundDepth:  len(p.background),
+		Workers:          make([]WorkerStatus, len(p.workers)),
+	}
+	copy(status.Workers, p.workers)
+	for _, w := range p.workers {
+		if w.Busy {
+			status.InFlight++
+		}
+		status.Processed += w.Processed
+		status.Failed += w.Failed
+	}
+	if status.Processed > 0 {
+		status.AvgJobDurationMs = float64(p.totalDuration.Milliseconds()) / float64(status.Processed)
+	}
+	return status
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+	"github.com/mwhite7112/woodpantry-pantry/internal/mocks"
+)
+
+func TestProcessJobAsync_QueueFull(t *testing.T) {
+	t.Parallel()
+
+	svc := NewIngestService(mocks.NewMockQuerier(t), NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	svc.StartWorkers(ctx, 0, 1)
+
+	require.NoError(t, svc.ProcessJobAsync(context.Background(), uuid.New(), "milk", PriorityInteractive))
+	err := svc.ProcessJobAsync(context.Background(), uuid.New(), "eggs", PriorityInteractive)
+	assert.ErrorIs(t, err, ErrIngestQueueFull)
+
+	status := svc.WorkerStatus()
+	assert.Equal(t, 1, status.QueueDepth)
+	assert.Equal(t, 1, status.QueueCapacity)
+	assert.Equal(t, 0, status.InFlight)
+}
+
+func TestWorkerStatus_RecordsLastError(t *testing.T) {
+	t.Parallel()
+
+	mockQ := mocks.NewMockQuerier(t)
+	mockQ.EXPECT().UpsertIngestionJobTimings(mock.Anything, mock.Anything).Return(nil)
+	mockLLM := NewMockLLMExtractor(t)
+	svc := NewIngestService(mockQ, NewMockDictionaryResolver(t), mockLLM)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	svc.StartWorkers(ctx, 1, 1)
+
+	jobID := uuid.New()
+	mockQ.EXPECT().TransitionIngestionJobStatus(mock.Anything, toProcessing(jobID)).Return(db.IngestionJob{}, nil)
+	mockLLM.EXPECT().Extract(mock.Anything, "milk").Return(nil, errors.New("openai timeout"))
+	mockQ.EXPECT().FailIngestionJob(mock.Anything, db.FailIngestionJobParams{
+		ID:            jobID,
+		FailureReason: "llm extraction: openai timeout",
+	}).Return(1, nil)
+
+	require.NoError(t, svc.ProcessJobAsync(context.Background(), jobID, "milk", PriorityInteractive))
+	require.Eventually(t, func() bool {
+		return svc.WorkerStatus().Processed == 1
+	}, time.Second, 5*time.Millisecond)
+
+	status := svc.WorkerStatus()
+	assert.Equal(t, 1, status.PoolSize)
+	assert.Equal(t, int64(1), status.Failed)
+	require.Len(t, status.Workers, 1)
+	assert.False(t, status.Workers[0].Busy)
+	assert.Contains(t, status.Workers[0].LastError, "openai timeout")
+	assert.NotNil(t, status.Workers[0].LastErrorAt)
+}
+
+func TestWorkerStatus_WithoutPool(t *testing.T) {
+	t.Parallel()
+
+	svc := NewIngestService(mocks.NewMockQuerier(t), NewMockDictionaryResolver(t), NewMockLLMExtractor(t))
+	status := svc.WorkerStatus()
+	assert.Equal(t, 0, status.PoolSize)
+	assert.Empty(t, status.Workers)
+}
+
+func TestWorkerPool_InteractiveFirstWithoutStarvingBackground(t *testing.T) {
+	t.Parallel()
+
+	p := &workerPool{interactive: make(chan ingestTask, 8), background: make(chan ingestTask, 8)}
+	for range 6 {
+		p.queueFor(PriorityInteractive) <- ingestTask{priority: PriorityInteractive}
+	}
+	for range 2 {
+		p.queueFor(PriorityBackground) <- ingestTask{priority: PriorityBackground}
+	}
+
+	var order []string
+	for range 8 {
+		task, ok := p.next(context.Background())
+		require.True(t, ok)
+		order = append(order, task.priority)
+	}
+	i, b := PriorityInteractive, PriorityBackground
+	assert.Equal(t, []string{i, i, i, i, b, i, i, b}, order)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, ok := p.next(ctx)
+	assert.False(t, ok)
+}
+
+func TestParseIngestPriority(t *testing.T) {
+	t.Parallel()
+
+	p, err := ParseIngestPriority("")
+	require.NoError(t, err)
+	assert.Equal(t, PriorityInteractive, p)
+	p, err = ParseIngestPriority("background")
+	require.NoError(t, err)
+	assert.Equal(t, PriorityBackground, p)
+	_, err = ParseIngestPriority("urgent")
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package slo
+
+import (
+	"sort"
+	"time"
+)
+
+// LatencyBuckets are the upper bounds, in seconds, of the latency
+// histograms. They reach well past DefaultLatency so slow ingests still land
+// in a finite bucket.
+var LatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// Exemplar ties a histogram bucket to one request that fell in it.
+type Exemplar struct {
+	TraceID string
+	Value   float64
+	At      time.Time
+}
+
+// histogram is cumulative since start, like the request counters. counts
+// has one slot per LatencyBuckets bound plus +Inf and is not cumulative;
+// exemplars holds the latest traced request in each slot.
+type histogram struct {
+	counts    []int64
+	exemplars []Exemplar
+	sum       float64
+	count     int64
+}
+
+func newHistogram() *histogram {
+	return &histogram{
+		counts:    make([]int64, len(LatencyBuckets)+1),
+		exemplars: make([]Exemplar, len(LatencyBuckets)+1),
+	}
+}
+
+func (h *histogram) observe(elapsed time.Duration, traceID string, at time.Time) {
+	v := elapsed.Seconds()
+	i := sort.SearchFloat64s(LatencyBuckets, v)
+	h.counts[i]++
+	h.sum += v
+	h.count++
+	if traceID != "" {
+		h.exemplars[i] = Exemplar{TraceID: traceID, Value: v, At: at}
+	}
+}
+
+func (h *histogram) clone() *histogram {
+	c := *h
+	c.counts = append([]int64(nil), h.counts...)
+	c.exemplars = append([]Exemplar(nil), h.exemplars...)
+	return &c
+}
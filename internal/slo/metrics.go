@@ -0,0 +1,115 @@
+package slo
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteMetrics writes the tracker's state in the Prometheus text exposition
+// format. Request counters and latency histograms are cumulative since
+// start; budget gauges cover the rolling window.
+func (t *Tracker) WriteMetrics(w io.Writer) error {
+	return t.writeMetrics(w, false)
+}
+
+// WriteOpenMetrics writes the same series as WriteMetrics in the OpenMetrics
+// text format, which can also carry the trace exemplars on latency buckets.
+func (t *Tracker) WriteOpenMetrics(w io.Writer) error {
+	return t.writeMetrics(w, true)
+}
+
+func (t *Tracker) writeMetrics(w io.Writer, openMetrics bool) error {
+	r := t.Report()
+
+	t.mu.Lock()
+	totals := make(map[string][2]int64, len(t.endpoints))
+	latencies := make(map[string]*histogram)
+	for name, e := range t.endpoints {
+		totals[name] = [2]int64{e.total, e.bad}
+		if e.latency != nil {
+			latencies[name] = e.latency.clone()
+		}
+	}
+	t.mu.Unlock()
+
+	var b strings.Builder
+	family := func(name, kind, help string) {
+		// OpenMetrics names a counter family without its _total suffix.
+		if openMetrics && kind == "counter" {
+			name = strings.TrimSuffix(name, "_total")
+		}
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	}
+	perEndpoint := func(name string, value func(EndpointReport) float64) {
+		for _, e := range r.Endpoints {
+			fmt.Fprintf(&b, "%s{endpoint=%s} %s\n", name, strconv.Quote(e.Endpoint), formatFloat(value(e)))
+		}
+	}
+
+	family("pantry_slo_requests_total", "counter", "Requests counted against the SLO.")
+	perEndpoint("pantry_slo_requests_total", func(e EndpointReport) float64 { return float64(totals[e.Endpoint][0]) })
+	family("pantry_slo_bad_requests_total", "counter", "Requests that failed with a 5xx or exceeded the latency target.")
+	perEndpoint("pantry_slo_bad_requests_total", func(e EndpointReport) float64 { return float64(totals[e.Endpoint][1]) })
+	family("pantry_slo_objective", "gauge", "Target fraction of good requests.")
+	perEndpoint("pantry_slo_objective", func(e EndpointReport) float64 { return e.Objective })
+	family("pantry_slo_latency_target_seconds", "gauge", "Latency above which a request counts as bad.")
+	perEndpoint("pantry_slo_latency_target_seconds", func(e EndpointReport) float64 { return float64(e.LatencyMS) / 1000 })
+	family("pantry_slo_burn_rate", "gauge", "Error budget burn rate over the window; 1 spends it exactly.")
+	perEndpoint("pantry_slo_burn_rate", func(e EndpointReport) float64 { return e.BurnRate })
+	family("pantry_slo_error_budget_remaining", "gauge", "Fraction of the error budget left over the window.")
+	perEndpoint("pantry_slo_error_budget_remaining", func(e EndpointReport) float64 { return e.BudgetRemaining })
+
+	family("pantry_slo_service_error_budget_remaining", "gauge", "Fraction of the whole service's error budget left.")
+	fmt.Fprintf(&b, "pantry_slo_service_error_budget_remaining %s\n", formatFloat(r.BudgetRemaining))
+	family("pantry_slo_shedding", "gauge", "1 while low-priority traffic is being shed.")
+	fmt.Fprintf(&b, "pantry_slo_shedding %d\n", boolGauge(r.Shedding))
+	family("pantry_slo_shed_requests_total", "counter", "Low-priority requests turned away to protect the budget.")
+	fmt.Fprintf(&b, "pantry_slo_shed_requests_total %d\n", r.ShedRequests)
+
+	family("pantry_slo_request_duration_seconds", "histogram", "Request latency for endpoints that keep a histogram.")
+	for _, e := range r.Endpoints {
+		if h := latencies[e.Endpoint]; h != nil {
+			writeHistogram(&b, "pantry_slo_request_duration_seconds", strconv.Quote(e.Endpoint), h, openMetrics)
+		}
+	}
+
+	if openMetrics {
+		b.WriteString("# EOF\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeHistogram writes h's cumulative buckets, sum and count. In
+// OpenMetrics each bucket carries its latest exemplar.
+func writeHistogram(b *strings.Builder, name, endpoint string, h *histogram, openMetrics bool) {
+	var cumulative int64
+	for i, n := range h.counts {
+		cumulative += n
+		le := "+Inf"
+		if i < len(LatencyBuckets) {
+			le = formatFloat(LatencyBuckets[i])
+		}
+		fmt.Fprintf(b, "%s_bucket{endpoint=%s,le=%q} %d", name, endpoint, le, cumulative)
+		if ex := h.exemplars[i]; openMetrics && ex.TraceID != "" {
+			fmt.Fprintf(b, " # {trace_id=%q} %s %s", ex.TraceID, formatFloat(ex.Value),
+				strconv.FormatFloat(float64(ex.At.UnixMilli())/1000, 'f', 3, 64))
+		}
+		b.WriteByte('\n')
+	}
+	fmt.Fprintf(b, "%s_sum{endpoint=%s} %s\n", name, endpoint, formatFloat(h.sum))
+	fmt.Fprintf(b, "%s_count{endpoint=%s} %d\n", name, endpoint, h.count)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func boolGauge(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,286 @@
+// Package slo tracks per-endpoint success rate and latency against
+// service-level objectives and reports how much of each error budget is
+// left over a rolling window.
+package slo
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultSuccess = 0.99
+	DefaultLatency = time.Second
+	DefaultWindow  = time.Hour
+)
+
+// windowBuckets is how many slices the rolling window is kept in; requests
+// age out one slice at a time.
+const windowBuckets = 60
+
+// minShedRequests keeps a handful of early failures from tripping shedding
+// before the window holds enough traffic to judge.
+const minShedRequests = 50
+
+// Objective is the target for one endpoint: at least Success of requests
+// must be good, where good means a non-5xx response within Latency.
+type Objective struct {
+	Success float64
+	Latency time.Duration
+}
+
+// ParseObjectives reads per-endpoint overrides such as
+//
+//	POST /pantry/ingest=0.95:5s,GET /pantry=0.999
+//
+// Endpoints are "METHOD /route/{pattern}" as registered on the router. A
+// missing latency falls back to def's.
+func ParseObjectives(spec string, def Objective) (map[string]Objective, error) {
+	objectives := map[string]Objective{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		endpoint, target, ok := strings.Cut(entry, "=")
+		method, route, _ := strings.Cut(strings.TrimSpace(endpoint), " ")
+		if !ok || method == "" || !strings.HasPrefix(route, "/") {
+			return nil, fmt.Errorf("slo: invalid entry %q", entry)
+		}
+		success, latency, hasLatency := strings.Cut(target, ":")
+		o := Objective{Latency: def.Latency}
+		var err error
+		if o.Success, err = strconv.ParseFloat(strings.TrimSpace(success), 64); err != nil ||
+			o.Success <= 0 || o.Success >= 1 {
+			return nil, fmt.Errorf("slo: %q: success target must be between 0 and 1 exclusive", entry)
+		}
+		if hasLatency {
+			if o.Latency, err = time.ParseDuration(strings.TrimSpace(latency)); err != nil || o.Latency <= 0 {
+				return nil, fmt.Errorf("slo: %q: latency must be a positive duration", entry)
+			}
+		}
+		objectives[strings.ToUpper(method)+" "+route] = o
+	}
+	return objectives, nil
+}
+
+// EndpointReport is one endpoint's standing over the current window.
+// BurnRate is how fast the budget is being spent: 1 spends it exactly over
+// the window, above 1 exhausts it early. BudgetRemaining is 1 - BurnRate,
+// floored at 0.
+type EndpointReport struct {
+	Endpoint        string  `json:"endpoint"`
+	Objective       float64 `json:"objective"`
+	LatencyMS       int64   `json:"latency_target_ms"`
+	Requests        int64   `json:"requests"`
+	Bad             int64   `json:"bad"`
+	SuccessRate     float64 `json:"success_rate"`
+	BurnRate        float64 `json:"burn_rate"`
+	BudgetRemaining float64 `json:"budget_remaining"`
+}
+
+// Report is the whole service's standing. The overall budget weighs every
+// endpoint's allowance by its traffic.
+type Report struct {
+	Window          string           `json:"window"`
+	Requests        int64            `json:"requests"`
+	Bad             int64            `json:"bad"`
+	BurnRate        float64          `json:"burn_rate"`
+	BudgetRemaining float64          `json:"budget_remaining"`
+	ShedBelow       float64          `json:"shed_below"`
+	Shedding        bool             `json:"shedding"`
+	ShedRequests    int64            `json:"shed_requests"`
+	Endpoints       []EndpointReport `json:"endpoints"`
+}
+
+type bucket struct {
+	slot       int64
+	total, bad int64
+}
+
+type endpoint struct {
+	objective Objective
+	buckets   [windowBuckets]bucket
+	// Cumulative since start, for Prometheus counters.
+	total, bad int64
+	// latency is nil unless SetHistograms named the endpoint.
+	latency *histogram
+}
+
+// Tracker records request outcomes. It is safe for concurrent use.
+type Tracker struct {
+	def       Objective
+	overrides map[string]Objective
+	window    time.Duration
+	shedBelow float64
+	now       func() time.Time
+	// histograms names the endpoints that keep a latency histogram.
+	histograms map[string]bool
+
+	mu        sync.Mutex
+	endpoints map[string]*endpoint
+	shed      int64
+}
+
+// New returns a tracker that holds every endpoint to def unless overrides
+// names it. A window shorter than a minute uses DefaultWindow.
+func New(def Objective, overrides map[string]Objective, window time.Duration) *Tracker {
+	if window < time.Minute {
+		window = DefaultWindow
+	}
+	return &Tracker{
+		def:       def,
+		overrides: overrides,
+		window:    window,
+		now:       time.Now,
+		endpoints: make(map[string]*endpoint),
+	}
+}
+
+// SetShedBelow turns on load shedding: Shedding reports true while the
+// overall budget remaining is under fraction. Zero turns it off.
+func (t *Tracker) SetShedBelow(fraction float64) {
+	t.shedBelow = fraction
+}
+
+// SetHistograms keeps a latency histogram, with trace exemplars, for each
+// of endpoints. Call it before the tracker records anything.
+func (t *Tracker) SetHistograms(endpoints ...string) {
+	t.histograms = make(map[string]bool, len(endpoints))
+	for _, name := range endpoints {
+		t.histograms[name] = true
+	}
+}
+
+// Record counts one request to endpoint ("METHOD /route").
+func (t *Tracker) Record(endpoint string, status int, elapsed time.Duration) {
+	t.RecordTrace(endpoint, status, elapsed, "")
+}
+
+// RecordTrace is Record for a request that belongs to traceID. When the
+// endpoint keeps a histogram, the request becomes the exemplar of the
+// latency bucket it falls in. An empty traceID records no exemplar.
+func (t *Tracker) RecordTrace(endpoint string, status int, elapsed time.Duration, traceID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e := t.endpoint(endpoint)
+	if e.latency != nil {
+		e.latency.observe(elapsed, traceID, t.now())
+	}
+	bad := status >= http.StatusInternalServerError || elapsed > e.objective.Latency
+	b := t.bucket(e)
+	b.total++
+	e.total++
+	if bad {
+		b.bad++
+		e.bad++
+	}
+}
+
+// RecordShed counts a request turned away by shedding. Shed requests do not
+// count against the budget, or shedding would keep itself going.
+func (t *Tracker) RecordShed() {
+	t.mu.Lock()
+	t.shed++
+	t.mu.Unlock()
+}
+
+// Shedding reports whether low-priority traffic should be turned away.
+func (t *Tracker) Shedding() bool {
+	if t.shedBelow <= 0 {
+		return false
+	}
+	return t.Report().Shedding
+}
+
+// Report summarizes the current window, endpoints sorted by name.
+func (t *Tracker) Report() Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	r := Report{
+		Window:       t.window.String(),
+		ShedBelow:    t.shedBelow,
+		ShedRequests: t.shed,
+		Endpoints:    []EndpointReport{},
+	}
+	var allowed float64
+	for name, e := range t.endpoints {
+		er := t.endpointReport(name, e)
+		r.Endpoints = append(r.Endpoints, er)
+		r.Requests += er.Requests
+		r.Bad += er.Bad
+		allowed += (1 - er.Objective) * float64(er.Requests)
+	}
+	slices.SortFunc(r.Endpoints, func(a, b EndpointReport) int { return strings.Compare(a.Endpoint, b.Endpoint) })
+	r.BurnRate, r.BudgetRemaining = burn(r.Bad, allowed)
+	r.Shedding = t.shedBelow > 0 && r.Requests >= minShedRequests && r.BudgetRemaining < t.shedBelow
+	return r
+}
+
+func (t *Tracker) endpointReport(name string, e *endpoint) EndpointReport {
+	er := EndpointReport{
+		Endpoint:    name,
+		Objective:   e.objective.Success,
+		LatencyMS:   e.objective.Latency.Milliseconds(),
+		SuccessRate: 1,
+	}
+	oldest := t.slot() - windowBuckets
+	for _, b := range e.buckets {
+		if b.slot > oldest {
+			er.Requests += b.total
+			er.Bad += b.bad
+		}
+	}
+	if er.Requests > 0 {
+		er.SuccessRate = 1 - float64(er.Bad)/float64(er.Requests)
+	}
+	er.BurnRate, er.BudgetRemaining = burn(er.Bad, (1-er.Objective)*float64(er.Requests))
+	return er
+}
+
+// burn compares bad requests with the number the objective allows. Success
+// targets are below 1, so allowed is positive whenever bad is.
+func burn(bad int64, allowed float64) (rate, remaining float64) {
+	if bad == 0 {
+		return 0, 1
+	}
+	rate = float64(bad) / allowed
+	return rate, max(0, 1-rate)
+}
+
+func (t *Tracker) endpoint(name string) *endpoint {
+	e, ok := t.endpoints[name]
+	if !ok {
+		o, set := t.overrides[name]
+		if !set {
+			o = t.def
+		}
+		e = &endpoint{objective: o}
+		if t.histograms[name] {
+			e.latency = newHistogram()
+		}
+		t.endpoints[name] = e
+	}
+	return e
+}
+
+func (t *Tracker) slot() int64 {
+	return t.now().UnixNano() / int64(t.window/windowBuckets)
+}
+
+// bucket returns the current slice of e's window, clearing it if it last
+// held an older slice.
+func (t *Tracker) bucket(e *endpoint) *bucket {
+	slot := t.slot()
+	b := &e.buckets[slot%windowBuckets]
+	if b.slot != slot {
+		*b = bucket{slot: slot}
+	}
+	return b
+}
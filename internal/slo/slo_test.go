@@ -0,0 +1,121 @@
+package slo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseObjectives(t *testing.T) {
+	t.Parallel()
+
+	def := Objective{Success: 0.99, Latency: time.Second}
+	objectives, err := ParseObjectives("post /pantry/ingest=0.95:5s, GET /pantry/items/{id}/lots=0.999", def)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Objective{
+		"POST /pantry/ingest":         {Success: 0.95, Latency: 5 * time.Second},
+		"GET /pantry/items/{id}/lots": {Success: 0.999, Latency: time.Second},
+	}, objectives)
+
+	for _, bad := range []string{"GET=0.9", "/pantry=0.9", "GET /pantry=1", "GET /pantry=0.9:fast", "GET /pantry"} {
+		_, err := ParseObjectives(bad, def)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestTracker_BudgetOverWindow(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tr := New(Objective{Success: 0.9, Latency: time.Second}, nil, time.Hour)
+	tr.now = func() time.Time { return now }
+
+	for range 18 {
+		tr.Record("GET /pantry", 200, 10*time.Millisecond)
+	}
+	tr.Record("GET /pantry", 500, 10*time.Millisecond)
+	tr.Record("GET /pantry", 200, 2*time.Second)
+
+	r := tr.Report()
+	require.Len(t, r.Endpoints, 1)
+	e := r.Endpoints[0]
+	assert.Equal(t, int64(20), e.Requests)
+	assert.Equal(t, int64(2), e.Bad, "a 5xx and a slow response")
+	assert.InDelta(t, 0.9, e.SuccessRate, 1e-9)
+	assert.InDelta(t, 1, e.BurnRate, 1e-9)
+	assert.InDelta(t, 0, e.BudgetRemaining, 1e-9)
+
+	now = now.Add(2 * time.Hour)
+	r = tr.Report()
+	assert.Zero(t, r.Requests, "old requests age out of the window")
+	assert.InDelta(t, 1, r.BudgetRemaining, 1e-9)
+}
+
+func TestTracker_Shedding(t *testing.T) {
+	t.Parallel()
+
+	tr := New(Objective{Success: 0.99, Latency: time.Second}, nil, time.Hour)
+	for range 10 {
+		tr.Record("GET /pantry", 503, 0)
+	}
+	assert.False(t, tr.Shedding(), "off until a threshold is set")
+
+	tr.SetShedBelow(0.2)
+	assert.False(t, tr.Shedding(), "too little traffic to judge")
+
+	for range minShedRequests {
+		tr.Record("GET /pantry", 200, 0)
+	}
+	assert.True(t, tr.Shedding())
+	tr.RecordShed()
+	assert.Equal(t, int64(1), tr.Report().ShedRequests)
+}
+
+func TestTracker_WriteMetrics(t *testing.T) {
+	t.Parallel()
+
+	tr := New(Objective{Success: 0.99, Latency: time.Second}, nil, time.Hour)
+	tr.Record("POST /pantry/items", 201, 0)
+	tr.Record("POST /pantry/items", 500, 0)
+
+	var b strings.Builder
+	require.NoError(t, tr.WriteMetrics(&b))
+	out := b.String()
+	assert.Contains(t, out, "# TYPE pantry_slo_requests_total counter\n")
+	assert.Contains(t, out, `pantry_slo_requests_total{endpoint="POST /pantry/items"} 2`)
+	assert.Contains(t, out, `pantry_slo_bad_requests_total{endpoint="POST /pantry/items"} 1`)
+	assert.Contains(t, out, `pantry_slo_error_budget_remaining{endpoint="POST /pantry/items"} 0`)
+	assert.Contains(t, out, "pantry_slo_shedding 0\n")
+}
+
+func TestTracker_HistogramExemplars(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tr := New(Objective{Success: 0.99, Latency: time.Second}, nil, time.Hour)
+	tr.now = func() time.Time { return now }
+	tr.SetHistograms("POST /pantry/ingest")
+
+	tr.RecordTrace("POST /pantry/ingest", 202, 40*time.Millisecond, "")
+	tr.RecordTrace("POST /pantry/ingest", 202, 3*time.Second, "4bf92f3577b34da6a3ce929d0e0e4736")
+	tr.RecordTrace("GET /pantry", 200, 3*time.Second, "0af7651916cd43dd8448eb211c80319c")
+
+	var b strings.Builder
+	require.NoError(t, tr.WriteOpenMetrics(&b))
+	const bucket = `pantry_slo_request_duration_seconds_bucket{endpoint="POST /pantry/ingest"`
+	out := b.String()
+	assert.Contains(t, out, "# TYPE pantry_slo_requests counter\n")
+	assert.Contains(t, out, bucket+`,le="0.05"} 1`+"\n")
+	assert.Contains(t, out, bucket+`,le="5"} 2 # {trace_id="4bf92f3577b34da6a3ce929d0e0e4736"} 3 1772366400.000`+"\n")
+	assert.Contains(t, out, `pantry_slo_request_duration_seconds_count{endpoint="POST /pantry/ingest"} 2`)
+	assert.NotContains(t, out, `request_duration_seconds_bucket{endpoint="GET /pantry"`, "no histogram unless named")
+	assert.True(t, strings.HasSuffix(out, "# EOF\n"))
+
+	b.Reset()
+	require.NoError(t, tr.WriteMetrics(&b))
+	assert.Contains(t, b.String(), bucket+`,le="5"} 2`+"\n")
+	assert.NotContains(t, b.String(), "trace_id", "the Prometheus text format has no exemplars")
+}
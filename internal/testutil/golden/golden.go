@@ -0,0 +1,111 @@
+// Package golden compares response bodies with snapshot files under
+// testdata/golden. Run the tests with -update to rewrite the snapshots after
+// an intended change, then review the diff.
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+var (
+	uuidPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	timePattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+	namePattern = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+)
+
+// Normalize makes body stable across runs. JSON is re-indented with sorted
+// keys, and the values of any scrub fields, at any depth, become <scrubbed>.
+// UUIDs become <uuid-N>, numbered by first appearance so that equal IDs stay
+// visibly equal. RFC 3339 timestamps become <time>.
+func Normalize(body []byte, scrub ...string) []byte {
+	var v any
+	if json.Unmarshal(body, &v) == nil {
+		scrubFields(v, scrub)
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if enc.Encode(v) == nil {
+			body = buf.Bytes()
+		}
+	}
+
+	seen := map[string]int{}
+	body = uuidPattern.ReplaceAllFunc(body, func(id []byte) []byte {
+		key := strings.ToLower(string(id))
+		n, ok := seen[key]
+		if !ok {
+			n = len(seen) + 1
+			seen[key] = n
+		}
+		return fmt.Appendf(nil, "<uuid-%d>", n)
+	})
+	body = timePattern.ReplaceAll(body, []byte("<time>"))
+	if !bytes.HasSuffix(body, []byte("\n")) {
+		body = append(body, '\n')
+	}
+	return body
+}
+
+func scrubFields(v any, fields []string) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if slices.Contains(fields, k) {
+				v[k] = "<scrubbed>"
+				continue
+			}
+			scrubFields(child, fields)
+		}
+	case []any:
+		for _, child := range v {
+			scrubFields(child, fields)
+		}
+	}
+}
+
+// Assert compares the normalized body with testdata/golden/<test name>.golden.
+func Assert(t *testing.T, body []byte, scrub ...string) {
+	t.Helper()
+	compare(t, Normalize(body, scrub...))
+}
+
+// AssertResponse snapshots the status line and Content-Type along with the
+// normalized body, so a changed status or media type is caught too.
+func AssertResponse(t *testing.T, rec *httptest.ResponseRecorder, scrub ...string) {
+	t.Helper()
+	head := fmt.Sprintf("%d %s\nContent-Type: %s\n\n",
+		rec.Code, http.StatusText(rec.Code), rec.Header().Get("Content-Type"))
+	compare(t, append([]byte(head), Normalize(rec.Body.Bytes(), scrub...)...))
+}
+
+func compare(t *testing.T, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", "golden", namePattern.ReplaceAllString(t.Name(), "_")+".golden")
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, got, 0o600))
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "missing golden file; run the test with -update to create it")
+	assert.Equal(t, string(want), string(got), "response differs from %s; run with -update if intended", path)
+}
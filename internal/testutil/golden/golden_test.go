@@ -0,0 +1,28 @@
+package golden
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	a, b := "6f1c2a0e-1b2c-4d3e-8f90-0123456789ab", "0a1b2c3d-4e5f-4a6b-8c7d-8e9f0a1b2c3d"
+	body := `{"b":"` + a + `","a":["` + b + `","` + a + `"],"at":"2026-03-01T12:00:00.123Z","took_ms":17}`
+
+	assert.Equal(t, `{
+  "a": [
+    "<uuid-1>",
+    "<uuid-2>"
+  ],
+  "at": "<time>",
+  "b": "<uuid-2>",
+  "took_ms": "<scrubbed>"
+}
+`, string(Normalize([]byte(body), "took_ms")))
+
+	assert.Equal(t, "id,at\n<uuid-1>,<time>\n", string(Normalize([]byte("id,at\n"+a+",2026-03-01T12:00:00+01:00"))),
+		"non-JSON bodies are kept as text")
+}
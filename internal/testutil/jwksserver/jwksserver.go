@@ -0,0 +1,142 @@
+// Package jwksserver provides an httptest-based identity provider: it serves
+// a JWKS and signs tokens with the matching private keys, so bearer auth can
+// be tested without a real issuer.
+package jwksserver
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// Server serves /.well-known/jwks.json with one RSA key ("rsa-1") and one
+// P-256 key ("ec-1").
+type Server struct {
+	*httptest.Server
+
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+
+	mu      sync.Mutex
+	status  int
+	fetches atomic.Int32
+}
+
+// New starts a Server that is closed via t.Cleanup.
+func New(t testing.TB) *Server {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("jwksserver: generate rsa key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("jwksserver: generate ec key: %v", err)
+	}
+	srv := &Server{rsaKey: rsaKey, ecKey: ecKey, status: http.StatusOK}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/jwks.json", srv.handle)
+	srv.Server = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// JWKSURL is the key set's address.
+func (s *Server) JWKSURL() string {
+	return s.URL + "/.well-known/jwks.json"
+}
+
+// Fetches returns how many times the key set has been requested.
+func (s *Server) Fetches() int {
+	return int(s.fetches.Load())
+}
+
+// SetStatus makes the key set endpoint answer status with no body, or
+// serve the keys again with 200.
+func (s *Server) SetStatus(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+// SignRS256 returns a token carrying claims, signed with the RSA key.
+func (s *Server) SignRS256(t testing.TB, claims map[string]any) string {
+	t.Helper()
+	input := signingInput(t, map[string]any{"alg": "RS256", "kid": "rsa-1", "typ": "JWT"}, claims)
+	digest := sha256.Sum256([]byte(input))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.rsaKey, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("jwksserver: sign: %v", err)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// SignES256 returns a token carrying claims, signed with the P-256 key.
+func (s *Server) SignES256(t testing.TB, claims map[string]any) string {
+	t.Helper()
+	input := signingInput(t, map[string]any{"alg": "ES256", "kid": "ec-1", "typ": "JWT"}, claims)
+	digest := sha256.Sum256([]byte(input))
+	r, sv, err := ecdsa.Sign(rand.Reader, s.ecKey, digest[:])
+	if err != nil {
+		t.Fatalf("jwksserver: sign: %v", err)
+	}
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	sv.FillBytes(sig[32:])
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func signingInput(t testing.TB, header, claims map[string]any) string {
+	t.Helper()
+	h, err := json.Marshal(header)
+	if err != nil {
+		t.Fatalf("jwksserver: marshal header: %v", err)
+	}
+	c, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("jwksserver: marshal claims: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+}
+
+func (s *Server) handle(w http.ResponseWriter, _ *http.Request) {
+	s.fetches.Add(1)
+	s.mu.Lock()
+	status := s.status
+	s.mu.Unlock()
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+		return
+	}
+
+	b64 := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	// An uncompressed P-256 point is 0x04 || x || y.
+	point, err := s.ecKey.PublicKey.Bytes()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	keys := []map[string]string{
+		{
+			"kty": "RSA", "kid": "rsa-1", "use": "sig", "alg": "RS256",
+			"n": b64(s.rsaKey.N.Bytes()),
+			"e": b64(big.NewInt(int64(s.rsaKey.E)).Bytes()),
+		},
+		{"kty": "EC", "kid": "ec-1", "use": "sig", "crv": "P-256", "x": b64(point[1:33]), "y": b64(point[33:])},
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"keys": keys}) //nolint:errcheck
+}
@@ -0,0 +1,227 @@
+// Package llmserver provides an httptest-based OpenAI-compatible chat
+// completions server with canned scenarios, so extraction behavior can be
+// tested deterministically without real API keys.
+package llmserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Scenario describes one canned response.
+type Scenario struct {
+	// Status is the HTTP status to return. Zero means 200.
+	Status int
+	// Content is the assistant message content wrapped in a chat completion
+	// envelope. Ignored when RawBody is set.
+	Content string
+	// RawBody, when non-empty, is written verbatim instead of an envelope.
+	RawBody string
+	// Headers are added to the response.
+	Headers map[string]string
+	// Delay is waited before responding (or until the client gives up).
+	Delay time.Duration
+	// TotalTokens is reported as usage.total_tokens in the envelope.
+	TotalTokens int
+}
+
+// HappyPath returns a scenario whose content is the JSON encoding of v,
+// typically an ExtractionResponse-shaped value.
+func HappyPath(v any) Scenario {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("llmserver: marshal happy path content: %v", err))
+	}
+	return Scenario{Content: string(b)}
+}
+
+// MalformedJSON returns a 200 whose message content is not valid JSON, as
+// when a model wraps its answer in prose.
+func MalformedJSON() Scenario {
+	return Scenario{Content: "Sure! Here are your items: {\"items\": [oops"}
+}
+
+// RateLimited returns a 429 with a Retry-After header.
+func RateLimited(retryAfter time.Duration) Scenario {
+	return Scenario{
+		Status:  http.StatusTooManyRequests,
+		RawBody: `{"error":{"message":"Rate limit reached","type":"rate_limit_error"}}`,
+		Headers: map[string]string{"Retry-After": strconv.Itoa(int(retryAfter.Seconds()))},
+	}
+}
+
+// ServerError returns a 500 with an OpenAI-style error body.
+func ServerError() Scenario {
+	return Scenario{
+		Status:  http.StatusInternalServerError,
+		RawBody: `{"error":{"message":"The server had an error","type":"server_error"}}`,
+	}
+}
+
+// Slow wraps s so the response is delayed by d.
+func Slow(d time.Duration, s Scenario) Scenario {
+	s.Delay = d
+	return s
+}
+
+// Message is one chat message received by the server.
+type Message struct {
+	Role    string `json:"role"`
+	Content any    `json:"content"`
+}
+
+// Request is a chat completion request received by the server.
+type Request struct {
+	Model          string         `json:"model"`
+	Messages       []Message      `json:"messages"`
+	ResponseFormat map[string]any `json:"response_format"`
+	Authorization  string         `json:"-"`
+}
+
+// LastUserText returns the text of the final message, or "" when its content
+// is not a plain string.
+func (r Request) LastUserText() string {
+	if len(r.Messages) == 0 {
+		return ""
+	}
+	s, _ := r.Messages[len(r.Messages)-1].Content.(string)
+	return s
+}
+
+// Server is a running mock OpenAI server.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	fallback  Scenario
+	byInput   map[string]Scenario
+	sequence  []Scenario
+	requests  []Request
+	callCount int
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithDefault sets the scenario used when no other rule matches. Without it,
+// unmatched requests receive ServerError.
+func WithDefault(s Scenario) Option {
+	return func(srv *Server) { srv.fallback = s }
+}
+
+// WithInput responds with s when the last message text equals input exactly.
+func WithInput(input string, s Scenario) Option {
+	return func(srv *Server) { srv.byInput[input] = s }
+}
+
+// WithSequence responds with the given scenarios in order, one per call,
+// before falling back to input matching and the default. Useful for retry
+// tests (e.g. RateLimited then HappyPath).
+func WithSequence(scenarios ...Scenario) Option {
+	return func(srv *Server) { srv.sequence = append(srv.sequence, scenarios...) }
+}
+
+// New starts a Server that is closed via t.Cleanup. Point the extractor at
+// URL (the server serves /chat/completions and /v1/chat/completions).
+func New(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+
+	srv := &Server{fallback: ServerError(), byInput: map[string]Scenario{}}
+	for _, opt := range opts {
+		opt(srv)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /chat/completions", srv.handle)
+	mux.HandleFunc("POST /v1/chat/completions", srv.handle)
+	srv.Server = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// Requests returns a copy of every request received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Calls returns the number of requests received so far.
+func (s *Server) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.callCount
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"error":{"message":"invalid request body"}}`, http.StatusBadRequest)
+		return
+	}
+	req.Authorization = r.Header.Get("Authorization")
+
+	sc := s.pick(req)
+
+	if sc.Delay > 0 {
+		select {
+		case <-time.After(sc.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	for k, v := range sc.Headers {
+		w.Header().Set(k, v)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	status := sc.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+
+	if sc.RawBody != "" {
+		w.Write([]byte(sc.RawBody)) //nolint:errcheck
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck
+		"id":     "chatcmpl-mock",
+		"object": "chat.completion",
+		"model":  req.Model,
+		"choices": []map[string]any{{
+			"index":         0,
+			"finish_reason": "stop",
+			"message":       map[string]string{"role": "assistant", "content": sc.Content},
+		}},
+		"usage": map[string]int{
+			"prompt_tokens":     0,
+			"completion_tokens": sc.TotalTokens,
+			"total_tokens":      sc.TotalTokens,
+		},
+	})
+}
+
+func (s *Server) pick(req Request) Scenario {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, req)
+	s.callCount++
+
+	if len(s.sequence) > 0 {
+		sc := s.sequence[0]
+		s.sequence = s.sequence[1:]
+		return sc
+	}
+	if sc, ok := s.byInput[req.LastUserText()]; ok {
+		return sc
+	}
+	return s.fallback
+}
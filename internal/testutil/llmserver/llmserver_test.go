@@ -0,0 +1,105 @@
+package llmserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func post(t *testing.T, ctx context.Context, url, input string) (*http.Response, error) {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{
+		"model":    "gpt-test",
+		"messages": []map[string]string{{"role": "user", "content": input}},
+	})
+	require.NoError(t, err)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url+"/chat/completions", bytes.NewReader(body))
+	require.NoError(t, err)
+	return http.DefaultClient.Do(req)
+}
+
+func content(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	defer resp.Body.Close()
+	var out struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	require.Len(t, out.Choices, 1)
+	return out.Choices[0].Message.Content
+}
+
+func TestServer_InputMatchingAndDefault(t *testing.T) {
+	t.Parallel()
+
+	srv := New(t,
+		WithInput("eggs", HappyPath(map[string]any{"items": []string{"eggs"}})),
+		WithDefault(MalformedJSON()),
+	)
+
+	resp, err := post(t, context.Background(), srv.URL, "eggs")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"items":["eggs"]}`, content(t, resp))
+
+	resp, err = post(t, context.Background(), srv.URL, "something else")
+	require.NoError(t, err)
+	assert.Equal(t, MalformedJSON().Content, content(t, resp))
+
+	assert.Equal(t, 2, srv.Calls())
+	assert.Equal(t, "eggs", srv.Requests()[0].LastUserText())
+}
+
+func TestServer_UnmatchedReturnsServerError(t *testing.T) {
+	t.Parallel()
+
+	srv := New(t)
+	resp, err := post(t, context.Background(), srv.URL, "anything")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestServer_SequenceThenDefault(t *testing.T) {
+	t.Parallel()
+
+	srv := New(t,
+		WithSequence(RateLimited(2*time.Second)),
+		WithDefault(HappyPath(map[string]any{"items": []any{}})),
+	)
+
+	resp, err := post(t, context.Background(), srv.URL, "x")
+	require.NoError(t, err)
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	assert.Contains(t, string(raw), "rate_limit_error")
+
+	resp, err = post(t, context.Background(), srv.URL, "x")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items":[]}`, content(t, resp))
+}
+
+func TestServer_SlowRespectsClientDeadline(t *testing.T) {
+	t.Parallel()
+
+	srv := New(t, WithDefault(Slow(time.Second, HappyPath(map[string]any{}))))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := post(t, ctx, srv.URL, "x")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
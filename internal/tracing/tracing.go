@@ -0,0 +1,159 @@
+// Package tracing wires OpenTelemetry spans through the service. Tracing is
+// off unless an OTLP endpoint is configured, in which case spans are exported
+// over OTLP/HTTP and W3C trace context is propagated on incoming requests,
+// Dictionary calls and RabbitMQ and Kafka messages.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/logging"
+)
+
+// ServiceName is the default service.name resource attribute; OTEL_SERVICE_NAME
+// overrides it.
+const ServiceName = "woodpantry-pantry"
+
+const instrumentation = "github.com/mwhite7112/woodpantry-pantry"
+
+// propagator carries W3C trace context and baggage. It is used directly
+// rather than through the global so propagation works before Setup runs.
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// Enabled reports whether getenv configures an OTLP trace endpoint.
+func Enabled(getenv func(string) string) bool {
+	return getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup installs the global tracer provider and propagator from the standard
+// OTEL_* environment variables (OTEL_EXPORTER_OTLP_ENDPOINT, _HEADERS,
+// OTEL_TRACES_SAMPLER, ...). Without an endpoint it leaves the no-op provider
+// in place. The returned function flushes and stops the exporter.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagator)
+	if !Enabled(os.Getenv) {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("otlp trace exporter: %w", err)
+	}
+	// Later options win, so OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES
+	// override the defaults.
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(ServiceName), semconv.ServiceVersion(logging.Version)),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("trace resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start starts a span named name under ctx's span, if any.
+func Start(
+	ctx context.Context, name string, kind trace.SpanKind, attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentation).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+}
+
+// End records err on span, marking it failed, and ends it. Pass the
+// function's named error so the span reflects how it returned.
+func End(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, context.Canceled) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Transport injects the trace context into outgoing request headers.
+func Transport(rt http.RoundTripper) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		carrier := propagation.HeaderCarrier{}
+		propagator.Inject(req.Context(), carrier)
+		if len(carrier) > 0 {
+			req = req.Clone(req.Context())
+			for k, v := range carrier {
+				req.Header[k] = v
+			}
+		}
+		return rt.RoundTrip(req)
+	})
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// HTTPRequest continues the trace in r's headers, if any.
+func HTTPRequest(r *http.Request) context.Context {
+	return propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+}
+
+// InjectAMQP adds ctx's trace context to message headers, allocating them if
+// needed, and returns them.
+func InjectAMQP(ctx context.Context, headers amqp.Table) amqp.Table {
+	if headers == nil {
+		headers = amqp.Table{}
+	}
+	propagator.Inject(ctx, amqpCarrier(headers))
+	return headers
+}
+
+// ExtractAMQP continues the trace in a delivery's headers, if any.
+func ExtractAMQP(ctx context.Context, headers amqp.Table) context.Context {
+	return propagator.Extract(ctx, amqpCarrier(headers))
+}
+
+// amqpCarrier adapts message headers to the propagation API.
+type amqpCarrier amqp.Table
+
+func (c amqpCarrier) Get(key string) string {
+	v, _ := c[key].(string)
+	return v
+}
+
+func (c amqpCarrier) Set(key, value string) { c[key] = value }
+
+func (c amqpCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// InjectKafka appends ctx's trace context to a message's headers and
+// returns them.
+func InjectKafka(ctx context.Context, headers []kafka.Header) []kafka.Header {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	for k, v := range carrier {
+		headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	return headers
+}
@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// record installs a provider that keeps every span for the test.
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return rec
+}
+
+func TestPropagation_HTTPAndAMQP(t *testing.T) {
+	rec := record(t)
+
+	ctx, span := Start(context.Background(), "parent", trace.SpanKindInternal)
+	End(span, nil)
+	parent := span.SpanContext()
+
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer srv.Close()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: Transport(http.DefaultTransport)}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, req.Header, "the caller's request is not modified")
+	remote := trace.SpanContextFromContext(HTTPRequest(&http.Request{Header: got}))
+	assert.Equal(t, parent.TraceID(), remote.TraceID())
+	assert.Equal(t, parent.SpanID(), remote.SpanID())
+
+	headers := InjectAMQP(ctx, amqp.Table{"x-attempt": int32(2)})
+	assert.Equal(t, int32(2), headers["x-attempt"])
+	child, span := Start(ExtractAMQP(context.Background(), headers), "child", trace.SpanKindConsumer)
+	End(span, errors.New("boom"))
+	assert.Equal(t, parent.TraceID(), trace.SpanContextFromContext(child).TraceID())
+
+	kafkaHeaders := map[string]string{}
+	for _, h := range InjectKafka(ctx, nil) {
+		kafkaHeaders[h.Key] = string(h.Value)
+	}
+	fromKafka := propagator.Extract(context.Background(), propagation.MapCarrier(kafkaHeaders))
+	assert.Equal(t, parent.SpanID(), trace.SpanContextFromContext(fromKafka).SpanID())
+
+	ended := rec.Ended()
+	require.Len(t, ended, 2)
+	assert.Equal(t, parent.SpanID(), ended[1].Parent().SpanID())
+	assert.Equal(t, codes.Error, ended[1].Status().Code)
+	assert.Equal(t, codes.Unset, ended[0].Status().Code)
+}
+
+func TestEnabled(t *testing.T) {
+	t.Parallel()
+
+	env := func(vars map[string]string) func(string) string {
+		return func(k string) string { return vars[k] }
+	}
+	assert.False(t, Enabled(env(nil)))
+	assert.True(t, Enabled(env(map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318"})))
+	traces := map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://collector:4318/v1/traces"}
+	assert.True(t, Enabled(env(traces)))
+}
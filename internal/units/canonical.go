@@ -0,0 +1,116 @@
+package units
+
+import (
+	"sort"
+	"strings"
+)
+
+// countUnits are the non-convertible units pantry items may be stored in,
+// keyed by accepted spelling. Together with the convertible table they make
+// up the canonical units the extraction prompt asks for.
+var countUnits = map[string]string{
+	"piece": "piece", "pieces": "piece", "pc": "piece", "pcs": "piece", "each": "piece", "ea": "piece",
+	"bunch": "bunch", "bunches": "bunch",
+	"head": "head", "heads": "head",
+	"clove": "clove", "cloves": "clove",
+	"carton": "carton", "cartons": "carton",
+	"can": "can", "cans": "can",
+	"jar": "jar", "jars": "jar",
+	"bottle": "bottle", "bottles": "bottle",
+	"bag": "bag", "bags": "bag",
+	"box": "box", "boxes": "box",
+	"pack": "pack", "packs": "pack", "package": "pack", "packages": "pack",
+	"loaf": "loaf", "loaves": "loaf",
+	"dozen": "dozen",
+}
+
+// informal maps vague kitchen measures that no table entry resembles to the
+// closest canonical unit.
+var informal = map[string]string{
+	"handful": "cup", "handfuls": "cup",
+	"splash": "tbsp", "splashes": "tbsp",
+	"drizzle": "tbsp", "glug": "tbsp", "knob": "tbsp",
+	"dash": "tsp", "dashes": "tsp",
+	"pinch": "tsp", "pinches": "tsp", "sprinkle": "tsp",
+	"sprig": "bunch", "sprigs": "bunch",
+	"stalk": "piece", "stalks": "piece", "slice": "piece", "slices": "piece",
+}
+
+// DefaultCountUnit is the unit for items whose unit is missing or cannot be
+// matched to anything.
+const DefaultCountUnit = "piece"
+
+// maxSuggestDistance is the largest edit distance Suggest treats as a typo.
+const maxSuggestDistance = 2
+
+// Canonical returns the canonical spelling of name if it is a known
+// convertible or count unit, accepting aliases and plurals.
+func Canonical(name string) (string, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if u, ok := Lookup(key); ok {
+		return u.Name, true
+	}
+	c, ok := countUnits[key]
+	return c, ok
+}
+
+// Suggest returns the canonical unit nearest to an unknown name: a known
+// equivalent for informal measures ("splash" → "tbsp"), else the unit whose
+// spelling is within a couple of typos, else DefaultCountUnit.
+func Suggest(name string) string {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if c, ok := Canonical(key); ok {
+		return c
+	}
+	if c, ok := informal[key]; ok {
+		return c
+	}
+
+	best, bestDist := DefaultCountUnit, maxSuggestDistance+1
+	for _, spelling := range spellings() {
+		if d := editDistance(key, spelling); d < bestDist {
+			best, _ = Canonical(spelling)
+			bestDist = d
+		}
+	}
+	return best
+}
+
+// spellings lists every accepted unit spelling in a stable order, so ties in
+// Suggest resolve the same way on every call.
+func spellings() []string {
+	out := make([]string, 0, len(table)+len(aliases)+len(countUnits))
+	for k := range table {
+		out = append(out, k)
+	}
+	for k := range aliases {
+		out = append(out, k)
+	}
+	for k := range countUnits {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
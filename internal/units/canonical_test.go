@@ -0,0 +1,52 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonical(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"lbs", "lb", true},
+		{" Cups ", "cup", true},
+		{"gallon", "gal", true},
+		{"cloves", "clove", true},
+		{"packages", "pack", true},
+		{"mg", "mg", true},
+		{"handful", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Canonical(tt.in)
+		assert.Equal(t, tt.ok, ok, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"splash", "tbsp"},
+		{"Handful", "cup"},
+		{"pinch", "tsp"},
+		{"gramms", "g"},
+		{"tablespon", "tbsp"},
+		{"boxs", "box"},
+		{"smidgen", "piece"},
+		{"kg", "kg"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Suggest(tt.in), tt.in)
+	}
+}
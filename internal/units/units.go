@@ -0,0 +1,166 @@
+// Package units holds the canonical unit table. It is used for display-only
+// conversion between metric and imperial measurements, and to validate the
+// units extraction produces. Stored quantities are never rewritten; callers
+// convert at the edge when rendering responses.
+package units
+
+import (
+	"math"
+	"strings"
+)
+
+// System is a measurement system preference.
+type System string
+
+const (
+	Metric   System = "metric"
+	Imperial System = "imperial"
+)
+
+// ParseSystem returns the System named by s, if any.
+func ParseSystem(s string) (System, bool) {
+	switch System(strings.ToLower(strings.TrimSpace(s))) {
+	case Metric:
+		return Metric, true
+	case Imperial:
+		return Imperial, true
+	default:
+		return "", false
+	}
+}
+
+// Dimension groups units that can be converted between each other.
+type Dimension string
+
+const (
+	Mass   Dimension = "mass"
+	Volume Dimension = "volume"
+)
+
+// Unit describes a convertible unit relative to its dimension's base unit
+// (grams for mass, millilitres for volume).
+type Unit struct {
+	Name      string
+	Dimension Dimension
+	System    System
+	ToBase    float64
+}
+
+var table = map[string]Unit{
+	"mg":    {"mg", Mass, Metric, 0.001},
+	"g":     {"g", Mass, Metric, 1},
+	"kg":    {"kg", Mass, Metric, 1000},
+	"oz":    {"oz", Mass, Imperial, 28.349523125},
+	"lb":    {"lb", Mass, Imperial, 453.59237},
+	"ml":    {"ml", Volume, Metric, 1},
+	"l":     {"l", Volume, Metric, 1000},
+	"tsp":   {"tsp", Volume, Imperial, 4.92892159375},
+	"tbsp":  {"tbsp", Volume, Imperial, 14.78676478125},
+	"fl oz": {"fl oz", Volume, Imperial, 29.5735295625},
+	"cup":   {"cup", Volume, Imperial, 236.5882365},
+	"pint":  {"pint", Volume, Imperial, 473.176473},
+	"quart": {"quart", Volume, Imperial, 946.352946},
+	"gal":   {"gal", Volume, Imperial, 3785.411784},
+}
+
+var aliases = map[string]string{
+	"milligram": "mg", "milligrams": "mg",
+	"gram": "g", "grams": "g",
+	"kilogram": "kg", "kilograms": "kg", "kgs": "kg",
+	"ounce": "oz", "ounces": "oz",
+	"pound": "lb", "pounds": "lb", "lbs": "lb",
+	"milliliter": "ml", "milliliters": "ml", "millilitre": "ml", "millilitres": "ml",
+	"liter": "l", "liters": "l", "litre": "l", "litres": "l",
+	"teaspoon": "tsp", "teaspoons": "tsp",
+	"tablespoon": "tbsp", "tablespoons": "tbsp",
+	"floz": "fl oz", "fluid ounce": "fl oz", "fluid ounces": "fl oz",
+	"cups":  "cup",
+	"pints": "pint", "pt": "pint",
+	"quarts": "quart", "qt": "quart",
+	"gallon": "gal", "gallons": "gal",
+}
+
+// Lookup returns the canonical Unit for name, accepting common aliases and
+// plurals. Count units like "piece" or "bunch" are not convertible and return
+// false.
+func Lookup(name string) (Unit, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if canonical, ok := aliases[key]; ok {
+		key = canonical
+	}
+	u, ok := table[key]
+	return u, ok
+}
+
+// Convert converts quantity from one unit to another of the same dimension.
+func Convert(quantity float64, from, to string) (float64, bool) {
+	f, ok := Lookup(from)
+	if !ok {
+		return 0, false
+	}
+	t, ok := Lookup(to)
+	if !ok || f.Dimension != t.Dimension {
+		return 0, false
+	}
+	return quantity * f.ToBase / t.ToBase, true
+}
+
+// preferred lists the display units for each system and dimension, smallest
+// first. Localize picks the largest unit that keeps the quantity >= 1.
+var preferred = map[System]map[Dimension][]string{
+	Metric: {
+		Mass:   {"g", "kg"},
+		Volume: {"ml", "l"},
+	},
+	Imperial: {
+		Mass:   {"oz", "lb"},
+		Volume: {"tsp", "tbsp", "cup", "gal"},
+	},
+}
+
+// Localize converts quantity/unit into the preferred display unit for system.
+// Units that are unknown, non-convertible, or already in the target system are
+// returned unchanged with ok=false.
+func Localize(quantity float64, unit string, system System) (float64, string, bool) {
+	u, known := Lookup(unit)
+	if !known || u.System == system {
+		return quantity, unit, false
+	}
+	candidates := preferred[system][u.Dimension]
+	if len(candidates) == 0 {
+		return quantity, unit, false
+	}
+
+	base := quantity * u.ToBase
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		if base/table[c].ToBase >= 1 {
+			best = c
+		}
+	}
+	return round(base/table[best].ToBase, 2), best, true
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow10(places)
+	return math.Round(v*p) / p
+}
+
+// imperialRegions are the ISO 3166 regions that customarily use US units.
+var imperialRegions = map[string]bool{"US": true, "LR": true, "MM": true}
+
+// SystemForLanguage infers a measurement system from an Accept-Language
+// header. Only the highest-priority tag is considered, and only when it
+// carries a region (e.g. "en-US", "de-DE"); bare languages are ambiguous.
+func SystemForLanguage(acceptLanguage string) (System, bool) {
+	first, _, _ := strings.Cut(acceptLanguage, ",")
+	tag, _, _ := strings.Cut(strings.TrimSpace(first), ";")
+	_, region, ok := strings.Cut(tag, "-")
+	if !ok || region == "" {
+		return "", false
+	}
+	if imperialRegions[strings.ToUpper(region)] {
+		return Imperial, true
+	}
+	return Metric, true
+}
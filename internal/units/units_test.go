@@ -0,0 +1,77 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	got, ok := Convert(2, "lbs", "kg")
+	assert.True(t, ok)
+	assert.InDelta(t, 0.907, got, 0.001)
+
+	_, ok = Convert(1, "cup", "g")
+	assert.False(t, ok, "mass and volume are not convertible")
+
+	_, ok = Convert(1, "bunch", "g")
+	assert.False(t, ok, "count units are not convertible")
+}
+
+func TestLocalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		quantity float64
+		unit     string
+		system   System
+		wantQty  float64
+		wantUnit string
+		wantOK   bool
+	}{
+		{"grams to pounds", 1000, "g", Imperial, 2.2, "lb", true},
+		{"small grams to ounces", 100, "g", Imperial, 3.53, "oz", true},
+		{"pounds to grams", 2, "lb", Metric, 907.18, "g", true},
+		{"large pounds to kilograms", 5, "lb", Metric, 2.27, "kg", true},
+		{"cups to millilitres", 2, "cup", Metric, 473.18, "ml", true},
+		{"litres to cups", 1, "l", Imperial, 4.23, "cup", true},
+		{"already imperial", 2, "lb", Imperial, 2, "lb", false},
+		{"count unit", 3, "clove", Metric, 3, "clove", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			qty, unit, ok := Localize(tc.quantity, tc.unit, tc.system)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantUnit, unit)
+			assert.InDelta(t, tc.wantQty, qty, 0.01)
+		})
+	}
+}
+
+func TestSystemForLanguage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		header string
+		want   System
+		wantOK bool
+	}{
+		{"en-US,en;q=0.9", Imperial, true},
+		{"de-DE,de;q=0.9", Metric, true},
+		{"en-GB", Metric, true},
+		{"fr", "", false},
+		{"", "", false},
+	}
+
+	for _, tc := range tests {
+		got, ok := SystemForLanguage(tc.header)
+		assert.Equal(t, tc.wantOK, ok, tc.header)
+		assert.Equal(t, tc.want, got, tc.header)
+	}
+}
@@ -0,0 +1,176 @@
+// Package webhook sends outgoing webhooks for every subsystem that calls out
+// over HTTP, so they share one signature format and one delivery audit.
+//
+// A signed request carries the Unix time it was sent, a random nonce and the
+// hex HMAC-SHA256, under the endpoint's secret, of "<timestamp>.<nonce>.<body>".
+// Receivers recompute the signature, reject timestamps outside their
+// tolerance, and remember nonces for that long to refuse replays.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Signature headers set on signed requests.
+const (
+	SignatureHeader = "X-Pantry-Signature"
+	TimestampHeader = "X-Pantry-Timestamp"
+	NonceHeader     = "X-Pantry-Nonce"
+
+	signaturePrefix = "sha256="
+)
+
+// ErrInvalidSignature is returned by Verify for a missing, stale or
+// mismatched signature.
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Sign returns the signature header value for body sent at timestamp with
+// nonce.
+func Sign(secret string, timestamp int64, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.%s.", timestamp, nonce)
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature headers in h against body and rejects
+// timestamps more than tolerance away from now. Tracking nonces is left to
+// the receiver.
+func Verify(secret string, h http.Header, body []byte, now time.Time, tolerance time.Duration) error {
+	ts, err := strconv.ParseInt(h.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: bad timestamp", ErrInvalidSignature)
+	}
+	if age := now.Sub(time.Unix(ts, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidSignature)
+	}
+	nonce := h.Get(NonceHeader)
+	if nonce == "" {
+		return fmt.Errorf("%w: missing nonce", ErrInvalidSignature)
+	}
+	if !hmac.Equal([]byte(h.Get(SignatureHeader)), []byte(Sign(secret, ts, nonce, body))) {
+		return fmt.Errorf("%w: signature mismatch", ErrInvalidSignature)
+	}
+	return nil
+}
+
+// Attempt is one delivery attempt. StatusCode is zero when no response
+// arrived.
+type Attempt struct {
+	Source     string
+	Endpoint   string
+	Signed     bool
+	StatusCode int
+	Latency    time.Duration
+	Err        error
+}
+
+// Recorder stores delivery attempts. Implementations log their own failures;
+// auditing never fails a delivery.
+type Recorder interface {
+	RecordAttempt(ctx context.Context, a Attempt)
+}
+
+// Client POSTs JSON webhooks, signing them when the endpoint has a secret
+// and reporting every attempt to its Recorder.
+type Client struct {
+	http     *http.Client
+	recorder Recorder
+	now      func() time.Time
+}
+
+// NewClient returns a client sending through httpClient. Both arguments may
+// be nil: http.DefaultClient is used and attempts go unrecorded.
+func NewClient(httpClient *http.Client, recorder Recorder) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{http: httpClient, recorder: recorder, now: time.Now}
+}
+
+// Post sends body to endpoint on behalf of source, e.g. "notification".
+// headers are added as given. A non-2xx response is an error.
+func (c *Client) Post(
+	ctx context.Context,
+	source, endpoint, secret string,
+	headers map[string]string,
+	body []byte,
+) error {
+	attempt := Attempt{Source: source, Endpoint: Redact(endpoint), Signed: secret != ""}
+	start := c.now()
+	attempt.StatusCode, attempt.Err = c.post(ctx, endpoint, secret, headers, body)
+	attempt.Latency = c.now().Sub(start)
+	if c.recorder != nil {
+		c.recorder.RecordAttempt(ctx, attempt)
+	}
+	return attempt.Err
+}
+
+func (c *Client) post(
+	ctx context.Context,
+	endpoint, secret string,
+	headers map[string]string,
+	body []byte,
+) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if secret != "" {
+		nonce, err := newNonce()
+		if err != nil {
+			return 0, err
+		}
+		ts := c.now().Unix()
+		req.Header.Set(TimestampHeader, strconv.FormatInt(ts, 10))
+		req.Header.Set(NonceHeader, nonce)
+		req.Header.Set(SignatureHeader, Sign(secret, ts, nonce, body))
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func newNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Redact drops credentials, query and fragment from an endpoint URL, which
+// often carry tokens, so it can be logged and stored.
+func Redact(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "(invalid url)"
+	}
+	u.User, u.RawQuery, u.ForceQuery, u.Fragment, u.RawFragment = nil, "", false, "", ""
+	return u.String()
+}
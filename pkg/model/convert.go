@@ -0,0 +1,110 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+// FromPantryItem maps a pantry_items row to its API shape.
+func FromPantryItem(item db.PantryItem) PantryItem {
+	return PantryItem{
+		ID:              item.ID,
+		IngredientID:    item.IngredientID,
+		Quantity:        item.Quantity,
+		Unit:            item.Unit,
+		QuantityUnknown: item.QuantityUnknown,
+		ExpiresAt:       timePtr(item.ExpiresAt),
+		AddedAt:         item.AddedAt,
+		UpdatedAt:       item.UpdatedAt,
+	}
+}
+
+// FromPantryItems maps a list of rows, returning an empty slice rather than
+// nil so it encodes as [].
+func FromPantryItems(items []db.PantryItem) []PantryItem {
+	return mapAll(items, FromPantryItem)
+}
+
+// FromTombstones maps pantry_item_tombstones rows to deleted items.
+func FromTombstones(tombstones []db.PantryItemTombstone) []DeletedItem {
+	return mapAll(tombstones, func(t db.PantryItemTombstone) DeletedItem {
+		return DeletedItem{ItemID: t.ItemID, IngredientID: t.IngredientID, DeletedAt: t.DeletedAt}
+	})
+}
+
+// FromPantryLots maps pantry_lots rows to their API shape.
+func FromPantryLots(lots []db.PantryLot) []PantryLot {
+	return mapAll(lots, func(lot db.PantryLot) PantryLot {
+		return PantryLot{
+			ID:           lot.ID,
+			PantryItemID: lot.PantryItemID,
+			Quantity:     lot.Quantity,
+			Unit:         lot.Unit,
+			ExpiresAt:    timePtr(lot.ExpiresAt),
+			SourceJobID:  uuidPtr(lot.SourceJobID),
+			AddedAt:      lot.AddedAt,
+		}
+	})
+}
+
+// FromPantryLotEvents maps pantry_lot_events rows to their API shape.
+func FromPantryLotEvents(events []db.PantryLotEvent) []PantryLotEvent {
+	return mapAll(events, func(ev db.PantryLotEvent) PantryLotEvent {
+		return PantryLotEvent{
+			ID:           ev.ID,
+			PantryItemID: ev.PantryItemID,
+			LotID:        ev.LotID,
+			Kind:         ev.Kind,
+			Quantity:     ev.Quantity,
+			Unit:         ev.Unit,
+			ExpiresAt:    timePtr(ev.ExpiresAt),
+			OccurredAt:   ev.OccurredAt,
+		}
+	})
+}
+
+// FromStagedItem maps a staged_items row to its API shape.
+func FromStagedItem(item db.StagedItem) StagedItem {
+	return StagedItem{
+		ID:              item.ID,
+		JobID:           item.JobID,
+		IngredientID:    uuidPtr(item.IngredientID),
+		RawText:         item.RawText,
+		Quantity:        item.Quantity,
+		Unit:            item.Unit,
+		Confidence:      item.Confidence,
+		NeedsReview:     item.NeedsReview,
+		QuantityUnknown: item.QuantityUnknown,
+	}
+}
+
+// FromStagedItems maps a list of staged_items rows.
+func FromStagedItems(items []db.StagedItem) []StagedItem {
+	return mapAll(items, FromStagedItem)
+}
+
+func mapAll[From, To any](in []From, fn func(From) To) []To {
+	out := make([]To, len(in))
+	for i, v := range in {
+		out[i] = fn(v)
+	}
+	return out
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func uuidPtr(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	return &id.UUID
+}
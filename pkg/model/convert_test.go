@@ -0,0 +1,49 @@
+package model
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mwhite7112/woodpantry-pantry/internal/db"
+)
+
+func TestFromPantryItem_OmitsUnsetExpiry(t *testing.T) {
+	t.Parallel()
+
+	item := db.PantryItem{ID: uuid.New(), IngredientID: uuid.New(), Quantity: 2, Unit: "cup"}
+	body, err := json.Marshal(FromPantryItem(item))
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "expires_at")
+
+	expires := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	item.ExpiresAt = sql.NullTime{Time: expires, Valid: true}
+	got := FromPantryItem(item)
+	require.NotNil(t, got.ExpiresAt)
+	assert.Equal(t, expires, *got.ExpiresAt)
+}
+
+func TestFromStagedItem_UnresolvedIngredient(t *testing.T) {
+	t.Parallel()
+
+	got := FromStagedItem(db.StagedItem{ID: uuid.New(), RawText: "mystery jar", NeedsReview: true})
+	assert.Nil(t, got.IngredientID)
+
+	id := uuid.New()
+	got = FromStagedItem(db.StagedItem{IngredientID: uuid.NullUUID{UUID: id, Valid: true}})
+	require.NotNil(t, got.IngredientID)
+	assert.Equal(t, id, *got.IngredientID)
+}
+
+func TestFromPantryItems_EmptyEncodesAsArray(t *testing.T) {
+	t.Parallel()
+
+	body, err := json.Marshal(FromPantryItems(nil))
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(body))
+}
@@ -0,0 +1,68 @@
+// Package model defines the JSON shapes the pantry API sends to clients.
+// Handlers map database rows onto these types instead of serializing them
+// directly, so a schema or sqlc change cannot alter the wire format.
+// Nullable columns become pointers that are omitted when unset.
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PantryItem is one ingredient the household has on hand.
+type PantryItem struct {
+	ID              uuid.UUID  `json:"id"`
+	IngredientID    uuid.UUID  `json:"ingredient_id"`
+	Quantity        float64    `json:"quantity"`
+	Unit            string     `json:"unit"`
+	QuantityUnknown bool       `json:"quantity_unknown"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	AddedAt         time.Time  `json:"added_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// DeletedItem records a pantry item removed since a delta sync's cursor.
+type DeletedItem struct {
+	ItemID       uuid.UUID `json:"item_id"`
+	IngredientID uuid.UUID `json:"ingredient_id"`
+	DeletedAt    time.Time `json:"deleted_at"`
+}
+
+// PantryLot is one dated purchase of a pantry item, kept when lot tracking
+// is enabled.
+type PantryLot struct {
+	ID           uuid.UUID  `json:"id"`
+	PantryItemID uuid.UUID  `json:"pantry_item_id"`
+	Quantity     float64    `json:"quantity"`
+	Unit         string     `json:"unit"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	SourceJobID  *uuid.UUID `json:"source_job_id,omitempty"`
+	AddedAt      time.Time  `json:"added_at"`
+}
+
+// PantryLotEvent is one change to a lot: added, consumed or removed.
+type PantryLotEvent struct {
+	ID           uuid.UUID  `json:"id"`
+	PantryItemID uuid.UUID  `json:"pantry_item_id"`
+	LotID        uuid.UUID  `json:"lot_id"`
+	Kind         string     `json:"kind"`
+	Quantity     float64    `json:"quantity"`
+	Unit         string     `json:"unit"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	OccurredAt   time.Time  `json:"occurred_at"`
+}
+
+// StagedItem is one extracted line of an ingestion job awaiting review.
+// IngredientID is omitted until the line resolves to an ingredient.
+type StagedItem struct {
+	ID              uuid.UUID  `json:"id"`
+	JobID           uuid.UUID  `json:"job_id"`
+	IngredientID    *uuid.UUID `json:"ingredient_id,omitempty"`
+	RawText         string     `json:"raw_text"`
+	Quantity        float64    `json:"quantity"`
+	Unit            string     `json:"unit"`
+	Confidence      float64    `json:"confidence"`
+	NeedsReview     bool       `json:"needs_review"`
+	QuantityUnknown bool       `json:"quantity_unknown"`
+}